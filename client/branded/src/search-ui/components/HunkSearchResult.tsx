@@ -0,0 +1,118 @@
+import React, { useMemo } from 'react'
+
+import VisuallyHidden from '@reach/visually-hidden'
+import classNames from 'classnames'
+
+import { displayRepoName } from '@sourcegraph/shared/src/components/RepoLink'
+import type { PlatformContextProps } from '@sourcegraph/shared/src/platform/context'
+import {
+    type CommitMatch,
+    type HunkMatch,
+    getCommitMatchUrl,
+    getRepositoryUrl,
+} from '@sourcegraph/shared/src/search/stream'
+import { Link, Code } from '@sourcegraph/wildcard'
+
+import { Timestamp } from '../../components/Timestamp'
+
+import { CommitSearchResultMatch } from './CommitSearchResultMatch'
+import { ResultContainer } from './ResultContainer'
+
+import styles from './CommitSearchResult.module.scss'
+import resultStyles from './ResultContainer.module.scss'
+
+interface Props extends PlatformContextProps<'requestGraphQL'> {
+    result: HunkMatch
+    onSelect: () => void
+    openInNewTab?: boolean
+    containerClassName?: string
+    as?: React.ElementType
+    index: number
+}
+
+/**
+ * Returns the hunk as a diff match of its commit, so that it is rendered like the diffs of
+ * diff results.
+ */
+export function hunkToCommitMatch(hunk: HunkMatch): CommitMatch {
+    const header =
+        `@@ -${hunk.oldStart},${hunk.oldCount} +${hunk.newStart},${hunk.newCount} @@` +
+        (hunk.sectionHeader ? ` ${hunk.sectionHeader}` : '')
+    return {
+        type: 'commit',
+        url: hunk.url,
+        repository: hunk.repository,
+        repoStars: hunk.repoStars,
+        repoLastFetched: hunk.repoLastFetched,
+        oid: hunk.oid,
+        message: '',
+        authorName: hunk.authorName,
+        authorDate: hunk.authorDate,
+        committerName: hunk.committerName,
+        committerDate: hunk.committerDate,
+        content: '```diff\n' + header + '\n' + hunk.content + '```',
+        // Skip the line of the code block indicator and the hunk header.
+        ranges: hunk.ranges.map(([line, character, length]) => [line + 2, character, length]),
+    }
+}
+
+// This is a search result for a single hunk of a diff, returned for select:commit.diff.hunk.
+export const HunkSearchResult: React.FunctionComponent<Props> = ({
+    result,
+    platformContext,
+    onSelect,
+    openInNewTab,
+    containerClassName,
+    as,
+    index,
+}) => {
+    const commitMatch = useMemo(() => hunkToCommitMatch(result), [result])
+
+    const title = (
+        <div className={resultStyles.title}>
+            <span className={classNames('test-search-result-label flex-grow-1', resultStyles.titleInner)}>
+                <Link to={getRepositoryUrl(result.repository)}>{displayRepoName(result.repository)}</Link>
+                <span aria-hidden={true}> ›</span>{' '}
+                <Link to={getCommitMatchUrl(result)} data-selectable-search-result="true">
+                    {result.oldPath ? `${result.oldPath} → ${result.path}` : result.path}
+                </Link>
+            </span>
+            {/*
+                Relative positioning needed needed to avoid VisuallyHidden creating a scrollable overflow in Chrome.
+                Related bug: https://bugs.chromium.org/p/chromium/issues/detail?id=1154640#c15
+            */}
+            <Link to={getCommitMatchUrl(result)} className={classNames('position-relative', resultStyles.titleInner)}>
+                <Code className={styles.commitOid}>
+                    <VisuallyHidden>Commit hash:</VisuallyHidden>
+                    {result.oid.slice(0, 7)}
+                    <VisuallyHidden>,</VisuallyHidden>
+                </Code>{' '}
+                <VisuallyHidden>Committed</VisuallyHidden>
+                {/* Display commit date in UTC to match behavior of before/after filters */}
+                <Timestamp date={result.committerDate} noAbout={true} strict={true} utc={true} />
+            </Link>
+            {result.repoStars && <div className={resultStyles.divider} />}
+        </div>
+    )
+
+    return (
+        <ResultContainer
+            index={index}
+            title={title}
+            resultType={result.type}
+            onResultClicked={onSelect}
+            repoName={result.repository}
+            repoStars={result.repoStars}
+            className={containerClassName}
+            as={as}
+            repoLastFetched={result.repoLastFetched}
+        >
+            <CommitSearchResultMatch
+                key={result.url}
+                item={commitMatch}
+                platformContext={platformContext}
+                openInNewTab={openInNewTab}
+            />
+        </ResultContainer>
+    )
+}
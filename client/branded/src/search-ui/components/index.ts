@@ -7,6 +7,7 @@ export * from './CommitSearchResult'
 export * from './CommitSearchResultMatch'
 export * from './CopyPathAction'
 export * from './FileContentSearchResult'
+export * from './HunkSearchResult'
 export * from './LastSyncedIcon'
 export * from './RepoFileLink'
 export * from './RepoMetadata'
@@ -89,6 +89,7 @@ describe('codmirror completions', () => {
                 'fork',
                 'lang',
                 '-lang',
+                'lines',
                 'message',
                 '-message',
                 'patterntype',
@@ -99,6 +100,7 @@ describe('codmirror completions', () => {
                 '-repohasfile',
                 'rev',
                 'select',
+                'size',
                 'timeout',
                 'type',
                 'visibility',
@@ -128,6 +130,7 @@ describe('codmirror completions', () => {
                 'fork',
                 'lang',
                 '-lang',
+                'lines',
                 'message',
                 '-message',
                 'patterntype',
@@ -138,6 +141,7 @@ describe('codmirror completions', () => {
                 '-repohasfile',
                 'rev',
                 'select',
+                'size',
                 'timeout',
                 'type',
                 'visibility',
@@ -167,6 +171,7 @@ describe('codmirror completions', () => {
             'fork',
             'lang',
             '-lang',
+            'lines',
             'message',
             '-message',
             'patterntype',
@@ -177,6 +182,7 @@ describe('codmirror completions', () => {
             '-repohasfile',
             'rev',
             'select',
+            'size',
             'timeout',
             'type',
             'visibility',
@@ -206,6 +212,7 @@ describe('codmirror completions', () => {
                 'fork',
                 'lang',
                 '-lang',
+                'lines',
                 'message',
                 '-message',
                 'patterntype',
@@ -216,6 +223,7 @@ describe('codmirror completions', () => {
                 '-repohasfile',
                 'rev',
                 'select',
+                'size',
                 'timeout',
                 'type',
                 'visibility',
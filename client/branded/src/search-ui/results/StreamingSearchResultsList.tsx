@@ -24,6 +24,7 @@ import {
     CommitSearchResult,
     FileContentSearchResult,
     FilePathSearchResult,
+    HunkSearchResult,
     RepoSearchResult,
     SymbolSearchResult,
 } from '../components'
@@ -203,6 +204,19 @@ export const StreamingSearchResultsList: React.FunctionComponent<
                             />
                         )
                     }
+                    case 'hunk': {
+                        return (
+                            <HunkSearchResult
+                                index={index}
+                                result={result}
+                                platformContext={platformContext}
+                                onSelect={() => logSearchResultClicked?.(index, 'hunk', resultsNumber)}
+                                openInNewTab={openMatchesInNewTab}
+                                containerClassName={resultClassName}
+                                as="li"
+                            />
+                        )
+                    }
                     case 'repo': {
                         return (
                             <RepoSearchResult
@@ -328,5 +342,9 @@ function itemKey(item: SearchMatch): string {
     if (item.type === 'symbol') {
         return `file:${getMatchUrl(item)}`
     }
+    if (item.type === 'hunk') {
+        // All hunks of a commit have the URL of the commit.
+        return `hunk:${getMatchUrl(item)}:${item.path}:${item.oldStart}:${item.newStart}`
+    }
     return getMatchUrl(item)
 }
@@ -260,6 +260,7 @@ To use this filter, the search query must contain \`type:diff\` or \`type:commit
 - \`select:repo\`
 - \`select:commit.diff.added\`
 - \`select:commit.diff.removed\`
+- \`select:commit.diff.hunk\`
 - \`select:file\`
 - \`select:file.directory\`
 - \`select:file.path\`
@@ -19,6 +19,7 @@ export enum FilterType {
     file = 'file',
     fork = 'fork',
     lang = 'lang',
+    lines = 'lines',
     message = 'message',
     patterntype = 'patterntype',
     repo = 'repo',
@@ -27,6 +28,7 @@ export enum FilterType {
 
     rev = 'rev',
     select = 'select',
+    size = 'size',
     timeout = 'timeout',
     type = 'type',
     visibility = 'visibility',
@@ -261,6 +263,10 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         negatable: true,
         description: negated => `${negated ? 'Exclude' : 'Include only'} results from the given language`,
     },
+    [FilterType.lines]: {
+        description: 'Include only files with a number of lines in the given range, e.g. >5000 or <=100. Searches with this filter are never indexed, so they are slower.',
+        placeholder: '>5000',
+    },
     [FilterType.message]: {
         alias: 'm',
         negatable: true,
@@ -315,6 +321,10 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: 'Select repo, file, symbol, content, or commit result types.',
         singular: true,
     },
+    [FilterType.size]: {
+        description: 'Include only files with a size in the given range, e.g. >100kb or <2mb. Indexed search results are filtered by size after they are found, so these searches are slower.',
+        placeholder: '>100kb',
+    },
     [FilterType.timeout]: {
         description: 'Duration before timeout, e.g. 30s, 1m, 2h, 3d, 4w, 5y.',
         placeholder: 'duration-value',
@@ -26,6 +26,7 @@ export const PREDICATES: PredicateDefinition[] = [
     { field: 'file', name: 'contains.content' },
     { field: 'file', name: 'has.content' },
     { field: 'file', name: 'has.owner' },
+    { field: 'file', name: 'has.commit.after' },
     { field: 'rev', name: 'at.time' },
 ]
 
@@ -186,6 +187,12 @@ export const predicateCompletion = (field: FilterType): Completion[] => {
                 asSnippet: true,
                 description: 'Search only inside files that have a contributor that matches a pattern',
             },
+            {
+                label: 'has.commit.after(...)',
+                insertText: 'has.commit.after(${1:3 months ago})',
+                asSnippet: true,
+                description: 'Search only inside files that have been committed to since then',
+            },
         ]
     }
     if (field === 'rev') {
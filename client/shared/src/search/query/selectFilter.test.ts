@@ -58,7 +58,8 @@ describe('selectorCompletion', () => {
             commit,
             commit.diff,
             commit.diff.added,
-            commit.diff.removed
+            commit.diff.removed,
+            commit.diff.hunk
         `)
     })
 })
@@ -36,7 +36,14 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
+export type SearchMatch =
+    | ContentMatch
+    | RepositoryMatch
+    | CommitMatch
+    | HunkMatch
+    | SymbolMatch
+    | PathMatch
+    | OwnerMatch
 
 export interface PathMatch {
     type: 'path'
@@ -155,6 +162,39 @@ export interface CommitMatch {
     ranges: number[][]
 }
 
+/**
+ * A single hunk of a commit diff, returned for select:commit.diff.hunk.
+ */
+export interface HunkMatch {
+    type: 'hunk'
+    url: string
+    repository: string
+    repositoryID: number
+    repoStars?: number
+    repoLastFetched?: string
+    oid: string
+    authorName: string
+    authorDate: string
+    committerName: string
+    committerDate: string
+
+    // The path of the file after the commit, or before it if the file was deleted.
+    path: string
+    // Only set if the file was renamed.
+    oldPath?: string
+
+    oldStart: number
+    oldCount: number
+    newStart: number
+    newCount: number
+    sectionHeader?: string
+
+    // The lines of the hunk, each prefixed with "+", "-" or " ".
+    content: string
+    // Array of [line, character, length] triplets, relative to content
+    ranges: number[][]
+}
+
 export interface RepositoryMatch {
     type: 'repo'
     repository: string
@@ -657,7 +697,7 @@ export function getRepoMatchUrl(repoMatch: RepositoryMatch): string {
     return '/' + encodeURI(label)
 }
 
-export function getCommitMatchUrl(commitMatch: CommitMatch): string {
+export function getCommitMatchUrl(commitMatch: Pick<CommitMatch | HunkMatch, 'repository' | 'oid'>): string {
     return '/' + encodeURI(commitMatch.repository) + '/-/commit/' + commitMatch.oid
 }
 
@@ -691,7 +731,8 @@ export function getMatchUrl(match: SearchMatch): string {
         case 'symbol': {
             return getFileMatchUrl(match)
         }
-        case 'commit': {
+        case 'commit':
+        case 'hunk': {
             return getCommitMatchUrl(match)
         }
         case 'repo': {
@@ -93,7 +93,9 @@ export const SearchResultsInfoBar: React.FunctionComponent<
         }
         const globalTypeFilterInQuery = findFilter(fullQuery, 'type', FilterKind.Global)
         const globalTypeFilterValue = globalTypeFilterInQuery?.value ? globalTypeFilterInQuery.value.value : undefined
-        return globalTypeFilterValue === 'diff' || globalTypeFilterValue === 'commit'
+        return (
+            globalTypeFilterValue === 'diff' || globalTypeFilterValue === 'commit' || globalTypeFilterValue === 'file'
+        )
     }, [fullQuery])
 
     const createCodeMonitorButton = useMemo(() => {
@@ -105,7 +107,7 @@ export const SearchResultsInfoBar: React.FunctionComponent<
                 <Tooltip
                     content={
                         !canCreateMonitorFromQuery
-                            ? 'Code monitors only support type:diff or type:commit searches.'
+                            ? 'Code monitors only support type:diff, type:commit or type:file searches.'
                             : undefined
                     }
                 >
@@ -40,6 +40,7 @@ export {
     type CommitMatch,
     type ContentMatch,
     type Filter,
+    type HunkMatch,
     type LineMatch,
     type OwnerMatch,
     type PathMatch,
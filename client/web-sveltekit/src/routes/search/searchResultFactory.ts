@@ -5,6 +5,7 @@ import type { SearchMatch } from '$lib/shared'
 import CommitSearchResult from './CommitSearchResult.svelte'
 import FileContentSearchResult from './FileContentSearchResult.svelte'
 import FilePathSearchResult from './FilePathSearchResult.svelte'
+import HunkSearchResult from './HunkSearchResult.svelte'
 import PersonSearchResult from './PersonSearchResult.svelte'
 import RepoSearchResult from './RepoSearchResult.svelte'
 import SymbolSearchResult from './SymbolSearchResult.svelte'
@@ -26,6 +27,7 @@ const searchResultComponents: SearchResultUIMap = {
     person: PersonSearchResult,
     team: TeamSearchResult,
     commit: CommitSearchResult,
+    hunk: HunkSearchResult,
 }
 
 export function getSearchResultComponent<T extends SearchMatchType>(result: {
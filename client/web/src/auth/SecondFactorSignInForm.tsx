@@ -0,0 +1,264 @@
+import React, { useCallback, useEffect, useState } from 'react'
+
+import { asError, logger } from '@sourcegraph/common'
+import { Button, Code, Form, Input, LoadingSpinner, Text } from '@sourcegraph/wildcard'
+
+/**
+ * The response of a sign in with a correct password that must be completed with a second factor.
+ */
+export interface SecondFactorChallenge {
+    secondFactorRequired?: boolean
+    secondFactorEnrollmentRequired?: boolean
+    methods?: ('totp' | 'webauthn' | 'recoveryCode')[]
+    webauthn?: {
+        challenge: string
+        rpId: string
+        allowCredentials: { type: 'public-key'; id: string }[]
+        timeout: number
+        userVerification: UserVerificationRequirement
+    }
+}
+
+interface Props {
+    challenge: SecondFactorChallenge
+    xhrHeaders: { [key: string]: string }
+    onSignedIn: () => void
+    onAuthError: (error: Error | null) => void
+    className?: string
+}
+
+const base64URLToBuffer = (value: string): ArrayBuffer =>
+    Uint8Array.from(atob(value.replaceAll('-', '+').replaceAll('_', '/')), character => character.charCodeAt(0))
+        .buffer
+
+const bufferToBase64URL = (buffer: ArrayBuffer): string =>
+    btoa(String.fromCharCode(...new Uint8Array(buffer)))
+        .replaceAll('+', '-')
+        .replaceAll('/', '_')
+        .replace(/=+$/, '')
+
+/**
+ * The second step of signing in with a username and password: entering a code from an authenticator app or a
+ * recovery code, or using a security key. Users who must use a second factor but have none are asked to set up
+ * an authenticator app instead.
+ */
+export const SecondFactorSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
+    challenge,
+    xhrHeaders,
+    onSignedIn,
+    onAuthError,
+    className,
+}) => {
+    const [code, setCode] = useState('')
+    const [useRecoveryCode, setUseRecoveryCode] = useState(!challenge.methods?.includes('totp'))
+    const [loading, setLoading] = useState(false)
+    const [enrollment, setEnrollment] = useState<{ secret: string; keyUri: string } | null>(null)
+    const [recoveryCodes, setRecoveryCodes] = useState<string[] | null>(null)
+
+    const post = useCallback(
+        async (url: string, body: object): Promise<Response> =>
+            fetch(url, {
+                credentials: 'same-origin',
+                method: 'POST',
+                headers: {
+                    ...xhrHeaders,
+                    Accept: 'application/json',
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify(body),
+            }),
+        [xhrHeaders]
+    )
+
+    const run = useCallback(
+        async (action: () => Promise<void>): Promise<void> => {
+            setLoading(true)
+            onAuthError(null)
+            try {
+                await action()
+            } catch (error) {
+                logger.error('Auth error:', error)
+                onAuthError(asError(error))
+            } finally {
+                setLoading(false)
+            }
+        },
+        [onAuthError]
+    )
+
+    const checkResponse = async (response: Response): Promise<void> => {
+        if (response.status === 401) {
+            throw new Error('The code was incorrect')
+        } else if (response.status === 422) {
+            throw new Error(
+                challenge.secondFactorEnrollmentRequired ? 'The code was incorrect' : 'The account has been locked out'
+            )
+        } else if (response.status !== 200) {
+            const text = await response.text()
+            throw new Error(text === '' ? 'Unknown error' : text)
+        }
+    }
+
+    useEffect(() => {
+        if (!challenge.secondFactorEnrollmentRequired) {
+            return
+        }
+        run(async () => {
+            const response = await post('/-/second-factor/totp/enroll', {})
+            await checkResponse(response)
+            setEnrollment(await response.json())
+        }).catch(() => {})
+        // Enrollment must only be started once.
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [])
+
+    const onSubmit = useCallback(
+        (event: React.FormEvent<HTMLFormElement>): void => {
+            event.preventDefault()
+            run(async () => {
+                if (enrollment) {
+                    const response = await post('/-/second-factor/totp/confirm', { code })
+                    await checkResponse(response)
+                    const body = (await response.json()) as { recoveryCodes?: string[] }
+                    if (body.recoveryCodes) {
+                        // The user is signed in now, but must save their recovery codes first.
+                        setRecoveryCodes(body.recoveryCodes)
+                        return
+                    }
+                    onSignedIn()
+                    return
+                }
+                const response = await post(
+                    '/-/sign-in/second-factor',
+                    useRecoveryCode ? { recoveryCode: code } : { totpCode: code }
+                )
+                await checkResponse(response)
+                onSignedIn()
+            }).catch(() => {})
+        },
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+        [code, enrollment, useRecoveryCode, post, run, onSignedIn]
+    )
+
+    const onUseSecurityKey = useCallback((): void => {
+        const options = challenge.webauthn
+        if (!options) {
+            return
+        }
+        run(async () => {
+            const credential = (await navigator.credentials.get({
+                publicKey: {
+                    ...options,
+                    challenge: base64URLToBuffer(options.challenge),
+                    allowCredentials: options.allowCredentials.map(credential => ({
+                        ...credential,
+                        id: base64URLToBuffer(credential.id),
+                    })),
+                },
+            })) as PublicKeyCredential | null
+            if (!credential) {
+                throw new Error('No security key was used')
+            }
+            const response = credential.response as AuthenticatorAssertionResponse
+            const result = await post('/-/sign-in/second-factor', {
+                webauthn: {
+                    id: credential.id,
+                    rawId: bufferToBase64URL(credential.rawId),
+                    type: credential.type,
+                    response: {
+                        clientDataJSON: bufferToBase64URL(response.clientDataJSON),
+                        authenticatorData: bufferToBase64URL(response.authenticatorData),
+                        signature: bufferToBase64URL(response.signature),
+                        userHandle: response.userHandle ? bufferToBase64URL(response.userHandle) : undefined,
+                    },
+                },
+            })
+            await checkResponse(result)
+            onSignedIn()
+        }).catch(() => {})
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [challenge.webauthn, post, run, onSignedIn])
+
+    if (recoveryCodes) {
+        return (
+            <div className={className}>
+                <Text alignment="left">
+                    Save these recovery codes in a safe place. Each of them can be used once to sign in if you lose
+                    access to your authenticator app.
+                </Text>
+                <Code className="d-block mb-3">
+                    {recoveryCodes.map(recoveryCode => (
+                        <div key={recoveryCode}>{recoveryCode}</div>
+                    ))}
+                </Code>
+                <Button display="block" variant="primary" onClick={onSignedIn}>
+                    Continue
+                </Button>
+            </div>
+        )
+    }
+
+    let label = 'Authentication code'
+    if (enrollment) {
+        label = 'Code from your authenticator app'
+    } else if (useRecoveryCode) {
+        label = 'Recovery code'
+    }
+
+    return (
+        <Form onSubmit={onSubmit} className={className}>
+            {challenge.secondFactorEnrollmentRequired && (
+                <Text alignment="left">
+                    Two-factor authentication is required. Add this key to your authenticator app, then enter the
+                    code it shows.
+                    {enrollment && (
+                        <>
+                            <br />
+                            <a href={enrollment.keyUri}>
+                                <Code>{enrollment.secret}</Code>
+                            </a>
+                        </>
+                    )}
+                </Text>
+            )}
+            {(!challenge.secondFactorEnrollmentRequired || enrollment) && (
+                <Input
+                    id="second-factor-code"
+                    label={<Text alignment="left">{label}</Text>}
+                    onChange={event => setCode(event.target.value)}
+                    required={true}
+                    value={code}
+                    disabled={loading}
+                    autoCapitalize="off"
+                    autoFocus={true}
+                    autoComplete="one-time-code"
+                    inputMode={useRecoveryCode ? 'text' : 'numeric'}
+                    className="form-group"
+                />
+            )}
+            <div className="form-group">
+                <Button display="block" type="submit" disabled={loading} variant="primary">
+                    {loading ? <LoadingSpinner /> : 'Verify'}
+                </Button>
+            </div>
+            {challenge.methods?.includes('webauthn') && (
+                <div className="form-group">
+                    <Button display="block" disabled={loading} variant="secondary" onClick={onUseSecurityKey}>
+                        Use a security key
+                    </Button>
+                </div>
+            )}
+            {challenge.methods?.includes('totp') && (
+                <Button
+                    display="block"
+                    variant="link"
+                    size="sm"
+                    disabled={loading}
+                    onClick={() => setUseRecoveryCode(!useRecoveryCode)}
+                >
+                    {useRecoveryCode ? 'Use your authenticator app' : 'Use a recovery code'}
+                </Button>
+            )}
+        </Form>
+    )
+}
@@ -11,6 +11,7 @@ import { Label, Button, LoadingSpinner, Link, Text, Input, Form } from '@sourceg
 import type { SourcegraphContext } from '../jscontext'
 import { V2AuthProviderTypes } from '../util/constants'
 
+import { type SecondFactorChallenge, SecondFactorSignInForm } from './SecondFactorSignInForm'
 import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
 
 interface Props extends TelemetryV2Props {
@@ -39,6 +40,8 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
     const [usernameOrEmail, setUsernameOrEmail] = useState(email || '')
     const [password, setPassword] = useState('')
     const [loading, setLoading] = useState(false)
+    // Set once the password was correct, if the sign in must be completed with a second factor.
+    const [secondFactor, setSecondFactor] = useState<SecondFactorChallenge | null>(null)
 
     const onUsernameOrEmailFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
         setUsernameOrEmail(event.target.value)
@@ -48,6 +51,15 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
         setPassword(event.target.value)
     }, [])
 
+    const onSignedIn = useCallback((): void => {
+        if (new URLSearchParams(location.search).get('close') === 'true') {
+            window.close()
+        } else {
+            const returnTo = getReturnTo(location)
+            window.location.replace(returnTo)
+        }
+    }, [location])
+
     const handleSubmit = useCallback(
         async (event: React.FormEvent<HTMLFormElement>): Promise<void> => {
             event.preventDefault()
@@ -73,12 +85,14 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     }),
                 })
                 if (response.status === 200) {
-                    if (new URLSearchParams(location.search).get('close') === 'true') {
-                        window.close()
-                    } else {
-                        const returnTo = getReturnTo(location)
-                        window.location.replace(returnTo)
+                    const text = await response.text()
+                    const challenge = text === '' ? null : (JSON.parse(text) as SecondFactorChallenge)
+                    if (challenge?.secondFactorRequired || challenge?.secondFactorEnrollmentRequired) {
+                        setSecondFactor(challenge)
+                        setLoading(false)
+                        return
                     }
+                    onSignedIn()
                 } else if (response.status === 401) {
                     throw new Error('User or password was incorrect')
                 } else if (response.status === 422) {
@@ -93,9 +107,21 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                 onAuthError(asError(error))
             }
         },
-        [usernameOrEmail, loading, location, password, onAuthError, context, telemetryRecorder]
+        [usernameOrEmail, loading, password, onAuthError, onSignedIn, context, telemetryRecorder]
     )
 
+    if (secondFactor) {
+        return (
+            <SecondFactorSignInForm
+                challenge={secondFactor}
+                xhrHeaders={context.xhrHeaders}
+                onSignedIn={onSignedIn}
+                onAuthError={onAuthError}
+                className={className}
+            />
+        )
+    }
+
     return (
         <>
             <Form onSubmit={handleSubmit} className={className}>
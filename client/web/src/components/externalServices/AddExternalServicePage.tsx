@@ -45,6 +45,12 @@ const v2ExternalServiceKinds: { [key in ExternalServiceKind]: number } = {
     PYTHONPACKAGES: 16,
     RUBYPACKAGES: 17,
     RUSTPACKAGES: 18,
+    GITEA: 19,
+    MERCURIAL: 20,
+    SUBVERSION: 21,
+    NUGETPACKAGES: 22,
+    HEXPACKAGES: 23,
+    PACKAGISTPACKAGES: 24,
 }
 
 /**
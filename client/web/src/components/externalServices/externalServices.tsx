@@ -6,8 +6,11 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import HexagonOutlineIcon from 'mdi-react/HexagonOutlineIcon'
+import LanguageCsharpIcon from 'mdi-react/LanguageCsharpIcon'
 import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
+import LanguagePhpIcon from 'mdi-react/LanguagePhpIcon'
 import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
 import LanguageRubyIcon from 'mdi-react/LanguageRubyIcon'
 import LanguageRustIcon from 'mdi-react/LanguageRustIcon'
@@ -22,19 +25,25 @@ import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
+import hexPackagesSchemaJSON from '../../../../../schema/hex-packages.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
+import mercurialSchemaJSON from '../../../../../schema/mercurial.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
+import nugetPackagesSchemaJSON from '../../../../../schema/nuget-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
+import packagistPackagesSchemaJSON from '../../../../../schema/packagist-packages.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
 import pythonPackagesJSON from '../../../../../schema/python-packages.schema.json'
 import rubyPackagesSchemaJSON from '../../../../../schema/ruby-packages.schema.json'
 import rustPackagesJSON from '../../../../../schema/rust-packages.schema.json'
+import subversionSchemaJSON from '../../../../../schema/subversion.schema.json'
 import {
     type ExternalRepositoryFields,
     ExternalServiceKind,
@@ -1343,6 +1352,116 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Create an access token with the <Code>read:repository</Code>, <Code>read:organization</Code> and{' '}
+                    <Code>read:user</Code> scopes and set it as <Field>token</Field>.
+                </li>
+                <li>
+                    Select the repositories to mirror with <Field>orgs</Field>, <Field>users</Field>,{' '}
+                    <Field>repos</Field> or <Field>repositoryQuery</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const MERCURIAL: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.MERCURIAL,
+    title: 'Mercurial',
+    icon: GitIcon,
+    jsonSchema: mercurialSchemaJSON,
+    defaultDisplayName: 'Mercurial repositories',
+    defaultConfig: `{
+  "url": "https://hg.example.com",
+  "repos": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the base URL of your Mercurial server.
+                </li>
+                <li>
+                    Add the paths of the repositories you wish to index to the <Field>repos</Field> field. Each
+                    repository is converted to Git on clone, with its branches, bookmarks and tags.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'addRepo',
+            label: 'Add a repository',
+            run: (config: string) => {
+                const value = 'path/to/repository'
+                const edits = modify(config, ['repos', -1], value, defaultModificationOptions)
+                return { edits, selectText: value }
+            },
+        },
+    ],
+}
+
+const SUBVERSION: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.SUBVERSION,
+    title: 'Subversion',
+    icon: GitIcon,
+    jsonSchema: subversionSchemaJSON,
+    defaultDisplayName: 'Subversion repositories',
+    defaultConfig: `{
+  "url": "https://svn.example.com",
+  "username": "<username>",
+  "password": "<password>",
+  "repos": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the base URL of your Subversion server and
+                    set <Field>username</Field> and <Field>password</Field> to a user with read access.
+                </li>
+                <li>
+                    Add the paths of the repositories you wish to index to the <Field>repos</Field> field. Each
+                    repository is converted to Git on clone: trunk and branches become branches, tags become tags.
+                </li>
+                <li>
+                    If your repositories don't use the standard trunk, branches and tags directories, configure them
+                    in <Field>layout</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'addRepo',
+            label: 'Add a repository',
+            run: (config: string) => {
+                const value = 'path/to/repository'
+                const edits = modify(config, ['repos', -1], value, defaultModificationOptions)
+                return { edits, selectText: value }
+            },
+        },
+    ],
+}
+
 const GERRIT: AddExternalServiceOptions = {
     kind: ExternalServiceKind.GERRIT,
     title: 'Gerrit',
@@ -1579,6 +1698,112 @@ const RUBY_PACKAGES: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const NUGET_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.NUGETPACKAGES,
+    title: 'NuGet Dependencies',
+    icon: LanguageCsharpIcon,
+    jsonSchema: nugetPackagesSchemaJSON,
+    defaultDisplayName: 'NuGet Dependencies',
+    defaultConfig: `{
+  "registry": "https://api.nuget.org/v3/index.json",
+  "dependencies": ["Newtonsoft.Json@13.0.3"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    The feed at https://api.nuget.org/v3/index.json is used if the field <Code>"registry"</Code> is
+                    empty. Any feed that implements the NuGet V3 API, such as Azure Artifacts or Artifactory, can be
+                    used instead.
+                </li>
+                <li>
+                    Use the syntax <Code>"PACKAGE_ID@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>password</Field> if the feed requires authentication. The
+                    password is redacted after saving.
+                </li>
+                <li>
+                    Compiled assemblies are not synced, only the sources, content files and the <Code>.nuspec</Code>{' '}
+                    manifest of a package.
+                </li>
+            </ol>
+            <Text>⚠️ NuGet package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one NuGet packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const HEX_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.HEXPACKAGES,
+    title: 'Hex Dependencies',
+    icon: HexagonOutlineIcon,
+    jsonSchema: hexPackagesSchemaJSON,
+    defaultDisplayName: 'Hex Dependencies',
+    defaultConfig: `{
+  "repository": "https://repo.hex.pm",
+  "dependencies": ["phoenix@1.7.10"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    The repository at https://repo.hex.pm is used if the field <Code>"repository"</Code> is empty.
+                    Packages of a private Hex.pm organization are served from{' '}
+                    <Code>https://repo.hex.pm/repos/ORGANIZATION</Code>.
+                </li>
+                <li>
+                    Use the syntax <Code>"PACKAGE_NAME@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    Set <Field>apiKey</Field> if the repository requires authentication. The key is redacted after
+                    saving.
+                </li>
+            </ol>
+            <Text>⚠️ Hex package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one Hex packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const PACKAGIST_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.PACKAGISTPACKAGES,
+    title: 'Packagist Dependencies',
+    icon: LanguagePhpIcon,
+    jsonSchema: packagistPackagesSchemaJSON,
+    defaultDisplayName: 'Packagist Dependencies',
+    defaultConfig: `{
+  "repository": "https://repo.packagist.org",
+  "dependencies": ["monolog/monolog@3.5.0"]
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    The repository at https://repo.packagist.org is used if the field <Code>"repository"</Code> is
+                    empty. Private Packagist and Satis repositories can be used instead.
+                </li>
+                <li>
+                    Use the syntax <Code>"VENDOR/PACKAGE@VERSION"</Code> to list a dependency for the{' '}
+                    <Code>"dependencies"</Code> field.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>password</Field> if the repository requires authentication.
+                    The password is redacted after saving, and it is never sent to the hosts that distribution
+                    archives are downloaded from.
+                </li>
+            </ol>
+            <Text>⚠️ Packagist package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one Packagist packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB,
     ghapp: GITHUB_APP,
@@ -1590,6 +1815,9 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     gitolite: GITOLITE,
     git: GENERIC_GIT,
     gerrit: GERRIT,
+    gitea: GITEA,
+    mercurial: MERCURIAL,
+    subversion: SUBVERSION,
     azuredevops: AZUREDEVOPS,
     phabricator: PHABRICATOR_SERVICE,
     ...(window.context?.experimentalFeatures?.perforce !== 'disabled' ? { perforce: PERFORCE } : {}),
@@ -1603,6 +1831,11 @@ export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptio
     ...(window.context?.experimentalFeatures?.goPackages === 'enabled' ? { goModules: GO_MODULES } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.nugetPackages === 'enabled' ? { nugetPackages: NUGET_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.hexPackages === 'enabled' ? { hexPackages: HEX_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.packagistPackages === 'enabled'
+        ? { packagistPackages: PACKAGIST_PACKAGES }
+        : {}),
 }
 
 export const allExternalServices = {
@@ -1622,6 +1855,9 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.GITEA]: GITEA,
+    [ExternalServiceKind.MERCURIAL]: MERCURIAL,
+    [ExternalServiceKind.SUBVERSION]: SUBVERSION,
     [ExternalServiceKind.PAGURE]: PAGURE,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
@@ -1629,6 +1865,9 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
     [ExternalServiceKind.RUSTPACKAGES]: RUST_PACKAGES,
     [ExternalServiceKind.RUBYPACKAGES]: RUBY_PACKAGES,
+    [ExternalServiceKind.NUGETPACKAGES]: NUGET_PACKAGES,
+    [ExternalServiceKind.HEXPACKAGES]: HEX_PACKAGES,
+    [ExternalServiceKind.PACKAGISTPACKAGES]: PACKAGIST_PACKAGES,
 }
 
 export const externalRepoIcon = (
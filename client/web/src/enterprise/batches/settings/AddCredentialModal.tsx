@@ -64,18 +64,28 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
     ),
     [ExternalServiceKind.GERRIT]: <span />,
     [ExternalServiceKind.PERFORCE]: <span>with the ability to shelve changelists.</span>,
+    [ExternalServiceKind.GITEA]: (
+        <span>
+            with <Code>write:repository</Code>, <Code>write:issue</Code>, and <Code>read:user</Code> scopes.
+        </span>
+    ),
     // These are just for type completeness and serve as placeholders for a bright future.
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUSTPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUBYPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.NUGETPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.HEXPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.PACKAGISTPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.JVMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.NPMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.PHABRICATOR]: <span>Unsupported</span>,
     [ExternalServiceKind.AWSCODECOMMIT]: <span>Unsupported</span>,
     [ExternalServiceKind.PAGURE]: <span>Unsupported</span>,
     [ExternalServiceKind.OTHER]: <span>Unsupported</span>,
+    [ExternalServiceKind.MERCURIAL]: <span>Unsupported</span>,
+    [ExternalServiceKind.SUBVERSION]: <span>Unsupported</span>,
 }
 
 type Step = 'add-token' | 'get-ssh-key'
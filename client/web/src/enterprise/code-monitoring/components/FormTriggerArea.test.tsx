@@ -91,6 +91,14 @@ describe.skip('FormTriggerArea', () => {
             repoChecked: false,
             validChecked: true,
         },
+        {
+            query: 'test type:file',
+            isSourcegraphDotCom: true,
+            patternTypeChecked: true,
+            typeChecked: true,
+            repoChecked: false,
+            validChecked: true,
+        },
         {
             query: 'test repo:test',
             isSourcegraphDotCom: true,
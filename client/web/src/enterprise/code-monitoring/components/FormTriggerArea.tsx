@@ -29,7 +29,8 @@ interface TriggerAreaProps {
     isSourcegraphDotCom: boolean
 }
 
-const isDiffOrCommit = (value: string): boolean => value === 'diff' || value === 'commit'
+// type:file monitors report lines that newly match in the searched files
+const isSupportedType = (value: string): boolean => value === 'diff' || value === 'commit' || value === 'file'
 
 // Code monitors don't support pattern type "structural"
 const isValidPatternType = (value: string): boolean =>
@@ -114,17 +115,20 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
     }, [])
 
     const [isValidQuery, setIsValidQuery] = useState(false)
-    const [hasTypeDiffOrCommitFilter, setHasTypeDiffOrCommitFilter] = useState(false)
+    const [hasSupportedTypeFilter, setHasSupportedTypeFilter] = useState(false)
     const [hasRepoFilter, setHasRepoFilter] = useState(false)
+    const [hasFileTypeFilter, setHasFileTypeFilter] = useState(false)
     const [hasPatternTypeFilter, setHasPatternTypeFilter] = useState(false)
     const [hasValidPatternTypeFilter, setHasValidPatternTypeFilter] = useState(true)
+    // Content monitors search the whole default branch on every run, so they must always be scoped.
+    const requiresRepoFilter = isSourcegraphDotCom || hasFileTypeFilter
     const isTriggerQueryComplete = useMemo(
         () =>
             isValidQuery &&
-            hasTypeDiffOrCommitFilter &&
-            (!isSourcegraphDotCom || hasRepoFilter) &&
+            hasSupportedTypeFilter &&
+            (!requiresRepoFilter || hasRepoFilter) &&
             hasValidPatternTypeFilter,
-        [hasRepoFilter, hasTypeDiffOrCommitFilter, hasValidPatternTypeFilter, isValidQuery, isSourcegraphDotCom]
+        [hasRepoFilter, hasSupportedTypeFilter, hasValidPatternTypeFilter, isValidQuery, requiresRepoFilter]
     )
 
     const [queryState, setQueryState] = useState<QueryState>({ query: query || '' })
@@ -136,25 +140,37 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
         const isValidQuery = !!value && tokens.type === 'success'
         setIsValidQuery(isValidQuery)
 
-        let hasTypeDiffOrCommitFilter = false
+        let hasSupportedTypeFilter = false
         let hasRepoFilter = false
+        let hasFileTypeFilter = false
         let hasPatternTypeFilter = false
         let hasValidPatternTypeFilter = true
 
         if (tokens.type === 'success') {
             const filters = tokens.term.filter(token => token.type === 'filter')
-            hasTypeDiffOrCommitFilter = filters.some(
+            hasSupportedTypeFilter = filters.some(
                 filter =>
                     filter.type === 'filter' &&
                     resolveFilter(filter.field.value)?.type === FilterType.type &&
                     filter.value &&
-                    isDiffOrCommit(filter.value.value)
+                    isSupportedType(filter.value.value)
             )
 
+            hasFileTypeFilter = filters.some(
+                filter =>
+                    filter.type === 'filter' &&
+                    resolveFilter(filter.field.value)?.type === FilterType.type &&
+                    filter.value?.value === 'file'
+            )
+
+            // A search context scopes content monitors as well as a repo: filter does.
             hasRepoFilter = filters.some(
                 filter =>
                     filter.type === 'filter' &&
-                    resolveFilter(filter.field.value)?.type === FilterType.repo &&
+                    (resolveFilter(filter.field.value)?.type === FilterType.repo ||
+                        (hasFileTypeFilter &&
+                            resolveFilter(filter.field.value)?.type === FilterType.context &&
+                            filter.value?.value !== 'global')) &&
                     filter.value
             )
 
@@ -178,8 +194,9 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                 )
         }
 
-        setHasTypeDiffOrCommitFilter(hasTypeDiffOrCommitFilter)
+        setHasSupportedTypeFilter(hasSupportedTypeFilter)
         setHasRepoFilter(hasRepoFilter)
+        setHasFileTypeFilter(hasFileTypeFilter)
         setHasPatternTypeFilter(hasPatternTypeFilter)
         setHasValidPatternTypeFilter(hasValidPatternTypeFilter)
     }, [queryState.query])
@@ -266,19 +283,24 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                             </li>
                             <li>
                                 <ValidQueryChecklistItem
-                                    checked={hasTypeDiffOrCommitFilter}
-                                    hint="type:diff targets code present in new commits, while type:commit targets commit messages"
+                                    checked={hasSupportedTypeFilter}
+                                    hint="type:diff targets code present in new commits, type:commit targets commit messages, and type:file targets lines that newly match in the searched files"
                                     dataTestid="type-checkbox"
                                 >
-                                    Contains a <Code>type:diff</Code> or <Code>type:commit</Code> filter
+                                    Contains a <Code>type:diff</Code>, <Code>type:commit</Code> or{' '}
+                                    <Code>type:file</Code> filter
                                 </ValidQueryChecklistItem>
                             </li>
-                            {/* Enforce repo filter on sourcegraph.com because otherwise it's too easy to generate a lot of load */}
-                            {isSourcegraphDotCom && (
+                            {/* Enforce repo filter on sourcegraph.com and for content monitors because otherwise it's too easy to generate a lot of load */}
+                            {requiresRepoFilter && (
                                 <li>
                                     <ValidQueryChecklistItem
                                         checked={hasRepoFilter}
-                                        hint="The repo: filter is required to narrow down your search."
+                                        hint={
+                                            hasFileTypeFilter
+                                                ? 'type:file monitors must be narrowed down with a repo: filter or a search context.'
+                                                : 'The repo: filter is required to narrow down your search.'
+                                        }
                                         dataTestid="repo-checkbox"
                                     >
                                         Contains a <Code>repo:</Code> filter
@@ -59,6 +59,9 @@ export function useFlagsOverrides(): { data: OrgFlagOverride[]; loading: boolean
                         ... on FeatureFlagRollout {
                             name
                         }
+                        ... on FeatureFlagTargeting {
+                            name
+                        }
                     }
                     value
                 }
@@ -28,6 +28,7 @@ export interface AuthProvider {
         | 'openidconnect'
         | 'sourcegraph-operator'
         | 'saml'
+        | 'ldap'
         | 'builtin'
         | 'gerrit'
         | 'azuredevops'
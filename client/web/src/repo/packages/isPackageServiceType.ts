@@ -12,6 +12,9 @@ export function isPackageServiceType(serviceType?: string): boolean {
         case 'npmPackages':
         case 'pythonPackages':
         case 'rubyPackages':
+        case 'nugetPackages':
+        case 'hexPackages':
+        case 'packagistPackages':
         case 'goModules':
         case 'rustPackages': {
             return true
@@ -99,7 +99,7 @@ export const SearchActionsMenu: React.FunctionComponent<SearchActionsMenuProps>
                             <Tooltip
                                 content={
                                     authenticatedUser && !canCreateMonitor
-                                        ? 'Code monitors only support type:diff or type:commit searches.'
+                                        ? 'Code monitors only support type:diff, type:commit or type:file searches.'
                                         : undefined
                                 }
                                 placement="left"
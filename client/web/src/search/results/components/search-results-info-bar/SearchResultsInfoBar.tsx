@@ -106,7 +106,7 @@ export const SearchResultsInfoBar: FC<SearchResultsInfoBarProps> = props => {
     )
 
     const canCreateMonitorFromQuery = useMemo(
-        () => globalTypeFilter === 'diff' || globalTypeFilter === 'commit',
+        () => globalTypeFilter === 'diff' || globalTypeFilter === 'commit' || globalTypeFilter === 'file',
         [globalTypeFilter]
     )
 
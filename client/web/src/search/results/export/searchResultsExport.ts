@@ -8,6 +8,7 @@ import {
     type PathMatch,
     type RepositoryMatch,
     type CommitMatch,
+    type HunkMatch,
     getCommitMatchUrl,
     type SymbolMatch,
     type PersonMatch,
@@ -172,6 +173,30 @@ export const searchResultsToFileContent = (
             break
         }
 
+        case 'hunk': {
+            content = [
+                [...headers, 'Date', 'Author', 'oid', 'Commit URL', 'File path', 'Hunk'],
+                ...searchResults
+                    .filter((result: SearchMatch): result is HunkMatch => result.type === 'hunk')
+                    .map(result => {
+                        const repoURL = new URL(getRepositoryUrl(result.repository), sourcegraphURL).toString()
+                        const commitURL = new URL(getCommitMatchUrl(result), sourcegraphURL).toString()
+                        return [
+                            result.type,
+                            result.repository,
+                            repoURL,
+                            result.authorDate,
+                            result.authorName,
+                            result.oid,
+                            commitURL,
+                            result.path,
+                            result.content,
+                        ]
+                    }),
+            ]
+            break
+        }
+
         case 'person':
         case 'team': {
             content = [
@@ -43,6 +43,12 @@ const v2CodeHostKind: { [key in ExternalServiceKind]: number } = {
     PYTHONPACKAGES: 15,
     RUBYPACKAGES: 16,
     RUSTPACKAGES: 17,
+    GITEA: 18,
+    MERCURIAL: 19,
+    SUBVERSION: 20,
+    NUGETPACKAGES: 21,
+    HEXPACKAGES: 22,
+    PACKAGISTPACKAGES: 23,
 }
 
 /**
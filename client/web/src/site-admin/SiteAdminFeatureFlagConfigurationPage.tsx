@@ -26,6 +26,7 @@ import {
     Label,
     H3,
     Text,
+    TextArea,
     ErrorAlert,
     Form,
 } from '@sourcegraph/wildcard'
@@ -87,7 +88,16 @@ export const SiteAdminFeatureFlagConfigurationPage: FunctionComponent<
         if (featureFlagOrError && !isErrorLike(featureFlagOrError)) {
             setFlagName(featureFlagOrError.name)
             setFlagType(featureFlagOrError.__typename)
-            setFlagValue(featureFlagOrError)
+            setFlagValue(
+                featureFlagOrError.__typename === 'FeatureFlagTargeting'
+                    ? {
+                          targeting: {
+                              rules: featureFlagOrError.rules,
+                              rolloutBasisPoints: featureFlagOrError.rolloutBasisPoints,
+                          },
+                      }
+                    : featureFlagOrError
+            )
             setOverrides(featureFlagOrError.overrides)
         }
     }, [featureFlagOrError])
@@ -272,7 +282,7 @@ export const SiteAdminFeatureFlagConfigurationPage: FunctionComponent<
     )
 }
 
-type FeatureFlagType = 'FeatureFlagBoolean' | 'FeatureFlagRollout'
+type FeatureFlagType = 'FeatureFlagBoolean' | 'FeatureFlagRollout' | 'FeatureFlagTargeting'
 
 interface FeatureFlagOverride {
     id: string
@@ -293,6 +303,21 @@ interface FeatureFlagRolloutValue {
     rolloutBasisPoints: number
 }
 
+interface FeatureFlagTargetingRule {
+    type: string
+    orgIDs?: string[]
+    roles?: string[]
+    emailDomains?: string[]
+    value: boolean
+}
+
+interface FeatureFlagTargetingValue {
+    targeting: {
+        rules: FeatureFlagTargetingRule[]
+        rolloutBasisPoints: number
+    }
+}
+
 interface CreateFeatureFlagOverrideResult {
     createFeatureFlagOverride: FeatureFlagOverride
 }
@@ -303,7 +328,7 @@ interface CreateFeatureFlagOverrideVariables {
     value: boolean
 }
 
-type FeatureFlagValue = FeatureFlagBooleanValue | FeatureFlagRolloutValue
+type FeatureFlagValue = FeatureFlagBooleanValue | FeatureFlagRolloutValue | FeatureFlagTargetingValue
 type FeatureFlagOverrideType = 'User' | 'Org'
 
 const AddFeatureFlagOverride: FunctionComponent<
@@ -678,6 +703,7 @@ const CreateFeatureFlag: React.FunctionComponent<
             <option value="">Select flag type</option>
             <option value="FeatureFlagRollout">Rollout</option>
             <option value="FeatureFlagBoolean">Boolean</option>
+            <option value="FeatureFlagTargeting">Targeting</option>
         </Select>
 
         {type && <FeatureFlagValueSettings type={type} value={value} setFlagValue={setFlagValue} />}
@@ -695,6 +721,14 @@ const FeatureFlagValueSettings: React.FunctionComponent<
         setFlagValue: (next: FeatureFlagValue) => void
     }>
 > = ({ type, value, setFlagValue }) => {
+    if (type === 'FeatureFlagTargeting') {
+        if (!value || !('targeting' in value)) {
+            value = { targeting: { rules: [], rolloutBasisPoints: 0 } }
+            setFlagValue({ ...value })
+        }
+        return <FeatureFlagTargetingValueSettings value={value} update={next => setFlagValue({ ...next })} />
+    }
+
     if (type === 'FeatureFlagRollout') {
         if (!value || !('rolloutBasisPoints' in value)) {
             value = { rolloutBasisPoints: 0 }
@@ -734,8 +768,9 @@ const FeatureFlagRolloutValueSettings: React.FunctionComponent<
     React.PropsWithChildren<{
         value: FeatureFlagRolloutValue
         update: (next: FeatureFlagRolloutValue) => void
+        description?: string
     }>
-> = ({ value, update }) => (
+> = ({ value, update, description = 'of users' }) => (
     <div className="form-group d-flex flex-column align-content-start">
         <Input
             type="range"
@@ -756,12 +791,65 @@ const FeatureFlagRolloutValueSettings: React.FunctionComponent<
         <div className="flex-column mt-3" id="feature-flag-rollout-description">
             <div>{value.rolloutBasisPoints} basis points</div>
             <div className="text-muted">
-                This feature is enabled for {Math.floor(value.rolloutBasisPoints / 100) || 0}% of users.
+                This feature is enabled for {Math.floor(value.rolloutBasisPoints / 100) || 0}% {description}.
             </div>
         </div>
     </div>
 )
 
+const TARGETING_RULES_EXAMPLE = '[{"type": "EMAIL_DOMAIN", "emailDomains": ["example.com"], "value": true}]'
+
+const FeatureFlagTargetingValueSettings: React.FunctionComponent<
+    React.PropsWithChildren<{
+        value: FeatureFlagTargetingValue
+        update: (next: FeatureFlagTargetingValue) => void
+    }>
+> = ({ value, update }) => {
+    const [rules, setRules] = useState<string>(JSON.stringify(value.targeting.rules, null, 2))
+    const [rulesError, setRulesError] = useState<string>()
+
+    return (
+        <>
+            <TextArea
+                id="targeting-rules"
+                label={<H3>Rules</H3>}
+                className="form-group"
+                rows={10}
+                value={rules}
+                onChange={({ target }) => {
+                    setRules(target.value)
+                    try {
+                        const parsed = JSON.parse(target.value) as FeatureFlagTargetingRule[]
+                        if (!Array.isArray(parsed)) {
+                            throw new TypeError('Rules must be a JSON array.')
+                        }
+                        setRulesError(undefined)
+                        update({ targeting: { ...value.targeting, rules: parsed } })
+                    } catch (error) {
+                        setRulesError(asError(error).message)
+                    }
+                }}
+                isValid={rulesError === undefined ? undefined : false}
+                message={
+                    rulesError ?? (
+                        <>
+                            A JSON array of rules, evaluated in order. The first rule that matches a user decides
+                            the value, for example <Code>{TARGETING_RULES_EXAMPLE}</Code>. Rule types are{' '}
+                            <Code>ORG</Code> (with <Code>orgIDs</Code>), <Code>ROLE</Code> (with <Code>roles</Code>),{' '}
+                            <Code>EMAIL_DOMAIN</Code> (with <Code>emailDomains</Code>) and <Code>SITE_ADMIN</Code>.
+                        </>
+                    )
+                }
+            />
+            <FeatureFlagRolloutValueSettings
+                value={{ rolloutBasisPoints: value.targeting.rolloutBasisPoints }}
+                update={next => update({ targeting: { ...value.targeting, ...next } })}
+                description="of users that match no rule"
+            />
+        </>
+    )
+}
+
 const FeatureFlagBooleanValueSettings: React.FunctionComponent<
     React.PropsWithChildren<{
         value: FeatureFlagBooleanValue
@@ -841,16 +929,26 @@ const ReferencesCollapsible: React.FunctionComponent<
 }
 
 const CREATE_FEATURE_FLAG_MUTATION = gql`
-    mutation create($name: String!, $value: Boolean, $rolloutBasisPoints: Int) {
-        createFeatureFlag(name: $name, value: $value, rolloutBasisPoints: $rolloutBasisPoints) {
+    mutation create(
+        $name: String!
+        $value: Boolean
+        $rolloutBasisPoints: Int
+        $targeting: FeatureFlagTargetingInput
+    ) {
+        createFeatureFlag(name: $name, value: $value, rolloutBasisPoints: $rolloutBasisPoints, targeting: $targeting) {
             __typename
         }
     }
 `
 
 const UPDATE_FEATURE_FLAG_MUTATION = gql`
-    mutation update($name: String!, $value: Boolean, $rolloutBasisPoints: Int) {
-        updateFeatureFlag(name: $name, value: $value, rolloutBasisPoints: $rolloutBasisPoints) {
+    mutation update(
+        $name: String!
+        $value: Boolean
+        $rolloutBasisPoints: Int
+        $targeting: FeatureFlagTargetingInput
+    ) {
+        updateFeatureFlag(name: $name, value: $value, rolloutBasisPoints: $rolloutBasisPoints, targeting: $targeting) {
             __typename
         }
     }
@@ -128,6 +128,12 @@ const filters: Filter[] = [
                 tooltip: 'Show rollout feature flags',
                 args: { type: 'FeatureFlagRollout' },
             },
+            {
+                label: 'Targeting',
+                value: 'targeting',
+                tooltip: 'Show targeting feature flags',
+                args: { type: 'FeatureFlagTargeting' },
+            },
         ],
     },
 ]
@@ -277,6 +283,8 @@ const FeatureFlagNode: React.FunctionComponent<React.PropsWithChildren<FeatureFl
                     <div>
                         {node.__typename === 'FeatureFlagBoolean' && <Code>{JSON.stringify(node.value)}</Code>}
                         {node.__typename === 'FeatureFlagRollout' && node.rolloutBasisPoints}
+                        {node.__typename === 'FeatureFlagTargeting' &&
+                            `${node.rules.length} ${pluralize('rule', node.rules.length)}`}
                     </div>
 
                     {node.__typename === 'FeatureFlagRollout' && (
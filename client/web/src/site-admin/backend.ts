@@ -729,6 +729,22 @@ export function fetchFeatureFlags(): Observable<FeatureFlagFields[]> {
                     createdAt
                     updatedAt
                 }
+                ... on FeatureFlagTargeting {
+                    name
+                    rules {
+                        type
+                        orgIDs
+                        roles
+                        emailDomains
+                        value
+                    }
+                    rolloutBasisPoints
+                    overrides {
+                        ...OverrideFields
+                    }
+                    createdAt
+                    updatedAt
+                }
             }
 
             fragment OverrideFields on FeatureFlagOverride {
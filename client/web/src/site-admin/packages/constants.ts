@@ -33,6 +33,18 @@ export const ExternalServicePackageMap: Partial<
         label: 'Rust',
         value: PackageRepoReferenceKind.RUSTPACKAGES,
     },
+    [ExternalServiceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: PackageRepoReferenceKind.NUGETPACKAGES,
+    },
+    [ExternalServiceKind.HEXPACKAGES]: {
+        label: 'Hex',
+        value: PackageRepoReferenceKind.HEXPACKAGES,
+    },
+    [ExternalServiceKind.PACKAGISTPACKAGES]: {
+        label: 'Packagist',
+        value: PackageRepoReferenceKind.PACKAGISTPACKAGES,
+    },
 }
 
 export const PackageExternalServiceMap: Partial<
@@ -68,4 +80,16 @@ export const PackageExternalServiceMap: Partial<
         label: 'Rust',
         value: ExternalServiceKind.RUSTPACKAGES,
     },
+    [PackageRepoReferenceKind.NUGETPACKAGES]: {
+        label: 'NuGet',
+        value: ExternalServiceKind.NUGETPACKAGES,
+    },
+    [PackageRepoReferenceKind.HEXPACKAGES]: {
+        label: 'Hex',
+        value: ExternalServiceKind.HEXPACKAGES,
+    },
+    [PackageRepoReferenceKind.PACKAGISTPACKAGES]: {
+        label: 'Packagist',
+        value: ExternalServiceKind.PACKAGISTPACKAGES,
+    },
 }
@@ -123,6 +123,7 @@ export const ExternalAccountConnectionDetails: FC<ExternalAccountConnectionDetai
     switch (serviceType) {
         case 'openidconnect':
         case 'saml':
+        case 'ldap':
         case 'gerrit': {
             return <span>{account.external?.displayName || 'Not connected'}</span>
         }
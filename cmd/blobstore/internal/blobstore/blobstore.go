@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -90,9 +91,29 @@ func (s *Service) createBucket(ctx context.Context, name string) error {
 	return nil
 }
 
+func (s *Service) bucketExists(ctx context.Context, name string) bool {
+	_ = ctx
+
+	// Ensure the bucket cannot be created/deleted while we look at it.
+	bucketLock := s.bucketLock(name)
+	bucketLock.RLock()
+	defer bucketLock.RUnlock()
+
+	_, err := os.Stat(s.bucketDir(name))
+	return err == nil
+}
+
 type objectMetadata struct {
 	LastModified time.Time
 	Name         string
+	Size         int64
+}
+
+// etag returns an entity tag for the object. We don't store a checksum of object contents, so
+// the tag is derived from the size and modification time which change whenever the object is
+// overwritten.
+func (o *objectMetadata) etag() string {
+	return fmt.Sprintf("\"%x-%x\"", o.LastModified.UnixNano(), o.Size)
 }
 
 func (s *Service) putObject(ctx context.Context, bucketName, objectName string, data io.ReadCloser) (*objectMetadata, error) {
@@ -123,7 +144,8 @@ func (s *Service) putObject(ctx context.Context, bucketName, objectName string,
 		tmpFile.Close()
 		os.Remove(tmpFile.Name())
 	}()
-	if _, err := io.Copy(tmpFile, data); err != nil {
+	size, err := io.Copy(tmpFile, data)
+	if err != nil {
 		return nil, errors.Wrap(err, "copying data into tmp file")
 	}
 	// Ensure file bytes are on disk before renaming
@@ -150,10 +172,13 @@ func (s *Service) putObject(ctx context.Context, bucketName, objectName string,
 	return &objectMetadata{
 		LastModified: age,
 		Name:         objectName,
+		Size:         size,
 	}, nil
 }
 
-func (s *Service) getObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+// getObject opens the named object. The returned file is seekable so that range requests can be
+// served from it.
+func (s *Service) getObject(ctx context.Context, bucketName, objectName string) (*os.File, error) {
 	_ = ctx
 
 	// Ensure the bucket cannot be created/deleted while we look at it.
@@ -162,7 +187,7 @@ func (s *Service) getObject(ctx context.Context, bucketName, objectName string)
 	defer bucketLock.RUnlock()
 
 	// Read the object
-	// Note that we return an io.ReadCloser here, so f.Close is intentionally NOT called.
+	// Note that we return the open file here, so f.Close is intentionally NOT called.
 	objectFile := s.objectFilePath(bucketName, objectName)
 	f, err := os.Open(objectFile)
 	if err != nil {
@@ -176,6 +201,36 @@ func (s *Service) getObject(ctx context.Context, bucketName, objectName string)
 	return f, nil
 }
 
+func (s *Service) statObject(ctx context.Context, bucketName, objectName string) (*objectMetadata, error) {
+	_ = ctx
+
+	// Ensure the bucket cannot be created/deleted while we look at it.
+	bucketLock := s.bucketLock(bucketName)
+	bucketLock.RLock()
+	defer bucketLock.RUnlock()
+
+	info, err := os.Stat(s.objectFilePath(bucketName, objectName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSuchKey
+		}
+		return nil, errors.Wrap(err, "Stat")
+	}
+	return s.newObjectMetadata(objectName, info), nil
+}
+
+func (s *Service) newObjectMetadata(objectName string, info os.FileInfo) *objectMetadata {
+	age := info.ModTime().UTC()
+	if mock, ok := s.MockObjectAge[objectName]; ok {
+		age = mock
+	}
+	return &objectMetadata{
+		LastModified: age,
+		Name:         objectName,
+		Size:         info.Size(),
+	}
+}
+
 func (s *Service) deleteObject(ctx context.Context, bucketName, objectName string) error {
 	_ = ctx
 
@@ -225,18 +280,67 @@ func (s *Service) listObjects(_ context.Context, bucketName string, prefix strin
 			s.Log.Warn("error listing objects in bucket (ignoring)", sglog.String("key", bucketName+"/"+objectName), sglog.Error(err))
 			continue
 		}
-		age := info.ModTime().UTC()
-		if mock, ok := s.MockObjectAge[objectName]; ok {
-			age = mock
-		}
-		objects = append(objects, objectMetadata{
-			Name:         objectName,
-			LastModified: age,
-		})
+		objects = append(objects, *s.newObjectMetadata(objectName, info))
 	}
+
+	// Directory entries are sorted by their escaped file name, but S3 lists keys in the
+	// lexicographical order of the keys themselves.
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
 	return objects, nil
 }
 
+// objectListPage is a single page of a bucket listing.
+type objectListPage struct {
+	Objects        []objectMetadata
+	CommonPrefixes []string
+	IsTruncated    bool
+	// NextMarker is the last key or common prefix in the page. Listing continues after it.
+	NextMarker string
+}
+
+// paginateObjects returns the page of objects (sorted by name, and all matching prefix) that
+// follows marker, with at most maxKeys objects and common prefixes.
+//
+// If delimiter is non-empty, keys that contain the delimiter after the prefix are rolled up
+// into a single common prefix which ends in the first occurrence of the delimiter.
+func paginateObjects(objects []objectMetadata, prefix, delimiter, marker string, maxKeys int) objectListPage {
+	var page objectListPage
+	for _, obj := range objects {
+		if obj.Name <= marker {
+			continue
+		}
+		// A marker ending in the delimiter is a common prefix returned by a previous page,
+		// all keys within it have already been rolled up.
+		if delimiter != "" && strings.HasSuffix(marker, delimiter) && strings.HasPrefix(obj.Name, marker) {
+			continue
+		}
+
+		entry := obj.Name
+		isCommonPrefix := false
+		if delimiter != "" {
+			if i := strings.Index(obj.Name[len(prefix):], delimiter); i >= 0 {
+				entry = obj.Name[:len(prefix)+i+len(delimiter)]
+				isCommonPrefix = true
+			}
+		}
+		if isCommonPrefix && len(page.CommonPrefixes) > 0 && page.CommonPrefixes[len(page.CommonPrefixes)-1] == entry {
+			continue
+		}
+
+		if len(page.Objects)+len(page.CommonPrefixes) >= maxKeys {
+			page.IsTruncated = true
+			break
+		}
+		if isCommonPrefix {
+			page.CommonPrefixes = append(page.CommonPrefixes, entry)
+		} else {
+			page.Objects = append(page.Objects, obj)
+		}
+		page.NextMarker = entry
+	}
+	return page
+}
+
 // Returns a bucket-level lock
 //
 // When locked for reading, you have shared access to the bucket, for reading/writing objects to it.
@@ -280,3 +384,8 @@ var metricRunning = promauto.NewGauge(prometheus.GaugeOpts{
 	Name: "blobstore_service_running",
 	Help: "Number of running blobstore requests.",
 })
+
+var metricObjectsExpired = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "blobstore_service_objects_expired_total",
+	Help: "Total number of objects deleted by bucket lifecycle rules.",
+})
@@ -2,9 +2,12 @@ package blobstore_test
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -281,6 +284,195 @@ func TestExpireObjects(t *testing.T) {
 	assertObjectDoesNotExist(ctx, store, t, "foobar2")
 }
 
+type listBucketResult struct {
+	IsTruncated           bool
+	KeyCount              int
+	Contents              []struct{ Key string }
+	CommonPrefixes        []struct{ Prefix string }
+	NextContinuationToken string
+}
+
+func listObjectsV2(t *testing.T, server *httptest.Server, query url.Values) listBucketResult {
+	t.Helper()
+	query.Set("list-type", "2")
+	resp, err := http.Get(server.URL + "/lsif-uploads?" + query.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result listBucketResult
+	require.NoError(t, xml.NewDecoder(resp.Body).Decode(&result))
+	return result
+}
+
+func (r listBucketResult) entries() []string {
+	var entries []string
+	for _, c := range r.CommonPrefixes {
+		entries = append(entries, c.Prefix)
+	}
+	for _, c := range r.Contents {
+		entries = append(entries, c.Key)
+	}
+	return entries
+}
+
+// Initialize uploadstore, upload objects, list them page by page
+func TestListPagination(t *testing.T) {
+	ctx := context.Background()
+	store, server, _ := initTestStore(ctx, t, t.TempDir())
+	defer server.Close()
+
+	for _, key := range []string{"a/1", "a/2", "b", "c/1/x", "c/2", "d"} {
+		if _, err := store.Upload(ctx, key, strings.NewReader("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("continuation tokens", func(t *testing.T) {
+		var pages [][]string
+		query := url.Values{"max-keys": {"4"}}
+		for {
+			result := listObjectsV2(t, server, query)
+			pages = append(pages, result.entries())
+			require.Equal(t, len(result.entries()), result.KeyCount)
+			if !result.IsTruncated {
+				break
+			}
+			query.Set("continuation-token", result.NextContinuationToken)
+		}
+		require.Equal(t, [][]string{{"a/1", "a/2", "b", "c/1/x"}, {"c/2", "d"}}, pages)
+	})
+
+	t.Run("delimiter", func(t *testing.T) {
+		result := listObjectsV2(t, server, url.Values{"delimiter": {"/"}, "max-keys": {"2"}})
+		require.True(t, result.IsTruncated)
+		require.Equal(t, []string{"a/", "b"}, result.entries())
+
+		// Objects in a common prefix returned on the previous page are not returned again.
+		result = listObjectsV2(t, server, url.Values{"delimiter": {"/"}, "continuation-token": {result.NextContinuationToken}})
+		require.False(t, result.IsTruncated)
+		require.Equal(t, []string{"c/", "d"}, result.entries())
+
+		result = listObjectsV2(t, server, url.Values{"delimiter": {"/"}, "prefix": {"c/"}})
+		require.Equal(t, []string{"c/1/", "c/2"}, result.entries())
+	})
+
+	t.Run("start after", func(t *testing.T) {
+		result := listObjectsV2(t, server, url.Values{"start-after": {"b"}})
+		require.Equal(t, []string{"c/1/x", "c/2", "d"}, result.entries())
+	})
+}
+
+// Initialize uploadstore, upload an object, read its metadata and parts of it
+func TestHeadAndRangeGet(t *testing.T) {
+	ctx := context.Background()
+	store, server, _ := initTestStore(ctx, t, t.TempDir())
+	defer server.Close()
+
+	if _, err := store.Upload(ctx, "foobar", strings.NewReader("Hello world!")); err != nil {
+		t.Fatal(err)
+	}
+	objectURL := server.URL + "/lsif-uploads/foobar"
+
+	resp, err := http.Head(objectURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int64(12), resp.ContentLength)
+	require.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	require.NotEmpty(t, resp.Header.Get("ETag"))
+	require.NotEmpty(t, resp.Header.Get("Last-Modified"))
+
+	get := func(byteRange string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, objectURL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Range", byteRange)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("bytes=6-")
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, "bytes 6-11/12", resp.Header.Get("Content-Range"))
+	require.Equal(t, "world!", body)
+
+	resp, body = get("bytes=-6")
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, "world!", body)
+
+	resp, body = get("bytes=12-")
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	require.Contains(t, body, "InvalidRange")
+
+	// The store resumes interrupted downloads with range requests.
+	reader, err := store.Get(ctx, "foobar")
+	require.NoError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "Hello world!", string(data))
+}
+
+// Initialize uploadstore, configure a lifecycle rule, expire objects server-side
+func TestLifecycleExpiration(t *testing.T) {
+	ctx := context.Background()
+	store, server, svc := initTestStore(ctx, t, t.TempDir())
+	defer server.Close()
+
+	lifecycleURL := server.URL + "/lsif-uploads?lifecycle"
+	do := func(method, body string) (*http.Response, string) {
+		req, err := http.NewRequest(method, lifecycleURL, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(respBody)
+	}
+
+	resp, body := do(http.MethodGet, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Contains(t, body, "NoSuchLifecycleConfiguration")
+
+	resp, body = do(http.MethodPut, `<LifecycleConfiguration><Rule><ID>dates</ID><Status>Enabled</Status><Expiration><Date>2024-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Contains(t, body, "MalformedXML")
+
+	resp, _ = do(http.MethodPut, `<LifecycleConfiguration><Rule><ID>uploads</ID><Filter><Prefix>upload-</Prefix></Filter><Status>Enabled</Status><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body = do(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, body, "<Prefix>upload-</Prefix>")
+
+	for _, key := range []string{"upload-old", "upload-new", "other-old"} {
+		if _, err := store.Upload(ctx, key, strings.NewReader("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc.MockObjectAge = map[string]time.Time{
+		"upload-old": time.Now().Add(-8 * 24 * time.Hour),
+		"upload-new": time.Now().Add(-6 * 24 * time.Hour),
+		"other-old":  time.Now().Add(-8 * 24 * time.Hour),
+	}
+
+	require.NoError(t, svc.ExpireObjects(ctx))
+	assertObjectDoesNotExist(ctx, store, t, "upload-old")
+	require.Equal(t, []string{"other-old", "upload-new"}, listObjectsV2(t, server, url.Values{}).entries())
+
+	// Without a lifecycle configuration, nothing expires.
+	resp, _ = do(http.MethodDelete, "")
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	svc.MockObjectAge["upload-new"] = time.Now().Add(-8 * 24 * time.Hour)
+	require.NoError(t, svc.ExpireObjects(ctx))
+	require.Equal(t, []string{"other-old", "upload-new"}, listObjectsV2(t, server, url.Values{}).entries())
+}
+
 func initTestStore(ctx context.Context, t *testing.T, dataDir string) (object.Storage, *httptest.Server, *blobstore.Service) {
 	observationCtx := observation.TestContextTB(t)
 	svc := &blobstore.Service{
@@ -0,0 +1,218 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	ErrNoSuchLifecycleConfiguration = errors.New("no lifecycle configuration")
+	ErrMalformedLifecycle           = errors.New("malformed lifecycle configuration")
+)
+
+// Bucket lifecycle configurations are stored next to (not in) the bucket directories, so that
+// they never show up as objects.
+func (s *Service) lifecycleFilePath(bucketName string) string {
+	return filepath.Join(s.DataDir, "lifecycle", bucketName+".xml")
+}
+
+// validateLifecycleConfiguration checks that every rule only uses features we support: rules
+// may match a key prefix, and may only expire objects after a number of days.
+func validateLifecycleConfiguration(config *s3LifecycleConfiguration) error {
+	if len(config.Rule) == 0 {
+		return errors.Wrap(ErrMalformedLifecycle, "at least one rule is required")
+	}
+	for _, rule := range config.Rule {
+		if rule.Status != "Enabled" && rule.Status != "Disabled" {
+			return errors.Wrapf(ErrMalformedLifecycle, "rule %q: status must be Enabled or Disabled", rule.ID)
+		}
+		if rule.Expiration != nil && rule.Expiration.Date != "" {
+			return errors.Wrapf(ErrMalformedLifecycle, "rule %q: expiration dates are not supported", rule.ID)
+		}
+		if rule.Expiration == nil || rule.Expiration.Days <= 0 {
+			return errors.Wrapf(ErrMalformedLifecycle, "rule %q: expiration days must be a positive integer", rule.ID)
+		}
+		if rule.Filter != nil && (rule.Filter.And != nil || rule.Filter.Tag != nil) {
+			return errors.Wrapf(ErrMalformedLifecycle, "rule %q: only prefix filters are supported", rule.ID)
+		}
+	}
+	return nil
+}
+
+func (s *Service) putLifecycleConfiguration(ctx context.Context, bucketName string, config *s3LifecycleConfiguration) error {
+	_ = ctx
+
+	if err := validateLifecycleConfiguration(config); err != nil {
+		return err
+	}
+
+	// Ensure the bucket cannot be created/deleted while we look at it.
+	bucketLock := s.bucketLock(bucketName)
+	bucketLock.RLock()
+	defer bucketLock.RUnlock()
+
+	if _, err := os.Stat(s.bucketDir(bucketName)); err != nil {
+		return ErrNoSuchBucket
+	}
+
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).Encode(config); err != nil {
+		return errors.Wrap(err, "Encode")
+	}
+
+	// Like objects, the configuration is replaced with an atomic rename.
+	lifecycleDir := filepath.Dir(s.lifecycleFilePath(bucketName))
+	if err := os.MkdirAll(lifecycleDir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "MkdirAll")
+	}
+	tmpFile, err := os.CreateTemp(lifecycleDir, "*-"+bucketName+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating tmp file")
+	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+	if _, err := io.Copy(tmpFile, &buf); err != nil {
+		return errors.Wrap(err, "writing tmp file")
+	}
+	if err := tmpFile.Sync(); err != nil {
+		return errors.Wrap(err, "sync tmp file")
+	}
+	tmpFile.Close()
+	if err := os.Rename(tmpFile.Name(), s.lifecycleFilePath(bucketName)); err != nil {
+		return errors.Wrap(err, "renaming lifecycle file")
+	}
+	if err := fsync(lifecycleDir); err != nil {
+		return errors.Wrap(err, "sync lifecycle dir")
+	}
+	s.Log.Debug("put lifecycle configuration", sglog.String("bucket", bucketName), sglog.Int("rules", len(config.Rule)))
+	return nil
+}
+
+func (s *Service) getLifecycleConfiguration(ctx context.Context, bucketName string) (*s3LifecycleConfiguration, error) {
+	_ = ctx
+
+	// Ensure the bucket cannot be created/deleted while we look at it.
+	bucketLock := s.bucketLock(bucketName)
+	bucketLock.RLock()
+	defer bucketLock.RUnlock()
+
+	if _, err := os.Stat(s.bucketDir(bucketName)); err != nil {
+		return nil, ErrNoSuchBucket
+	}
+
+	f, err := os.Open(s.lifecycleFilePath(bucketName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSuchLifecycleConfiguration
+		}
+		return nil, errors.Wrap(err, "Open")
+	}
+	defer f.Close()
+
+	var config s3LifecycleConfiguration
+	if err := xml.NewDecoder(f).Decode(&config); err != nil {
+		return nil, errors.Wrap(err, "Decode")
+	}
+	return &config, nil
+}
+
+func (s *Service) deleteLifecycleConfiguration(ctx context.Context, bucketName string) error {
+	_ = ctx
+
+	// Ensure the bucket cannot be created/deleted while we look at it.
+	bucketLock := s.bucketLock(bucketName)
+	bucketLock.RLock()
+	defer bucketLock.RUnlock()
+
+	if _, err := os.Stat(s.bucketDir(bucketName)); err != nil {
+		return ErrNoSuchBucket
+	}
+	if err := os.Remove(s.lifecycleFilePath(bucketName)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "Remove")
+	}
+	s.Log.Debug("delete lifecycle configuration", sglog.String("bucket", bucketName))
+	return nil
+}
+
+// ExpireObjects deletes all objects which have outlived the expiration of an enabled lifecycle
+// rule of their bucket.
+func (s *Service) ExpireObjects(ctx context.Context) error {
+	s.init()
+
+	entries, err := os.ReadDir(filepath.Join(s.DataDir, "buckets"))
+	if err != nil {
+		return errors.Wrap(err, "ReadDir")
+	}
+
+	var errs error
+	for _, entry := range entries {
+		bucketName := entry.Name()
+		if !entry.IsDir() || strings.HasSuffix(bucketName, multipartUploadsBucketSuffix) {
+			continue
+		}
+
+		config, err := s.getLifecycleConfiguration(ctx, bucketName)
+		if err != nil {
+			if err == ErrNoSuchLifecycleConfiguration || err == ErrNoSuchBucket {
+				continue
+			}
+			errs = errors.Append(errs, errors.Wrapf(err, "bucket %q", bucketName))
+			continue
+		}
+		if err := s.expireBucketObjects(ctx, bucketName, config); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "bucket %q", bucketName))
+		}
+	}
+	return errs
+}
+
+func (s *Service) expireBucketObjects(ctx context.Context, bucketName string, config *s3LifecycleConfiguration) error {
+	now := time.Now()
+	for _, rule := range config.Rule {
+		if rule.Status != "Enabled" || rule.Expiration == nil {
+			continue
+		}
+
+		objects, err := s.listObjects(ctx, bucketName, rule.prefix())
+		if err != nil {
+			return errors.Wrap(err, "listObjects")
+		}
+		expireBefore := now.Add(-time.Duration(rule.Expiration.Days) * 24 * time.Hour)
+		for _, obj := range objects {
+			if !obj.LastModified.Before(expireBefore) {
+				continue
+			}
+			if err := s.deleteObject(ctx, bucketName, obj.Name); err != nil && err != ErrNoSuchKey {
+				return errors.Wrap(err, "deleteObject")
+			}
+			metricObjectsExpired.Inc()
+			s.Log.Debug("expired object", sglog.String("key", bucketName+"/"+obj.Name), sglog.String("rule", rule.ID))
+		}
+	}
+	return nil
+}
+
+// NewLifecycleExpirer returns a background routine which periodically applies the lifecycle
+// rules of all buckets.
+func (s *Service) NewLifecycleExpirer(ctx context.Context, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(s.ExpireObjects),
+		goroutine.WithName("blobstore.lifecycle-expirer"),
+		goroutine.WithDescription("deletes objects which have expired according to their bucket lifecycle rules"),
+		goroutine.WithInterval(interval),
+	)
+}
@@ -1,8 +1,8 @@
 package blobstore
 
 import (
+	"encoding/base64"
 	"encoding/xml"
-	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -19,23 +19,38 @@ import (
 
 // serveS3 serves an S3-compatible HTTP API.
 func (s *Service) serveS3(w http.ResponseWriter, r *http.Request) error {
-	path := strings.FieldsFunc(r.URL.Path, func(r rune) bool { return r == '/' })
-	switch len(path) {
-	case 1:
-		bucketName := path[0]
+	// Object keys may contain slashes, so only the first path component names the bucket.
+	bucketName, objectName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case bucketName == "":
+	case objectName == "":
 		switch r.Method {
 		case "GET":
+			if r.URL.Query().Has("lifecycle") {
+				return s.serveGetBucketLifecycleConfiguration(w, r, bucketName)
+			}
+			if r.URL.Query().Has("versioning") {
+				return s.serveGetBucketVersioning(w, r, bucketName)
+			}
 			return s.serveListObjectsV2(w, r, bucketName)
 		case "PUT":
+			if r.URL.Query().Has("lifecycle") {
+				return s.servePutBucketLifecycleConfiguration(w, r, bucketName)
+			}
+			if r.URL.Query().Has("versioning") {
+				return s.servePutBucketVersioning(w, r, bucketName)
+			}
 			return s.serveCreateBucket(w, r, bucketName)
 		case "POST":
 			if r.URL.Query().Has("delete") {
 				return s.serveDeleteObjects(w, r, bucketName)
 			}
+		case "DELETE":
+			if r.URL.Query().Has("lifecycle") {
+				return s.serveDeleteBucketLifecycle(w, r, bucketName)
+			}
 		}
-	case 2:
-		bucketName := path[0]
-		objectName := path[1]
+	default:
 		switch r.Method {
 		case "HEAD":
 			return s.serveHeadObject(w, r, bucketName, objectName)
@@ -67,25 +82,147 @@ func (s *Service) serveS3(w http.ResponseWriter, r *http.Request) error {
 // GET /<bucket>
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectsV2.html
 func (s *Service) serveListObjectsV2(w http.ResponseWriter, r *http.Request, bucketName string) error {
-	prefix := r.URL.Query().Get("prefix")
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+	startAfter := query.Get("start-after")
+	continuationToken := query.Get("continuation-token")
+
+	maxKeys := maxListKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return writeS3Error(w, s3ErrorInvalidArgument, bucketName, errors.New("max-keys must be a non-negative integer"), http.StatusBadRequest)
+		}
+		maxKeys = min(n, maxListKeys)
+	}
+
+	// Listing resumes after the continuation token if one is given, start-after is only
+	// respected on the first page.
+	marker := startAfter
+	if query.Has("continuation-token") {
+		decoded, err := base64.StdEncoding.DecodeString(continuationToken)
+		if err != nil {
+			return writeS3Error(w, s3ErrorInvalidArgument, bucketName, errors.New("the continuation token provided is incorrect"), http.StatusBadRequest)
+		}
+		marker = string(decoded)
+	}
 
-	var contents []s3Object
 	objects, err := s.listObjects(r.Context(), bucketName, prefix)
 	if err != nil {
 		return writeS3Error(w, s3ErrorNoSuchBucket, bucketName, err, http.StatusConflict)
 	}
-	for _, obj := range objects {
+	page := paginateObjects(objects, prefix, delimiter, marker, maxKeys)
+
+	var contents []s3Object
+	for _, obj := range page.Objects {
 		contents = append(contents, s3Object{
 			Key:          obj.Name,
 			LastModified: obj.LastModified.Format(time.RFC3339Nano),
+			ETag:         obj.etag(),
+			Size:         obj.Size,
+			StorageClass: "STANDARD",
 		})
 	}
-	return writeXML(w, http.StatusOK, s3ListBucketResult{
-		Name:        bucketName,
-		KeyCount:    len(contents),
-		IsTruncated: false,
-		Contents:    contents,
-	})
+	var commonPrefixes []s3CommonPrefix
+	for _, commonPrefix := range page.CommonPrefixes {
+		commonPrefixes = append(commonPrefixes, s3CommonPrefix{Prefix: commonPrefix})
+	}
+	result := s3ListBucketResult{
+		Name:              bucketName,
+		Prefix:            prefix,
+		Delimiter:         delimiter,
+		MaxKeys:           maxKeys,
+		KeyCount:          len(contents) + len(commonPrefixes),
+		IsTruncated:       page.IsTruncated,
+		Contents:          contents,
+		CommonPrefixes:    commonPrefixes,
+		ContinuationToken: continuationToken,
+		StartAfter:        startAfter,
+	}
+	if page.IsTruncated {
+		result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(page.NextMarker))
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+// maxListKeys is the maximum (and default) number of keys returned by a single ListObjectsV2 request.
+const maxListKeys = 1000
+
+// PUT /<bucket>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+func (s *Service) servePutBucketLifecycleConfiguration(w http.ResponseWriter, r *http.Request, bucketName string) error {
+	var config s3LifecycleConfiguration
+	defer r.Body.Close()
+	if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+		return writeS3Error(w, s3ErrorMalformedXML, bucketName, err, http.StatusBadRequest)
+	}
+	if err := s.putLifecycleConfiguration(r.Context(), bucketName, &config); err != nil {
+		if err == ErrNoSuchBucket {
+			return writeS3Error(w, s3ErrorNoSuchBucket, bucketName, err, http.StatusNotFound)
+		}
+		if errors.Is(err, ErrMalformedLifecycle) {
+			return writeS3Error(w, s3ErrorMalformedXML, bucketName, err, http.StatusBadRequest)
+		}
+		return errors.Wrap(err, "putLifecycleConfiguration")
+	}
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// GET /<bucket>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+func (s *Service) serveGetBucketLifecycleConfiguration(w http.ResponseWriter, r *http.Request, bucketName string) error {
+	config, err := s.getLifecycleConfiguration(r.Context(), bucketName)
+	if err != nil {
+		if err == ErrNoSuchBucket {
+			return writeS3Error(w, s3ErrorNoSuchBucket, bucketName, err, http.StatusNotFound)
+		}
+		if err == ErrNoSuchLifecycleConfiguration {
+			return writeS3Error(w, s3ErrorNoSuchLifecycleConfiguration, bucketName, err, http.StatusNotFound)
+		}
+		return errors.Wrap(err, "getLifecycleConfiguration")
+	}
+	return writeXML(w, http.StatusOK, config)
+}
+
+// DELETE /<bucket>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html
+func (s *Service) serveDeleteBucketLifecycle(w http.ResponseWriter, r *http.Request, bucketName string) error {
+	if err := s.deleteLifecycleConfiguration(r.Context(), bucketName); err != nil {
+		if err == ErrNoSuchBucket {
+			return writeS3Error(w, s3ErrorNoSuchBucket, bucketName, err, http.StatusNotFound)
+		}
+		return errors.Wrap(err, "deleteLifecycleConfiguration")
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// GET /<bucket>?versioning
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketVersioning.html
+func (s *Service) serveGetBucketVersioning(w http.ResponseWriter, r *http.Request, bucketName string) error {
+	if !s.bucketExists(r.Context(), bucketName) {
+		return writeS3Error(w, s3ErrorNoSuchBucket, bucketName, ErrNoSuchBucket, http.StatusNotFound)
+	}
+	// Buckets are never versioned, which S3 reports as an empty configuration.
+	return writeXML(w, http.StatusOK, s3VersioningConfiguration{})
+}
+
+// PUT /<bucket>?versioning
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketVersioning.html
+func (s *Service) servePutBucketVersioning(w http.ResponseWriter, r *http.Request, bucketName string) error {
+	var config s3VersioningConfiguration
+	defer r.Body.Close()
+	if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+		return writeS3Error(w, s3ErrorMalformedXML, bucketName, err, http.StatusBadRequest)
+	}
+	// Suspending versioning on a bucket that was never versioned is a no-op.
+	if config.Status == "Suspended" {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	return writeS3Error(w, s3ErrorNotImplemented, bucketName, errors.New("object versioning is not supported"), http.StatusNotImplemented)
 }
 
 // PUT /<bucket>
@@ -107,44 +244,82 @@ func (s *Service) serveCreateBucket(w http.ResponseWriter, r *http.Request, buck
 // HEAD /<bucket>/<object>
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadObject.html
 func (s *Service) serveHeadObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string) error {
-	// TODO(blobstore): HEAD should not need to actually read the entire file, implement this with os.Stat
-	reader, err := s.getObject(r.Context(), bucketName, objectName)
+	metadata, err := s.statObject(r.Context(), bucketName, objectName)
 	if err != nil {
 		if err == ErrNoSuchKey {
 			return writeS3Error(w, s3ErrorNoSuchKey, bucketName, err, http.StatusNotFound)
 		}
-		return errors.Wrap(err, "getObject")
+		return errors.Wrap(err, "statObject")
 	}
-	defer reader.Close()
-	var numBytes int
-	for {
-		var buf [1024 * 10]byte
-		n, err := reader.Read(buf[:])
-		numBytes += n
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return errors.Wrap(err, "Read")
-		}
-	}
-	w.Header().Set("Content-Length", strconv.Itoa(numBytes))
+	setObjectHeaders(w, metadata)
+	w.Header().Set("Content-Length", strconv.FormatInt(metadata.Size, 10))
+	w.WriteHeader(http.StatusOK)
 	return nil
 }
 
 // GET /<bucket>/<object>
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObject.html
 func (s *Service) serveGetObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string) error {
-	reader, err := s.getObject(r.Context(), bucketName, objectName)
+	f, err := s.getObject(r.Context(), bucketName, objectName)
 	if err != nil {
 		if err == ErrNoSuchKey {
 			return writeS3Error(w, s3ErrorNoSuchKey, bucketName, err, http.StatusNotFound)
 		}
 		return errors.Wrap(err, "getObject")
 	}
-	defer reader.Close()
-	_, err = io.Copy(w, reader)
-	return errors.Wrap(err, "Copy")
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "Stat")
+	}
+	metadata := s.newObjectMetadata(objectName, info)
+
+	// Unlike http.ServeContent, S3 responds with an XML error if the range can't be satisfied.
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" && !isSatisfiableRange(rangeHeader, metadata.Size) {
+		w.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(metadata.Size, 10))
+		return writeS3Error(w, s3ErrorInvalidRange, bucketName, errors.New("the requested range is not satisfiable"), http.StatusRequestedRangeNotSatisfiable)
+	}
+
+	// http.ServeContent handles Range and conditional (If-Match, If-None-Match etc.) requests
+	// according to the headers we set here.
+	setObjectHeaders(w, metadata)
+	http.ServeContent(w, r, "", metadata.LastModified, f)
+	return nil
+}
+
+// setObjectHeaders sets the response headers which HeadObject and GetObject have in common.
+func setObjectHeaders(w http.ResponseWriter, metadata *objectMetadata) {
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("Content-Type", "binary/octet-stream")
+	w.Header().Set("ETag", metadata.etag())
+	w.Header().Set("Last-Modified", metadata.LastModified.UTC().Format(http.TimeFormat))
+}
+
+// isSatisfiableRange reports whether at least one range in the "bytes=" Range header overlaps an
+// object of the given size. Headers we don't understand are considered satisfiable, leaving
+// their handling to http.ServeContent.
+func isSatisfiableRange(header string, size int64) bool {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return true
+	}
+	for _, r := range strings.Split(spec, ",") {
+		start, end, ok := strings.Cut(strings.TrimSpace(r), "-")
+		if !ok {
+			return true
+		}
+		if start == "" {
+			// A suffix range, e.g. "bytes=-500", is satisfiable unless it's empty.
+			if n, err := strconv.ParseInt(end, 10, 64); err != nil || n != 0 {
+				return true
+			}
+			continue
+		}
+		if n, err := strconv.ParseInt(start, 10, 64); err != nil || n < size {
+			return true
+		}
+	}
+	return false
 }
 
 // PUT /<bucket>/<object>?uploadId=foobar&partNumber=123
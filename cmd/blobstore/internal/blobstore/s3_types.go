@@ -13,6 +13,12 @@ var (
 	s3ErrorNoSuchKey               = "NoSuchKey"
 	s3ErrorNoSuchUpload            = "NoSuchUpload"
 	s3ErrorInvalidPartOrder        = "InvalidPartOrder"
+	s3ErrorInvalidArgument         = "InvalidArgument"
+	s3ErrorInvalidRange            = "InvalidRange"
+	s3ErrorMalformedXML            = "MalformedXML"
+	s3ErrorNotImplemented          = "NotImplemented"
+
+	s3ErrorNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
 )
 
 type s3Error struct {
@@ -66,23 +72,29 @@ type s3Object struct {
 	XMLName      xml.Name `xml:"Contents"`
 	Key          string
 	LastModified string
+	ETag         string
 	Owner        s3ObjectOwner
-	Size         int
+	Size         int64
 	StorageClass string
 }
 
+type s3CommonPrefix struct {
+	Prefix string
+}
+
 type s3ListBucketResult struct {
 	XMLName               xml.Name `xml:"ListBucketResult"`
 	IsTruncated           bool
 	Name                  string
 	Prefix                string
-	Delimiter             string
+	Delimiter             string `xml:",omitempty"`
 	MaxKeys               int
 	KeyCount              int
 	Contents              []s3Object
-	ContinuationToken     string
-	NextContinuationToken string
-	StartAfter            string
+	CommonPrefixes        []s3CommonPrefix
+	ContinuationToken     string `xml:",omitempty"`
+	NextContinuationToken string `xml:",omitempty"`
+	StartAfter            string `xml:",omitempty"`
 }
 
 type s3ObjectIdentifier struct {
@@ -97,6 +109,46 @@ type s3DeleteObjectsRequest struct {
 	Quiet   bool
 }
 
+// s3LifecycleConfiguration is the subset of bucket lifecycle configuration that we support:
+// rules which expire objects, optionally filtered by a key prefix, after a number of days.
+type s3LifecycleConfiguration struct {
+	XMLName xml.Name `xml:"LifecycleConfiguration"`
+	Rule    []s3LifecycleRule
+}
+
+type s3LifecycleRule struct {
+	ID         string                 `xml:",omitempty"`
+	Filter     *s3LifecycleRuleFilter `xml:",omitempty"`
+	Prefix     string                 `xml:",omitempty"` // deprecated in favor of Filter, but still sent by some clients
+	Status     string
+	Expiration *s3LifecycleExpiration `xml:",omitempty"`
+}
+
+func (r *s3LifecycleRule) prefix() string {
+	if r.Filter != nil {
+		return r.Filter.Prefix
+	}
+	return r.Prefix
+}
+
+type s3LifecycleRuleFilter struct {
+	Prefix string
+	// And and Tag are only decoded to reject them, we don't support object tags.
+	And *struct{} `xml:",omitempty"`
+	Tag *struct{} `xml:",omitempty"`
+}
+
+type s3LifecycleExpiration struct {
+	Days int    `xml:",omitempty"`
+	Date string `xml:",omitempty"`
+}
+
+type s3VersioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	// Status is empty if versioning has never been enabled on the bucket.
+	Status string `xml:",omitempty"`
+}
+
 func writeS3Error(w http.ResponseWriter, code, bucketName string, err error, statusCode int) error {
 	return writeXML(w, statusCode,
 		s3Error{Code: code},
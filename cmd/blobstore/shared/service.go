@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -26,11 +27,13 @@ var Service service.Service = svc{}
 type Config struct {
 	env.BaseConfig
 
-	DataDir string
+	DataDir           string
+	LifecycleInterval time.Duration
 }
 
 func (c *Config) Load() {
 	c.DataDir = c.Get("BLOBSTORE_DATA_DIR", "/data", "directory to store blobstore buckets and objects")
+	c.LifecycleInterval = c.GetInterval("BLOBSTORE_LIFECYCLE_INTERVAL", "1h", "How frequently to delete objects that have expired according to bucket lifecycle rules.")
 }
 
 func LoadConfig() *Config {
@@ -108,5 +108,10 @@ func Start(ctx context.Context, observationCtx *observation.Context, config *Con
 		return shutdownOnSignal(ctx, server)
 	})
 
+	// Apply bucket lifecycle rules
+	g.Go(func() error {
+		return goroutine.MonitorBackgroundRoutines(ctx, bsService.NewLifecycleExpirer(ctx, config.LifecycleInterval))
+	})
+
 	return g.Wait()
 }
@@ -24,7 +24,10 @@ type Client struct {
 }
 
 // Compile time validation.
-var _ files.Store = &Client{}
+var (
+	_ files.Store      = &Client{}
+	_ files.CacheStore = &Client{}
+)
 
 // New creates a new Client based on the provided Options.
 func New(observationCtx *observation.Context, options apiclient.BaseClientOptions) (*Client, error) {
@@ -53,11 +56,8 @@ func (c *Client) Exists(ctx context.Context, job types.Job, bucket string, key s
 	}
 
 	if err = c.client.DoAndDrop(ctx, req); err != nil {
-		var unexpectedStatusCodeError *apiclient.UnexpectedStatusCodeErr
-		if errors.As(err, &unexpectedStatusCodeError) {
-			if unexpectedStatusCodeError.StatusCode == http.StatusNotFound {
-				return false, nil
-			}
+		if isNotFound(err) {
+			return false, nil
 		}
 		return false, err
 	}
@@ -82,3 +82,90 @@ func (c *Client) Get(ctx context.Context, job types.Job, bucket string, key stri
 	}
 	return body, nil
 }
+
+func (c *Client) CacheExists(ctx context.Context, job types.Job, key string) (exists bool, err error) {
+	ctx, _, endObservation := c.operations.cacheExists.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queue", job.Queue),
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := cachePath(job, key)
+	if err != nil {
+		return false, err
+	}
+	req, err := c.client.NewRequest(job.ID, job.Token, http.MethodHead, path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if err = c.client.DoAndDrop(ctx, req); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) GetCache(ctx context.Context, job types.Job, key string) (content io.ReadCloser, found bool, err error) {
+	ctx, _, endObservation := c.operations.getCache.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queue", job.Queue),
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := cachePath(job, key)
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := c.client.NewRequest(job.ID, job.Token, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, body, err := c.client.Do(ctx, req)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+func (c *Client) PutCache(ctx context.Context, job types.Job, key string, content io.Reader) (err error) {
+	ctx, _, endObservation := c.operations.putCache.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queue", job.Queue),
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := cachePath(job, key)
+	if err != nil {
+		return err
+	}
+	req, err := c.client.NewRequest(job.ID, job.Token, http.MethodPut, path, content)
+	if err != nil {
+		return err
+	}
+
+	return c.client.DoAndDrop(ctx, req)
+}
+
+// cachePath returns the path of the cache entry. The frontend further scopes the entry to
+// the namespace and repository of the job, which are looked up from the job token.
+func cachePath(job types.Job, key string) (string, error) {
+	if job.Queue == "" {
+		return "", errors.New("job has no queue")
+	}
+	if err := types.ValidateCacheKey(key); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cache/%s/%s", job.Queue, key), nil
+}
+
+func isNotFound(err error) bool {
+	var unexpectedStatusCodeError *apiclient.UnexpectedStatusCodeErr
+	return errors.As(err, &unexpectedStatusCodeError) && unexpectedStatusCodeError.StatusCode == http.StatusNotFound
+}
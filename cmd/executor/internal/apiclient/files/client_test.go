@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -242,3 +243,67 @@ func TestClient_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Cache(t *testing.T) {
+	observationContext := observation.TestContextTB(t)
+
+	entries := map[string]string{"/.executors/files/cache/codeintel/go-mod": "archive"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sometoken", r.Header.Get("Authorization"))
+		assert.Equal(t, "42", r.Header.Get("X-Sourcegraph-Job-ID"))
+
+		switch r.Method {
+		case http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			entries[r.URL.Path] = string(content)
+		case http.MethodHead, http.MethodGet:
+			content, ok := entries[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(content))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := files.New(observationContext, apiclient.BaseClientOptions{
+		ExecutorName: "test-executor",
+		EndpointOptions: apiclient.EndpointOptions{
+			URL:        srv.URL,
+			PathPrefix: "/.executors/files",
+		},
+	})
+	require.NoError(t, err)
+
+	job := types.Job{ID: 42, Queue: "codeintel", Token: "sometoken"}
+
+	exists, err := client.CacheExists(context.Background(), job, "go-mod")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CacheExists(context.Background(), job, "node-modules")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, found, err := client.GetCache(context.Background(), job, "node-modules")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, client.PutCache(context.Background(), job, "node-modules", strings.NewReader("other archive")))
+
+	content, found, err := client.GetCache(context.Background(), job, "node-modules")
+	require.NoError(t, err)
+	require.True(t, found)
+	defer content.Close()
+	actualBytes, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "other archive", string(actualBytes))
+
+	// Entries are scoped to a queue and keys are used in the path as-is.
+	_, err = client.CacheExists(context.Background(), types.Job{ID: 42}, "go-mod")
+	assert.Error(t, err)
+	_, err = client.CacheExists(context.Background(), job, "../go-mod")
+	assert.Error(t, err)
+}
@@ -10,6 +10,10 @@ import (
 type operations struct {
 	exists *observation.Operation
 	get    *observation.Operation
+
+	cacheExists *observation.Operation
+	getCache    *observation.Operation
+	putCache    *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -31,5 +35,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		exists: op("Exists"),
 		get:    op("Get"),
+
+		cacheExists: op("CacheExists"),
+		getCache:    op("GetCache"),
+		putCache:    op("PutCache"),
 	}
 }
@@ -40,6 +40,8 @@ type Config struct {
 	KeepWorkspaces                                 bool
 	DockerHostMountPath                            string
 	UseFirecracker                                 bool
+	UsePodman                                      bool
+	PodmanNetwork                                  string
 	JobNumCPUs                                     int
 	JobMemory                                      string
 	FirecrackerDiskSpace                           string
@@ -119,7 +121,9 @@ func (c *Config) Load() {
 	c.QueueNamesStr = c.GetOptional("EXECUTOR_QUEUE_NAMES", "The names of multiple queues to listen to, comma-separated.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
-	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes()), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
+	c.UsePodman = c.GetBool("EXECUTOR_USE_PODMAN", "false", "Whether to run commands in rootless Podman containers instead of Docker containers. Requires podman. Linux hosts only. Cannot be combined with Firecracker.")
+	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes() && !c.UsePodman), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
+	c.PodmanNetwork = c.GetOptional("EXECUTOR_PODMAN_NETWORK", "The network to connect Podman containers to. Use 'none' to run jobs without network access. Defaults to the Podman default network.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -333,6 +337,18 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UsePodman {
+		if runtime.GOOS != "linux" {
+			c.AddError(errors.New("EXECUTOR_USE_PODMAN is only supported on linux hosts."))
+		}
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_PODMAN and EXECUTOR_USE_FIRECRACKER cannot both be enabled"))
+		}
+		if IsKubernetes() {
+			c.AddError(errors.New("EXECUTOR_USE_PODMAN is not supported in Kubernetes"))
+		}
+	}
+
 	if len(c.KubernetesNodeSelector) > 0 {
 		nodeSelectorValues := strings.Split(c.KubernetesNodeSelector, ",")
 		for _, value := range nodeSelectorValues {
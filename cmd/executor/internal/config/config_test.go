@@ -90,6 +90,7 @@ func TestConfig_Load(t *testing.T) {
 	assert.Equal(t, 10*time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 10, cfg.MaximumNumJobs)
 	assert.True(t, cfg.UseFirecracker)
+	assert.Equal(t, "EXECUTOR_PODMAN_NETWORK", cfg.PodmanNetwork)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_IMAGE", cfg.FirecrackerImage)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_KERNEL_IMAGE", cfg.FirecrackerKernelImage)
 	assert.Equal(t, "EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", cfg.FirecrackerSandboxImage)
@@ -200,6 +201,8 @@ func TestConfig_Load_Defaults(t *testing.T) {
 	assert.Empty(t, cfg.QueueNamesStr)
 	assert.Equal(t, time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 1, cfg.MaximumNumJobs)
+	assert.False(t, cfg.UsePodman)
+	assert.Empty(t, cfg.PodmanNetwork)
 	assert.Equal(t, "sourcegraph/executor-vm:insiders", cfg.FirecrackerImage)
 	assert.Equal(t, "sourcegraph/ignite-kernel:5.10.135-amd64", cfg.FirecrackerKernelImage)
 	assert.Equal(t, "sourcegraph/ignite:v0.10.5", cfg.FirecrackerSandboxImage)
@@ -245,6 +248,26 @@ func TestConfig_Load_Defaults(t *testing.T) {
 	assert.Empty(t, cfg.KubernetesImagePullSecrets)
 }
 
+func TestConfig_Load_Podman(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SetMockGetter(func(name, defaultValue, description string) string {
+		switch name {
+		case "EXECUTOR_USE_PODMAN":
+			return "true"
+		case "EXECUTOR_PODMAN_NETWORK":
+			return "none"
+		default:
+			return defaultValue
+		}
+	})
+	cfg.Load()
+
+	assert.True(t, cfg.UsePodman)
+	assert.Equal(t, "none", cfg.PodmanNetwork)
+	// Podman replaces Firecracker, so it isn't enabled by default anymore.
+	assert.False(t, cfg.UseFirecracker)
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
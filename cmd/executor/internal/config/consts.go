@@ -71,6 +71,13 @@ var (
 		"git":    "Use your package manager, or build from source.",
 		"src":    "Run executor install src-cli, or refer to https://github.com/sourcegraph/src-cli to install src-cli yourself.",
 	}
+	// RequiredCLIToolsPodman contains all the programs that are expected to exist in
+	// PATH when running the executor with rootless Podman instead of Docker.
+	RequiredCLIToolsPodman = map[string]string{
+		"podman": "Use your package manager, or check out https://podman.io/docs/installation on how to install.",
+		"git":    RequiredCLITools["git"],
+		"src":    RequiredCLITools["src"],
+	}
 	// RequiredCLIToolsFirecracker contains all the programs that are expected to
 	// exist in PATH when running the executor with firecracker enabled.
 	RequiredCLIToolsFirecracker = []string{"dmsetup", "losetup", "mkfs.ext4", "strings"}
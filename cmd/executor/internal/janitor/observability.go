@@ -7,8 +7,9 @@ import (
 )
 
 type metrics struct {
-	numVMsRemoved prometheus.Counter
-	numErrors     prometheus.Counter
+	numVMsRemoved        prometheus.Counter
+	numContainersRemoved prometheus.Counter
+	numErrors            prometheus.Counter
 }
 
 var NewMetrics = newMetrics
@@ -28,13 +29,18 @@ func newMetrics(observationCtx *observation.Context) *metrics {
 		"src_executor_orphaned_vms_removed_total",
 		"The number of orphaned virtual machines removed from the host.",
 	)
+	numContainersRemoved := counter(
+		"src_executor_orphaned_containers_removed_total",
+		"The number of orphaned podman containers removed from the host.",
+	)
 	numErrors := counter(
 		"src_executor_janitor_errors_total",
 		"The number of errors that occur during the janitor job.",
 	)
 
 	return &metrics{
-		numVMsRemoved: numVMsRemoved,
-		numErrors:     numErrors,
+		numVMsRemoved:        numVMsRemoved,
+		numContainersRemoved: numContainersRemoved,
+		numErrors:            numErrors,
 	}
 }
@@ -0,0 +1,115 @@
+package janitor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/podman"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type orphanedContainerJanitor struct {
+	logger    log.Logger
+	prefix    string
+	names     *NameSet
+	metrics   *metrics
+	cmdRunner util.CmdRunner
+}
+
+var (
+	_ goroutine.Handler      = &orphanedContainerJanitor{}
+	_ goroutine.ErrorHandler = &orphanedContainerJanitor{}
+)
+
+// NewOrphanedContainerJanitor returns a background routine that periodically removes all
+// podman job containers on the host that do not belong to a job known by the worker running
+// within this executor instance.
+func NewOrphanedContainerJanitor(
+	logger log.Logger,
+	prefix string,
+	names *NameSet,
+	interval time.Duration,
+	metrics *metrics,
+	cmdRunner util.CmdRunner,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		newOrphanedContainerJanitor(
+			logger,
+			prefix,
+			names,
+			metrics,
+			cmdRunner,
+		),
+		goroutine.WithName("executors.orphaned-container-janitor"),
+		goroutine.WithDescription("deletes podman containers from a previous executor instance"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+func newOrphanedContainerJanitor(
+	logger log.Logger,
+	prefix string,
+	names *NameSet,
+	metrics *metrics,
+	cmdRunner util.CmdRunner,
+) *orphanedContainerJanitor {
+	return &orphanedContainerJanitor{
+		logger:    logger,
+		prefix:    prefix,
+		names:     names,
+		metrics:   metrics,
+		cmdRunner: cmdRunner,
+	}
+}
+
+func (j *orphanedContainerJanitor) Handle(ctx context.Context) (err error) {
+	containersByID, err := podman.ActiveContainersByID(ctx, j.cmdRunner, j.prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range findOrphanedContainers(containersByID, j.names.Slice()) {
+		j.logger.Info("Removing orphaned container", log.String("id", id))
+
+		if out, removeErr := j.cmdRunner.CombinedOutput(ctx, "podman", "rm", "-f", id); removeErr != nil {
+			err = errors.Append(err, errors.Wrapf(removeErr, "removing container %s: %s", id, out))
+		} else {
+			j.metrics.numContainersRemoved.Inc()
+		}
+	}
+
+	return err
+}
+
+func (j *orphanedContainerJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	j.logger.Error("Failed to remove orphaned containers", log.Error(err))
+}
+
+// findOrphanedContainers returns the set of container identifiers of running containers
+// whose job is absent from the expected jobs. The runningContainers argument is expected
+// to be a map from container identifiers to job names.
+func findOrphanedContainers(runningContainers map[string]string, expectedJobs []string) []string {
+	expectedMap := make(map[string]struct{}, len(expectedJobs))
+	for _, job := range expectedJobs {
+		expectedMap[job] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(runningContainers))
+	for id, job := range runningContainers {
+		if _, ok := expectedMap[job]; ok {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
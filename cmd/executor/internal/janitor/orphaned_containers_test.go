@@ -0,0 +1,27 @@
+package janitor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindOrphanedContainers(t *testing.T) {
+	orphans := findOrphanedContainers(
+		map[string]string{
+			"100": "a",
+			"101": "b",
+			"102": "b",
+			"103": "d",
+			"104": "e",
+			"105": "e",
+		},
+		[]string{
+			"d", "e", "f",
+			"x", "y", "z",
+		},
+	)
+	if diff := cmp.Diff([]string{"100", "101", "102"}, orphans); diff != "" {
+		t.Fatalf("unexpected orphans (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,44 @@
+package podman
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
+)
+
+// JobLabel is the label set on all containers started for a job. Its value is the
+// name of the job, which begins with the VM prefix of the executor instance.
+const JobLabel = "com.sourcegraph.executor.job"
+
+// ActiveContainersByID returns the set of job containers existant on the host as a map
+// from container identifiers to job names. Containers of jobs starting with a prefix
+// distinct from the given prefix are ignored.
+func ActiveContainersByID(ctx context.Context, cmdRunner util.CmdRunner, prefix string) (map[string]string, error) {
+	out, err := cmdRunner.CombinedOutput(
+		ctx,
+		"podman",
+		"ps",
+		"-a",
+		"--filter", "label="+JobLabel,
+		"--format", `{{ .ID }}:{{ index .Labels "`+JobLabel+`" }}`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePodmanList(prefix, string(out)), nil
+}
+
+// parsePodmanList parses the output from the `podman ps` invocation in ActiveContainersByID.
+// Containers of jobs starting with a prefix distinct from the given prefix are ignored.
+func parsePodmanList(prefix, out string) map[string]string {
+	activeContainersMap := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if parts := strings.Split(line, ":"); len(parts) == 2 && strings.HasPrefix(parts[1], prefix) {
+			activeContainersMap[parts[0]] = parts[1]
+		}
+	}
+
+	return activeContainersMap
+}
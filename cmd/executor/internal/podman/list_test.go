@@ -0,0 +1,36 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testPodmanOut = `
+0a1b2c3d4e5f:xa
+1a1b2c3d4e5f:yb
+2a1b2c3d4e5f:xa
+3a1b2c3d4e5f:yd
+4a1b2c3d4e5f:xe
+5a1b2c3d4e5f:
+WARN[0000] Test that we ignore annoying log/stderr text
+`
+
+func TestParsePodmanList(t *testing.T) {
+	expectedForX := map[string]string{
+		"0a1b2c3d4e5f": "xa",
+		"2a1b2c3d4e5f": "xa",
+		"4a1b2c3d4e5f": "xe",
+	}
+	if diff := cmp.Diff(expectedForX, parsePodmanList("x", testPodmanOut)); diff != "" {
+		t.Fatalf("unexpected active containers (-want +got):\n%s", diff)
+	}
+
+	expectedForY := map[string]string{
+		"1a1b2c3d4e5f": "yb",
+		"3a1b2c3d4e5f": "yd",
+	}
+	if diff := cmp.Diff(expectedForY, parsePodmanList("y", testPodmanOut)); diff != "" {
+		t.Fatalf("unexpected active containers (-want +got):\n%s", diff)
+	}
+}
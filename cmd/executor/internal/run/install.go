@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"os/user"
 	"path"
 	"runtime"
 	"strings"
@@ -55,7 +56,26 @@ func InstallIPTablesRules(cliCtx *cli.Context, runner util.CmdRunner, logger log
 	return SetupIPTables(&util.RealCmdRunner{}, recreateChain)
 }
 
+func InstallPodman(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, config *config.Config) error {
+	if !hostMightBeAbleToRunPodman() {
+		return ErrNoPodmanSupport
+	}
+
+	return setupRootlessPodman(cliCtx.Context, runner, logger)
+}
+
 func InstallAll(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, config *config.Config) error {
+	if config.UsePodman {
+		// None of the Firecracker components are used when running jobs in Podman.
+		logger.Info("Running executor install src-cli")
+		if err := installSrc(cliCtx, logger, config); err != nil {
+			return err
+		}
+
+		logger.Info("Running executor install podman")
+		return setupRootlessPodman(cliCtx.Context, runner, logger)
+	}
+
 	logger.Info("Running executor install ignite")
 	if err := installIgnite(cliCtx); err != nil {
 		return err
@@ -237,6 +257,74 @@ func installSrc(cliCtx *cli.Context, logger log.Logger, config *config.Config) e
 	return download.ArchivedExecutable(cliCtx.Context, fmt.Sprintf("https://github.com/sourcegraph/src-cli/releases/download/%s/src-cli_%s_%s_%s.tar.gz", srcVersion, srcVersion, runtime.GOOS, runtime.GOARCH), path.Join(binDir, "src"), "src")
 }
 
+// setupRootlessPodman makes sure that the current user can run rootless Podman containers.
+// Podman itself must be installed through the package manager of the host.
+func setupRootlessPodman(ctx context.Context, runner util.CmdRunner, logger log.Logger) error {
+	if found, err := util.ExistsPath(runner, "podman"); err != nil {
+		return errors.Wrap(err, "failed to lookup podman")
+	} else if !found {
+		return errors.New("podman not found in PATH. Install it using your package manager, see https://podman.io/docs/installation")
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current user")
+	}
+	if u.Uid == "0" {
+		return errors.New("podman must be set up for the unprivileged user that runs the executor, not root")
+	}
+
+	// Rootless containers map the users of the container to subordinate IDs of the
+	// current user on the host.
+	for _, file := range []string{"/etc/subuid", "/etc/subgid"} {
+		found, err := hasSubordinateIDs(file, u)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.Newf(`no subordinate IDs configured for user %q in %s. To add them run:
+  $ sudo usermod --add-subuids 100000-165535 --add-subgids 100000-165535 %s`, u.Username, file, u.Username)
+		}
+	}
+
+	// Make sure podman picks up changes to the subordinate IDs.
+	logger.Info("Running podman system migrate")
+	cmd := runner.CommandContext(ctx, "podman", "system", "migrate")
+	// Forward output.
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "running podman system migrate")
+	}
+
+	return util.ValidatePodmanRootless(ctx, runner)
+}
+
+// hasSubordinateIDs returns true if the given subuid or subgid file contains a range for
+// the given user. Entries may reference the user by name or by ID.
+func hasSubordinateIDs(path string, u *user.User) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "reading %s", path)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if parts := strings.Split(strings.TrimSpace(line), ":"); len(parts) == 3 && (parts[0] == u.Username || parts[0] == u.Uid) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var ErrNoPodmanSupport = errors.New("this host cannot run rootless podman containers, only linux hosts are supported")
+
+func hostMightBeAbleToRunPodman() bool {
+	return runtime.GOOS == "linux"
+}
+
 var ErrNoIgniteSupport = errors.New("this host cannot run firecracker VMs, only linux hosts on amd64 processors are supported at the moment")
 
 func hostMightBeAbleToRunIgnite() bool {
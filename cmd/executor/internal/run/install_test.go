@@ -0,0 +1,37 @@
+package run
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasSubordinateIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subuid")
+	require.NoError(t, os.WriteFile(path, []byte("alice:100000:65536\n1001:165536:65536\n"), 0644))
+
+	tests := []struct {
+		name     string
+		user     *user.User
+		expected bool
+	}{
+		{name: "By name", user: &user.User{Username: "alice", Uid: "1000"}, expected: true},
+		{name: "By ID", user: &user.User{Username: "bob", Uid: "1001"}, expected: true},
+		{name: "Missing", user: &user.User{Username: "carol", Uid: "1002"}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			found, err := hasSubordinateIDs(path, test.user)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, found)
+		})
+	}
+
+	found, err := hasSubordinateIDs(filepath.Join(t.TempDir(), "does-not-exist"), &user.User{Username: "alice"})
+	require.NoError(t, err)
+	assert.False(t, found)
+}
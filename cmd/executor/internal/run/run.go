@@ -41,7 +41,7 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		return newQueueTelemetryOptions(ctx, runner, cfg.UseFirecracker, logger)
+		return newQueueTelemetryOptions(ctx, runner, cfg.UseFirecracker, cfg.UsePodman, logger)
 	}()
 	logger.Debug("Telemetry information gathered", log.String("info", fmt.Sprintf("%+v", queueTelemetryOptions)))
 
@@ -50,7 +50,7 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if runVerifyChecks {
 		// Then, validate all tools that are required are installed.
-		if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker); err != nil {
+		if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker, cfg.UsePodman); err != nil {
 			return err
 		}
 
@@ -89,6 +89,13 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 			// TODO: Validate ignite images are pulled and imported. Sadly, the
 			// output of ignite is not very parser friendly.
 		}
+
+		if cfg.UsePodman {
+			// Validate job containers cannot get root privileges on the host.
+			if err = util.ValidatePodmanRootless(ctx, runner); err != nil {
+				return err
+			}
+		}
 	}
 
 	nameSet := janitor.NewNameSet()
@@ -114,6 +121,17 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 		mustRegisterVMCountMetric(observationCtx, runner, logger, cfg.VMPrefix)
 	}
 
+	if cfg.UsePodman {
+		routines = append(routines, janitor.NewOrphanedContainerJanitor(
+			log.Scoped("orphaned-container-janitor"),
+			cfg.VMPrefix,
+			nameSet,
+			cfg.CleanupTaskInterval,
+			janitor.NewMetrics(observationCtx),
+			runner,
+		))
+	}
+
 	go func() {
 		// Block until the worker has exited. The executor worker is unique
 		// in that we want a maximum runtime and/or number of jobs to be
@@ -25,7 +25,7 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 		return err
 	}
 
-	telemetryOptions := newQueueTelemetryOptions(cliCtx.Context, runner, conf.UseFirecracker, logger)
+	telemetryOptions := newQueueTelemetryOptions(cliCtx.Context, runner, conf.UseFirecracker, conf.UsePodman, logger)
 	copts := queueOptions(conf, telemetryOptions)
 	client, err := apiclient.NewBaseClient(logger, copts.BaseClientOptions)
 	if err != nil {
@@ -34,7 +34,7 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 
 	if !config.IsKubernetes() {
 		// Then, validate all tools that are required are installed.
-		if err = util.ValidateRequiredTools(runner, conf.UseFirecracker); err != nil {
+		if err = util.ValidateRequiredTools(runner, conf.UseFirecracker, conf.UsePodman); err != nil {
 			return err
 		}
 
@@ -74,6 +74,13 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 		// output of ignite is not very parser friendly.
 	}
 
+	if conf.UsePodman {
+		// Validate job containers cannot get root privileges on the host.
+		if err = util.ValidatePodmanRootless(cliCtx.Context, runner); err != nil {
+			return err
+		}
+	}
+
 	fmt.Print("All checks passed!\n")
 
 	return nil
@@ -32,3 +32,17 @@ func GetDockerVersion(ctx context.Context, runner CmdRunner) (string, error) {
 func GetIgniteVersion(ctx context.Context, runner CmdRunner) (string, error) {
 	return execOutput(ctx, runner, "ignite", "version", "-o", "short")
 }
+
+// GetPodmanVersion returns the version of podman installed on the host.
+func GetPodmanVersion(ctx context.Context, runner CmdRunner) (string, error) {
+	return execOutput(ctx, runner, "podman", "version", "-f", "{{.Client.Version}}")
+}
+
+// GetPodmanRootless returns whether podman runs containers rootless on the host.
+func GetPodmanRootless(ctx context.Context, runner CmdRunner) (bool, error) {
+	out, err := execOutput(ctx, runner, "podman", "info", "-f", "{{.Host.Security.Rootless}}")
+	if err != nil {
+		return false, err
+	}
+	return out == "true", nil
+}
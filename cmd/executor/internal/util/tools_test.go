@@ -180,3 +180,43 @@ func TestGetIgniteVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodmanVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		exitStatus      int
+		stdout          string
+		expectedVersion string
+		expectedErr     error
+	}{
+		{
+			name:            "Success",
+			stdout:          "4.9.3",
+			expectedVersion: "4.9.3",
+		},
+		{
+			name:        "Error",
+			exitStatus:  1,
+			stdout:      "failed to get version",
+			expectedErr: errors.New("'podman version -f {{.Client.Version}}': failed to get version: exit status 1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			runner.On("CombinedOutput", mock.Anything, "podman", []string{"version", "-f", "{{.Client.Version}}"}).
+				Return(test.exitStatus, test.stdout)
+
+			version, err := util.GetPodmanVersion(context.Background(), runner)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, test.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expectedVersion, version)
+			}
+		})
+	}
+}
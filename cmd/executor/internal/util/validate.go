@@ -69,8 +69,12 @@ func ValidateSrcCLIVersion(ctx context.Context, runner CmdRunner, client *apicli
 // ErrSrcPatchBehind is the specific error if the currently installed src version is a patch behind the latest version.
 var ErrSrcPatchBehind = errors.New("installed src-cli is not the latest version")
 
-// ValidateRequiredTools validates that the tools required to run Docker and/or Firecracker are installed.
-func ValidateRequiredTools(runner CmdRunner, useFirecracker bool) error {
+// ValidateRequiredTools validates that the tools required to run Docker and/or Firecracker, or
+// rootless Podman, are installed.
+func ValidateRequiredTools(runner CmdRunner, useFirecracker, usePodman bool) error {
+	if usePodman {
+		return ValidatePodmanTools(runner)
+	}
 	if err := ValidateDockerTools(runner); err != nil {
 		return err
 	}
@@ -84,11 +88,20 @@ func ValidateRequiredTools(runner CmdRunner, useFirecracker bool) error {
 
 // ValidateDockerTools validates that the tools required to run Docker are installed.
 func ValidateDockerTools(runner CmdRunner) error {
+	return validateTools(runner, config.RequiredCLITools)
+}
+
+// ValidatePodmanTools validates that the tools required to run rootless Podman are installed.
+func ValidatePodmanTools(runner CmdRunner) error {
+	return validateTools(runner, config.RequiredCLIToolsPodman)
+}
+
+func validateTools(runner CmdRunner, requiredTools map[string]string) error {
 	var missingTools []string
 	// So, iterating thru a map is not deterministic, breaking unit tests, so we need to sort the keys.
-	tools := make([]string, len(config.RequiredCLITools))
+	tools := make([]string, len(requiredTools))
 	i := 0
-	for t := range config.RequiredCLITools {
+	for t := range requiredTools {
 		tools[i] = t
 		i++
 	}
@@ -154,6 +167,21 @@ Try running "executor install ignite", or:
 	return nil
 }
 
+// ValidatePodmanRootless validates that podman runs containers rootless, so that the
+// processes of a job never run as root on the host.
+func ValidatePodmanRootless(ctx context.Context, runner CmdRunner) error {
+	rootless, err := GetPodmanRootless(ctx, runner)
+	if err != nil {
+		return errors.Wrap(err, "failed to get podman info")
+	}
+	if !rootless {
+		return errors.New(`podman is not running rootless. Run the executor as an unprivileged user.
+
+Try running "executor install podman", or refer to https://github.com/containers/podman/blob/main/docs/tutorials/rootless_tutorial.md`)
+	}
+	return nil
+}
+
 // ValidateCNIInstalled validate that the CNI plugins for firecracker are properly installed.
 func ValidateCNIInstalled(cmdRunner CmdRunner) error {
 	var errs error
@@ -212,6 +240,9 @@ func (e *ErrMissingTools) Error() string {
 	var errs error
 	for _, tool := range e.Tools {
 		helpText, ok := config.RequiredCLITools[tool]
+		if !ok {
+			helpText, ok = config.RequiredCLIToolsPodman[tool]
+		}
 		// TODO: Help lines for config.RequiredCLIToolsFirecracker.
 		helpLine := ""
 		if ok {
@@ -503,3 +503,61 @@ func TestValidateIgniteInstalled(t *testing.T) {
 //		})
 //	}
 //}
+
+func TestValidatePodmanTools(t *testing.T) {
+	t.Parallel()
+
+	runner := new(fakeCmdRunner)
+	runner.On("LookPath", "git").
+		Return("", nil)
+	runner.On("LookPath", "podman").
+		Return("", exec.ErrNotFound)
+	runner.On("LookPath", "src").
+		Return("", nil)
+
+	err := util.ValidatePodmanTools(runner)
+	require.Error(t, err)
+	assert.EqualError(t, err, "podman not found in PATH, is it installed?\nUse your package manager, or check out https://podman.io/docs/installation on how to install.")
+}
+
+func TestValidatePodmanRootless(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		exitStatus  int
+		stdout      string
+		expectedErr error
+	}{
+		{
+			name:   "Rootless",
+			stdout: "true",
+		},
+		{
+			name:        "Not rootless",
+			stdout:      "false",
+			expectedErr: errors.New("podman is not running rootless. Run the executor as an unprivileged user.\n\nTry running \"executor install podman\", or refer to https://github.com/containers/podman/blob/main/docs/tutorials/rootless_tutorial.md"),
+		},
+		{
+			name:        "Failed to get info",
+			exitStatus:  1,
+			stdout:      "failed to get info",
+			expectedErr: errors.New("failed to get podman info: 'podman info -f {{.Host.Security.Rootless}}': failed to get info: exit status 1"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			runner.On("CombinedOutput", mock.Anything, "podman", []string{"info", "-f", "{{.Host.Security.Rootless}}"}).
+				Return(test.exitStatus, test.stdout)
+
+			err := util.ValidatePodmanRootless(context.Background(), runner)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/podman"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+// PodmanOptions are the options that are specific to running a rootless Podman container.
+type PodmanOptions struct {
+	// DockerAuthConfig is the default registry auth config. Podman reads the same format
+	// as the docker config file.
+	DockerAuthConfig types.DockerAuthConfig
+	// AuthFilePath is the path to the registry auth file that is passed to podman.
+	AuthFilePath string
+	// JobName is the value of the job label of the containers. It is used by the janitor
+	// to tell the containers of running jobs apart from orphaned containers.
+	JobName        string
+	AddHostGateway bool
+	// Network is the network the container is connected to. If empty, the default network
+	// of podman is used, which gives every rootless container its own network namespace.
+	// Use "none" to run jobs without network access.
+	Network   string
+	Resources ResourceOptions
+}
+
+// NewPodmanSpec constructs the command to run on the host in order to invoke the given
+// spec. If the spec does not specify an image, then the command will be run _directly_
+// on the host. Otherwise, the command will be run inside a one-shot rootless podman
+// container subject to the resource limits specified in the given options.
+func NewPodmanSpec(workingDir string, image string, scriptPath string, spec Spec, options PodmanOptions) Spec {
+	// TODO - remove this once src-cli is not required anymore for SSBC.
+	if image == "" {
+		env := spec.Env
+		if options.AuthFilePath != "" {
+			env = append(env, fmt.Sprintf("REGISTRY_AUTH_FILE=%s", options.AuthFilePath))
+		}
+		return Spec{
+			Key:       spec.Key,
+			Command:   spec.Command,
+			Dir:       filepath.Join(workingDir, spec.Dir),
+			Env:       env,
+			Operation: spec.Operation,
+		}
+	}
+
+	return Spec{
+		Key:       spec.Key,
+		Command:   formatPodmanCommand(workingDir, image, scriptPath, spec, options),
+		Operation: spec.Operation,
+	}
+}
+
+func formatPodmanCommand(hostDir string, image string, scriptPath string, spec Spec, options PodmanOptions) []string {
+	return Flatten(
+		"podman",
+		"run",
+		"--rm",
+		podmanLabelFlags(options.JobName),
+		podmanAuthFileFlag(options.AuthFilePath),
+		dockerHostGatewayFlag(options.AddHostGateway),
+		podmanNetworkFlag(options.Network),
+		dockerResourceFlags(options.Resources),
+		dockerVolumeFlags(hostDir),
+		dockerWorkingDirectoryFlags(spec.Dir),
+		dockerEnvFlags(spec.Env),
+		dockerEntrypointFlags,
+		image,
+		filepath.Join("/data", files.ScriptsPath, scriptPath),
+	)
+}
+
+func podmanLabelFlags(jobName string) []string {
+	if jobName == "" {
+		return nil
+	}
+	return []string{"--label", podman.JobLabel + "=" + jobName}
+}
+
+func podmanAuthFileFlag(authFilePath string) []string {
+	if authFilePath == "" {
+		return nil
+	}
+	return []string{"--authfile", authFilePath}
+}
+
+func podmanNetworkFlag(network string) []string {
+	if network == "" {
+		return nil
+	}
+	return []string{"--network", network}
+}
@@ -0,0 +1,304 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+)
+
+func TestNewPodmanSpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		workingDir   string
+		image        string
+		scriptPath   string
+		spec         command.Spec
+		options      command.PodmanOptions
+		expectedSpec command.Spec
+	}{
+		{
+			name:       "Converts to podman spec",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "script/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/script/path",
+				},
+			},
+		},
+		{
+			name:       "Job name",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				JobName: "executor-1234",
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--label",
+					"com.sourcegraph.executor.job=executor-1234",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "Auth File Path",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				AuthFilePath: "/podman/auth.json",
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--authfile",
+					"/podman/auth.json",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "Host Gateway",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				AddHostGateway: true,
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--add-host=host.docker.internal:host-gateway",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "Network",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				Network: "none",
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--network",
+					"none",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "CPU and Memory",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				Resources: command.ResourceOptions{
+					NumCPUs: 10,
+					Memory:  "10G",
+				},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--cpus",
+					"10",
+					"--memory",
+					"10G",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "Docker Host Mount Path is ignored",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+			},
+			options: command.PodmanOptions{
+				Resources: command.ResourceOptions{
+					DockerHostMountPath: "/docker/host/mount/path",
+				},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "src-cli Spec",
+			workingDir: "/workingDirectory",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			expectedSpec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/workingDirectory/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+		},
+		{
+			name:       "src-cli Spec with Auth File Path",
+			workingDir: "/workingDirectory",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				AuthFilePath: "/podman/auth.json",
+			},
+			expectedSpec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/workingDirectory/some/dir",
+				Env:     []string{"FOO=BAR", "REGISTRY_AUTH_FILE=/podman/auth.json"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualSpec := command.NewPodmanSpec(test.workingDir, test.image, test.scriptPath, test.spec, test.options)
+			assert.Equal(t, test.expectedSpec, actualSpec)
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package files
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CacheStore handles interactions with the cache entries that are kept between runs
+// of a job. Entries are scoped to the queue of the job.
+type CacheStore interface {
+	// CacheExists determines if the cache entry exists.
+	CacheExists(ctx context.Context, job types.Job, key string) (bool, error)
+	// GetCache retrieves the archive of the cache entry. If the entry does not exist, a
+	// false-valued flag is returned.
+	GetCache(ctx context.Context, job types.Job, key string) (io.ReadCloser, bool, error)
+	// PutCache uploads the archive of the cache entry.
+	PutCache(ctx context.Context, job types.Job, key string, content io.Reader) error
+}
+
+// JobCaches returns the caches declared by the docker steps of the job. Caches that are
+// declared by multiple steps are only returned once.
+func JobCaches(job types.Job) []types.StepCache {
+	var caches []types.StepCache
+	seen := map[string]struct{}{}
+	for _, step := range job.DockerSteps {
+		for _, cache := range step.Caches {
+			if _, ok := seen[cache.Key]; ok {
+				continue
+			}
+			seen[cache.Key] = struct{}{}
+			caches = append(caches, cache)
+		}
+	}
+	return caches
+}
+
+// CacheKey returns the key of the cache entry in the given workspace. If the cache has
+// key files, a hash of their content is appended to the key. Missing key files are
+// hashed as empty files.
+func CacheKey(workingDirectory string, cache types.StepCache) (string, error) {
+	key := cache.Key
+	if len(cache.KeyFiles) > 0 {
+		h := sha256.New()
+		for _, keyFile := range cache.KeyFiles {
+			path, err := workspacePath(workingDirectory, keyFile)
+			if err != nil {
+				return "", err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return "", errors.Wrapf(err, "reading key file %q", keyFile)
+			}
+			h.Write([]byte(keyFile))
+			h.Write([]byte{0})
+			h.Write(content)
+			h.Write([]byte{0})
+		}
+		key += "-" + hex.EncodeToString(h.Sum(nil))
+	}
+
+	if err := types.ValidateCacheKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// RestoreCache extracts the cache entry with the given key into the workspace. If the
+// entry does not exist, a false-valued flag is returned.
+func RestoreCache(ctx context.Context, store CacheStore, job types.Job, workingDirectory string, key string) (bool, error) {
+	content, ok, err := store.GetCache(ctx, job, key)
+	if err != nil || !ok {
+		return false, err
+	}
+	defer content.Close()
+
+	if err := extractArchive(content, workingDirectory); err != nil {
+		return false, errors.Wrap(err, "extracting cache archive")
+	}
+	return true, nil
+}
+
+// SaveCache archives the paths of the cache and uploads them as the cache entry with
+// the given key. Cache entries are immutable, so nothing is uploaded if the entry
+// already exists, in which case a false-valued flag is returned.
+func SaveCache(ctx context.Context, store CacheStore, job types.Job, workingDirectory string, key string, paths []string) (bool, error) {
+	exists, err := store.CacheExists(ctx, job, key)
+	if err != nil || exists {
+		return false, err
+	}
+
+	// The archive is buffered on disk, as cache entries can be larger than we are
+	// willing to hold in memory.
+	f, err := os.CreateTemp("", "executor-cache-*.tar.gz")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	if err := writeArchive(f, workingDirectory, paths); err != nil {
+		return false, errors.Wrap(err, "creating cache archive")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	if err := store.PutCache(ctx, job, key, f); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeArchive writes a gzipped tarball of the given paths to w. Paths that do not exist
+// are skipped, as they are typically created by the job.
+func writeArchive(w io.Writer, workingDirectory string, paths []string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, relativePath := range paths {
+		root, err := workspacePath(workingDirectory, relativePath)
+		if err != nil {
+			return err
+		}
+
+		// Walk doesn't follow symlinks, so the archive only ever contains files from
+		// within the workspace.
+		err = filepath.Walk(root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				return err
+			}
+			return writeArchiveEntry(tw, workingDirectory, path, info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeArchiveEntry(tw *tar.Writer, workingDirectory string, path string, info fs.FileInfo) error {
+	var link string
+	switch {
+	case info.Mode().IsRegular(), info.IsDir():
+	case info.Mode()&fs.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		link = target
+	default:
+		// Sockets, devices and the like can't be restored.
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	name, err := filepath.Rel(workingDirectory, path)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extractArchive extracts the gzipped tarball in r into the workspace. Existing files
+// are overwritten.
+func extractArchive(r io.Reader, workingDirectory string) error {
+	// Resolve the workspace itself, so that workspaces under symlinked directories
+	// (such as /tmp on macOS) are not mistaken for escapes.
+	resolvedWorkingDirectory, err := filepath.EvalSymlinks(workingDirectory)
+	if err != nil {
+		return err
+	}
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if filepath.Clean(header.Name) == "." {
+			continue
+		}
+		target, err := extractionPath(workingDirectory, resolvedWorkingDirectory, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, header.FileInfo().Mode().Perm()|0o700); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := replaceFile(target, tr, header); err != nil {
+				return err
+			}
+
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			if err := removeNonDirectory(target); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractionPath returns the path that the archive entry with the given name is
+// extracted to. 🚨 SECURITY: Archives are created from the content of a job, so entries
+// must neither point outside of the workspace nor be written through symlinks that
+// point outside of the workspace.
+func extractionPath(workingDirectory, resolvedWorkingDirectory, name string) (string, error) {
+	target, err := workspacePath(workingDirectory, filepath.FromSlash(name))
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Dir(target)
+	for {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			if !withinDirectory(resolvedWorkingDirectory, resolved) {
+				return "", errors.Newf("refusing to extract %q through a symlink outside of the working directory", name)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		// The directory doesn't exist yet, check its closest existing parent.
+		dir = filepath.Dir(dir)
+	}
+
+	return target, nil
+}
+
+func replaceFile(target string, r io.Reader, header *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	// Don't write through a symlink that might exist at the target path.
+	if err := removeNonDirectory(target); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, header.FileInfo().Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Append(err, f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// Build tools compare modification times to decide what is up to date.
+	return os.Chtimes(target, header.ModTime, header.ModTime)
+}
+
+func removeNonDirectory(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.IsDir() {
+		return errors.Newf("refusing to replace directory %q", path)
+	}
+	return os.Remove(path)
+}
+
+// workspacePath returns the absolute path of the given path relative to the workspace.
+func workspacePath(workingDirectory string, relativePath string) (string, error) {
+	dir, err := filepath.Abs(workingDirectory)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, relativePath)
+	if !withinDirectory(dir, path) {
+		return "", errors.Newf("refusing to access %q outside of working directory", relativePath)
+	}
+	return path, nil
+}
+
+func withinDirectory(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
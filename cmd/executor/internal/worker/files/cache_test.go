@@ -0,0 +1,163 @@
+package files_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestJobCaches(t *testing.T) {
+	job := types.Job{
+		DockerSteps: []types.DockerStep{
+			{Caches: []types.StepCache{{Key: "go-mod", Paths: []string{".cache/go"}}}},
+			{},
+			{Caches: []types.StepCache{
+				{Key: "go-mod", Paths: []string{"ignored"}},
+				{Key: "node-modules", Paths: []string{"node_modules"}},
+			}},
+		},
+	}
+
+	assert.Equal(t, []types.StepCache{
+		{Key: "go-mod", Paths: []string{".cache/go"}},
+		{Key: "node-modules", Paths: []string{"node_modules"}},
+	}, files.JobCaches(job))
+}
+
+func TestCacheKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("v1"), os.ModePerm))
+
+	key, err := files.CacheKey(dir, types.StepCache{Key: "go-mod"})
+	require.NoError(t, err)
+	assert.Equal(t, "go-mod", key)
+
+	hashedKey, err := files.CacheKey(dir, types.StepCache{Key: "go-mod", KeyFiles: []string{"go.sum"}})
+	require.NoError(t, err)
+	assert.Regexp(t, "^go-mod-[0-9a-f]{64}$", hashedKey)
+
+	// The key changes with the content of the key files.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("v2"), os.ModePerm))
+	changedKey, err := files.CacheKey(dir, types.StepCache{Key: "go-mod", KeyFiles: []string{"go.sum"}})
+	require.NoError(t, err)
+	assert.NotEqual(t, hashedKey, changedKey)
+
+	_, err = files.CacheKey(dir, types.StepCache{Key: "go-mod", KeyFiles: []string{"../go.sum"}})
+	assert.Error(t, err)
+
+	_, err = files.CacheKey(dir, types.StepCache{Key: "go/mod"})
+	assert.Error(t, err)
+}
+
+func TestSaveAndRestoreCache(t *testing.T) {
+	job := types.Job{ID: 42, Queue: "codeintel"}
+
+	var archive bytes.Buffer
+	store := files.NewMockCacheStore()
+	store.PutCacheFunc.SetDefaultHook(func(_ context.Context, _ types.Job, _ string, content io.Reader) error {
+		_, err := io.Copy(&archive, content)
+		return err
+	})
+	store.GetCacheFunc.SetDefaultHook(func(context.Context, types.Job, string) (io.ReadCloser, bool, error) {
+		return io.NopCloser(bytes.NewReader(archive.Bytes())), true, nil
+	})
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "node_modules", ".bin"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "node_modules", "index.js"), []byte("module.exports = 42"), 0o644))
+	require.NoError(t, os.Symlink("../index.js", filepath.Join(src, "node_modules", ".bin", "index")))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "uncached.txt"), []byte("nope"), os.ModePerm))
+
+	saved, err := files.SaveCache(context.Background(), store, job, src, "node-modules", []string{"node_modules", "does-not-exist"})
+	require.NoError(t, err)
+	assert.True(t, saved)
+	require.Len(t, store.PutCacheFunc.History(), 1)
+	assert.Equal(t, "node-modules", store.PutCacheFunc.History()[0].Arg2)
+
+	dst := t.TempDir()
+	restored, err := files.RestoreCache(context.Background(), store, job, dst, "node-modules")
+	require.NoError(t, err)
+	assert.True(t, restored)
+
+	content, err := os.ReadFile(filepath.Join(dst, "node_modules", "index.js"))
+	require.NoError(t, err)
+	assert.Equal(t, "module.exports = 42", string(content))
+	link, err := os.Readlink(filepath.Join(dst, "node_modules", ".bin", "index"))
+	require.NoError(t, err)
+	assert.Equal(t, "../index.js", link)
+	_, err = os.Stat(filepath.Join(dst, "uncached.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSaveCache_Exists(t *testing.T) {
+	store := files.NewMockCacheStore()
+	store.CacheExistsFunc.SetDefaultReturn(true, nil)
+
+	saved, err := files.SaveCache(context.Background(), store, types.Job{ID: 42}, t.TempDir(), "go-mod", []string{".cache/go"})
+	require.NoError(t, err)
+	assert.False(t, saved)
+	assert.Empty(t, store.PutCacheFunc.History())
+}
+
+func TestRestoreCache_Miss(t *testing.T) {
+	store := files.NewMockCacheStore()
+
+	restored, err := files.RestoreCache(context.Background(), store, types.Job{ID: 42}, t.TempDir(), "go-mod")
+	require.NoError(t, err)
+	assert.False(t, restored)
+}
+
+func TestRestoreCache_SymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+
+	// Archives are created from the content of the job, so they could contain a
+	// symlink that points outside of the workspace and a file below it.
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "escape", Linkname: outside}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "escape/passwd", Mode: 0o644, Size: 5}))
+	_, err := tw.Write([]byte("pwned"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	store := files.NewMockCacheStore()
+	store.GetCacheFunc.SetDefaultReturn(io.NopCloser(&archive), true, nil)
+
+	_, err = files.RestoreCache(context.Background(), store, types.Job{ID: 42}, t.TempDir(), "escape")
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(outside, "passwd"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRestoreCache_PathEscape(t *testing.T) {
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../passwd", Mode: 0o644}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	store := files.NewMockCacheStore()
+	store.GetCacheFunc.SetDefaultReturn(io.NopCloser(&archive), true, nil)
+
+	dir := t.TempDir()
+	workingDirectory := filepath.Join(dir, "workspace")
+	require.NoError(t, os.Mkdir(workingDirectory, os.ModePerm))
+	_, err := files.RestoreCache(context.Background(), store, types.Job{ID: 42}, workingDirectory, "escape")
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "passwd"))
+	assert.True(t, os.IsNotExist(err))
+}
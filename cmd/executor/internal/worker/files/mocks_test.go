@@ -14,6 +14,418 @@ import (
 	types "github.com/sourcegraph/sourcegraph/internal/executor/types"
 )
 
+// MockCacheStore is a mock implementation of the CacheStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files)
+// used for unit testing.
+type MockCacheStore struct {
+	// CacheExistsFunc is an instance of a mock function object controlling
+	// the behavior of the method CacheExists.
+	CacheExistsFunc *CacheStoreCacheExistsFunc
+	// GetCacheFunc is an instance of a mock function object controlling the
+	// behavior of the method GetCache.
+	GetCacheFunc *CacheStoreGetCacheFunc
+	// PutCacheFunc is an instance of a mock function object controlling the
+	// behavior of the method PutCache.
+	PutCacheFunc *CacheStorePutCacheFunc
+}
+
+// NewMockCacheStore creates a new mock of the CacheStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockCacheStore() *MockCacheStore {
+	return &MockCacheStore{
+		CacheExistsFunc: &CacheStoreCacheExistsFunc{
+			defaultHook: func(context.Context, types.Job, string) (r0 bool, r1 error) {
+				return
+			},
+		},
+		GetCacheFunc: &CacheStoreGetCacheFunc{
+			defaultHook: func(context.Context, types.Job, string) (r0 io.ReadCloser, r1 bool, r2 error) {
+				return
+			},
+		},
+		PutCacheFunc: &CacheStorePutCacheFunc{
+			defaultHook: func(context.Context, types.Job, string, io.Reader) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockCacheStore creates a new mock of the CacheStore interface.
+// All methods panic on invocation, unless overwritten.
+func NewStrictMockCacheStore() *MockCacheStore {
+	return &MockCacheStore{
+		CacheExistsFunc: &CacheStoreCacheExistsFunc{
+			defaultHook: func(context.Context, types.Job, string) (bool, error) {
+				panic("unexpected invocation of MockCacheStore.CacheExists")
+			},
+		},
+		GetCacheFunc: &CacheStoreGetCacheFunc{
+			defaultHook: func(context.Context, types.Job, string) (io.ReadCloser, bool, error) {
+				panic("unexpected invocation of MockCacheStore.GetCache")
+			},
+		},
+		PutCacheFunc: &CacheStorePutCacheFunc{
+			defaultHook: func(context.Context, types.Job, string, io.Reader) error {
+				panic("unexpected invocation of MockCacheStore.PutCache")
+			},
+		},
+	}
+}
+
+// NewMockCacheStoreFrom creates a new mock of the MockCacheStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockCacheStoreFrom(i CacheStore) *MockCacheStore {
+	return &MockCacheStore{
+		CacheExistsFunc: &CacheStoreCacheExistsFunc{
+			defaultHook: i.CacheExists,
+		},
+		GetCacheFunc: &CacheStoreGetCacheFunc{
+			defaultHook: i.GetCache,
+		},
+		PutCacheFunc: &CacheStorePutCacheFunc{
+			defaultHook: i.PutCache,
+		},
+	}
+}
+
+// CacheStoreCacheExistsFunc describes the behavior when the CacheExists
+// method of the parent MockCacheStore instance is invoked.
+type CacheStoreCacheExistsFunc struct {
+	defaultHook func(context.Context, types.Job, string) (bool, error)
+	hooks       []func(context.Context, types.Job, string) (bool, error)
+	history     []CacheStoreCacheExistsFuncCall
+	mutex       sync.Mutex
+}
+
+// CacheExists delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCacheStore) CacheExists(v0 context.Context, v1 types.Job, v2 string) (bool, error) {
+	r0, r1 := m.CacheExistsFunc.nextHook()(v0, v1, v2)
+	m.CacheExistsFunc.appendCall(CacheStoreCacheExistsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CacheExists method
+// of the parent MockCacheStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheStoreCacheExistsFunc) SetDefaultHook(hook func(context.Context, types.Job, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CacheExists method of the parent MockCacheStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheStoreCacheExistsFunc) PushHook(hook func(context.Context, types.Job, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheStoreCacheExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.Job, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheStoreCacheExistsFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, types.Job, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CacheStoreCacheExistsFunc) nextHook() func(context.Context, types.Job, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheStoreCacheExistsFunc) appendCall(r0 CacheStoreCacheExistsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheStoreCacheExistsFuncCall objects
+// describing the invocations of this function.
+func (f *CacheStoreCacheExistsFunc) History() []CacheStoreCacheExistsFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheStoreCacheExistsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheStoreCacheExistsFuncCall is an object that describes an invocation
+// of method CacheExists on an instance of MockCacheStore.
+type CacheStoreCacheExistsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Job
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheStoreCacheExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheStoreCacheExistsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CacheStoreGetCacheFunc describes the behavior when the GetCache method of
+// the parent MockCacheStore instance is invoked.
+type CacheStoreGetCacheFunc struct {
+	defaultHook func(context.Context, types.Job, string) (io.ReadCloser, bool, error)
+	hooks       []func(context.Context, types.Job, string) (io.ReadCloser, bool, error)
+	history     []CacheStoreGetCacheFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCache delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCacheStore) GetCache(v0 context.Context, v1 types.Job, v2 string) (io.ReadCloser, bool, error) {
+	r0, r1, r2 := m.GetCacheFunc.nextHook()(v0, v1, v2)
+	m.GetCacheFunc.appendCall(CacheStoreGetCacheFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetCache method of
+// the parent MockCacheStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheStoreGetCacheFunc) SetDefaultHook(hook func(context.Context, types.Job, string) (io.ReadCloser, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCache method of the parent MockCacheStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheStoreGetCacheFunc) PushHook(hook func(context.Context, types.Job, string) (io.ReadCloser, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheStoreGetCacheFunc) SetDefaultReturn(r0 io.ReadCloser, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.Job, string) (io.ReadCloser, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheStoreGetCacheFunc) PushReturn(r0 io.ReadCloser, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.Job, string) (io.ReadCloser, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CacheStoreGetCacheFunc) nextHook() func(context.Context, types.Job, string) (io.ReadCloser, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheStoreGetCacheFunc) appendCall(r0 CacheStoreGetCacheFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheStoreGetCacheFuncCall objects
+// describing the invocations of this function.
+func (f *CacheStoreGetCacheFunc) History() []CacheStoreGetCacheFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheStoreGetCacheFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheStoreGetCacheFuncCall is an object that describes an invocation of
+// method GetCache on an instance of MockCacheStore.
+type CacheStoreGetCacheFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Job
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 io.ReadCloser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheStoreGetCacheFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheStoreGetCacheFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// CacheStorePutCacheFunc describes the behavior when the PutCache method of
+// the parent MockCacheStore instance is invoked.
+type CacheStorePutCacheFunc struct {
+	defaultHook func(context.Context, types.Job, string, io.Reader) error
+	hooks       []func(context.Context, types.Job, string, io.Reader) error
+	history     []CacheStorePutCacheFuncCall
+	mutex       sync.Mutex
+}
+
+// PutCache delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCacheStore) PutCache(v0 context.Context, v1 types.Job, v2 string, v3 io.Reader) error {
+	r0 := m.PutCacheFunc.nextHook()(v0, v1, v2, v3)
+	m.PutCacheFunc.appendCall(CacheStorePutCacheFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the PutCache method of
+// the parent MockCacheStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheStorePutCacheFunc) SetDefaultHook(hook func(context.Context, types.Job, string, io.Reader) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PutCache method of the parent MockCacheStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheStorePutCacheFunc) PushHook(hook func(context.Context, types.Job, string, io.Reader) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheStorePutCacheFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, types.Job, string, io.Reader) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheStorePutCacheFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, types.Job, string, io.Reader) error {
+		return r0
+	})
+}
+
+func (f *CacheStorePutCacheFunc) nextHook() func(context.Context, types.Job, string, io.Reader) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheStorePutCacheFunc) appendCall(r0 CacheStorePutCacheFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheStorePutCacheFuncCall objects
+// describing the invocations of this function.
+func (f *CacheStorePutCacheFunc) History() []CacheStorePutCacheFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheStorePutCacheFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheStorePutCacheFuncCall is an object that describes an invocation of
+// method PutCache on an instance of MockCacheStore.
+type CacheStorePutCacheFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Job
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 io.Reader
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheStorePutCacheFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheStorePutCacheFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockStore is a mock implementation of the Store interface (from the
 // package
 // github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files)
@@ -193,6 +193,12 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 		return h.handle(ctx, logger, commandLogger, job)
 	}
 
+	// Caches are scoped to the queue of the job, which is only set by multi-queue
+	// executors.
+	if job.Queue == "" {
+		job.Queue = h.options.QueueName
+	}
+
 	// Setup all the file, mounts, etc...
 	logger.Info("Creating workspace")
 	ws, err := h.jobRuntime.PrepareWorkspace(ctx, commandLogger, job)
@@ -262,9 +268,21 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 		}
 	}
 
+	h.saveCaches(ctx, commandLogger, ws, job)
+
 	return nil
 }
 
+// saveCaches uploads the caches of the job from the workspace. Firecracker workspaces are
+// only mounted on the host while they are prepared, so their caches are restored but
+// never saved.
+func (h *handler) saveCaches(ctx context.Context, commandLogger cmdlogger.Logger, ws workspace.Workspace, job types.Job) {
+	if h.options.RunnerOptions.FirecrackerOptions.Enabled || ws.WorkingDirectory() == "" {
+		return
+	}
+	workspace.SaveCaches(ctx, h.filesStore, job, ws.WorkingDirectory(), commandLogger)
+}
+
 func newSecretRedactor(replacements map[string]string) *strings.Replacer {
 	oldnew := make([]string, 0, len(replacements)*2)
 	for k, v := range replacements {
@@ -400,6 +418,8 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, commandLogger c
 		}
 	}
 
+	h.saveCaches(ctx, commandLogger, ws, job)
+
 	return nil
 }
 
@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PodmanOptions are the options to run jobs in rootless Podman containers.
+type PodmanOptions struct {
+	Enabled          bool
+	ContainerOptions command.PodmanOptions
+}
+
+type podmanRunner struct {
+	cmd              command.Command
+	dir              string
+	internalLogger   log.Logger
+	commandLogger    cmdlogger.Logger
+	options          command.PodmanOptions
+	dockerAuthConfig types.DockerAuthConfig
+	// tmpDir is used to store temporary files used for podman execution.
+	tmpDir string
+}
+
+var _ Runner = &podmanRunner{}
+
+// NewPodmanRunner creates a runner that runs each command in a one-shot rootless Podman
+// container. All containers are labeled with the given job name.
+func NewPodmanRunner(
+	cmd command.Command,
+	logger cmdlogger.Logger,
+	dir string,
+	name string,
+	options command.PodmanOptions,
+	dockerAuthConfig types.DockerAuthConfig,
+) Runner {
+	// Use the option configuration unless the user has provided a custom configuration.
+	actualDockerAuthConfig := options.DockerAuthConfig
+	if len(dockerAuthConfig.Auths) > 0 {
+		actualDockerAuthConfig = dockerAuthConfig
+	}
+	options.JobName = name
+
+	return &podmanRunner{
+		cmd:              cmd,
+		dir:              dir,
+		internalLogger:   log.Scoped("podman-runner"),
+		commandLogger:    logger,
+		options:          options,
+		dockerAuthConfig: actualDockerAuthConfig,
+	}
+}
+
+func (r *podmanRunner) TempDir() string {
+	return r.tmpDir
+}
+
+func (r *podmanRunner) Setup(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "executor-podman-runner")
+	if err != nil {
+		return errors.Wrap(err, "failed to create tmp dir for podman runner")
+	}
+	r.tmpDir = dir
+
+	// If docker auth config is present, write it. Podman reads registry credentials
+	// from a file in the format of the docker config file.
+	if len(r.dockerAuthConfig.Auths) > 0 {
+		d, err := json.Marshal(r.dockerAuthConfig)
+		if err != nil {
+			return err
+		}
+
+		authDir, err := os.MkdirTemp(r.tmpDir, "podman_auth")
+		if err != nil {
+			return err
+		}
+		r.options.AuthFilePath = filepath.Join(authDir, "auth.json")
+
+		if err = os.WriteFile(r.options.AuthFilePath, d, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *podmanRunner) Teardown(ctx context.Context) error {
+	if err := os.RemoveAll(r.tmpDir); err != nil {
+		r.internalLogger.Error(
+			"Failed to remove podman state tmp dir",
+			log.String("tmpDir", r.tmpDir),
+			log.Error(err),
+		)
+	}
+
+	return nil
+}
+
+func (r *podmanRunner) Run(ctx context.Context, spec Spec) error {
+	podmanSpec := command.NewPodmanSpec(r.dir, spec.Image, spec.ScriptPath, spec.CommandSpecs[0], r.options)
+	return r.cmd.Run(ctx, r.commandLogger, podmanSpec)
+}
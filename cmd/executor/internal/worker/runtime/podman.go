@@ -0,0 +1,72 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/workspace"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type podmanRuntime struct {
+	cmd          command.Command
+	operations   *command.Operations
+	filesStore   files.Store
+	cloneOptions workspace.CloneOptions
+	podmanOpts   command.PodmanOptions
+}
+
+var _ Runtime = &podmanRuntime{}
+
+func (r *podmanRuntime) Name() Name {
+	return NamePodman
+}
+
+func (r *podmanRuntime) PrepareWorkspace(ctx context.Context, logger cmdlogger.Logger, job types.Job) (workspace.Workspace, error) {
+	// Rootless containers mount the workspace from the host just like Docker containers.
+	return workspace.NewDockerWorkspace(
+		ctx,
+		r.filesStore,
+		job,
+		r.cmd,
+		logger,
+		r.cloneOptions,
+		r.operations,
+	)
+}
+
+func (r *podmanRuntime) NewRunner(ctx context.Context, logger cmdlogger.Logger, filesStore files.Store, options RunnerOptions) (runner.Runner, error) {
+	run := runner.NewPodmanRunner(r.cmd, logger, options.Path, options.Name, r.podmanOpts, options.DockerAuthConfig)
+	if err := run.Setup(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to setup podman runner")
+	}
+	return run, nil
+}
+
+func (r *podmanRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([]runner.Spec, error) {
+	runnerSpecs := make([]runner.Spec, len(job.DockerSteps))
+	for i, step := range job.DockerSteps {
+		runnerSpecs[i] = runner.Spec{
+			Job: job,
+			CommandSpecs: []command.Spec{
+				{
+					// Steps are keyed like docker steps, so that step outputs and skipping
+					// behave the same in both runtimes.
+					Key:       dockerKey(step.Key, i),
+					Command:   nil,
+					Dir:       step.Dir,
+					Env:       step.Env,
+					Operation: r.operations.Exec,
+				},
+			},
+			Image:      step.Image,
+			ScriptPath: ws.ScriptFilenames()[i],
+		}
+	}
+
+	return runnerSpecs, nil
+}
@@ -36,6 +36,10 @@ func prepareScripts(
 		return nil, errors.Wrap(err, "failed to write virtual machine files")
 	}
 
+	// Restore caches last, so that they are extracted on top of the repository and
+	// the files of the job.
+	restoreCaches(ctx, filesStore, job, workspaceDir, commandLogger)
+
 	scriptNames := make([]string, 0, len(job.DockerSteps))
 	for _, file := range workspaceFiles {
 		if file.IsStepScript {
@@ -108,3 +112,73 @@ func writeFiles(logger cmdlogger.Logger, workspaceFiles []files.WorkspaceFile) (
 
 	return nil
 }
+
+// restoreCaches extracts the caches declared by the job into the workspace. Caches are
+// an optimization, so failing to restore one does not fail the job.
+func restoreCaches(ctx context.Context, filesStore files.Store, job types.Job, workspaceDir string, logger cmdlogger.Logger) {
+	caches := files.JobCaches(job)
+	cacheStore, ok := filesStore.(files.CacheStore)
+	if !ok || len(caches) == 0 {
+		return
+	}
+
+	handle := logger.LogEntry("setup.fs.cache", nil)
+	defer func() {
+		handle.Finalize(0)
+		_ = handle.Close()
+	}()
+
+	for _, cache := range caches {
+		start := time.Now()
+		key, err := files.CacheKey(workspaceDir, cache)
+		if err != nil {
+			fmt.Fprintf(handle, "Skipping cache %q: %s\n", cache.Key, err)
+			continue
+		}
+
+		restored, err := files.RestoreCache(ctx, cacheStore, job, workspaceDir, key)
+		switch {
+		case err != nil:
+			fmt.Fprintf(handle, "Failed to restore cache %q: %s\n", key, err)
+		case restored:
+			fmt.Fprintf(handle, "Restored cache %q in %s\n", key, time.Since(start))
+		default:
+			fmt.Fprintf(handle, "Cache %q not found\n", key)
+		}
+	}
+}
+
+// SaveCaches uploads the caches declared by the job from the workspace. Caches are an
+// optimization, so failing to save one does not fail the job.
+func SaveCaches(ctx context.Context, filesStore files.Store, job types.Job, workspaceDir string, logger cmdlogger.Logger) {
+	caches := files.JobCaches(job)
+	cacheStore, ok := filesStore.(files.CacheStore)
+	if !ok || len(caches) == 0 {
+		return
+	}
+
+	handle := logger.LogEntry("teardown.fs.cache", nil)
+	defer func() {
+		handle.Finalize(0)
+		_ = handle.Close()
+	}()
+
+	for _, cache := range caches {
+		start := time.Now()
+		key, err := files.CacheKey(workspaceDir, cache)
+		if err != nil {
+			fmt.Fprintf(handle, "Skipping cache %q: %s\n", cache.Key, err)
+			continue
+		}
+
+		saved, err := files.SaveCache(ctx, cacheStore, job, workspaceDir, key, cache.Paths)
+		switch {
+		case err != nil:
+			fmt.Fprintf(handle, "Failed to save cache %q: %s\n", key, err)
+		case saved:
+			fmt.Fprintf(handle, "Saved cache %q in %s\n", key, time.Since(start))
+		default:
+			fmt.Fprintf(handle, "Cache %q already exists\n", key)
+		}
+	}
+}
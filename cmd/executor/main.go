@@ -114,6 +114,11 @@ func main() {
 						},
 						Action: makeActionHandler(run.InstallSrc),
 					},
+					{
+						Name:   "podman",
+						Usage:  "Sets up rootless podman for the current user. Podman only.",
+						Action: makeActionHandler(run.InstallPodman),
+					},
 					{
 						Name:  "iptables-rules",
 						Usage: "Installs iptables rules required for maximum isolation of executor VMs. Firecracker only.",
@@ -128,7 +133,7 @@ func main() {
 					},
 					{
 						Name:   "all",
-						Usage:  "Runs all installers listed above.",
+						Usage:  "Runs all installers listed above. When EXECUTOR_USE_PODMAN is set, only src-cli and podman are installed.",
 						Action: makeActionHandler(run.InstallAll),
 					},
 				},
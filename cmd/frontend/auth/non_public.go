@@ -73,6 +73,15 @@ var (
 		router.ResetPasswordInit:  {},
 		router.ResetPasswordCode:  {},
 		router.CheckUsernameTaken: {},
+
+		// A sign in with a correct password is completed with a second
+		// factor, or by enrolling one if it is required. The handlers check
+		// the pending sign in of the session.
+		router.SignInSecondFactor:         {},
+		router.SecondFactorTOTPEnroll:     {},
+		router.SecondFactorTOTPConfirm:    {},
+		router.SecondFactorWebAuthnBegin:  {},
+		router.SecondFactorWebAuthnFinish: {},
 	}
 	anonymousAccessibleUIRoutes = map[string]struct{}{
 		uirouter.RouteSignIn:             {},
@@ -139,6 +148,7 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		"/.api/gitlab-webhooks",
 		"/.api/bitbucket-server-webhooks",
 		"/.api/bitbucket-cloud-webhooks",
+		"/.api/gitea-webhooks",
 	} {
 		if strings.HasPrefix(req.URL.Path, prefix) {
 			return true
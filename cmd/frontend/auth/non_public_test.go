@@ -39,6 +39,10 @@ func TestAllowAnonymousRequest(t *testing.T) {
 		{req: req("GET", "/"), want: false},
 		{req: req("POST", "/"), want: false},
 		{req: req("POST", "/-/sign-in"), want: true},
+		{req: req("POST", "/-/sign-in/second-factor"), want: true},
+		{req: req("POST", "/-/second-factor/totp/confirm"), want: true},
+		{req: req("GET", "/-/second-factor"), want: false},
+		{req: req("POST", "/-/second-factor/remove"), want: false},
 		{req: req("GET", "/sign-in"), want: true},
 		{req: req("GET", "/doesntexist"), want: false},
 		{req: req("POST", "/doesntexist"), want: false},
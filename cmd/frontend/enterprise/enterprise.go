@@ -33,6 +33,7 @@ type Services struct {
 	ReposGitLabWebhook          webhooks.Registerer
 	ReposBitbucketServerWebhook webhooks.Registerer
 	ReposBitbucketCloudWebhook  webhooks.Registerer
+	ReposGiteaWebhook           webhooks.Registerer
 
 	SCIMHandler http.Handler
 
@@ -100,6 +101,7 @@ func DefaultServices() Services {
 		ReposGitLabWebhook:              &emptyWebhookHandler{name: "gitlab sync webhook"},
 		ReposBitbucketServerWebhook:     &emptyWebhookHandler{name: "bitbucket server sync webhook"},
 		ReposBitbucketCloudWebhook:      &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
+		ReposGiteaWebhook:               &emptyWebhookHandler{name: "gitea sync webhook"},
 		PermissionsGitHubWebhook:        &emptyWebhookHandler{name: "permissions github webhook"},
 		BatchesGitHubWebhook:            &emptyWebhookHandler{name: "batches github webhook"},
 		BatchesGitLabWebhook:            &emptyWebhookHandler{name: "batches gitlab webhook"},
@@ -824,6 +824,9 @@ type ExternalChangesetResolver interface {
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
+
+	DependsOn(ctx context.Context) ([]ChangesetResolver, error)
+	HeldByDependencies(ctx context.Context) (bool, error)
 }
 
 // Only GitHubApps are supported for commit signing for now.
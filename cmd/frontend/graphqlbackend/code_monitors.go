@@ -31,6 +31,8 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTeamsWebhookAction(ctx context.Context, args *TriggerTestTeamsWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTemplatedWebhookAction(ctx context.Context, args *TriggerTestTemplatedWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -89,6 +91,8 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool)
+	ToMonitorTemplatedWebhook() (MonitorTemplatedWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -97,6 +101,7 @@ type MonitorEmailResolver interface {
 	IncludeResults() bool
 	Priority() string
 	Header() string
+	Digest() *string
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
@@ -114,6 +119,24 @@ type MonitorSlackWebhookResolver interface {
 	Enabled() bool
 	IncludeResults() bool
 	URL() string
+	Digest() *string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorTeamsWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorTemplatedWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Body() string
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -171,9 +194,11 @@ type CreateTriggerArgs struct {
 }
 
 type CreateActionArgs struct {
-	Email        *CreateActionEmailArgs
-	Webhook      *CreateActionWebhookArgs
-	SlackWebhook *CreateActionSlackWebhookArgs
+	Email            *CreateActionEmailArgs
+	Webhook          *CreateActionWebhookArgs
+	SlackWebhook     *CreateActionSlackWebhookArgs
+	TeamsWebhook     *CreateActionTeamsWebhookArgs
+	TemplatedWebhook *CreateActionTemplatedWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -182,6 +207,7 @@ type CreateActionEmailArgs struct {
 	Priority       string
 	Recipients     []graphql.ID
 	Header         string
+	Digest         *string
 }
 
 type CreateActionWebhookArgs struct {
@@ -194,6 +220,20 @@ type CreateActionSlackWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
+	Digest         *string
+}
+
+type CreateActionTeamsWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+}
+
+type CreateActionTemplatedWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+	Body           string
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -227,6 +267,18 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTeamsWebhookActionArgs struct {
+	Namespace    graphql.ID
+	Description  string
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+}
+
+type TriggerTestTemplatedWebhookActionArgs struct {
+	Namespace        graphql.ID
+	Description      string
+	TemplatedWebhook *CreateActionTemplatedWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -248,10 +300,22 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTeamsWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTeamsWebhookArgs
+}
+
+type EditActionTemplatedWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTemplatedWebhookArgs
+}
+
 type EditActionArgs struct {
-	Email        *EditActionEmailArgs
-	Webhook      *EditActionWebhookArgs
-	SlackWebhook *EditActionSlackWebhookArgs
+	Email            *EditActionEmailArgs
+	Webhook          *EditActionWebhookArgs
+	SlackWebhook     *EditActionSlackWebhookArgs
+	TeamsWebhook     *EditActionTeamsWebhookArgs
+	TemplatedWebhook *EditActionTemplatedWebhookArgs
 }
 
 type EditTriggerArgs struct {
@@ -35,6 +35,13 @@ func (f *FeatureFlagResolver) ToFeatureFlagRollout() (*FeatureFlagRolloutResolve
 	return nil, false
 }
 
+func (f *FeatureFlagResolver) ToFeatureFlagTargeting() (*FeatureFlagTargetingResolver, bool) {
+	if f.inner.Targeting != nil {
+		return &FeatureFlagTargetingResolver{f.db, f.inner}, true
+	}
+	return nil, false
+}
+
 type FeatureFlagBooleanResolver struct {
 	db database.DB
 	// Invariant: inner.Bool is non-nil
@@ -79,6 +86,111 @@ func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureF
 	return overridesToResolvers(f.db, overrides), nil
 }
 
+type FeatureFlagTargetingResolver struct {
+	db database.DB
+	// Invariant: inner.Targeting is non-nil
+	inner *featureflag.FeatureFlag
+}
+
+func (f *FeatureFlagTargetingResolver) Name() string { return f.inner.Name }
+func (f *FeatureFlagTargetingResolver) Rules() []*FeatureFlagTargetingRuleResolver {
+	res := make([]*FeatureFlagTargetingRuleResolver, 0, len(f.inner.Targeting.Rules))
+	for _, rule := range f.inner.Targeting.Rules {
+		res = append(res, &FeatureFlagTargetingRuleResolver{rule})
+	}
+	return res
+}
+func (f *FeatureFlagTargetingResolver) RolloutBasisPoints() int32 { return f.inner.Targeting.Rollout }
+func (f *FeatureFlagTargetingResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: f.inner.CreatedAt}
+}
+func (f *FeatureFlagTargetingResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: f.inner.UpdatedAt}
+}
+func (f *FeatureFlagTargetingResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
+	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
+	if err != nil {
+		return nil, err
+	}
+	return overridesToResolvers(f.db, overrides), nil
+}
+
+var targetingRuleTypes = map[featureflag.TargetingRuleType]string{
+	featureflag.TargetingRuleOrg:         "ORG",
+	featureflag.TargetingRuleRole:        "ROLE",
+	featureflag.TargetingRuleEmailDomain: "EMAIL_DOMAIN",
+	featureflag.TargetingRuleSiteAdmin:   "SITE_ADMIN",
+}
+
+type FeatureFlagTargetingRuleResolver struct {
+	inner featureflag.TargetingRule
+}
+
+func (r *FeatureFlagTargetingRuleResolver) Type() string { return targetingRuleTypes[r.inner.Type] }
+func (r *FeatureFlagTargetingRuleResolver) OrgIDs() []graphql.ID {
+	ids := make([]graphql.ID, 0, len(r.inner.OrgIDs))
+	for _, id := range r.inner.OrgIDs {
+		ids = append(ids, MarshalOrgID(id))
+	}
+	return ids
+}
+func (r *FeatureFlagTargetingRuleResolver) Roles() []string {
+	if r.inner.Roles == nil {
+		return []string{}
+	}
+	return r.inner.Roles
+}
+func (r *FeatureFlagTargetingRuleResolver) EmailDomains() []string {
+	if r.inner.EmailDomains == nil {
+		return []string{}
+	}
+	return r.inner.EmailDomains
+}
+func (r *FeatureFlagTargetingRuleResolver) Value() bool { return r.inner.Value }
+
+type FeatureFlagTargetingInput struct {
+	Rules []struct {
+		Type         string
+		OrgIDs       *[]graphql.ID
+		Roles        *[]string
+		EmailDomains *[]string
+		Value        bool
+	}
+	RolloutBasisPoints int32
+}
+
+func (i *FeatureFlagTargetingInput) toTargeting() (*featureflag.FeatureFlagTargeting, error) {
+	targeting := &featureflag.FeatureFlagTargeting{
+		Rules:   make([]featureflag.TargetingRule, 0, len(i.Rules)),
+		Rollout: i.RolloutBasisPoints,
+	}
+	for _, r := range i.Rules {
+		rule := featureflag.TargetingRule{Value: r.Value}
+		for t, name := range targetingRuleTypes {
+			if name == r.Type {
+				rule.Type = t
+			}
+		}
+		if r.OrgIDs != nil {
+			for _, id := range *r.OrgIDs {
+				orgID, err := UnmarshalOrgID(id)
+				if err != nil {
+					return nil, err
+				}
+				rule.OrgIDs = append(rule.OrgIDs, orgID)
+			}
+		}
+		if r.Roles != nil {
+			rule.Roles = *r.Roles
+		}
+		if r.EmailDomains != nil {
+			rule.EmailDomains = *r.EmailDomains
+		}
+		targeting.Rules = append(targeting.Rules, rule)
+	}
+	return targeting, nil
+}
+
 func overridesToResolvers(db database.DB, input []*featureflag.Override) []*FeatureFlagOverrideResolver {
 	res := make([]*FeatureFlagOverrideResolver, 0, len(input))
 	for _, flag := range input {
@@ -237,6 +349,7 @@ func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Targeting          *FeatureFlagTargetingInput
 }) (*FeatureFlagResolver, error) {
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
@@ -250,8 +363,14 @@ func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
 		res, err = ff.CreateBool(ctx, args.Name, *args.Value)
 	} else if args.RolloutBasisPoints != nil {
 		res, err = ff.CreateRollout(ctx, args.Name, *args.RolloutBasisPoints)
+	} else if args.Targeting != nil {
+		var targeting *featureflag.FeatureFlagTargeting
+		if targeting, err = args.Targeting.toTargeting(); err != nil {
+			return nil, err
+		}
+		res, err = ff.CreateFeatureFlag(ctx, &featureflag.FeatureFlag{Name: args.Name, Targeting: targeting})
 	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'targeting' must be set")
 	}
 
 	return &FeatureFlagResolver{r.db, res}, err
@@ -270,6 +389,7 @@ func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Targeting          *FeatureFlagTargetingInput
 }) (*FeatureFlagResolver, error) {
 	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
@@ -279,8 +399,14 @@ func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args struct {
 		ff.Bool = &featureflag.FeatureFlagBool{Value: *args.Value}
 	} else if args.RolloutBasisPoints != nil {
 		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *args.RolloutBasisPoints}
+	} else if args.Targeting != nil {
+		targeting, err := args.Targeting.toTargeting()
+		if err != nil {
+			return nil, err
+		}
+		ff.Targeting = targeting
 	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'targeting' must be set")
 	}
 
 	res, err := r.db.FeatureFlags().UpdateFeatureFlag(ctx, ff)
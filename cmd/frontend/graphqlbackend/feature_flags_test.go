@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	mockrequire "github.com/derision-test/go-mockgen/v2/testutil/require"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -295,3 +296,67 @@ func TestEvaluateFeatureFlag(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateFeatureFlag_Targeting(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	flags := dbmocks.NewMockFeatureFlagStore()
+	flags.CreateFeatureFlagFunc.SetDefaultHook(func(_ context.Context, flag *featureflag.FeatureFlag) (*featureflag.FeatureFlag, error) {
+		return flag, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	RunTest(t, &Test{
+		Context: ctx,
+		Schema:  mustParseGraphQLSchema(t, db),
+		Query: `
+		mutation {
+			createFeatureFlag(name: "targeted", targeting: {
+				rules: [
+					{type: ORG, orgIDs: ["T3JnOjE="], value: true},
+					{type: EMAIL_DOMAIN, emailDomains: ["example.com"], value: false},
+				],
+				rolloutBasisPoints: 500,
+			}) {
+				... on FeatureFlagTargeting {
+					name
+					rules {
+						type
+						orgIDs
+						emailDomains
+						value
+					}
+					rolloutBasisPoints
+				}
+			}
+		}
+		`,
+		ExpectedResult: `
+			{
+				"createFeatureFlag": {
+					"name": "targeted",
+					"rules": [
+						{"type": "ORG", "orgIDs": ["T3JnOjE="], "emailDomains": [], "value": true},
+						{"type": "EMAIL_DOMAIN", "orgIDs": [], "emailDomains": ["example.com"], "value": false}
+					],
+					"rolloutBasisPoints": 500
+				}
+			}
+		`,
+	})
+
+	mockrequire.CalledOnce(t, flags.CreateFeatureFlagFunc)
+	assert.Equal(t, &featureflag.FeatureFlagTargeting{
+		Rules: []featureflag.TargetingRule{
+			{Type: featureflag.TargetingRuleOrg, OrgIDs: []int32{1}, Value: true},
+			{Type: featureflag.TargetingRuleEmailDomain, EmailDomains: []string{"example.com"}, Value: false},
+		},
+		Rollout: 500,
+	}, flags.CreateFeatureFlagFunc.History()[0].Arg1.Targeting)
+}
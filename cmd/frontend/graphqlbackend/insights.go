@@ -49,6 +49,11 @@ type InsightsResolver interface {
 	RetryInsightSeriesBackfill(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
 	MoveInsightSeriesBackfillToFrontOfQueue(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
 	MoveInsightSeriesBackfillToBackOfQueue(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
+
+	// Alerts
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -238,6 +243,7 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	RepositoryDefinition(ctx context.Context) (InsightRepositoryDefinition, error)
 	TimeScope(ctx context.Context) (InsightTimeScope, error)
 	GeneratedFromCaptureGroups() (bool, error)
+	LanguageStatistics() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
 }
@@ -429,6 +435,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	RepositoryScope            *RepositoryScopeInput
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
+	LanguageStatistics         *bool
 	GroupBy                    *string
 }
 
@@ -590,3 +597,44 @@ type AdminBackfillQueueArgs struct {
 	States     *[]string
 	TextSearch *string
 }
+
+type InsightSeriesAlertsArgs struct {
+	SeriesId string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	SeriesId  string
+	Kind      string // enum
+	Threshold *float64
+	Intervals *int32
+	Actions   []InsightSeriesAlertActionInput
+}
+
+type InsightSeriesAlertActionInput struct {
+	Type string // enum
+	Url  *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string // enum
+	Threshold() float64
+	Intervals() int32
+	Actions() []InsightSeriesAlertActionResolver
+	LastRecordingTime() *gqlutil.DateTime
+	CreatedAt() gqlutil.DateTime
+}
+
+type InsightSeriesAlertActionResolver interface {
+	Type() string // enum
+	Url() *string
+}
@@ -27,21 +27,27 @@ type PackageRepoReferenceConnectionArgs struct {
 }
 
 var externalServiceToPackageSchemeMap = map[string]string{
-	extsvc.KindJVMPackages:    dependencies.JVMPackagesScheme,
-	extsvc.KindNpmPackages:    dependencies.NpmPackagesScheme,
-	extsvc.KindGoPackages:     dependencies.GoPackagesScheme,
-	extsvc.KindPythonPackages: dependencies.PythonPackagesScheme,
-	extsvc.KindRustPackages:   dependencies.RustPackagesScheme,
-	extsvc.KindRubyPackages:   dependencies.RubyPackagesScheme,
+	extsvc.KindJVMPackages:       dependencies.JVMPackagesScheme,
+	extsvc.KindNpmPackages:       dependencies.NpmPackagesScheme,
+	extsvc.KindGoPackages:        dependencies.GoPackagesScheme,
+	extsvc.KindPythonPackages:    dependencies.PythonPackagesScheme,
+	extsvc.KindRustPackages:      dependencies.RustPackagesScheme,
+	extsvc.KindRubyPackages:      dependencies.RubyPackagesScheme,
+	extsvc.KindNuGetPackages:     dependencies.NuGetPackagesScheme,
+	extsvc.KindHexPackages:       dependencies.HexPackagesScheme,
+	extsvc.KindPackagistPackages: dependencies.PackagistPackagesScheme,
 }
 
 var packageSchemeToExternalServiceMap = map[string]string{
-	dependencies.JVMPackagesScheme:    extsvc.KindJVMPackages,
-	dependencies.NpmPackagesScheme:    extsvc.KindNpmPackages,
-	dependencies.GoPackagesScheme:     extsvc.KindGoPackages,
-	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
-	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
-	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.JVMPackagesScheme:       extsvc.KindJVMPackages,
+	dependencies.NpmPackagesScheme:       extsvc.KindNpmPackages,
+	dependencies.GoPackagesScheme:        extsvc.KindGoPackages,
+	dependencies.PythonPackagesScheme:    extsvc.KindPythonPackages,
+	dependencies.RustPackagesScheme:      extsvc.KindRustPackages,
+	dependencies.RubyPackagesScheme:      extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:     extsvc.KindNuGetPackages,
+	dependencies.HexPackagesScheme:       extsvc.KindHexPackages,
+	dependencies.PackagistPackagesScheme: extsvc.KindPackagistPackages,
 }
 
 func (r *schemaResolver) PackageRepoReferences(ctx context.Context, args *PackageRepoReferenceConnectionArgs) (_ *packageRepoReferenceConnectionResolver, err error) {
@@ -32,7 +32,9 @@ type ValidateSearchJobQueryResolver interface {
 }
 
 type CreateSearchJobArgs struct {
-	Query string
+	Query   string
+	Format  *string
+	Columns *[]string
 }
 
 type SearchJobResolver interface {
@@ -45,6 +47,8 @@ type SearchJobResolver interface {
 	FinishedAt(ctx context.Context) *gqlutil.DateTime
 	URL(ctx context.Context) (*string, error)
 	LogURL(ctx context.Context) (*string, error)
+	ResultsFormat() string
+	ResultsColumns() *[]string
 	RepoStats(ctx context.Context) (SearchJobStatsResolver, error)
 }
 
@@ -158,6 +158,13 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 			})
 		case *result.OwnerMatch:
 			// todo(own): add OwnerSearchResultResolver
+		case *result.CommitDiffHunkMatch:
+			// GraphQL has no hunk results, so every hunk is a commit result
+			// whose diff only contains the hunk.
+			resolvers = append(resolvers, &CommitSearchResultResolver{
+				db:          db,
+				CommitMatch: *v.CommitMatch(),
+			})
 		}
 	}
 	return resolvers
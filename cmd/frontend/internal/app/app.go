@@ -59,6 +59,15 @@ func NewHandler(db database.DB, logger log.Logger) http.Handler {
 	r.Get(router.UnlockUserAccount).Handler(trace.Route(userpasswd.HandleUnlockUserAccount(logger, db, lockoutStore)))
 	r.Get(router.ResetPasswordInit).Handler(trace.Route(userpasswd.HandleResetPasswordInit(logger, db)))
 	r.Get(router.ResetPasswordCode).Handler(trace.Route(userpasswd.HandleResetPasswordCode(logger, db)))
+	r.Get(router.SignInSecondFactor).Handler(trace.Route(userpasswd.HandleSignInSecondFactor(logger, db, lockoutStore)))
+	r.Get(router.SecondFactorStatus).Handler(trace.Route(userpasswd.HandleSecondFactorStatus(logger, db)))
+	r.Get(router.SecondFactorTOTPEnroll).Handler(trace.Route(userpasswd.HandleEnrollTOTP(logger, db)))
+	r.Get(router.SecondFactorTOTPConfirm).Handler(trace.Route(userpasswd.HandleConfirmTOTP(logger, db)))
+	r.Get(router.SecondFactorWebAuthnBegin).Handler(trace.Route(userpasswd.HandleBeginWebAuthnRegistration(logger, db)))
+	r.Get(router.SecondFactorWebAuthnFinish).Handler(trace.Route(userpasswd.HandleFinishWebAuthnRegistration(logger, db)))
+	r.Get(router.SecondFactorRecoveryCodes).Handler(trace.Route(userpasswd.HandleRegenerateRecoveryCodes(logger, db)))
+	r.Get(router.SecondFactorRemove).Handler(trace.Route(userpasswd.HandleRemoveSecondFactor(logger, db)))
+	r.Get(router.ResetUserSecondFactors).Handler(trace.Route(userpasswd.HandleResetUserSecondFactors(logger, db)))
 	r.Get(router.VerifyEmail).Handler(trace.Route(serveVerifyEmail(db)))
 
 	r.Get(router.CheckUsernameTaken).Handler(trace.Route(userpasswd.HandleCheckUsernameTaken(logger, db)))
@@ -34,6 +34,16 @@ const (
 	ResetPasswordCode  = "reset-password.code"
 	CheckUsernameTaken = "check-username-taken"
 
+	SignInSecondFactor         = "sign-in.second-factor"
+	SecondFactorStatus         = "second-factor.status"
+	SecondFactorTOTPEnroll     = "second-factor.totp.enroll"
+	SecondFactorTOTPConfirm    = "second-factor.totp.confirm"
+	SecondFactorWebAuthnBegin  = "second-factor.webauthn.begin"
+	SecondFactorWebAuthnFinish = "second-factor.webauthn.finish"
+	SecondFactorRecoveryCodes  = "second-factor.recovery-codes"
+	SecondFactorRemove         = "second-factor.remove"
+	ResetUserSecondFactors     = "reset-user-second-factors"
+
 	UsageStatsDownload = "usage-stats.download"
 
 	OneClickExportArchive = "one-click-export.archive"
@@ -81,6 +91,16 @@ func newRouter() *mux.Router {
 	base.Path("/-/reset-password-init").Methods("POST").Name(ResetPasswordInit)
 	base.Path("/-/reset-password-code").Methods("POST").Name(ResetPasswordCode)
 
+	base.Path("/-/sign-in/second-factor").Methods("POST").Name(SignInSecondFactor)
+	base.Path("/-/second-factor").Methods("GET").Name(SecondFactorStatus)
+	base.Path("/-/second-factor/totp/enroll").Methods("POST").Name(SecondFactorTOTPEnroll)
+	base.Path("/-/second-factor/totp/confirm").Methods("POST").Name(SecondFactorTOTPConfirm)
+	base.Path("/-/second-factor/webauthn/register/begin").Methods("POST").Name(SecondFactorWebAuthnBegin)
+	base.Path("/-/second-factor/webauthn/register/finish").Methods("POST").Name(SecondFactorWebAuthnFinish)
+	base.Path("/-/second-factor/recovery-codes").Methods("POST").Name(SecondFactorRecoveryCodes)
+	base.Path("/-/second-factor/remove").Methods("POST").Name(SecondFactorRemove)
+	base.Path("/-/reset-user-second-factors").Methods("POST").Name(ResetUserSecondFactors)
+
 	base.Path("/-/check-username-taken/{username}").Methods("GET").Name(CheckUsernameTaken)
 
 	base.Path("/-/editor").Methods("GET").Name(Editor)
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/githuboauth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/gitlaboauth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/httpheader"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/ldap"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/openidconnect"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/saml"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/sourcegraphoperator"
@@ -38,6 +39,7 @@ func Init(logger log.Logger, db database.DB) {
 	githuboauth.Init(logger, db)
 	gitlaboauth.Init(logger, db)
 	httpheader.Init()
+	ldap.Init()
 	openidconnect.Init()
 	saml.Init()
 	sourcegraphoperator.Init()
@@ -49,6 +51,7 @@ func Init(logger log.Logger, db database.DB) {
 		sourcegraphoperator.Middleware(db),
 		saml.Middleware(db),
 		httpheader.Middleware(logger, db),
+		ldap.Middleware(logger, db, userpasswd.NewLockoutStoreFromConf(conf.AuthLockout())),
 		githuboauth.Middleware(db),
 		gitlaboauth.Middleware(db),
 		bitbucketcloudoauth.Middleware(db),
@@ -89,6 +92,8 @@ func Init(logger log.Logger, db database.DB) {
 				name = "Azure DevOps"
 			case p.HttpHeader != nil:
 				name = "HTTP header"
+			case p.Ldap != nil:
+				name = "LDAP"
 			case p.Openidconnect != nil:
 				name = "OpenID Connect"
 			case p.Saml != nil:
@@ -0,0 +1,124 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	sgldap "github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const pkgName = "ldap"
+
+func Init() {
+	conf.ContributeValidator(validateConfig)
+
+	logger := log.Scoped(pkgName)
+	go func() {
+		conf.Watch(func() {
+			ps := getProviders(logger)
+			if len(ps) == 0 {
+				providers.Update(pkgName, nil)
+				return
+			}
+
+			if err := licensing.Check(licensing.FeatureSSO); err != nil {
+				logger.Error("Check license for SSO (LDAP)", log.Error(err))
+				providers.Update(pkgName, nil)
+				return
+			}
+
+			psp := make([]providers.Provider, 0, len(ps))
+			for _, p := range ps {
+				psp = append(psp, p)
+			}
+			providers.Update(pkgName, psp)
+		})
+	}()
+}
+
+func getProviders(logger log.Logger) []*provider {
+	var cfgs []*schema.LDAPAuthProvider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap != nil {
+			cfgs = append(cfgs, p.Ldap)
+		}
+	}
+	multiple := len(cfgs) >= 2
+
+	ps := make([]*provider, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		directory, err := sgldap.NewDirectory(cfg)
+		if err != nil {
+			// Reported to site admins by validateConfig.
+			logger.Error("Invalid LDAP auth provider", log.String("url", cfg.Url), log.Error(err))
+			continue
+		}
+		ps = append(ps, &provider{config: *cfg, multiple: multiple, directory: directory})
+	}
+	return ps
+}
+
+var mockGetProviderValue *provider
+
+// getProvider looks up the registered LDAP auth provider with the given ID.
+func getProvider(pcID string) *provider {
+	if mockGetProviderValue != nil {
+		return mockGetProviderValue
+	}
+
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: pcID}).(*provider)
+	return p
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+
+		if _, err := sgldap.NewDirectory(p.Ldap); err != nil {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is invalid: %s", i, err)))
+		}
+
+		// We can ignore errors: converting to JSON must work, as we parsed from JSON before.
+		bytes, _ := json.Marshal(*p.Ldap)
+		key := string(bytes)
+		if j, ok := seen[key]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+		} else {
+			seen[key] = i
+		}
+	}
+	return problems
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object. It
+// is used to tell the login form which of multiple LDAP auth providers to authenticate against.
+// Its value is never persisted, and it must be deterministic.
+//
+// If there is only a single LDAP auth provider, it returns the empty string because that
+// satisfies the requirements above.
+func providerConfigID(pc *schema.LDAPAuthProvider, multiple bool) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	if !multiple {
+		return ""
+	}
+	data, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
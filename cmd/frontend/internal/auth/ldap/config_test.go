@@ -0,0 +1,63 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		input        conf.Unified
+		wantProblems conf.Problems
+	}{
+		"valid": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com"}},
+				},
+			}},
+		},
+		"invalid filter": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com", UserFilter: "(uid={username}"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0 is invalid"),
+		},
+		"duplicates": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com"}},
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 1 is duplicate of index 0"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf.TestValidator(t, test.input, validateConfig, test.wantProblems)
+		})
+	}
+}
+
+func TestProviderConfigID(t *testing.T) {
+	p := schema.LDAPAuthProvider{Url: "ldaps://ldap.example.com"}
+	if id := providerConfigID(&p, false); id != "" {
+		t.Errorf("got %q for a single provider, want empty", id)
+	}
+
+	id1 := providerConfigID(&p, true)
+	id2 := providerConfigID(&p, true)
+	if id1 != id2 {
+		t.Errorf("id1 (%q) != id2 (%q)", id1, id2)
+	}
+
+	p.ConfigID = "corp"
+	if id := providerConfigID(&p, true); id != "corp" {
+		t.Errorf("got %q, want the configured configID", id)
+	}
+}
@@ -0,0 +1,256 @@
+package ldap
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/session"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	sgldap "github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/groupsync"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication. It serves the login form under the auth path
+// prefix. Unlike the other SSO providers, LDAP has no external sign-in page to redirect to, so
+// the credentials are entered into and checked by Sourcegraph.
+//
+// Failed sign ins count towards the same account lockout as those with the builtin auth
+// provider.
+//
+// 🚨 SECURITY
+func Middleware(logger log.Logger, db database.DB, lockout userpasswd.LockoutStore) *auth.Middleware {
+	logger = logger.Scoped(pkgName)
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != authPrefix+"/login" {
+					next.ServeHTTP(w, r)
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					returnTo := firstNonEmpty(r.URL.Query().Get("returnTo"), r.URL.Query().Get("redirect"), r.Referer())
+					renderLoginForm(logger, w, http.StatusOK, r.URL.Query().Get("pc"), returnTo, "")
+				case http.MethodPost:
+					handleSignIn(logger, db, lockout, w, r)
+				default:
+					http.Error(w, "", http.StatusMethodNotAllowed)
+				}
+			})
+		},
+	}
+}
+
+func handleSignIn(logger log.Logger, db database.DB, lockout userpasswd.LockoutStore, w http.ResponseWriter, r *http.Request) {
+	// 🚨 SECURITY: Browsers send the Origin header with cross-origin form posts. Reject those so
+	// that other sites can't sign users in to an account of the attacker's choosing.
+	if !isSameOrigin(r) {
+		http.Error(w, "Cross-origin sign in requests are not allowed.", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	pcID, returnTo := r.PostFormValue("pc"), r.PostFormValue("returnTo")
+
+	p := getProvider(pcID)
+	if p == nil {
+		logger.Error("no LDAP auth provider found with ID", log.String("id", pcID))
+		http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+		return
+	}
+
+	username := r.PostFormValue("username")
+
+	// 🚨 SECURITY: Lock out users who already have an account after too many failed attempts,
+	// so that their password can't be guessed through this form.
+	lockoutUserID, err := getLockoutUserID(r.Context(), db, p, username)
+	if err != nil {
+		logger.Error("error looking up LDAP user", log.String("username", username), log.Error(err))
+		renderLoginForm(logger, w, http.StatusInternalServerError, pcID, returnTo, "Unable to verify your credentials with the LDAP server. If the problem persists, a site admin must check the configuration.")
+		return
+	}
+	if lockoutUserID != 0 {
+		if reason, locked := lockout.IsLockedOut(lockoutUserID); locked {
+			logger.Warn("LDAP user is locked out", log.Int32("userID", lockoutUserID), log.String("reason", reason))
+			renderLoginForm(logger, w, http.StatusUnprocessableEntity, pcID, returnTo, "Your account has been locked out after too many failed sign in attempts. Try again later.")
+			return
+		}
+	}
+
+	user, err := p.directory.Authenticate(r.Context(), username, r.PostFormValue("password"))
+	if err != nil {
+		if errors.Is(err, sgldap.ErrInvalidCredentials) {
+			if lockoutUserID != 0 {
+				lockout.IncreaseFailedAttempt(lockoutUserID)
+			}
+			renderLoginForm(logger, w, http.StatusUnauthorized, pcID, returnTo, "Invalid username or password.")
+			return
+		}
+		logger.Error("error authenticating LDAP user", log.String("username", username), log.Error(err))
+		renderLoginForm(logger, w, http.StatusInternalServerError, pcID, returnTo, "Unable to verify your credentials with the LDAP server. If the problem persists, a site admin must check the configuration.")
+		return
+	}
+
+	if len(p.config.AllowGroups) > 0 && !user.InAnyGroup(p.config.AllowGroups) {
+		logger.Warn("LDAP user is not in any of the allowed groups", log.String("dn", user.DN), log.Strings("allowGroups", p.config.AllowGroups))
+		renderLoginForm(logger, w, http.StatusForbidden, pcID, returnTo, "You are not a member of any of the groups allowed to sign in.")
+		return
+	}
+
+	newUserCreated, actor, safeErrMsg, err := getOrCreateUser(r.Context(), logger, db, p, user)
+	if err != nil {
+		logger.Error("error looking up LDAP-authenticated user", log.Error(err), log.String("userErr", safeErrMsg))
+		renderLoginForm(logger, w, http.StatusInternalServerError, pcID, returnTo, safeErrMsg)
+		return
+	}
+
+	// Failing to sync groups should not lock users out; the background sync will retry.
+	if err := groupsync.Sync(r.Context(), logger, db, p.config.GroupMappings, actor.UID, user.Groups); err != nil {
+		logger.Error("error syncing LDAP groups", log.Int32("userID", actor.UID), log.Error(err))
+	}
+
+	dbUser, err := db.Users().GetByID(r.Context(), actor.UID)
+	if err != nil {
+		logger.Error("error retrieving LDAP-authenticated user from database", log.Error(err))
+		http.Error(w, "Failed to retrieve user.", http.StatusInternalServerError)
+		return
+	}
+	if _, err := session.SetActorFromUser(r.Context(), w, r, dbUser, 0); err != nil {
+		logger.Error("error setting LDAP-authenticated actor in session", log.Error(err))
+		http.Error(w, "Error starting LDAP-authenticated session. Try signing in again.", http.StatusInternalServerError)
+		return
+	}
+
+	lockout.Reset(actor.UID)
+
+	// Add a ?signup= or ?signin= parameter to the redirect URL.
+	redirectURL := auth.AddPostAuthRedirectParametersToString(returnTo, newUserCreated, "LDAP")
+
+	// 🚨 SECURITY: Call auth.SafeRedirectURL to avoid an open-redirect vuln.
+	http.Redirect(w, r, auth.SafeRedirectURL(redirectURL), http.StatusFound)
+}
+
+// getLockoutUserID returns the ID of the user whose account the directory user with the given
+// username signs in to, or 0 if they don't have one yet.
+//
+// The directory resolves the username, so that users can't avoid the lockout by entering a
+// different spelling of their username that the directory also accepts.
+func getLockoutUserID(ctx context.Context, db database.DB, p *provider, username string) (int32, error) {
+	if username == "" {
+		return 0, nil
+	}
+	user, err := p.directory.LookupUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, sgldap.ErrUserNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: providerType,
+		ServiceID:   p.CachedInfo().ServiceID,
+		AccountID:   user.Username,
+		LimitOffset: &database.LimitOffset{Limit: 1},
+	})
+	if err != nil || len(accounts) == 0 {
+		return 0, err
+	}
+	return accounts[0].UserID, nil
+}
+
+// isSameOrigin reports whether the request's Origin header, if present, is the origin of the
+// external URL.
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	externalURL := conf.ExternalURLParsed()
+	if externalURL == nil {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Scheme == externalURL.Scheme && u.Host == externalURL.Host
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+var loginFormTemplate = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in with {{.DisplayName}} - Sourcegraph</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background: #f9fafb; color: #343a4d; }
+form { max-width: 20rem; margin: 6rem auto; padding: 1.5rem; background: #fff; border: 1px solid #dbe2f0; border-radius: 4px; }
+h1 { font-size: 1.25rem; margin-top: 0; }
+label { display: block; margin-top: 0.75rem; font-weight: 500; }
+input[type=text], input[type=password] { box-sizing: border-box; width: 100%; padding: 0.375rem 0.5rem; margin-top: 0.25rem; }
+button { width: 100%; margin-top: 1.25rem; padding: 0.5rem; }
+.error { color: #ad1f2b; }
+</style>
+</head>
+<body>
+<form method="POST" action="{{.Action}}">
+<h1>Sign in with {{.DisplayName}}</h1>
+{{if .Error}}<p class="error" role="alert">{{.Error}}</p>{{end}}
+<input type="hidden" name="pc" value="{{.ProviderID}}">
+<input type="hidden" name="returnTo" value="{{.ReturnTo}}">
+<label for="username">Username</label>
+<input type="text" id="username" name="username" autocomplete="username" autofocus required>
+<label for="password">Password</label>
+<input type="password" id="password" name="password" autocomplete="current-password" required>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+func renderLoginForm(logger log.Logger, w http.ResponseWriter, status int, pcID, returnTo, errorMessage string) {
+	displayName := "LDAP"
+	if p := getProvider(pcID); p != nil {
+		displayName = p.CachedInfo().DisplayName
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// 🚨 SECURITY: Don't allow the login form to be framed, to prevent clickjacking.
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := loginFormTemplate.Execute(w, struct {
+		Action, DisplayName, ProviderID, ReturnTo, Error string
+	}{
+		Action:      authPrefix + "/login",
+		DisplayName: displayName,
+		ProviderID:  pcID,
+		ReturnTo:    returnTo,
+		Error:       errorMessage,
+	}); err != nil {
+		logger.Error("error rendering LDAP login form", log.Error(err))
+	}
+}
@@ -0,0 +1,180 @@
+package ldap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/session"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	sgldap "github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/ldaptest"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry/telemetrytest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := ldaptest.New(t)
+	server.AddEntry("ou=people,dc=example,dc=com", "", map[string][]string{"ou": {"people"}})
+	server.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alicepw", map[string][]string{
+		"uid":      {"alice"},
+		"mail":     {"alice@example.com"},
+		"cn":       {"Alice"},
+		"memberOf": {"cn=eng,ou=groups,dc=example,dc=com"},
+	})
+	server.AddEntry("uid=bob,ou=people,dc=example,dc=com", "bobpw", map[string][]string{
+		"uid": {"bob"},
+	})
+
+	config := schema.LDAPAuthProvider{
+		Type:        "ldap",
+		Url:         server.URL,
+		UserBaseDN:  "ou=people,dc=example,dc=com",
+		AllowGroups: []string{"eng"},
+	}
+	directory, err := sgldap.NewDirectory(&config)
+	require.NoError(t, err)
+	mockGetProviderValue = &provider{config: config, directory: directory}
+	t.Cleanup(func() { mockGetProviderValue = nil })
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "http://example.com"}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	session.ResetMockSessionStore(t)
+
+	const mockedUserID = 123
+	var gotOp auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (newUserCreated bool, userID int32, safeErrMsg string, err error) {
+		gotOp = op
+		if op.ExternalAccount.ServiceType == "ldap" && op.ExternalAccount.ServiceID == server.URL && op.ExternalAccount.AccountID == "alice" {
+			return true, mockedUserID, "", nil
+		}
+		return false, 0, "safeErr", errors.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+	externalAccounts := dbmocks.NewStrictMockUserExternalAccountsStore()
+	externalAccounts.ListFunc.SetDefaultHook(func(ctx context.Context, opt database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opt.ServiceType == "ldap" && opt.ServiceID == server.URL && opt.AccountID == "alice" {
+			return []*extsvc.Account{{UserID: mockedUserID}}, nil
+		}
+		return nil, nil
+	})
+
+	users := dbmocks.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, CreatedAt: time.Now()}, nil
+	})
+	db := dbmocks.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+	_ = telemetrytest.AddDBMocks(db)
+
+	lockout := &memoryLockoutStore{threshold: 3, failedAttempts: map[int32]int{}}
+	handler := Middleware(logtest.Scoped(t), db, lockout).App(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("next"))
+	}))
+
+	signIn := func(username, password, origin string) *http.Response {
+		form := url.Values{"username": {username}, "password": {password}, "returnTo": {"/search?q=foo"}}
+		req := httptest.NewRequest(http.MethodPost, "http://example.com/.auth/ldap/login", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("other paths are passed through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/search", nil))
+		assert.Equal(t, "next", w.Body.String())
+	})
+
+	t.Run("login form", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/.auth/ldap/login?returnTo=%2Fsearch%22", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<form method="POST" action="/.auth/ldap/login">`)
+		// The returnTo value is escaped.
+		assert.Contains(t, w.Body.String(), `name="returnTo" value="/search&#34;"`)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp := signIn("alice", "alicepw", "http://example.com")
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/search?q=foo&signup=LDAP", resp.Header.Get("Location"))
+		assert.NotEmpty(t, resp.Cookies())
+
+		assert.Equal(t, "alice", gotOp.UserProps.Username)
+		assert.Equal(t, "alice@example.com", gotOp.UserProps.Email)
+		assert.Equal(t, "Alice", gotOp.UserProps.DisplayName)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		resp := signIn("alice", "wrong", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("not in allowed groups", func(t *testing.T) {
+		resp := signIn("bob", "bobpw", "")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("cross-origin request", func(t *testing.T) {
+		resp := signIn("alice", "alicepw", "http://evil.example.com")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("locked out after repeated failures", func(t *testing.T) {
+		lockout.Reset(mockedUserID)
+		for range lockout.threshold {
+			resp := signIn("alice", "wrong", "")
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+
+		// The correct password is rejected too while the account is locked.
+		resp := signIn("alice", "alicepw", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		lockout.Reset(mockedUserID)
+		resp = signIn("alice", "alicepw", "")
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
+
+// memoryLockoutStore locks users out after threshold failed attempts.
+type memoryLockoutStore struct {
+	userpasswd.LockoutStore
+	threshold      int
+	failedAttempts map[int32]int
+}
+
+func (s *memoryLockoutStore) IsLockedOut(userID int32) (string, bool) {
+	return "too many failed attempts", s.failedAttempts[userID] >= s.threshold
+}
+
+func (s *memoryLockoutStore) IncreaseFailedAttempt(userID int32) {
+	s.failedAttempts[userID]++
+}
+
+func (s *memoryLockoutStore) Reset(userID int32) {
+	delete(s.failedAttempts, userID)
+}
@@ -0,0 +1,67 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	sgldap "github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = sgldap.ServiceType
+
+var _ providers.Provider = (*provider)(nil)
+
+type provider struct {
+	config    schema.LDAPAuthProvider
+	multiple  bool // whether there are multiple LDAP auth providers
+	directory *sgldap.Directory
+}
+
+// ConfigID implements providers.Provider.
+func (p *provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config, p.multiple),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// CachedInfo implements providers.Provider.
+func (p *provider) CachedInfo() *providers.Info {
+	info := &providers.Info{
+		ServiceID:         p.config.Url,
+		DisplayName:       p.config.DisplayName,
+		AuthenticationURL: loginURL(p.ConfigID().ID, ""),
+	}
+	if info.DisplayName == "" {
+		info.DisplayName = "LDAP"
+	}
+	return info
+}
+
+func (p *provider) ExternalAccountInfo(ctx context.Context, account extsvc.Account) (*extsvc.PublicAccountData, error) {
+	return GetPublicExternalAccountData(ctx, &account.AccountData)
+}
+
+func (p *provider) Type() providers.ProviderType {
+	return providers.ProviderTypeLDAP
+}
+
+// loginURL returns the URL of the login form of the provider with the given config ID.
+func loginURL(pcID, returnTo string) string {
+	q := url.Values{}
+	if pcID != "" {
+		q.Set("pc", pcID)
+	}
+	if returnTo != "" {
+		q.Set("returnTo", returnTo)
+	}
+	return (&url.URL{Path: authPrefix + "/login", RawQuery: q.Encode()}).String()
+}
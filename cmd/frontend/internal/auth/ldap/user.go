@@ -0,0 +1,100 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	sgldap "github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry/telemetryrecorder"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExternalAccountData is the data stored for the external account of a user who signed in with
+// an LDAP auth provider.
+type ExternalAccountData struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+}
+
+// getOrCreateUser gets or creates a user account for the directory user. It returns the
+// authenticated actor if successful; otherwise it returns a friendly error message (safeErrMsg)
+// that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, logger log.Logger, db database.DB, p *provider, user *sgldap.User) (newUserCreated bool, _ *actor.Actor, safeErrMsg string, err error) {
+	data := ExternalAccountData{
+		DN:          user.DN,
+		Username:    user.Username,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+	}
+	for _, g := range user.Groups {
+		data.Groups = append(data.Groups, g.Name)
+	}
+	serializedData, err := json.Marshal(data)
+	if err != nil {
+		return false, nil, "", err
+	}
+
+	username, err := auth.NormalizeUsername(user.Username)
+	if err != nil {
+		return false, nil, fmt.Sprintf("Error normalizing the username %q. See https://sourcegraph.com/docs/admin/auth/#username-normalization.", user.Username), err
+	}
+
+	info := p.CachedInfo()
+	allowSignup := p.config.AllowSignup == nil || *p.config.AllowSignup
+	newUserCreated, userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, logger, db, telemetryrecorder.New(db), auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username: username,
+			Email:    user.Email,
+			// Email addresses in the directory are managed by its admins, so we
+			// trust them like the ones of other SSO providers.
+			EmailIsVerified: user.Email != "",
+			DisplayName:     user.DisplayName,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   info.ServiceID,
+			AccountID:   user.Username,
+		},
+		ExternalAccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData(serializedData)},
+		CreateIfNotExist:    allowSignup,
+	})
+	if err != nil {
+		return false, nil, safeErrMsg, err
+	}
+	return newUserCreated, actor.FromUser(userID), "", nil
+}
+
+// GetExternalAccountData returns the deserialized JSON blob from user external accounts table.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (*ExternalAccountData, error) {
+	if data.Data == nil {
+		return nil, errors.New("could not find data for the external account")
+	}
+	return encryption.DecryptJSON[ExternalAccountData](ctx, data.Data)
+}
+
+func GetPublicExternalAccountData(ctx context.Context, accountData *extsvc.AccountData) (*extsvc.PublicAccountData, error) {
+	data, err := GetExternalAccountData(ctx, accountData)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := data.Username
+	if data.DisplayName != "" {
+		displayName = data.DisplayName
+	}
+	return &extsvc.PublicAccountData{
+		DisplayName: displayName,
+		Login:       data.Username,
+	}, nil
+}
@@ -52,6 +52,7 @@ const (
 	ProviderTypeSAML          ProviderType = "saml"
 	ProviderTypeOpenIDConnect ProviderType = "openidconnect"
 	ProviderTypeHTTPHeader    ProviderType = "httpheader"
+	ProviderTypeLDAP          ProviderType = "ldap"
 	ProviderTypeBuiltin       ProviderType = "builtin"
 	ProviderTypeGerrit        ProviderType = "gerrit"
 )
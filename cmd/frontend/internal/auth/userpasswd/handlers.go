@@ -352,7 +352,14 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore, recorde
 		// will log the correct security event in case of a failure.
 		signInResult = database.SecurityEventNameSignInFailed
 		telemetrySignInResult := telemetry.ActionFailed
+		// secondFactorPending is set if the password was correct, but the sign
+		// in is only complete once HandleSignInSecondFactor verified the second
+		// factor. That handler records the result.
+		secondFactorPending := false
 		defer func() {
+			if secondFactorPending {
+				return
+			}
 			recordSignInSecurityEvent(r, db, &user, &signInResult)
 			events.Record(ctx, "signIn", telemetrySignInResult, &telemetry.EventParameters{
 				Version: 2,
@@ -412,6 +419,22 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore, recorde
 			return
 		}
 
+		hasSecondFactor, err := secondFactors(db).HasSecondFactor(ctx, user.ID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Error checking second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if hasSecondFactor || conf.AuthSecondFactor().Required {
+			resp, err := beginSecondFactor(ctx, w, r, db, &user, !hasSecondFactor)
+			if err != nil {
+				httpLogError(logger.Error, w, "Could not start second factor verification", http.StatusInternalServerError, log.Error(err))
+				return
+			}
+			secondFactorPending = true
+			writeJSON(logger, w, resp)
+			return
+		}
+
 		// Write the session cookie and get an authenticated context
 		ctx, err = session.SetActorFromUser(ctx, w, r, &user, 0)
 		if err != nil {
@@ -0,0 +1,908 @@
+package userpasswd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/session"
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/auth/secondfactor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/cookie"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// pendingSecondFactorKey is the session key of the sign in that is
+	// waiting for a second factor.
+	pendingSecondFactorKey = "secondFactorPending"
+	// webAuthnRegistrationKey is the session key of the WebAuthn session of a
+	// security key registration by a signed in user.
+	webAuthnRegistrationKey = "secondFactorWebAuthnRegistration"
+
+	// pendingSecondFactorExpiry is how long a user has to enter their second
+	// factor after entering their password.
+	pendingSecondFactorExpiry = 5 * time.Minute
+
+	secondFactorMethodTOTP     = "totp"
+	secondFactorMethodWebAuthn = "webauthn"
+	secondFactorMethodRecovery = "recoveryCode"
+)
+
+// pendingSecondFactor is stored in the session of a user who entered their
+// password correctly but has not completed the second factor yet.
+type pendingSecondFactor struct {
+	UserID    int32
+	ExpiresAt time.Time
+	// Enroll is set if the user has no second factor, but one is required.
+	// They must enroll one to complete the sign in.
+	Enroll bool
+	// WebAuthn is the WebAuthn session of a security key sign in or
+	// registration.
+	WebAuthn *secondfactor.Session
+}
+
+// secondFactorRequiredResponse is returned by HandleSignIn instead of signing
+// the user in if they have to complete a second factor.
+type secondFactorRequiredResponse struct {
+	SecondFactorRequired           bool                         `json:"secondFactorRequired,omitempty"`
+	SecondFactorEnrollmentRequired bool                         `json:"secondFactorEnrollmentRequired,omitempty"`
+	Methods                        []string                     `json:"methods,omitempty"`
+	WebAuthn                       *secondfactor.RequestOptions `json:"webauthn,omitempty"`
+}
+
+func secondFactors(db database.DB) database.UserSecondFactorStore {
+	return db.UserSecondFactors(keyring.Default().UserSecondFactorKey)
+}
+
+func relyingParty() secondfactor.RelyingParty {
+	return secondfactor.RelyingPartyFromURL(conf.ExternalURLParsed(), conf.AuthSecondFactor().Issuer)
+}
+
+// newWebAuthnUser returns the user with the given security keys for the
+// relying party.
+func newWebAuthnUser(user *types.User, credentials []*database.UserWebAuthnCredential) *secondfactor.User {
+	u := &secondfactor.User{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+	}
+	for _, c := range credentials {
+		u.Credentials = append(u.Credentials, secondfactor.Credential{
+			ID:        c.CredentialID,
+			PublicKey: c.PublicKey,
+			SignCount: c.SignCount,
+		})
+	}
+	return u
+}
+
+// beginSecondFactor stores a pending sign in for the user, whose password was
+// correct, and returns what the client needs to complete it.
+func beginSecondFactor(ctx context.Context, w http.ResponseWriter, r *http.Request, db database.DB, user *types.User, enroll bool) (*secondFactorRequiredResponse, error) {
+	pending := pendingSecondFactor{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(pendingSecondFactorExpiry),
+		Enroll:    enroll,
+	}
+	if enroll {
+		if err := session.SetData(w, r, pendingSecondFactorKey, pending); err != nil {
+			return nil, err
+		}
+		return &secondFactorRequiredResponse{SecondFactorEnrollmentRequired: true}, nil
+	}
+
+	resp := &secondFactorRequiredResponse{SecondFactorRequired: true}
+	store := secondFactors(db)
+
+	totp, err := store.GetTOTP(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+	if totp != nil && totp.ConfirmedAt != nil {
+		resp.Methods = append(resp.Methods, secondFactorMethodTOTP)
+	}
+
+	credentials, err := store.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) > 0 {
+		options, webAuthnSession, err := relyingParty().BeginLogin(newWebAuthnUser(user, credentials))
+		if err != nil {
+			return nil, err
+		}
+		pending.WebAuthn = webAuthnSession
+		resp.Methods = append(resp.Methods, secondFactorMethodWebAuthn)
+		resp.WebAuthn = options
+	}
+	resp.Methods = append(resp.Methods, secondFactorMethodRecovery)
+
+	if err := session.SetData(w, r, pendingSecondFactorKey, pending); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// getPendingSecondFactor returns the pending sign in of the session, or nil if
+// there is none or it expired.
+func getPendingSecondFactor(r *http.Request) (*pendingSecondFactor, error) {
+	var pending pendingSecondFactor
+	if err := session.GetData(r, pendingSecondFactorKey, &pending); err != nil {
+		return nil, err
+	}
+	if pending.UserID == 0 || time.Now().After(pending.ExpiresAt) {
+		return nil, nil
+	}
+	return &pending, nil
+}
+
+func clearPendingSecondFactor(w http.ResponseWriter, r *http.Request) error {
+	return session.SetData(w, r, pendingSecondFactorKey, nil)
+}
+
+func logSecondFactorEvent(r *http.Request, db database.DB, userID int32, name database.SecurityEventName, method string) {
+	// Safe to ignore this error
+	anonymousID, _ := cookie.AnonymousUID(r)
+	args := map[string]string{"method": method}
+	if err := db.SecurityEventLogs().LogSecurityEvent(r.Context(), name, r.URL.Path, uint32(userID), anonymousID, "BACKEND", args); err != nil {
+		log.Scoped("secondFactor").Error("failed to log security event", log.Error(err))
+	}
+}
+
+type secondFactorSignIn struct {
+	TOTPCode     string `json:"totpCode"`
+	RecoveryCode string `json:"recoveryCode"`
+	// WebAuthn is the PublicKeyCredential returned by navigator.credentials.get.
+	WebAuthn *json.RawMessage `json:"webauthn"`
+}
+
+// HandleSignInSecondFactor accepts a POST containing the second factor of a
+// sign in that HandleSignIn left pending, and authenticates the current
+// session if it is valid.
+//
+// Failed attempts count towards the account lockout like wrong passwords.
+func HandleSignInSecondFactor(logger log.Logger, db database.DB, store LockoutStore) http.HandlerFunc {
+	logger = logger.Scoped("HandleSignInSecondFactor")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		pending, err := getPendingSecondFactor(r)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not read session", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if pending == nil || pending.Enroll {
+			http.Error(w, "No sign in is waiting for a second factor", http.StatusUnauthorized)
+			return
+		}
+
+		user, err := db.Users().GetByID(ctx, pending.UserID)
+		if err != nil {
+			httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized, log.Error(err))
+			return
+		}
+
+		var req secondFactorSignIn
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		if reason, locked := store.IsLockedOut(user.ID); locked {
+			httpLogError(logger.Error, w, fmt.Sprintf("Account has been locked out due to %q", reason), http.StatusUnprocessableEntity)
+			return
+		}
+
+		// 🚨 SECURITY: A WebAuthn challenge may only be answered once, so it is
+		// consumed before the assertion is verified, whether it is valid or not.
+		webAuthnSession := pending.WebAuthn
+		if req.WebAuthn != nil && webAuthnSession != nil {
+			pending.WebAuthn = nil
+			if err := session.SetData(w, r, pendingSecondFactorKey, pending); err != nil {
+				httpLogError(logger.Error, w, "Could not update session", http.StatusInternalServerError, log.Error(err))
+				return
+			}
+		}
+
+		method, ok, err := verifySecondFactor(ctx, db, user, webAuthnSession, &req)
+		if err != nil {
+			httpLogError(logger.Error, w, "Error checking second factor", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		signInResult := database.SecurityEventNameSignInFailed
+		if !ok {
+			logSecondFactorEvent(r, db, user.ID, database.SecurityEventNameSecondFactorFailed, method)
+			recordSignInSecurityEvent(r, db, user, &signInResult)
+			checkAccountLockout(store, user, &signInResult)
+			httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized)
+			return
+		}
+
+		if err := clearPendingSecondFactor(w, r); err != nil {
+			httpLogError(logger.Error, w, "Could not update session", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if _, err := session.SetActorFromUser(ctx, w, r, user, 0); err != nil {
+			httpLogError(logger.Error, w, fmt.Sprintf("Could not create new user session: %s", err.Error()), http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		logSecondFactorEvent(r, db, user.ID, database.SecurityEventNameSecondFactorSucceeded, method)
+		signInResult = database.SecurityEventNameSignInSucceeded
+		recordSignInSecurityEvent(r, db, user, &signInResult)
+		checkAccountLockout(store, user, &signInResult)
+	}
+}
+
+// verifySecondFactor checks the second factor in req and returns the method
+// that was used. webAuthnSession is the WebAuthn session of the pending sign
+// in.
+func verifySecondFactor(ctx context.Context, db database.DB, user *types.User, webAuthnSession *secondfactor.Session, req *secondFactorSignIn) (method string, ok bool, err error) {
+	store := secondFactors(db)
+	userID := user.ID
+
+	switch {
+	case req.TOTPCode != "":
+		totp, err := store.GetTOTP(ctx, userID)
+		if errcode.IsNotFound(err) || (err == nil && totp.ConfirmedAt == nil) {
+			return secondFactorMethodTOTP, false, nil
+		} else if err != nil {
+			return secondFactorMethodTOTP, false, err
+		}
+		secret, err := totp.Secret(ctx)
+		if err != nil {
+			return secondFactorMethodTOTP, false, err
+		}
+		counter, ok, err := secondfactor.ValidateTOTP(secret, req.TOTPCode, time.Now(), totp.LastUsedCounter)
+		if err != nil || !ok {
+			return secondFactorMethodTOTP, false, err
+		}
+		// 🚨 SECURITY: Every code may only be used once, even by concurrent
+		// requests.
+		ok, err = store.UseTOTPCounter(ctx, userID, counter)
+		return secondFactorMethodTOTP, ok, err
+
+	case req.RecoveryCode != "":
+		ok, err := store.UseRecoveryCode(ctx, userID, secondfactor.HashRecoveryCode(req.RecoveryCode))
+		return secondFactorMethodRecovery, ok, err
+
+	case req.WebAuthn != nil:
+		if webAuthnSession == nil {
+			return secondFactorMethodWebAuthn, false, nil
+		}
+		credentials, err := store.ListWebAuthnCredentials(ctx, userID)
+		if err != nil {
+			return secondFactorMethodWebAuthn, false, err
+		}
+		used, err := relyingParty().FinishLogin(newWebAuthnUser(user, credentials), webAuthnSession, *req.WebAuthn)
+		if err != nil {
+			return secondFactorMethodWebAuthn, false, nil
+		}
+		for _, c := range credentials {
+			if bytes.Equal(c.CredentialID, used.ID) {
+				// 🚨 SECURITY: The counter is only updated if it is still lower, so
+				// of concurrent sign ins with the same signature only one succeeds.
+				ok, err := store.UpdateWebAuthnSignCount(ctx, c.ID, used.SignCount)
+				return secondFactorMethodWebAuthn, ok, err
+			}
+		}
+		return secondFactorMethodWebAuthn, false, nil
+
+	default:
+		return "", false, nil
+	}
+}
+
+// secondFactorUser returns the user who manages their second factors: the
+// signed in user, or the user whose sign in is pending until they enroll one.
+func secondFactorUser(ctx context.Context, r *http.Request, db database.DB) (*types.User, *pendingSecondFactor, error) {
+	if a := sgactor.FromContext(ctx); a.IsAuthenticated() {
+		user, err := a.User(ctx, db.Users())
+		return user, nil, err
+	}
+
+	pending, err := getPendingSecondFactor(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pending == nil || !pending.Enroll {
+		return nil, nil, nil
+	}
+	user, err := db.Users().GetByID(ctx, pending.UserID)
+	return user, pending, err
+}
+
+// completeEnrollment is called after a user enrolled a second factor. It
+// returns new recovery codes if the user has none left, and completes the
+// sign in if it was waiting for the enrollment.
+func completeEnrollment(w http.ResponseWriter, r *http.Request, db database.DB, user *types.User, pending *pendingSecondFactor, method string) (recoveryCodes []string, err error) {
+	ctx := r.Context()
+	logSecondFactorEvent(r, db, user.ID, database.SecurityEventNameSecondFactorEnrolled, method)
+
+	store := secondFactors(db)
+	count, err := store.CountUnusedRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		if recoveryCodes, err = replaceRecoveryCodes(ctx, store, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if pending != nil {
+		if err := clearPendingSecondFactor(w, r); err != nil {
+			return nil, err
+		}
+		if _, err := session.SetActorFromUser(ctx, w, r, user, 0); err != nil {
+			return nil, err
+		}
+		signInResult := database.SecurityEventNameSignInSucceeded
+		recordSignInSecurityEvent(r, db, user, &signInResult)
+	}
+	return recoveryCodes, nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, store database.UserSecondFactorStore, userID int32) ([]string, error) {
+	codes, err := secondfactor.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([][]byte, 0, len(codes))
+	for _, c := range codes {
+		hashes = append(hashes, secondfactor.HashRecoveryCode(c))
+	}
+	return codes, store.ReplaceRecoveryCodes(ctx, userID, hashes)
+}
+
+type enrollmentResponse struct {
+	// RecoveryCodes is only set when the user enrolled their first second
+	// factor.
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+func writeJSON(logger log.Logger, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("failed to write response", log.Error(err))
+	}
+}
+
+// HandleEnrollTOTP generates a new TOTP secret for the user, which they add
+// to their authenticator app. The secret can't be used until it is confirmed
+// with HandleConfirmTOTP.
+func HandleEnrollTOTP(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleEnrollTOTP")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		user, _, err := secondFactorUser(ctx, r, db)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get user", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if user == nil {
+			http.Error(w, "Not signed in", http.StatusUnauthorized)
+			return
+		}
+
+		secret, err := secondfactor.GenerateTOTPSecret()
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not generate secret", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if err := secondFactors(db).CreateTOTP(ctx, user.ID, secret); err != nil {
+			if errors.Is(err, database.ErrTOTPAlreadyEnrolled) {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			httpLogError(logger.Error, w, "Could not store secret", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		writeJSON(logger, w, struct {
+			Secret string `json:"secret"`
+			KeyURI string `json:"keyUri"`
+		}{
+			Secret: secret,
+			KeyURI: secondfactor.TOTPKeyURI(conf.AuthSecondFactor().Issuer, user.Username, secret),
+		})
+	}
+}
+
+// HandleConfirmTOTP accepts a POST containing a code from the authenticator
+// app of the user, which enables the TOTP secret from HandleEnrollTOTP.
+func HandleConfirmTOTP(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleConfirmTOTP")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		user, pending, err := secondFactorUser(ctx, r, db)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get user", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if user == nil {
+			http.Error(w, "Not signed in", http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Code string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		store := secondFactors(db)
+		totp, err := store.GetTOTP(ctx, user.ID)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				http.Error(w, "No authenticator app enrollment in progress", http.StatusBadRequest)
+				return
+			}
+			httpLogError(logger.Error, w, "Could not get secret", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if totp.ConfirmedAt != nil {
+			http.Error(w, database.ErrTOTPAlreadyEnrolled.Error(), http.StatusConflict)
+			return
+		}
+		secret, err := totp.Secret(ctx)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not decrypt secret", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		counter, ok, err := secondfactor.ValidateTOTP(secret, req.Code, time.Now(), 0)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not validate code", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if !ok {
+			http.Error(w, "Invalid code", http.StatusUnprocessableEntity)
+			return
+		}
+		if err := store.ConfirmTOTP(ctx, user.ID, counter); err != nil {
+			httpLogError(logger.Error, w, "Could not confirm secret", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		recoveryCodes, err := completeEnrollment(w, r, db, user, pending, secondFactorMethodTOTP)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not complete enrollment", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		writeJSON(logger, w, enrollmentResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+// HandleBeginWebAuthnRegistration returns the options for the browser to
+// create a new security key credential for the user.
+func HandleBeginWebAuthnRegistration(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleBeginWebAuthnRegistration")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		user, pending, err := secondFactorUser(ctx, r, db)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get user", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if user == nil {
+			http.Error(w, "Not signed in", http.StatusUnauthorized)
+			return
+		}
+
+		credentials, err := secondFactors(db).ListWebAuthnCredentials(ctx, user.ID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not list security keys", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		options, webAuthnSession, err := relyingParty().BeginRegistration(newWebAuthnUser(user, credentials))
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not begin security key registration", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if pending != nil {
+			pending.WebAuthn = webAuthnSession
+			err = session.SetData(w, r, pendingSecondFactorKey, pending)
+		} else {
+			err = session.SetData(w, r, webAuthnRegistrationKey, webAuthnSession)
+		}
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not update session", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		writeJSON(logger, w, options)
+	}
+}
+
+// HandleFinishWebAuthnRegistration accepts a POST containing the response of
+// the browser to the options from HandleBeginWebAuthnRegistration, and stores
+// the new security key.
+func HandleFinishWebAuthnRegistration(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleFinishWebAuthnRegistration")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		user, pending, err := secondFactorUser(ctx, r, db)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get user", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if user == nil {
+			http.Error(w, "Not signed in", http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Name string `json:"name"`
+			// Response is the PublicKeyCredential returned by
+			// navigator.credentials.create.
+			Response json.RawMessage `json:"response"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		// Each challenge can only be used once.
+		var webAuthnSession *secondfactor.Session
+		if pending != nil {
+			webAuthnSession = pending.WebAuthn
+			pending.WebAuthn = nil
+			err = session.SetData(w, r, pendingSecondFactorKey, pending)
+		} else {
+			if err = session.GetData(r, webAuthnRegistrationKey, &webAuthnSession); err == nil {
+				err = session.SetData(w, r, webAuthnRegistrationKey, nil)
+			}
+		}
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not update session", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if webAuthnSession == nil {
+			http.Error(w, "No security key registration in progress", http.StatusBadRequest)
+			return
+		}
+
+		credential, err := relyingParty().FinishRegistration(newWebAuthnUser(user, nil), webAuthnSession, req.Response)
+		if err != nil {
+			httpLogError(logger.Warn, w, fmt.Sprintf("Invalid security key registration: %s", err.Error()), http.StatusUnprocessableEntity)
+			return
+		}
+		if req.Name == "" {
+			req.Name = "Security key"
+		}
+		if _, err := secondFactors(db).CreateWebAuthnCredential(ctx, &database.UserWebAuthnCredential{
+			UserID:       user.ID,
+			Name:         req.Name,
+			CredentialID: credential.ID,
+			PublicKey:    credential.PublicKey,
+			SignCount:    credential.SignCount,
+		}); err != nil {
+			httpLogError(logger.Error, w, "Could not store security key", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		recoveryCodes, err := completeEnrollment(w, r, db, user, pending, secondFactorMethodWebAuthn)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not complete enrollment", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		writeJSON(logger, w, enrollmentResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+type secondFactorStatus struct {
+	Required          bool                   `json:"required"`
+	TOTP              bool                   `json:"totp"`
+	WebAuthn          []webAuthnCredentialJS `json:"webauthn"`
+	RecoveryCodesLeft int                    `json:"recoveryCodesLeft"`
+}
+
+type webAuthnCredentialJS struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// HandleSecondFactorStatus returns the second factors of the signed in user.
+func HandleSecondFactorStatus(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleSecondFactorStatus")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		a := sgactor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			http.Error(w, "Not signed in", http.StatusUnauthorized)
+			return
+		}
+
+		store := secondFactors(db)
+		status := secondFactorStatus{
+			Required: conf.AuthSecondFactor().Required,
+			WebAuthn: []webAuthnCredentialJS{},
+		}
+
+		totp, err := store.GetTOTP(ctx, a.UID)
+		if err != nil && !errcode.IsNotFound(err) {
+			httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		status.TOTP = totp != nil && totp.ConfirmedAt != nil
+
+		credentials, err := store.ListWebAuthnCredentials(ctx, a.UID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		for _, c := range credentials {
+			status.WebAuthn = append(status.WebAuthn, webAuthnCredentialJS{
+				ID:         c.ID,
+				Name:       c.Name,
+				CreatedAt:  c.CreatedAt,
+				LastUsedAt: c.LastUsedAt,
+			})
+		}
+
+		if status.RecoveryCodesLeft, err = store.CountUnusedRecoveryCodes(ctx, a.UID); err != nil {
+			httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		writeJSON(logger, w, status)
+	}
+}
+
+// checkCurrentUserPassword returns the signed in user if password is their
+// password. Changes that weaken the second factors of an account require it,
+// so that they can't be made from a stolen session.
+func checkCurrentUserPassword(ctx context.Context, db database.DB, password string) (int32, error) {
+	a := sgactor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return 0, errors.New("not signed in")
+	}
+	ok, err := db.Users().IsPassword(ctx, a.UID, password)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errors.New("wrong password")
+	}
+	return a.UID, nil
+}
+
+// HandleRegenerateRecoveryCodes accepts a POST containing the password of the
+// signed in user, and replaces their recovery codes with new ones.
+func HandleRegenerateRecoveryCodes(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleRegenerateRecoveryCodes")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		var req struct {
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+		userID, err := checkCurrentUserPassword(ctx, db, req.Password)
+		if err != nil {
+			httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized, log.Error(err))
+			return
+		}
+
+		store := secondFactors(db)
+		has, err := store.HasSecondFactor(ctx, userID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if !has {
+			http.Error(w, "No second factor is enrolled", http.StatusBadRequest)
+			return
+		}
+
+		codes, err := replaceRecoveryCodes(ctx, store, userID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not generate recovery codes", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		writeJSON(logger, w, enrollmentResponse{RecoveryCodes: codes})
+	}
+}
+
+// HandleRemoveSecondFactor accepts a POST containing the password of the
+// signed in user and the second factor to remove. Their recovery codes are
+// removed along with their last second factor.
+func HandleRemoveSecondFactor(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleRemoveSecondFactor")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handleEnabledCheck(logger, w) {
+			return
+		}
+
+		ctx := r.Context()
+		var req struct {
+			Password string `json:"password"`
+			Method   string `json:"method"`
+			// ID is the ID of the security key to remove.
+			ID int32 `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+		userID, err := checkCurrentUserPassword(ctx, db, req.Password)
+		if err != nil {
+			httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized, log.Error(err))
+			return
+		}
+
+		store := secondFactors(db)
+		if conf.AuthSecondFactor().Required {
+			last, err := isLastSecondFactor(ctx, store, userID, req.Method, req.ID)
+			if err != nil {
+				httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+				return
+			}
+			if last {
+				http.Error(w, "A second factor is required, so the last one can't be removed", http.StatusConflict)
+				return
+			}
+		}
+
+		switch req.Method {
+		case secondFactorMethodTOTP:
+			err = store.DeleteTOTP(ctx, userID)
+		case secondFactorMethodWebAuthn:
+			err = store.DeleteWebAuthnCredential(ctx, userID, req.ID)
+		default:
+			http.Error(w, fmt.Sprintf("Unknown second factor method %q", req.Method), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				http.Error(w, "Second factor not found", http.StatusNotFound)
+				return
+			}
+			httpLogError(logger.Error, w, "Could not remove second factor", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		logSecondFactorEvent(r, db, userID, database.SecurityEventNameSecondFactorRemoved, req.Method)
+
+		has, err := store.HasSecondFactor(ctx, userID)
+		if err != nil {
+			httpLogError(logger.Error, w, "Could not get second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+		if !has {
+			// Recovery codes would otherwise become usable again when the
+			// user enrolls a new second factor.
+			if err := store.ReplaceRecoveryCodes(ctx, userID, nil); err != nil {
+				httpLogError(logger.Error, w, "Could not remove recovery codes", http.StatusInternalServerError, log.Error(err))
+				return
+			}
+		}
+	}
+}
+
+// isLastSecondFactor returns true if the second factor with the given method
+// and security key ID is the only second factor of the user.
+func isLastSecondFactor(ctx context.Context, store database.UserSecondFactorStore, userID int32, method string, id int32) (bool, error) {
+	totp, err := store.GetTOTP(ctx, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return false, err
+	}
+	credentials, err := store.ListWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	var removed, others int
+	if totp != nil && totp.ConfirmedAt != nil {
+		if method == secondFactorMethodTOTP {
+			removed++
+		} else {
+			others++
+		}
+	}
+	for _, c := range credentials {
+		if method == secondFactorMethodWebAuthn && c.ID == id {
+			removed++
+		} else {
+			others++
+		}
+	}
+	return removed > 0 && others == 0, nil
+}
+
+type resetUserSecondFactorsInfo struct {
+	Username string `json:"username"`
+}
+
+// HandleResetUserSecondFactors lets site admins remove all second factors of
+// a user who lost access to them. If second factors are required, the user
+// has to enroll a new one the next time they sign in.
+func HandleResetUserSecondFactors(logger log.Logger, db database.DB) http.HandlerFunc {
+	logger = logger.Scoped("HandleResetUserSecondFactors")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			http.Error(w, "Only site admins can reset the second factors of users", http.StatusUnauthorized)
+			return
+		}
+
+		var info resetUserSecondFactorsInfo
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+		if info.Username == "" {
+			http.Error(w, "Bad request: missing username", http.StatusBadRequest)
+			return
+		}
+
+		user, err := db.Users().GetByUsername(ctx, info.Username)
+		if err != nil {
+			http.Error(w,
+				fmt.Sprintf("Not found: could not find user with username %q", info.Username),
+				http.StatusNotFound)
+			return
+		}
+
+		if err := secondFactors(db).DeleteAll(ctx, user.ID); err != nil {
+			httpLogError(logger.Error, w, "Could not reset second factors", http.StatusInternalServerError, log.Error(err))
+			return
+		}
+
+		// Safe to ignore this error
+		anonymousID, _ := cookie.AnonymousUID(r)
+		args := map[string]any{"method": "all", "resetBy": sgactor.FromContext(ctx).UID}
+		if err := db.SecurityEventLogs().LogSecurityEvent(ctx, database.SecurityEventNameSecondFactorRemoved, r.URL.Path, uint32(user.ID), anonymousID, "BACKEND", args); err != nil {
+			logger.Error("failed to log security event", log.Error(err))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package userpasswd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/v2/testutil/require"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth/secondfactor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry/telemetrytest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func mockSecondFactorConf(t *testing.T, required bool) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExternalURL: "https://sourcegraph.example.com",
+			AuthProviders: []schema.AuthProviders{
+				{
+					Builtin: &schema.BuiltinAuthProvider{
+						Type: providerType,
+					},
+				},
+			},
+			AuthSecondFactor: &schema.AuthSecondFactor{Required: required},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
+
+func newSecondFactorTestDB(secondFactors *dbmocks.MockUserSecondFactorStore) *dbmocks.MockDB {
+	users := dbmocks.NewMockUserStore()
+	users.GetByUsernameFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice", CreatedAt: time.Now()}, nil)
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice", CreatedAt: time.Now()}, nil)
+	users.IsPasswordFunc.SetDefaultReturn(true, nil)
+
+	gss := dbmocks.NewMockGlobalStateStore()
+	gss.GetFunc.SetDefaultReturn(database.GlobalState{SiteID: "a"}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.GlobalStateFunc.SetDefaultReturn(gss)
+	db.UsersFunc.SetDefaultReturn(users)
+	db.EventLogsFunc.SetDefaultReturn(dbmocks.NewMockEventLogStore())
+	db.SecurityEventLogsFunc.SetDefaultReturn(dbmocks.NewMockSecurityEventLogsStore())
+	db.UserEmailsFunc.SetDefaultReturn(dbmocks.NewMockUserEmailsStore())
+	db.UserSecondFactorsFunc.SetDefaultReturn(secondFactors)
+	return db
+}
+
+// signInWithPassword signs in with a correct password and returns the response
+// and the session cookies.
+func signInWithPassword(t *testing.T, db database.DB, lockout LockoutStore) (*httptest.ResponseRecorder, []*http.Cookie) {
+	t.Helper()
+
+	h := HandleSignIn(logtest.Scoped(t), db, lockout, telemetry.NewEventRecorder(telemetrytest.NewMockEventsStore()))
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"alice","password":"pw"}`))
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	h(resp, req)
+	return resp, resp.Result().Cookies()
+}
+
+func post(t *testing.T, h http.HandlerFunc, body string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	require.NoError(t, err)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	resp := httptest.NewRecorder()
+	h(resp, req)
+	return resp
+}
+
+func TestHandleSignIn_SecondFactor(t *testing.T) {
+	mockSecondFactorConf(t, false)
+	session.ResetMockSessionStore(t)
+
+	secondFactors := dbmocks.NewMockUserSecondFactorStore()
+	secondFactors.HasSecondFactorFunc.SetDefaultReturn(true, nil)
+	secondFactors.GetTOTPFunc.SetDefaultReturn(nil, database.UserSecondFactorNotFoundErr{})
+	secondFactors.UseRecoveryCodeFunc.SetDefaultHook(func(_ context.Context, _ int32, hash []byte) (bool, error) {
+		return string(hash) == string(secondfactor.HashRecoveryCode("abcde-fghjk")), nil
+	})
+	db := newSecondFactorTestDB(secondFactors)
+	lockout := NewMockLockoutStore()
+
+	resp, cookies := signInWithPassword(t, db, lockout)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body secondFactorRequiredResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.True(t, body.SecondFactorRequired)
+	assert.Equal(t, []string{secondFactorMethodRecovery}, body.Methods)
+	// The password alone must not count as a successful sign in.
+	mockrequire.NotCalled(t, lockout.ResetFunc)
+	mockrequire.NotCalled(t, lockout.IncreaseFailedAttemptFunc)
+
+	h := HandleSignInSecondFactor(logtest.Scoped(t), db, lockout)
+
+	t.Run("no pending sign in", func(t *testing.T) {
+		resp := post(t, h, `{"recoveryCode":"abcde-fghjk"}`, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		mockrequire.NotCalled(t, secondFactors.UseRecoveryCodeFunc)
+	})
+
+	t.Run("wrong recovery code", func(t *testing.T) {
+		resp := post(t, h, `{"recoveryCode":"zzzzz-zzzzz"}`, cookies)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		mockrequire.CalledOnce(t, lockout.IncreaseFailedAttemptFunc)
+	})
+
+	t.Run("correct recovery code", func(t *testing.T) {
+		resp := post(t, h, `{"recoveryCode":"ABCDE-FGHJK"}`, cookies)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockrequire.CalledOnce(t, lockout.ResetFunc)
+	})
+}
+
+func TestHandleSignIn_WebAuthnChallengeConsumed(t *testing.T) {
+	mockSecondFactorConf(t, false)
+	session.ResetMockSessionStore(t)
+
+	secondFactors := dbmocks.NewMockUserSecondFactorStore()
+	secondFactors.HasSecondFactorFunc.SetDefaultReturn(true, nil)
+	secondFactors.GetTOTPFunc.SetDefaultReturn(nil, database.UserSecondFactorNotFoundErr{})
+	secondFactors.ListWebAuthnCredentialsFunc.SetDefaultReturn([]*database.UserWebAuthnCredential{{ID: 1, UserID: 1, CredentialID: []byte("credential")}}, nil)
+	db := newSecondFactorTestDB(secondFactors)
+	lockout := NewMockLockoutStore()
+
+	resp, cookies := signInWithPassword(t, db, lockout)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body secondFactorRequiredResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.NotNil(t, body.WebAuthn)
+
+	h := HandleSignInSecondFactor(logtest.Scoped(t), db, lockout)
+	assertion := `{"webauthn":{"id":"Y3JlZGVudGlhbA","rawId":"Y3JlZGVudGlhbA","type":"public-key","response":{"clientDataJSON":"e30","authenticatorData":"","signature":""}}}`
+
+	// The credentials are listed once to begin the sign in, and once to
+	// verify the assertion.
+	resp = post(t, h, assertion, cookies)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	mockrequire.CalledN(t, secondFactors.ListWebAuthnCredentialsFunc, 2)
+
+	// The failed assertion consumed the challenge, so it can't be answered
+	// again.
+	resp = post(t, h, assertion, cookies)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	mockrequire.CalledN(t, secondFactors.ListWebAuthnCredentialsFunc, 2)
+	mockrequire.NotCalled(t, secondFactors.UpdateWebAuthnSignCountFunc)
+}
+
+func TestHandleSignIn_SecondFactorEnrollmentRequired(t *testing.T) {
+	mockSecondFactorConf(t, true)
+	session.ResetMockSessionStore(t)
+
+	secondFactors := dbmocks.NewMockUserSecondFactorStore()
+	db := newSecondFactorTestDB(secondFactors)
+
+	resp, cookies := signInWithPassword(t, db, NewMockLockoutStore())
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body secondFactorRequiredResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.True(t, body.SecondFactorEnrollmentRequired)
+
+	// The pending sign in can only be used to enroll, not to sign in.
+	resp = post(t, HandleSignInSecondFactor(logtest.Scoped(t), db, NewMockLockoutStore()), `{"recoveryCode":"abcde-fghjk"}`, cookies)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = post(t, HandleEnrollTOTP(logtest.Scoped(t), db), `{}`, cookies)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var enroll struct {
+		Secret string `json:"secret"`
+		KeyURI string `json:"keyUri"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &enroll))
+	assert.NotEmpty(t, enroll.Secret)
+	assert.True(t, strings.HasPrefix(enroll.KeyURI, "otpauth://totp/sourcegraph.example.com:alice?"))
+	mockrequire.CalledOnceWith(t, secondFactors.CreateTOTPFunc, mockrequire.Values(mockrequire.Skip, int32(1), enroll.Secret))
+
+	// Without a pending sign in, the user has to be signed in.
+	resp = post(t, HandleEnrollTOTP(logtest.Scoped(t), db), `{}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestHandleRemoveSecondFactor_Required(t *testing.T) {
+	mockSecondFactorConf(t, true)
+
+	secondFactors := dbmocks.NewMockUserSecondFactorStore()
+	secondFactors.GetTOTPFunc.SetDefaultReturn(&database.UserTOTPCredential{UserID: 1, ConfirmedAt: &time.Time{}}, nil)
+	db := newSecondFactorTestDB(secondFactors)
+	h := HandleRemoveSecondFactor(logtest.Scoped(t), db)
+
+	remove := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		require.NoError(t, err)
+		req = req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1)))
+		resp := httptest.NewRecorder()
+		h(resp, req)
+		return resp
+	}
+
+	t.Run("last second factor", func(t *testing.T) {
+		resp := remove(t, `{"password":"pw","method":"totp"}`)
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		mockrequire.NotCalled(t, secondFactors.DeleteTOTPFunc)
+	})
+
+	t.Run("another second factor remains", func(t *testing.T) {
+		secondFactors.ListWebAuthnCredentialsFunc.SetDefaultReturn([]*database.UserWebAuthnCredential{{ID: 1, UserID: 1}}, nil)
+
+		resp := remove(t, `{"password":"pw","method":"totp"}`)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockrequire.CalledOnce(t, secondFactors.DeleteTOTPFunc)
+	})
+}
+
+func TestHandleSignIn_NoSecondFactor(t *testing.T) {
+	mockSecondFactorConf(t, false)
+	session.ResetMockSessionStore(t)
+
+	db := newSecondFactorTestDB(dbmocks.NewMockUserSecondFactorStore())
+	lockout := NewMockLockoutStore()
+
+	resp, _ := signInWithPassword(t, db, lockout)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Body.String())
+	mockrequire.CalledOnce(t, lockout.ResetFunc)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -366,6 +367,11 @@ func addRepoToExclude(ctx context.Context, logger log.Logger, externalService *t
 		if !schemaContainsExclusion(c.Exclude, exclusion) {
 			c.Exclude = append(c.Exclude, &schema.ExcludedGerritProject{Name: excludableName})
 		}
+	case *schema.GiteaConnection:
+		exclusion := &schema.ExcludedGiteaRepo{Name: excludableName}
+		if !schemaContainsExclusion(c.Exclude, exclusion) {
+			c.Exclude = append(c.Exclude, &schema.ExcludedGiteaRepo{Name: excludableName})
+		}
 	case *schema.GitHubConnection:
 		exclusion := &schema.ExcludedGitHubRepo{Name: excludableName}
 		if !schemaContainsExclusion(c.Exclude, exclusion) {
@@ -381,6 +387,16 @@ func addRepoToExclude(ctx context.Context, logger log.Logger, externalService *t
 		if !schemaContainsExclusion(c.Exclude, exclusion) {
 			c.Exclude = append(c.Exclude, &schema.ExcludedGitoliteRepo{Name: excludableName})
 		}
+	case *schema.MercurialConnection:
+		exclusion := &schema.ExcludedMercurialRepo{Name: excludableName}
+		if !schemaContainsExclusion(c.Exclude, exclusion) {
+			c.Exclude = append(c.Exclude, &schema.ExcludedMercurialRepo{Name: excludableName})
+		}
+	case *schema.SubversionConnection:
+		exclusion := &schema.ExcludedSubversionRepo{Name: excludableName}
+		if !schemaContainsExclusion(c.Exclude, exclusion) {
+			c.Exclude = append(c.Exclude, &schema.ExcludedSubversionRepo{Name: excludableName})
+		}
 	}
 
 	strConfig, err := json.Marshal(config)
@@ -436,6 +452,12 @@ func ExcludableRepoName(repository *types.Repo, logger log.Logger) (name string)
 		} else {
 			logger.Error("invalid repo metadata schema", log.String("extSvcType", extsvc.TypeGerrit))
 		}
+	case extsvc.TypeGitea:
+		if repo, ok := repository.Metadata.(*gitea.Repository); ok {
+			name = repo.FullName
+		} else {
+			logger.Error("invalid repo metadata schema", log.String("extSvcType", extsvc.TypeGitea))
+		}
 	case extsvc.TypeGitHub:
 		if repo, ok := repository.Metadata.(*github.Repository); ok {
 			name = repo.NameWithOwner
@@ -454,6 +476,10 @@ func ExcludableRepoName(repository *types.Repo, logger log.Logger) (name string)
 		} else {
 			logger.Error("invalid repo metadata schema", log.String("extSvcType", extsvc.TypeGitolite))
 		}
+	case extsvc.TypeMercurial, extsvc.TypeSubversion:
+		// Mercurial and Subversion exclusions match the name after applying
+		// repositoryPathPattern.
+		name = string(repository.Name)
 	}
 	return
 }
@@ -134,6 +134,8 @@ func TestRepoExcludableRepoName(t *testing.T) {
 		"Successful parsing of Gitolite repo excludable name":        {repo: makeGitoliteRepo(), expectedName: "vegeta"},
 		"GitoliteRepo doesn't have a name, empty result":             {repo: makeGitoliteRepoParams(true, false), expectedName: ""},
 		"GitoliteRepo doesn't have metadata, empty result":           {repo: makeGitoliteRepoParams(false, false), expectedName: ""},
+		"Successful parsing of Mercurial repo excludable name":       {repo: typestest.MakeRepo("hg.mozilla.org/mozilla-central", "https://hg.mozilla.org", extsvc.TypeMercurial), expectedName: "hg.mozilla.org/mozilla-central"},
+		"Successful parsing of Subversion repo excludable name":      {repo: typestest.MakeRepo("svn.apache.org/repos/asf/commons-lang", "https://svn.apache.org", extsvc.TypeSubversion), expectedName: "svn.apache.org/repos/asf/commons-lang"},
 	}
 
 	for testName, testCase := range testCases {
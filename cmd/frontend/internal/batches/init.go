@@ -10,13 +10,21 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/batches/resolvers"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/batches/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/insights"
+	insightsdb "github.com/sourcegraph/sourcegraph/internal/insights/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	searchjobs "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/service"
+	searchjobsstore "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/store"
 )
 
 // Init initializes the given enterpriseServices to include the required
@@ -37,9 +45,14 @@ func Init(
 	// Initialize store.
 	bstore := store.New(db, observationCtx, keyring.Default().BatchChangesCredentialKey)
 
+	storedResults, err := initStoredResults(ctx, observationCtx, db)
+	if err != nil {
+		return err
+	}
+
 	// Register enterprise services.
 	logger := sglog.Scoped("Batches")
-	enterpriseServices.BatchChangesResolver = resolvers.New(db, bstore, gitserver.NewClient("graphql.batches"), logger)
+	enterpriseServices.BatchChangesResolver = resolvers.New(db, bstore, gitserver.NewClient("graphql.batches"), storedResults, logger)
 	gitserverClient := gitserver.NewClient("http.batches.webhook")
 	enterpriseServices.BatchesGitHubWebhook = webhooks.NewGitHubWebhook(bstore, gitserverClient.Scoped("github"), logger)
 	enterpriseServices.BatchesBitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(bstore, gitserverClient.Scoped("bitbucketserver"), logger)
@@ -55,3 +68,29 @@ func Init(
 
 	return nil
 }
+
+// initStoredResults returns the StoredResults used to preview workspaces of
+// batch specs that target search jobs or insight series.
+func initStoredResults(ctx context.Context, observationCtx *observation.Context, db database.DB) (service.StoredResults, error) {
+	var searchJobs *searchjobs.Service
+	if exhaustive.IsEnabled(conf.Get()) {
+		uploadStore, err := search.NewObjectStorage(ctx, observationCtx, search.ObjectStorageConfigInst)
+		if err != nil {
+			return nil, err
+		}
+		// Only the results of completed search jobs are read, so no searcher
+		// is needed.
+		searchJobs = searchjobs.New(observationCtx, searchjobsstore.New(db, observationCtx), uploadStore, nil)
+	}
+
+	var insightsDB database.InsightsDB
+	if insights.IsEnabled() {
+		var err error
+		insightsDB, err = insightsdb.InitializeCodeInsightsDB(observationCtx, "frontend")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return service.NewStoredResults(db, searchJobs, insightsDB), nil
+}
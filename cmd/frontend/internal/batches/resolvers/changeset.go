@@ -14,8 +14,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
 	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	bgql "github.com/sourcegraph/sourcegraph/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/batches/syncer"
@@ -52,6 +54,10 @@ type changesetResolver struct {
 	specOnce sync.Once
 	spec     *btypes.ChangesetSpec
 	specErr  error
+
+	prerequisitesOnce sync.Once
+	prerequisites     []*btypes.Changeset
+	prerequisitesErr  error
 }
 
 func NewChangesetResolverWithNextSync(store *store.Store, gitserverClient gitserver.Client, logger log.Logger, changeset *btypes.Changeset, repo *types.Repo, nextSyncAt time.Time) *changesetResolver {
@@ -112,6 +118,15 @@ func (r *changesetResolver) computeSpec(ctx context.Context) (*btypes.ChangesetS
 	return r.spec, r.specErr
 }
 
+func (r *changesetResolver) computePrerequisites(ctx context.Context) ([]*btypes.Changeset, error) {
+	r.prerequisitesOnce.Do(func() {
+		// Whether the changeset is held back doesn't depend on which repositories
+		// the viewer can see, so the dependencies are resolved as the internal actor.
+		r.prerequisites, r.prerequisitesErr = reconciler.LoadPrerequisites(sgactor.WithInternalActor(ctx), r.store, r.changeset)
+	})
+	return r.prerequisites, r.prerequisitesErr
+}
+
 func (r *changesetResolver) computeNextSyncAt(ctx context.Context) (time.Time, error) {
 	r.nextSyncAtOnce.Do(func() {
 		if r.attemptedPreloadNextSyncAt {
@@ -434,6 +449,44 @@ func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.Vis
 	return NewChangesetSpecResolverWithRepo(r.store, r.repo, spec), nil
 }
 
+func (r *changesetResolver) DependsOn(ctx context.Context) ([]graphqlbackend.ChangesetResolver, error) {
+	prerequisites, err := r.computePrerequisites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(prerequisites) == 0 {
+		return []graphqlbackend.ChangesetResolver{}, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(prerequisites))
+	for _, c := range prerequisites {
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	// Changesets in repositories the viewer can't see resolve to hidden changesets.
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetResolver, 0, len(prerequisites))
+	for _, c := range prerequisites {
+		resolvers = append(resolvers, NewChangesetResolver(r.store, r.gitserverClient, r.logger, c, reposByID[c.RepoID]))
+	}
+	return resolvers, nil
+}
+
+func (r *changesetResolver) HeldByDependencies(ctx context.Context) (bool, error) {
+	if r.changeset.Published() && r.changeset.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false, nil
+	}
+
+	prerequisites, err := r.computePrerequisites(ctx)
+	if err != nil {
+		return false, err
+	}
+	return !reconciler.PrerequisitesMerged(prerequisites), nil
+}
+
 func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.ChangesetLabelResolver, error) {
 	if !r.changeset.Published() {
 		return []graphqlbackend.ChangesetLabelResolver{}, nil
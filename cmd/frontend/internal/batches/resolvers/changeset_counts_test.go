@@ -200,7 +200,7 @@ func TestChangesetCountsOverTimeIntegration(t *testing.T) {
 		}
 	}
 
-	s, err := newSchema(db, New(db, bstore, gitserver.NewMockClient(), logger))
+	s, err := newSchema(db, New(db, bstore, gitserver.NewMockClient(), nil, logger))
 	if err != nil {
 		t.Fatal(err)
 	}
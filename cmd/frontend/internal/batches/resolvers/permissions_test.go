@@ -50,7 +50,7 @@ func TestPermissionLevels(t *testing.T) {
 	key := et.TestKey{}
 
 	bstore := store.New(db, observation.TestContextTB(t), key)
-	sr := New(db, bstore, gitserver.NewMockClient(), logger)
+	sr := New(db, bstore, gitserver.NewMockClient(), nil, logger)
 	s, err := newSchema(db, sr)
 	if err != nil {
 		t.Fatal(err)
@@ -45,13 +45,14 @@ import (
 type Resolver struct {
 	store           *store.Store
 	gitserverClient gitserver.Client
+	storedResults   service.StoredResults
 	db              database.DB
 	logger          log.Logger
 }
 
 // New returns a new Resolver whose store uses the given database
-func New(db database.DB, store *store.Store, gitserverClient gitserver.Client, logger log.Logger) graphqlbackend.BatchChangesResolver {
-	return &Resolver{store: store, gitserverClient: gitserverClient, db: db, logger: logger}
+func New(db database.DB, store *store.Store, gitserverClient gitserver.Client, storedResults service.StoredResults, logger log.Logger) graphqlbackend.BatchChangesResolver {
+	return &Resolver{store: store, gitserverClient: gitserverClient, storedResults: storedResults, db: db, logger: logger}
 }
 
 // batchChangesCreateAccess returns true if the current user has batch changes enabled for
@@ -231,7 +232,7 @@ func (r *Resolver) ResolveWorkspacesForBatchSpec(ctx context.Context, args *grap
 	}
 
 	// Run the resolution.
-	resolver := service.NewWorkspaceResolver(r.store)
+	resolver := service.NewWorkspaceResolver(r.store, r.storedResults)
 	workspaces, err := resolver.ResolveWorkspacesForBatchSpec(ctx, evaluatableSpec)
 	if err != nil {
 		return nil, err
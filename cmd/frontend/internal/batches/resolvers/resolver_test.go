@@ -52,7 +52,7 @@ func TestNullIDResilience(t *testing.T) {
 	logger := logtest.Scoped(t)
 
 	db := database.NewDB(logger, dbtest.NewDB(t))
-	sr := New(db, store.New(db, observation.TestContextTB(t), nil), gitserver.NewMockClient(), logger)
+	sr := New(db, store.New(db, observation.TestContextTB(t), nil), gitserver.NewMockClient(), nil, logger)
 
 	s, err := newSchema(db, sr)
 	if err != nil {
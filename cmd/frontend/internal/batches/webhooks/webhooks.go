@@ -12,12 +12,16 @@ import (
 	"github.com/inconshreveable/log15" //nolint:logging // TODO move all logging to sourcegraph/log
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/batches/syncer"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -172,14 +176,56 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	previousState := cs.ExternalState
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// Changesets that depend on a changeset that was just merged may be
+	// published now, and they can't be if it was closed.
+	if cs.OwnedByBatchChangeID != 0 && store.HeldChangesetsAffected(previousState, cs.ExternalState) {
+		return tx.EnqueueHeldChangesets(ctx, cs.OwnedByBatchChangeID)
+	}
+
+	// The event may have been the approval or the passing check that the
+	// auto-merge policy of the batch change waits for. Merging talks to the
+	// code host, so it only happens once the new state is committed.
+	if cs.OwnedByBatchChangeID != 0 && cs.ExternalState == btypes.ChangesetExternalStateOpen {
+		basestore.AfterCommit(tx, func() { h.autoMergeChangeset(ctx, cs, r, events) })
+	}
+
 	return nil
 }
 
+// autoMergeChangeset merges the changeset if it satisfies the auto-merge policy
+// of its batch change. Failures are only logged: the changeset syncer tries
+// again on the next sync.
+func (h webhook) autoMergeChangeset(ctx context.Context, cs *btypes.Changeset, repo *types.Repo, events []*btypes.ChangesetEvent) {
+	logger := h.logger.With(sglog.Int64("changeset", cs.ID))
+
+	policy, err := syncer.AutoMergePolicy(ctx, h.Store, cs)
+	if err != nil {
+		logger.Warn("loading auto-merge policy", sglog.Error(err))
+		return
+	}
+	if policy == nil {
+		return
+	}
+
+	source, err := sources.NewSourcer(httpcli.ExternalClientFactory).ForChangeset(ctx, h.Store, cs, repo, sources.SourcerOpts{
+		AuthenticationStrategy: sources.AuthenticationStrategyUserCredential,
+	})
+	if err != nil {
+		logger.Warn("loading changeset source", sglog.Error(err))
+		return
+	}
+
+	if err := syncer.AutoMergeChangeset(ctx, h.Store, h.gitserverClient, source, repo, cs, events, policy); err != nil {
+		logger.Warn("auto-merging changeset", sglog.Error(err))
+	}
+}
+
 type httpError struct {
 	code int
 	err  error
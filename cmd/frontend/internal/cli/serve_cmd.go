@@ -331,6 +331,7 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 			GitLabSyncWebhook:               enterprise.ReposGitLabWebhook,
 			BitbucketServerSyncWebhook:      enterprise.ReposBitbucketServerWebhook,
 			BitbucketCloudSyncWebhook:       enterprise.ReposBitbucketCloudWebhook,
+			GiteaSyncWebhook:                enterprise.ReposGiteaWebhook,
 			PermissionsGitHubWebhook:        enterprise.PermissionsGitHubWebhook,
 			BatchesGitHubWebhook:            enterprise.BatchesGitHubWebhook,
 			BatchesGitLabWebhook:            enterprise.BatchesGitLabWebhook,
@@ -49,6 +49,8 @@ func RepoSourceCloneURLToRepoName(ctx context.Context, db database.DB, cloneURL
 			extsvc.KindAWSCodeCommit,
 			extsvc.KindGitolite,
 			extsvc.KindPhabricator,
+			extsvc.KindMercurial,
+			extsvc.KindSubversion,
 			extsvc.KindOther,
 		},
 		LimitOffset: &database.LimitOffset{
@@ -119,6 +121,9 @@ func getRepoNameFromService(ctx context.Context, cloneURL string, svc *types.Ext
 	case *schema.GerritConnection:
 		rs = reposource.Gerrit{GerritConnection: c}
 		host = c.Url
+	case *schema.GiteaConnection:
+		rs = reposource.Gitea{GiteaConnection: c}
+		host = c.Url
 	case *schema.AWSCodeCommitConnection:
 		rs = reposource.AWS{AWSCodeCommitConnection: c}
 		// AWS type does not have URL
@@ -134,6 +139,12 @@ func getRepoNameFromService(ctx context.Context, cloneURL string, svc *types.Ext
 	case *schema.OtherExternalServiceConnection:
 		rs = reposource.Other{OtherExternalServiceConnection: c}
 		host = c.Url
+	case *schema.MercurialConnection:
+		rs = reposource.Mercurial{MercurialConnection: c}
+		host = c.Url
+	case *schema.SubversionConnection:
+		rs = reposource.Subversion{SubversionConnection: c}
+		host = c.Url
 	default:
 		return "", errors.Errorf("unexpected connection type: %T", cfg)
 	}
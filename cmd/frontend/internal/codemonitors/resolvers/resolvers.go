@@ -169,8 +169,8 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 		}
 
 		// Save the snapshotted commit IDs
-		for repoID, commitIDs := range resolvedRevisions {
-			err = tx.db.CodeMonitors().UpsertLastSearched(ctx, m.ID, repoID, commitIDs)
+		for repoID, snapshot := range resolvedRevisions {
+			err = snapshot.Save(ctx, tx.db.CodeMonitors(), m.ID, repoID)
 			if err != nil {
 				return err
 			}
@@ -291,6 +291,7 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				IncludeResults: a.Email.IncludeResults,
 				Priority:       a.Email.Priority,
 				Header:         a.Email.Header,
+				Digest:         toDigest(a.Email.Digest),
 			})
 			if err != nil {
 				return err
@@ -308,19 +309,35 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err := validateSlackURL(a.SlackWebhook.URL); err != nil {
 				return err
 			}
-			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL)
+			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL, toDigest(a.SlackWebhook.Digest))
+			if err != nil {
+				return err
+			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.TemplatedWebhook != nil:
+			if err := background.ValidateWebhookTemplate(a.TemplatedWebhook.Body); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTemplatedWebhookAction(ctx, monitorID, a.TemplatedWebhook.Enabled, a.TemplatedWebhook.IncludeResults, a.TemplatedWebhook.URL, a.TemplatedWebhook.Body)
 			if err != nil {
 				return err
 			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, TeamsWebhook, or TemplatedWebhook must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook, templatedWebhook []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -335,8 +352,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
+		case monitorActionTemplatedWebhookKind:
+			templatedWebhook = append(templatedWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, teams webhook, or templated webhook")
 		}
 	}
 
@@ -352,6 +373,14 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
+	if err := r.db.CodeMonitors().DeleteTemplatedWebhookActions(ctx, monitorID, templatedWebhook...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -432,6 +461,36 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamsURL(args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) TriggerTestTemplatedWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTemplatedWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTemplatedWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TemplatedWebhook.URL, args.TemplatedWebhook.Body); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -463,7 +522,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	templatedWebhookActions, err := r.db.CodeMonitors().ListTemplatedWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions)+len(templatedWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -473,6 +540,12 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
+	for _, templatedWebhookAction := range templatedWebhookActions {
+		ids = append(ids, (&monitorTemplatedWebhook{TemplatedWebhookAction: templatedWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -517,6 +590,26 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
+		case a.TemplatedWebhook != nil:
+			if a.TemplatedWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TemplatedWebhook: a.TemplatedWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TemplatedWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TemplatedWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TemplatedWebhook.Id)
 		}
 	}
 
@@ -571,8 +664,8 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 		if err != nil {
 			return nil, err
 		}
-		for repoID, commitIDs := range resolvedRevisions {
-			err = r.db.CodeMonitors().UpsertLastSearched(ctx, monitorID, repoID, commitIDs)
+		for repoID, snapshot := range resolvedRevisions {
+			err = snapshot.Save(ctx, r.db.CodeMonitors(), monitorID, repoID)
 			if err != nil {
 				return nil, err
 			}
@@ -603,8 +696,18 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
+		case action.TemplatedWebhook != nil:
+			if err := background.ValidateWebhookTemplate(action.TemplatedWebhook.Update.Body); err != nil {
+				return nil, err
+			}
+			err = r.updateTemplatedWebhookAction(ctx, *action.TemplatedWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, teams webhook, or templated webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -631,6 +734,7 @@ func (r *Resolver) updateEmailAction(ctx context.Context, args graphqlbackend.Ed
 		IncludeResults: args.Update.IncludeResults,
 		Priority:       args.Update.Priority,
 		Header:         args.Update.Header,
+		Digest:         toDigest(args.Update.Digest),
 	})
 	if err != nil {
 		return err
@@ -656,7 +760,29 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, toDigest(args.Update.Digest))
+	return err
+}
+
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateTemplatedWebhookAction(ctx context.Context, args graphqlbackend.EditActionTemplatedWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTemplatedWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, args.Update.Body)
 	return err
 }
 
@@ -740,16 +866,20 @@ func (m *monitorConnection) PageInfo() *gqlutil.PageInfo {
 }
 
 const (
-	MonitorKind                        = "CodeMonitor"
-	monitorTriggerQueryKind            = "CodeMonitorTriggerQuery"
-	monitorTriggerEventKind            = "CodeMonitorTriggerEvent"
-	monitorActionEmailKind             = "CodeMonitorActionEmail"
-	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
-	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
-	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
-	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
-	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
-	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
+	MonitorKind                            = "CodeMonitor"
+	monitorTriggerQueryKind                = "CodeMonitorTriggerQuery"
+	monitorTriggerEventKind                = "CodeMonitorTriggerEvent"
+	monitorActionEmailKind                 = "CodeMonitorActionEmail"
+	monitorActionWebhookKind               = "CodeMonitorActionWebhook"
+	monitorActionSlackWebhookKind          = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind          = "CodeMonitorActionTeamsWebhook"
+	monitorActionTemplatedWebhookKind      = "CodeMonitorActionTemplatedWebhook"
+	monitorActionEmailEventKind            = "CodeMonitorActionEmailEvent"
+	monitorActionWebhookEventKind          = "CodeMonitorActionWebhookEvent"
+	monitorActionSlackWebhookEventKind     = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionTeamsWebhookEventKind     = "CodeMonitorActionTeamsWebhookEvent"
+	monitorActionTemplatedWebhookEventKind = "CodeMonitorActionTemplatedWebhookEvent"
+	monitorActionEmailRecipientKind        = "CodeMonitorActionEmailRecipient"
 )
 
 func unmarshalMonitorID(id graphql.ID) (int64, error) {
@@ -841,7 +971,17 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tpws, err := r.db.CodeMonitors().ListTemplatedWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws)+len(tpws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -869,6 +1009,24 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
+	for _, tpw := range tpws {
+		actions = append(actions, &action{
+			templatedWebhook: &monitorTemplatedWebhook{
+				Resolver:               r,
+				TemplatedWebhookAction: tpw,
+				triggerEventID:         triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1051,9 +1209,11 @@ func (a *monitorActionConnection) PageInfo() *gqlutil.PageInfo {
 
 // Action <<UNION>>
 type action struct {
-	email        graphqlbackend.MonitorEmailResolver
-	webhook      graphqlbackend.MonitorWebhookResolver
-	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	email            graphqlbackend.MonitorEmailResolver
+	webhook          graphqlbackend.MonitorWebhookResolver
+	slackWebhook     graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook     graphqlbackend.MonitorTeamsWebhookResolver
+	templatedWebhook graphqlbackend.MonitorTemplatedWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1064,6 +1224,10 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
+	case a.templatedWebhook != nil:
+		return a.templatedWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1081,6 +1245,14 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
+func (a *action) ToMonitorTemplatedWebhook() (graphqlbackend.MonitorTemplatedWebhookResolver, bool) {
+	return a.templatedWebhook, a.templatedWebhook != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1150,6 +1322,10 @@ func (m *monitorEmail) Header() string {
 	return m.EmailAction.Header
 }
 
+func (m *monitorEmail) Digest() *string {
+	return digestEnum(m.EmailAction.Digest)
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.EmailAction.ID)
 }
@@ -1266,6 +1442,10 @@ func (m *monitorSlackWebhook) URL() string {
 	return m.SlackWebhookAction.URL
 }
 
+func (m *monitorSlackWebhook) Digest() *string {
+	return digestEnum(m.SlackWebhookAction.Digest)
+}
+
 func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1296,6 +1476,122 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorTeamsWebhook struct {
+	*Resolver
+	*database.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL() string {
+	return m.TeamsWebhookAction.URL
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          pointers.Ptr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorTemplatedWebhook struct {
+	*Resolver
+	*database.TemplatedWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTemplatedWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTemplatedWebhookKind, m.TemplatedWebhookAction.ID)
+}
+
+func (m *monitorTemplatedWebhook) Enabled() bool {
+	return m.TemplatedWebhookAction.Enabled
+}
+
+func (m *monitorTemplatedWebhook) IncludeResults() bool {
+	return m.TemplatedWebhookAction.IncludeResults
+}
+
+func (m *monitorTemplatedWebhook) URL() string {
+	return m.TemplatedWebhookAction.URL
+}
+
+func (m *monitorTemplatedWebhook) Body() string {
+	return m.TemplatedWebhookAction.Body
+}
+
+func (m *monitorTemplatedWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		TemplatedWebhookID: pointers.Ptr(int(m.TemplatedWebhookAction.ID)),
+		TriggerEventID:     m.triggerEventID,
+		First:              pointers.Ptr(int(args.First)),
+		After:              after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		TemplatedWebhookID: pointers.Ptr(int(m.TemplatedWebhookAction.ID)),
+		TriggerEventID:     m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
 		return nil
@@ -1376,6 +1672,23 @@ func (m *monitorActionEvent) Timestamp() gqlutil.DateTime {
 	return gqlutil.DateTime{Time: *m.FinishedAt}
 }
 
+// toDigest converts a MonitorDigest GraphQL enum value to the digest period
+// stored in the database. A nil value disables the digest.
+func toDigest(digest *string) database.CodeMonitorDigest {
+	if digest == nil {
+		return ""
+	}
+	return database.CodeMonitorDigest(strings.ToLower(*digest))
+}
+
+func digestEnum(digest database.CodeMonitorDigest) *string {
+	if digest == "" {
+		return nil
+	}
+	s := strings.ToUpper(string(digest))
+	return &s
+}
+
 func validateSlackURL(urlString string) error {
 	u, err := url.Parse(urlString)
 	if err != nil {
@@ -1388,3 +1701,18 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Teams webhooks are hosted on different domains depending on whether
+	// they were created with a connector or a workflow, so we can only
+	// enforce HTTPS.
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("Microsoft Teams webhook URL must begin with 'https://'")
+	}
+	return nil
+}
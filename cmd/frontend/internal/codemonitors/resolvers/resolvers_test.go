@@ -85,6 +85,35 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid teams webhook", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TeamsWebhook: &graphqlbackend.CreateActionTeamsWebhookArgs{
+					URL: "http://example.webhook.office.com",
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid webhook template", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TemplatedWebhook: &graphqlbackend.CreateActionTemplatedWebhookArgs{
+					URL:  "https://mattermost.example.com/hooks/abc",
+					Body: `{"text": {{ json .Description }}}`,
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("invalid query", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -402,7 +431,7 @@ func TestQueryMonitor(t *testing.T) {
 		// To have a consistent state we have to log the number of search results for
 		// each completed trigger job.
 		func() error {
-			return r.db.CodeMonitors().UpdateTriggerJobWithResults(ctx, 1, "", make([]*result.CommitMatch, 1))
+			return r.db.CodeMonitors().UpdateTriggerJobWithResults(ctx, 1, "", result.Matches{&result.CommitMatch{}})
 		},
 	})
 	_, err = r.insertTestMonitorWithOpts(ctx, t, actionOpt, postHookOpt)
@@ -1356,3 +1385,37 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	valid := []string{
+		"https://example.webhook.office.com/webhookb2/8d8d8/IncomingWebhook/838383",
+		"https://prod-00.westus.logic.azure.com:443/workflows/8d8d8/triggers/manual/paths/invoke",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateTeamsURL(url))
+	}
+
+	invalid := []string{
+		"http://example.webhook.office.com/webhookb2",
+		"example.webhook.office.com",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
+
+func TestDigest(t *testing.T) {
+	require.Equal(t, database.CodeMonitorDigest(""), toDigest(nil))
+	require.Nil(t, digestEnum(""))
+
+	for _, digest := range []string{"HOURLY", "DAILY", "WEEKLY"} {
+		d := toDigest(&digest)
+		require.NotEmpty(t, d)
+		require.Equal(t, &digest, digestEnum(d))
+	}
+
+	daily := "DAILY"
+	require.Equal(t, database.CodeMonitorDigestDaily, toDigest(&daily))
+}
@@ -0,0 +1,180 @@
+package executorqueue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/executor/cache"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/object"
+)
+
+// maxCacheUploadSize is the maximum size of a single cache archive.
+const maxCacheUploadSize = 2 << 30 // 2GB
+
+// cacheHandler serves the caches that executor jobs keep between runs. The archives
+// are kept in object storage, their records are used for eviction.
+//
+// Cache entries are scoped to the namespace and repository of the job, as returned by
+// the cache scope of its queue. The key sent by the executor only names the entry within
+// that scope, so that a job can't restore or overwrite the caches of other jobs.
+type cacheHandler struct {
+	logger  log.Logger
+	store   store.CacheEntryStore
+	storage object.Storage
+	scopes  map[string]handler.CacheScopeFunc
+}
+
+func newCacheHandler(logger log.Logger, cacheStore store.CacheEntryStore, storage object.Storage, scopes map[string]handler.CacheScopeFunc) *cacheHandler {
+	return &cacheHandler{
+		logger:  logger.Scoped("cache"),
+		store:   cacheStore,
+		storage: storage,
+		scopes:  scopes,
+	}
+}
+
+func (h *cacheHandler) setupRoutes(router *mux.Router) {
+	router.Path("/{queueName}/{key}").Methods(http.MethodHead).HandlerFunc(h.handleExists)
+	router.Path("/{queueName}/{key}").Methods(http.MethodGet).HandlerFunc(h.handleGet)
+	router.Path("/{queueName}/{key}").Methods(http.MethodPut).HandlerFunc(h.handlePut)
+}
+
+func (h *cacheHandler) handleExists(w http.ResponseWriter, r *http.Request) {
+	queue, key, ok := h.cacheEntryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	exists, err := h.store.Exists(r.Context(), queue, key)
+	if err != nil {
+		h.logger.Error("failed to check cache entry existence", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "failed to check cache entry existence", http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *cacheHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	queue, key, ok := h.cacheEntryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	found, err := h.store.MarkAccessed(r.Context(), queue, key)
+	if err != nil {
+		h.logger.Error("failed to mark cache entry as accessed", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "failed to retrieve cache entry", http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "cache entry does not exist", http.StatusNotFound)
+		return
+	}
+
+	rc, err := h.storage.Get(r.Context(), cache.ObjectKey(queue, key))
+	if err != nil {
+		h.logger.Warn("failed to retrieve cache archive", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "cache entry does not exist", http.StatusNotFound)
+		return
+	}
+	defer rc.Close()
+
+	// Some backends only report errors on the first read. An archive that can't be read
+	// (for example because it was evicted concurrently) is reported as a cache miss, so
+	// that the job continues without it.
+	br := bufio.NewReader(rc)
+	if _, err := br.Peek(1); err != nil {
+		h.logger.Warn("failed to read cache archive", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "cache entry does not exist", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, br); err != nil {
+		h.logger.Error("failed to write cache archive to client", log.String("queue", queue), log.String("key", key), log.Error(err))
+	}
+}
+
+func (h *cacheHandler) handlePut(w http.ResponseWriter, r *http.Request) {
+	queue, key, ok := h.cacheEntryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCacheUploadSize)
+	size, err := h.storage.Upload(r.Context(), cache.ObjectKey(queue, key), r.Body)
+	if err != nil {
+		h.logger.Error("failed to upload cache archive", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "failed to upload cache archive", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.store.Upsert(r.Context(), queue, key, size); err != nil {
+		h.logger.Error("failed to create cache entry", log.String("queue", queue), log.String("key", key), log.Error(err))
+		http.Error(w, "failed to create cache entry", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// cacheEntryFromRequest returns the queue and scoped key of the requested cache entry.
+// Both are used in object storage keys, so they are validated here.
+func (h *cacheHandler) cacheEntryFromRequest(w http.ResponseWriter, r *http.Request) (queue string, key string, ok bool) {
+	queue = mux.Vars(r)["queueName"]
+	key = mux.Vars(r)["key"]
+	if !slices.Contains(types.ValidQueueNames, queue) {
+		http.Error(w, fmt.Sprintf("unknown queue %q", queue), http.StatusBadRequest)
+		return "", "", false
+	}
+	if err := types.ValidateCacheKey(key); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+
+	// 🚨 SECURITY: Only jobs can use caches, as the scope of the entry is derived from the
+	// job. The job token has already been checked against the queue by jobAuthMiddleware.
+	jobToken, ok := jobTokenFromContext(r.Context())
+	if !ok {
+		http.Error(w, "caches can only be used with a job token", http.StatusForbidden)
+		return "", "", false
+	}
+	scopeFunc := h.scopes[queue]
+	if scopeFunc == nil {
+		http.Error(w, fmt.Sprintf("queue %q does not support caches", queue), http.StatusBadRequest)
+		return "", "", false
+	}
+	scope, err := scopeFunc(r.Context(), int(jobToken.JobID))
+	if err != nil {
+		h.logger.Error("failed to look up cache scope", log.String("queue", queue), log.Int64("jobID", jobToken.JobID), log.Error(err))
+		http.Error(w, "failed to look up cache scope", http.StatusInternalServerError)
+		return "", "", false
+	}
+	// 🚨 SECURITY: The job token is only valid for the repository of the job, so the job
+	// must not use the caches of another repository.
+	if int64(scope.RepoID) != jobToken.RepoID {
+		h.logger.Error("repo of the job does not match the job token", log.String("queue", queue), log.Int64("jobID", jobToken.JobID))
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return "", "", false
+	}
+
+	return queue, scopedCacheKey(scope, key), true
+}
+
+// scopedCacheKey returns the key of a cache entry within the given scope.
+func scopedCacheKey(scope handler.CacheScope, key string) string {
+	return fmt.Sprintf("%s/repo-%d/%s", scope.Namespace, scope.RepoID, key)
+}
@@ -0,0 +1,141 @@
+package executorqueue
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/object/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCacheHandler(t *testing.T) {
+	cacheStore := executorstore.NewMockCacheEntryStore()
+	storage := mocks.NewMockStorage()
+
+	scopes := map[string]handler.CacheScopeFunc{
+		"batches": func(_ context.Context, jobID int) (handler.CacheScope, error) {
+			switch jobID {
+			case 42:
+				return handler.CacheScope{Namespace: "user-1", RepoID: 5678}, nil
+			case 43:
+				return handler.CacheScope{Namespace: "org-2", RepoID: 5678}, nil
+			default:
+				// The job runs in another repository than the one of its token.
+				return handler.CacheScope{Namespace: "user-1", RepoID: 1234}, nil
+			}
+		},
+	}
+
+	router := mux.NewRouter()
+	newCacheHandler(logtest.Scoped(t), cacheStore, storage, scopes).setupRoutes(router)
+
+	serveJob := func(jobToken *executorstore.JobToken, method, path string, body io.Reader) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, body)
+		req = req.WithContext(withJobToken(req.Context(), jobToken))
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		return rw
+	}
+	jobToken := &executorstore.JobToken{JobID: 42, Queue: "batches", RepoID: 5678}
+	serve := func(method, path string, body io.Reader) *httptest.ResponseRecorder {
+		return serveJob(jobToken, method, path, body)
+	}
+
+	t.Run("put", func(t *testing.T) {
+		storage.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			return io.Copy(io.Discard, r)
+		})
+
+		rw := serve(http.MethodPut, "/batches/go-mod", strings.NewReader("archive"))
+		assert.Equal(t, http.StatusOK, rw.Code)
+
+		require.Len(t, storage.UploadFunc.History(), 1)
+		assert.Equal(t, "batches/user-1/repo-5678/go-mod", storage.UploadFunc.History()[0].Arg1)
+		require.Len(t, cacheStore.UpsertFunc.History(), 1)
+		call := cacheStore.UpsertFunc.History()[0]
+		assert.Equal(t, []any{"batches", "user-1/repo-5678/go-mod", int64(7)}, []any{call.Arg1, call.Arg2, call.Arg3})
+	})
+
+	t.Run("exists", func(t *testing.T) {
+		cacheStore.ExistsFunc.PushReturn(false, nil)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodHead, "/batches/go-mod", nil).Code)
+
+		cacheStore.ExistsFunc.PushReturn(true, nil)
+		assert.Equal(t, http.StatusOK, serve(http.MethodHead, "/batches/go-mod", nil).Code)
+
+		history := cacheStore.ExistsFunc.History()
+		require.Len(t, history, 2)
+		assert.Equal(t, "user-1/repo-5678/go-mod", history[1].Arg2)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		cacheStore.MarkAccessedFunc.PushReturn(false, nil)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/batches/go-mod", nil).Code)
+		assert.Empty(t, storage.GetFunc.History())
+
+		cacheStore.MarkAccessedFunc.PushReturn(true, nil)
+		storage.GetFunc.PushReturn(io.NopCloser(strings.NewReader("archive")), nil)
+		rw := serve(http.MethodGet, "/batches/go-mod", nil)
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "archive", rw.Body.String())
+		require.Len(t, storage.GetFunc.History(), 1)
+		assert.Equal(t, "batches/user-1/repo-5678/go-mod", storage.GetFunc.History()[0].Arg1)
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		cacheStore.MarkAccessedFunc.PushReturn(false, nil)
+		rw := serveJob(&executorstore.JobToken{JobID: 43, Queue: "batches", RepoID: 5678}, http.MethodGet, "/batches/go-mod", nil)
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+
+		history := cacheStore.MarkAccessedFunc.History()
+		assert.Equal(t, "org-2/repo-5678/go-mod", history[len(history)-1].Arg2)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		cacheStore := executorstore.NewStrictMockCacheEntryStore()
+		storage := mocks.NewStrictMockStorage()
+		router := mux.NewRouter()
+		newCacheHandler(logtest.Scoped(t), cacheStore, storage, scopes).setupRoutes(router)
+
+		for _, method := range []string{http.MethodHead, http.MethodGet, http.MethodPut} {
+			// Requests authenticated with the executor access token carry no job.
+			req := httptest.NewRequest(method, "/batches/go-mod", strings.NewReader("archive"))
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+			assert.Equal(t, http.StatusForbidden, rw.Code, method)
+
+			// The repository of the job doesn't match the job token.
+			req = httptest.NewRequest(method, "/batches/go-mod", strings.NewReader("archive"))
+			req = req.WithContext(withJobToken(req.Context(), &executorstore.JobToken{JobID: 44, Queue: "batches", RepoID: 5678}))
+			rw = httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+			assert.Equal(t, http.StatusForbidden, rw.Code, method)
+		}
+	})
+
+	t.Run("get missing archive", func(t *testing.T) {
+		cacheStore.MarkAccessedFunc.PushReturn(true, nil)
+		storage.GetFunc.PushReturn(io.NopCloser(iotest.ErrReader(errors.New("no such key"))), nil)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/batches/go-mod", nil).Code)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/unknown/go-mod", nil).Code)
+		// The codeintel queue has no cache scope in this test.
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/codeintel/go-mod", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/batches/.hidden", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/batches/-go-mod", strings.NewReader("archive")).Code)
+	})
+}
@@ -65,11 +65,27 @@ type QueueHandler[T workerutil.Record] struct {
 	// RecordTransformer is a required hook for each registered queue that transforms a generic
 	// record from that queue into the job to be given to an executor.
 	RecordTransformer TransformerFunc[T]
+	// CacheScope is an optional hook that returns the scope of the caches the job with the
+	// given ID can use. Jobs of queues without it can't use caches.
+	CacheScope CacheScopeFunc
 }
 
 // TransformerFunc is the function to transform a workerutil.Record into an executor.Job.
 type TransformerFunc[T workerutil.Record] func(ctx context.Context, version string, record T, resourceMetadata ResourceMetadata) (executortypes.Job, error)
 
+// CacheScopeFunc is the function to look up the cache scope of a job.
+type CacheScopeFunc func(ctx context.Context, jobID int) (CacheScope, error)
+
+// CacheScope is the namespace and repository the caches of a job belong to. Jobs only share
+// caches with jobs of the same scope, so that a job can't read or overwrite the caches of
+// jobs it has no access to.
+type CacheScope struct {
+	// Namespace identifies who the job runs on behalf of, for example "user-1".
+	Namespace string
+	// RepoID is the repository the job runs in.
+	RepoID int
+}
+
 // NewHandler creates a new ExecutorHandler.
 func NewHandler[T workerutil.Record](
 	executorStore database.ExecutorStore,
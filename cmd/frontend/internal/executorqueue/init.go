@@ -1,15 +1,22 @@
 package executorqueue
 
 import (
+	"context"
+
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/executor/cache"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 )
 
+func LoadConfig() {
+	cache.ObjectStorageConfigInst.Load()
+}
+
 // Init initializes the executor endpoints required for use with the executor service.
 func Init(
 	observationCtx *observation.Context,
@@ -27,6 +34,11 @@ func Init(
 
 	logger := log.Scoped("executorqueue")
 
+	cacheStorage, err := cache.NewObjectStorage(context.Background(), observationCtx, cache.ObjectStorageConfigInst)
+	if err != nil {
+		return err
+	}
+
 	queueHandler := newExecutorQueuesHandler(
 		observationCtx,
 		db,
@@ -35,6 +47,7 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		cacheStorage,
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
@@ -1,6 +1,7 @@
 package executorqueue
 
 import (
+	"context"
 	"crypto/subtle"
 	"fmt"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/executor/store"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/object"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -31,6 +33,7 @@ func newExecutorQueuesHandler(
 	uploadHandler http.Handler,
 	batchesWorkspaceFileGetHandler http.Handler,
 	batchesWorkspaceFileExistsHandler http.Handler,
+	cacheStorage object.Storage,
 ) func() http.Handler {
 	metricsStore := metricsstore.NewDistributedStore("executors:")
 	executorStore := db.Executors()
@@ -44,6 +47,11 @@ func newExecutorQueuesHandler(
 	autoIndexQueueHandler := codeintelqueue.QueueHandler(observationCtx, db, accessToken)
 	batchesQueueHandler := batches.QueueHandler(observationCtx, db, accessToken)
 
+	cacheHandler := newCacheHandler(logger, store.NewCacheEntryStore(db), cacheStorage, map[string]handler.CacheScopeFunc{
+		autoIndexQueueHandler.Name: autoIndexQueueHandler.CacheScope,
+		batchesQueueHandler.Name:   batchesQueueHandler.CacheScope,
+	})
+
 	codeintelHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, autoIndexQueueHandler)
 	batchesHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, batchesQueueHandler)
 	handlers := []handler.ExecutorHandler{codeintelHandler, batchesHandler}
@@ -109,6 +117,14 @@ func newExecutorQueuesHandler(
 		// The scip route are treated as an internal actor and require the executor access token to authenticate.
 		scipRouter.Use(withInternalActor, executorAuth)
 
+		// Serve the caches that jobs keep between runs. This is registered before the other
+		// files routes, as caches are not restricted to the batches queue.
+		cacheRouter := base.PathPrefix("/files/cache").Name("executor-files-cache").Subrouter()
+		cacheHandler.setupRoutes(cacheRouter)
+		// The cache routes are treated as internal actor. Additionally, each job comes with a short-lived token that is
+		// checked by jobAuthMiddleware.
+		cacheRouter.Use(withInternalActor, jobAuthMiddleware(logger, routeCache, jobTokenStore, executorStore))
+
 		filesRouter := base.PathPrefix("/files").Name("executor-files").Subrouter()
 		batchChangesRouter := filesRouter.PathPrefix("/batch-changes").Subrouter()
 		batchChangesRouter.Path("/{spec}/{file}").Methods(http.MethodGet).Handler(batchesWorkspaceFileGetHandler)
@@ -125,6 +141,7 @@ func newExecutorQueuesHandler(
 type routeName string
 
 const (
+	routeCache = "cache"
 	routeFiles = "files"
 	routeGit   = "git"
 	routeQueue = "queue"
@@ -200,8 +217,8 @@ func jobAuthMiddleware(
 ) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if validateJobRequest(w, r, logger, routeName, tokenStore, executorStore) {
-				next.ServeHTTP(w, r)
+			if jobToken, ok := validateJobRequest(w, r, logger, routeName, tokenStore, executorStore); ok {
+				next.ServeHTTP(w, r.WithContext(withJobToken(r.Context(), jobToken)))
 			}
 		})
 	}
@@ -214,7 +231,7 @@ func validateJobRequest(
 	routeName routeName,
 	tokenStore store.JobTokenStore,
 	executorStore database.ExecutorStore,
-) bool {
+) (*store.JobToken, bool) {
 	// Get the auth token from the Authorization header.
 	var tokenType string
 	var authToken string
@@ -222,21 +239,21 @@ func validateJobRequest(
 		parts := strings.Split(headerValue, " ")
 		if len(parts) != 2 {
 			http.Error(w, fmt.Sprintf(`HTTP Authorization request header value must be of the following form: '%s "TOKEN"' or '%s TOKEN'`, "Bearer", "token-executor"), http.StatusUnauthorized)
-			return false
+			return nil, false
 		}
 		// Check what the token type is. For backwards compatibility sake, we should also accept the general executor
 		// access token.
 		tokenType = parts[0]
 		if tokenType != "Bearer" && tokenType != "token-executor" {
 			http.Error(w, fmt.Sprintf("unrecognized HTTP Authorization request header scheme (supported values: %q, %q)", "Bearer", "token-executor"), http.StatusUnauthorized)
-			return false
+			return nil, false
 		}
 
 		authToken = parts[1]
 	}
 	if authToken == "" {
 		http.Error(w, "no token value in the HTTP Authorization request header", http.StatusUnauthorized)
-		return false
+		return nil, false
 	}
 
 	// If the general executor access token was provided, simply check the value.
@@ -245,10 +262,10 @@ func validateJobRequest(
 		// code via timing attack. It is not important to avoid leaking the *length* of
 		// the code, because the length of verification codes is constant.
 		if subtle.ConstantTimeCompare([]byte(authToken), []byte(conf.SiteConfig().ExecutorsAccessToken)) == 1 {
-			return true
+			return nil, true
 		} else {
 			w.WriteHeader(http.StatusForbidden)
-			return false
+			return nil, false
 		}
 	}
 
@@ -260,6 +277,8 @@ func validateJobRequest(
 
 	// Each route is "special". Set additional information based on the route that is being worked with.
 	switch routeName {
+	case routeCache:
+		queue = mux.Vars(r)["queueName"]
 	case routeFiles:
 		queue = "batches"
 	case routeGit:
@@ -269,14 +288,14 @@ func validateJobRequest(
 	default:
 		logger.Error("unsupported route", log.String("route", string(routeName)))
 		http.Error(w, "unsupported route", http.StatusBadRequest)
-		return false
+		return nil, false
 	}
 
 	jobId, err = parseJobIdHeader(r)
 	if err != nil {
 		logger.Error("failed to parse jobId", log.Error(err))
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return false
+		return nil, false
 	}
 
 	// When the requester sets a User with a username, r.URL.User.Username() will return a blank value (always).
@@ -287,21 +306,21 @@ func validateJobRequest(
 	// Since the payload partially deserialize, ensure the worker hostname is valid.
 	if len(executorName) == 0 {
 		http.Error(w, "worker hostname cannot be empty", http.StatusBadRequest)
-		return false
+		return nil, false
 	}
 
 	jobToken, err := tokenStore.GetByToken(r.Context(), authToken)
 	if err != nil {
 		logger.Error("failed to retrieve token", log.Error(err))
 		http.Error(w, "invalid token", http.StatusUnauthorized)
-		return false
+		return nil, false
 	}
 
 	// Ensure the token was generated for the correct job.
 	if jobToken.JobID != jobId {
 		logger.Error("job ID does not match")
 		http.Error(w, "invalid token", http.StatusForbidden)
-		return false
+		return nil, false
 	}
 
 	// Check if the token is associated with the correct queue or repo.
@@ -309,24 +328,38 @@ func validateJobRequest(
 		if jobToken.Repo != repo {
 			logger.Error("repo does not match")
 			http.Error(w, "invalid token", http.StatusForbidden)
-			return false
+			return nil, false
 		}
 	} else {
 		// Ensure the token was generated for the correct queue.
 		if jobToken.Queue != queue {
 			logger.Error("queue name does not match")
 			http.Error(w, "invalid token", http.StatusForbidden)
-			return false
+			return nil, false
 		}
 	}
 	// Ensure the token came from a legit executor instance.
 	if _, _, err = executorStore.GetByHostname(r.Context(), executorName); err != nil {
 		logger.Error("failed to lookup executor by hostname", log.Error(err))
 		http.Error(w, "invalid token", http.StatusUnauthorized)
-		return false
+		return nil, false
 	}
 
-	return true
+	return &jobToken, true
+}
+
+type jobTokenContextKey struct{}
+
+// withJobToken returns a context carrying the job token the request was authenticated
+// with. The token is nil for requests authenticated with the executor access token.
+func withJobToken(ctx context.Context, jobToken *store.JobToken) context.Context {
+	return context.WithValue(ctx, jobTokenContextKey{}, jobToken)
+}
+
+// jobTokenFromContext returns the job token the request was authenticated with, if any.
+func jobTokenFromContext(ctx context.Context) (*store.JobToken, bool) {
+	jobToken, ok := ctx.Value(jobTokenContextKey{}).(*store.JobToken)
+	return jobToken, ok && jobToken != nil
 }
 
 func parseJobIdHeader(r *http.Request) (int64, error) {
@@ -187,6 +187,44 @@ func TestJobAuthMiddleware(t *testing.T) {
 				require.Len(t, executorStore.GetByHostnameFunc.History(), 0)
 			},
 		},
+		{
+			name:      "Cache Authorized",
+			routeName: routeCache,
+			header: map[string]string{
+				"Authorization":               "Bearer somejobtoken",
+				"X-Sourcegraph-Job-ID":        "42",
+				"X-Sourcegraph-Executor-Name": "test-executor",
+			},
+			mockFunc: func(executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				jobTokenStore.GetByTokenFunc.PushReturn(executorstore.JobToken{JobID: 42, Queue: "test"}, nil)
+				executorStore.GetByHostnameFunc.PushReturn(types.Executor{}, true, nil)
+			},
+			expectedStatusCode: http.StatusTeapot,
+			assertionFunc: func(t *testing.T, executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				require.Len(t, jobTokenStore.GetByTokenFunc.History(), 1)
+				assert.Equal(t, jobTokenStore.GetByTokenFunc.History()[0].Arg1, "somejobtoken")
+				require.Len(t, executorStore.GetByHostnameFunc.History(), 1)
+				assert.Equal(t, executorStore.GetByHostnameFunc.History()[0].Arg1, "test-executor")
+			},
+		},
+		{
+			name:      "Cache queue does not match",
+			routeName: routeCache,
+			header: map[string]string{
+				"Authorization":               "Bearer somejobtoken",
+				"X-Sourcegraph-Job-ID":        "42",
+				"X-Sourcegraph-Executor-Name": "test-executor",
+			},
+			mockFunc: func(executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				jobTokenStore.GetByTokenFunc.PushReturn(executorstore.JobToken{JobID: 42, Queue: "batches"}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "invalid token\n",
+			assertionFunc: func(t *testing.T, executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				require.Len(t, jobTokenStore.GetByTokenFunc.History(), 1)
+				require.Len(t, executorStore.GetByHostnameFunc.History(), 0)
+			},
+		},
 		{
 			name:      "No worker hostname provided",
 			routeName: routeQueue,
@@ -430,3 +468,41 @@ func TestJobAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestJobAuthMiddleware_JobToken(t *testing.T) {
+	logger := logtest.Scoped(t)
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExecutorsAccessToken: "hunter2"}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	executorStore := dbmocks.NewMockExecutorStore()
+	executorStore.GetByHostnameFunc.SetDefaultReturn(types.Executor{}, true, nil)
+	jobTokenStore := executorstore.NewMockJobTokenStore()
+	jobTokenStore.GetByTokenFunc.SetDefaultReturn(executorstore.JobToken{JobID: 42, Queue: "batches", RepoID: 5678}, nil)
+
+	var jobToken *executorstore.JobToken
+	router := mux.NewRouter()
+	router.HandleFunc("/{queueName}", func(w http.ResponseWriter, r *http.Request) {
+		jobToken, _ = jobTokenFromContext(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	})
+	router.Use(jobAuthMiddleware(logger, routeCache, jobTokenStore, executorStore))
+
+	serve := func(authorization string) {
+		jobToken = nil
+		req := httptest.NewRequest(http.MethodGet, "/batches", nil)
+		req.Header.Add("Authorization", authorization)
+		req.Header.Add("X-Sourcegraph-Job-ID", "42")
+		req.Header.Add("X-Sourcegraph-Executor-Name", "test-executor")
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		require.Equal(t, http.StatusTeapot, rw.Code)
+	}
+
+	// The job token is passed on to the handler, so that it can scope the request to the job.
+	serve("Bearer somejobtoken")
+	assert.Equal(t, &executorstore.JobToken{JobID: 42, Queue: "batches", RepoID: 5678}, jobToken)
+
+	// Requests authenticated with the executor access token have no job.
+	serve("token-executor hunter2")
+	assert.Nil(t, jobToken)
+}
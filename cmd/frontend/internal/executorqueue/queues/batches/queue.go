@@ -2,6 +2,7 @@ package batches
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sourcegraph/log"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func QueueHandler(observationCtx *observation.Context, db database.DB, _ func() string) handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob] {
@@ -20,10 +22,42 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, _ func()
 		return transformRecord(ctx, logger, batchesStore, record, version)
 	}
 
+	cacheScopeFunc := func(ctx context.Context, jobID int) (handler.CacheScope, error) {
+		batchesStore := bstore.New(db, observationCtx, nil)
+		job, err := batchesStore.GetBatchSpecWorkspaceExecutionJob(ctx, bstore.GetBatchSpecWorkspaceExecutionJobOpts{ID: int64(jobID), ExcludeRank: true})
+		if err != nil {
+			return handler.CacheScope{}, errors.Wrapf(err, "fetching workspace execution job %d", jobID)
+		}
+		return cacheScope(ctx, batchesStore, job)
+	}
+
 	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())
 	return handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{
 		Name:              "batches",
 		Store:             store,
 		RecordTransformer: recordTransformer,
+		CacheScope:        cacheScopeFunc,
+	}
+}
+
+// cacheScope returns the cache scope of a workspace execution job. Caches are shared by the
+// batch specs of the same namespace in the same repository.
+func cacheScope(ctx context.Context, s BatchesStore, job *btypes.BatchSpecWorkspaceExecutionJob) (handler.CacheScope, error) {
+	workspace, err := s.GetBatchSpecWorkspace(ctx, bstore.GetBatchSpecWorkspaceOpts{ID: job.BatchSpecWorkspaceID})
+	if err != nil {
+		return handler.CacheScope{}, errors.Wrapf(err, "fetching workspace %d", job.BatchSpecWorkspaceID)
+	}
+
+	batchSpec, err := s.GetBatchSpec(ctx, bstore.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return handler.CacheScope{}, errors.Wrap(err, "fetching batch spec")
+	}
+
+	var namespace string
+	if batchSpec.NamespaceOrgID != 0 {
+		namespace = fmt.Sprintf("org-%d", batchSpec.NamespaceOrgID)
+	} else {
+		namespace = fmt.Sprintf("user-%d", batchSpec.NamespaceUserID)
 	}
+	return handler.CacheScope{Namespace: namespace, RepoID: int(workspace.RepoID)}, nil
 }
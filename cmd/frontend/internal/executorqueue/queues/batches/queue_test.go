@@ -0,0 +1,34 @@
+package batches
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func TestCacheScope(t *testing.T) {
+	store := NewMockBatchesStore()
+	store.GetBatchSpecWorkspaceFunc.SetDefaultHook(func(_ context.Context, opts bstore.GetBatchSpecWorkspaceOpts) (*btypes.BatchSpecWorkspace, error) {
+		return &btypes.BatchSpecWorkspace{ID: opts.ID, BatchSpecID: opts.ID * 10, RepoID: 5678}, nil
+	})
+	store.GetBatchSpecFunc.SetDefaultHook(func(_ context.Context, opts bstore.GetBatchSpecOpts) (*btypes.BatchSpec, error) {
+		if opts.ID == 10 {
+			return &btypes.BatchSpec{ID: opts.ID, UserID: 1, NamespaceUserID: 1}, nil
+		}
+		return &btypes.BatchSpec{ID: opts.ID, UserID: 1, NamespaceOrgID: 2}, nil
+	})
+
+	scope, err := cacheScope(context.Background(), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, BatchSpecWorkspaceID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, handler.CacheScope{Namespace: "user-1", RepoID: 5678}, scope)
+
+	scope, err = cacheScope(context.Background(), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 43, BatchSpecWorkspaceID: 2})
+	require.NoError(t, err)
+	assert.Equal(t, handler.CacheScope{Namespace: "org-2", RepoID: 5678}, scope)
+}
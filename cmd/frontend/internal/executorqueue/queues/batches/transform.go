@@ -260,9 +260,10 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 			})
 
 			dockerSteps = append(dockerSteps, apiclient.DockerStep{
-				Key:   executorutil.FormatRunKey(i),
-				Image: step.Container,
-				Dir:   runDir,
+				Key:    executorutil.FormatRunKey(i),
+				Image:  step.Container,
+				Dir:    runDir,
+				Caches: stepCaches(runDir, step.Caches),
 				// Invoke the script file but also write stdout and stderr to separate files, which will then be
 				// consumed by the post step to build the AfterStepResult.
 				Commands: []string{
@@ -344,3 +345,33 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 
 	return aj, nil
 }
+
+// stepCaches returns the caches of a step with their paths made relative to the workspace,
+// instead of to the directory the step runs in.
+func stepCaches(runDir string, caches []batcheslib.StepCache) []apiclient.StepCache {
+	if len(caches) == 0 {
+		return nil
+	}
+
+	stepCaches := make([]apiclient.StepCache, 0, len(caches))
+	for _, cache := range caches {
+		stepCaches = append(stepCaches, apiclient.StepCache{
+			Key:      cache.Key,
+			KeyFiles: joinPaths(runDir, cache.KeyFiles),
+			Paths:    joinPaths(runDir, cache.Paths),
+		})
+	}
+	return stepCaches
+}
+
+func joinPaths(dir string, paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	joined := make([]string, 0, len(paths))
+	for _, p := range paths {
+		joined = append(joined, path.Join(dir, p))
+	}
+	return joined
+}
@@ -397,3 +397,64 @@ steps:
 		mockassert.CalledN(t, sal.CreateFunc, 5)
 	})
 }
+
+func TestTransformRecord_StepCaches(t *testing.T) {
+	db := dbmocks.NewMockDB()
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/sourcegraph/sourcegraph"}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.ExecutorSecretsFunc.SetDefaultReturn(dbmocks.NewMockExecutorSecretStore())
+	db.ExecutorSecretAccessLogsFunc.SetDefaultReturn(dbmocks.NewMockExecutorSecretAccessLogStore())
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://test.io"}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+	})
+
+	spec := batcheslib.BatchSpec{}
+	err := yaml.Unmarshal([]byte(`
+steps:
+  - run: npm ci
+    container: node:20
+    caches:
+      - key: node-modules
+        keyFiles:
+          - package-lock.json
+        paths:
+          - node_modules
+  - run: npm run fix
+    container: node:20
+`), &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMockBatchesStore()
+	store.GetBatchSpecFunc.SetDefaultReturn(&btypes.BatchSpec{UserID: 123, NamespaceUserID: 123, Spec: &spec}, nil)
+	store.GetBatchSpecWorkspaceFunc.SetDefaultReturn(&btypes.BatchSpecWorkspace{RepoID: 5678, Commit: "d34db33f", Path: "web"}, nil)
+	store.DatabaseDBFunc.SetDefaultReturn(db)
+
+	job, err := transformRecord(context.Background(), logtest.Scoped(t), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 123, Version: 2}, "0.0.0-dev")
+	if err != nil {
+		t.Fatalf("unexpected error transforming record: %s", err)
+	}
+
+	caches := map[string][]apiclient.StepCache{}
+	for _, step := range job.DockerSteps {
+		if len(step.Caches) > 0 {
+			caches[step.Key] = step.Caches
+		}
+	}
+	// Cache paths are relative to the directory the step runs in, but the executor
+	// resolves them relative to the workspace.
+	expected := map[string][]apiclient.StepCache{
+		"step.0.run": {
+			{Key: "node-modules", KeyFiles: []string{"repository/web/package-lock.json"}, Paths: []string{"repository/web/node_modules"}},
+		},
+	}
+	if diff := cmp.Diff(expected, caches); diff != "" {
+		t.Errorf("unexpected caches (-want +got):\n%s", diff)
+	}
+}
@@ -2,6 +2,9 @@ package codeintel
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing"
@@ -10,6 +13,7 @@ import (
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func QueueHandler(observationCtx *observation.Context, db database.DB, accessToken func() string) handler.QueueHandler[uploadsshared.AutoIndexJob] {
@@ -19,9 +23,36 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, accessTok
 
 	store := dbworkerstore.New(observationCtx, db.Handle(), autoindexing.IndexWorkerStoreOptions)
 
+	cacheScopeFunc := func(ctx context.Context, jobID int) (handler.CacheScope, error) {
+		return cacheScope(ctx, db, jobID)
+	}
+
 	return handler.QueueHandler[uploadsshared.AutoIndexJob]{
 		Name:              "codeintel",
 		Store:             store,
 		RecordTransformer: recordTransformer,
+		CacheScope:        cacheScopeFunc,
+	}
+}
+
+// cacheScope returns the cache scope of an auto-indexing job. Jobs that were enqueued by a
+// user don't share caches with the jobs enqueued automatically, as the user may have chosen
+// the steps of the job.
+func cacheScope(ctx context.Context, db database.DB, jobID int) (handler.CacheScope, error) {
+	q := sqlf.Sprintf(cacheScopeQuery, jobID)
+
+	var repositoryID, enqueuerUserID int
+	if err := db.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&repositoryID, &enqueuerUserID); err != nil {
+		return handler.CacheScope{}, errors.Wrapf(err, "fetching auto-indexing job %d", jobID)
 	}
+
+	namespace := "auto"
+	if enqueuerUserID != 0 {
+		namespace = fmt.Sprintf("user-%d", enqueuerUserID)
+	}
+	return handler.CacheScope{Namespace: namespace, RepoID: repositoryID}, nil
 }
+
+const cacheScopeQuery = `
+SELECT repository_id, enqueuer_user_id FROM lsif_indexes WHERE id = %s
+`
@@ -0,0 +1,43 @@
+package codeintel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCacheScope(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO repo (id, name) VALUES (50, 'github.com/sourcegraph/sourcegraph')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO lsif_indexes (id, commit, repository_id, docker_steps, root, indexer, indexer_args, outfile, local_steps, enqueuer_user_id)
+		VALUES
+			(1, 'deadbeef', 50, '{}', '', 'lsif-go', '{}', '', '{}', 0),
+			(2, 'deadbeef', 50, '{}', '', 'lsif-go', '{}', '', '{}', 7)
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	scope, err := cacheScope(ctx, db, 1)
+	require.NoError(t, err)
+	assert.Equal(t, handler.CacheScope{Namespace: "auto", RepoID: 50}, scope)
+
+	scope, err = cacheScope(ctx, db, 2)
+	require.NoError(t, err)
+	assert.Equal(t, handler.CacheScope{Namespace: "user-7", RepoID: 50}, scope)
+
+	_, err = cacheScope(ctx, db, 3)
+	assert.Error(t, err)
+}
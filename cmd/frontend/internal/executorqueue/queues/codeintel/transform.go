@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
 const (
@@ -81,6 +83,7 @@ func transformRecord(ctx context.Context, db database.DB, autoIndexJob uploadssh
 			Commands: dockerStep.Commands,
 			Dir:      dockerStep.Root,
 			Env:      envVars,
+			Caches:   stepCaches(dockerStep.Root, dockerStep.Caches),
 		})
 	}
 
@@ -189,6 +192,36 @@ const (
 	defaultDiskSpace = "20G"
 )
 
+// stepCaches returns the caches of a step with their paths made relative to the workspace,
+// instead of to the root of the step.
+func stepCaches(root string, caches []config.StepCache) []apiclient.StepCache {
+	if len(caches) == 0 {
+		return nil
+	}
+
+	stepCaches := make([]apiclient.StepCache, 0, len(caches))
+	for _, cache := range caches {
+		stepCaches = append(stepCaches, apiclient.StepCache{
+			Key:      cache.Key,
+			KeyFiles: joinPaths(root, cache.KeyFiles),
+			Paths:    joinPaths(root, cache.Paths),
+		})
+	}
+	return stepCaches
+}
+
+func joinPaths(root string, paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	joined := make([]string, 0, len(paths))
+	for _, p := range paths {
+		joined = append(joined, path.Join(root, p))
+	}
+	return joined
+}
+
 func makeResourceEnvironment(resourceMetadata handler.ResourceMetadata) []string {
 	env := []string{}
 	addBytesValuesVariables := func(value, defaultValue, prefix string) {
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	srccli "github.com/sourcegraph/sourcegraph/internal/src-cli"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -247,6 +248,51 @@ func TestTransformRecordWithoutIndexer(t *testing.T) {
 	}
 }
 
+func TestTransformRecordWithCaches(t *testing.T) {
+	db := dbmocks.NewMockDB()
+	db.ExecutorSecretsFunc.SetDefaultReturn(dbmocks.NewMockExecutorSecretStore())
+
+	index := uploadsshared.NewAutoIndexJob(config.AutoIndexJobSpec{
+		Steps: []config.DockerStep{
+			{
+				Image:    "node",
+				Commands: []string{"yarn", "install"},
+				Root:     "web",
+				Caches: []config.StepCache{
+					{Key: "node-modules", KeyFiles: []string{"yarn.lock"}, Paths: []string{"node_modules", "../.yarn/cache"}},
+				},
+			},
+		},
+		Root:    "web",
+		Indexer: "lsif-node",
+	}, 50, "deadbeef", uploadsshared.JobStateQueued)
+	index.ID = 42
+	index.RepositoryName = "linux"
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://test.io"}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+	})
+
+	job, err := transformRecord(context.Background(), db, index, handler.ResourceMetadata{}, "hunter2")
+	if err != nil {
+		t.Fatalf("unexpected error transforming record: %s", err)
+	}
+
+	// Cache paths are relative to the root of the step, but the executor resolves them
+	// relative to the workspace.
+	expected := []apiclient.StepCache{
+		{Key: "node-modules", KeyFiles: []string{"web/yarn.lock"}, Paths: []string{"web/node_modules", ".yarn/cache"}},
+	}
+	if diff := cmp.Diff(expected, job.DockerSteps[0].Caches); diff != "" {
+		t.Errorf("unexpected caches (-want +got):\n%s", diff)
+	}
+	for _, step := range job.DockerSteps[1:] {
+		if len(step.Caches) != 0 {
+			t.Errorf("unexpected caches for step %q: %v", step.Key, step.Caches)
+		}
+	}
+}
+
 func TestTransformRecordWithSecrets(t *testing.T) {
 	db := dbmocks.NewMockDB()
 	secs := dbmocks.NewMockExecutorSecretStore()
@@ -43,6 +43,7 @@ func newTest(t *testing.T) *httptestutil.Client {
 			GitLabSyncWebhook:               enterpriseServices.ReposGitLabWebhook,
 			BitbucketServerSyncWebhook:      enterpriseServices.ReposBitbucketServerWebhook,
 			BitbucketCloudSyncWebhook:       enterpriseServices.ReposBitbucketCloudWebhook,
+			GiteaSyncWebhook:                enterpriseServices.ReposGiteaWebhook,
 			BatchesBitbucketServerWebhook:   enterpriseServices.BatchesBitbucketServerWebhook,
 			BatchesBitbucketCloudWebhook:    enterpriseServices.BatchesBitbucketCloudWebhook,
 			BatchesAzureDevOpsWebhook:       enterpriseServices.BatchesAzureDevOpsWebhook,
@@ -55,6 +55,7 @@ type Handlers struct {
 	GitLabSyncWebhook          webhooks.Registerer
 	BitbucketServerSyncWebhook webhooks.Registerer
 	BitbucketCloudSyncWebhook  webhooks.Registerer
+	GiteaSyncWebhook           webhooks.Registerer
 
 	// Permissions
 	PermissionsGitHubWebhook webhooks.Registerer
@@ -139,6 +140,7 @@ func NewHandler(
 	handlers.BatchesBitbucketCloudWebhook.Register(&wh)
 	handlers.GitHubSyncWebhook.Register(&wh)
 	handlers.GitLabSyncWebhook.Register(&wh)
+	handlers.GiteaSyncWebhook.Register(&wh)
 	handlers.PermissionsGitHubWebhook.Register(&wh)
 	handlers.BatchesAzureDevOpsWebhook.Register(&wh)
 	// Second: register handler on main router
@@ -153,6 +155,8 @@ func NewHandler(
 	m.Path("/gitlab-webhooks").Methods("POST").Handler(webhookMiddleware.Logger(handlers.BatchesGitLabWebhook))
 	m.Path("/bitbucket-server-webhooks").Methods("POST").Handler(webhookMiddleware.Logger(handlers.BatchesBitbucketServerWebhook))
 	m.Path("/bitbucket-cloud-webhooks").Methods("POST").Handler(webhookMiddleware.Logger(handlers.BatchesBitbucketCloudWebhook))
+	giteaWebhook := webhooks.GiteaWebhook{Router: &wh}
+	m.Path("/gitea-webhooks").Methods("POST").Handler(webhookMiddleware.Logger(&giteaWebhook))
 
 	// Other routes
 	m.Path("/files/batch-changes/{spec}/{file}").Methods("GET").Handler(handlers.BatchesChangesFileGetHandler)
@@ -170,7 +174,7 @@ func NewHandler(
 	m.Path("/src-cli/{rest:.*}").Methods("GET").Handler(newSrcCliVersionHandler(logger))
 	m.Path("/insights/export/{id}").Methods("GET").Handler(handlers.CodeInsightsDataExportHandler)
 	m.Path("/search/stream").Methods("GET").Handler(frontendsearch.StreamHandler(db))
-	m.Path("/search/export/{id}.jsonl").Methods("GET").Handler(handlers.SearchJobsDataExportHandler)
+	m.Path("/search/export/{id}.{ext:jsonl|ndjson|csv}").Methods("GET").Handler(handlers.SearchJobsDataExportHandler)
 	m.Path("/search/export/{id}.log").Methods("GET").Handler(handlers.SearchJobsLogsHandler)
 
 	m.Path("/completions/stream").Methods("POST").Handler(handlers.NewChatCompletionsStreamHandler())
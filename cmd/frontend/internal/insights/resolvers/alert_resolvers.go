@@ -0,0 +1,192 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/alerts"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+var _ graphqlbackend.InsightSeriesAlertActionResolver = &insightSeriesAlertActionResolver{}
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if !actr.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	seriesAlerts, err := r.alertStore.GetAlerts(ctx, store.AlertQueryArgs{SeriesID: args.SeriesId, UserID: actr.UID})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(seriesAlerts))
+	for _, alert := range seriesAlerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if !actr.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	// 🚨 SECURITY: alerts can only be created on series that are part of an insight the user can view. The
+	// series is also checked when the alert is evaluated, in case the user loses access later on.
+	userIDs, orgIDs, err := getUserPermissions(ctx, r.postgresDB.Orgs())
+	if err != nil {
+		return nil, errors.Wrap(err, "getUserPermissions")
+	}
+	views, err := r.insightStore.GetAll(ctx, store.InsightQueryArgs{
+		SeriesID: args.Input.SeriesId,
+		UserIDs:  userIDs,
+		OrgIDs:   orgIDs,
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, errors.Newf("insight series %q not found", args.Input.SeriesId)
+	}
+
+	alert := types.InsightSeriesAlert{
+		SeriesID:  args.Input.SeriesId,
+		UserID:    actr.UID,
+		Kind:      types.AlertKind(fromGraphQLEnum(args.Input.Kind)),
+		Intervals: 1,
+	}
+	if args.Input.Threshold != nil {
+		alert.Threshold = *args.Input.Threshold
+	}
+	if args.Input.Intervals != nil {
+		alert.Intervals = int(*args.Input.Intervals)
+	}
+	for _, action := range args.Input.Actions {
+		a := types.InsightSeriesAlertAction{Type: types.AlertActionType(fromGraphQLEnum(action.Type))}
+		if action.Url != nil {
+			a.URL = *action.Url
+		}
+		alert.Actions = append(alert.Actions, a)
+	}
+	if err := alerts.Validate(alert); err != nil {
+		return nil, err
+	}
+
+	created, err := r.alertStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlert")
+	}
+	return &insightSeriesAlertResolver{alert: created}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	actr := actor.FromContext(ctx)
+	if !actr.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal insight series alert id")
+	}
+	seriesAlerts, err := r.alertStore.GetAlerts(ctx, store.AlertQueryArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(seriesAlerts) == 0 {
+		return nil, errors.New("insight series alert not found")
+	}
+
+	// 🚨 SECURITY: only the owner of an alert or a site admin can delete it.
+	if seriesAlerts[0].UserID != actr.UID {
+		if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.alertStore.DeleteAlert(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// fromGraphQLEnum converts a GraphQL enum value such as ABSOLUTE_THRESHOLD to
+// its stored form, absolute-threshold.
+func fromGraphQLEnum(value string) string {
+	return strings.ReplaceAll(strings.ToLower(value), "_", "-")
+}
+
+// toGraphQLEnum is the inverse of fromGraphQLEnum.
+func toGraphQLEnum(value string) string {
+	return strings.ReplaceAll(strings.ToUpper(value), "-", "_")
+}
+
+type insightSeriesAlertResolver struct {
+	alert types.InsightSeriesAlert
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string {
+	return r.alert.SeriesID
+}
+
+func (r *insightSeriesAlertResolver) Kind() string {
+	return toGraphQLEnum(string(r.alert.Kind))
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64 {
+	return r.alert.Threshold
+}
+
+func (r *insightSeriesAlertResolver) Intervals() int32 {
+	return int32(r.alert.Intervals)
+}
+
+func (r *insightSeriesAlertResolver) Actions() []graphqlbackend.InsightSeriesAlertActionResolver {
+	resolvers := make([]graphqlbackend.InsightSeriesAlertActionResolver, 0, len(r.alert.Actions))
+	for _, action := range r.alert.Actions {
+		resolvers = append(resolvers, &insightSeriesAlertActionResolver{action: action})
+	}
+	return resolvers
+}
+
+func (r *insightSeriesAlertResolver) LastRecordingTime() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.alert.LastRecordingTime)
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.alert.CreatedAt}
+}
+
+type insightSeriesAlertActionResolver struct {
+	action types.InsightSeriesAlertAction
+}
+
+func (r *insightSeriesAlertActionResolver) Type() string {
+	return toGraphQLEnum(string(r.action.Type))
+}
+
+func (r *insightSeriesAlertActionResolver) Url() *string {
+	if r.action.URL == "" {
+		return nil
+	}
+	return &r.action.URL
+}
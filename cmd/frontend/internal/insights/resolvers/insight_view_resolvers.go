@@ -340,6 +340,10 @@ func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromCaptureGroups()
 	return s.series.GeneratedFromCaptureGroups, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) LanguageStatistics() (bool, error) {
+	return s.series.GenerationMethod == types.HistoricalLanguageStats, nil
+}
+
 func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 	if s.series.GroupBy != nil {
 		groupBy := strings.ToUpper(*s.series.GroupBy)
@@ -612,7 +616,7 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 	// Capture group insight only have 1 associated insight series at most.
 	captureGroupInsight := false
 	for _, newSeries := range args.Input.DataSeries {
-		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) {
+		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) || isLanguageStatsSeries(newSeries.LanguageStatistics) {
 			captureGroupInsight = true
 			break
 		}
@@ -666,6 +670,16 @@ func isCaptureGroupSeries(generatedFromCaptureGroups *bool) bool {
 	return *generatedFromCaptureGroups
 }
 
+// isLanguageStatsSeries returns true if the series records line counts per
+// language. Like capture group series, such an insight has a single series that
+// expands into one series per language.
+func isLanguageStatsSeries(languageStatistics *bool) bool {
+	if languageStatistics == nil {
+		return false
+	}
+	return *languageStatistics
+}
+
 func updateCaptureGroupInsight(ctx context.Context, input graphqlbackend.LineChartSearchInsightDataSeriesInput, existingSeries []types.InsightViewSeries, view types.InsightView, tx *store.InsightStore, seriesFillStrategy fillSeriesStrategy) error {
 	if len(existingSeries) == 0 {
 		// This should not happen, but if we somehow have no existing series for an insight, create one.
@@ -773,6 +787,9 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 			return true
 		}
 	}
+	if isLanguageStatsSeries(new.LanguageStatistics) != (existing.GenerationMethod == types.HistoricalLanguageStats) {
+		return true
+	}
 	return emptyIfNil(new.GroupBy) != emptyIfNil(existing.GroupBy)
 }
 
@@ -1316,7 +1333,14 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	var err error
 	var dynamic bool
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+	if isLanguageStatsSeries(series.LanguageStatistics) {
+		if series.GroupBy != nil {
+			return errors.New("language statistics series cannot be grouped")
+		}
+		if _, err := querybuilder.ParseQuery(series.Query, "literal"); err != nil {
+			return errors.Wrap(err, "query validation")
+		}
+	} else if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
 		if _, err := querybuilder.ParseComputeQuery(series.Query, gitserver.NewClient("graphql.insights.computequery")); err != nil {
 			return errors.Wrap(err, "query validation")
 		}
@@ -1329,6 +1353,10 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	if series.GeneratedFromCaptureGroups != nil {
 		dynamic = *series.GeneratedFromCaptureGroups
 	}
+	if isLanguageStatsSeries(series.LanguageStatistics) {
+		// Each language found in the matched repositories becomes its own series.
+		dynamic = true
+	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
 	var nextRecordingAfter time.Time
@@ -1350,6 +1378,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			StepIntervalValue:         int(series.TimeScope.StepInterval.Value),
 			GenerateFromCaptureGroups: dynamic,
 			GroupBy:                   groupBy,
+			GenerationMethod:          searchGenerationMethod(series),
 		})
 		if err != nil {
 			return errors.Wrap(err, "FindMatchingSeries")
@@ -1399,6 +1428,9 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 }
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if isLanguageStatsSeries(series.LanguageStatistics) {
+		return types.HistoricalLanguageStats
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
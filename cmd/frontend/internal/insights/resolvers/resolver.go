@@ -66,6 +66,7 @@ type Resolver struct {
 	insightMetadataStore store.InsightMetadataStore
 	dataSeriesStore      store.DataSeriesStore
 	insightEnqueuer      *background.InsightEnqueuer
+	alertStore           *store.AlertStore
 
 	baseInsightResolver
 }
@@ -86,6 +87,7 @@ func newWithClock(db edb.InsightsDB, postgres database.DB, clock func() time.Tim
 		insightMetadataStore: base.insightStore,
 		dataSeriesStore:      base.insightStore,
 		insightEnqueuer:      background.NewInsightEnqueuer(clock, base.workerBaseStore, log.Scoped("resolver insight enqueuer")),
+		alertStore:           store.NewAlertStore(db),
 	}
 }
 
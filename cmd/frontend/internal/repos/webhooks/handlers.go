@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
@@ -37,6 +38,7 @@ func Init(
 	enterpriseServices.ReposGitLabWebhook = NewGitLabHandler()
 	enterpriseServices.ReposBitbucketServerWebhook = NewBitbucketServerHandler()
 	enterpriseServices.ReposBitbucketCloudWebhook = NewBitbucketCloudHandler()
+	enterpriseServices.ReposGiteaWebhook = NewGiteaHandler()
 
 	enterpriseServices.WebhooksResolver = resolvers.NewWebhooksResolver(db)
 	return nil
@@ -156,6 +158,36 @@ func bitbucketCloudExternalIDFromEvent(event *bitbucketcloud.PushEvent) (string,
 	return event.Repository.UUID, nil
 }
 
+type GiteaHandler struct {
+	logger log.Logger
+}
+
+func NewGiteaHandler() *GiteaHandler {
+	return &GiteaHandler{
+		logger: log.Scoped("webhooks.GiteaHandler"),
+	}
+}
+
+func (g *GiteaHandler) Register(router *webhooks.Router) {
+	router.Register(func(ctx context.Context, db database.DB, baseURL extsvc.CodeHostBaseURL, payload any) error {
+		return g.handlePushEvent(ctx, db, baseURL, payload)
+	}, extsvc.KindGitea, gitea.PushEventType)
+}
+
+func (g *GiteaHandler) handlePushEvent(ctx context.Context, db database.DB, baseURL extsvc.CodeHostBaseURL, payload any) error {
+	return handlePushEvent[*gitea.PushEvent](ctx, db, g.logger, extsvc.TypeGitea, baseURL, payload, giteaExternalIDFromEvent)
+}
+
+func giteaExternalIDFromEvent(event *gitea.PushEvent) (string, error) {
+	if event == nil {
+		return "", errors.New("nil PushEvent received")
+	}
+	if event.Repository == nil || event.Repository.ID == 0 {
+		return "", errors.New("repository ID not found in PushEvent")
+	}
+	return strconv.FormatInt(event.Repository.ID, 10), nil
+}
+
 // handlePushEvent takes a push payload and a function to extract the repo
 // clone URL from the event. It then uses the clone URL to find a repo and queues
 // a repo update.
@@ -31,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
@@ -324,3 +325,49 @@ func TestBitbucketCloudHandler(t *testing.T) {
 	}
 	assert.Equal(t, repoName, updateQueued)
 }
+
+func TestGiteaHandler(t *testing.T) {
+	repoName := "gitea.sgdev.org/sourcegraph/src-cli"
+
+	db := dbmocks.NewMockDB()
+	repositories := dbmocks.NewMockRepoStore()
+	repositories.ListFunc.SetDefaultHook(func(ctx context.Context, rlo database.ReposListOptions) ([]*types.Repo, error) {
+		require.Equal(t, rlo.ExternalRepos, []api.ExternalRepoSpec{
+			{
+				ID:          "3",
+				ServiceType: "gitea",
+				ServiceID:   "https://gitea.sgdev.org/",
+			},
+		})
+		return []*types.Repo{{Name: api.RepoName(repoName)}}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repositories)
+
+	handler := NewGiteaHandler()
+	data, err := os.ReadFile("testdata/gitea-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload gitea.PushEvent
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var updateQueued string
+	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+		updateQueued = string(repo)
+		return &protocol.RepoUpdateResponse{
+			ID:   1,
+			Name: string(repo),
+		}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+
+	baseURL, err := extsvc.NewCodeHostBaseURL("https://gitea.sgdev.org")
+	require.NoError(t, err)
+
+	if err := handler.handlePushEvent(context.Background(), db, baseURL, &payload); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, repoName, updateQueued)
+}
@@ -33,14 +33,20 @@ func ServeSearchJobDownload(logger log.Logger, svc *service.Service) http.Handle
 			return
 		}
 
-		writerTo, err := svc.GetSearchJobResultsWriterTo(r.Context(), int64(jobID))
+		job, err := svc.GetSearchJob(r.Context(), jobID)
 		if err != nil {
 			httpError(w, err)
 			return
 		}
 
-		filename := filenamePrefix(jobID) + ".jsonl"
-		writeJSON(logger.With(log.Int64("jobID", jobID)), w, filename, writerTo)
+		writerTo, err := svc.GetSearchJobResultsWriterTo(r.Context(), jobID)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+
+		filename := filenamePrefix(jobID) + "." + job.ResultsFormat.FileExtension()
+		writeResults(logger.With(log.Int64("jobID", jobID)), w, filename, job.ResultsFormat.ContentType(), writerTo)
 	}
 }
 
@@ -113,8 +119,8 @@ func writeCSV(logger log.Logger, w http.ResponseWriter, filenameNoQuotes string,
 	}
 }
 
-func writeJSON(logger log.Logger, w http.ResponseWriter, filenameNoQuotes string, writerTo io.WriterTo) {
-	w.Header().Set("Content-Type", "application/jsonlines")
+func writeResults(logger log.Logger, w http.ResponseWriter, filenameNoQuotes, contentType string, writerTo io.WriterTo) {
+	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filenameNoQuotes))
 	w.WriteHeader(200)
 	n, err := writerTo.WriteTo(w)
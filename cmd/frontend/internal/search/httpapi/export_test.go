@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/service"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/store"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
 	"github.com/sourcegraph/sourcegraph/lib/iterator"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -67,7 +68,7 @@ func TestServeSearchJobDownload(t *testing.T) {
 		userCtx := actor.WithActor(context.Background(), &actor.Actor{
 			UID: userID,
 		})
-		_, err = svc.CreateSearchJob(userCtx, "1@rev1")
+		_, err = svc.CreateSearchJob(userCtx, "1@rev1", types.ResultsFormatJSON, nil)
 		require.NoError(t, err)
 
 		req, err := http.NewRequest(http.MethodGet, "/1.json", nil)
@@ -79,7 +80,25 @@ func TestServeSearchJobDownload(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/jsonlines", w.Header().Get("Content-Type"))
 		require.Equal(t, "", w.Body.String())
+
+		// CSV results start with a header even without blobs.
+		_, err = svc.CreateSearchJob(userCtx, "1@rev1", types.ResultsFormatCSV, []string{"repository", "path"})
+		require.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/2.json", nil)
+		require.NoError(t, err)
+
+		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: userID}))
+		w = httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+		require.Equal(t, "repository,path\n", w.Body.String())
 	}
 
 	// wrong user
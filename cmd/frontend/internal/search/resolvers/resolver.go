@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/service"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/store"
+	exhaustivetypes "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -40,7 +41,21 @@ func New(logger log.Logger, db database.DB, svc *service.Service) graphqlbackend
 var _ graphqlbackend.SearchJobsResolver = &Resolver{}
 
 func (r *Resolver) CreateSearchJob(ctx context.Context, args *graphqlbackend.CreateSearchJobArgs) (graphqlbackend.SearchJobResolver, error) {
-	job, err := r.svc.CreateSearchJob(ctx, args.Query)
+	var format exhaustivetypes.ResultsFormat
+	if args.Format != nil {
+		var err error
+		format, err = exhaustivetypes.ParseResultsFormat(*args.Format)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var columns []string
+	if args.Columns != nil {
+		columns = *args.Columns
+	}
+
+	job, err := r.svc.CreateSearchJob(ctx, args.Query, format, columns)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -76,7 +77,7 @@ func (r *searchJobResolver) FinishedAt(ctx context.Context) *gqlutil.DateTime {
 }
 
 func (r *searchJobResolver) URL(ctx context.Context) (*string, error) {
-	exportPath, err := url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/search/export/%d.jsonl", r.Job.ID))
+	exportPath, err := url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/search/export/%d.%s", r.Job.ID, r.Job.ResultsFormat.FileExtension()))
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +92,20 @@ func (r *searchJobResolver) LogURL(ctx context.Context) (*string, error) {
 	return pointers.Ptr(exportPath), nil
 }
 
+func (r *searchJobResolver) ResultsFormat() string {
+	return strings.ToUpper(string(r.Job.ResultsFormat))
+}
+
+func (r *searchJobResolver) ResultsColumns() *[]string {
+	if r.Job.ResultsFormat == types.ResultsFormatJSON {
+		return nil
+	}
+	if len(r.Job.ResultsColumns) == 0 {
+		return &types.DefaultResultsColumns
+	}
+	return &r.Job.ResultsColumns
+}
+
 func (r *searchJobResolver) RepoStats(ctx context.Context) (graphqlbackend.SearchJobStatsResolver, error) {
 	repoRevStats, err := r.svc.GetAggregateRepoRevState(ctx, r.Job.ID)
 	if err != nil {
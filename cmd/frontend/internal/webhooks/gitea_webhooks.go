@@ -0,0 +1,157 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GiteaWebhook serves webhooks sent to the per external service endpoint
+// (.api/gitea-webhooks?externalServiceID=N), whose secrets are configured in
+// the "webhooks" field of the connection.
+type GiteaWebhook struct {
+	*Router
+}
+
+func (h *GiteaWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.Scoped("ServeGiteaWebhook")
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Error while reading request body.", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	extSvc, config, err := h.getExternalService(r.Context(), r.FormValue(extsvc.IDParam))
+	if err != nil {
+		logger.Error("Could not find valid external service for webhook", log.Error(err))
+		if errcode.IsNotFound(err) {
+			http.Error(w, "External service not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error validating payload", http.StatusBadRequest)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	if err := validateAnyConfiguredGiteaSecret(config, gitea.WebhookSignature(r), body); err != nil {
+		http.Error(w, "Could not validate payload with secret.", http.StatusBadRequest)
+		return
+	}
+
+	codeHostURN, err := extsvc.NewCodeHostBaseURL(config.Url)
+	if err != nil {
+		logger.Error("Could not parse code host URL from config", log.Error(err))
+		http.Error(w, "Invalid code host URL", http.StatusInternalServerError)
+		return
+	}
+
+	h.HandleGiteaWebhook(logger, w, r, codeHostURN, body)
+}
+
+// HandleGiteaWebhook parses an already authenticated Gitea webhook payload and
+// dispatches it to the registered handlers.
+func (wr *Router) HandleGiteaWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, codeHostURN extsvc.CodeHostBaseURL, payload []byte) {
+	// 🚨 SECURITY: now that the payload and shared secret have been validated,
+	// we can use an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	eventType := gitea.WebhookEventType(r)
+	e, err := gitea.ParseWebhookEvent(eventType, payload)
+	if err != nil {
+		if errors.Is(err, gitea.ErrEventTypeUnknown) {
+			// Gitea retries failed deliveries, so we acknowledge events we
+			// don't care about.
+			logger.Debug("unknown event type", log.Error(err))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = wr.Dispatch(ctx, eventType, extsvc.KindGitea, codeHostURN, e)
+	if err != nil {
+		logger.Error("Error handling gitea webhook event", log.Error(err))
+		if errcode.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *GiteaWebhook) getExternalService(ctx context.Context, rawID string) (*types.ExternalService, *schema.GiteaConnection, error) {
+	if rawID == "" {
+		return nil, nil, errors.Errorf("missing %s parameter", extsvc.IDParam)
+	}
+
+	externalServiceID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := h.DB.ExternalServices().GetByID(ctx, externalServiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := e.Configuration(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gc, ok := c.(*schema.GiteaConnection)
+	if !ok {
+		return nil, nil, errors.Errorf("invalid configuration, received gitea webhook for non-gitea external service: %v", externalServiceID)
+	}
+	return e, gc, nil
+}
+
+func validateAnyConfiguredGiteaSecret(c *schema.GiteaConnection, sig string, body []byte) error {
+	// 🚨 SECURITY: If secrets are configured, one of them must have been used
+	// to sign the payload.
+	var configured bool
+	for _, hook := range c.Webhooks {
+		if hook.Secret == "" {
+			continue
+		}
+		configured = true
+
+		if err := gitea.ValidateSignature(sig, body, []byte(hook.Secret)); err == nil {
+			return nil
+		}
+	}
+
+	if !configured {
+		// No secret configured, nothing to validate against.
+		return nil
+	}
+	return errors.Errorf("unable to validate webhook signature")
+}
+
+func handleGiteaWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, urn extsvc.CodeHostBaseURL, secret string, wr *Router) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Error while reading request body.", http.StatusInternalServerError)
+		return
+	}
+
+	if secret != "" {
+		if err := gitea.ValidateSignature(gitea.WebhookSignature(r), payload, []byte(secret)); err != nil {
+			http.Error(w, "Could not validate payload with secret.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	wr.HandleGiteaWebhook(logger, w, r, urn, payload)
+}
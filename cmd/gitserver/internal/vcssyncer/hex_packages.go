@@ -0,0 +1,132 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewHexPackagesSyncer(
+	connection *schema.HexPackagesConnection,
+	svc *dependencies.Service,
+	client *hex.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("HexPackagesSyncer"),
+		typ:                "hex_packages",
+		scheme:             dependencies.HexPackagesScheme,
+		placeholder:        reposource.NewHexVersionedPackage("sourcegraph_placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &hexPackagesSyncer{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type hexPackagesSyncer struct {
+	client *hex.Client
+	fs     gitserverfs.FS
+}
+
+func (hexPackagesSyncer) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseHexVersionedPackage(string(name) + "@" + version)
+}
+
+func (hexPackagesSyncer) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseHexVersionedPackage(dep)
+}
+
+func (hexPackagesSyncer) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseHexPackageFromName(name)
+}
+
+func (hexPackagesSyncer) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseHexPackageFromRepoName(repoName)
+}
+
+func (s *hexPackagesSyncer) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Hex package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	mkdirTemp := func() (string, error) {
+		return s.fs.TempDir("hex-packages")
+	}
+
+	if err = unpackHexPackage(pkgContents, mkdirTemp, dir); err != nil {
+		return errors.Wrapf(err, "failed to unpack Hex package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackHexPackage unpacks the files of the given Hex package tarball into
+// workDir. The package metadata is kept as hex_metadata.config, which is where
+// Mix puts it when it fetches a dependency.
+func unpackHexPackage(pkg io.Reader, mkdirTemp func() (string, error), workDir string) error {
+	tmpDir, err := mkdirTemp()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			return path == "contents.tar.gz" || path == "metadata.config"
+		},
+	}
+	if err := unpack.Tar(pkg, tmpDir, opts); err != nil {
+		return errors.Wrap(err, "failed to unpack downloaded tar")
+	}
+
+	contents, err := os.Open(filepath.Join(tmpDir, "contents.tar.gz"))
+	if err != nil {
+		return errors.Wrap(err, "failed to read contents archive")
+	}
+	defer contents.Close()
+
+	opts = unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+	if err := unpack.Tgz(contents, workDir, opts); err != nil {
+		return err
+	}
+
+	metadata, err := os.ReadFile(filepath.Join(tmpDir, "metadata.config"))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workDir, "hex_metadata.config"), metadata, 0o644)
+}
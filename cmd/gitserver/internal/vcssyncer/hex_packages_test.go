@@ -0,0 +1,60 @@
+package vcssyncer
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackHexPackage(t *testing.T) {
+	contents := createTgz(t, []fileInfo{
+		{path: "mix.exs", contents: []byte("defmodule Jason.MixProject do end")},
+		{path: "lib/jason.ex", contents: []byte("defmodule Jason do end")},
+		{path: "../../evil.ex", contents: []byte("pwned")},
+	})
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	for _, f := range []fileInfo{
+		{path: "VERSION", contents: []byte("3")},
+		{path: "CHECKSUM", contents: []byte("ABCDEF")},
+		{path: "metadata.config", contents: []byte(`{<<"name">>,<<"jason">>}.`)},
+		{path: "contents.tar.gz", contents: contents},
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.path, Mode: 0o644, Size: int64(len(f.contents))}))
+		_, err := tw.Write(f.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	tmp := t.TempDir()
+	workDir := filepath.Join(tmp, "work")
+	require.NoError(t, os.Mkdir(workDir, 0o755))
+	err := unpackHexPackage(&tarBuf, func() (string, error) { return os.MkdirTemp(tmp, "hex") }, workDir)
+	require.NoError(t, err)
+
+	var got []string
+	require.NoError(t, filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, workDir))
+		}
+		return nil
+	}))
+	sort.Strings(got)
+
+	require.Equal(t, []string{"/hex_metadata.config", "/lib/jason.ex", "/mix.exs"}, got)
+
+	metadata, err := os.ReadFile(filepath.Join(workDir, "hex_metadata.config"))
+	require.NoError(t, err)
+	require.Equal(t, `{<<"name">>,<<"jason">>}.`, string(metadata))
+}
@@ -0,0 +1,216 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// mercurialSyncer is a syncer for Mercurial repositories. The conversion to git
+// is done by the git-cinnabar remote helper, which needs to be available on the
+// PATH of gitserver as git-remote-hg.
+//
+// git-cinnabar derives git commits deterministically from the Mercurial
+// changesets and keeps its mapping in refs/cinnabar of the converted repository,
+// so commit hashes stay the same across incremental fetches.
+type mercurialSyncer struct {
+	logger                  log.Logger
+	recordingCommandFactory *wrexec.RecordingCommandFactory
+	getRemoteURLSource      func(ctx context.Context, name api.RepoName) (RemoteURLSource, error)
+}
+
+func NewMercurialSyncer(
+	logger log.Logger,
+	r *wrexec.RecordingCommandFactory,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &mercurialSyncer{
+		logger:                  logger.Scoped("MercurialSyncer"),
+		recordingCommandFactory: r,
+		getRemoteURLSource:      getRemoteURLSource,
+	}
+}
+
+func (s *mercurialSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *mercurialSyncer) IsCloneable(ctx context.Context, repoName api.RepoName) error {
+	remoteURL, err := s.remoteURL(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", mercurialRemote(remoteURL))
+	executil.ConfigureRemoteGitCommand(cmd, remoteURL)
+
+	r := urlredactor.New(remoteURL)
+	out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(r.Redact).CombinedOutput()
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Wrap(err, "failed to check remote access: "+r.Redact(string(out)))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Clone converts a Mercurial repository into a git repository at tmpPath,
+// reporting redacted progress logs via the progressWriter.
+func (s *mercurialSyncer) Clone(ctx context.Context, repo api.RepoName, _ common.GitDir, tmpPath string, progressWriter io.Writer) error {
+	dir := common.GitDir(tmpPath)
+
+	if err := os.MkdirAll(string(dir), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	tryWrite(s.logger, progressWriter, "Creating bare repo\n")
+	if err := git.MakeBareRepo(ctx, string(dir)); err != nil {
+		return err
+	}
+	tryWrite(s.logger, progressWriter, "Created bare repo at %s\n", string(dir))
+
+	tryWrite(s.logger, progressWriter, "Converting Mercurial repository\n")
+	if exitCode, err := s.runFetchCommand(ctx, repo, dir, progressWriter); err != nil {
+		return errors.Wrapf(err, "failed to run hg->git conversion: exit code %d", exitCode)
+	}
+	tryWrite(s.logger, progressWriter, "Converted Mercurial repository\n")
+
+	tryWrite(s.logger, progressWriter, "Setting HEAD to the default branch\n")
+	if err := s.setHEAD(ctx, dir); err != nil {
+		return errors.Wrap(err, "failed to set HEAD")
+	}
+
+	return nil
+}
+
+// Fetch incrementally converts new Mercurial changesets, bookmarks and tags.
+func (s *mercurialSyncer) Fetch(ctx context.Context, repoName api.RepoName, dir common.GitDir, progressWriter io.Writer) error {
+	tryWrite(s.logger, progressWriter, "Fetching Mercurial changesets\n")
+	if exitCode, err := s.runFetchCommand(ctx, repoName, dir, progressWriter); err != nil {
+		return errors.Wrapf(err, "failed to update: exit code %d", exitCode)
+	}
+	tryWrite(s.logger, progressWriter, "Fetched Mercurial changesets\n")
+
+	// A bookmark that HEAD points to may have been removed upstream.
+	if err := s.setHEAD(ctx, dir); err != nil {
+		return errors.Wrap(err, "failed to set HEAD")
+	}
+
+	// The update was successful, after a git fetch it is expected that a repos
+	// FETCH_HEAD has either been updated, or that HEAD has been touched, even
+	// if no changes were fetched.
+	// Since we use this for last_fetched, we touch it here.
+	if err := os.Chtimes(dir.Path("HEAD"), time.Time{}, time.Now()); err != nil {
+		s.logger.Error("failed to touch HEAD after mercurial fetch", log.Error(err))
+	}
+
+	return nil
+}
+
+func (s *mercurialSyncer) runFetchCommand(ctx context.Context, repoName api.RepoName, dir common.GitDir, progressWriter io.Writer) (exitCode int, err error) {
+	remoteURL, err := s.remoteURL(ctx, repoName)
+	if err != nil {
+		return -1, err
+	}
+
+	// cinnabar.refs=bookmarks,tips exposes bookmarks as refs/heads/bookmarks/<name>
+	// and the tip of every named branch as refs/heads/branches/<name>/tip.
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "cinnabar.refs=bookmarks,tips",
+		"fetch", "--progress", "--prune", mercurialRemote(remoteURL),
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	)
+	dir.Set(cmd)
+	executil.ConfigureRemoteGitCommand(cmd, remoteURL)
+
+	redactor := urlredactor.New(remoteURL)
+	wrCmd := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).WithRedactorFunc(redactor.Redact)
+
+	// Note: Using RunCommandWriteOutput here does NOT store the output of the
+	// command as the command output of the wrexec command, because the pipes are
+	// already used.
+	return executil.RunCommandWriteOutput(ctx, wrCmd, progressWriter, redactor.Redact)
+}
+
+// setHEAD points HEAD at the ref that a Mercurial clone would check out.
+func (s *mercurialSyncer) setHEAD(ctx context.Context, dir common.GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(refname:lstrip=2)", "refs/heads/")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(err, "failed to list branches")
+	}
+
+	head := mercurialHEADBranch(strings.Fields(string(out)))
+	if head == "" {
+		// An empty repository, keep git's default HEAD.
+		return nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD", "refs/heads/"+head)
+	dir.Set(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to set HEAD: %s", string(out))
+	}
+
+	return nil
+}
+
+func (s *mercurialSyncer) remoteURL(ctx context.Context, repoName api.RepoName) (*vcs.URL, error) {
+	source, err := s.getRemoteURLSource(ctx, repoName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remote URL source for %s", repoName)
+	}
+
+	remoteURL, err := source.RemoteURL(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remote URL for %s", repoName)
+	}
+
+	return remoteURL, nil
+}
+
+// mercurialRemote returns the remote that makes git use the git-cinnabar
+// remote helper for the given URL.
+func mercurialRemote(remoteURL *vcs.URL) string {
+	return "hg::" + remoteURL.String()
+}
+
+// mercurialHEADBranch picks the branch HEAD should point to from the converted
+// branches. Like hg clone, it prefers the "@" bookmark over the tip of the
+// default branch. If neither exists the first branch is used.
+func mercurialHEADBranch(branches []string) string {
+	for _, candidate := range []string{"bookmarks/@", "branches/default/tip"} {
+		if slices.Contains(branches, candidate) {
+			return candidate
+		}
+	}
+	if len(branches) == 0 {
+		return ""
+	}
+	return slices.Min(branches)
+}
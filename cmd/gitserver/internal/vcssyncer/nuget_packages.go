@@ -0,0 +1,158 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewNuGetPackagesSyncer(
+	connection *schema.NuGetPackagesConnection,
+	svc *dependencies.Service,
+	client *nuget.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("NuGetPackagesSyncer"),
+		typ:                "nuget_packages",
+		scheme:             dependencies.NuGetPackagesScheme,
+		placeholder:        reposource.NewNuGetVersionedPackage("sourcegraph.placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &nugetPackagesSyncer{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type nugetPackagesSyncer struct {
+	client *nuget.Client
+	fs     gitserverfs.FS
+}
+
+func (nugetPackagesSyncer) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(string(name) + "@" + version)
+}
+
+func (nugetPackagesSyncer) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(dep)
+}
+
+func (nugetPackagesSyncer) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromName(name)
+}
+
+func (nugetPackagesSyncer) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromRepoName(repoName)
+}
+
+func (s *nugetPackagesSyncer) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading NuGet package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	mkdirTemp := func() (string, error) {
+		return s.fs.TempDir("nuget-packages")
+	}
+
+	if err = unpackNuGetPackage(pkgContents, mkdirTemp, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip NuGet package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// nugetBinaryExtensions are the extensions of compiled artifacts that NuGet
+// packages ship next to their sources and content. There is nothing to
+// search in them, so they are left out of the repository.
+var nugetBinaryExtensions = map[string]struct{}{
+	".a":      {},
+	".dll":    {},
+	".dylib":  {},
+	".exe":    {},
+	".lib":    {},
+	".nupkg":  {},
+	".pdb":    {},
+	".snupkg": {},
+	".so":     {},
+	".winmd":  {},
+}
+
+// unpackNuGetPackage unpacks the given .nupkg archive into workDir. Only the
+// .nuspec manifest and the source and content files of the package are kept,
+// compiled artifacts and the packaging metadata of the archive are skipped.
+func unpackNuGetPackage(pkg io.Reader, mkdirTemp func() (string, error), workDir string) error {
+	logger := log.Scoped("unpackNuGetPackage")
+
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(filePath string, file fs.FileInfo) bool {
+			if isNuGetPackagingFile(filePath) {
+				return false
+			}
+			if _, ok := nugetBinaryExtensions[strings.ToLower(path.Ext(filePath))]; ok {
+				return false
+			}
+
+			size := file.Size()
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				logger.With(
+					log.String("path", file.Name()),
+					log.Int64("size", size),
+					log.Float64("limit", sizeLimit),
+				).Warn("skipping large file in NuGet package")
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(filePath, workDir)
+			return !malicious
+		},
+	}
+
+	// A .nupkg is a zip archive, which cannot be read in a streaming fashion,
+	// so we write it to a temporary file first.
+	tmpdir, err := mkdirTemp()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	return unpack.Zip(zip, zipLen, workDir, opts)
+}
+
+// isNuGetPackagingFile reports whether filePath is part of the Open Packaging
+// Conventions metadata or the signature of the archive, rather than the package.
+func isNuGetPackagingFile(filePath string) bool {
+	switch {
+	case filePath == "[Content_Types].xml", filePath == ".signature.p7s":
+		return true
+	case strings.HasPrefix(filePath, "_rels/"), strings.HasPrefix(filePath, "package/"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package vcssyncer
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "_rels/.rels", contents: []byte("<Relationships/>")},
+		{path: "[Content_Types].xml", contents: []byte("<Types/>")},
+		{path: ".signature.p7s", contents: []byte("signature")},
+		{path: "package/services/metadata/core-properties/abc.psmdcp", contents: []byte("<coreProperties/>")},
+		{path: "Newtonsoft.Json.nuspec", contents: []byte("<package/>")},
+		{path: "README.md", contents: []byte("# Json.NET")},
+		{path: "lib/net6.0/Newtonsoft.Json.dll", contents: []byte("MZ")},
+		{path: "lib/net6.0/Newtonsoft.Json.xml", contents: []byte("<doc/>")},
+		{path: "src/Newtonsoft.Json/JsonConvert.cs", contents: []byte("namespace Newtonsoft.Json;")},
+		{path: "contentFiles/cs/any/Settings.cs", contents: []byte("class Settings {}")},
+		{path: ".git/config", contents: []byte("[core]")},
+	} {
+		fw, err := zw.Create(f.path)
+		require.NoError(t, err)
+		_, err = fw.Write(f.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	tmp := t.TempDir()
+	workDir := filepath.Join(tmp, "work")
+	require.NoError(t, os.Mkdir(workDir, 0o755))
+	err := unpackNuGetPackage(&zipBuf, func() (string, error) { return os.MkdirTemp(tmp, "zip") }, workDir)
+	require.NoError(t, err)
+
+	var got []string
+	require.NoError(t, filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, workDir))
+		}
+		return nil
+	}))
+	sort.Strings(got)
+
+	require.Equal(t, []string{
+		"/Newtonsoft.Json.nuspec",
+		"/README.md",
+		"/contentFiles/cs/any/Settings.cs",
+		"/lib/net6.0/Newtonsoft.Json.xml",
+		"/src/Newtonsoft.Json/JsonConvert.cs",
+	}, got)
+}
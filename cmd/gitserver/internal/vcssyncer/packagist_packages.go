@@ -0,0 +1,119 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewPackagistPackagesSyncer(
+	connection *schema.PackagistPackagesConnection,
+	svc *dependencies.Service,
+	client *packagist.Client,
+	fs gitserverfs.FS,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:             log.Scoped("PackagistPackagesSyncer"),
+		typ:                "packagist_packages",
+		scheme:             dependencies.PackagistPackagesScheme,
+		placeholder:        reposource.NewPackagistVersionedPackage("sourcegraph/placeholder", "0.0.0"),
+		svc:                svc,
+		configDeps:         connection.Dependencies,
+		source:             &packagistPackagesSyncer{client: client, fs: fs},
+		fs:                 fs,
+		getRemoteURLSource: getRemoteURLSource,
+	}
+}
+
+type packagistPackagesSyncer struct {
+	client *packagist.Client
+	fs     gitserverfs.FS
+}
+
+func (packagistPackagesSyncer) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParsePackagistVersionedPackage(string(name) + "@" + version)
+}
+
+func (packagistPackagesSyncer) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParsePackagistVersionedPackage(dep)
+}
+
+func (packagistPackagesSyncer) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParsePackagistPackageFromName(name)
+}
+
+func (packagistPackagesSyncer) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParsePackagistPackageFromRepoName(repoName)
+}
+
+func (s *packagistPackagesSyncer) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading Composer package %q", dep.VersionedPackageSyntax())
+	}
+	defer pkgContents.Close()
+
+	mkdirTemp := func() (string, error) {
+		return s.fs.TempDir("packagist-packages")
+	}
+
+	if err = unpackPackagistPackage(pkgContents, mkdirTemp, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip Composer package %q", dep.VersionedPackageSyntax())
+	}
+
+	return nil
+}
+
+// unpackPackagistPackage unpacks the given zip distribution archive of a
+// Composer package into workDir, skipping any files that aren't valid or that
+// are potentially malicious.
+func unpackPackagistPackage(pkg io.Reader, mkdirTemp func() (string, error), workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	tmpdir, err := mkdirTemp()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zip, zipLen, err := writeZipToTemp(tmpdir, pkg)
+	if err != nil {
+		return err
+	}
+	defer zip.Close()
+
+	if err := unpack.Zip(zip, zipLen, workDir, opts); err != nil {
+		return err
+	}
+
+	// Archives downloaded from GitHub and GitLab wrap the package in a
+	// directory named after the repository and commit.
+	return stripSingleOutermostDirectory(workDir)
+}
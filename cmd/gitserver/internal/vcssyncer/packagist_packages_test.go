@@ -0,0 +1,50 @@
+package vcssyncer
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackPackagistPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "Seldaek-monolog-c915e26/composer.json", contents: []byte(`{"name":"monolog/monolog"}`)},
+		{path: "Seldaek-monolog-c915e26/src/Monolog/Logger.php", contents: []byte("<?php class Logger {}")},
+		{path: "Seldaek-monolog-c915e26/.git/config", contents: []byte("[core]")},
+	} {
+		fw, err := zw.Create(f.path)
+		require.NoError(t, err)
+		_, err = fw.Write(f.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	tmp := t.TempDir()
+	workDir := filepath.Join(tmp, "work")
+	require.NoError(t, os.Mkdir(workDir, 0o755))
+	err := unpackPackagistPackage(&zipBuf, func() (string, error) { return os.MkdirTemp(tmp, "zip") }, workDir)
+	require.NoError(t, err)
+
+	var got []string
+	require.NoError(t, filepath.Walk(workDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, workDir))
+		}
+		return nil
+	}))
+	sort.Strings(got)
+
+	require.Equal(t, []string{"/composer.json", "/src/Monolog/Logger.php"}, got)
+}
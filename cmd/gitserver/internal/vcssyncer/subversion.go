@@ -0,0 +1,295 @@
+package vcssyncer
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// subversionSyncer is a syncer for Subversion repositories. The conversion to
+// git is done by git-svn, which needs to be available on the PATH of gitserver.
+//
+// git-svn keeps the mapping of Subversion revisions to commits in the svn
+// directory of the converted repository, so a fetch only converts revisions
+// that were committed after the last converted one. Every converted commit
+// records its revision in a git-svn-id trailer, which the worker uses to build
+// the revision to commit mapping in the database.
+type subversionSyncer struct {
+	logger                  log.Logger
+	recordingCommandFactory *wrexec.RecordingCommandFactory
+	fs                      gitserverfs.FS
+
+	// layout describes where trunk, branches and tags live in the repository.
+	layout subversionLayout
+
+	// getRemoteURLSource returns the RemoteURLSource for the given repository.
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error)
+}
+
+func NewSubversionSyncer(
+	logger log.Logger,
+	r *wrexec.RecordingCommandFactory,
+	fs gitserverfs.FS,
+	connection *schema.SubversionConnection,
+	getRemoteURLSource func(ctx context.Context, name api.RepoName) (RemoteURLSource, error),
+) VCSSyncer {
+	return &subversionSyncer{
+		logger:                  logger.Scoped("SubversionSyncer"),
+		recordingCommandFactory: r,
+		fs:                      fs,
+		layout:                  configureSubversionLayout(connection.Layout),
+		getRemoteURLSource:      getRemoteURLSource,
+	}
+}
+
+func (s *subversionSyncer) Type() string {
+	return "subversion"
+}
+
+// IsCloneable checks to see if the Subversion repository can be accessed with
+// the configured credentials.
+func (s *subversionSyncer) IsCloneable(ctx context.Context, repoName api.RepoName) error {
+	remote, err := s.remote(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	args := []string{"info", "--non-interactive", "--no-auth-cache"}
+	if remote.username != "" {
+		args = append(args, "--username", remote.username, "--password-from-stdin")
+	}
+	cmd := exec.CommandContext(ctx, "svn", append(args, remote.url)...)
+	cmd.Stdin = strings.NewReader(remote.password)
+
+	r := urlredactor.New(remote.remoteURL)
+	out, err := s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(r.Redact).CombinedOutput()
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Wrap(err, "failed to check remote access: "+r.Redact(string(out)))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Clone converts a Subversion repository into a git repository at tmpPath,
+// reporting redacted progress logs via the progressWriter.
+func (s *subversionSyncer) Clone(ctx context.Context, repo api.RepoName, _ common.GitDir, tmpPath string, progressWriter io.Writer) error {
+	remote, err := s.remote(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	dir := common.GitDir(tmpPath)
+
+	if err := os.MkdirAll(string(dir), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	tryWrite(s.logger, progressWriter, "Creating bare repo\n")
+	if err := git.MakeBareRepo(ctx, string(dir)); err != nil {
+		return err
+	}
+	tryWrite(s.logger, progressWriter, "Created bare repo at %s\n", string(dir))
+
+	for _, kv := range subversionRemoteConfig(remote.url, s.layout) {
+		cmd := exec.CommandContext(ctx, "git", "config", "--add", kv[0], kv[1])
+		dir.Set(cmd)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "failed to configure svn-remote: %s", urlredactor.New(remote.remoteURL).Redact(string(out)))
+		}
+	}
+
+	tryWrite(s.logger, progressWriter, "Converting Subversion repository\n")
+	if exitCode, err := s.runFetchCommand(ctx, repo, remote, dir, progressWriter); err != nil {
+		return errors.Wrapf(err, "failed to run svn->git conversion: exit code %d", exitCode)
+	}
+	tryWrite(s.logger, progressWriter, "Converted Subversion repository\n")
+
+	// Trunk is the closest thing Subversion has to a default branch.
+	cmd := exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD", "refs/heads/"+subversionTrunkBranch)
+	dir.Set(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to set HEAD: %s", string(out))
+	}
+
+	return nil
+}
+
+// Fetch converts the Subversion revisions committed since the last conversion.
+func (s *subversionSyncer) Fetch(ctx context.Context, repoName api.RepoName, dir common.GitDir, progressWriter io.Writer) error {
+	remote, err := s.remote(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	tryWrite(s.logger, progressWriter, "Fetching Subversion revisions\n")
+	if exitCode, err := s.runFetchCommand(ctx, repoName, remote, dir, progressWriter); err != nil {
+		return errors.Wrapf(err, "failed to update: exit code %d", exitCode)
+	}
+	tryWrite(s.logger, progressWriter, "Fetched Subversion revisions\n")
+
+	// The update was successful, after a git fetch it is expected that a repos
+	// FETCH_HEAD has either been updated, or that HEAD has been touched, even
+	// if no changes were fetched.
+	// Since we use this for last_fetched, we touch it here.
+	if err := os.Chtimes(dir.Path("HEAD"), time.Time{}, time.Now()); err != nil {
+		s.logger.Error("failed to touch HEAD after subversion fetch", log.Error(err))
+	}
+
+	return nil
+}
+
+func (s *subversionSyncer) runFetchCommand(ctx context.Context, repoName api.RepoName, remote *subversionRemote, dir common.GitDir, progressWriter io.Writer) (exitCode int, err error) {
+	// git-svn resumes from the last revision recorded in its rev_map, so the
+	// same command is used for the initial conversion and for updates.
+	args := []string{"svn", "fetch", "--no-auth-cache"}
+	if remote.username != "" {
+		args = append(args, "--username", remote.username)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+
+	// Subversion writes its configuration to $HOME/.subversion, we pass in a
+	// directory under our control which also holds the askpass helper that
+	// hands the password to git-svn.
+	home, err := s.fs.TempDir("svn-home-")
+	if err != nil {
+		return -1, errors.Wrap(err, "failed to create subversion home dir")
+	}
+	defer os.RemoveAll(home)
+
+	askpass := filepath.Join(home, "askpass.sh")
+	if err := os.WriteFile(askpass, []byte(subversionAskpassScript), 0o700); err != nil {
+		return -1, errors.Wrap(err, "failed to write askpass helper")
+	}
+	cmd.Env = append(cmd.Env,
+		"HOME="+home,
+		"GIT_ASKPASS="+askpass,
+		"SG_SVN_PASSWORD="+remote.password,
+	)
+
+	redactor := urlredactor.New(remote.remoteURL)
+	wrCmd := s.recordingCommandFactory.WrapWithRepoName(ctx, s.logger, repoName, cmd).WithRedactorFunc(redactor.Redact)
+
+	// Note: Using RunCommandWriteOutput here does NOT store the output of the
+	// command as the command output of the wrexec command, because the pipes are
+	// already used.
+	return executil.RunCommandWriteOutput(ctx, wrCmd, progressWriter, redactor.Redact)
+}
+
+// subversionAskpassScript answers the password prompt of git-svn with the
+// password from the environment, so it never ends up on disk.
+const subversionAskpassScript = `#!/bin/sh
+printf '%s\n' "$SG_SVN_PASSWORD"
+`
+
+// subversionRemote is a remote URL split into the URL of the Subversion
+// repository and the credentials to access it.
+type subversionRemote struct {
+	remoteURL *vcs.URL
+	url       string
+	username  string
+	password  string
+}
+
+func (s *subversionSyncer) remote(ctx context.Context, repoName api.RepoName) (*subversionRemote, error) {
+	source, err := s.getRemoteURLSource(ctx, repoName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remote URL source for %s", repoName)
+	}
+
+	remoteURL, err := source.RemoteURL(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get remote URL for %s", repoName)
+	}
+
+	return decomposeSubversionRemoteURL(remoteURL), nil
+}
+
+// decomposeSubversionRemoteURL splits the credentials that were added to the
+// clone URL from the URL passed to Subversion.
+func decomposeSubversionRemoteURL(remoteURL *vcs.URL) *subversionRemote {
+	u := *remoteURL
+	u.User = nil
+
+	r := &subversionRemote{
+		remoteURL: remoteURL,
+		url:       strings.TrimSuffix(u.String(), "/"),
+	}
+	if remoteURL.User != nil {
+		r.username = remoteURL.User.Username()
+		r.password, _ = remoteURL.User.Password()
+	}
+	return r
+}
+
+// subversionTrunkBranch is the git branch trunk is converted to.
+const subversionTrunkBranch = "trunk"
+
+// subversionLayout holds the paths of trunk, branches and tags relative to the
+// repository root.
+type subversionLayout struct {
+	trunk    string
+	branches string
+	tags     string
+}
+
+func configureSubversionLayout(l *schema.SubversionLayout) subversionLayout {
+	layout := subversionLayout{
+		trunk:    "trunk",
+		branches: "branches",
+		tags:     "tags",
+	}
+	if l == nil {
+		return layout
+	}
+	if l.Trunk != "" {
+		layout.trunk = strings.Trim(l.Trunk, "/")
+	}
+	if l.Branches != "" {
+		layout.branches = strings.Trim(l.Branches, "/")
+	}
+	if l.Tags != "" {
+		layout.tags = strings.Trim(l.Tags, "/")
+	}
+	return layout
+}
+
+// subversionRemoteConfig returns the git config entries that make git-svn
+// convert trunk into the trunk branch, every directory below branches into a
+// branch and every directory below tags into a tag. The refs are written
+// directly to refs/heads and refs/tags, so the repository looks like any
+// other repository on gitserver.
+func subversionRemoteConfig(url string, layout subversionLayout) [][2]string {
+	return [][2]string{
+		{"svn-remote.svn.url", url},
+		{"svn-remote.svn.fetch", layout.trunk + ":refs/heads/" + subversionTrunkBranch},
+		{"svn-remote.svn.branches", layout.branches + "/*:refs/heads/*"},
+		{"svn-remote.svn.tags", layout.tags + "/*:refs/tags/*"},
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hex"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -119,6 +122,15 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 			}
 
 			return NewPerforceDepotSyncer(opts.Logger, opts.RecordingCommandFactory, opts.FS, &c, opts.GetRemoteURLSource), nil
+		case extsvc.TypeMercurial:
+			return NewMercurialSyncer(opts.Logger, opts.RecordingCommandFactory, opts.GetRemoteURLSource), nil
+		case extsvc.TypeSubversion:
+			var c schema.SubversionConnection
+			if _, err := extractOptions(&c); err != nil {
+				return nil, err
+			}
+
+			return NewSubversionSyncer(opts.Logger, opts.RecordingCommandFactory, opts.FS, &c, opts.GetRemoteURLSource), nil
 		case extsvc.TypeJVMPackages:
 			var c schema.JVMPackagesConnection
 			if _, err := extractOptions(&c); err != nil {
@@ -177,6 +189,39 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 				return nil, err
 			}
 			return NewRubyPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypeNuGetPackages:
+			var c schema.NuGetPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := nuget.NewClient(urn, c.Registry, c.Username, c.Password, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewNuGetPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypeHexPackages:
+			var c schema.HexPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := hex.NewClient(urn, c.Repository, c.ApiKey, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewHexPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
+		case extsvc.TypePackagistPackages:
+			var c schema.PackagistPackagesConnection
+			urn, err := extractOptions(&c)
+			if err != nil {
+				return nil, err
+			}
+			cli, err := packagist.NewClient(urn, c.Repository, c.Username, c.Password, httpcli.ExternalClientFactory)
+			if err != nil {
+				return nil, err
+			}
+			return NewPackagistPackagesSyncer(&c, opts.DepsSvc, cli, opts.FS, opts.GetRemoteURLSource), nil
 		}
 
 		return NewGitRepoSyncer(opts.Logger, opts.RecordingCommandFactory, opts.GetRemoteURLSource), nil
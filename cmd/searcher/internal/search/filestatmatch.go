@@ -0,0 +1,46 @@
+package search
+
+import (
+	"bytes"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
+)
+
+// fileStatMatcher checks whether a file satisfies the size: and lines:
+// filters. The zero value matches every file.
+type fileStatMatcher struct {
+	Size  query.IntRange
+	Lines query.IntRange
+}
+
+// Matches checks whether a file of the given size matches. It accepts a
+// callback to fetch the content, which is only called when the number of lines
+// needs to be counted.
+func (sm *fileStatMatcher) Matches(size int64, getContent func() []byte) bool {
+	if !sm.Size.Contains(size) {
+		return false
+	}
+	if sm.Lines.IsZero() {
+		return true
+	}
+	return sm.Lines.Contains(countLines(getContent()))
+}
+
+func (sm *fileStatMatcher) IsZero() bool {
+	return sm.Size.IsZero() && sm.Lines.IsZero()
+}
+
+// countLines returns the number of lines in content. A trailing line without
+// a newline counts as a line, so an empty file has zero lines.
+func countLines(content []byte) int64 {
+	n := int64(bytes.Count(content, []byte{'\n'}))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		n++
+	}
+	return n
+}
+
+func toFileStatMatcher(p *protocol.PatternInfo) *fileStatMatcher {
+	return &fileStatMatcher{Size: p.Size, Lines: p.Lines}
+}
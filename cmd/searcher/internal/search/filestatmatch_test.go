@@ -0,0 +1,42 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestFileStatMatcher(t *testing.T) {
+	cases := []struct {
+		name    string
+		matcher fileStatMatcher
+		content string
+		want    bool
+	}{
+		{name: "no filters", content: "", want: true},
+		{name: "size too small", matcher: fileStatMatcher{Size: query.IntRange{Min: 4}}, content: "abc", want: false},
+		{name: "size in range", matcher: fileStatMatcher{Size: query.IntRange{Min: 3, Max: 4}}, content: "abc", want: true},
+		{name: "size too large", matcher: fileStatMatcher{Size: query.IntRange{Max: 3}}, content: "abc", want: false},
+		{name: "empty file has no lines", matcher: fileStatMatcher{Lines: query.IntRange{Max: 1}}, content: "", want: true},
+		{name: "trailing newline", matcher: fileStatMatcher{Lines: query.IntRange{Min: 2, Max: 3}}, content: "a\nb\n", want: true},
+		{name: "no trailing newline", matcher: fileStatMatcher{Lines: query.IntRange{Min: 2, Max: 3}}, content: "a\nb", want: true},
+		{name: "too many lines", matcher: fileStatMatcher{Lines: query.IntRange{Max: 2}}, content: "a\nb\nc", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			loaded := false
+			getContent := func() []byte {
+				loaded = true
+				return []byte(tc.content)
+			}
+
+			if got := tc.matcher.Matches(int64(len(tc.content)), getContent); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			if loaded && tc.matcher.Lines.IsZero() {
+				t.Fatal("content was loaded without a lines: filter")
+			}
+		})
+	}
+}
@@ -75,7 +75,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		attribute.Int("limit", p.Limit),
 		attribute.Bool("patternMatchesContent", p.PatternMatchesContent),
 		attribute.Bool("patternMatchesPath", p.PatternMatchesPath),
-		attribute.String("select", p.Select))
+		attribute.String("select", p.Select),
+		attribute.Stringer("size", p.Size),
+		attribute.Stringer("lines", p.Lines))
 	defer tr.End()
 	defer func(start time.Time) {
 		code := "200"
@@ -141,13 +143,16 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	}
 
 	lm := toLangMatcher(&p.PatternInfo)
+	sm := toFileStatMatcher(&p.PatternInfo)
 	pm, err := toPathMatcher(&p.PatternInfo)
 	if err != nil {
 		return badRequestError{err.Error()}
 	}
 
 	var paths []string
-	if !s.DisableHybridSearch {
+	// Zoekt cannot evaluate the size: and lines: filters, so hybrid search
+	// would return unfiltered matches for the files it searches.
+	if !s.DisableHybridSearch && sm.IsZero() {
 		logger := logWithTrace(ctx, s.Logger).Scoped("hybrid").With(
 			log.String("repo", string(p.Repo)),
 			log.String("commit", string(p.Commit)),
@@ -177,7 +182,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	}
 	defer zf.Close()
 
-	return regexSearch(ctx, rm, pm, lm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, p.NumContextLines)
+	return regexSearch(ctx, rm, pm, lm, sm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, p.NumContextLines)
 }
 
 func (s *Service) getZipFile(ctx context.Context, tr trace.Trace, p *protocol.Request, paths []string) (string, *zipFile, error) {
@@ -217,7 +222,8 @@ func validateParams(r *protocol.Request) error {
 
 	if p, ok := r.Query.(*protocol.PatternNode); ok {
 		if p.Value == "" && r.ExcludePaths == "" && len(r.IncludePaths) == 0 &&
-			len(r.IncludeLangs) == 0 && len(r.ExcludeLangs) == 0 {
+			len(r.IncludeLangs) == 0 && len(r.ExcludeLangs) == 0 &&
+			r.Size.IsZero() && r.Lines.IsZero() {
 			return errors.New("At least one of pattern and include/exclude patterns must be non-empty")
 		}
 	}
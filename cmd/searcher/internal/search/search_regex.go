@@ -28,6 +28,7 @@ func regexSearchBatch(
 	}
 
 	lm := toLangMatcher(p)
+	sm := toFileStatMatcher(p)
 	pm, err := toPathMatcher(p)
 	if err != nil {
 		return nil, err
@@ -35,7 +36,7 @@ func regexSearchBatch(
 
 	ctx, cancel, sender := newLimitedStreamCollector(ctx, p.Limit)
 	defer cancel()
-	err = regexSearch(ctx, m, pm, lm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, contextLines)
+	err = regexSearch(ctx, m, pm, lm, sm, zf, p.PatternMatchesContent, p.PatternMatchesPath, p.IsCaseSensitive, sender, contextLines)
 	return sender.collected, err
 }
 
@@ -58,6 +59,7 @@ func regexSearch(
 	m matchTree,
 	pm *pathMatcher,
 	lm langMatcher,
+	sm *fileStatMatcher,
 	zf *zipFile,
 	patternMatchesContent, patternMatchesPaths bool,
 	isCaseSensitive bool,
@@ -115,6 +117,10 @@ func regexSearch(
 			l.load(f)
 			return l.fileBuf, nil
 		}
+		loadContent := func() []byte {
+			l.load(f)
+			return l.fileBuf
+		}
 
 		g.Go(func() error {
 			for !contextCanceled.Load() {
@@ -130,6 +136,12 @@ func regexSearch(
 					filesSkipped.Inc()
 					continue
 				}
+
+				// Apply size and line count filters
+				if !sm.Matches(int64(f.Len), loadContent) {
+					filesSkipped.Inc()
+					continue
+				}
 				filesSearched.Inc()
 
 				// Check pattern against file path and contents
@@ -383,6 +383,7 @@ func TestMaxMatches(t *testing.T) {
 	}
 
 	lm := toLangMatcher(p)
+	sm := toFileStatMatcher(p)
 	pm, err := toPathMatcher(p)
 	if err != nil {
 		t.Fatal(err)
@@ -390,7 +391,7 @@ func TestMaxMatches(t *testing.T) {
 
 	ctx, cancel, sender := newLimitedStreamCollector(context.Background(), maxMatches)
 	defer cancel()
-	err = regexSearch(ctx, m, pm, lm, zf, true, false, false, sender, 0)
+	err = regexSearch(ctx, m, pm, lm, sm, zf, true, false, false, sender, 0)
 	fileMatches := sender.collected
 	limitHit := sender.LimitHit()
 
@@ -27,6 +27,7 @@ import (
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
 	"github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	proto "github.com/sourcegraph/sourcegraph/internal/searcher/v1"
 	v1 "github.com/sourcegraph/sourcegraph/internal/searcher/v1"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -183,6 +184,23 @@ main.go
 milton.png
 nonutf8.txt
 symlink
+`),
+	}, {
+		arg: protocol.PatternInfo{Query: &protocol.PatternNode{Value: ""}, Size: query.IntRange{Min: 1024}},
+		want: autogold.Expect(`milton.png
+`),
+	}, {
+		arg: protocol.PatternInfo{Query: &protocol.PatternNode{Value: ""}, Lines: query.IntRange{Min: 3}},
+		want: autogold.Expect(`README.md
+main.go
+`),
+	}, {
+		arg: protocol.PatternInfo{Query: &protocol.PatternNode{Value: "world"}, Size: query.IntRange{Max: 64}},
+		want: autogold.Expect(`README.md:1:1:
+# Hello World
+README.md:3:3:
+Hello world example in go
+// No newline at end of chunk
 `),
 	}, {
 		arg: protocol.PatternInfo{Query: &protocol.PatternNode{Value: "world"}, IncludePaths: []string{`\.md$`}},
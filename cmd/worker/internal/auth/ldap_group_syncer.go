@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/groupsync"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type ldapGroupSyncerConfig struct {
+	env.BaseConfig
+
+	Interval time.Duration
+}
+
+func (c *ldapGroupSyncerConfig) Load() {
+	c.Interval = c.GetInterval("LDAP_GROUP_SYNC_INTERVAL", "1h", "How often to sync the LDAP group memberships of users to organizations and roles.")
+}
+
+var _ job.Job = (*ldapGroupSyncer)(nil)
+
+// ldapGroupSyncer is a worker that periodically syncs the LDAP groups of users
+// who signed in with an LDAP auth provider, so that changes in the directory
+// are picked up without users signing in again.
+type ldapGroupSyncer struct {
+	config *ldapGroupSyncerConfig
+}
+
+func NewLDAPGroupSyncer() job.Job {
+	return &ldapGroupSyncer{config: &ldapGroupSyncerConfig{}}
+}
+
+func (j *ldapGroupSyncer) Description() string {
+	return "Syncs the LDAP group memberships of users to organizations and roles."
+}
+
+func (j *ldapGroupSyncer) Config() []env.Config {
+	return []env.Config{j.config}
+}
+
+func (j *ldapGroupSyncer) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "init DB")
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(
+			context.Background(),
+			&ldapGroupSyncHandler{
+				logger: observationCtx.Logger.Scoped("ldap-group-syncer"),
+				db:     db,
+			},
+			goroutine.WithName("auth.ldap-group-syncer"),
+			goroutine.WithDescription("syncs LDAP group memberships to organizations and roles"),
+			goroutine.WithInterval(j.config.Interval),
+		),
+	}, nil
+}
+
+var _ goroutine.Handler = (*ldapGroupSyncHandler)(nil)
+
+type ldapGroupSyncHandler struct {
+	logger log.Logger
+	db     database.DB
+}
+
+// Handle syncs the groups of all users with an external account of an LDAP
+// auth provider that has group mappings. Users that no longer exist in the
+// directory are removed from all mapped organizations and roles.
+func (h *ldapGroupSyncHandler) Handle(ctx context.Context) error {
+	var configs []*schema.LDAPAuthProvider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap != nil && len(p.Ldap.GroupMappings) > 0 {
+			configs = append(configs, p.Ldap)
+		}
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	if err := licensing.Check(licensing.FeatureSSO); err != nil {
+		return nil
+	}
+
+	var errs error
+	for _, c := range configs {
+		if err := h.syncProvider(ctx, c); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing LDAP groups of %s", c.Url))
+		}
+	}
+	return errs
+}
+
+func (h *ldapGroupSyncHandler) syncProvider(ctx context.Context, c *schema.LDAPAuthProvider) error {
+	directory, err := ldap.NewDirectory(c)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: ldap.ServiceType,
+		ServiceID:   c.Url,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing external accounts")
+	}
+
+	var errs error
+	for _, account := range accounts {
+		var groups []ldap.Group
+		user, err := directory.LookupUser(ctx, account.AccountID)
+		switch {
+		case errors.Is(err, ldap.ErrUserNotFound):
+			// Sync with no groups to remove the user from all mapped orgs
+			// and roles.
+		case err != nil:
+			errs = errors.Append(errs, errors.Wrapf(err, "looking up %q", account.AccountID))
+			continue
+		default:
+			groups = user.Groups
+		}
+
+		if err := groupsync.Sync(ctx, h.logger, h.db, c.GroupMappings, account.UserID, groups); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing user %d", account.UserID))
+		}
+	}
+	return errs
+}
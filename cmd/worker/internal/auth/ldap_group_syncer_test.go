@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/ldaptest"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestLDAPGroupSyncHandler(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	server := ldaptest.New(t)
+	server.AddEntry("ou=people,dc=example,dc=com", "", map[string][]string{"ou": {"people"}})
+	server.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alicepw", map[string][]string{
+		"uid":      {"alice"},
+		"memberOf": {"cn=eng,ou=groups,dc=example,dc=com"},
+	})
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuthProviders: []schema.AuthProviders{{Ldap: &schema.LDAPAuthProvider{
+			Type:          "ldap",
+			Url:           server.URL,
+			UserBaseDN:    "ou=people,dc=example,dc=com",
+			GroupMappings: []*schema.LDAPGroupMapping{{Group: "eng", Orgs: []string{"eng"}}},
+		}}},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	oldLicensingMock := licensing.MockCheckFeature
+	licensing.MockCheckFeature = func(licensing.Feature) error { return nil }
+	t.Cleanup(func() { licensing.MockCheckFeature = oldLicensingMock })
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	handler := ldapGroupSyncHandler{logger: logger, db: db}
+
+	org, err := db.Orgs().Create(ctx, "eng", nil)
+	require.NoError(t, err)
+
+	// alice is in the eng group, bob was deleted from the directory but is
+	// still a member of the org.
+	newLDAPUser := func(username string) int32 {
+		t.Helper()
+		user, err := db.Users().Create(ctx, database.NewUser{Username: username})
+		require.NoError(t, err)
+		_, err = db.UserExternalAccounts().Insert(ctx, &extsvc.Account{
+			UserID: user.ID,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: ldap.ServiceType,
+				ServiceID:   server.URL,
+				AccountID:   username,
+			},
+		})
+		require.NoError(t, err)
+		return user.ID
+	}
+	alice := newLDAPUser("alice")
+	bob := newLDAPUser("bob")
+	_, err = db.OrgMembers().Create(ctx, org.ID, bob)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx))
+
+	_, err = db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, alice)
+	require.NoError(t, err, "alice should have been added to the org")
+	_, err = db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, bob)
+	require.Error(t, err, "bob should have been removed from the org")
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -21,10 +22,12 @@ func NewBatchSpecResolutionWorker(
 	observationCtx *observation.Context,
 	s *store.Store,
 	workerStore dbworkerstore.Store[*btypes.BatchSpecResolutionJob],
+	storedResults service.StoredResults,
 ) *workerutil.Worker[*btypes.BatchSpecResolutionJob] {
 	e := &batchSpecWorkspaceCreator{
-		store:  s,
-		logger: log.Scoped("batch-spec-workspace-creator"),
+		store:         s,
+		logger:        log.Scoped("batch-spec-workspace-creator"),
+		storedResults: storedResults,
 	}
 
 	options := workerutil.WorkerOptions{
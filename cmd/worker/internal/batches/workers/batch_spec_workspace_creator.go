@@ -31,8 +31,9 @@ import (
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
 // RepoWorkspaces and then persists those as pending BatchSpecWorkspaces.
 type batchSpecWorkspaceCreator struct {
-	store  *store.Store
-	logger log.Logger
+	store         *store.Store
+	logger        log.Logger
+	storedResults service.StoredResults
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -43,7 +44,9 @@ func (r *batchSpecWorkspaceCreator) HandlerFunc() workerutil.HandlerFunc[*btypes
 		// that are visible to the user are returned.
 		ctx = actor.WithActor(ctx, actor.FromUser(job.InitiatorID))
 
-		return r.process(ctx, service.NewWorkspaceResolver, job)
+		return r.process(ctx, func(tx *store.Store) service.WorkspaceResolver {
+			return service.NewWorkspaceResolver(tx, r.storedResults)
+		}, job)
 	}
 }
 
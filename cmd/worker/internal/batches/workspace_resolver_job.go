@@ -5,11 +5,21 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/batches/workers"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerinsightsdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/codeinsights"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/batches"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	searchjobs "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/service"
+	searchjobsstore "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/store"
 )
 
 type workspaceResolverJob struct{}
@@ -23,7 +33,7 @@ func (j *workspaceResolverJob) Description() string {
 }
 
 func (j *workspaceResolverJob) Config() []env.Config {
-	return []env.Config{}
+	return []env.Config{search.ObjectStorageConfigInst}
 }
 
 func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
@@ -43,11 +53,17 @@ func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *obser
 		return nil, err
 	}
 
+	storedResults, err := initStoredResults(workCtx, observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	resolverWorker := workers.NewBatchSpecResolutionWorker(
 		workCtx,
 		observationCtx,
 		bstore,
 		resStore,
+		storedResults,
 	)
 
 	routines := []goroutine.BackgroundRoutine{
@@ -56,3 +72,34 @@ func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *obser
 
 	return routines, nil
 }
+
+// initStoredResults returns the StoredResults that batch specs can target with
+// `on: exhaustiveSearchJob` and `on: insightSeries`. Sources whose feature is
+// disabled are left out.
+func initStoredResults(ctx context.Context, observationCtx *observation.Context) (service.StoredResults, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchJobs *searchjobs.Service
+	if exhaustive.IsEnabled(conf.Get()) {
+		uploadStore, err := search.NewObjectStorage(ctx, observationCtx, search.ObjectStorageConfigInst)
+		if err != nil {
+			return nil, err
+		}
+		// The service is only used to read the results of completed search
+		// jobs, so it doesn't need a searcher.
+		searchJobs = searchjobs.New(observationCtx, searchjobsstore.New(db, observationCtx), uploadStore, nil)
+	}
+
+	var insightsDB database.InsightsDB
+	if insights.IsEnabled() {
+		insightsDB, err = workerinsightsdb.InitDB(observationCtx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return service.NewStoredResults(db, searchJobs, insightsDB), nil
+}
@@ -34,6 +34,13 @@ func (j *lsifuploadstoreExpirer) Config() []env.Config {
 func (j *lsifuploadstoreExpirer) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
 	ctx := context.Background()
 
+	// Managed S3, GCS and blobstore buckets expire uploads with a lifecycle rule, which
+	// is configured when the upload store is initialized.
+	if lsifuploadstore.HasLifecycleExpiry(lsifuploadstoreExpirerConfigInst.LSIFUploadStoreConfig) {
+		observationCtx.Logger.Info("Upload store bucket expires uploads with a lifecycle rule, not starting expirer")
+		return nil, nil
+	}
+
 	uploadStore, err := lsifuploadstore.New(ctx, observationCtx, lsifuploadstoreExpirerConfigInst.LSIFUploadStoreConfig)
 	if err != nil {
 		observationCtx.Logger.Fatal("Failed to create upload store", log.Error(err))
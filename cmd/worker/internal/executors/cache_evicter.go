@@ -0,0 +1,76 @@
+package executors
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/cache"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/object"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type cacheEvicter struct {
+	store             store.CacheEntryStore
+	storage           object.Storage
+	maxAge            time.Duration
+	maxTotalSizeBytes int64
+	logger            log.Logger
+}
+
+var _ goroutine.Handler = &cacheEvicter{}
+
+// NewCacheEvicter returns a PeriodicGoroutine that deletes the executor job caches that
+// are older than maxAge, as well as the least recently used caches once their total size
+// exceeds maxTotalSizeBytes.
+func NewCacheEvicter(cacheStore store.CacheEntryStore, storage object.Storage, maxAge time.Duration, maxTotalSizeBytes int64, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		&cacheEvicter{
+			store:             cacheStore,
+			storage:           storage,
+			maxAge:            maxAge,
+			maxTotalSizeBytes: maxTotalSizeBytes,
+			logger:            log.Scoped("executor-cache-evicter"),
+		},
+		goroutine.WithName("executors.cache-evicter"),
+		goroutine.WithDescription("deletes executor job caches that are expired or exceed the size limit"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+// Handle deletes the evictable entries, starting with the least recently used one.
+func (e *cacheEvicter) Handle(ctx context.Context) error {
+	entries, err := e.store.ListEvictable(ctx, e.maxAge, e.maxTotalSizeBytes)
+	if err != nil {
+		return errors.Wrap(err, "listing evictable cache entries")
+	}
+
+	for _, entry := range entries {
+		// Delete the record first, so that jobs stop restoring the entry before its archive
+		// disappears. If the entry was replaced in the meantime, its archive is kept.
+		deleted, err := e.store.Delete(ctx, entry)
+		if err != nil {
+			return errors.Wrap(err, "deleting cache entry")
+		}
+		if !deleted {
+			continue
+		}
+
+		if err := e.storage.Delete(ctx, cache.ObjectKey(entry.Queue, entry.Key)); err != nil {
+			return errors.Wrap(err, "deleting cache archive")
+		}
+		e.logger.Debug("Evicted executor cache entry", log.String("queue", entry.Queue), log.String("key", entry.Key), log.Int64("sizeBytes", entry.SizeBytes))
+	}
+
+	// Archives without a record, for example from failed uploads, are never listed
+	// above. Their age is bounded by the same limit as the records.
+	if err := e.storage.ExpireObjects(ctx, "", e.maxAge); err != nil {
+		return errors.Wrap(err, "expiring cache archives")
+	}
+
+	return nil
+}
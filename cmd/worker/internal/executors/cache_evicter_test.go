@@ -0,0 +1,51 @@
+package executors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/object/mocks"
+)
+
+func Test_cacheEvicter_Handle(t *testing.T) {
+	cacheStore := store.NewMockCacheEntryStore()
+	cacheStore.ListEvictableFunc.SetDefaultReturn([]store.CacheEntry{
+		{ID: 1, Queue: "batches", Key: "go-mod"},
+		{ID: 2, Queue: "batches", Key: "node-modules"},
+		{ID: 3, Queue: "codeintel", Key: "go-mod"},
+	}, nil)
+	cacheStore.DeleteFunc.SetDefaultHook(func(_ context.Context, entry store.CacheEntry) (bool, error) {
+		// The second entry was replaced after it was listed.
+		return entry.ID != 2, nil
+	})
+	storage := mocks.NewMockStorage()
+
+	evicter := &cacheEvicter{
+		store:             cacheStore,
+		storage:           storage,
+		maxAge:            time.Hour,
+		maxTotalSizeBytes: 1024,
+		logger:            logtest.Scoped(t),
+	}
+	require.NoError(t, evicter.Handle(context.Background()))
+
+	require.Len(t, cacheStore.ListEvictableFunc.History(), 1)
+	assert.Equal(t, time.Hour, cacheStore.ListEvictableFunc.History()[0].Arg1)
+	assert.Equal(t, int64(1024), cacheStore.ListEvictableFunc.History()[0].Arg2)
+
+	assert.Len(t, cacheStore.DeleteFunc.History(), 3)
+	var deletedKeys []string
+	for _, call := range storage.DeleteFunc.History() {
+		deletedKeys = append(deletedKeys, call.Arg1)
+	}
+	assert.Equal(t, []string{"batches/go-mod", "codeintel/go-mod"}, deletedKeys)
+
+	require.Len(t, storage.ExpireObjectsFunc.History(), 1)
+	assert.Equal(t, time.Hour, storage.ExpireObjectsFunc.History()[0].Arg2)
+}
@@ -15,6 +15,10 @@ type janitorConfig struct {
 
 	CacheCleanupInterval time.Duration
 	CacheDequeueTtl      time.Duration
+
+	JobCacheEvictionInterval time.Duration
+	JobCacheMaxAge           time.Duration
+	JobCacheMaxTotalSize     int
 }
 
 var janitorConfigInst = &janitorConfig{}
@@ -25,4 +29,8 @@ func (c *janitorConfig) Load() {
 
 	c.CacheCleanupInterval = c.GetInterval("EXECUTORS_MULTIQUEUE_CACHE_CLEANUP_INTERVAL", executortypes.CleanupInterval.String(), "The frequency with which the multiqueue dequeue cache is cleaned up.")
 	c.CacheDequeueTtl = c.GetInterval("EXECUTORS_MULTIQUEUE_CACHE_DEQUEUE_TTL", executortypes.DequeueTtl.String(), "The duration after which a dequeue is deleted from the multiqueue dequeue cache.")
+
+	c.JobCacheEvictionInterval = c.GetInterval("EXECUTORS_JOB_CACHE_EVICTION_INTERVAL", "10m", "The frequency with which executor job caches are evicted.")
+	c.JobCacheMaxAge = c.GetInterval("EXECUTORS_JOB_CACHE_MAX_AGE", "168h", "The age after which executor job caches are evicted.") // one week
+	c.JobCacheMaxTotalSize = c.GetInt("EXECUTORS_JOB_CACHE_MAX_TOTAL_SIZE_MB", "10240", "The total size of executor job caches above which the least recently used caches are evicted.")
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	executorcache "github.com/sourcegraph/sourcegraph/internal/executor/cache"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -24,15 +26,20 @@ func (j *janitorJob) Description() string {
 }
 
 func (j *janitorJob) Config() []env.Config {
-	return []env.Config{janitorConfigInst}
+	return []env.Config{janitorConfigInst, executorcache.ObjectStorageConfigInst}
 }
 
-func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+func (j *janitorJob) Routines(ctx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
 	db, err := workerdb.InitDB(observationCtx)
 	if err != nil {
 		return nil, err
 	}
 
+	cacheStorage, err := executorcache.NewObjectStorage(ctx, observationCtx, executorcache.ObjectStorageConfigInst)
+	if err != nil {
+		return nil, err
+	}
+
 	dequeueCache := rcache.New(redispool.Cache, executortypes.DequeueCachePrefix)
 
 	routines := []goroutine.BackgroundRoutine{
@@ -46,6 +53,13 @@ func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Con
 			goroutine.WithInterval(janitorConfigInst.CleanupTaskInterval),
 		),
 		NewMultiqueueCacheCleaner(executortypes.ValidQueueNames, dequeueCache, janitorConfigInst.CacheDequeueTtl, janitorConfigInst.CacheCleanupInterval),
+		NewCacheEvicter(
+			executorstore.NewCacheEntryStore(db),
+			cacheStorage,
+			janitorConfigInst.JobCacheMaxAge,
+			int64(janitorConfigInst.JobCacheMaxTotalSize)*1024*1024,
+			janitorConfigInst.JobCacheEvictionInterval,
+		),
 	}
 
 	return routines, nil
@@ -60,19 +60,19 @@ type exhaustiveSearchRepoRevHandler struct {
 var _ workerutil.Handler[*types.ExhaustiveSearchRepoRevisionJob] = &exhaustiveSearchRepoRevHandler{}
 
 func (h *exhaustiveSearchRepoRevHandler) Handle(ctx context.Context, logger log.Logger, record *types.ExhaustiveSearchRepoRevisionJob) error {
-	jobID, query, repoRev, initiatorID, err := h.store.GetQueryRepoRev(ctx, record)
+	searchJob, repoRev, err := h.store.GetQueryRepoRev(ctx, record)
 	if err != nil {
 		return err
 	}
 
-	ctx = actor.WithActor(ctx, actor.FromUser(initiatorID))
+	ctx = actor.WithActor(ctx, actor.FromUser(searchJob.InitiatorID))
 
-	q, err := h.newSearcher.NewSearch(ctx, initiatorID, query)
+	q, err := h.newSearcher.NewSearch(ctx, searchJob.InitiatorID, searchJob.Query)
 	if err != nil {
 		return err
 	}
 
-	w, err := service.NewJSONWriter(ctx, h.uploadStore, fmt.Sprintf("%d-%d", jobID, record.ID))
+	w, err := service.NewResultsWriter(ctx, h.uploadStore, fmt.Sprintf("%d-%d", searchJob.ID, record.ID), searchJob)
 	if err != nil {
 		return err
 	}
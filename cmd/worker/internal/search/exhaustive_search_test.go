@@ -61,7 +61,7 @@ func TestExhaustiveSearch(t *testing.T) {
 	query := "1@rev1 1@rev2 2@rev3"
 
 	// Create a job
-	job, err := svc.CreateSearchJob(userCtx, query)
+	job, err := svc.CreateSearchJob(userCtx, query, types.ResultsFormatJSON, nil)
 	require.NoError(err)
 
 	// Do some assertions on the job before it runs
@@ -0,0 +1,19 @@
+package subversion
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type Config struct {
+	env.BaseConfig
+
+	Interval            time.Duration
+	RepositoryBatchSize int
+}
+
+func (c *Config) Load() {
+	c.Interval = c.GetInterval("SRC_SUBVERSION_REVISION_MAPPER_INTERVAL", "1m", "How frequently to run the Subversion revision mapper routine.")
+	c.RepositoryBatchSize = c.GetInt("SRC_SUBVERSION_REVISION_MAPPER_REPO_BATCH_SIZE", "100", "The number of repositories to load at once when processing for Subversion revision mappings.")
+}
@@ -0,0 +1,74 @@
+package subversion
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type subversionRevisionMappingJob struct {
+	cfg *Config
+}
+
+func NewSubversionRevisionMappingJob() job.Job {
+	return &subversionRevisionMappingJob{cfg: &Config{}}
+}
+
+func (j *subversionRevisionMappingJob) Description() string {
+	return "Background job indexing mapping data from Subversion revision numbers to converted Git commits"
+}
+
+func (j *subversionRevisionMappingJob) Config() []env.Config {
+	return []env.Config{j.cfg}
+}
+
+func (j *subversionRevisionMappingJob) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := observationCtx.Logger.Scoped("subversion-revision-mapper")
+
+	gs := gitserver.NewClient("subversion-revision-mapper")
+
+	// The revision mapper has to be able to read every Subversion repo, so we
+	// use an internal actor.
+	ctx := actor.WithInternalActor(context.Background())
+
+	redMetrics := metrics.NewREDMetrics(
+		observationCtx.Registerer,
+		"subversion_revision_mapper",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of method invocations."),
+	)
+
+	op := observationCtx.Operation(observation.Op{
+		Name:              "subversion.revision-mapper",
+		MetricLabelValues: []string{"processRepo"},
+		Metrics:           redMetrics,
+	})
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(
+			ctx,
+			&subversionRevisionMapper{
+				cfg:    j.cfg,
+				db:     db,
+				gs:     gs,
+				logger: logger,
+			},
+			goroutine.WithName("subversion-revision-mapper"),
+			goroutine.WithDescription("Background job indexing mapping data from Subversion revision numbers to converted Git commits"),
+			goroutine.WithInterval(j.cfg.Interval),
+			goroutine.WithOperation(op),
+		),
+	}, nil
+}
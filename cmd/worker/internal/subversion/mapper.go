@@ -0,0 +1,159 @@
+package subversion
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type GitserverClient interface {
+	Commits(ctx context.Context, repo api.RepoName, opt gitserver.CommitsOptions) ([]*gitdomain.Commit, error)
+}
+
+type subversionRevisionMapper struct {
+	cfg    *Config
+	db     database.DB
+	logger log.Logger
+	gs     GitserverClient
+}
+
+func (m *subversionRevisionMapper) Handle(ctx context.Context) (errs error) {
+	m.logger.Debug("Indexing Subversion revisions")
+
+	page := 0
+	// Initially empty to consider all repos once.
+	var lastCheck time.Time
+	for {
+		nextLastCheck := time.Now()
+		rs, err := m.db.Repos().ListMinimalRepos(ctx, database.ReposListOptions{
+			ExternalServiceType: extsvc.TypeSubversion,
+			MinLastChanged:      lastCheck,
+			LimitOffset:         &database.LimitOffset{Limit: m.cfg.RepositoryBatchSize, Offset: page * m.cfg.RepositoryBatchSize},
+		})
+		if err != nil {
+			return err
+		}
+		lastCheck = nextLastCheck
+
+		for _, r := range rs {
+			m.logger.Debug("Indexing revisions for repo", log.String("repo", string(r.Name)))
+			err := processRepo(ctx, m.logger, m.gs, m.db.RepoCommitsSubversionRevisions(), r)
+			if err != nil {
+				m.logger.Error("failed to process repo", log.Error(err), log.String("repo", string(r.Name)))
+				errs = errors.Append(errs, err)
+			}
+		}
+
+		if len(rs) != m.cfg.RepositoryBatchSize {
+			break
+		}
+		page++
+	}
+
+	return errs
+}
+
+func processRepo(ctx context.Context, logger log.Logger, gs GitserverClient, store database.RepoCommitsSubversionRevisionsStore, repo types.MinimalRepo) error {
+	start := time.Now()
+
+	var lastMapped int64
+	latest, err := store.GetLatestForRepo(ctx, repo.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "RepoCommitsSubversionRevisions.GetLatestForRepo")
+	}
+	if latest != nil {
+		lastMapped = latest.SubversionRevision
+	}
+
+	revisions, err := extractRevisionsFromCommits(ctx, gs, repo.Name, lastMapped)
+	if err != nil {
+		return errors.Wrap(err, "failed to map subversion revisions")
+	}
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	// All revisions are written in a single transaction, so the highest mapped
+	// revision is a reliable point to resume from on the next run.
+	if err := store.BatchInsertCommitSHAsWithSubversionRevision(ctx, repo.ID, revisions); err != nil {
+		return errors.Wrap(err, "failed to insert subversion revision mappings")
+	}
+
+	if timeTaken := time.Since(start); timeTaken > time.Minute {
+		logger.Warn("mapping job took long to complete", log.Duration("duration", timeTaken))
+	}
+
+	return nil
+}
+
+// extractRevisionsFromCommits returns the Subversion revision of every commit
+// on any ref that converted a revision newer than lastMapped.
+//
+// Commits are listed newest first, and git-svn uses the date of the revision as
+// the commit date, so we can stop paging once we see a revision that has
+// already been mapped.
+func extractRevisionsFromCommits(ctx context.Context, gs GitserverClient, repo api.RepoName, lastMapped int64) ([]types.SubversionRevision, error) {
+	mapped := []types.SubversionRevision{}
+	const pageSize = 50
+	page := 0
+	for {
+		commits, err := gs.Commits(ctx, repo, gitserver.CommitsOptions{
+			AllRefs: true,
+			N:       pageSize,
+			Skip:    uint(page * pageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		done := len(commits) < pageSize
+		for _, c := range commits {
+			rev, ok := parseSubversionRevision(string(c.Message))
+			if !ok {
+				// Not a commit converted by git-svn.
+				continue
+			}
+			if rev <= lastMapped {
+				done = true
+				continue
+			}
+			mapped = append(mapped, types.SubversionRevision{
+				CommitSHA: c.ID,
+				Revision:  rev,
+			})
+		}
+		if done {
+			break
+		}
+		page++
+	}
+
+	return mapped, nil
+}
+
+var gitSvnIDPattern = regexp.MustCompile(`(?m)^git-svn-id: \S+@(\d+) \S+\s*$`)
+
+// parseSubversionRevision returns the revision recorded in the git-svn-id
+// trailer that git-svn appends to every converted commit message.
+func parseSubversionRevision(commitMessage string) (int64, bool) {
+	matches := gitSvnIDPattern.FindAllStringSubmatch(commitMessage, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	rev, err := strconv.ParseInt(matches[len(matches)-1][1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return rev, true
+}
@@ -0,0 +1,98 @@
+package subversion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSubversionRevisionMapper_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	// Newest first, like git log.
+	commits := []*gitdomain.Commit{}
+	for rev := 120; rev > 0; rev-- {
+		commits = append(commits, &gitdomain.Commit{
+			ID:      commitID(rev),
+			Message: gitdomain.Message(gitSvnCommitMessage("Commit message", rev)),
+		})
+	}
+	gs := gitserver.NewMockClient()
+	gs.CommitsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opts gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		require.True(t, opts.AllRefs)
+		return commits[min(opts.Skip, uint(len(commits))):min(opts.N+opts.Skip, uint(len(commits)))], nil
+	})
+
+	logger := logtest.NoOp(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	r := &types.Repo{
+		Name: api.RepoName("svn.example.org/repos/app"),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "svn.example.org/repos/app",
+			ServiceType: extsvc.TypeSubversion,
+		},
+	}
+	require.NoError(t, db.Repos().Create(ctx, r))
+
+	// Pretend an earlier run already mapped the first 100 revisions.
+	require.NoError(t, db.RepoCommitsSubversionRevisions().BatchInsertCommitSHAsWithSubversionRevision(ctx, r.ID, []types.SubversionRevision{
+		{CommitSHA: commitID(100), Revision: 100},
+	}))
+
+	m := &subversionRevisionMapper{cfg: &Config{RepositoryBatchSize: 1}, db: db, logger: logger, gs: gs}
+	require.NoError(t, m.Handle(ctx))
+
+	for rev := 100; rev <= 120; rev++ {
+		c, err := db.RepoCommitsSubversionRevisions().GetRepoCommitSubversionRevision(ctx, r.ID, int64(rev))
+		require.NoError(t, err)
+		require.Equal(t, dbutil.CommitBytea(commitID(rev)), c.CommitSHA)
+	}
+
+	// Only the new revisions were read, older ones stay unmapped.
+	_, err := db.RepoCommitsSubversionRevisions().GetRepoCommitSubversionRevision(ctx, r.ID, 10)
+	require.Error(t, err)
+	require.Len(t, gs.CommitsFunc.History(), 1)
+}
+
+func TestParseSubversionRevision(t *testing.T) {
+	testCases := []struct {
+		message string
+		want    int64
+		wantOK  bool
+	}{
+		{message: gitSvnCommitMessage("fix the build\n\nmore details", 123), want: 123, wantOK: true},
+		{message: gitSvnCommitMessage("", 7), want: 7, wantOK: true},
+		{message: "git-svn-id: https://svn.example.org/repos/app/branches/1.x@42 f0e1d2c3-0000-0000-0000-000000000000\n", want: 42, wantOK: true},
+		{message: "a commit that did not come from subversion"},
+		{message: "git-svn-id: mentioned in passing @5"},
+	}
+
+	for _, tc := range testCases {
+		got, ok := parseSubversionRevision(tc.message)
+		require.Equal(t, tc.wantOK, ok, tc.message)
+		require.Equal(t, tc.want, got, tc.message)
+	}
+}
+
+func commitID(rev int) api.CommitID {
+	return api.CommitID(fmt.Sprintf("deadbeefdeadbeefdeadbeefdeadbeefdead%04d", rev))
+}
+
+func gitSvnCommitMessage(message string, rev int) string {
+	return fmt.Sprintf(`%s
+
+git-svn-id: https://svn.example.org/repos/app/trunk@%d 2ff5ab84-ab0c-4a3b-a4b5-0c1fd7e2e7bb`, message, rev)
+}
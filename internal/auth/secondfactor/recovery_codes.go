@@ -0,0 +1,74 @@
+package secondfactor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"strings"
+)
+
+const (
+	// RecoveryCodeCount is the number of recovery codes generated at once.
+	RecoveryCodeCount = 10
+
+	// recoveryCodeLength is the number of characters of a recovery code,
+	// without the separating dash. With the 31 character alphabet below this
+	// is about 50 bits of entropy.
+	recoveryCodeLength = 10
+
+	// recoveryCodeAlphabet omits characters that are easily confused, such
+	// as 0 and o or 1 and l.
+	recoveryCodeAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+)
+
+// GenerateRecoveryCodes returns RecoveryCodeCount new random recovery codes in
+// the form xxxxx-xxxxx.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		var sb strings.Builder
+		for sb.Len() < recoveryCodeLength+1 {
+			if sb.Len() == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			c, err := randomAlphabetIndex()
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteByte(recoveryCodeAlphabet[c])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// randomAlphabetIndex returns a uniformly distributed random index into
+// recoveryCodeAlphabet.
+func randomAlphabetIndex() (int, error) {
+	// Reject bytes past the largest multiple of the alphabet size to avoid
+	// modulo bias.
+	limit := 256 - 256%len(recoveryCodeAlphabet)
+	var b [1]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if int(b[0]) < limit {
+			return int(b[0]) % len(recoveryCodeAlphabet), nil
+		}
+	}
+}
+
+// HashRecoveryCode returns the hash of code that is stored in the database.
+// Codes are normalized first, so that users can enter them without the dash
+// or in upper case.
+func HashRecoveryCode(code string) []byte {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return sum[:]
+}
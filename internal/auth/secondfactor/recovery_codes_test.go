@@ -0,0 +1,33 @@
+package secondfactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		require.Len(t, code, recoveryCodeLength+1)
+		require.Equal(t, byte('-'), code[recoveryCodeLength/2])
+		for _, r := range strings.ReplaceAll(code, "-", "") {
+			require.Contains(t, recoveryCodeAlphabet, string(r))
+		}
+		require.False(t, seen[code], "duplicate code %q", code)
+		seen[code] = true
+	}
+
+	// Users may type codes without the dash, in upper case or with spaces.
+	code := codes[0]
+	want := HashRecoveryCode(code)
+	require.Equal(t, want, HashRecoveryCode(strings.ToUpper(code)))
+	require.Equal(t, want, HashRecoveryCode(strings.ReplaceAll(code, "-", "")))
+	require.Equal(t, want, HashRecoveryCode(" "+strings.ReplaceAll(code, "-", " ")+" "))
+	require.NotEqual(t, want, HashRecoveryCode(codes[1]))
+}
@@ -0,0 +1,110 @@
+package secondfactor
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 secret of the test vectors in RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// The RFC lists 8 digit codes, of which ours are the last 6 digits.
+	for _, tc := range []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	} {
+		got, err := TOTPCode(rfc6238Secret, TOTPCounter(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "at %d", tc.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := TOTPCounter(now)
+
+	codeAt := func(counter int64) string {
+		code, err := TOTPCode(rfc6238Secret, counter)
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("current period", func(t *testing.T) {
+		counter, ok, err := ValidateTOTP(rfc6238Secret, codeAt(current), now, 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, current, counter)
+	})
+
+	t.Run("adjacent periods", func(t *testing.T) {
+		for _, c := range []int64{current - 1, current + 1} {
+			counter, ok, err := ValidateTOTP(rfc6238Secret, codeAt(c), now, 0)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, c, counter)
+		}
+	})
+
+	t.Run("outside of the window", func(t *testing.T) {
+		for _, c := range []int64{current - 2, current + 2} {
+			_, ok, err := ValidateTOTP(rfc6238Secret, codeAt(c), now, 0)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		_, ok, err := ValidateTOTP(rfc6238Secret, codeAt(current), now, current)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("spaces are ignored", func(t *testing.T) {
+		code := codeAt(current)
+		_, ok, err := ValidateTOTP(rfc6238Secret, " "+code[:3]+" "+code[3:], now, 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		_, ok, err := ValidateTOTP(rfc6238Secret, "12345", now, 0)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	a, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	b, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(a)
+	require.NoError(t, err)
+	require.Len(t, key, totpSecretSize)
+}
+
+func TestTOTPKeyURI(t *testing.T) {
+	u, err := url.Parse(TOTPKeyURI("Sourcegraph", "alice", "JBSWY3DPEHPK3PXP"))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Sourcegraph:alice", u.Path)
+	require.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	require.Equal(t, "Sourcegraph", u.Query().Get("issuer"))
+	require.Equal(t, "6", u.Query().Get("digits"))
+	require.Equal(t, "30", u.Query().Get("period"))
+}
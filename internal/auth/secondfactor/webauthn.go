@@ -0,0 +1,217 @@
+package secondfactor
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// This file implements the relying party side of WebAuthn
+// (https://www.w3.org/TR/webauthn-2/) for security keys used as a second
+// factor, on top of github.com/go-webauthn/webauthn.
+//
+// Attestation statements are not requested: we don't restrict which
+// authenticator models users may register, so there is nothing to check them
+// against. We only require user presence, because the password already is the
+// first factor.
+
+// webauthnTimeout is how long browsers wait for the user to touch their
+// security key, and how long the challenge stays valid.
+const webauthnTimeout = 2 * time.Minute
+
+// RelyingParty identifies the Sourcegraph instance to authenticators.
+type RelyingParty struct {
+	// ID is the relying party ID, the hostname of the external URL.
+	// Credentials are scoped to it.
+	ID string
+	// Name is shown to users by some browsers when they register a
+	// credential.
+	Name string
+	// Origin is the origin of the external URL, which must match the origin
+	// reported by the browser.
+	Origin string
+}
+
+// RelyingPartyFromURL returns the RelyingParty for a Sourcegraph instance with
+// the given external URL.
+func RelyingPartyFromURL(externalURL *url.URL, name string) RelyingParty {
+	return RelyingParty{
+		ID:     externalURL.Hostname(),
+		Name:   name,
+		Origin: externalURL.Scheme + "://" + externalURL.Host,
+	}
+}
+
+func (rp RelyingParty) webAuthn() (*webauthn.WebAuthn, error) {
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: webauthnTimeout, TimeoutUVD: webauthnTimeout}
+	return webauthn.New(&webauthn.Config{
+		RPID:                  rp.ID,
+		RPDisplayName:         rp.Name,
+		RPOrigins:             []string{rp.Origin},
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementDiscouraged,
+			UserVerification: protocol.VerificationDiscouraged,
+		},
+		Timeouts: webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+}
+
+// Credential is a registered credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE-encoded public key of the credential.
+	PublicKey []byte
+	SignCount uint32
+}
+
+// User is a user who registers or signs in with a security key.
+type User struct {
+	ID          int32
+	Username    string
+	DisplayName string
+	// Credentials are the credentials the user already registered.
+	Credentials []Credential
+}
+
+// webAuthnUser implements webauthn.User.
+type webAuthnUser struct{ *User }
+
+func (u webAuthnUser) WebAuthnID() []byte {
+	return []byte(strconv.Itoa(int(u.ID)))
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	return u.Username
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	if u.DisplayName == "" {
+		return u.Username
+	}
+	return u.DisplayName
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		credentials = append(credentials, webauthn.Credential{
+			ID:            c.ID,
+			PublicKey:     c.PublicKey,
+			Authenticator: webauthn.Authenticator{SignCount: c.SignCount},
+		})
+	}
+	return credentials
+}
+
+// Session is the state of a registration or sign in between the options sent
+// to the browser and its response. It must be stored server-side, and may only
+// be used once.
+type Session = webauthn.SessionData
+
+// CreationOptions are the options for navigator.credentials.create to
+// register a new credential. Binary values are base64url-encoded, and must be
+// decoded by the client.
+type CreationOptions = protocol.PublicKeyCredentialCreationOptions
+
+// RequestOptions are the options for navigator.credentials.get to sign in
+// with a registered credential. Binary values are base64url-encoded, and must
+// be decoded by the client.
+type RequestOptions = protocol.PublicKeyCredentialRequestOptions
+
+// BeginRegistration returns the options to register a new credential for the
+// user. The credentials the user already registered are excluded, which keeps
+// them from registering the same security key twice.
+func (rp RelyingParty) BeginRegistration(user *User) (*CreationOptions, *Session, error) {
+	w, err := rp.webAuthn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u := webAuthnUser{user}
+	exclude := make([]protocol.CredentialDescriptor, 0, len(user.Credentials))
+	for _, c := range u.WebAuthnCredentials() {
+		exclude = append(exclude, c.Descriptor())
+	}
+	creation, session, err := w.BeginRegistration(u, webauthn.WithExclusions(exclude))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &creation.Response, session, nil
+}
+
+// FinishRegistration verifies the JSON-encoded PublicKeyCredential the browser
+// returned for the registration of the session, and returns the new
+// credential.
+func (rp RelyingParty) FinishRegistration(user *User, session *Session, response []byte) (*Credential, error) {
+	w, err := rp.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing registration response")
+	}
+	credential, err := w.CreateCredential(webAuthnUser{user}, *session, parsed)
+	if err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:        credential.ID,
+		PublicKey: credential.PublicKey,
+		SignCount: credential.Authenticator.SignCount,
+	}, nil
+}
+
+// BeginLogin returns the options to sign in with one of the credentials of the
+// user.
+func (rp RelyingParty) BeginLogin(user *User) (*RequestOptions, *Session, error) {
+	w, err := rp.webAuthn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assertion, session, err := w.BeginLogin(webAuthnUser{user})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &assertion.Response, session, nil
+}
+
+// FinishLogin verifies the JSON-encoded PublicKeyCredential the browser
+// returned for the sign in of the session against the credentials of the user.
+// It returns the credential that was used, with its new signature counter.
+func (rp RelyingParty) FinishLogin(user *User, session *Session, response []byte) (*Credential, error) {
+	w, err := rp.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing sign in response")
+	}
+	credential, err := w.ValidateLogin(webAuthnUser{user}, *session, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: A counter that doesn't increase indicates that the
+	// credential was cloned. Authenticators that don't implement a counter
+	// always report 0.
+	if credential.Authenticator.CloneWarning {
+		return nil, errors.New("signature counter did not increase, the security key may have been cloned")
+	}
+	return &Credential{
+		ID:        credential.ID,
+		PublicKey: credential.PublicKey,
+		SignCount: credential.Authenticator.SignCount,
+	}, nil
+}
@@ -0,0 +1,334 @@
+package secondfactor
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRP = RelyingParty{ID: "sourcegraph.example.com", Name: "Sourcegraph", Origin: "https://sourcegraph.example.com"}
+
+func TestRelyingPartyFromURL(t *testing.T) {
+	u, err := url.Parse("https://sourcegraph.example.com:8443/foo")
+	require.NoError(t, err)
+	require.Equal(t, RelyingParty{
+		ID:     "sourcegraph.example.com",
+		Name:   "Sourcegraph",
+		Origin: "https://sourcegraph.example.com:8443",
+	}, RelyingPartyFromURL(u, "Sourcegraph"))
+}
+
+func TestWebAuthn(t *testing.T) {
+	for name, newKey := range map[string]func(t *testing.T) *testAuthenticator{
+		"ES256": newES256Authenticator,
+		"EdDSA": newEdDSAAuthenticator,
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := newKey(t)
+			user := &User{ID: 1, Username: "alice"}
+
+			options, session, err := testRP.BeginRegistration(user)
+			require.NoError(t, err)
+			require.Equal(t, testRP.ID, options.RelyingParty.ID)
+			credential, err := testRP.FinishRegistration(user, session, a.register(t, testRP, options.Challenge.String()))
+			require.NoError(t, err)
+			require.Equal(t, a.id, credential.ID)
+			require.Equal(t, uint32(1), credential.SignCount)
+
+			// The registered credential is excluded from new registrations.
+			user.Credentials = []Credential{*credential}
+			options, _, err = testRP.BeginRegistration(user)
+			require.NoError(t, err)
+			require.Len(t, options.CredentialExcludeList, 1)
+			require.Equal(t, a.id, []byte(options.CredentialExcludeList[0].CredentialID))
+
+			requestOptions, session, err := testRP.BeginLogin(user)
+			require.NoError(t, err)
+			require.Len(t, requestOptions.AllowedCredentials, 1)
+			resp := a.assert(t, testRP, requestOptions.Challenge.String())
+			used, err := testRP.FinishLogin(user, session, resp)
+			require.NoError(t, err)
+			require.Equal(t, a.id, used.ID)
+			require.Equal(t, uint32(2), used.SignCount)
+
+			// Replaying the assertion fails once the counter is stored.
+			user.Credentials[0].SignCount = used.SignCount
+			_, err = testRP.FinishLogin(user, session, resp)
+			require.ErrorContains(t, err, "signature counter")
+		})
+	}
+}
+
+func TestFinishRegistration_Invalid(t *testing.T) {
+	user := &User{ID: 1, Username: "alice"}
+	options, session, err := testRP.BeginRegistration(user)
+	require.NoError(t, err)
+	challenge := options.Challenge.String()
+
+	for name, register := range map[string]func(a *testAuthenticator) []byte{
+		"wrong challenge": func(a *testAuthenticator) []byte {
+			return a.register(t, testRP, base64.RawURLEncoding.EncodeToString(randomBytes(t, 32)))
+		},
+		"wrong origin": func(a *testAuthenticator) []byte {
+			rp := testRP
+			rp.Origin = "https://evil.example.com"
+			return a.register(t, rp, challenge)
+		},
+		"wrong relying party": func(a *testAuthenticator) []byte {
+			rp := testRP
+			rp.ID = "evil.example.com"
+			return a.register(t, rp, challenge)
+		},
+		"user not present": func(a *testAuthenticator) []byte {
+			a.flags = 0
+			return a.register(t, testRP, challenge)
+		},
+		"assertion instead of registration": func(a *testAuthenticator) []byte {
+			return a.assert(t, testRP, challenge)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := testRP.FinishRegistration(user, session, register(newES256Authenticator(t)))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFinishLogin_Invalid(t *testing.T) {
+	a := newES256Authenticator(t)
+	user := &User{ID: 1, Username: "alice"}
+	options, session, err := testRP.BeginRegistration(user)
+	require.NoError(t, err)
+	credential, err := testRP.FinishRegistration(user, session, a.register(t, testRP, options.Challenge.String()))
+	require.NoError(t, err)
+	user.Credentials = []Credential{*credential}
+
+	requestOptions, session, err := testRP.BeginLogin(user)
+	require.NoError(t, err)
+	challenge := requestOptions.Challenge.String()
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other := newES256Authenticator(t)
+		other.id = a.id
+		other.signCount = 10
+		_, err := testRP.FinishLogin(user, session, other.assert(t, testRP, challenge))
+		require.Error(t, err)
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		var resp testPublicKeyCredential
+		require.NoError(t, json.Unmarshal(a.assert(t, testRP, challenge), &resp))
+		authData, err := base64.RawURLEncoding.DecodeString(resp.Response["authenticatorData"])
+		require.NoError(t, err)
+		authData[36]++
+		resp.Response["authenticatorData"] = base64.RawURLEncoding.EncodeToString(authData)
+		b, err := json.Marshal(resp)
+		require.NoError(t, err)
+		_, err = testRP.FinishLogin(user, session, b)
+		require.Error(t, err)
+	})
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		_, err := testRP.FinishLogin(user, session, a.assert(t, testRP, base64.RawURLEncoding.EncodeToString(randomBytes(t, 32))))
+		require.Error(t, err)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		other := &User{ID: 2, Username: "bob", Credentials: user.Credentials}
+		_, err := testRP.FinishLogin(other, session, a.assert(t, testRP, challenge))
+		require.Error(t, err)
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		_, err := testRP.FinishLogin(user, session, []byte(`{"id":"Y3JlZGVudGlhbA"}`))
+		require.Error(t, err)
+	})
+}
+
+// The COSE algorithm identifiers of the test keys.
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+)
+
+// The flags of authenticator data.
+const (
+	flagUserPresent            = 0x01
+	flagAttestedCredentialData = 0x40
+)
+
+// testAuthenticator simulates a security key with a single credential.
+type testAuthenticator struct {
+	id        []byte
+	coseKey   []byte
+	sign      func(data []byte) []byte
+	flags     byte
+	signCount uint32
+}
+
+func newES256Authenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+	return &testAuthenticator{
+		id:      randomBytes(t, 32),
+		coseKey: cborMap(1, int64(2), 3, int64(coseAlgES256), -1, int64(1), -2, x, -3, y),
+		sign: func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			require.NoError(t, err)
+			return sig
+		},
+		flags: flagUserPresent,
+	}
+}
+
+func newEdDSAAuthenticator(t *testing.T) *testAuthenticator {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return &testAuthenticator{
+		id:      randomBytes(t, 16),
+		coseKey: cborMap(1, int64(1), 3, int64(coseAlgEdDSA), -1, int64(6), -2, []byte(pub)),
+		sign: func(data []byte) []byte {
+			return ed25519.Sign(priv, data)
+		},
+		flags: flagUserPresent,
+	}
+}
+
+func (a *testAuthenticator) authenticatorData(rp RelyingParty, attested bool) []byte {
+	a.signCount++
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	b := append([]byte{}, rpIDHash[:]...)
+	flags := a.flags
+	if attested {
+		flags |= flagAttestedCredentialData
+	}
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, a.signCount)
+	if attested {
+		b = append(b, make([]byte, 16)...) // AAGUID
+		b = binary.BigEndian.AppendUint16(b, uint16(len(a.id)))
+		b = append(b, a.id...)
+		b = append(b, a.coseKey...)
+	}
+	return b
+}
+
+// testPublicKeyCredential is the JSON encoding of a PublicKeyCredential that
+// browsers return, with base64url-encoded binary values.
+type testPublicKeyCredential struct {
+	ID       string            `json:"id"`
+	RawID    string            `json:"rawId"`
+	Type     string            `json:"type"`
+	Response map[string]string `json:"response"`
+}
+
+func (a *testAuthenticator) credential(t *testing.T, response map[string]string) []byte {
+	id := base64.RawURLEncoding.EncodeToString(a.id)
+	b, err := json.Marshal(testPublicKeyCredential{ID: id, RawID: id, Type: "public-key", Response: response})
+	require.NoError(t, err)
+	return b
+}
+
+func (a *testAuthenticator) register(t *testing.T, rp RelyingParty, challenge string) []byte {
+	authData := a.authenticatorData(rp, true)
+	return a.credential(t, map[string]string{
+		"clientDataJSON":    clientDataJSON(t, "webauthn.create", challenge, rp.Origin),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(cborStringMap("fmt", "none", "attStmt", encodedCBOR(cborMap()), "authData", authData)),
+	})
+}
+
+func (a *testAuthenticator) assert(t *testing.T, rp RelyingParty, challenge string) []byte {
+	authData := a.authenticatorData(rp, false)
+	clientData := clientDataJSON(t, "webauthn.get", challenge, rp.Origin)
+	raw, err := base64.RawURLEncoding.DecodeString(clientData)
+	require.NoError(t, err)
+	clientDataHash := sha256.Sum256(raw)
+
+	return a.credential(t, map[string]string{
+		"clientDataJSON":    clientData,
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(a.sign(append(append([]byte{}, authData...), clientDataHash[:]...))),
+	})
+}
+
+func clientDataJSON(t *testing.T, typ, challenge, origin string) string {
+	b, err := json.Marshal(map[string]any{"type": typ, "challenge": challenge, "origin": origin, "crossOrigin": false})
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
+
+// cborMap encodes a map with integer keys from alternating keys and values.
+func cborMap(kvs ...any) []byte {
+	b := cborHead(5, uint64(len(kvs)/2))
+	for i := 0; i < len(kvs); i += 2 {
+		b = append(b, cborEncode(int64(kvs[i].(int)))...)
+		b = append(b, cborEncode(kvs[i+1])...)
+	}
+	return b
+}
+
+// cborStringMap encodes a map with text keys from alternating keys and
+// values.
+func cborStringMap(kvs ...any) []byte {
+	b := cborHead(5, uint64(len(kvs)/2))
+	for i := 0; i < len(kvs); i += 2 {
+		b = append(b, cborEncode(kvs[i])...)
+		b = append(b, cborEncode(kvs[i+1])...)
+	}
+	return b
+}
+
+func cborEncode(v any) []byte {
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+		return cborHead(0, uint64(v))
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case encodedCBOR:
+		return v
+	}
+	panic("unsupported type")
+}
+
+type encodedCBOR []byte
+
+func cborHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	}
+}
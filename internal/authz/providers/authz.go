@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/authz/providers/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/authz/providers/perforce"
@@ -61,6 +62,7 @@ func ProvidersFromConfig(
 			extsvc.VariantBitbucketCloud.AsKind(),
 			extsvc.VariantBitbucketServer.AsKind(),
 			extsvc.VariantGerrit.AsKind(),
+			extsvc.VariantGitea.AsKind(),
 			extsvc.VariantGitHub.AsKind(),
 			extsvc.VariantGitLab.AsKind(),
 			extsvc.VariantPerforce.AsKind(),
@@ -77,6 +79,7 @@ func ProvidersFromConfig(
 		perforceConns        []*types.PerforceConnection
 		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		gerritConns          []*types.GerritConnection
+		giteaConns           []*types.GiteaConnection
 		azuredevopsConns     []*types.AzureDevOpsConnection
 	)
 	for {
@@ -118,6 +121,11 @@ func ProvidersFromConfig(
 					URN:              svc.URN(),
 					GerritConnection: c,
 				})
+			case *schema.GiteaConnection:
+				giteaConns = append(giteaConns, &types.GiteaConnection{
+					URN:             svc.URN(),
+					GiteaConnection: c,
+				})
 			case *schema.GitHubConnection:
 				gitHubConns = append(gitHubConns,
 					&github.ExternalConnection{
@@ -161,6 +169,7 @@ func ProvidersFromConfig(
 	initResult.Append(perforce.NewAuthzProviders(db, perforceConns))
 	initResult.Append(bitbucketcloud.NewAuthzProviders(db, bitbucketCloudConns))
 	initResult.Append(gerrit.NewAuthzProviders(gerritConns))
+	initResult.Append(gitea.NewAuthzProviders(giteaConns, httpcli.ExternalDoer))
 	initResult.Append(azuredevops.NewAuthzProviders(db, azuredevopsConns, httpcli.ExternalClient))
 
 	return initResult.Providers, initResult.Problems, initResult.Warnings, initResult.InvalidConnections
@@ -0,0 +1,54 @@
+package gitea
+
+import (
+	"net/url"
+
+	atypes "github.com/sourcegraph/sourcegraph/internal/authz/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Gitea authz providers derived from the connections.
+func NewAuthzProviders(conns []*types.GiteaConnection, cli httpcli.Doer) *atypes.ProviderInitResult {
+	initResults := &atypes.ProviderInitResult{}
+
+	for _, c := range conns {
+		if c.Authorization == nil {
+			// No authorization required
+			continue
+		}
+
+		if err := licensing.Check(licensing.FeatureACLs); err != nil {
+			initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.TypeGitea)
+			initResults.Problems = append(initResults.Problems, err.Error())
+			continue
+		}
+
+		p, err := newAuthzProvider(c, cli)
+		if err != nil {
+			initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.TypeGitea)
+			initResults.Problems = append(initResults.Problems, err.Error())
+		}
+		if p != nil {
+			initResults.Providers = append(initResults.Providers, p)
+		}
+	}
+	return initResults
+}
+
+func newAuthzProvider(c *types.GiteaConnection, cli httpcli.Doer) (*Provider, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gitea.NewClient(c.URN, baseURL, c.Token, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProvider(c.URN, client), nil
+}
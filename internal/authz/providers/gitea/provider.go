@@ -0,0 +1,140 @@
+// Package gitea contains an authorization provider for Gitea and Forgejo.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of authz.Provider that provides repository
+// permissions as determined from a Gitea instance API. It assumes usernames of
+// Sourcegraph accounts match 1-1 with usernames of Gitea users.
+type Provider struct {
+	urn      string
+	client   *gitea.Client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitea authorization provider. The given client
+// must be authenticated with a token of a site admin, since permissions are
+// fetched by impersonating the user in question.
+func NewProvider(urn string, client *gitea.Client) *Provider {
+	return &Provider{
+		urn:      urn,
+		client:   client,
+		codeHost: extsvc.NewCodeHost(client.URL, extsvc.TypeGitea),
+	}
+}
+
+// FetchAccount looks up the Gitea user with the same username as the given
+// Sourcegraph user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	giteaUser, err := p.client.GetUser(ctx, user.Username)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(giteaUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   strconv.FormatInt(giteaUser.ID, 10),
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of private repository IDs (on code host) that
+// the given account has read access to. Access through collaboration and
+// organization team membership is included, since the list is fetched on behalf
+// of the user.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	user, err := encryption.DecryptJSON[gitea.User](ctx, account.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	extIDs := []extsvc.RepoID{}
+	it := p.client.WithSudo(user.Login).ListAccessibleRepos(ctx)
+	for it.Next() {
+		repo := it.Current()
+		if !repo.Private && !repo.Internal {
+			continue
+		}
+		extIDs = append(extIDs, extsvc.RepoID(strconv.FormatInt(repo.ID, 10)))
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, it.Err()
+}
+
+// FetchRepoPerms is not implemented for Gitea, since the collaborators API does
+// not include users that have access through organization teams.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitea.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection validates that the configured token belongs to a site
+// admin, which is required to fetch permissions on behalf of other users.
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	user, err := p.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching authenticated user")
+	}
+	if !user.IsAdmin {
+		return errors.Newf("Gitea token of user %q is not a site admin token", user.Login)
+	}
+	return nil
+}
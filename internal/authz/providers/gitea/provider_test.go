@@ -0,0 +1,161 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func createTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/user":
+			json.NewEncoder(w).Encode(gitea.User{ID: 1, Login: "admin", IsAdmin: true})
+		case "/api/v1/users/alice":
+			json.NewEncoder(w).Encode(gitea.User{ID: 2, Login: "alice"})
+		case "/api/v1/user/repos":
+			if r.Header.Get("Sudo") != "alice" {
+				http.Error(w, "expected impersonation of alice", http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode([]gitea.Repository{
+				{ID: 10, FullName: "org/private", Private: true},
+				{ID: 11, FullName: "org/public"},
+				{ID: 12, FullName: "org/internal", Internal: true},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+
+	ratelimit.SetupForTest(t)
+
+	srv := createTestServer(t)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli, err := gitea.NewClient("extsvc:gitea:1", u, "admin-token", http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProvider("extsvc:gitea:1", cli)
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t)
+	ctx := context.Background()
+
+	t.Run("matching user", func(t *testing.T) {
+		acct, err := p.FetchAccount(ctx, &types.User{ID: 42, Username: "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := extsvc.AccountSpec{
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   p.ServiceID(),
+			AccountID:   "2",
+		}
+		if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+			t.Fatalf("AccountSpec mismatch (-want +got):\n%s", diff)
+		}
+		if acct.UserID != 42 {
+			t.Fatalf("wrong UserID: %d", acct.UserID)
+		}
+	})
+
+	t.Run("no matching user", func(t *testing.T) {
+		acct, err := p.FetchAccount(ctx, &types.User{ID: 43, Username: "bob"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct != nil {
+			t.Fatalf("expected no account, got %+v", acct)
+		}
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t)
+	ctx := context.Background()
+
+	t.Run("nil account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(ctx, nil, authz.FetchPermsOptions{})
+		want := "no account provided"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(ctx,
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitea,
+					ServiceID:   "https://gitea.example.com/",
+				},
+				AccountData: extsvc.AccountData{
+					Data: extsvc.NewUnencryptedData(json.RawMessage(`{}`)),
+				},
+			},
+			authz.FetchPermsOptions{},
+		)
+		want := `not a code host of the account: want "` + p.ServiceID() + `" but have "https://gitea.example.com/"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("private repos of the account", func(t *testing.T) {
+		acct, err := p.FetchAccount(ctx, &types.User{ID: 42, Username: "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		perms, err := p.FetchUserPerms(ctx, acct, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []extsvc.RepoID{"10", "12"}
+		if diff := cmp.Diff(want, perms.Exacts); diff != "" {
+			t.Fatalf("Exacts mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t)
+
+	_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{}, authz.FetchPermsOptions{})
+	if _, ok := err.(*authz.ErrUnimplemented); !ok {
+		t.Fatalf("expected ErrUnimplemented, got %v", err)
+	}
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	p := newTestProvider(t)
+
+	if err := p.ValidateConnection(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/batches/syncer"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/batches/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -91,7 +92,11 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 	switch job.JobType {
 
 	case btypes.ChangesetJobTypeComment:
-		return nil, b.comment(ctx, job)
+		if err := b.comment(ctx, job); err != nil {
+			return nil, err
+		}
+		b.autoMergeChangeset(ctx)
+		return nil, nil
 	case btypes.ChangesetJobTypeDetach:
 		return nil, b.detach(ctx, job)
 	case btypes.ChangesetJobTypeReenqueue:
@@ -127,6 +132,30 @@ func (b *bulkProcessor) comment(ctx context.Context, job *btypes.ChangesetJob) e
 	return b.css.CreateComment(ctx, cs, typedPayload.Message)
 }
 
+// autoMergeChangeset merges the changeset if it satisfies the auto-merge policy
+// of its batch change, so that it doesn't have to wait for the next sync. The
+// job already succeeded, so a failed merge is only logged: retrying the job
+// would post the comment again.
+func (b *bulkProcessor) autoMergeChangeset(ctx context.Context) {
+	policy, err := syncer.AutoMergePolicy(ctx, b.tx, b.ch)
+	if err != nil {
+		b.logger.Warn("loading auto-merge policy", log.Int64("changeset", b.ch.ID), log.Error(err))
+		return
+	}
+	if policy == nil {
+		return
+	}
+	events, err := b.ch.Events()
+	if err != nil {
+		b.logger.Warn("loading changeset events", log.Int64("changeset", b.ch.ID), log.Error(err))
+		return
+	}
+	client := gitserver.NewClient("batches.bulkprocessor.automerge")
+	if err := syncer.AutoMergeChangeset(ctx, b.tx, client, b.css, b.repo, b.ch, events, policy); err != nil {
+		b.logger.Warn("auto-merging changeset", log.Int64("changeset", b.ch.ID), log.Error(err))
+	}
+}
+
 func (b *bulkProcessor) detach(ctx context.Context, job *btypes.ChangesetJob) error {
 	// Try to detach the changeset from the batch change of the job.
 	var detached bool
@@ -188,6 +217,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		b.logger.Error("Events", log.Error(err))
 		return nil, errcode.MakeNonRetryable(err)
 	}
+	previousState := cs.Changeset.ExternalState
 	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.mergechangeset"), cs.Changeset, events)
 
 	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
@@ -200,6 +230,11 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets that depend on the merged changeset may be published now.
+	if err := b.enqueueHeldChangesets(ctx, previousState); err != nil {
+		return nil, err
+	}
+
 	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetClose) }
 	return afterDone, nil
 }
@@ -224,6 +259,7 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (afterDone func(*sto
 		b.logger.Error("Events", log.Error(err))
 		return nil, errcode.MakeNonRetryable(err)
 	}
+	previousState := cs.Changeset.ExternalState
 	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.closechangeset"), cs.Changeset, events)
 
 	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
@@ -236,10 +272,29 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (afterDone func(*sto
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets that depend on the closed changeset can't be published anymore.
+	if err := b.enqueueHeldChangesets(ctx, previousState); err != nil {
+		return nil, err
+	}
+
 	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetClose) }
 	return afterDone, nil
 }
 
+// enqueueHeldChangesets re-enqueues the changesets that are held back until the
+// changeset is merged, if its external state changed from previousState in a
+// way that affects them.
+func (b *bulkProcessor) enqueueHeldChangesets(ctx context.Context, previousState btypes.ChangesetExternalState) error {
+	if b.ch.OwnedByBatchChangeID == 0 || !store.HeldChangesetsAffected(previousState, b.ch.ExternalState) {
+		return nil
+	}
+	if err := b.tx.EnqueueHeldChangesets(ctx, b.ch.OwnedByBatchChangeID); err != nil {
+		b.logger.Error("EnqueueHeldChangesets", log.Error(err))
+		return errcode.MakeNonRetryable(err)
+	}
+	return nil
+}
+
 func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
 	typedPayload, ok := job.Payload.(*btypes.ChangesetJobPublishPayload)
 	if !ok {
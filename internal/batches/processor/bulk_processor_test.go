@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func mockDoer(req *http.Request) (*http.Response, error) {
@@ -162,6 +163,63 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Comment job auto-merges changeset", func(t *testing.T) {
+		autoMergeSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-bulk-auto-merge", user.ID, 0)
+		autoMergeSpec.Spec.AutoMerge = &batcheslib.AutoMerge{MergeMethod: batcheslib.MergeMethodSquash}
+		if err := bstore.UpdateBatchSpec(ctx, autoMergeSpec); err != nil {
+			t.Fatal(err)
+		}
+		autoMergeBatchChange := bt.CreateBatchChange(t, ctx, bstore, "test-bulk-auto-merge", user.ID, autoMergeSpec.ID)
+		autoMergeChangeset := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: autoMergeBatchChange.ID}},
+			OwnedByBatchChange:  autoMergeBatchChange.ID,
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+		})
+
+		fake := &stesting.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+			logger:  logtest.Scoped(t),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeComment,
+			ChangesetID: autoMergeChangeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobCommentPayload{},
+		}
+		if err := bstore.CreateChangesetJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bp.Process(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+
+		event, err := bstore.GetChangesetEvent(ctx, store.GetChangesetEventOpts{
+			ChangesetID: autoMergeChangeset.ID,
+			Kind:        btypes.ChangesetEventKindAutoMerged,
+			Key:         (&btypes.AutoMergedEvent{}).Key(),
+		})
+		if err != nil {
+			t.Fatalf("expected auto-merge event to be recorded: %s", err)
+		}
+		merged, ok := event.Metadata.(*btypes.AutoMergedEvent)
+		if !ok {
+			t.Fatalf("unexpected event metadata %T", event.Metadata)
+		}
+		if merged.BatchChangeID != autoMergeBatchChange.ID || !merged.Squash {
+			t.Fatalf("unexpected auto-merge event %+v", merged)
+		}
+	})
+
 	t.Run("Detach job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{}
 		bp := &bulkProcessor{
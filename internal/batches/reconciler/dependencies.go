@@ -0,0 +1,175 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// LoadPrerequisites returns the changesets that have to be merged before the
+// given changeset is published, according to the changeset dependencies in the
+// batch spec of the batch change that owns it. Imported changesets have no
+// prerequisites.
+//
+// Repositories are loaded with the actor in ctx, so changesets in repositories
+// that aren't visible to the actor are neither matched nor returned.
+func LoadPrerequisites(ctx context.Context, s *store.Store, ch *btypes.Changeset) ([]*btypes.Changeset, error) {
+	if ch.OwnedByBatchChangeID == 0 {
+		return nil, nil
+	}
+
+	batchChange, err := s.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+	batchSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec")
+	}
+	if len(batchSpec.Spec.ChangesetDependencies) == 0 {
+		return nil, nil
+	}
+
+	repo, err := s.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "loading repository")
+	}
+	patterns, err := batchSpec.Spec.ChangesetPrerequisitePatterns(string(repo.Name))
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	// Only the changesets in the repositories matching the patterns are
+	// loaded, instead of all changesets of the batch change.
+	regexps := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := batches.GlobRegexp(pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexps = append(regexps, re)
+	}
+	changesets, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+		RepoNameRegexps:      regexps,
+		EnforceAuthz:         true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	var prerequisites []*btypes.Changeset
+	for _, c := range changesets {
+		// Changesets never depend on changesets in their own repository.
+		if c.RepoID != ch.RepoID {
+			prerequisites = append(prerequisites, c)
+		}
+	}
+	return prerequisites, nil
+}
+
+// PrerequisitesMerged returns true if all of the given changesets have been
+// merged.
+func PrerequisitesMerged(prerequisites []*btypes.Changeset) bool {
+	for _, c := range prerequisites {
+		if c.ExternalState != btypes.ChangesetExternalStateMerged {
+			return false
+		}
+	}
+	return true
+}
+
+// BlockedPrerequisites returns the given changesets that are not going to be
+// merged unless a user steps in: they were closed, deleted or archived on the
+// code host, or they are going to stay unpublished. Changesets that depend on
+// them would otherwise be held forever.
+func BlockedPrerequisites(ctx context.Context, s *store.Store, prerequisites []*btypes.Changeset) ([]*btypes.Changeset, error) {
+	var specIDs []int64
+	for _, c := range prerequisites {
+		if c.Unpublished() && c.CurrentSpecID != 0 {
+			specIDs = append(specIDs, c.CurrentSpecID)
+		}
+	}
+
+	specs := make(map[int64]*btypes.ChangesetSpec, len(specIDs))
+	if len(specIDs) > 0 {
+		cs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: specIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing changeset specs")
+		}
+		for _, spec := range cs {
+			specs[spec.ID] = spec
+		}
+	}
+
+	var blocked []*btypes.Changeset
+	for _, c := range prerequisites {
+		switch c.ExternalState {
+		case btypes.ChangesetExternalStateClosed, btypes.ChangesetExternalStateDeleted, btypes.ChangesetExternalStateReadOnly:
+			blocked = append(blocked, c)
+			continue
+		}
+		if spec, ok := specs[c.CurrentSpecID]; ok && c.Unpublished() {
+			if calculatePublicationState(spec.Published, c.UiPublicationState).IsUnpublished() {
+				blocked = append(blocked, c)
+			}
+		}
+	}
+	return blocked, nil
+}
+
+// blockedPrerequisitesError is returned by the reconciler for a changeset that
+// is held back until changesets are merged that are not going to be merged.
+type blockedPrerequisitesError struct {
+	descriptions []string
+}
+
+func newBlockedPrerequisitesError(ctx context.Context, s *store.Store, blocked []*btypes.Changeset) error {
+	repoIDs := make([]api.RepoID, 0, len(blocked))
+	for _, c := range blocked {
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	repos, err := s.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return errors.Wrap(err, "loading repositories")
+	}
+
+	descriptions := make([]string, 0, len(blocked))
+	for _, c := range blocked {
+		name := fmt.Sprintf("changeset %d", c.ID)
+		if repo, ok := repos[c.RepoID]; ok {
+			name = fmt.Sprintf("the changeset in %s", repo.Name)
+		}
+		switch c.ExternalState {
+		case btypes.ChangesetExternalStateClosed:
+			descriptions = append(descriptions, name+" was closed")
+		case btypes.ChangesetExternalStateDeleted:
+			descriptions = append(descriptions, name+" was deleted")
+		case btypes.ChangesetExternalStateReadOnly:
+			descriptions = append(descriptions, name+" is in an archived repository")
+		default:
+			descriptions = append(descriptions, name+" is not going to be published")
+		}
+	}
+	return errcode.MakeNonRetryable(blockedPrerequisitesError{descriptions: descriptions})
+}
+
+func (e blockedPrerequisitesError) Error() string {
+	return fmt.Sprintf("changeset can't be published until the changesets it depends on are merged, but %s", strings.Join(e.descriptions, ", "))
+}
@@ -0,0 +1,117 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestPrerequisites(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := bstore.New(db, observation.TestContextTB(t), nil)
+
+	user := bt.CreateTestUser(t, db, true)
+	// The repositories are named repo-<external service ID>-<n>.
+	repos, _ := bt.CreateTestRepos(t, ctx, db, 3)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "dependencies", user.ID, 0)
+	batchSpec.Spec.ChangesetDependencies = []batches.ChangesetDependency{
+		{Repository: string(repos[0].Name), DependsOn: []string{"repo-*-2"}},
+	}
+	if err := store.UpdateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := bt.CreateBatchChange(t, ctx, store, "dependencies", user.ID, batchSpec.ID)
+
+	createChangeset := func(repo int, opts bt.TestChangesetOpts) *btypes.Changeset {
+		opts.Repo = repos[repo].ID
+		opts.BatchChange = batchChange.ID
+		opts.OwnedByBatchChange = batchChange.ID
+		return bt.CreateChangeset(t, ctx, store, opts)
+	}
+	changesetIDs := func(cs []*btypes.Changeset) []int64 {
+		ids := []int64{}
+		for _, c := range cs {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	dependent := createChangeset(0, bt.TestChangesetOpts{PublicationState: btypes.ChangesetPublicationStateUnpublished})
+	open := createChangeset(1, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+	})
+	closed := createChangeset(1, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateClosed,
+	})
+	unpublishedSpec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repos[1].ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/unpublished",
+		Typ:       btypes.ChangesetSpecTypeBranch,
+		Published: false,
+	})
+	unpublished := createChangeset(1, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		CurrentSpec:      unpublishedSpec.ID,
+	})
+	// The changeset in the third repository is neither loaded nor returned.
+	createChangeset(2, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+	})
+
+	prerequisites, err := LoadPrerequisites(ctx, store, dependent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int64{open.ID, closed.ID, unpublished.ID}, changesetIDs(prerequisites)); diff != "" {
+		t.Fatalf("wrong prerequisites (-want +have):\n%s", diff)
+	}
+
+	withoutPrerequisites, err := LoadPrerequisites(ctx, store, open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withoutPrerequisites) != 0 {
+		t.Fatalf("unexpected prerequisites %v", changesetIDs(withoutPrerequisites))
+	}
+
+	blocked, err := BlockedPrerequisites(ctx, store, prerequisites)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int64{closed.ID, unpublished.ID}, changesetIDs(blocked)); diff != "" {
+		t.Fatalf("wrong blocked prerequisites (-want +have):\n%s", diff)
+	}
+
+	err = newBlockedPrerequisitesError(ctx, store, blocked)
+	if !errcode.IsNonRetryable(err) {
+		t.Fatalf("error is retryable: %v", err)
+	}
+	want := "changeset can't be published until the changesets it depends on are merged, but the changeset in " + string(repos[1].Name) + " was closed, the changeset in " + string(repos[1].Name) + " is not going to be published"
+	if err.Error() != want {
+		t.Fatalf("wrong error. want=%q, have=%q", want, err.Error())
+	}
+}
@@ -112,6 +112,30 @@ type Plan struct {
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// HoldForPrerequisites changes the plan so that the changeset isn't opened for
+// review while the changesets it depends on haven't been merged. A changeset
+// that would be published is published as a draft instead, if the code host
+// supports drafts, and stays unpublished otherwise. Drafts aren't undrafted.
+func (p *Plan) HoldForPrerequisites() {
+	if p.Ops.Contains(btypes.ReconcilerOperationPublish) && !p.Changeset.SupportsDraft() {
+		p.Ops = Operations{}
+		return
+	}
+
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish:
+			ops = append(ops, btypes.ReconcilerOperationPublishDraft)
+		case btypes.ReconcilerOperationUndraft:
+			// Stay a draft until the prerequisites are merged.
+		default:
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -460,3 +460,43 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 		})
 	}
 }
+
+func TestPlan_HoldForPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name           string
+		changeset      bt.TestChangesetOpts
+		ops            Operations
+		wantOperations Operations
+	}{
+		{
+			name:           "publish as draft",
+			changeset:      bt.TestChangesetOpts{ExternalServiceType: extsvc.TypeGitHub},
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+		},
+		{
+			name:           "stay unpublished without draft support",
+			changeset:      bt.TestChangesetOpts{ExternalServiceType: extsvc.TypeBitbucketServer},
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "stay draft",
+			changeset:      bt.TestChangesetOpts{ExternalServiceType: extsvc.TypeGitHub},
+			ops:            Operations{btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationUpdate},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{Changeset: bt.BuildChangeset(tc.changeset), Ops: tc.ops}
+			plan.HoldForPrerequisites()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined, want=%v have=%v", want, have)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Reconciler processes changesets and reconciles their current state — in
@@ -97,6 +98,25 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil, err
 	}
 
+	// Only publishing and undrafting wait for the changesets this changeset
+	// depends on, so the dependencies aren't loaded otherwise.
+	if plan.Ops.Contains(btypes.ReconcilerOperationPublish) || plan.Ops.Contains(btypes.ReconcilerOperationUndraft) {
+		prerequisites, err := LoadPrerequisites(ctx, tx, ch)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading changeset dependencies")
+		}
+		if !PrerequisitesMerged(prerequisites) {
+			blocked, err := BlockedPrerequisites(ctx, tx, prerequisites)
+			if err != nil {
+				return nil, errors.Wrap(err, "checking changeset dependencies")
+			}
+			if len(blocked) > 0 {
+				return nil, newBlockedPrerequisitesError(ctx, tx, blocked)
+			}
+			plan.HoldForPrerequisites()
+		}
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
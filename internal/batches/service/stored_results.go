@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	insightsstore "github.com/sourcegraph/sourcegraph/internal/insights/store"
+	searchjobs "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/service"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StoredResults loads the results of searches that ran ahead of time, so that
+// `on` entries can reference them instead of running a search that may time
+// out. Both methods check that the actor in ctx can access the search job or
+// series, but the returned repositories are not filtered by repository
+// permissions.
+type StoredResults interface {
+	// SearchJobRepoRevisions returns the repository revisions in which the
+	// completed search job with the given ID found results.
+	SearchJobRepoRevisions(ctx context.Context, id int64) ([]StoredRepoRevision, error)
+	// InsightSeriesRepos returns the repositories with a non-zero value in the
+	// latest recording of the insight series with the given series ID.
+	InsightSeriesRepos(ctx context.Context, seriesID string) ([]api.RepoID, error)
+}
+
+// StoredRepoRevision is a repository revision found in stored results. An
+// empty revision or HEAD stands for the default branch.
+type StoredRepoRevision struct {
+	Repo     api.RepoID
+	Revision string
+}
+
+var (
+	ErrSearchJobsDisabled = errors.New("search jobs are not enabled")
+	ErrInsightsDisabled   = errors.New("code insights are not enabled")
+)
+
+// NewStoredResults returns StoredResults that read search jobs with
+// searchJobs and insight series from insightsDB. Either may be nil if the
+// feature is disabled, in which case `on` entries referencing it fail to
+// resolve.
+func NewStoredResults(db database.DB, searchJobs *searchjobs.Service, insightsDB database.InsightsDB) StoredResults {
+	r := &storedResults{searchJobs: searchJobs}
+	if insightsDB != nil {
+		r.insightPermStore = insightsstore.NewInsightPermissionStore(db)
+		r.insightStore = insightsstore.NewInsightStore(insightsDB)
+		r.seriesStore = insightsstore.New(insightsDB, r.insightPermStore)
+	}
+	return r
+}
+
+type storedResults struct {
+	searchJobs *searchjobs.Service
+
+	insightPermStore *insightsstore.InsightPermStore
+	insightStore     *insightsstore.InsightStore
+	seriesStore      *insightsstore.Store
+}
+
+func (r *storedResults) SearchJobRepoRevisions(ctx context.Context, id int64) ([]StoredRepoRevision, error) {
+	if r.searchJobs == nil {
+		return nil, ErrSearchJobsDisabled
+	}
+
+	repoRevs, err := r.searchJobs.ListSearchJobRepoRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revs := make([]StoredRepoRevision, 0, len(repoRevs))
+	for _, rr := range repoRevs {
+		revs = append(revs, StoredRepoRevision{Repo: rr.Repository, Revision: rr.Revision})
+	}
+	return revs, nil
+}
+
+func (r *storedResults) InsightSeriesRepos(ctx context.Context, seriesID string) ([]api.RepoID, error) {
+	if r.seriesStore == nil {
+		return nil, ErrInsightsDisabled
+	}
+
+	// 🚨 SECURITY: the series may only be used if it is part of an insight
+	// that the user can view.
+	userIDs, orgIDs, err := r.insightPermStore.GetUserPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	views, err := r.insightStore.GetAll(ctx, insightsstore.InsightQueryArgs{
+		SeriesID: seriesID,
+		UserIDs:  userIDs,
+		OrgIDs:   orgIDs,
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, errors.Newf("insight series %q not found", seriesID)
+	}
+
+	return r.seriesStore.SeriesRepoIDs(ctx, seriesID)
+}
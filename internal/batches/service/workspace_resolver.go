@@ -73,12 +73,16 @@ type WorkspaceResolver interface {
 
 type WorkspaceResolverBuilder func(tx *store.Store) WorkspaceResolver
 
-func NewWorkspaceResolver(s *store.Store) WorkspaceResolver {
+// NewWorkspaceResolver returns a WorkspaceResolver that resolves `on` entries
+// referencing search jobs and insight series with storedResults, which may be
+// nil if neither is available.
+func NewWorkspaceResolver(s *store.Store, storedResults StoredResults) WorkspaceResolver {
 	return &workspaceResolver{
 		store:               s,
 		logger:              log.Scoped("batches.workspaceResolver"),
 		gitserverClient:     gitserver.NewClient("batches.workspaceresolver"),
 		frontendInternalURL: internalapi.Client.URL + "/.internal",
+		storedResults:       storedResults,
 	}
 }
 
@@ -87,6 +91,7 @@ type workspaceResolver struct {
 	store               *store.Store
 	gitserverClient     gitserver.Client
 	frontendInternalURL string
+	storedResults       StoredResults
 }
 
 func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context, batchSpec *batcheslib.BatchSpec) (workspaces []*RepoWorkspace, err error) {
@@ -239,6 +244,18 @@ func (wr *workspaceResolver) resolveRepositoriesOn(ctx context.Context, on *batc
 		return revs, onlib.RepositoryRuleTypeQuery, err
 	}
 
+	// Stored results are treated like the results of a query, so that
+	// explicitly listed repositories take precedence over them.
+	if on.ExhaustiveSearchJob != 0 {
+		revs, err := wr.resolveSearchJob(ctx, on.ExhaustiveSearchJob)
+		return revs, onlib.RepositoryRuleTypeQuery, err
+	}
+
+	if on.InsightSeries != "" {
+		revs, err := wr.resolveInsightSeries(ctx, on.InsightSeries)
+		return revs, onlib.RepositoryRuleTypeQuery, err
+	}
+
 	branches, err := on.GetBranches()
 	if err != nil {
 		return nil, onlib.RepositoryRuleTypeExplicit, err
@@ -386,6 +403,117 @@ func (wr *workspaceResolver) resolveRepositoriesMatchingQuery(ctx context.Contex
 	return revs, nil
 }
 
+// resolveSearchJob resolves the repository revisions in which a completed
+// search job found results.
+func (wr *workspaceResolver) resolveSearchJob(ctx context.Context, id int64) (_ []*RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveSearchJob", attribute.Int64("searchJobID", id))
+	defer tr.EndWithErr(&err)
+
+	if wr.storedResults == nil {
+		return nil, ErrSearchJobsDisabled
+	}
+
+	storedRevs, err := wr.storedResults.SearchJobRepoRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(storedRevs))
+	for _, rev := range storedRevs {
+		repoIDs = append(repoIDs, rev.Repo)
+	}
+	repos, err := wr.accessibleRepos(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	revs := make([]*RepoRevision, 0, len(storedRevs))
+	for _, storedRev := range storedRevs {
+		repo, ok := repos[storedRev.Repo]
+		if !ok {
+			continue
+		}
+
+		var rev *RepoRevision
+		if storedRev.Revision == "" || storedRev.Revision == "HEAD" {
+			rev, err = repoToRepoRevisionWithDefaultBranch(ctx, wr.gitserverClient, repo, []string{})
+		} else {
+			rev, err = repoToRepoRevisionWithBranch(ctx, wr.gitserverClient, repo, storedRev.Revision)
+		}
+		if err != nil {
+			// The repository or branch may have been deleted since the search
+			// job ran.
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+
+	return revs, nil
+}
+
+// resolveInsightSeries resolves the default branches of the repositories in
+// the latest recording of an insight series.
+func (wr *workspaceResolver) resolveInsightSeries(ctx context.Context, seriesID string) (_ []*RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveInsightSeries", attribute.String("seriesID", seriesID))
+	defer tr.EndWithErr(&err)
+
+	if wr.storedResults == nil {
+		return nil, ErrInsightsDisabled
+	}
+
+	repoIDs, err := wr.storedResults.InsightSeriesRepos(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := wr.accessibleRepos(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	revs := make([]*RepoRevision, 0, len(repos))
+	for _, id := range repoIDs {
+		repo, ok := repos[id]
+		if !ok {
+			continue
+		}
+		rev, err := repoToRepoRevisionWithDefaultBranch(ctx, wr.gitserverClient, repo, []string{})
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+
+	return revs, nil
+}
+
+// accessibleRepos returns the repositories with the given IDs that the actor in
+// ctx can access, by ID.
+func (wr *workspaceResolver) accessibleRepos(ctx context.Context, ids []api.RepoID) (map[api.RepoID]*types.Repo, error) {
+	if len(ids) == 0 {
+		return map[api.RepoID]*types.Repo{}, nil
+	}
+
+	// 🚨 SECURITY: Stored results may include repositories that the user can no
+	// longer access, so we use database.Repos.List to check whether the user
+	// has access to the repositories or not.
+	repos, err := wr.store.Repos().List(ctx, database.ReposListOptions{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		byID[repo.ID] = repo
+	}
+	return byID, nil
+}
+
 const internalSearchClientUserAgent = "Batch Changes repository resolver"
 
 func determineDefaultPatternType(batchSpecVersion int) searchquery.SearchType {
@@ -452,6 +580,25 @@ func repoToRepoRevisionWithDefaultBranch(ctx context.Context, gitserverClient gi
 	return repoRev, nil
 }
 
+func repoToRepoRevisionWithBranch(ctx context.Context, gitserverClient gitserver.Client, repo *types.Repo, branch string) (_ *RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "repoToRepoRevisionWithBranch")
+	defer tr.EndWithErr(&err)
+
+	commit, err := gitserverClient.ResolveRevision(ctx, repo.Name, branch, gitserver.ResolveRevisionOptions{
+		EnsureRevision: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RepoRevision{
+		Repo:        repo,
+		Branch:      branch,
+		Commit:      commit,
+		FileMatches: []string{},
+	}, nil
+}
+
 const batchIgnoreFilePath = ".batchignore"
 
 func hasBatchIgnoreFile(ctx context.Context, gitserverClient gitserver.Client, r *RepoRevision) (_ bool, err error) {
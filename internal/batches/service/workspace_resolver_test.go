@@ -410,6 +410,79 @@ func TestService_ResolveWorkspacesForBatchSpec(t *testing.T) {
 		want := []*RepoWorkspace{ws1}
 		resolveWorkspacesAndCompare(t, s, gs, u, map[string][]streamhttp.EventMatch{}, batchSpec, want)
 	})
+
+	t.Run("stored results", func(t *testing.T) {
+		batchSpec := &batcheslib.BatchSpec{
+			On: []batcheslib.OnQueryOrRepository{
+				{ExhaustiveSearchJob: 42},
+				{InsightSeries: "series-1"},
+			},
+			Steps: steps,
+		}
+
+		gs := newGitserverClient(
+			map[api.CommitID]bool{
+				defaultBranches[rs[0].Name].commit: false,
+				defaultBranches[rs[2].Name].commit: false,
+				defaultBranches[rs[3].Name].commit: false,
+				"d34db33f":                         false,
+			},
+			map[string]api.CommitID{
+				"refs/heads/feature": "d34db33f",
+			},
+		)
+
+		storedResults := &fakeStoredResults{
+			searchJobs: map[int64][]StoredRepoRevision{
+				42: {
+					{Repo: rs[0].ID, Revision: "HEAD"},
+					{Repo: rs[1].ID, Revision: "refs/heads/feature"},
+					// The user can't access rs[4].
+					{Repo: rs[4].ID, Revision: "HEAD"},
+					// rs[6] is not cloned.
+					{Repo: rs[6].ID, Revision: "HEAD"},
+				},
+			},
+			insightSeries: map[string][]api.RepoID{
+				"series-1": {rs[2].ID, rs[3].ID, rs[4].ID},
+			},
+		}
+
+		wr := &workspaceResolver{
+			store:               s,
+			gitserverClient:     gs,
+			frontendInternalURL: newStreamSearchTestServer(t, nil),
+			storedResults:       storedResults,
+		}
+		ctx := actor.WithActor(context.Background(), actor.FromUser(u.ID))
+		have, err := wr.ResolveWorkspacesForBatchSpec(ctx, batchSpec)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := []*RepoWorkspace{
+			buildRepoWorkspace(rs[0], "", "", []string{}),
+			buildRepoWorkspace(rs[1], "refs/heads/feature", "d34db33f", []string{}),
+			buildRepoWorkspace(rs[2], "", "", []string{}),
+			buildRepoWorkspace(rs[3], "", "", []string{}),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("returned workspaces wrong. (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("stored results unavailable", func(t *testing.T) {
+		batchSpec := &batcheslib.BatchSpec{
+			On:    []batcheslib.OnQueryOrRepository{{ExhaustiveSearchJob: 42}},
+			Steps: steps,
+		}
+
+		wr := &workspaceResolver{store: s, gitserverClient: newGitserverClient(nil, nil)}
+		ctx := actor.WithActor(context.Background(), actor.FromUser(u.ID))
+		if _, err := wr.ResolveWorkspacesForBatchSpec(ctx, batchSpec); !errors.Is(err, ErrSearchJobsDisabled) {
+			t.Fatalf("wrong error returned. want=%s, have=%v", ErrSearchJobsDisabled, err)
+		}
+	})
 }
 
 func resolveWorkspacesAndCompare(t *testing.T, s *store.Store, gs gitserver.Client, u *types.User, matches map[string][]streamhttp.EventMatch, spec *batcheslib.BatchSpec, want []*RepoWorkspace) {
@@ -699,3 +772,24 @@ type mockDirectoryFinder struct {
 func (m *mockDirectoryFinder) FindDirectoriesInRepos(ctx context.Context, fileName string, batchSpecVersion int, repos ...*RepoRevision) (map[repoRevKey][]string, error) {
 	return m.results, nil
 }
+
+type fakeStoredResults struct {
+	searchJobs    map[int64][]StoredRepoRevision
+	insightSeries map[string][]api.RepoID
+}
+
+func (f *fakeStoredResults) SearchJobRepoRevisions(_ context.Context, id int64) ([]StoredRepoRevision, error) {
+	revs, ok := f.searchJobs[id]
+	if !ok {
+		return nil, errors.Newf("search job %d not found", id)
+	}
+	return revs, nil
+}
+
+func (f *fakeStoredResults) InsightSeriesRepos(_ context.Context, seriesID string) ([]api.RepoID, error) {
+	ids, ok := f.insightSeries[seriesID]
+	if !ok {
+		return nil, errors.Newf("insight series %q not found", seriesID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,434 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type GiteaSource struct {
+	client *gitea.Client
+	au     auth.Authenticator
+}
+
+var (
+	_ ChangesetSource         = GiteaSource{}
+	_ DraftChangesetSource    = GiteaSource{}
+	_ ForkableChangesetSource = GiteaSource{}
+)
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gitea URL")
+	}
+
+	client, err := gitea.NewClient(svc.URN(), u, c.Token, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gitea client")
+	}
+
+	var au auth.Authenticator
+	if c.Token != "" {
+		au = &auth.OAuthBearerToken{Token: c.Token}
+	}
+
+	return &GiteaSource{client: client, au: au}, nil
+}
+
+func (s GiteaSource) AuthenticationStrategy() AuthenticationStrategy {
+	return AuthenticationStrategyUserCredential
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GiteaSource) GitserverPushConfig(ctx context.Context, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GiteaSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	var token string
+	switch av := a.(type) {
+	case *auth.OAuthBearerToken:
+		token = av.Token
+	case *auth.OAuthBearerTokenWithSSH:
+		token = av.Token
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	return &GiteaSource{client: s.client.WithToken(token), au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GiteaSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo.Owner.Login, repo.Name, number)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GiteaSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	opts := s.changesetToCreatePullRequestOptions(cs)
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, repo.Owner.Login, repo.Name, opts)
+	if err != nil {
+		if !errors.Is(err, gitea.ErrPullRequestAlreadyExists) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		exists = true
+		pr, err = s.client.GetPullRequestByBaseHead(ctx, repo.Owner.Login, repo.Name, opts.Base, opts.Head)
+		if err != nil {
+			return exists, errors.Wrap(err, "fetching existing pull request")
+		}
+	}
+
+	if err := s.setChangesetMetadata(ctx, repo, pr, cs); err != nil {
+		return exists, err
+	}
+	return exists, nil
+}
+
+// CreateDraftChangeset creates the Changeset on the source as a work in
+// progress pull request. Gitea has no separate draft flag: a pull request is a
+// draft if its title carries a "WIP:" prefix.
+func (s GiteaSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	cs.Title = gitea.SetWIP(cs.Title)
+
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	pr, ok := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+	if !ok {
+		return false, errors.New("Changeset is not a Gitea pull request")
+	}
+
+	// If it already exists, but is not a WIP, we need to update the title.
+	if exists && !pr.IsWorkInProgress() {
+		if err := s.UpdateChangeset(ctx, cs); err != nil {
+			return exists, err
+		}
+	}
+	return exists, nil
+}
+
+// UndraftChangeset marks the changeset as no longer being work in progress.
+func (s GiteaSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	pr, ok := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Gitea pull request")
+	}
+
+	cs.Title = gitea.UnsetWIP(cs.Title)
+	// Also clear the prefix from the synced title, otherwise UpdateChangeset
+	// will add it back to keep the changeset in draft mode.
+	pr.Title = gitea.UnsetWIP(pr.Title)
+
+	return s.UpdateChangeset(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s GiteaSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	state := gitea.PullRequestStateClosed
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Number, gitea.EditPullRequestOptions{
+		State: &state,
+	})
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	if conf.Get().BatchChangesAutoDeleteBranch {
+		if err := s.deleteHeadBranch(ctx, updated); err != nil {
+			return err
+		}
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GiteaSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	// Avoid accidentally undrafting the changeset by checking its current
+	// status.
+	title := cs.Title
+	if pr.IsWorkInProgress() {
+		title = gitea.SetWIP(cs.Title)
+	}
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Number, gitea.EditPullRequestOptions{
+		Title: &title,
+		Body:  &cs.Body,
+		Base:  &base,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GiteaSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	state := gitea.PullRequestStateOpen
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Number, gitea.EditPullRequestOptions{
+		State: &state,
+	})
+	if err != nil {
+		return errors.Wrap(err, "reopening pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GiteaSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	return s.client.CreateIssueComment(ctx, repo.Owner.Login, repo.Name, pr.Number, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s GiteaSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	pr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+
+	style := gitea.MergeStyleMerge
+	if squash {
+		style = gitea.MergeStyleSquash
+	}
+
+	err := s.client.MergePullRequest(ctx, repo.Owner.Login, repo.Name, pr.Number, gitea.MergePullRequestOptions{
+		Do:                     style,
+		DeleteBranchAfterMerge: conf.Get().BatchChangesAutoDeleteBranch,
+	})
+	if err != nil {
+		if errors.Is(err, gitea.ErrPullRequestNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	// The merge endpoint doesn't return the pull request, so we have to
+	// reload it to pick up the merged state.
+	updated, err := s.client.GetPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Number)
+	if err != nil {
+		return errors.Wrap(err, "getting merged pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
+// exists and creating it if it doesn't. If namespace is not provided, the fork will be in
+// the currently authenticated user's namespace. If name is not provided, the fork will be
+// named with the default Sourcegraph convention: "${original-namespace}-${original-name}"
+func (s GiteaSource) GetFork(ctx context.Context, targetRepo *types.Repo, ns, n *string) (*types.Repo, error) {
+	user, err := s.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the current user")
+	}
+
+	namespace := user.Login
+	if ns != nil {
+		namespace = *ns
+	}
+
+	tr := targetRepo.Metadata.(*gitea.Repository)
+
+	name := DefaultForkName(tr.Owner.Login, tr.Name)
+	if n != nil {
+		name = *n
+	}
+
+	// Figure out if we already have a fork of the repo in the given namespace.
+	if fork, err := s.client.GetRepo(ctx, namespace, name); err == nil {
+		return s.checkAndCopy(targetRepo, fork)
+	} else if !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "checking for fork existence")
+	}
+
+	opts := gitea.CreateForkOptions{Name: &name}
+	// Forks into the user's own namespace must not name an organization.
+	if namespace != user.Login {
+		opts.Organization = &namespace
+	}
+
+	fork, err := s.client.CreateFork(ctx, tr.Owner.Login, tr.Name, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "forking repository")
+	}
+
+	return s.checkAndCopy(targetRepo, fork)
+}
+
+func (s GiteaSource) BuildCommitOpts(repo *types.Repo, _ *btypes.Changeset, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+	return BuildCommitOptsCommon(repo, spec, pushOpts)
+}
+
+func (s GiteaSource) checkAndCopy(targetRepo *types.Repo, fork *gitea.Repository) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repository)
+
+	if !fork.Fork || fork.Parent == nil {
+		return nil, errors.New("repo is not a fork")
+	} else if fork.Parent.ID != tr.ID {
+		return nil, errors.New("repo was not forked from the given parent")
+	}
+
+	// Now we make a copy of targetRepo, but with its sources and metadata updated to
+	// point to the fork
+	forkRepo, err := CopyRepoAsFork(targetRepo, fork, tr.FullName, fork.FullName)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating target repo sources and metadata")
+	}
+
+	return forkRepo, nil
+}
+
+func (s GiteaSource) deleteHeadBranch(ctx context.Context, pr *gitea.PullRequest) error {
+	if pr.Head == nil || pr.Head.Repository == nil || pr.Head.Repository.Owner == nil {
+		return errors.New("pull request has no head repository to delete the source branch from")
+	}
+
+	head := pr.Head.Repository
+	if err := s.client.DeleteBranch(ctx, head.Owner.Login, head.Name, pr.Head.Ref); err != nil && !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "deleting source branch")
+	}
+	return nil
+}
+
+func (s GiteaSource) annotatePullRequest(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest) (*giteabatches.AnnotatedPullRequest, error) {
+	reviews := []*gitea.PullReview{}
+	it := s.client.ListPullReviews(ctx, repo.Owner.Login, repo.Name, pr.Number)
+	for it.Next() {
+		reviews = append(reviews, it.Current())
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.Wrap(err, "getting pull request reviews")
+	}
+
+	statuses := []*gitea.CommitStatus{}
+	if pr.Head != nil && pr.Head.Sha != "" {
+		combined, err := s.client.GetCombinedStatus(ctx, repo.Owner.Login, repo.Name, pr.Head.Sha)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting commit statuses")
+		}
+		statuses = append(statuses, combined.Statuses...)
+	}
+
+	return &giteabatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Reviews:     reviews,
+		Statuses:    statuses,
+	}, nil
+}
+
+func (s GiteaSource) setChangesetMetadata(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+func (s GiteaSource) changesetToCreatePullRequestOptions(cs *Changeset) gitea.CreatePullRequestOptions {
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+	// Pull requests from forks reference the head branch by its owner.
+	if cs.RemoteRepo != cs.TargetRepo {
+		fork := cs.RemoteRepo.Metadata.(*gitea.Repository)
+		head = fork.Owner.Login + ":" + head
+	}
+
+	return gitea.CreatePullRequestOptions{
+		Head:  head,
+		Base:  gitdomain.AbbreviateRef(cs.BaseRef),
+		Title: cs.Title,
+		Body:  cs.Body,
+	}
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"sort"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+// ComputeApprovals returns the number of reviewers whose latest review of the
+// changeset is an approval. Reviews are tracked the same way as when computing
+// the review state of a changeset: dismissals and unapprovals remove the
+// previous review of their author, and reviews of deleted users are ignored.
+func ComputeApprovals(es []*btypes.ChangesetEvent) (int, error) {
+	// Copy so that we can sort without mutating the argument
+	events := make(ChangesetEvents, len(es))
+	copy(events, es)
+	sort.Sort(events)
+
+	lastReviewByAuthor := map[string]btypes.ChangesetReviewState{}
+	for _, e := range events {
+		author := e.ReviewAuthor()
+		if author == "" {
+			continue
+		}
+
+		switch e.Type() {
+		case btypes.ChangesetEventKindGitHubReviewed,
+			btypes.ChangesetEventKindBitbucketServerApproved,
+			btypes.ChangesetEventKindBitbucketServerReviewed,
+			btypes.ChangesetEventKindGitLabApproved,
+			btypes.ChangesetEventKindBitbucketCloudApproved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
+			btypes.ChangesetEventKindGiteaApproved,
+			btypes.ChangesetEventKindGiteaChangesRequested,
+			btypes.ChangesetEventKindGiteaReviewDismissed:
+			s, err := e.ReviewState()
+			if err != nil {
+				return 0, err
+			}
+
+			switch s {
+			case btypes.ChangesetReviewStateApproved, btypes.ChangesetReviewStateChangesRequested:
+				lastReviewByAuthor[author] = s
+			case btypes.ChangesetReviewStateDismissed:
+				delete(lastReviewByAuthor, author)
+			}
+
+		case btypes.ChangesetEventKindBitbucketServerUnapproved,
+			btypes.ChangesetEventKindBitbucketServerDismissed,
+			btypes.ChangesetEventKindGitLabUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved:
+			delete(lastReviewByAuthor, author)
+
+		case btypes.ChangesetEventKindAzureDevOpsPullRequestRejected,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor:
+			lastReviewByAuthor[author] = btypes.ChangesetReviewStateChangesRequested
+		}
+	}
+
+	approvals := 0
+	for _, s := range lastReviewByAuthor {
+		if s == btypes.ChangesetReviewStateApproved {
+			approvals++
+		}
+	}
+	return approvals, nil
+}
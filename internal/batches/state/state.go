@@ -10,9 +10,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	adobatches "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -123,6 +125,8 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 		return computeAzureDevOpsBuildState(m)
 	case *gerritbatches.AnnotatedChange:
 		return computeGerritBuildState(m)
+	case *giteabatches.AnnotatedPullRequest:
+		return computeGiteaCheckState(m)
 	case *perforce.ChangelistState:
 		// Perforce doesn't have builds built-in, its better to be explicit by still
 		// including this case for clarity.
@@ -344,6 +348,29 @@ func parseGerritBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
+func computeGiteaCheckState(apr *giteabatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	// The combined status only contains the latest status per context, so we
+	// don't have to deduplicate here.
+	states := make([]btypes.ChangesetCheckState, 0, len(apr.Statuses))
+	for _, status := range apr.Statuses {
+		states = append(states, parseGiteaCommitStatusState(status.State))
+	}
+	return combineCheckStates(states)
+}
+
+func parseGiteaCommitStatusState(s gitea.CommitStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case gitea.CommitStatusError, gitea.CommitStatusFailure:
+		return btypes.ChangesetCheckStateFailed
+	case gitea.CommitStatusPending:
+		return btypes.ChangesetCheckStatePending
+	case gitea.CommitStatusSuccess:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -640,6 +667,23 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Gerrit Change state: %s", m.Change.Status)
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		switch m.State {
+		case gitea.PullRequestStateClosed:
+			if m.HasMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case gitea.PullRequestStateOpen:
+			if m.IsWorkInProgress() {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gitea pull request state: %s", m.State)
+		}
 	case *perforce.Changelist:
 		switch m.State {
 		case perforce.ChangelistStateClosed:
@@ -759,6 +803,32 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Only the most recent review of each user counts, and reviews that
+		// only left comments don't change what the user decided before.
+		// Reviews are returned in the order they were submitted.
+		latest := make(map[string]gitea.ReviewState)
+		for _, review := range m.Reviews {
+			if review.User == nil {
+				continue
+			}
+			if review.Dismissed {
+				delete(latest, review.User.Login)
+				continue
+			}
+			switch review.State {
+			case gitea.ReviewStateApproved, gitea.ReviewStateRequestChanges:
+				latest[review.User.Login] = review.State
+			}
+		}
+
+		for _, state := range latest {
+			if state == gitea.ReviewStateApproved {
+				states[btypes.ChangesetReviewStateApproved] = true
+			} else {
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			}
+		}
 	case *perforce.Changelist:
 		states[btypes.ChangesetReviewStatePending] = true
 	default:
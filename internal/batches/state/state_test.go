@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	azuredevops2 "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -17,6 +18,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -420,6 +422,72 @@ func TestComputeAzureDevOpsBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeGiteaCheckState(t *testing.T) {
+	t.Parallel()
+
+	pr := &giteabatches.AnnotatedPullRequest{
+		PullRequest: &gitea.PullRequest{},
+	}
+
+	tests := []struct {
+		name     string
+		statuses []*gitea.CommitStatus
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name:     "empty slice",
+			statuses: []*gitea.CommitStatus{},
+			want:     btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "single success",
+			statuses: []*gitea.CommitStatus{{Context: "ci", State: gitea.CommitStatusSuccess}},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "single pending",
+			statuses: []*gitea.CommitStatus{{Context: "ci", State: gitea.CommitStatusPending}},
+			want:     btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:     "single error",
+			statuses: []*gitea.CommitStatus{{Context: "ci", State: gitea.CommitStatusError}},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "single failure",
+			statuses: []*gitea.CommitStatus{{Context: "ci", State: gitea.CommitStatusFailure}},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "failure + pending",
+			statuses: []*gitea.CommitStatus{
+				{Context: "ci", State: gitea.CommitStatusFailure},
+				{Context: "lint", State: gitea.CommitStatusPending},
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "failure + success",
+			statuses: []*gitea.CommitStatus{
+				{Context: "ci", State: gitea.CommitStatusFailure},
+				{Context: "lint", State: gitea.CommitStatusSuccess},
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr.Statuses = tc.statuses
+			have := computeGiteaCheckState(pr)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -679,6 +747,38 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gitea - no reviews",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, "title", nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gitea - approved, then commented",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, "title", []*gitea.PullReview{
+				{User: &gitea.User{Login: "alice"}, State: gitea.ReviewStateApproved},
+				{User: &gitea.User{Login: "alice"}, State: gitea.ReviewStateComment},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gitea - changes requested by one reviewer",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, "title", []*gitea.PullReview{
+				{User: &gitea.User{Login: "alice"}, State: gitea.ReviewStateApproved},
+				{User: &gitea.User{Login: "bob"}, State: gitea.ReviewStateRequestChanges},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gitea - approval dismissed",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, "title", []*gitea.PullReview{
+				{User: &gitea.User{Login: "alice"}, State: gitea.ReviewStateApproved, Dismissed: true},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -900,6 +1000,36 @@ func TestComputeExternalState(t *testing.T) {
 			history:   []changesetStatesAtTime{},
 			want:      btypes.ChangesetExternalStateMerged,
 		},
+		{
+			name:      "gitea - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateOpen, false, "title", nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gitea work in progress - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateOpen, false, "WIP: title", nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gitea closed - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, false, "title", nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gitea merged - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, true, "title", nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gitea unknown state",
+			changeset: giteaChangeset(daysAgo(10), "foobar", false, "title", nil),
+			history:   []changesetStatesAtTime{},
+			wantErr:   errors.New("unknown Gitea pull request state: foobar"),
+		},
 		{
 			name:      "perforce pending - no events",
 			changeset: perforceChangeset(daysAgo(10), perforce.ChangelistStatePending),
@@ -1075,6 +1205,21 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func giteaChangeset(updatedAt time.Time, state gitea.PullRequestState, merged bool, title string, reviews []*gitea.PullReview) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGitea,
+		UpdatedAt:           updatedAt,
+		Metadata: &giteabatches.AnnotatedPullRequest{
+			PullRequest: &gitea.PullRequest{
+				Title:     title,
+				State:     state,
+				HasMerged: merged,
+			},
+			Reviews: reviews,
+		},
+	}
+}
+
 func perforceChangeset(updatedAt time.Time, state perforce.ChangelistState) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypePerforce,
@@ -1089,3 +1234,76 @@ func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
 }
+
+func TestComputeApprovals(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name   string
+		events []*btypes.ChangesetEvent
+		want   int
+	}{
+		{
+			name: "no events",
+			want: 0,
+		},
+		{
+			name: "github - approvals by different authors",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "bob", "APPROVED"),
+				ghReview(1, daysAgo(1), "carol", "COMMENTED"),
+			},
+			want: 2,
+		},
+		{
+			name: "github - repeated approvals by one author",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "alice", "APPROVED"),
+			},
+			want: 1,
+		},
+		{
+			name: "github - approval followed by requested changes",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(1), "alice", "CHANGES_REQUESTED"),
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(2), "bob", "APPROVED"),
+			},
+			want: 1,
+		},
+		{
+			name: "github - dismissed approval",
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "alice", "DISMISSED"),
+			},
+			want: 0,
+		},
+		{
+			name: "bitbucketserver - unapproved",
+			events: []*btypes.ChangesetEvent{
+				bbsActivity(1, daysAgo(3), "alice", btypes.ChangesetEventKindBitbucketServerApproved),
+				bbsActivity(1, daysAgo(2), "bob", btypes.ChangesetEventKindBitbucketServerApproved),
+				bbsActivity(1, daysAgo(1), "alice", btypes.ChangesetEventKindBitbucketServerUnapproved),
+			},
+			want: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ComputeApprovals(tc.events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong number of approvals. want=%d, have=%d", tc.want, have)
+			}
+		})
+	}
+}
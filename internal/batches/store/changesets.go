@@ -14,8 +14,10 @@ import (
 
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 
 	"github.com/keegancsmith/sqlf"
@@ -458,6 +460,9 @@ func countChangesetsQuery(opts *CountChangesetsOpts, authzConds *sqlf.Query) *sq
 	if len(opts.RepoIDs) > 0 {
 		preds = append(preds, sqlf.Sprintf("repo.id = ANY (%s)", pq.Array(opts.RepoIDs)))
 	}
+	if len(opts.RepoNameRegexps) > 0 {
+		preds = append(preds, sqlf.Sprintf("repo.name ~ ANY (%s)", pq.Array(opts.RepoNameRegexps)))
+	}
 
 	join := sqlf.Sprintf("")
 	if len(opts.TextSearch) != 0 {
@@ -652,6 +657,9 @@ type ListChangesetsOpts struct {
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
 	RepoIDs              []api.RepoID
+	// RepoNameRegexps limits the changesets to those in repositories whose
+	// name matches at least one of the regular expressions.
+	RepoNameRegexps      []string
 	BitbucketCloudCommit string
 }
 
@@ -1388,6 +1396,71 @@ updated_records AS (
 SELECT COUNT(id) FROM all_matching WHERE all_matching.reconciler_state = %s
 `
 
+// HeldChangesetsAffected returns true if a changeset whose external state
+// changed from previous to current affects the changesets that are held back
+// until it is merged: they can be published once it is merged, and they fail
+// once it was closed, deleted or archived. Either way, they have to be
+// re-enqueued with EnqueueHeldChangesets.
+func HeldChangesetsAffected(previous, current btypes.ChangesetExternalState) bool {
+	if previous == current {
+		return false
+	}
+	switch current {
+	case btypes.ChangesetExternalStateMerged,
+		btypes.ChangesetExternalStateClosed,
+		btypes.ChangesetExternalStateDeleted,
+		btypes.ChangesetExternalStateReadOnly:
+		return true
+	}
+	return false
+}
+
+// EnqueueHeldChangesets re-enqueues the changesets owned by the given batch
+// change that may be held back until the changesets they depend on are merged,
+// so that the reconciler can publish them, or fail them if a changeset they
+// depend on won't be merged. It is a no-op for batch changes whose batch spec
+// doesn't declare changeset dependencies.
+func (s *Store) EnqueueHeldChangesets(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.enqueueHeldChangesets.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueHeldChangesetsFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		batchChangeID,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ChangesetExternalStateDraft,
+	))
+}
+
+const enqueueHeldChangesetsFmtstr = `
+UPDATE
+	changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	updated_at = %s
+FROM
+	batch_changes
+JOIN
+	batch_specs ON batch_specs.id = batch_changes.batch_spec_id
+WHERE
+	batch_changes.id = %d
+	AND
+	changesets.owned_by_batch_change_id = batch_changes.id
+	AND
+	batch_specs.spec->'changesetDependencies' IS NOT NULL
+	AND
+	changesets.reconciler_state = %s
+	AND
+	(changesets.publication_state = %s OR changesets.external_state = %s)
+`
+
 // jsonBatchChangeChangesetSet represents a "join table" set as a JSONB object
 // where the keys are the ids and the values are json objects holding the properties.
 // It implements the sql.Scanner interface so it can be used as a scan destination,
@@ -1526,6 +1599,11 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		m := new(gerritbatches.AnnotatedChange)
 		m.Change = &gerrit.Change{}
 		t.Metadata = m
+	case extsvc.TypeGitea:
+		m := new(giteabatches.AnnotatedPullRequest)
+		// Ensure the inner PR is initialized, it should never be nil.
+		m.PullRequest = &gitea.PullRequest{}
+		t.Metadata = m
 	case extsvc.TypePerforce:
 		t.Metadata = new(perforce.Changelist)
 	case extsvc.TypeGerrit:
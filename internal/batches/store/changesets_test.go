@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
@@ -765,6 +767,14 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 				assert.NoError(t, err)
 				assert.ElementsMatch(t, []*btypes.Changeset{}, have)
 			})
+
+			t.Run("repo name regexps", func(t *testing.T) {
+				have, _, err := s.ListChangesets(ctx, ListChangesetsOpts{
+					RepoNameRegexps: []string{"^" + regexp.QuoteMeta(string(otherRepo.Name)) + "$", "^no-such-repo$"},
+				})
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []*btypes.Changeset{otherChangeset}, have)
+			})
 		})
 
 		statePublished := btypes.ChangesetPublicationStatePublished
@@ -1537,6 +1547,71 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 		})
 	})
 
+	t.Run("EnqueueHeldChangesets", func(t *testing.T) {
+		spec := bt.CreateBatchSpec(t, ctx, s, "held-changesets", user.ID, 0)
+		batchChange := bt.CreateBatchChange(t, ctx, s, "held-changesets", user.ID, spec.ID)
+
+		unpublished := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChange.ID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		})
+		draft := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChange.ID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateDraft,
+		})
+		open := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChange.ID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+		})
+
+		assertReconcilerStates := func(t *testing.T, want map[*btypes.Changeset]btypes.ReconcilerState) {
+			t.Helper()
+			for c, state := range want {
+				reloaded, err := s.GetChangesetByID(ctx, c.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if reloaded.ReconcilerState != state {
+					t.Fatalf("changeset %d has wrong reconciler state. want=%s, have=%s", c.ID, state, reloaded.ReconcilerState)
+				}
+			}
+		}
+
+		// The batch spec doesn't declare dependencies, so nothing is held back.
+		if err := s.EnqueueHeldChangesets(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		assertReconcilerStates(t, map[*btypes.Changeset]btypes.ReconcilerState{
+			unpublished: btypes.ReconcilerStateCompleted,
+			draft:       btypes.ReconcilerStateCompleted,
+			open:        btypes.ReconcilerStateCompleted,
+		})
+
+		spec.Spec.ChangesetDependencies = []batcheslib.ChangesetDependency{
+			{Repository: "*", DependsOn: []string{string(otherRepo.Name)}},
+		}
+		if err := s.UpdateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.EnqueueHeldChangesets(ctx, batchChange.ID); err != nil {
+			t.Fatal(err)
+		}
+		assertReconcilerStates(t, map[*btypes.Changeset]btypes.ReconcilerState{
+			unpublished: btypes.ReconcilerStateQueued,
+			draft:       btypes.ReconcilerStateQueued,
+			open:        btypes.ReconcilerStateCompleted,
+		})
+	})
+
 	t.Run("UpdateChangesetBatchChanges", func(t *testing.T) {
 		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
 			ReconcilerState:  btypes.ReconcilerStateCompleted,
@@ -2723,3 +2798,22 @@ func TestCleanDetachedChangesets(t *testing.T) {
 		})
 	}
 }
+
+func TestHeldChangesetsAffected(t *testing.T) {
+	for _, tc := range []struct {
+		previous, current btypes.ChangesetExternalState
+		want              bool
+	}{
+		{previous: btypes.ChangesetExternalStateOpen, current: btypes.ChangesetExternalStateMerged, want: true},
+		{previous: btypes.ChangesetExternalStateOpen, current: btypes.ChangesetExternalStateClosed, want: true},
+		{previous: btypes.ChangesetExternalStateDraft, current: btypes.ChangesetExternalStateDeleted, want: true},
+		{previous: btypes.ChangesetExternalStateOpen, current: btypes.ChangesetExternalStateReadOnly, want: true},
+		{previous: btypes.ChangesetExternalStateMerged, current: btypes.ChangesetExternalStateMerged, want: false},
+		{previous: btypes.ChangesetExternalStateClosed, current: btypes.ChangesetExternalStateOpen, want: false},
+		{previous: btypes.ChangesetExternalStateDraft, current: btypes.ChangesetExternalStateOpen, want: false},
+	} {
+		if have := HeldChangesetsAffected(tc.previous, tc.current); have != tc.want {
+			t.Errorf("%s -> %s: want=%t, have=%t", tc.previous, tc.current, tc.want, have)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AutoMergePolicy returns the auto-merge policy that applies to the given
+// changeset, or nil if there is none: the changeset has to be open and owned by
+// a batch change that is not closed and whose batch spec sets a policy.
+func AutoMergePolicy(ctx context.Context, syncStore SyncStore, c *btypes.Changeset) (*batcheslib.AutoMerge, error) {
+	if c.OwnedByBatchChangeID == 0 || c.ExternalState != btypes.ChangesetExternalStateOpen {
+		return nil, nil
+	}
+
+	batchChange, err := syncStore.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: c.OwnedByBatchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+	if batchChange.Closed() {
+		return nil, nil
+	}
+
+	batchSpec, err := syncStore.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec")
+	}
+	return batchSpec.Spec.AutoMerge, nil
+}
+
+// AutoMergeChangeset merges the given changeset on the code host if it and its
+// events satisfy the auto-merge policy returned by AutoMergePolicy. The state
+// of the changeset must be current, so callers use it right after a sync or a
+// webhook event. A merge is recorded as a ChangesetEventKindAutoMerged event.
+//
+// Changesets that the code host refuses to merge keep the reason as their sync
+// error message until the next sync.
+func AutoMergeChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset, events []*btypes.ChangesetEvent, policy *batcheslib.AutoMerge) (err error) {
+	if policy == nil {
+		return nil
+	}
+
+	now := syncStore.Clock()()
+	ready, err := readyForAutoMerge(policy, c, events, now)
+	if err != nil || !ready {
+		return err
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, source, repo, c, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  c,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := source.MergeChangeset(ctx, cs, policy.Squash()); err != nil {
+		if !errors.HasType[sources.ChangesetNotMergeableError](err) {
+			return err
+		}
+
+		errMsg := err.Error()
+		c.SyncErrorMessage = &errMsg
+		return syncStore.UpdateChangesetCodeHostState(ctx, c)
+	}
+
+	events, err = c.Events()
+	if err != nil {
+		return err
+	}
+	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
+
+	merged := &btypes.AutoMergedEvent{
+		BatchChangeID: c.OwnedByBatchChangeID,
+		Squash:        policy.Squash(),
+		MergedAt:      now,
+	}
+	events = append(events, &btypes.ChangesetEvent{
+		ChangesetID: c.ID,
+		Kind:        btypes.ChangesetEventKindAutoMerged,
+		Key:         merged.Key(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Metadata:    merged,
+	})
+
+	tx, err := syncStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.UpdateChangesetCodeHostState(ctx, c); err != nil {
+		return err
+	}
+
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return enqueueHeldChangesets(ctx, tx, c, btypes.ChangesetExternalStateOpen)
+}
+
+// readyForAutoMerge returns true if the changeset satisfies the auto-merge
+// policy at the given time.
+func readyForAutoMerge(policy *batcheslib.AutoMerge, c *btypes.Changeset, events []*btypes.ChangesetEvent, now time.Time) (bool, error) {
+	if c.ExternalState != btypes.ChangesetExternalStateOpen {
+		return false, nil
+	}
+
+	if policy.RequirePassingChecks && c.ExternalCheckState != btypes.ChangesetCheckStatePassed {
+		return false, nil
+	}
+
+	if policy.RequiredApprovals > 0 {
+		// Requested changes block the merge, no matter how many approvals
+		// the changeset has.
+		if c.ExternalReviewState != btypes.ChangesetReviewStateApproved {
+			return false, nil
+		}
+		approvals, err := state.ComputeApprovals(events)
+		if err != nil {
+			return false, errors.Wrap(err, "computing approvals")
+		}
+		if approvals < policy.RequiredApprovals {
+			return false, nil
+		}
+	}
+
+	if policy.Window != nil {
+		return policy.Window.Contains(now)
+	}
+
+	return true, nil
+}
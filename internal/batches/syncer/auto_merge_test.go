@@ -0,0 +1,112 @@
+package syncer
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestReadyForAutoMerge(t *testing.T) {
+	t.Parallel()
+
+	// A Wednesday.
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	approval := func(login string) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindGitHubReviewed,
+			Metadata: &github.PullRequestReview{
+				UpdatedAt: now.Add(-time.Hour),
+				State:     "APPROVED",
+				Author:    github.Actor{Login: login},
+			},
+		}
+	}
+	changeset := func(externalState btypes.ChangesetExternalState, checkState btypes.ChangesetCheckState, reviewState btypes.ChangesetReviewState) *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalState:       externalState,
+			ExternalCheckState:  checkState,
+			ExternalReviewState: reviewState,
+		}
+	}
+	tests := []struct {
+		name      string
+		policy    *batcheslib.AutoMerge
+		changeset *btypes.Changeset
+		events    []*btypes.ChangesetEvent
+		want      bool
+	}{
+		{
+			name:      "passing checks",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStatePending),
+			want:      true,
+		},
+		{
+			name:      "draft",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true},
+			changeset: changeset(btypes.ChangesetExternalStateDraft, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStatePending),
+			want:      false,
+		},
+		{
+			name:      "pending checks",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePending, btypes.ChangesetReviewStatePending),
+			want:      false,
+		},
+		{
+			name:      "checks not required",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: false},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateFailed, btypes.ChangesetReviewStatePending),
+			want:      true,
+		},
+		{
+			name:      "enough approvals",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true, RequiredApprovals: 2},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			events:    []*btypes.ChangesetEvent{approval("alice"), approval("bob")},
+			want:      true,
+		},
+		{
+			name:      "too few approvals",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true, RequiredApprovals: 2},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved),
+			events:    []*btypes.ChangesetEvent{approval("alice")},
+			want:      false,
+		},
+		{
+			name:      "changes requested",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true, RequiredApprovals: 1},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateChangesRequested),
+			events:    []*btypes.ChangesetEvent{approval("alice"), approval("bob")},
+			want:      false,
+		},
+		{
+			name:      "inside merge window",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true, Window: &batcheslib.MergeWindow{Days: []string{"Wednesday"}, Start: "09:00", End: "17:00"}},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStatePending),
+			want:      true,
+		},
+		{
+			name:      "outside merge window",
+			policy:    &batcheslib.AutoMerge{RequirePassingChecks: true, Window: &batcheslib.MergeWindow{Days: []string{"Saturday", "Sunday"}, Start: "09:00", End: "17:00"}},
+			changeset: changeset(btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStatePending),
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, err := readyForAutoMerge(tt.policy, tt.changeset, tt.events, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tt.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tt.want, have)
+			}
+		})
+	}
+}
@@ -33,6 +33,9 @@ type MockSyncStore struct {
 	// GetBatchChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBatchChange.
 	GetBatchChangeFunc *SyncStoreGetBatchChangeFunc
+	// GetBatchSpecFunc is an instance of a mock function object controlling
+	// the behavior of the method GetBatchSpec.
+	GetBatchSpecFunc *SyncStoreGetBatchSpecFunc
 	// GetChangesetFunc is an instance of a mock function object controlling
 	// the behavior of the method GetChangeset.
 	GetChangesetFunc *SyncStoreGetChangesetFunc
@@ -99,6 +102,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: func(context.Context, store.GetBatchSpecOpts) (r0 *types.BatchSpec, r1 error) {
+				return
+			},
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: func(context.Context, store.GetChangesetOpts) (r0 *types.Changeset, r1 error) {
 				return
@@ -191,6 +199,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.GetBatchChange")
 			},
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+				panic("unexpected invocation of MockSyncStore.GetBatchSpec")
+			},
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: func(context.Context, store.GetChangesetOpts) (*types.Changeset, error) {
 				panic("unexpected invocation of MockSyncStore.GetChangeset")
@@ -275,6 +288,9 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		GetBatchChangeFunc: &SyncStoreGetBatchChangeFunc{
 			defaultHook: i.GetBatchChange,
 		},
+		GetBatchSpecFunc: &SyncStoreGetBatchSpecFunc{
+			defaultHook: i.GetBatchSpec,
+		},
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: i.GetChangeset,
 		},
@@ -720,6 +736,114 @@ func (c SyncStoreGetBatchChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SyncStoreGetBatchSpecFunc describes the behavior when the GetBatchSpec
+// method of the parent MockSyncStore instance is invoked.
+type SyncStoreGetBatchSpecFunc struct {
+	defaultHook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)
+	hooks       []func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)
+	history     []SyncStoreGetBatchSpecFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBatchSpec delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSyncStore) GetBatchSpec(v0 context.Context, v1 store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+	r0, r1 := m.GetBatchSpecFunc.nextHook()(v0, v1)
+	m.GetBatchSpecFunc.appendCall(SyncStoreGetBatchSpecFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetBatchSpec method
+// of the parent MockSyncStore instance is invoked and the hook queue is
+// empty.
+func (f *SyncStoreGetBatchSpecFunc) SetDefaultHook(hook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBatchSpec method of the parent MockSyncStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SyncStoreGetBatchSpecFunc) PushHook(hook func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreGetBatchSpecFunc) SetDefaultReturn(r0 *types.BatchSpec, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreGetBatchSpecFunc) PushReturn(r0 *types.BatchSpec, r1 error) {
+	f.PushHook(func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreGetBatchSpecFunc) nextHook() func(context.Context, store.GetBatchSpecOpts) (*types.BatchSpec, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreGetBatchSpecFunc) appendCall(r0 SyncStoreGetBatchSpecFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SyncStoreGetBatchSpecFuncCall objects
+// describing the invocations of this function.
+func (f *SyncStoreGetBatchSpecFunc) History() []SyncStoreGetBatchSpecFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreGetBatchSpecFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreGetBatchSpecFuncCall is an object that describes an invocation
+// of method GetBatchSpec on an instance of MockSyncStore.
+type SyncStoreGetBatchSpecFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.GetBatchSpecOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.BatchSpec
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreGetBatchSpecFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreGetBatchSpecFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreGetChangesetFunc describes the behavior when the GetChangeset
 // method of the parent MockSyncStore instance is invoked.
 type SyncStoreGetChangesetFunc struct {
@@ -26,6 +26,7 @@ type SyncStore interface {
 	GetExternalServiceIDs(ctx context.Context, opts store.GetExternalServiceIDsOpts) ([]int64, error)
 	UserCredentials() database.UserCredentialsStore
 	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
+	GetBatchSpec(ctx context.Context, opts store.GetBatchSpecOpts) (*btypes.BatchSpec, error)
 	GitHubAppsStore() ghastore.GitHubAppsStore
 	GetChangesetSpecByID(ctx context.Context, id int64) (*btypes.ChangesetSpec, error)
 }
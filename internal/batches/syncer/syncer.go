@@ -508,12 +508,26 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return SyncChangeset(ctx, s.syncStore, gitserver.NewClient("batches.changesetsyncer"), source, repo, cs)
+	client := gitserver.NewClient("batches.changesetsyncer")
+	if err := SyncChangeset(ctx, s.syncStore, client, source, repo, cs); err != nil {
+		return err
+	}
+
+	policy, err := AutoMergePolicy(ctx, s.syncStore, cs)
+	if err != nil || policy == nil {
+		return err
+	}
+	events, err := cs.Events()
+	if err != nil {
+		return err
+	}
+	return AutoMergeChangeset(ctx, s.syncStore, client, source, repo, cs, events, policy)
 }
 
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	previousState := c.ExternalState
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType[sources.ChangesetNotFoundError](err) {
@@ -551,5 +565,19 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return enqueueHeldChangesets(ctx, tx, c, previousState)
+}
+
+// enqueueHeldChangesets re-enqueues the changesets that may be held back until
+// the given changeset is merged, if it has been merged or closed since it was
+// in previousState.
+func enqueueHeldChangesets(ctx context.Context, tx *store.Store, c *btypes.Changeset, previousState btypes.ChangesetExternalState) error {
+	if c.OwnedByBatchChangeID == 0 || !store.HeldChangesetsAffected(previousState, c.ExternalState) {
+		return nil
+	}
+	return tx.EnqueueHeldChangesets(ctx, c.OwnedByBatchChangeID)
 }
@@ -15,11 +15,13 @@ import (
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/bitbucketcloud"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -468,6 +470,27 @@ func (c *Changeset) SetMetadata(meta any) error {
 		c.ExternalServiceType = extsvc.TypeGerrit
 		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Change.Branch)
 		c.ExternalUpdatedAt = pr.Change.Updated
+	case *giteabatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.Number, 10)
+		c.ExternalServiceType = extsvc.TypeGitea
+		c.ExternalUpdatedAt = pr.UpdatedAt
+
+		// Gitea omits the head and base of pull requests whose fork or branch
+		// has been deleted, in which case we keep what we knew before.
+		if pr.Head != nil && pr.Head.Ref != "" {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Head.Ref)
+		}
+		if pr.Head == nil || pr.Base == nil {
+			break
+		}
+		if pr.Head.RepoID != pr.Base.RepoID && pr.Head.Repository != nil && pr.Head.Repository.Owner != nil {
+			c.ExternalForkNamespace = pr.Head.Repository.Owner.Login
+			c.ExternalForkName = pr.Head.Repository.Name
+		} else {
+			c.ExternalForkNamespace = ""
+			c.ExternalForkName = ""
+		}
 	case *perforce.Changelist:
 		c.Metadata = pr
 		c.ExternalID = pr.ID
@@ -508,6 +531,8 @@ func (c *Changeset) Title() (string, error) {
 		// Remove extra quotes added by the commit message
 		title = strings.TrimPrefix(strings.TrimSuffix(title, "\""), "\"")
 		return title, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Title, nil
 	case *perforce.Changelist:
 		return m.Title, nil
 	default:
@@ -535,6 +560,11 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Name, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Login, nil
 	case *perforce.Changelist:
 		return m.Author, nil
 	default:
@@ -570,6 +600,13 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Email, nil
+	case *giteabatches.AnnotatedPullRequest:
+		// Gitea hides the e-mail of users that opted to keep it private, in
+		// which case this is empty or a noreply address.
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Email, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -594,6 +631,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreationDate
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Created
+	case *giteabatches.AnnotatedPullRequest:
+		return m.CreatedAt
 	case *perforce.Changelist:
 		return m.CreationDate
 	default:
@@ -617,6 +656,8 @@ func (c *Changeset) Body() (string, error) {
 	case *gerritbatches.AnnotatedChange:
 		// Gerrit doesn't really differentiate between title/description.
 		return m.Change.Subject, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Body, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -685,6 +726,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return returnURL.String(), nil
 	case *gerritbatches.AnnotatedChange:
 		return m.CodeHostURL.JoinPath("c", url.PathEscape(m.Change.Project), "+", url.PathEscape(strconv.Itoa(m.Change.ChangeNumber))).String(), nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.HTMLURL, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -905,6 +948,35 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    reviewer,
 			})
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// There are two types of event that we create from an annotated pull
+		// request: review events, based on the submitted reviews, and check
+		// events, based on the commit statuses of the head commit.
+		var kind ChangesetEventKind
+
+		for _, review := range m.Reviews {
+			if kind, err = ChangesetEventKindFor(review); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         review.Key(),
+				Kind:        kind,
+				Metadata:    review,
+			})
+		}
+
+		for _, status := range m.Statuses {
+			if kind, err = ChangesetEventKindFor(status); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         status.Key(),
+				Kind:        kind,
+				Metadata:    status,
+			})
+		}
 	case *perforce.Changelist:
 		// We don't have any events we care about right now
 		break
@@ -930,6 +1002,11 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Head == nil {
+			return "", nil
+		}
+		return m.Head.Sha, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -953,6 +1030,11 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.SourceRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Head == nil {
+			return "", nil
+		}
+		return "refs/heads/" + m.Head.Ref, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -977,6 +1059,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Base == nil {
+			return "", nil
+		}
+		return m.Base.Sha, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -1000,6 +1087,11 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.TargetRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "refs/heads/" + m.Change.Branch, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Base == nil {
+			return "", nil
+		}
+		return "refs/heads/" + m.Base.Ref, nil
 	case *perforce.Changelist:
 		// TODO: @peterguy we may need to change this to something.
 		return "", nil
@@ -1119,6 +1211,16 @@ func (c *Changeset) Labels() []ChangesetLabel {
 			labels[i] = ChangesetLabel{Name: l, Color: "000000"}
 		}
 		return labels
+	case *giteabatches.AnnotatedPullRequest:
+		labels := make([]ChangesetLabel, len(m.Labels))
+		for i, l := range m.Labels {
+			labels[i] = ChangesetLabel{
+				Name:        l.Name,
+				Color:       l.Color,
+				Description: l.Description,
+			}
+		}
+		return labels
 	default:
 		return []ChangesetLabel{}
 	}
@@ -1397,6 +1499,22 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 				}
 			}
 		}
+	case *gitea.PullReview:
+		if e.Dismissed {
+			return ChangesetEventKindGiteaReviewDismissed, nil
+		}
+		switch e.State {
+		case gitea.ReviewStateApproved:
+			return ChangesetEventKindGiteaApproved, nil
+		case gitea.ReviewStateRequestChanges:
+			return ChangesetEventKindGiteaChangesRequested, nil
+		default:
+			return ChangesetEventKindGiteaReviewed, nil
+		}
+	case *gitea.CommitStatus:
+		return ChangesetEventKindGiteaCommitStatus, nil
+	case *AutoMergedEvent:
+		return ChangesetEventKindAutoMerged, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("changeset eventkindfor unknown changeset event kind for %T", e)
@@ -1546,6 +1664,18 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 			ChangesetEventKindGerritChangeBuildSucceeded:
 			return new(gerrit.Reviewer), nil
 		}
+	case strings.HasPrefix(string(k), "gitea"):
+		switch k {
+		case ChangesetEventKindGiteaApproved,
+			ChangesetEventKindGiteaChangesRequested,
+			ChangesetEventKindGiteaReviewed,
+			ChangesetEventKindGiteaReviewDismissed:
+			return new(gitea.PullReview), nil
+		case ChangesetEventKindGiteaCommitStatus:
+			return new(gitea.CommitStatus), nil
+		}
+	case k == ChangesetEventKindAutoMerged:
+		return new(AutoMergedEvent), nil
 	}
 	return nil, errors.Errorf("changeset event metadata unknown changeset event kind %q", k)
 }
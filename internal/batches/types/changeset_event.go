@@ -12,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -125,9 +126,32 @@ const (
 	ChangesetEventKindGerritChangeBuildFailed             ChangesetEventKind = "gerrit:change:build_failed"
 	ChangesetEventKindGerritChangeBuildPending            ChangesetEventKind = "gerrit:change:build_pending"
 
+	ChangesetEventKindGiteaApproved         ChangesetEventKind = "gitea:approved"
+	ChangesetEventKindGiteaChangesRequested ChangesetEventKind = "gitea:changes_requested"
+	ChangesetEventKindGiteaReviewed         ChangesetEventKind = "gitea:reviewed"
+	ChangesetEventKindGiteaReviewDismissed  ChangesetEventKind = "gitea:review_dismissed"
+	ChangesetEventKindGiteaCommitStatus     ChangesetEventKind = "gitea:commit_status"
+
+	// ChangesetEventKindAutoMerged is recorded by Sourcegraph, not the code host, when
+	// it merges a changeset because of the auto-merge policy of its batch change.
+	ChangesetEventKindAutoMerged ChangesetEventKind = "batches:auto_merged"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
+// AutoMergedEvent is the metadata of a ChangesetEventKindAutoMerged event.
+type AutoMergedEvent struct {
+	BatchChangeID int64     `json:"batchChangeID"`
+	Squash        bool      `json:"squash"`
+	MergedAt      time.Time `json:"mergedAt"`
+}
+
+// Key is a unique key identifying this event in the context of its changeset.
+// A changeset can only be merged once.
+func (e *AutoMergedEvent) Key() string {
+	return "auto-merge"
+}
+
 // A ChangesetEvent is an event that happened in the lifetime
 // and context of a Changeset.
 type ChangesetEvent struct {
@@ -202,6 +226,12 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 		return meta.PullRequest.Reviewers[len(meta.PullRequest.Reviewers)-1].UniqueName
 	case *azuredevops.PullRequestUpdatedEvent:
 		return meta.PullRequest.CreatedBy.UniqueName
+
+	case *gitea.PullReview:
+		if meta.User == nil {
+			return ""
+		}
+		return meta.User.Login
 	default:
 		return ""
 	}
@@ -214,7 +244,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindBitbucketCloudPullRequestApproved,
-		ChangesetEventKindAzureDevOpsPullRequestApproved:
+		ChangesetEventKindAzureDevOpsPullRequestApproved,
+		ChangesetEventKindGiteaApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
@@ -223,7 +254,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindBitbucketCloudChangesRequested,
 		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
 		ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
-		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions:
+		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+		ChangesetEventKindGiteaChangesRequested:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -246,7 +278,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindGitLabUnapproved,
 		ChangesetEventKindBitbucketCloudPullRequestUnapproved,
 		ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
-		ChangesetEventKindAzureDevOpsPullRequestRejected:
+		ChangesetEventKindAzureDevOpsPullRequestRejected,
+		ChangesetEventKindGiteaReviewDismissed:
 		return ChangesetReviewStateDismissed, nil
 	default:
 		return ChangesetReviewStatePending, nil
@@ -370,6 +403,17 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedDate
 	case *azuredevops.PullRequestMergedEvent:
 		t = ev.CreatedDate
+	case *gitea.PullReview:
+		// Dismissing a review only bumps its update time.
+		if ev.Dismissed {
+			t = ev.UpdatedAt
+		} else {
+			t = ev.SubmittedAt
+		}
+	case *gitea.CommitStatus:
+		t = ev.UpdatedAt
+	case *AutoMergedEvent:
+		t = ev.MergedAt
 	}
 
 	return t
@@ -913,6 +957,17 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 	case *azuredevops.PullRequestRejectedEvent:
 		o := o.Metadata.(*azuredevops.PullRequestRejectedEvent)
 		*e = *o
+
+	case *gitea.PullReview:
+		o := o.Metadata.(*gitea.PullReview)
+		*e = *o
+	case *gitea.CommitStatus:
+		o := o.Metadata.(*gitea.CommitStatus)
+		*e = *o
+
+	case *AutoMergedEvent:
+		o := o.Metadata.(*AutoMergedEvent)
+		*e = *o
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -11,11 +11,13 @@ import (
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/bitbucketcloud"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -242,6 +244,60 @@ func TestChangeset_SetMetadata(t *testing.T) {
 				ExternalUpdatedAt:     time.Unix(10, 0),
 			},
 		},
+		"gitea with fork": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{
+					Number: 12345,
+					Head: &gitea.PRBranchInfo{
+						Ref:    "branch",
+						RepoID: 2,
+						Repository: &gitea.Repository{
+							Name:  "repo",
+							Owner: &gitea.User{Login: "fork"},
+						},
+					},
+					Base:      &gitea.PRBranchInfo{Ref: "main", RepoID: 1},
+					UpdatedAt: time.Unix(10, 0),
+				},
+			},
+			want: &Changeset{
+				ExternalID:            "12345",
+				ExternalServiceType:   extsvc.TypeGitea,
+				ExternalBranch:        "refs/heads/branch",
+				ExternalForkNamespace: "fork",
+				ExternalForkName:      "repo",
+				ExternalUpdatedAt:     time.Unix(10, 0),
+			},
+		},
+		"gitea with deleted head repo": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{
+					Number:    12345,
+					Head:      &gitea.PRBranchInfo{Ref: "branch", RepoID: 2},
+					Base:      &gitea.PRBranchInfo{Ref: "main", RepoID: 1},
+					UpdatedAt: time.Unix(10, 0),
+				},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeGitea,
+				ExternalBranch:      "refs/heads/branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
+		"gitea without head and base": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{
+					Number:    12345,
+					UpdatedAt: time.Unix(10, 0),
+				},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeGitea,
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			have := &Changeset{}
@@ -513,6 +569,16 @@ func TestChangeset_HeadRefOid(t *testing.T) {
 			meta: &github.PullRequest{HeadRefOid: "foo"},
 			want: "foo",
 		},
+		"Gitea": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{Head: &gitea.PRBranchInfo{Sha: "foo"}},
+			},
+			want: "foo",
+		},
+		"Gitea without head": {
+			meta: &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}},
+			want: "",
+		},
 		"GitLab": {
 			meta: &gitlab.MergeRequest{
 				DiffRefs: gitlab.DiffRefs{HeadSHA: "foo"},
@@ -578,6 +644,16 @@ func TestChangeset_HeadRef(t *testing.T) {
 			meta: &github.PullRequest{HeadRefName: "foo"},
 			want: "refs/heads/foo",
 		},
+		"Gitea": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{Head: &gitea.PRBranchInfo{Ref: "foo"}},
+			},
+			want: "refs/heads/foo",
+		},
+		"Gitea without head": {
+			meta: &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}},
+			want: "",
+		},
 		"GitLab": {
 			meta: &gitlab.MergeRequest{
 				SourceBranch: "foo",
@@ -638,6 +714,16 @@ func TestChangeset_BaseRefOid(t *testing.T) {
 			meta: &github.PullRequest{BaseRefOid: "foo"},
 			want: "foo",
 		},
+		"Gitea": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{Base: &gitea.PRBranchInfo{Sha: "foo"}},
+			},
+			want: "foo",
+		},
+		"Gitea without base": {
+			meta: &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}},
+			want: "",
+		},
 		"GitLab": {
 			meta: &gitlab.MergeRequest{
 				DiffRefs: gitlab.DiffRefs{BaseSHA: "foo"},
@@ -704,6 +790,16 @@ func TestChangeset_BaseRef(t *testing.T) {
 			meta: &github.PullRequest{BaseRefName: "foo"},
 			want: "refs/heads/foo",
 		},
+		"Gitea": {
+			meta: &giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{Base: &gitea.PRBranchInfo{Ref: "foo"}},
+			},
+			want: "refs/heads/foo",
+		},
+		"Gitea without base": {
+			meta: &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}},
+			want: "",
+		},
 		"GitLab": {
 			meta: &gitlab.MergeRequest{
 				TargetBranch: "foo",
@@ -79,6 +79,7 @@ func NewDependencyIndexSchedulers(
 		depsSvc,
 		autoindexingSvc.store,
 		autoindexingSvc.indexEnqueuer,
+		autoindexingSvc.gitserverClient,
 		DependenciesConfigInst,
 	)
 }
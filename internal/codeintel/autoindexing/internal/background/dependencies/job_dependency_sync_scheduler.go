@@ -2,19 +2,24 @@ package dependencies
 
 import (
 	"context"
+	"os"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/lockfiles"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/packagefilters"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -31,16 +36,18 @@ func NewDependencySyncScheduler(
 	depsSvc DependenciesService,
 	store store.Store,
 	externalServiceStore ExternalServiceStore,
+	gitserverClient gitserver.Client,
 	metrics workerutil.WorkerObservability,
 	config *Config,
 ) *workerutil.Worker[dependencySyncingJob] {
 	rootContext := actor.WithInternalActor(context.Background())
 	handler := &dependencySyncSchedulerHandler{
-		uploadsSvc:  uploadSvc,
-		depsSvc:     depsSvc,
-		store:       store,
-		workerStore: dependencySyncStore,
-		extsvcStore: externalServiceStore,
+		uploadsSvc:      uploadSvc,
+		depsSvc:         depsSvc,
+		store:           store,
+		workerStore:     dependencySyncStore,
+		extsvcStore:     externalServiceStore,
+		gitserverClient: gitserverClient,
 	}
 
 	return dbworker.NewWorker[dependencySyncingJob](rootContext, dependencySyncStore, handler, workerutil.WorkerOptions{
@@ -54,22 +61,26 @@ func NewDependencySyncScheduler(
 }
 
 type dependencySyncSchedulerHandler struct {
-	uploadsSvc  UploadService
-	depsSvc     DependenciesService
-	store       store.Store
-	workerStore dbworkerstore.Store[dependencySyncingJob]
-	extsvcStore ExternalServiceStore
+	uploadsSvc      UploadService
+	depsSvc         DependenciesService
+	store           store.Store
+	workerStore     dbworkerstore.Store[dependencySyncingJob]
+	extsvcStore     ExternalServiceStore
+	gitserverClient gitserver.Client
 }
 
 // For mocking in tests
 var autoIndexingEnabled = conf.CodeIntelAutoIndexingEnabled
 
 var schemeToExternalService = map[string]string{
-	dependencies.JVMPackagesScheme:    extsvc.KindJVMPackages,
-	dependencies.NpmPackagesScheme:    extsvc.KindNpmPackages,
-	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
-	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
-	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.JVMPackagesScheme:       extsvc.KindJVMPackages,
+	dependencies.NpmPackagesScheme:       extsvc.KindNpmPackages,
+	dependencies.PythonPackagesScheme:    extsvc.KindPythonPackages,
+	dependencies.RustPackagesScheme:      extsvc.KindRustPackages,
+	dependencies.RubyPackagesScheme:      extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:     extsvc.KindNuGetPackages,
+	dependencies.HexPackagesScheme:       extsvc.KindHexPackages,
+	dependencies.PackagistPackagesScheme: extsvc.KindPackagistPackages,
 }
 
 func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.Logger, job dependencySyncingJob) error {
@@ -77,6 +88,11 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 		return nil
 	}
 
+	upload, _, err := h.uploadsSvc.GetUploadByID(ctx, job.UploadID)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.GetUploadByID")
+	}
+
 	scanner, err := h.uploadsSvc.ReferencesForUpload(ctx, job.UploadID)
 	if err != nil {
 		return errors.Wrap(err, "dbstore.ReferencesForUpload")
@@ -107,41 +123,19 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 		return err
 	}
 
-	for {
-		packageReference, exists, err := scanner.Next()
-		if err != nil {
-			return errors.Wrap(err, "dbstore.ReferencesForUpload.Next")
-		}
-		if !exists {
-			break
-		}
-
-		pkgRef, err := newPackage(packageReference.Package)
-		if err != nil {
-			// Indexers can potentially create package references with bad names,
-			// which are no longer recognized by the package manager. In such a
-			// case, it doesn't make sense to add a bad package as a dependency repo.
-			logger.Warn("package referenced by upload was invalid",
-				log.Error(err),
-				log.String("name", packageReference.Name),
-				log.String("version", packageReference.Version),
-				log.Int("uploadID", packageReference.UploadID))
-			continue
-		}
-		pkg := *pkgRef
-
+	insertPackage := func(pkg precise.Package) {
 		extsvcKind, ok := schemeToExternalService[pkg.Scheme]
 		// add entry for empty string/kind here so dependencies such as lsif-go ones still get
 		// an associated dependency indexing job
 		kinds[extsvcKind] = struct{}{}
 		if !ok {
-			continue
+			return
 		}
 
 		newRepo, newVersion, err := h.insertPackageRepoRef(ctx, pkg, packageFilters, instant)
 		if err != nil {
 			errs = append(errs, err)
-			continue
+			return
 		}
 
 		if newRepo {
@@ -156,6 +150,40 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 		}
 	}
 
+	for {
+		packageReference, exists, err := scanner.Next()
+		if err != nil {
+			return errors.Wrap(err, "dbstore.ReferencesForUpload.Next")
+		}
+		if !exists {
+			break
+		}
+
+		pkgRef, err := newPackage(packageReference.Package)
+		if err != nil {
+			// Indexers can potentially create package references with bad names,
+			// which are no longer recognized by the package manager. In such a
+			// case, it doesn't make sense to add a bad package as a dependency repo.
+			logger.Warn("package referenced by upload was invalid",
+				log.Error(err),
+				log.String("name", packageReference.Name),
+				log.String("version", packageReference.Version),
+				log.Int("uploadID", packageReference.UploadID))
+			continue
+		}
+		insertPackage(*pkgRef)
+	}
+
+	// Not every indexer reports the packages it references, so the packages
+	// pinned by lockfiles at the root of the upload are synced as well.
+	lockfilePackages, err := h.lockfilePackages(ctx, logger, upload)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, pkg := range lockfilePackages {
+		insertPackage(pkg)
+	}
+
 	var nextSync time.Time
 	kindsArray := kindsToArray(kinds)
 	// If len == 0, it will return all external services, which we definitely don't want.
@@ -193,12 +221,7 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 		logger.Info("no package schema kinds to sync external services for", log.Int("upload", job.UploadID), log.Int("job", job.ID))
 	}
 
-	shouldIndex, err := h.shouldIndexDependencies(ctx, h.uploadsSvc, job.UploadID)
-	if err != nil {
-		return err
-	}
-
-	if shouldIndex {
+	if shouldIndexDependencies(upload) {
 		// If we saw a kind that's not in schemeToExternalService, then kinds contains an empty string key
 		for kind := range kinds {
 			if _, err := h.store.InsertDependencyIndexingJob(ctx, job.UploadID, kind, nextSync); err != nil {
@@ -242,6 +265,14 @@ func newPackage(pkg uploadsshared.Package) (*precise.Package, error) {
 		// Override scip-python scheme so that we are able to autoindex
 		// index.scip created by scip-python
 		p.Scheme = dependencies.PythonPackagesScheme
+	case dependencies.PackagistPackagesScheme:
+		// scip-php takes package names from composer.lock as they were
+		// written there, but Composer treats them case-insensitively.
+		pkg, err := reposource.ParsePackagistPackageFromName(reposource.PackageName(p.Name))
+		if err != nil {
+			return nil, err
+		}
+		p.Name = string(pkg.Name)
 	}
 
 	return &p, nil
@@ -267,15 +298,40 @@ func (h *dependencySyncSchedulerHandler) insertPackageRepoRef(ctx context.Contex
 	return len(insertedRepos) != 0, len(insertedVersions) != 0, nil
 }
 
+// lockfilePackages returns the packages pinned by the lockfiles in the root
+// directory of the upload. Lockfiles that do not exist are skipped, and so are
+// invalid entries of the ones that do.
+func (h *dependencySyncSchedulerHandler) lockfilePackages(ctx context.Context, logger log.Logger, upload uploadsshared.Upload) (pkgs []precise.Package, errs error) {
+	for _, filename := range lockfiles.Filenames {
+		file := path.Join(upload.Root, filename)
+		r, err := h.gitserverClient.NewFileReader(ctx, api.RepoName(upload.RepositoryName), api.CommitID(upload.Commit), file)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = errors.Append(errs, errors.Wrapf(err, "gitserver.NewFileReader %q", file))
+			}
+			continue
+		}
+		lockfilePkgs, _, err := lockfiles.Parse(logger, file, r)
+		r.Close()
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "lockfiles.Parse %q", file))
+			continue
+		}
+		for _, pkg := range lockfilePkgs {
+			pkgs = append(pkgs, precise.Package{
+				Scheme:  pkg.Scheme,
+				Name:    string(pkg.Name),
+				Version: pkg.Version,
+			})
+		}
+	}
+	return pkgs, errs
+}
+
 // shouldIndexDependencies returns true if the given upload should undergo dependency
 // indexing. Currently, we're only enabling dependency indexing for a repositories that
 // were indexed via lsif-go, scip-java, lsif-tsc and scip-typescript.
-func (h *dependencySyncSchedulerHandler) shouldIndexDependencies(ctx context.Context, store UploadService, uploadID int) (bool, error) {
-	upload, _, err := store.GetUploadByID(ctx, uploadID)
-	if err != nil {
-		return false, errors.Wrap(err, "dbstore.GetUploadByID")
-	}
-
+func shouldIndexDependencies(upload uploadsshared.Upload) bool {
 	return upload.Indexer == "lsif-go" ||
 		upload.Indexer == "scip-java" ||
 		upload.Indexer == "lsif-java" ||
@@ -284,7 +340,8 @@ func (h *dependencySyncSchedulerHandler) shouldIndexDependencies(ctx context.Con
 		upload.Indexer == "lsif-typescript" ||
 		upload.Indexer == "scip-python" ||
 		upload.Indexer == "scip-ruby" ||
-		upload.Indexer == "rust-analyzer", nil
+		upload.Indexer == "scip-php" ||
+		upload.Indexer == "rust-analyzer"
 }
 
 func kindsToArray(k map[string]struct{}) (s []string) {
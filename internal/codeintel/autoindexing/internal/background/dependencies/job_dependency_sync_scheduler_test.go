@@ -2,15 +2,21 @@ package dependencies
 
 import (
 	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	dbworkermocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -26,16 +32,19 @@ func TestDependencySyncSchedulerJVM(t *testing.T) {
 	mockStore := NewMockStore()
 	mockExtsvcStore := NewMockExternalServiceStore()
 	mockScanner := NewMockPackageReferenceScanner()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockGitserverClient.NewFileReaderFunc.SetDefaultReturn(nil, os.ErrNotExist)
 	mockUploadsSvc.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
 	mockUploadsSvc.GetUploadByIDFunc.SetDefaultReturn(shared.Upload{ID: 42, RepositoryID: 50, Indexer: "scip-java"}, true, nil)
 	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{UploadID: 42, Scheme: dependencies.JVMPackagesScheme, Name: "name1", Version: "v2.2.0"}}, true, nil)
 
 	handler := dependencySyncSchedulerHandler{
-		uploadsSvc:  mockUploadsSvc,
-		depsSvc:     mockDepedenciesSvc,
-		store:       mockStore,
-		workerStore: mockWorkerStore,
-		extsvcStore: mockExtsvcStore,
+		uploadsSvc:      mockUploadsSvc,
+		depsSvc:         mockDepedenciesSvc,
+		store:           mockStore,
+		workerStore:     mockWorkerStore,
+		extsvcStore:     mockExtsvcStore,
+		gitserverClient: mockGitserverClient,
 	}
 
 	logger := logtest.Scoped(t)
@@ -77,16 +86,19 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 	mockStore := NewMockStore()
 	mockExtsvcStore := NewMockExternalServiceStore()
 	mockScanner := NewMockPackageReferenceScanner()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockGitserverClient.NewFileReaderFunc.SetDefaultReturn(nil, os.ErrNotExist)
 	mockUploadsSvc.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
 	mockUploadsSvc.GetUploadByIDFunc.SetDefaultReturn(shared.Upload{ID: 42, RepositoryID: 50, Indexer: "lsif-go"}, true, nil)
 	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{UploadID: 42, Scheme: "gomod", Name: "name1", Version: "v2.2.0"}}, true, nil)
 
 	handler := dependencySyncSchedulerHandler{
-		uploadsSvc:  mockUploadsSvc,
-		depsSvc:     mockDepedenciesSvc,
-		store:       mockStore,
-		workerStore: mockWorkerStore,
-		extsvcStore: mockExtsvcStore,
+		uploadsSvc:      mockUploadsSvc,
+		depsSvc:         mockDepedenciesSvc,
+		store:           mockStore,
+		workerStore:     mockWorkerStore,
+		extsvcStore:     mockExtsvcStore,
+		gitserverClient: mockGitserverClient,
 	}
 
 	logger := logtest.Scoped(t)
@@ -121,6 +133,61 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 	}
 }
 
+func TestDependencySyncSchedulerLockfiles(t *testing.T) {
+	mockWorkerStore := dbworkermocks.NewMockStore[dependencySyncingJob]()
+	mockUploadsSvc := NewMockUploadService()
+	mockDepedenciesSvc := NewMockDependenciesService()
+	mockStore := NewMockStore()
+	mockExtsvcStore := NewMockExternalServiceStore()
+	mockScanner := NewMockPackageReferenceScanner()
+	mockGitserverClient := gitserver.NewMockClient()
+	mockGitserverClient.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, name string) (io.ReadCloser, error) {
+		if name != "apps/web/mix.lock" {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		// The invalid entry is skipped rather than failing the whole lockfile.
+		return io.NopCloser(strings.NewReader(`%{
+  "invalid": {:hex, :"1nvalid", "1.0.0", "9eeebb394cc9a0f3ae56b813459f990abb0a3dedee1be6b27fdb50301930502f", [:mix], [], "hexpm", "8d7c597c3e4a64c395980882d4bca3cebb8d74197c590dc272cfd3b6a6310578"},
+  "jason": {:hex, :jason, "1.4.1", "af1504e35f629ddcdd6addb3513c3853991f694921b1b9368b0bd32beb9f1b63", [:mix], [], "hexpm", "fbb01ecdfd565b56261302f7e1fcc27c4fb8f32d56eab74db621fc154604a7a1"},
+}`)), nil
+	})
+	mockUploadsSvc.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
+	mockUploadsSvc.GetUploadByIDFunc.SetDefaultReturn(shared.Upload{ID: 42, RepositoryID: 50, RepositoryName: "github.com/acme/web", Commit: "deadbeef", Root: "apps/web", Indexer: "scip-elixir"}, true, nil)
+
+	handler := dependencySyncSchedulerHandler{
+		uploadsSvc:      mockUploadsSvc,
+		depsSvc:         mockDepedenciesSvc,
+		store:           mockStore,
+		workerStore:     mockWorkerStore,
+		extsvcStore:     mockExtsvcStore,
+		gitserverClient: mockGitserverClient,
+	}
+
+	logger := logtest.Scoped(t)
+	if err := handler.Handle(context.Background(), logger, dependencySyncingJob{UploadID: 42}); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(mockGitserverClient.NewFileReaderFunc.History()) != 2 {
+		t.Errorf("unexpected number of calls to NewFileReader. want=%d have=%d", 2, len(mockGitserverClient.NewFileReaderFunc.History()))
+	}
+
+	if history := mockDepedenciesSvc.InsertPackageRepoRefsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of calls to InsertPackageRepoRefs. want=%d have=%d", 1, len(history))
+	} else {
+		ref := history[0].Arg1[0]
+		if ref.Scheme != dependencies.HexPackagesScheme || ref.Name != "jason" || ref.Versions[0].Version != "1.4.1" {
+			t.Errorf("unexpected package repo ref %+v", ref)
+		}
+	}
+
+	if len(mockExtsvcStore.ListFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to extsvc.List. want=%d have=%d", 1, len(mockExtsvcStore.ListFunc.History()))
+	} else if kinds := mockExtsvcStore.ListFunc.History()[0].Arg1.Kinds; !slices.Equal(kinds, []string{extsvc.KindHexPackages}) {
+		t.Errorf("unexpected external service kinds %v", kinds)
+	}
+}
+
 func TestNewPackage(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -188,6 +255,31 @@ func TestNewPackage(t *testing.T) {
 				Version: "12.7.0",
 			},
 		},
+		{
+			name: "packagist name normalization",
+			in: shared.Package{
+				Scheme:  dependencies.PackagistPackagesScheme,
+				Manager: "composer",
+				Name:    "Symfony/Console",
+				Version: "v7.0.1",
+			},
+			out: &precise.Package{
+				Scheme:  dependencies.PackagistPackagesScheme,
+				Manager: "composer",
+				Name:    "symfony/console",
+				Version: "v7.0.1",
+			},
+		},
+		{
+			name: "packagist bad-name",
+			in: shared.Package{
+				Scheme:  dependencies.PackagistPackagesScheme,
+				Manager: "composer",
+				Name:    "ext-json",
+				Version: "*",
+			},
+			out: nil,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			have, err := newPackage(tc.in)
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/reposcheduler"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -56,6 +57,7 @@ func NewDependencyIndexSchedulers(
 	depsSvc dependencies.DependenciesService,
 	store store.Store,
 	indexEnqueuer dependencies.IndexEnqueuer,
+	gitserverClient gitserver.Client,
 	config *dependencies.Config,
 ) []goroutine.BackgroundRoutine {
 	metrics := dependencies.NewResetterMetrics(observationCtx)
@@ -74,6 +76,7 @@ func NewDependencyIndexSchedulers(
 			depsSvc,
 			store,
 			externalServiceStore,
+			gitserverClient,
 			workerutil.NewMetrics(observationCtx, "codeintel_dependency_index_processor"),
 			config,
 		),
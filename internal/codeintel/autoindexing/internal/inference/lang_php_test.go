@@ -0,0 +1,38 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "php without indexer",
+			repositoryContents: map[string]string{
+				"composer.json": "",
+				"composer.lock": "",
+			},
+		},
+	)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelAutoIndexingIndexerMap: map[string]string{"php": "davidrjenni/scip-php:latest"},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "php composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":                "",
+				"composer.lock":                "",
+				"packages/api/composer.json":   "",
+				"vendor/monolog/composer.json": "",
+				"tests/fixtures/composer.json": "",
+			},
+		},
+	)
+}
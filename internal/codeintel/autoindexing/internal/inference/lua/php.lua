@@ -0,0 +1,52 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+-- There is no default image for PHP, so PHP projects are only indexed once a
+-- site admin registers one under the "php" key of the indexer map.
+local has_indexer, indexer = pcall(require("sg.autoindex.indexes").get, "php")
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    if not has_indexer then
+      return {}
+    end
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            -- Installs the exact versions pinned in composer.lock, which
+            -- scip-php reads to attribute symbols to their packages.
+            commands = { "composer install --no-interaction --no-scripts --no-plugins --ignore-platform-reqs" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-php" },
+        outfile = outfile,
+        requested_envvars = { "COMPOSER_AUTH" },
+      })
+    end
+
+    return jobs
+  end,
+}
@@ -3,6 +3,7 @@ local config = require("sg.autoindex.config").new({})
 for _, name in ipairs({
   "go",
   "java",
+  "php",
   "python",
   "ruby",
   "rust",
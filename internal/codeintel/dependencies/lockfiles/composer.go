@@ -0,0 +1,57 @@
+package lockfiles
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type composerLock struct {
+	Packages    []composerLockPackage `json:"packages"`
+	PackagesDev []composerLockPackage `json:"packages-dev"`
+}
+
+type composerLockPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+}
+
+// ParseComposerLock parses a composer.lock file of a PHP project. Both the
+// regular and the development packages are returned. Packages with invalid
+// names or versions are logged and skipped.
+func ParseComposerLock(logger log.Logger, r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	var lock composerLock
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, errors.Wrap(err, "invalid composer.lock")
+	}
+
+	var pkgs []shared.MinimialVersionedPackageRepo
+	for _, p := range append(lock.Packages, lock.PackagesDev...) {
+		// Metapackages have no sources, and dev- versions are branches which
+		// are not published as releases on a Packagist repository.
+		if p.Type == "metapackage" || strings.HasPrefix(p.Version, "dev-") {
+			continue
+		}
+		pkg, err := reposource.ParsePackagistVersionedPackage(p.Name + "@" + p.Version)
+		if err != nil {
+			logger.Warn("composer.lock package was invalid",
+				log.Error(err),
+				log.String("name", p.Name),
+				log.String("version", p.Version))
+			continue
+		}
+		pkgs = append(pkgs, shared.MinimialVersionedPackageRepo{
+			Scheme:  pkg.Scheme(),
+			Name:    pkg.PackageSyntax(),
+			Version: pkg.PackageVersion(),
+		})
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,46 @@
+package lockfiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+func TestParseComposerLock(t *testing.T) {
+	lock := `{
+    "content-hash": "5d2d8b4b5f2f0b8f1c1b2d4a3e6f7a8b",
+    "packages": [
+        {"name": "Monolog/Monolog", "version": "3.5.0", "type": "library"},
+        {"name": "symfony/console", "version": "v7.0.1", "type": "library"},
+        {"name": "not-a-package", "version": "1.0.0", "type": "library"},
+        {"name": "acme/tools", "version": "dev-main", "type": "library"},
+        {"name": "acme/bundle", "version": "1.0.0", "type": "metapackage"}
+    ],
+    "packages-dev": [
+        {"name": "phpunit/phpunit", "version": "10.5.3", "type": "library"}
+    ],
+    "platform": {"php": ">=8.1"}
+}`
+
+	pkgs, err := ParseComposerLock(logtest.Scoped(t), strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []shared.MinimialVersionedPackageRepo{
+		{Scheme: "scip-php", Name: "monolog/monolog", Version: "3.5.0"},
+		{Scheme: "scip-php", Name: "symfony/console", Version: "v7.0.1"},
+		{Scheme: "scip-php", Name: "phpunit/phpunit", Version: "10.5.3"},
+	}
+	if diff := cmp.Diff(want, pkgs); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseComposerLock(logtest.Scoped(t), strings.NewReader(`{"packages": [`)); err == nil {
+		t.Error("expected an error for a truncated lockfile")
+	}
+}
@@ -0,0 +1,38 @@
+// Package lockfiles resolves the exact dependencies of a project from the
+// lockfile of its package manager, for package managers whose indexers do not
+// report the packages they reference.
+package lockfiles
+
+import (
+	"io"
+	"path"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+type parseFunc func(logger log.Logger, r io.Reader) ([]shared.MinimialVersionedPackageRepo, error)
+
+var parsers = map[string]parseFunc{
+	"mix.lock":      ParseMixLock,
+	"composer.lock": ParseComposerLock,
+}
+
+// Filenames are the names of the lockfiles that can be parsed.
+var Filenames = []string{"mix.lock", "composer.lock"}
+
+// Parse parses the lockfile at the given path, and returns the packages it
+// pins. Dependencies that are not resolved from a package host, such as git
+// or path dependencies, are skipped. Entries with invalid package names or
+// versions are logged and skipped as well, so that one bad entry doesn't hide
+// the rest of the file. ok is false if the file is not a lockfile this package
+// can parse.
+func Parse(logger log.Logger, file string, r io.Reader) (_ []shared.MinimialVersionedPackageRepo, ok bool, err error) {
+	parse, ok := parsers[path.Base(file)]
+	if !ok {
+		return nil, false, nil
+	}
+	pkgs, err := parse(logger.With(log.String("lockfile", file)), r)
+	return pkgs, true, err
+}
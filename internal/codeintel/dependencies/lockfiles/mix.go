@@ -0,0 +1,48 @@
+package lockfiles
+
+import (
+	"io"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// mixLockHexEntry matches the start of an entry of a mix.lock file that is
+// resolved from a Hex repository, capturing the package name and version:
+//
+//	"plug_crypto": {:hex, :plug_crypto, "2.0.0", "<checksum>", [:mix], [...], "hexpm", "<checksum>"},
+//
+// The name of the package can differ from the key of the entry, which is the
+// name of the OTP application, and is quoted if it is not a plain atom. Git
+// and path dependencies start with :git and :path and are not matched.
+var mixLockHexEntry = lazyregexp.New(`(?m)^\s*"[^"]+":\s*\{:hex,\s*:"?([^",\s]+)"?,\s*"([^"]+)"`)
+
+// ParseMixLock parses a mix.lock file of an Elixir project. Entries with
+// invalid package names or versions are logged and skipped.
+func ParseMixLock(logger log.Logger, r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []shared.MinimialVersionedPackageRepo
+	for _, match := range mixLockHexEntry.FindAllStringSubmatch(string(content), -1) {
+		pkg, err := reposource.ParseHexVersionedPackage(match[1] + "@" + match[2])
+		if err != nil {
+			logger.Warn("mix.lock entry was invalid",
+				log.Error(err),
+				log.String("name", match[1]),
+				log.String("version", match[2]))
+			continue
+		}
+		pkgs = append(pkgs, shared.MinimialVersionedPackageRepo{
+			Scheme:  pkg.Scheme(),
+			Name:    pkg.PackageSyntax(),
+			Version: pkg.PackageVersion(),
+		})
+	}
+	return pkgs, nil
+}
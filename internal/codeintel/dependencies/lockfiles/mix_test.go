@@ -0,0 +1,37 @@
+package lockfiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+func TestParseMixLock(t *testing.T) {
+	lock := `%{
+  "castore": {:hex, :castore, "1.0.5", "9eeebb394cc9a0f3ae56b813459f990abb0a3dedee1be6b27fdb50301930502f", [:mix], [], "hexpm", "8d7c597c3e4a64c395980882d4bca3cebb8d74197c590dc272cfd3b6a6310578"},
+  "jason": {:hex, :jason, "1.4.1", "af1504e35f629ddcdd6addb3513c3853991f694921b1b9368b0bd32beb9f1b63", [:mix], [{:decimal, "~> 1.0 or ~> 2.0", [hex: :decimal, repo: "hexpm", optional: true]}], "hexpm", "fbb01ecdfd565b56261302f7e1fcc27c4fb8f32d56eab74db621fc154604a7a1"},
+  "invalid": {:hex, :"1nvalid", "1.0.0", "9eeebb394cc9a0f3ae56b813459f990abb0a3dedee1be6b27fdb50301930502f", [:mix], [], "hexpm", "8d7c597c3e4a64c395980882d4bca3cebb8d74197c590dc272cfd3b6a6310578"},
+  "phoenix": {:git, "https://github.com/phoenixframework/phoenix.git", "5f3fbd5b2c1bcbb0c1d0e2c1b8d3f2b5b2d7a1f0", []},
+  "private_lib": {:hex, :acme_lib, "0.2.0", "1bd9aaa0b7a6e1d5d1f8d8c0e2d4c5c5b4a0d3c1f6f2e5e9c1a9b3e0f4d2c1a0", [:mix], [], "hexpm:acme", "2c0e6c9b7e5d3b1a9f7e5d3c1b9a7f5e3d1c9b7a5f3e1d9c7b5a3f1e9d7c5b3a"},
+  "local": {:path, "../local", []},
+}
+`
+
+	pkgs, err := ParseMixLock(logtest.Scoped(t), strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []shared.MinimialVersionedPackageRepo{
+		{Scheme: "hex", Name: "castore", Version: "1.0.5"},
+		{Scheme: "hex", Name: "jason", Version: "1.4.1"},
+		{Scheme: "hex", Name: "acme_lib", Version: "0.2.0"},
+	}
+	if diff := cmp.Diff(want, pkgs); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+}
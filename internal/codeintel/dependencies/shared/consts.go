@@ -1,10 +1,13 @@
 package shared
 
 const (
-	GoPackagesScheme     = "go"
-	JVMPackagesScheme    = "semanticdb"
-	NpmPackagesScheme    = "npm"
-	PythonPackagesScheme = "python"
-	RustPackagesScheme   = "rust-analyzer"
-	RubyPackagesScheme   = "scip-ruby"
+	GoPackagesScheme        = "go"
+	JVMPackagesScheme       = "semanticdb"
+	NpmPackagesScheme       = "npm"
+	PythonPackagesScheme    = "python"
+	RustPackagesScheme      = "rust-analyzer"
+	RubyPackagesScheme      = "scip-ruby"
+	NuGetPackagesScheme     = "scip-dotnet"
+	HexPackagesScheme       = "hex"
+	PackagistPackagesScheme = "scip-php"
 )
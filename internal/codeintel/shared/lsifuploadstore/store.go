@@ -8,10 +8,22 @@ import (
 )
 
 func New(ctx context.Context, observationCtx *observation.Context, conf *Config) (object.Storage, error) {
-	c := object.StorageConfig{
+	return object.CreateLazyStorage(ctx, storageConfig(conf), object.NewOperations(observationCtx, "codeintel", "uploadstore"))
+}
+
+// HasLifecycleExpiry returns true if the upload store configures its bucket to expire
+// uploads older than the configured TTL, so that they don't need to be expired by the
+// worker.
+func HasLifecycleExpiry(conf *Config) bool {
+	return object.HasLifecycleExpiry(storageConfig(conf))
+}
+
+func storageConfig(conf *Config) object.StorageConfig {
+	return object.StorageConfig{
 		Backend:      conf.Backend,
 		ManageBucket: conf.ManageBucket,
 		Bucket:       conf.Bucket,
+		TTL:          conf.TTL,
 		S3: object.S3Config{
 			Region:          conf.S3Region,
 			Endpoint:        conf.S3Endpoint,
@@ -25,7 +37,8 @@ func New(ctx context.Context, observationCtx *observation.Context, conf *Config)
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
 		},
+		Filesystem: object.FilesystemConfig{
+			Dir: conf.FilesystemDir,
+		},
 	}
-
-	return object.CreateLazyStorage(ctx, c, object.NewOperations(observationCtx, "codeintel", "uploadstore"))
 }
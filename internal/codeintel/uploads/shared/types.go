@@ -202,6 +202,7 @@ func NewAutoIndexJob(job config.AutoIndexJobSpec, repositoryID api.RepoID, commi
 			Root:     step.Root,
 			Image:    step.Image,
 			Commands: step.Commands,
+			Caches:   step.Caches,
 		}
 	})
 
@@ -228,9 +229,10 @@ func (i AutoIndexJob) RecordUID() string {
 }
 
 type DockerStep struct {
-	Root     string   `json:"root"`
-	Image    string   `json:"image"`
-	Commands []string `json:"commands"`
+	Root     string             `json:"root"`
+	Image    string             `json:"image"`
+	Commands []string           `json:"commands"`
+	Caches   []config.StepCache `json:"caches,omitempty"`
 }
 
 func (s *DockerStep) Scan(value any) error {
@@ -1,8 +1,11 @@
 package background
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -15,7 +18,81 @@ type actionArgs struct {
 	UTMSource          string
 	MonitorOwnerName   string
 
-	Query          string
-	Results        []*result.CommitMatch
+	Query string
+	// Results are commit matches for diff and commit monitors, and file
+	// matches with the new matched lines for content monitors.
+	Results        result.Matches
 	IncludeResults bool
 }
+
+// matchDescription is what actions show of a result.
+type matchDescription struct {
+	// Type is "Diff" or "Message" for commit matches, and "Content" for the
+	// file matches of content monitors.
+	Type     string
+	RepoName api.RepoName
+	CommitID api.CommitID
+	// Path is the path of the file of a file match.
+	Path string
+	// URL links to the commit of a commit match, and to the file of a file
+	// match.
+	URL string
+	// Content is the matched diff or message of a commit match, and the
+	// matched lines of a file match.
+	Content string
+}
+
+// Label identifies the result in a notification.
+func (d matchDescription) Label() string {
+	label := fmt.Sprintf("%s@%s", d.RepoName, d.CommitID.Short())
+	if d.Path != "" {
+		label += ": " + d.Path
+	}
+	return label
+}
+
+func describeMatch(match result.Match, externalURL *url.URL, utmSource string) matchDescription {
+	switch m := match.(type) {
+	case *result.CommitMatch:
+		d := matchDescription{
+			RepoName: m.Repo.Name,
+			CommitID: m.Commit.ID,
+			URL:      getCommitURL(externalURL, string(m.Repo.Name), string(m.Commit.ID), utmSource),
+		}
+		switch {
+		case m.DiffPreview != nil:
+			d.Type = "Diff"
+			d.Content = m.DiffPreview.Content
+		case m.MessagePreview != nil:
+			d.Type = "Message"
+			d.Content = m.MessagePreview.Content
+		default:
+			panic("exactly one of DiffPreview or MessagePreview must be set")
+		}
+		return d
+	case *result.FileMatch:
+		return matchDescription{
+			Type:     "Content",
+			RepoName: m.Repo.Name,
+			CommitID: m.CommitID,
+			Path:     m.Path,
+			URL:      getFileURL(externalURL, string(m.Repo.Name), string(m.CommitID), m.Path, utmSource),
+			Content:  fileMatchContent(m),
+		}
+	default:
+		panic(fmt.Sprintf("unexpected code monitor result type %T", match))
+	}
+}
+
+// fileMatchContent renders the matched lines of a file match, prefixed with
+// their line numbers.
+func fileMatchContent(fm *result.FileMatch) string {
+	var b strings.Builder
+	for _, chunk := range fm.ChunkMatches {
+		for i, line := range strings.Split(strings.TrimSuffix(chunk.Content, "\n"), "\n") {
+			// Line numbers of chunk matches are 0-based.
+			fmt.Fprintf(&b, "%d: %s\n", chunk.ContentStart.Line+i+1, line)
+		}
+	}
+	return b.String()
+}
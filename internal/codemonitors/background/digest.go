@@ -0,0 +1,274 @@
+package background
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxDigestRepositories is the number of repositories listed per monitor in
+// a digest. Repositories with the most results are listed first.
+const maxDigestRepositories = 10
+
+// DigestMonitor summarises the results of a code monitor over a digest
+// period.
+type DigestMonitor struct {
+	MonitorID        int64
+	Description      string
+	CodeMonitorURL   string
+	SearchURL        string
+	TotalCount       int
+	ResultPluralized string
+	Repositories     []DigestRepository
+	// TruncatedRepositoryCount is the number of repositories with results
+	// that are not listed in Repositories.
+	TruncatedRepositoryCount int
+}
+
+type DigestRepository struct {
+	Name  string
+	Count int
+}
+
+// newDigestMonitors groups the results of the action jobs of a digest by
+// monitor, in the order in which the monitors first appear in jobs.
+func newDigestMonitors(externalURL *url.URL, utmSource string, jobs []*database.ActionJobMetadata) []*DigestMonitor {
+	var monitors []*DigestMonitor
+	byMonitor := make(map[int64]*DigestMonitor)
+	repoCounts := make(map[int64]map[string]int)
+	for _, j := range jobs {
+		m, ok := byMonitor[j.MonitorID]
+		if !ok {
+			// The query of the earliest job has the earliest after: filter,
+			// so it finds the results of all later jobs, too.
+			m = &DigestMonitor{
+				MonitorID:      j.MonitorID,
+				Description:    j.Description,
+				CodeMonitorURL: getCodeMonitorURL(externalURL, j.MonitorID, utmSource),
+				SearchURL:      getSearchURL(externalURL, j.Query, utmSource),
+			}
+			byMonitor[j.MonitorID] = m
+			repoCounts[j.MonitorID] = make(map[string]int)
+			monitors = append(monitors, m)
+		}
+		for _, r := range j.Results {
+			count := r.ResultCount()
+			m.TotalCount += count
+			repoCounts[j.MonitorID][string(r.RepoName().Name)] += count
+		}
+	}
+
+	for _, m := range monitors {
+		m.ResultPluralized = pluralize("result", m.TotalCount)
+		for name, count := range repoCounts[m.MonitorID] {
+			m.Repositories = append(m.Repositories, DigestRepository{Name: name, Count: count})
+		}
+		sort.Slice(m.Repositories, func(i, j int) bool {
+			if m.Repositories[i].Count != m.Repositories[j].Count {
+				return m.Repositories[i].Count > m.Repositories[j].Count
+			}
+			return m.Repositories[i].Name < m.Repositories[j].Name
+		})
+		if len(m.Repositories) > maxDigestRepositories {
+			m.TruncatedRepositoryCount = len(m.Repositories) - maxDigestRepositories
+			m.Repositories = m.Repositories[:maxDigestRepositories]
+		}
+	}
+	return monitors
+}
+
+// digestPeriod returns the unit of time that a digest covers, e.g. "day".
+func digestPeriod(digest database.CodeMonitorDigest) string {
+	switch digest {
+	case database.CodeMonitorDigestHourly:
+		return "hour"
+	case database.CodeMonitorDigestWeekly:
+		return "week"
+	default:
+		return "day"
+	}
+}
+
+var (
+	//go:embed digest_email_template.html.tmpl
+	digestHTMLTemplate string
+
+	//go:embed digest_email_template.txt.tmpl
+	digestTextTemplate string
+)
+
+var digestEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Your {{.Digest}} Sourcegraph code monitor digest: {{.TotalCount}} new {{.ResultPluralized}}`,
+	Text:    digestTextTemplate,
+	HTML:    digestHTMLTemplate,
+})
+
+type TemplateDataDigest struct {
+	Digest           database.CodeMonitorDigest
+	Period           string
+	TotalCount       int
+	ResultPluralized string
+	Monitors         []*DigestMonitor
+}
+
+func NewTemplateDataForDigest(externalURL *url.URL, digest database.CodeMonitorDigest, jobs []*database.ActionJobMetadata) *TemplateDataDigest {
+	monitors := newDigestMonitors(externalURL, utmSourceEmail, jobs)
+
+	totalCount := 0
+	for _, m := range monitors {
+		totalCount += m.TotalCount
+	}
+
+	return &TemplateDataDigest{
+		Digest:           digest,
+		Period:           digestPeriod(digest),
+		TotalCount:       totalCount,
+		ResultPluralized: pluralize("result", totalCount),
+		Monitors:         monitors,
+	}
+}
+
+var MockSendEmailForDigest func(ctx context.Context, db database.DB, userID int32, data *TemplateDataDigest) error
+
+func SendEmailForDigest(ctx context.Context, db database.DB, userID int32, data *TemplateDataDigest) error {
+	if MockSendEmailForDigest != nil {
+		return MockSendEmailForDigest(ctx, db, userID, data)
+	}
+	return sendEmail(ctx, db, userID, digestEmailTemplates, data)
+}
+
+// sendEmailDigest sends one email to every recipient of the email actions in
+// the digest of the given action job, with the results of the actions they
+// are a recipient of. The digest contains the email actions with the same
+// digest period of all monitors of the same owner.
+//
+// A retry would send the digest again to the recipients who already got it,
+// so failing to send to some recipients is only logged. It only returns an
+// error if no email could be sent at all.
+func sendEmailDigest(ctx context.Context, db database.DB, jobID int32, digest database.CodeMonitorDigest) error {
+	logger := log.Scoped("sendEmailDigest")
+	s := db.CodeMonitors()
+	jobs, err := s.CollectDigestActionJobs(ctx, jobID)
+	if err != nil {
+		return errors.Wrap(err, "CollectDigestActionJobs")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []int32
+	jobsByUser := make(map[int32][]*database.ActionJobMetadata)
+	recipientsByEmail := make(map[int64][]int32)
+	for _, j := range jobs {
+		if j.EmailID == nil {
+			continue
+		}
+		recipients, ok := recipientsByEmail[*j.EmailID]
+		if !ok {
+			recs, err := s.ListRecipients(ctx, database.ListRecipientsOpts{EmailID: j.EmailID})
+			if err != nil {
+				return errors.Wrap(err, "ListRecipients")
+			}
+			recipients, err = recipientUserIDs(ctx, db, recs)
+			if err != nil {
+				return err
+			}
+			recipientsByEmail[*j.EmailID] = recipients
+		}
+		for _, userID := range recipients {
+			userJobs, ok := jobsByUser[userID]
+			if !ok {
+				userIDs = append(userIDs, userID)
+			}
+			jobsByUser[userID] = append(userJobs, j)
+		}
+	}
+
+	var sendErr error
+	sent := 0
+	for _, userID := range userIDs {
+		data := NewTemplateDataForDigest(externalURL, digest, jobsByUser[userID])
+		if err := SendEmailForDigest(ctx, db, userID, data); err != nil {
+			sendErr = errors.Append(sendErr, err)
+			continue
+		}
+		sent++
+	}
+	if sent == 0 {
+		return sendErr
+	}
+	if sendErr != nil {
+		logger.Warn("failed to send code monitor digest to some recipients", log.Int32("jobID", jobID), log.Error(sendErr))
+	}
+	return nil
+}
+
+func slackDigestPayload(ownerName string, digest database.CodeMonitorDigest, m *DigestMonitor) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches in the last %s.",
+			ownerName,
+			m.Description,
+			m.TotalCount,
+			digestPeriod(digest),
+		)),
+	}
+
+	var repos strings.Builder
+	for _, r := range m.Repositories {
+		fmt.Fprintf(&repos, "• `%s`: %d\n", r.Name, r.Count)
+	}
+	if m.TruncatedRepositoryCount > 0 {
+		fmt.Fprintf(&repos, "• ...and %d more repositories\n", m.TruncatedRepositoryCount)
+	}
+	blocks = append(blocks,
+		newMarkdownSection(repos.String()),
+		newMarkdownSection(fmt.Sprintf("<%s|View results>", m.SearchURL)),
+		newMarkdownSection(fmt.Sprintf(
+			`If you are %s, you can <%s|edit your code monitor>`,
+			ownerName,
+			m.CodeMonitorURL,
+		)),
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+// sendSlackDigest sends one Slack message with the results of all action
+// jobs of the given Slack webhook action in the current digest period.
+func sendSlackDigest(ctx context.Context, s database.CodeMonitorStore, jobID int32, w *database.SlackWebhookAction) error {
+	jobs, err := s.CollectDigestActionJobs(ctx, jobID)
+	if err != nil {
+		return errors.Wrap(err, "CollectDigestActionJobs")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	monitors := newDigestMonitors(externalURL, "code-monitor-slack-webhook", jobs)
+	if len(monitors) == 0 {
+		return nil
+	}
+	return postSlackWebhook(ctx, httpcli.ExternalDoer, w.URL, slackDigestPayload(jobs[0].OwnerName, w.Digest, monitors[0]))
+}
@@ -0,0 +1,209 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestDigest(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	inRepo := func(m result.CommitMatch, repo string) *result.CommitMatch {
+		m.Repo.Name = api.RepoName(repo)
+		return &m
+	}
+
+	jobs := []*database.ActionJobMetadata{{
+		MonitorID:   1,
+		Description: "First monitor",
+		Query:       `foo after:"2024-08-21T00:00:00Z"`,
+		OwnerName:   "Camden Cheek",
+		Results:     result.Matches{inRepo(commitResultMock, "github.com/test/a"), inRepo(diffResultMock, "github.com/test/b")},
+	}, {
+		MonitorID:   2,
+		Description: "Second monitor",
+		Query:       `bar after:"2024-08-21T00:00:00Z"`,
+		OwnerName:   "Camden Cheek",
+		Results:     result.Matches{inRepo(commitResultMock, "github.com/test/a")},
+	}, {
+		MonitorID:   1,
+		Description: "First monitor",
+		Query:       `foo after:"2024-08-21T01:00:00Z"`,
+		OwnerName:   "Camden Cheek",
+		Results:     result.Matches{inRepo(commitResultMock, "github.com/test/a")},
+	}}
+
+	t.Run("monitors", func(t *testing.T) {
+		monitors := newDigestMonitors(eu, "test", jobs)
+		require.Len(t, monitors, 2)
+
+		first := monitors[0]
+		require.Equal(t, int64(1), first.MonitorID)
+		require.Equal(t, 4, first.TotalCount)
+		require.Equal(t, "results", first.ResultPluralized)
+		// Repositories with the same count are sorted by name.
+		require.Equal(t, []DigestRepository{{Name: "github.com/test/a", Count: 2}, {Name: "github.com/test/b", Count: 2}}, first.Repositories)
+		require.Equal(t, "https://sourcegraph.com/search?q=foo+after%3A%222024-08-21T00%3A00%3A00Z%22&utm_source=test", first.SearchURL)
+		require.Equal(t, "https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6MQ==?utm_source=test", first.CodeMonitorURL)
+
+		second := monitors[1]
+		require.Equal(t, int64(2), second.MonitorID)
+		require.Equal(t, 1, second.TotalCount)
+		require.Equal(t, "result", second.ResultPluralized)
+	})
+
+	t.Run("truncated repositories", func(t *testing.T) {
+		var results result.Matches
+		for i := range 12 {
+			results = append(results, inRepo(commitResultMock, fmt.Sprintf("github.com/test/%02d", i)))
+		}
+		results = append(results, inRepo(commitResultMock, "github.com/test/11"))
+
+		monitors := newDigestMonitors(eu, "test", []*database.ActionJobMetadata{{MonitorID: 1, Results: results}})
+		require.Len(t, monitors[0].Repositories, maxDigestRepositories)
+		require.Equal(t, DigestRepository{Name: "github.com/test/11", Count: 2}, monitors[0].Repositories[0])
+		require.Equal(t, 2, monitors[0].TruncatedRepositoryCount)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		template := txemail.MustParseTemplate(digestEmailTemplates)
+		data := NewTemplateDataForDigest(eu, database.CodeMonitorDigestDaily, jobs)
+		require.Equal(t, 5, data.TotalCount)
+
+		var buf bytes.Buffer
+		require.NoError(t, template.Subj.Execute(&buf, data))
+		require.Equal(t, "Your daily Sourcegraph code monitor digest: 5 new results", buf.String())
+
+		buf.Reset()
+		require.NoError(t, template.Text.Execute(&buf, data))
+		require.Contains(t, buf.String(), "Your Sourcegraph code monitors detected 5 new results in the last day.")
+		require.Contains(t, buf.String(), "First monitor: 4 new results\n- github.com/test/a: 2\n- github.com/test/b: 2\n")
+		require.Contains(t, buf.String(), "Second monitor: 1 new result\n- github.com/test/a: 1\n")
+
+		buf.Reset()
+		require.NoError(t, template.Html.Execute(&buf, data))
+		require.Contains(t, buf.String(), "<li>github.com/test/b: <b>2</b></li>")
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		monitors := newDigestMonitors(eu, "test", jobs[:1])
+		msg := slackDigestPayload("Camden Cheek", database.CodeMonitorDigestHourly, monitors[0])
+
+		var texts []string
+		for _, b := range msg.Blocks.BlockSet {
+			texts = append(texts, b.(*slack.SectionBlock).Text.Text)
+		}
+		require.Equal(t, []string{
+			"Camden Cheek's Sourcegraph Code monitor, *First monitor*, detected *3* new matches in the last hour.",
+			"• `github.com/test/b`: 2\n• `github.com/test/a`: 1\n",
+			"<https://sourcegraph.com/search?q=foo+after%3A%222024-08-21T00%3A00%3A00Z%22&utm_source=test|View results>",
+			"If you are Camden Cheek, you can <https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6MQ==?utm_source=test|edit your code monitor>",
+		}, texts)
+	})
+}
+
+func TestSendEmailDigest(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExternalURL: "https://sourcegraph.com",
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	emailID := func(id int64) *int64 { return &id }
+	namespace := func(id int32) *int32 { return &id }
+
+	// The owner, user 1, is only a recipient of the first monitor's action.
+	jobs := []*database.ActionJobMetadata{
+		{MonitorID: 1, Description: "First monitor", OwnerID: 1, EmailID: emailID(10), Results: result.Matches{&commitResultMock}},
+		{MonitorID: 2, Description: "Second monitor", OwnerID: 1, EmailID: emailID(20), Results: result.Matches{&commitResultMock}},
+		{MonitorID: 1, Description: "First monitor", OwnerID: 1, EmailID: emailID(10), Results: result.Matches{&commitResultMock}},
+	}
+	recipients := map[int64][]*database.Recipient{
+		10: {{Email: 10, NamespaceUserID: namespace(1)}, {Email: 10, NamespaceUserID: namespace(2)}},
+		20: {{Email: 20, NamespaceUserID: namespace(2)}, {Email: 20, NamespaceOrgID: namespace(3)}},
+	}
+
+	s := dbmocks.NewMockCodeMonitorStore()
+	s.CollectDigestActionJobsFunc.SetDefaultReturn(jobs, nil)
+	s.ListRecipientsFunc.SetDefaultHook(func(_ context.Context, opts database.ListRecipientsOpts) ([]*database.Recipient, error) {
+		return recipients[*opts.EmailID], nil
+	})
+
+	// User 2 is a recipient of the second monitor's action both directly and
+	// as a member of org 3.
+	orgMembers := dbmocks.NewMockOrgMemberStore()
+	orgMembers.GetByOrgIDFunc.SetDefaultReturn([]*types.OrgMembership{{OrgID: 3, UserID: 2}, {OrgID: 3, UserID: 4}}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.CodeMonitorsFunc.SetDefaultReturn(s)
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+	var sent map[int32][]int64
+	sendErr := map[int32]error{}
+	MockSendEmailForDigest = func(_ context.Context, _ database.DB, userID int32, data *TemplateDataDigest) error {
+		if err := sendErr[userID]; err != nil {
+			return err
+		}
+		for _, m := range data.Monitors {
+			sent[userID] = append(sent[userID], m.MonitorID)
+		}
+		return nil
+	}
+	t.Cleanup(func() { MockSendEmailForDigest = nil })
+
+	t.Run("recipients", func(t *testing.T) {
+		sent = map[int32][]int64{}
+		require.NoError(t, sendEmailDigest(context.Background(), db, 1, database.CodeMonitorDigestDaily))
+		require.Equal(t, map[int32][]int64{1: {1}, 2: {1, 2}, 4: {2}}, sent)
+		// The recipients of every action are only listed once.
+		require.Len(t, s.ListRecipientsFunc.History(), 2)
+	})
+
+	t.Run("failed recipient", func(t *testing.T) {
+		// The other recipients still get their digest, and the job isn't
+		// retried, which would send it to them again.
+		sent = map[int32][]int64{}
+		sendErr = map[int32]error{2: errors.New("boom")}
+		require.NoError(t, sendEmailDigest(context.Background(), db, 1, database.CodeMonitorDigestDaily))
+		require.Equal(t, map[int32][]int64{1: {1}, 4: {2}}, sent)
+	})
+
+	t.Run("all recipients failed", func(t *testing.T) {
+		sent = map[int32][]int64{}
+		sendErr = map[int32]error{1: errors.New("boom"), 2: errors.New("boom"), 4: errors.New("boom")}
+		require.Error(t, sendEmailDigest(context.Background(), db, 1, database.CodeMonitorDigestDaily))
+		require.Empty(t, sent)
+	})
+}
+
+func TestSendSlackDigest_NoJobs(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExternalURL: "https://sourcegraph.com",
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// The jobs of the digest may have been collected by a concurrent job
+	// already.
+	s := dbmocks.NewMockCodeMonitorStore()
+	require.NoError(t, sendSlackDigest(context.Background(), s, 1, &database.SlackWebhookAction{URL: "https://slack.example.com"}))
+}
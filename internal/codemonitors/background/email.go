@@ -140,6 +140,37 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 	return nil
 }
 
+// recipientUserIDs returns the IDs of the users who receive the emails sent to
+// recs. The members of org recipients receive them, too.
+func recipientUserIDs(ctx context.Context, db database.DB, recs []*database.Recipient) ([]int32, error) {
+	var userIDs []int32
+	seen := make(map[int32]struct{})
+	add := func(userID int32) {
+		if _, ok := seen[userID]; !ok {
+			seen[userID] = struct{}{}
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	for _, rec := range recs {
+		switch {
+		case rec.NamespaceUserID != nil:
+			add(*rec.NamespaceUserID)
+		case rec.NamespaceOrgID != nil:
+			members, err := db.OrgMembers().GetByOrgID(ctx, *rec.NamespaceOrgID)
+			if err != nil {
+				return nil, errors.Wrap(err, "GetByOrgID")
+			}
+			for _, m := range members {
+				add(m.UserID)
+			}
+		default:
+			return nil, errors.New("nil recipient")
+		}
+	}
+	return userIDs, nil
+}
+
 func getSearchURL(externalURL *url.URL, query, utmSource string) string {
 	return sourcegraphURL(externalURL, "search", query, utmSource)
 }
@@ -152,6 +183,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, oid, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s@%s/-/blob/%s", repoName, oid, path), "", utmSource)
+}
+
 func sourcegraphURL(externalURL *url.URL, path, query, utmSource string) string {
 	// Construct URL to the search query.
 	u := externalURL.ResolveReference(&url.URL{Path: path})
@@ -174,24 +209,23 @@ func pluralize(word string, count int) string {
 
 type DisplayResult struct {
 	ResultType string
-	CommitURL  string
-	RepoName   string
-	CommitID   string
-	Content    string
+	// CommitURL links to the commit, or to the file of content matches.
+	CommitURL string
+	RepoName  string
+	CommitID  string
+	// Path is only set for content matches.
+	Path    string
+	Content string
 }
 
-func toDisplayResult(result *searchresult.CommitMatch, externalURL *url.URL) *DisplayResult {
-	resultType := "Message"
-	if result.DiffPreview != nil {
-		resultType = "Diff"
-	}
-
-	content := truncateMatchContent(result)
+func toDisplayResult(result searchresult.Match, externalURL *url.URL) *DisplayResult {
+	d := describeMatch(result, externalURL, utmSourceEmail)
 	return &DisplayResult{
-		ResultType: resultType,
-		CommitURL:  getCommitURL(externalURL, string(result.Repo.Name), string(result.Commit.ID), utmSourceEmail),
-		RepoName:   string(result.Repo.Name),
-		CommitID:   result.Commit.ID.Short(),
-		Content:    content,
+		ResultType: d.Type,
+		CommitURL:  d.URL,
+		RepoName:   string(d.RepoName),
+		CommitID:   d.CommitID.Short(),
+		Path:       d.Path,
+		Content:    truncateMatchContent(d.Content),
 	}
 }
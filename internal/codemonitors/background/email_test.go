@@ -188,4 +188,25 @@ func TestEmail(t *testing.T) {
 		})
 	})
 
+	t.Run("content result", func(t *testing.T) {
+		displayResult := toDisplayResult(&contentResultMock, externalURLMock)
+		require.Equal(t, &DisplayResult{
+			ResultType: "Content",
+			CommitURL:  "https://www.sourcegraph.com/github.com/test/test@7815187511872asbasdfgasd/-/blob/config/secrets.go?utm_source=code-monitoring-email",
+			RepoName:   "github.com/test/test",
+			CommitID:   "7815187",
+			Path:       "config/secrets.go",
+			Content:    "3: \ttoken := \"secret\"\n",
+		}, displayResult)
+
+		var buf bytes.Buffer
+		err := template.Text.Execute(&buf, &TemplateDataNewSearchResults{
+			TotalCount:       1,
+			ResultPluralized: "result",
+			IncludeResults:   true,
+			TruncatedResults: []*DisplayResult{displayResult},
+		})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "Content match: "+displayResult.CommitURL+" from github.com/test/test@7815187: config/secrets.go")
+	})
 }
@@ -1,19 +1,14 @@
 package background
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
 
 	"github.com/slack-go/slack"
 
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	searchresult "github.com/sourcegraph/sourcegraph/internal/search/result"
-	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func sendSlackNotification(ctx context.Context, url string, args actionArgs) error {
@@ -38,19 +33,15 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 
 	if args.IncludeResults {
 		for _, result := range truncatedResults {
-			resultType := "Message"
-			if result.DiffPreview != nil {
-				resultType = "Diff"
-			}
+			d := describeMatch(result, args.ExternalURL, args.UTMSource)
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
-				"%s match: <%s|%s@%s>",
-				resultType,
-				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
-				result.Repo.Name,
-				result.Commit.ID.Short(),
+				"%s match: <%s|%s>",
+				d.Type,
+				d.URL,
+				d.Label(),
 			)))
 
-			contentRaw := truncateMatchContent(result)
+			contentRaw := truncateMatchContent(d.Content)
 			blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
 		}
 		if truncatedCount > 0 {
@@ -81,27 +72,17 @@ func formatCodeBlock(s string) string {
 	return fmt.Sprintf("```%s```", strings.ReplaceAll(s, "```", "\\`\\`\\`"))
 }
 
-// truncateMatchContent truncates the match to at most 10 lines, and also
-// truncates lines once the content length exceeds 2500 bytes.
+// truncateMatchContent truncates the content of a match to at most 10 lines,
+// and also truncates lines once the content length exceeds 2500 bytes.
 //
 // We limit the bytes to ensure we don't hit Slack's max block size of 3000
 // characters. To be conservative, we truncate to 2500 bytes. We also limit
 // the number of lines to 10 to ensure the content is easy to read.
-func truncateMatchContent(result *searchresult.CommitMatch) string {
+func truncateMatchContent(content string) string {
 	const maxBytes = 2500
 	const maxLines = 10
 
-	var matchedString *searchresult.MatchedString
-	switch {
-	case result.DiffPreview != nil:
-		matchedString = result.DiffPreview
-	case result.MessagePreview != nil:
-		matchedString = result.MessagePreview
-	default:
-		panic("exactly one of DiffPreview or MessagePreview must be set")
-	}
-
-	splitLines := strings.SplitAfter(matchedString.Content, "\n")
+	splitLines := strings.SplitAfter(content, "\n")
 	limit := len(splitLines)
 	if limit > maxLines {
 		limit = maxLines
@@ -122,8 +103,8 @@ func truncateMatchContent(result *searchresult.CommitMatch) string {
 	return strings.Join(splitLines, "")
 }
 
-func truncateResults(results []*searchresult.CommitMatch, maxResults int) (_ []*searchresult.CommitMatch, totalCount, truncatedCount int) {
-	// Convert to type result.Matches
+func truncateResults(results searchresult.Matches, maxResults int) (_ searchresult.Matches, totalCount, truncatedCount int) {
+	// Limit truncates the slice in place, so work on a copy.
 	matches := make(searchresult.Matches, len(results))
 	for i, res := range results {
 		matches[i] = res
@@ -133,44 +114,11 @@ func truncateResults(results []*searchresult.CommitMatch, maxResults int) (_ []*
 	matches.Limit(maxResults)
 	outputCount := matches.ResultCount()
 
-	// Convert back type []*result.CommitMatch
-	output := make([]*searchresult.CommitMatch, len(matches))
-	for i, match := range matches {
-		output[i] = match.(*searchresult.CommitMatch)
-	}
-
-	return output, totalCount, totalCount - outputCount
+	return matches, totalCount, totalCount - outputCount
 }
 
-// adapted from slack.PostWebhookCustomHTTPContext
 func postSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *slack.WebhookMessage) error {
-	raw, err := json.Marshal(msg)
-	if err != nil {
-		return errors.Wrap(err, "marshal failed")
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
-	if err != nil {
-		return errors.Wrap(err, "failed new request")
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := doer.Do(req)
-	if err != nil {
-		return errors.Wrap(err, "failed to post webhook")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return StatusCodeError{
-			Code:   resp.StatusCode,
-			Status: resp.Status,
-			Body:   string(body),
-		}
-	}
-
-	return nil
+	return httpcli.PostJSON(ctx, doer, url, msg)
 }
 
 func SendTestSlackWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hexops/autogold/v2"
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -25,7 +26,7 @@ func TestSlackWebhook(t *testing.T) {
 		MonitorOwnerName:   "Camden Cheek",
 		ExternalURL:        eu,
 		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
-		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		Results:            result.Matches{&diffResultMock, &commitResultMock},
 		IncludeResults:     false,
 	}
 
@@ -91,6 +92,16 @@ func TestSlackWebhook(t *testing.T) {
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.ExpectFile(t, jsonSlackPayload(action))
 	})
+
+	t.Run("content results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.Results = result.Matches{&contentResultMock}
+
+		blocks := slackPayload(actionCopy).Blocks.BlockSet
+		require.Equal(t, "Content match: <https://sourcegraph.com/github.com/test/test@7815187511872asbasdfgasd/-/blob/config/secrets.go?utm_source=|github.com/test/test@7815187: config/secrets.go>", blocks[1].(*slack.SectionBlock).Text.Text)
+		require.Equal(t, "```3: \ttoken := \"secret\"\n```", blocks[2].(*slack.SectionBlock).Text.Text)
+	})
 }
 
 func TestTriggerTestSlackWebhookAction(t *testing.T) {
@@ -0,0 +1,148 @@
+package background
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postTeamsWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+// teamsMessage is the payload of a Microsoft Teams incoming webhook that
+// contains a single Adaptive Card.
+//
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []adaptiveCardElement `json:"body"`
+	Actions []adaptiveCardAction  `json:"actions,omitempty"`
+	MSTeams *adaptiveCardMSTeams  `json:"msteams,omitempty"`
+}
+
+// adaptiveCardElement is a TextBlock, a RichTextBlock or one of the TextRuns
+// of a RichTextBlock.
+type adaptiveCardElement struct {
+	Type     string                `json:"type"`
+	Text     string                `json:"text,omitempty"`
+	Wrap     bool                  `json:"wrap,omitempty"`
+	FontType string                `json:"fontType,omitempty"`
+	Inlines  []adaptiveCardElement `json:"inlines,omitempty"`
+}
+
+type adaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type adaptiveCardMSTeams struct {
+	Width string `json:"width"`
+}
+
+func newTeamsMessage(body []adaptiveCardElement, actions []adaptiveCardAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+				// Diffs are hard to read in the default card width.
+				MSTeams: &adaptiveCardMSTeams{Width: "Full"},
+			},
+		}},
+	}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	newTextBlock := func(s string) adaptiveCardElement {
+		return adaptiveCardElement{Type: "TextBlock", Text: s, Wrap: true}
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	body := []adaptiveCardElement{
+		newTextBlock(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			d := describeMatch(result, args.ExternalURL, args.UTMSource)
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"%s match: [%s](%s)",
+				d.Type,
+				d.Label(),
+				d.URL,
+			)))
+
+			// TextBlocks render markdown, which would turn removed lines into
+			// list items. TextRuns are displayed as is.
+			body = append(body, adaptiveCardElement{
+				Type: "RichTextBlock",
+				Inlines: []adaptiveCardElement{{
+					Type:     "TextRun",
+					Text:     truncateMatchContent(d.Content),
+					FontType: "Monospace",
+				}},
+			})
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	actions := []adaptiveCardAction{{
+		Type:  "Action.OpenUrl",
+		Title: "View results",
+		URL:   getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+	}, {
+		Type:  "Action.OpenUrl",
+		Title: "Edit code monitor",
+		URL:   getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	}}
+
+	return newTeamsMessage(body, actions)
+}
+
+func postTeamsWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *teamsMessage) error {
+	// Webhooks created with Teams workflows respond with 202 Accepted rather
+	// than 200 OK, which PostJSON accepts.
+	return httpcli.PostJSON(ctx, doer, url, msg)
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]adaptiveCardElement{{
+		Type: "TextBlock",
+		Text: fmt.Sprintf("Test message for Code Monitor '%s'", description),
+		Wrap: true,
+	}}, nil)
+
+	return postTeamsWebhook(ctx, doer, url, testMessage)
+}
@@ -0,0 +1,119 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestTeamsWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            result.Matches{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	t.Run("adaptive card", func(t *testing.T) {
+		msg := teamsPayload(action)
+		require.Equal(t, "message", msg.Type)
+		require.Len(t, msg.Attachments, 1)
+		require.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+
+		card := msg.Attachments[0].Content
+		require.Equal(t, "AdaptiveCard", card.Type)
+		require.Len(t, card.Body, 1)
+		require.Equal(t, "Camden Cheek's Sourcegraph Code monitor, **My test monitor**, detected **3** new matches.", card.Body[0].Text)
+		require.Len(t, card.Actions, 2)
+		require.Equal(t, "https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=", card.Actions[0].URL)
+		require.Equal(t, "https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6NDI=?utm_source=", card.Actions[1].URL)
+	})
+
+	t.Run("with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+
+		body := teamsPayload(actionCopy).Attachments[0].Content.Body
+		require.Len(t, body, 5)
+		require.Equal(t, "Diff match: [github.com/test/test@7815187](https://sourcegraph.com/github.com/test/test/-/commit/7815187511872asbasdfgasd?utm_source=)", body[1].Text)
+		require.Equal(t, "RichTextBlock", body[2].Type)
+		require.Equal(t, diffResultMock.DiffPreview.Content, body[2].Inlines[0].Text)
+		require.Equal(t, "Monospace", body[2].Inlines[0].FontType)
+		require.Equal(t, "Message match: [github.com/test/test@7815187](https://sourcegraph.com/github.com/test/test/-/commit/7815187511872asbasdfgasd?utm_source=)", body[3].Text)
+	})
+
+	t.Run("truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// Truncating results modifies them, so use copies of the shared mock.
+		newDiffResult := func() *result.CommitMatch {
+			r := diffResultMock
+			preview := *r.DiffPreview
+			r.DiffPreview = &preview
+			return &r
+		}
+		actionCopy.Results = result.Matches{newDiffResult(), newDiffResult(), newDiffResult()}
+
+		body := teamsPayload(actionCopy).Attachments[0].Content.Body
+		require.Equal(t, "...and [1 more matches](https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=).", body[len(body)-1].Text)
+	})
+
+	t.Run("accepted response", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var msg teamsMessage
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+			require.Len(t, msg.Attachments, 1)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer s.Close()
+
+		err := postTeamsWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		require.NoError(t, err)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid card"))
+		}))
+		defer s.Close()
+
+		err := postTeamsWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		var statusErr httpcli.StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, http.StatusBadRequest, statusErr.Code)
+		require.Equal(t, "invalid card", statusErr.Body)
+	})
+}
+
+func TestTriggerTestTeamsWebhookAction(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var msg teamsMessage
+		require.NoError(t, json.Unmarshal(b, &msg))
+		require.Equal(t, "Test message for Code Monitor 'My test monitor'", msg.Attachments[0].Content.Body[0].Text)
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	err := SendTestTeamsWebhook(context.Background(), httpcli.TestExternalDoer, "My test monitor", s.URL)
+	require.NoError(t, err)
+}
@@ -0,0 +1,155 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// TemplatedWebhookData is the data that the body template of a templated
+// webhook action is executed with.
+type TemplatedWebhookData struct {
+	MonitorDescription string
+	MonitorURL         string
+	MonitorOwnerName   string
+	Query              string
+	SearchURL          string
+	ResultCount        int
+	// Results is only set if the action includes results. Like in Slack
+	// notifications, it contains at most 5 results.
+	Results []TemplatedWebhookResult
+}
+
+type TemplatedWebhookResult struct {
+	// Type is "Diff" or "Message" for the results of diff and commit
+	// monitors, and "Content" for the results of content monitors.
+	Type       string
+	Repository string
+	Commit     string
+	CommitURL  string
+	// Path and FileURL are only set for the results of content monitors.
+	Path    string
+	FileURL string
+	// Content is the truncated matched message or diff, or the new matched
+	// lines of a file.
+	Content string
+}
+
+// templatedWebhookFuncs are the functions available to body templates. As
+// most chat services expect a JSON body, json is needed to safely embed
+// values in one.
+var templatedWebhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		// Unlike json.Marshal, don't escape the & in URLs.
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	},
+}
+
+func sendTemplatedWebhookNotification(ctx context.Context, url, body string, args actionArgs) error {
+	return postTemplatedWebhook(ctx, httpcli.ExternalDoer, url, body, templatedWebhookData(args))
+}
+
+func templatedWebhookData(args actionArgs) TemplatedWebhookData {
+	truncatedResults, totalCount, _ := truncateResults(args.Results, 5)
+
+	d := TemplatedWebhookData{
+		MonitorDescription: args.MonitorDescription,
+		MonitorURL:         getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		MonitorOwnerName:   args.MonitorOwnerName,
+		Query:              args.Query,
+		SearchURL:          getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		ResultCount:        totalCount,
+	}
+
+	if args.IncludeResults {
+		d.Results = make([]TemplatedWebhookResult, len(truncatedResults))
+		for i, result := range truncatedResults {
+			md := describeMatch(result, args.ExternalURL, args.UTMSource)
+			r := TemplatedWebhookResult{
+				Type:       md.Type,
+				Repository: string(md.RepoName),
+				Commit:     string(md.CommitID),
+				CommitURL:  getCommitURL(args.ExternalURL, string(md.RepoName), string(md.CommitID), args.UTMSource),
+				Content:    truncateMatchContent(md.Content),
+			}
+			if md.Path != "" {
+				r.Path = md.Path
+				r.FileURL = md.URL
+			}
+			d.Results[i] = r
+		}
+	}
+
+	return d
+}
+
+func renderWebhookTemplate(body string, data TemplatedWebhookData) ([]byte, error) {
+	tmpl, err := template.New("body").Funcs(templatedWebhookFuncs).Parse(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse webhook template")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrap(err, "execute webhook template")
+	}
+	return buf.Bytes(), nil
+}
+
+func postTemplatedWebhook(ctx context.Context, doer httpcli.Doer, url, body string, data TemplatedWebhookData) error {
+	raw, err := renderWebhookTemplate(body, data)
+	if err != nil {
+		return err
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if json.Valid(raw) {
+		contentType = "application/json"
+	}
+	return httpcli.Post(ctx, doer, url, contentType, raw)
+}
+
+func testTemplatedWebhookData(description string) TemplatedWebhookData {
+	return templatedWebhookData(actionArgs{
+		ExternalURL:        &url.URL{},
+		MonitorDescription: description,
+		Query:              "test query",
+	})
+}
+
+// ValidateWebhookTemplate returns an error if body isn't a valid body
+// template for a templated webhook action, including references to fields
+// that don't exist.
+func ValidateWebhookTemplate(body string) error {
+	data := testTemplatedWebhookData("test")
+	// Templates may index into the results, which are only set if the action
+	// includes results.
+	data.ResultCount = 1
+	data.Results = []TemplatedWebhookResult{{
+		Type:       "Content",
+		Repository: "github.com/sourcegraph/sourcegraph",
+		Commit:     "0000000000000000000000000000000000000000",
+		CommitURL:  "/github.com/sourcegraph/sourcegraph/-/commit/0000000000000000000000000000000000000000",
+		Path:       "README.md",
+		FileURL:    "/github.com/sourcegraph/sourcegraph@0000000000000000000000000000000000000000/-/blob/README.md",
+		Content:    "test content",
+	}}
+	_, err := renderWebhookTemplate(body, data)
+	return err
+}
+
+func SendTestTemplatedWebhook(ctx context.Context, doer httpcli.Doer, description, url, body string) error {
+	return postTemplatedWebhook(ctx, doer, url, body, testTemplatedWebhookData(description))
+}
@@ -0,0 +1,104 @@
+package background
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestTemplatedWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: `My "test" monitor`,
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            result.Matches{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	mattermost := `{"text": {{ json (printf "%s detected %d new matches: %s" .MonitorDescription .ResultCount .SearchURL) }}}`
+
+	t.Run("renders body", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.Equal(t, `{"text": "My \"test\" monitor detected 3 new matches: https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source="}`, string(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		err := postTemplatedWebhook(context.Background(), s.Client(), s.URL, mattermost, templatedWebhookData(action))
+		require.NoError(t, err)
+	})
+
+	t.Run("results are only included if enabled", func(t *testing.T) {
+		body := `{{ range .Results }}{{ .Repository }}@{{ .Commit }} {{ end }}`
+
+		rendered, err := renderWebhookTemplate(body, templatedWebhookData(action))
+		require.NoError(t, err)
+		require.Empty(t, rendered)
+
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		rendered, err = renderWebhookTemplate(body, templatedWebhookData(actionCopy))
+		require.NoError(t, err)
+		require.Equal(t, "github.com/test/test@7815187511872asbasdfgasd github.com/test/test@7815187511872asbasdfgasd ", string(rendered))
+	})
+
+	t.Run("plain text body", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "text/plain; charset=utf-8", r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer s.Close()
+
+		err := postTemplatedWebhook(context.Background(), s.Client(), s.URL, "{{ .MonitorDescription }}", templatedWebhookData(action))
+		require.NoError(t, err)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(500)
+		}))
+		defer s.Close()
+
+		err := postTemplatedWebhook(context.Background(), s.Client(), s.URL, mattermost, templatedWebhookData(action))
+		var statusErr httpcli.StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, 500, statusErr.Code)
+	})
+}
+
+func TestTriggerTestTemplatedWebhookAction(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"text": "My test monitor: test query"}`, string(b))
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	err := SendTestTemplatedWebhook(context.Background(), httpcli.TestExternalDoer, "My test monitor", s.URL, `{"text": "{{ .MonitorDescription }}: {{ .Query }}"}`)
+	require.NoError(t, err)
+}
+
+func TestValidateWebhookTemplate(t *testing.T) {
+	require.NoError(t, ValidateWebhookTemplate(`{"text": {{ json .MonitorDescription }}}`))
+	require.NoError(t, ValidateWebhookTemplate(`{{ (index .Results 0).Repository }}`))
+	require.Error(t, ValidateWebhookTemplate(`{{ .MonitorDescription `))
+	require.Error(t, ValidateWebhookTemplate(`{{ .Description }}`))
+}
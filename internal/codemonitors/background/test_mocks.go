@@ -67,3 +67,21 @@ var longCommitResultMock = result.CommitMatch{
 		}},
 	},
 }
+
+var contentResultMock = result.FileMatch{
+	File: result.File{
+		Repo: types.MinimalRepo{
+			Name: api.RepoName("github.com/test/test"),
+		},
+		CommitID: api.CommitID("7815187511872asbasdfgasd"),
+		Path:     "config/secrets.go",
+	},
+	ChunkMatches: result.ChunkMatches{{
+		Content:      "\ttoken := \"secret\"",
+		ContentStart: result.Location{Offset: 20, Line: 2},
+		Ranges: result.Ranges{{
+			Start: result.Location{Offset: 21, Line: 2, Column: 1},
+			End:   result.Location{Offset: 26, Line: 2, Column: 6},
+		}},
+	}},
+}
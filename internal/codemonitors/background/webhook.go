@@ -1,16 +1,11 @@
 package background
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
-	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
@@ -18,33 +13,7 @@ func sendWebhookNotification(ctx context.Context, url string, args actionArgs) e
 }
 
 func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
-	raw, err := json.Marshal(payload)
-	if err != nil {
-		return errors.Wrap(err, "marshal failed")
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
-	if err != nil {
-		return errors.Wrap(err, "failed new request")
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := doer.Do(req)
-	if err != nil {
-		return errors.Wrap(err, "failed to post webhook")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return StatusCodeError{
-			Code:   resp.StatusCode,
-			Status: resp.Status,
-			Body:   string(body),
-		}
-	}
-
-	return nil
+	return httpcli.PostJSON(ctx, doer, url, payload)
 }
 
 func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string) error {
@@ -84,26 +53,66 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+	// Path and Lines are only set for the results of content monitors.
+	Path  string        `json:"path,omitempty"`
+	Lines []webhookLine `json:"lines,omitempty"`
+}
+
+// webhookLine is a new matched line of a file.
+type webhookLine struct {
+	// LineNumber is 1-based.
+	LineNumber int    `json:"lineNumber"`
+	Content    string `json:"content"`
+	// MatchedRanges are byte offsets into Content.
+	MatchedRanges [][2]int `json:"matchedRanges"`
 }
 
-func generateResults(in []*result.CommitMatch) []webhookResult {
+func generateResults(in result.Matches) []webhookResult {
 	out := make([]webhookResult, len(in))
-	for i, match := range in {
-		res := webhookResult{
-			Repository: string(match.Repo.Name),
-			Commit:     string(match.Commit.ID),
+	for i, m := range in {
+		switch match := m.(type) {
+		case *result.CommitMatch:
+			res := webhookResult{
+				Repository: string(match.Repo.Name),
+				Commit:     string(match.Commit.ID),
+			}
+			if match.MessagePreview != nil {
+				res.Message = match.MessagePreview.Content
+				res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
+			}
+			if match.DiffPreview != nil {
+				res.Diff = match.DiffPreview.Content
+				res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
+			}
+			out[i] = res
+		case *result.FileMatch:
+			out[i] = webhookResult{
+				Repository: string(match.Repo.Name),
+				Commit:     string(match.CommitID),
+				Path:       match.Path,
+				Lines:      chunksToLines(match.ChunkMatches),
+			}
 		}
-		if match.MessagePreview != nil {
-			res.Message = match.MessagePreview.Content
-			res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
+	}
+	return out
+}
+
+// chunksToLines converts the chunk matches of content monitors, which have a
+// single line each, to webhook lines.
+func chunksToLines(chunks result.ChunkMatches) []webhookLine {
+	lines := make([]webhookLine, len(chunks))
+	for i, chunk := range chunks {
+		ranges := make([][2]int, len(chunk.Ranges))
+		for j, r := range chunk.Ranges {
+			ranges[j] = [2]int{r.Start.Offset - chunk.ContentStart.Offset, r.End.Offset - chunk.ContentStart.Offset}
 		}
-		if match.DiffPreview != nil {
-			res.Diff = match.DiffPreview.Content
-			res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
+		lines[i] = webhookLine{
+			LineNumber:    chunk.ContentStart.Line + 1,
+			Content:       chunk.Content,
+			MatchedRanges: ranges,
 		}
-		out[i] = res
 	}
-	return out
+	return lines
 }
 
 func rangesToInts(ranges result.Ranges) [][2]int {
@@ -25,7 +25,7 @@ func TestWebhook(t *testing.T) {
 		ExternalURL:        eu,
 		MonitorID:          42,
 		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
-		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		Results:            result.Matches{&diffResultMock, &commitResultMock},
 		IncludeResults:     false,
 	}
 
@@ -68,6 +68,21 @@ func TestWebhook(t *testing.T) {
 	})
 }
 
+func TestGenerateResults_Content(t *testing.T) {
+	got := generateResults(result.Matches{&contentResultMock})
+	want := []webhookResult{{
+		Repository: "github.com/test/test",
+		Commit:     "7815187511872asbasdfgasd",
+		Path:       "config/secrets.go",
+		Lines: []webhookLine{{
+			LineNumber:    3,
+			Content:       "\ttoken := \"secret\"",
+			MatchedRanges: [][2]int{{1, 6}},
+		}},
+	}}
+	require.Equal(t, want, got)
+}
+
 func TestTriggerTestWebhookAction(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
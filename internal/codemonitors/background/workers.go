@@ -2,7 +2,6 @@ package background
 
 import (
 	"context"
-	"fmt"
 	"net/url"
 	"time"
 
@@ -184,8 +183,9 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	results, searchErr := codemonitors.Search(ctx, logger, r.db, q.QueryString, m.ID, triggerJob.ID)
 
 	// Log next_run and latest_result to table cm_queries.
-	newLatestResult := latestResultTime(q.LatestResult, results, searchErr)
-	err = cm.SetQueryTriggerNextRun(ctx, q.ID, cm.Clock()().Add(conf.CodeMonitors().PollInterval), newLatestResult.UTC())
+	now := cm.Clock()()
+	newLatestResult := latestResultTime(q.LatestResult, results, searchErr, now)
+	err = cm.SetQueryTriggerNextRun(ctx, q.ID, now.Add(conf.CodeMonitors().PollInterval), newLatestResult.UTC())
 	if err != nil {
 		return err
 	}
@@ -223,8 +223,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *databas
 		return errors.Wrap(r.handleWebhook(ctx, j), "Webhook")
 	case j.SlackWebhook != nil:
 		return errors.Wrap(r.handleSlackWebhook(ctx, j), "SlackWebhook")
+	case j.TeamsWebhook != nil:
+		return errors.Wrap(r.handleTeamsWebhook(ctx, j), "TeamsWebhook")
+	case j.TemplatedWebhook != nil:
+		return errors.Wrap(r.handleTemplatedWebhook(ctx, j), "TemplatedWebhook")
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or templated webhook")
 	}
 }
 
@@ -245,6 +249,11 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *database.ActionJob) e
 		return errors.Wrap(err, "GetEmailAction")
 	}
 
+	if e.Digest != "" {
+		err = sendEmailDigest(ctx, database.NewDBWith(log.Scoped("sendEmailDigest"), s), j.ID, e.Digest)
+		return err
+	}
+
 	recs, err := s.ListRecipients(ctx, database.ListRecipientsOpts{EmailID: j.Email})
 	if err != nil {
 		return errors.Wrap(err, "ListRecipients")
@@ -270,15 +279,12 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *database.ActionJob) e
 	if err != nil {
 		return errors.Wrap(err, "NewTemplateDataForNewSearchResults")
 	}
-	for _, rec := range recs {
-		if rec.NamespaceOrgID != nil {
-			// TODO (stefan): Send emails to org members.
-			continue
-		}
-		if rec.NamespaceUserID == nil {
-			return errors.New("nil recipient")
-		}
-		err = SendEmailForNewSearchResult(ctx, database.NewDBWith(log.Scoped("handleEmail"), r.CodeMonitorStore), *rec.NamespaceUserID, data)
+	userIDs, err := recipientUserIDs(ctx, database.NewDBWith(log.Scoped("handleEmail"), s), recs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		err = SendEmailForNewSearchResult(ctx, database.NewDBWith(log.Scoped("handleEmail"), r.CodeMonitorStore), userID, data)
 		if err != nil {
 			return err
 		}
@@ -339,6 +345,11 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *database.Actio
 		return errors.Wrap(err, "GetSlackWebhookAction")
 	}
 
+	if w.Digest != "" {
+		err = sendSlackDigest(ctx, s, j.ID, w)
+		return err
+	}
+
 	externalURL, err := url.Parse(conf.Get().ExternalURL)
 	if err != nil {
 		return err
@@ -358,28 +369,94 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *database.Actio
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
-type StatusCodeError struct {
-	Code   int
-	Status string
-	Body   string
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
 }
 
-func (s StatusCodeError) Error() string {
-	return fmt.Sprintf("non-200 response %d %s with body %q", s.Code, s.Status, s.Body)
+func (r *actionRunner) handleTemplatedWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTemplatedWebhookAction(ctx, *j.TemplatedWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTemplatedWebhookAction")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-templated-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTemplatedWebhookNotification(ctx, w.URL, w.Body, args)
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
+// latestResultTime returns the time of the latest result of a code monitor
+// query after a run at now.
+func latestResultTime(previousLastResult *time.Time, results result.Matches, searchErr error, now time.Time) time.Time {
 	if searchErr != nil || len(results) == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
 		if previousLastResult != nil {
 			return *previousLastResult
 		}
-		return time.Now()
+		return now
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	if cm, ok := results[0].(*result.CommitMatch); ok && cm.Commit.Committer != nil {
+		return cm.Commit.Committer.Date
 	}
-	return time.Now()
+	// The results of content monitors are the lines that match as of this
+	// run, so they have no time of their own.
+	return now
 }
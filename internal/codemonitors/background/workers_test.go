@@ -28,19 +28,19 @@ func TestActionRunner(t *testing.T) {
 	logger := logtest.Scoped(t)
 	tests := []struct {
 		name           string
-		results        []*result.CommitMatch
+		results        result.Matches
 		wantNumResults int
 		wantResults    []*DisplayResult
 	}{
 		{
 			name:           "9 results",
-			results:        []*result.CommitMatch{&diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock},
+			results:        result.Matches{&diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock},
 			wantNumResults: 9,
 			wantResults:    []*DisplayResult{diffDisplayResultMock, commitDisplayResultMock, diffDisplayResultMock},
 		},
 		{
 			name:           "1 result",
-			results:        []*result.CommitMatch{&commitResultMock},
+			results:        result.Matches{&commitResultMock},
 			wantNumResults: 1,
 			wantResults:    []*DisplayResult{commitDisplayResultMock},
 		},
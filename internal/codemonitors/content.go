@@ -0,0 +1,302 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// A content monitor is a code monitor whose query searches file contents
+// rather than diffs or commits. Since file contents have no history to
+// exclude, each run searches the repos at their current commits and compares
+// the set of matched lines to the one recorded by the previous run. Only lines
+// that were not matched before are reported.
+
+// maxContentResults is the largest count: a content monitor may use. Unlike
+// diff and commit monitors, which only search new commits, content monitors
+// search all files of their repos on every run.
+const maxContentResults = 1000
+
+var ErrUnscopedContentMonitor = errors.New("code monitors that search file contents must be limited to repositories with a repo: filter or a search context")
+
+// validateContentQuery makes sure that a content monitor's search stays
+// bounded: it must be scoped to some repos, and must not ask for more than
+// maxContentResults results.
+func validateContentQuery(plan query.Plan) error {
+	for _, b := range plan {
+		repos, _ := b.Repositories()
+		searchContext, _ := b.ToParseTree().StringValue(query.FieldContext)
+		if len(repos) == 0 && searchcontexts.IsGlobalSearchContextSpec(searchContext) {
+			return ErrUnscopedContentMonitor
+		}
+		if count := b.Count(); count != nil && *count > maxContentResults {
+			return errors.Errorf("code monitors that search file contents can find at most %d results, but the query contains count:%d", maxContentResults, *count)
+		}
+	}
+	return nil
+}
+
+// searchContent runs a content monitor's search and returns its new matches.
+// New matches are file matches that only contain the lines that weren't
+// matched by the previous run.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) (result.Matches, error) {
+	cm := db.CodeMonitors()
+
+	previous, err := cm.ListLastSearchedContentMatches(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := planJob.Run(ctx, clients, agg); err != nil {
+		return nil, err
+	}
+	// When the result limit is hit we don't know about every match, so we must
+	// not forget the ones we didn't see. Otherwise they would be reported as new
+	// once they show up again.
+	limitHit := agg.Stats.IsLimitHit
+
+	current := groupContentMatches(agg.Results)
+
+	var files []*result.FileMatch
+	for repoID, matches := range current {
+		previousKeys := previous[repoID]
+		seen := make(map[string]struct{}, len(previousKeys))
+		for _, key := range previousKeys {
+			seen[key] = struct{}{}
+		}
+		// Repos that weren't searched before are new to the monitor, so all of
+		// their matches are new.
+		files = append(files, matches.newMatches(seen)...)
+
+		keys := matches.keys()
+		if limitHit {
+			keys = mergeKeys(keys, previousKeys)
+		}
+		if err := cm.UpsertLastSearchedContentMatches(ctx, monitorID, repoID, matches.commitOIDs, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	if !limitHit {
+		// Repos without matches anymore start over, so that a match that
+		// reappears is reported again.
+		for repoID, keys := range previous {
+			if _, ok := current[repoID]; ok || len(keys) == 0 {
+				continue
+			}
+			if err := cm.UpsertLastSearchedContentMatches(ctx, monitorID, repoID, nil, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Repo.Name < files[j].Repo.Name
+	})
+	results := make(result.Matches, 0, len(files))
+	for _, fm := range files {
+		results = append(results, fm)
+	}
+	return results, nil
+}
+
+// snapshotContent runs a content monitor's search and returns the current
+// matches of every repo, without reporting any of them.
+func snapshotContent(ctx context.Context, clients job.RuntimeClients, planJob job.Job) (map[api.RepoID]RepoSnapshot, error) {
+	agg := streaming.NewAggregatingStream()
+	if _, err := planJob.Run(ctx, clients, agg); err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[api.RepoID]RepoSnapshot)
+	for repoID, matches := range groupContentMatches(agg.Results) {
+		snapshots[repoID] = RepoSnapshot{
+			CommitOIDs:       matches.commitOIDs,
+			ContentMatchKeys: matches.keys(),
+			content:          true,
+		}
+	}
+	return snapshots, nil
+}
+
+// repoContentMatches are the file content matches of a single repo. The
+// chunk matches of files are split into one chunk per matched line.
+type repoContentMatches struct {
+	repo       types.MinimalRepo
+	commitOIDs []string
+	files      []*result.FileMatch
+}
+
+// groupContentMatches groups the file content matches of a search by repo.
+// Other results, like path and repo matches, are ignored.
+func groupContentMatches(matches result.Matches) map[api.RepoID]*repoContentMatches {
+	byRepo := make(map[api.RepoID]*repoContentMatches)
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		lines := matchedLines(fm.ChunkMatches)
+		if len(lines) == 0 {
+			continue
+		}
+
+		rm, ok := byRepo[fm.Repo.ID]
+		if !ok {
+			rm = &repoContentMatches{repo: fm.Repo}
+			byRepo[fm.Repo.ID] = rm
+		}
+		if commitOID := string(fm.CommitID); !slices.Contains(rm.commitOIDs, commitOID) {
+			rm.commitOIDs = append(rm.commitOIDs, commitOID)
+		}
+		rm.files = append(rm.files, &result.FileMatch{File: fm.File, ChunkMatches: lines})
+	}
+	return byRepo
+}
+
+// keys returns the sorted keys of all matched lines.
+func (m *repoContentMatches) keys() []string {
+	set := make(map[string]struct{})
+	for _, fm := range m.files {
+		for _, line := range fm.ChunkMatches {
+			set[contentMatchKey(fm.Path, line.Content)] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newMatches returns one file match per searched commit and path that has
+// lines whose key isn't in seen. The file matches only contain those lines.
+func (m *repoContentMatches) newMatches(seen map[string]struct{}) []*result.FileMatch {
+	type fileAtCommit struct {
+		commitID api.CommitID
+		path     string
+	}
+	byFile := make(map[fileAtCommit]*result.FileMatch)
+	var files []*result.FileMatch
+	reported := make(map[string]struct{})
+	for _, fm := range m.files {
+		for _, line := range fm.ChunkMatches {
+			// The same commit may be reached through several revisions, and
+			// the same line may be matched several times in a file. Report
+			// each line once.
+			key := contentMatchKey(fm.Path, line.Content)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			if _, ok := reported[string(fm.CommitID)+key]; ok {
+				continue
+			}
+			reported[string(fm.CommitID)+key] = struct{}{}
+
+			f, ok := byFile[fileAtCommit{fm.CommitID, fm.Path}]
+			if !ok {
+				f = &result.FileMatch{File: fm.File}
+				byFile[fileAtCommit{fm.CommitID, fm.Path}] = f
+				files = append(files, f)
+			}
+			f.ChunkMatches = append(f.ChunkMatches, line)
+		}
+	}
+
+	for _, f := range files {
+		sort.SliceStable(f.ChunkMatches, func(i, j int) bool {
+			return f.ChunkMatches[i].ContentStart.Line < f.ChunkMatches[j].ContentStart.Line
+		})
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].CommitID != files[j].CommitID {
+			return files[i].CommitID < files[j].CommitID
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// matchedLines splits chunk matches into one chunk per line that has a match,
+// so that the lines can be compared to the ones of previous runs one by one.
+// Ranges that span several lines are split, too.
+func matchedLines(chunks result.ChunkMatches) result.ChunkMatches {
+	var lines result.ChunkMatches
+	for _, chunk := range chunks {
+		offset := chunk.ContentStart.Offset
+		for i, content := range strings.Split(strings.TrimSuffix(chunk.Content, "\n"), "\n") {
+			lineNumber := chunk.ContentStart.Line + i
+			lineStart := result.Location{Offset: offset, Line: lineNumber}
+			lineEnd := result.Location{Offset: offset + len(content), Line: lineNumber, Column: utf8.RuneCountInString(content)}
+			offset += len(content) + 1
+
+			var ranges result.Ranges
+			for _, r := range chunk.Ranges {
+				if r.Start.Line > lineNumber || r.End.Line < lineNumber {
+					continue
+				}
+				start, end := r.Start, r.End
+				if start.Line < lineNumber {
+					start = lineStart
+				}
+				if end.Line > lineNumber {
+					end = lineEnd
+				}
+				if start != end {
+					ranges = append(ranges, result.Range{Start: start, End: end})
+				}
+			}
+			if len(ranges) > 0 {
+				lines = append(lines, result.ChunkMatch{
+					Content:      content,
+					ContentStart: lineStart,
+					Ranges:       ranges,
+				})
+			}
+		}
+	}
+	return lines
+}
+
+// contentMatchKey identifies a matched line by its path and content rather
+// than its line number, so that a match isn't considered new just because
+// lines were added above it. Keys are hashed to bound their size.
+func contentMatchKey(path, line string) string {
+	h := sha256.Sum256([]byte(path + "\x00" + line))
+	return hex.EncodeToString(h[:16])
+}
+
+// mergeKeys returns the sorted union of a and b.
+func mergeKeys(a, b []string) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for _, key := range a {
+		set[key] = struct{}{}
+	}
+	for _, key := range b {
+		set[key] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
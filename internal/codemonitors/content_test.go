@@ -0,0 +1,139 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestContentMatches(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	fileMatch := func(commitID api.CommitID, path string, chunks ...result.ChunkMatch) *result.FileMatch {
+		return &result.FileMatch{
+			File:         result.File{Repo: repo, CommitID: commitID, Path: path},
+			ChunkMatches: chunks,
+		}
+	}
+	chunk := func(line int, content string, start, end int) result.ChunkMatch {
+		return result.ChunkMatch{
+			Content:      content,
+			ContentStart: result.Location{Line: line},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: line, Column: start},
+				End:   result.Location{Line: line, Column: end},
+			}},
+		}
+	}
+
+	matches := result.Matches{
+		fileMatch("abc", "main.go", chunk(2, "	token := \"secret\"", 1, 6), chunk(9, "	// token", 4, 9)),
+		fileMatch("abc", "docs/é token.md", chunk(0, "é token", 2, 7)),
+		// Path and repo matches are ignored.
+		fileMatch("abc", "token.txt"),
+		&result.RepoMatch{Name: "github.com/sourcegraph/other", ID: 2},
+	}
+
+	grouped := groupContentMatches(matches)
+	require.Len(t, grouped, 1)
+	repoMatches := grouped[1]
+	require.Equal(t, []string{"abc"}, repoMatches.commitOIDs)
+	keys := repoMatches.keys()
+	require.Len(t, keys, 3)
+
+	t.Run("all matches are new without previous keys", func(t *testing.T) {
+		newMatches := repoMatches.newMatches(nil)
+		require.Len(t, newMatches, 2)
+		for _, fm := range newMatches {
+			require.Equal(t, repo, fm.Repo)
+			require.Equal(t, api.CommitID("abc"), fm.CommitID)
+		}
+
+		require.Equal(t, "docs/é token.md", newMatches[0].Path)
+		require.Equal(t, result.ChunkMatches{chunk(0, "é token", 2, 7)}, newMatches[0].ChunkMatches)
+		require.Equal(t, "main.go", newMatches[1].Path)
+		require.Equal(t, result.ChunkMatches{
+			chunk(2, "	token := \"secret\"", 1, 6),
+			chunk(9, "	// token", 4, 9),
+		}, newMatches[1].ChunkMatches)
+	})
+
+	t.Run("only matches that weren't seen before are new", func(t *testing.T) {
+		seen := map[string]struct{}{
+			contentMatchKey("main.go", "	token := \"secret\""): {},
+			contentMatchKey("docs/é token.md", "é token"):      {},
+		}
+		newMatches := repoMatches.newMatches(seen)
+		require.Len(t, newMatches, 1)
+		require.Equal(t, "main.go", newMatches[0].Path)
+		require.Equal(t, result.ChunkMatches{chunk(9, "	// token", 4, 9)}, newMatches[0].ChunkMatches)
+	})
+
+	t.Run("no new matches", func(t *testing.T) {
+		seen := make(map[string]struct{})
+		for _, key := range keys {
+			seen[key] = struct{}{}
+		}
+		require.Empty(t, repoMatches.newMatches(seen))
+	})
+
+	t.Run("moved lines are not new", func(t *testing.T) {
+		moved := groupContentMatches(result.Matches{
+			fileMatch("def", "main.go", chunk(20, "	token := \"secret\"", 1, 6)),
+		})[1]
+		seen := make(map[string]struct{})
+		for _, key := range keys {
+			seen[key] = struct{}{}
+		}
+		require.Empty(t, moved.newMatches(seen))
+	})
+}
+
+func TestMatchedLines(t *testing.T) {
+	// A chunk of three lines at offset 100, with a match that spans the first
+	// two lines and a match on the last line. The line in between has no match
+	// of its own.
+	chunk := result.ChunkMatch{
+		Content:      "foo bar\nbaz\nqux foo\n",
+		ContentStart: result.Location{Offset: 100, Line: 4},
+		Ranges: result.Ranges{{
+			Start: result.Location{Offset: 104, Line: 4, Column: 4},
+			End:   result.Location{Offset: 111, Line: 5, Column: 3},
+		}, {
+			Start: result.Location{Offset: 116, Line: 6, Column: 4},
+			End:   result.Location{Offset: 119, Line: 6, Column: 7},
+		}},
+	}
+
+	want := result.ChunkMatches{{
+		Content:      "foo bar",
+		ContentStart: result.Location{Offset: 100, Line: 4},
+		Ranges: result.Ranges{{
+			Start: result.Location{Offset: 104, Line: 4, Column: 4},
+			End:   result.Location{Offset: 107, Line: 4, Column: 7},
+		}},
+	}, {
+		Content:      "baz",
+		ContentStart: result.Location{Offset: 108, Line: 5},
+		Ranges: result.Ranges{{
+			Start: result.Location{Offset: 108, Line: 5},
+			End:   result.Location{Offset: 111, Line: 5, Column: 3},
+		}},
+	}, {
+		Content:      "qux foo",
+		ContentStart: result.Location{Offset: 112, Line: 6},
+		Ranges: result.Ranges{{
+			Start: result.Location{Offset: 116, Line: 6, Column: 4},
+			End:   result.Location{Offset: 119, Line: 6, Column: 7},
+		}},
+	}}
+	require.Equal(t, want, matchedLines(result.ChunkMatches{chunk}))
+}
+
+func TestMergeKeys(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, mergeKeys([]string{"c", "a"}, []string{"b", "a"}))
+	require.Empty(t, mergeKeys(nil, nil))
+}
@@ -25,7 +25,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, triggerID int32) (_ []*result.CommitMatch, err error) {
+// Search runs the search of a code monitor and returns its new results. These
+// are commit matches for diff and commit monitors, and file matches for content
+// monitors.
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, triggerID int32) (_ result.Matches, err error) {
 	searchClient := client.New(logger, db, gitserver.NewClient("monitors.search"))
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -47,6 +50,13 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isContentQuery(planJob) {
+		if err := validateContentQuery(inputs.Plan); err != nil {
+			return nil, errcode.MakeNonRetryable(err)
+		}
+		return searchContent(ctx, db, clients, planJob, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 		return hookWithID(ctx, logger, db, gs, monitorID, triggerID, repoID, args, doSearch)
 	}
@@ -62,22 +72,39 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, err
 	}
 
-	results := make([]*result.CommitMatch, len(agg.Results))
-	for i, res := range agg.Results {
-		cm, ok := res.(*result.CommitMatch)
-		if !ok {
+	for _, res := range agg.Results {
+		if _, ok := res.(*result.CommitMatch); !ok {
 			return nil, errors.Errorf("expected search to only return commit matches, but got type %T", res)
 		}
-		results[i] = cm
 	}
 
-	return results, nil
+	return agg.Results, nil
+}
+
+// RepoSnapshot is the state of a searched repo, as recorded by Snapshot.
+type RepoSnapshot struct {
+	CommitOIDs []string
+
+	// ContentMatchKeys identify the matches found at CommitOIDs. They are only
+	// recorded for content monitors.
+	ContentMatchKeys []string
+	content          bool
+}
+
+// Save stores the snapshot as the last searched state of the repo for the
+// given monitor.
+func (s RepoSnapshot) Save(ctx context.Context, cm database.CodeMonitorStore, monitorID int64, repoID api.RepoID) error {
+	if s.content {
+		return cm.UpsertLastSearchedContentMatches(ctx, monitorID, repoID, s.CommitOIDs, s.ContentMatchKeys)
+	}
+	return cm.UpsertLastSearched(ctx, monitorID, repoID, s.CommitOIDs)
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
-func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string) (map[api.RepoID][]string, error) {
+// be searched from the beginning. For content monitors, it records the current matches so that only
+// matches found later are reported.
+func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string) (map[api.RepoID]RepoSnapshot, error) {
 	if db.Handle().InTransaction() {
 		return nil, errors.New("Snapshot cannot be run in a transaction")
 	}
@@ -102,6 +129,13 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return nil, err
 	}
 
+	if isContentQuery(planJob) {
+		if err := validateContentQuery(inputs.Plan); err != nil {
+			return nil, err
+		}
+		return snapshotContent(ctx, clients, planJob)
+	}
+
 	var (
 		mu                sync.Mutex
 		resolvedRevisions = make(map[api.RepoID][]string)
@@ -142,7 +176,17 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return nil, err
 	}
 
-	return resolvedRevisions, nil
+	snapshots := make(map[api.RepoID]RepoSnapshot, len(resolvedRevisions))
+	for repoID, commitOIDs := range resolvedRevisions {
+		snapshots[repoID] = RepoSnapshot{CommitOIDs: commitOIDs}
+	}
+	return snapshots, nil
+}
+
+// isContentQuery returns true if the query searches file contents rather than
+// diffs or commits.
+func isContentQuery(planJob job.Job) bool {
+	return !job.HasDescendent[*commit.SearchJob](planJob)
 }
 
 var ErrInvalidMonitorQuery = errors.New("code monitor cannot use different patterns for different repos")
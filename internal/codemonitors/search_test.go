@@ -110,6 +110,60 @@ func TestAddCodeMonitorHook(t *testing.T) {
 	})
 }
 
+func TestIsContentQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"foo":                  true,
+		"type:file foo repo:c": true,
+		"foo or bar":           true,
+		"type:diff foo":        false,
+		"type:commit foo":      false,
+		"type:diff a or b":     false,
+	}
+	for input, want := range cases {
+		t.Run(input, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(input))
+			require.NoError(t, err)
+			inputs := &search.Inputs{
+				UserSettings: &schema.Settings{},
+				PatternType:  query.SearchTypeLiteral,
+				Protocol:     search.Streaming,
+				Features:     &search.Features{},
+			}
+			j, err := jobutil.NewPlanJob(inputs, plan)
+			require.NoError(t, err)
+			require.Equal(t, want, isContentQuery(j))
+		})
+	}
+}
+
+func TestValidateContentQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"repo:a foo":                   "",
+		"context:@user/repos foo":      "",
+		"repo:a foo count:1000":        "",
+		"foo":                          ErrUnscopedContentMonitor.Error(),
+		"context:global foo":           ErrUnscopedContentMonitor.Error(),
+		"repo:a foo count:1001":        "code monitors that search file contents can find at most 1000 results, but the query contains count:1001",
+		"(repo:a foo) or (repo:b bar)": "",
+	}
+	for input, want := range cases {
+		t.Run(input, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(input))
+			require.NoError(t, err)
+			err = validateContentQuery(plan)
+			if want == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, want)
+			}
+		})
+	}
+}
+
 func TestCodeMonitorHook(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +93,8 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			}
 		}
 		return []string{sb.String()}
+	case *result.CommitDiffHunkMatch:
+		return []string{m.Preview.Content}
 	case *result.CommitMatch:
 		var content string
 		if m.DiffPreview != nil {
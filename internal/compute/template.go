@@ -319,6 +319,25 @@ func NewMetaEnvironment(r searchresult.Match, content string) *MetaEnvironment {
 			Lang:    lang,
 			Content: content,
 		}
+	case *searchresult.CommitDiffHunkMatch:
+		path := m.Path()
+		langs, _ := languages.GetLanguages(path, func() ([]byte, error) {
+			return []byte(content), nil
+		})
+		lang := ""
+		if len(langs) > 0 {
+			lang = langs[0]
+		}
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Commit:  string(m.Commit.ID),
+			Author:  m.Commit.Author.Name,
+			Date:    m.Commit.Committer.Date,
+			Email:   m.Commit.Author.Email,
+			Path:    path,
+			Lang:    lang,
+			Content: content,
+		}
 	case *searchresult.OwnerMatch:
 		return &MetaEnvironment{
 			Repo:    string(m.Repo.Name),
@@ -16,6 +16,8 @@ func AuthProviderType(p schema.AuthProviders) string {
 		return p.Saml.Type
 	case p.HttpHeader != nil:
 		return p.HttpHeader.Type
+	case p.Ldap != nil:
+		return p.Ldap.Type
 	case p.Github != nil:
 		return p.Github.Type
 	case p.Gitlab != nil:
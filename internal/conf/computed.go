@@ -725,6 +725,19 @@ func AuthLockout() *schema.AuthLockout {
 	return val
 }
 
+// AuthSecondFactor populates and returns the *schema.AuthSecondFactor with
+// default values for fields that are not initialized.
+func AuthSecondFactor() *schema.AuthSecondFactor {
+	val := schema.AuthSecondFactor{}
+	if c := Get().AuthSecondFactor; c != nil {
+		val = *c
+	}
+	if val.Issuer == "" {
+		val.Issuer = ExternalURLParsed().Hostname()
+	}
+	return &val
+}
+
 const defaultGitLongCommandTimeout = 2 * time.Hour
 
 // GitLongCommandTimeout returns the maximum amount of time in seconds that a
@@ -204,6 +204,45 @@ func TestAuthLockout(t *testing.T) {
 	}
 }
 
+func TestAuthSecondFactor(t *testing.T) {
+	defer Mock(nil)
+
+	tests := []struct {
+		name string
+		mock *schema.AuthSecondFactor
+		want *schema.AuthSecondFactor
+	}{
+		{
+			name: "missing entire config",
+			mock: nil,
+			want: &schema.AuthSecondFactor{Issuer: "sourcegraph.example.com"},
+		},
+		{
+			name: "missing issuer",
+			mock: &schema.AuthSecondFactor{Required: true},
+			want: &schema.AuthSecondFactor{Issuer: "sourcegraph.example.com", Required: true},
+		},
+		{
+			name: "custom issuer",
+			mock: &schema.AuthSecondFactor{Issuer: "Acme Code Search"},
+			want: &schema.AuthSecondFactor{Issuer: "Acme Code Search"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Mock(&Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					ExternalURL:      "https://sourcegraph.example.com",
+					AuthSecondFactor: test.mock,
+				},
+			})
+
+			got := AuthSecondFactor()
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
 func TestIsAccessRequestEnabled(t *testing.T) {
 	falseVal, trueVal := false, true
 	tests := []struct {
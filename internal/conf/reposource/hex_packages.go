@@ -0,0 +1,100 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const hexPackagesPrefix = "hex/"
+
+// hexPackageNameRegex matches valid Hex package names, see
+// https://github.com/hexpm/hexpm/blob/main/lib/hexpm/repository/package.ex
+var hexPackageNameRegex = lazyregexp.New(`^[a-z]\w*$`)
+
+// HexVersionedPackage is a Hex package (an Elixir or Erlang library) at an
+// optional version.
+type HexVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewHexVersionedPackage(name PackageName, version string) *HexVersionedPackage {
+	return &HexVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseHexVersionedPackage parses a string in a '<name>(@<version>)?' format
+// into a HexVersionedPackage.
+func ParseHexVersionedPackage(dependency string) (*HexVersionedPackage, error) {
+	name, version := dependency, ""
+	if i := strings.Index(dependency, "@"); i != -1 {
+		name = strings.TrimSpace(dependency[:i])
+		version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	if !hexPackageNameRegex.MatchString(name) {
+		return nil, errors.Newf("invalid Hex package name %q", name)
+	}
+	if strings.ContainsAny(version, "/\\ ") {
+		return nil, errors.Newf("invalid Hex package version %q", version)
+	}
+	return NewHexVersionedPackage(PackageName(name), version), nil
+}
+
+func ParseHexPackageFromName(name PackageName) (*HexVersionedPackage, error) {
+	return ParseHexVersionedPackage(string(name))
+}
+
+// ParseHexPackageFromRepoName is a convenience function to parse a repo name
+// in a 'hex/<name>(@<version>)?' format into a HexVersionedPackage.
+func ParseHexPackageFromRepoName(name api.RepoName) (*HexVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), hexPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid Hex dependency repo name, missing %s prefix '%s'", hexPackagesPrefix, name)
+	}
+	return ParseHexVersionedPackage(dependency)
+}
+
+func (p *HexVersionedPackage) Scheme() string {
+	return "hex"
+}
+
+func (p *HexVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *HexVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *HexVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *HexVersionedPackage) Description() string { return "" }
+
+func (p *HexVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(hexPackagesPrefix + p.Name)
+}
+
+func (p *HexVersionedPackage) GitTagFromVersion() string {
+	return "v" + strings.TrimPrefix(p.Version, "v")
+}
+
+func (p *HexVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*HexVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
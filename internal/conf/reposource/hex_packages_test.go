@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseHexVersionedPackage(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		name    PackageName
+		version string
+		wantErr bool
+	}{
+		{in: "phoenix@1.7.10", name: "phoenix", version: "1.7.10"},
+		{in: "phoenix_live_view@0.20.1", name: "phoenix_live_view", version: "0.20.1"},
+		{in: "cowboy@2.10.0-rc.1", name: "cowboy", version: "2.10.0-rc.1"},
+		{in: "jason", name: "jason"},
+		{in: "1password@1.0.0", wantErr: true},
+		{in: "../evil@1.0.0", wantErr: true},
+		{in: "phoenix@../../1.0.0", wantErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			dep, err := ParseHexVersionedPackage(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, dep.Name)
+			assert.Equal(t, tc.version, dep.Version)
+		})
+	}
+}
+
+func TestParseHexPackageFromRepoName(t *testing.T) {
+	dep, err := ParseHexPackageFromRepoName("hex/ecto_sql")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("ecto_sql"), dep.Name)
+	assert.Equal(t, api.RepoName("hex/ecto_sql"), dep.RepoName())
+
+	_, err = ParseHexPackageFromRepoName("npm/left-pad")
+	require.Error(t, err)
+}
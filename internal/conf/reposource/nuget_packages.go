@@ -0,0 +1,143 @@
+package reposource
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const nugetPackagesPrefix = "nuget/"
+
+// nugetPackageIDRegex matches valid NuGet package IDs, see
+// https://learn.microsoft.com/en-us/nuget/reference/nuspec#id
+var nugetPackageIDRegex = lazyregexp.New(`^[a-z0-9_][a-z0-9_.\-]{0,99}$`)
+
+// NuGetVersionedPackage is a NuGet package at an optional version. NuGet
+// package IDs and versions are case-insensitive, so both are kept in lower
+// case, matching the paths of the NuGet package content API. Versions are
+// normalized as well, see NormalizeNuGetVersion.
+type NuGetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewNuGetVersionedPackage(name PackageName, version string) *NuGetVersionedPackage {
+	return &NuGetVersionedPackage{
+		Name:    PackageName(strings.ToLower(string(name))),
+		Version: NormalizeNuGetVersion(version),
+	}
+}
+
+// NormalizeNuGetVersion returns the normalized, lower case form of a NuGet
+// version, which is the one used by the NuGet package content API. For
+// example, 1.0, 1.00.0 and 1.0.0.0 are all normalized to 1.0.0. See
+// https://learn.microsoft.com/en-us/nuget/concepts/package-versioning#normalized-version-numbers
+//
+// Versions that are not valid NuGet versions are only lower cased.
+func NormalizeNuGetVersion(version string) string {
+	version = strings.ToLower(version)
+
+	// Build metadata is not part of the normalized version.
+	v, _, _ := strings.Cut(version, "+")
+	// Release labels are kept as they are.
+	v, label, hasLabel := strings.Cut(v, "-")
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 4 {
+		return version
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return version
+		}
+		parts[i] = strconv.FormatUint(n, 10)
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	if len(parts) == 4 && parts[3] == "0" {
+		parts = parts[:3]
+	}
+
+	normalized := strings.Join(parts, ".")
+	if hasLabel {
+		normalized += "-" + label
+	}
+	return normalized
+}
+
+// ParseNuGetVersionedPackage parses a string in a '<id>(@<version>)?' format
+// into a NuGetVersionedPackage.
+func ParseNuGetVersionedPackage(dependency string) (*NuGetVersionedPackage, error) {
+	name, version := dependency, ""
+	if i := strings.LastIndex(dependency, "@"); i != -1 {
+		name = strings.TrimSpace(dependency[:i])
+		version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	dep := NewNuGetVersionedPackage(PackageName(name), version)
+	if !nugetPackageIDRegex.MatchString(string(dep.Name)) {
+		return nil, errors.Newf("invalid NuGet package id %q", name)
+	}
+	if strings.ContainsAny(dep.Version, "/\\ ") {
+		return nil, errors.Newf("invalid NuGet package version %q", version)
+	}
+	return dep, nil
+}
+
+func ParseNuGetPackageFromName(name PackageName) (*NuGetVersionedPackage, error) {
+	return ParseNuGetVersionedPackage(string(name))
+}
+
+// ParseNuGetPackageFromRepoName is a convenience function to parse a repo name
+// in a 'nuget/<id>(@<version>)?' format into a NuGetVersionedPackage.
+func ParseNuGetPackageFromRepoName(name api.RepoName) (*NuGetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), nugetPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid NuGet dependency repo name, missing %s prefix '%s'", nugetPackagesPrefix, name)
+	}
+	return ParseNuGetVersionedPackage(dependency)
+}
+
+func (p *NuGetVersionedPackage) Scheme() string {
+	return "scip-dotnet"
+}
+
+func (p *NuGetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *NuGetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *NuGetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Description() string { return "" }
+
+func (p *NuGetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(nugetPackagesPrefix + p.Name)
+}
+
+func (p *NuGetVersionedPackage) GitTagFromVersion() string {
+	return "v" + strings.TrimPrefix(p.Version, "v")
+}
+
+func (p *NuGetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*NuGetVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
@@ -0,0 +1,93 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		name    PackageName
+		version string
+		wantErr bool
+	}{
+		{in: "Newtonsoft.Json@13.0.3", name: "newtonsoft.json", version: "13.0.3"},
+		{in: "Serilog.Sinks.Console@5.0.0-Beta.1", name: "serilog.sinks.console", version: "5.0.0-beta.1"},
+		{in: "xunit", name: "xunit"},
+		{in: "Microsoft.Extensions.Logging @ 8.0.0", name: "microsoft.extensions.logging", version: "8.0.0"},
+		{in: "Newtonsoft.Json@13.0", name: "newtonsoft.json", version: "13.0.0"},
+		{in: "Newtonsoft.Json@13.0.3.0", name: "newtonsoft.json", version: "13.0.3"},
+		{in: "../evil@1.0.0", wantErr: true},
+		{in: "@1.0.0", wantErr: true},
+		{in: "Newtonsoft.Json@../../1.0.0", wantErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			dep, err := ParseNuGetVersionedPackage(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, dep.Name)
+			assert.Equal(t, tc.version, dep.Version)
+		})
+	}
+}
+
+func TestNormalizeNuGetVersion(t *testing.T) {
+	for in, want := range map[string]string{
+		"1":                    "1.0.0",
+		"1.0":                  "1.0.0",
+		"1.0.0":                "1.0.0",
+		"1.00.01":              "1.0.1",
+		"1.0.0.0":              "1.0.0",
+		"1.0.0.4":              "1.0.0.4",
+		"1.0.0+build.5":        "1.0.0",
+		"1.0-RC.1":             "1.0.0-rc.1",
+		"1.0.0-beta-2+sha.abc": "1.0.0-beta-2",
+		"":                     "",
+		"1.0.0.0.0":            "1.0.0.0.0",
+		"latest":               "latest",
+	} {
+		assert.Equal(t, want, NormalizeNuGetVersion(in), in)
+	}
+}
+
+func TestParseNuGetPackageFromRepoName(t *testing.T) {
+	dep, err := ParseNuGetPackageFromRepoName("nuget/Newtonsoft.Json")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("newtonsoft.json"), dep.Name)
+	assert.Equal(t, api.RepoName("nuget/newtonsoft.json"), dep.RepoName())
+
+	_, err = ParseNuGetPackageFromRepoName("npm/left-pad")
+	require.Error(t, err)
+}
+
+func TestNuGetVersionedPackage_Less(t *testing.T) {
+	parse := func(s string) *NuGetVersionedPackage {
+		dep, err := ParseNuGetVersionedPackage(s)
+		require.NoError(t, err)
+		return dep
+	}
+	dependencies := []*NuGetVersionedPackage{
+		parse("xunit@2.4.2"),
+		parse("xunit@2.10.0"),
+		parse("moq@4.20.70"),
+		parse("xunit@2.4.10"),
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].Less(dependencies[j])
+	})
+
+	var got []string
+	for _, d := range dependencies {
+		got = append(got, d.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"xunit@2.10.0", "xunit@2.4.10", "xunit@2.4.2", "moq@4.20.70"}, got)
+}
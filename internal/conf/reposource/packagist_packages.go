@@ -0,0 +1,107 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const packagistPackagesPrefix = "packagist/"
+
+// packagistPackageNameRegex matches valid Composer package names, see
+// https://getcomposer.org/doc/04-schema.md#name
+var packagistPackageNameRegex = lazyregexp.New(`^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]|-{1,2})?[a-z0-9]+)*$`)
+
+// PackagistVersionedPackage is a Composer package (a PHP library) published
+// to Packagist or a compatible repository, at an optional version. Package
+// names are in the '<vendor>/<package>' format and case-insensitive, so they
+// are kept in lower case.
+type PackagistVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewPackagistVersionedPackage(name PackageName, version string) *PackagistVersionedPackage {
+	return &PackagistVersionedPackage{
+		Name:    PackageName(strings.ToLower(string(name))),
+		Version: version,
+	}
+}
+
+// ParsePackagistVersionedPackage parses a string in a
+// '<vendor>/<package>(@<version>)?' format into a PackagistVersionedPackage.
+func ParsePackagistVersionedPackage(dependency string) (*PackagistVersionedPackage, error) {
+	name, version := dependency, ""
+	if i := strings.LastIndex(dependency, "@"); i != -1 {
+		name = strings.TrimSpace(dependency[:i])
+		version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	dep := NewPackagistVersionedPackage(PackageName(name), version)
+	if !packagistPackageNameRegex.MatchString(string(dep.Name)) {
+		return nil, errors.Newf("invalid Packagist package name %q", name)
+	}
+	if strings.ContainsAny(dep.Version, "/\\ ") {
+		return nil, errors.Newf("invalid Packagist package version %q", version)
+	}
+	return dep, nil
+}
+
+func ParsePackagistPackageFromName(name PackageName) (*PackagistVersionedPackage, error) {
+	return ParsePackagistVersionedPackage(string(name))
+}
+
+// ParsePackagistPackageFromRepoName is a convenience function to parse a repo
+// name in a 'packagist/<vendor>/<package>(@<version>)?' format into a
+// PackagistVersionedPackage.
+func ParsePackagistPackageFromRepoName(name api.RepoName) (*PackagistVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), packagistPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid Packagist dependency repo name, missing %s prefix '%s'", packagistPackagesPrefix, name)
+	}
+	return ParsePackagistVersionedPackage(dependency)
+}
+
+func (p *PackagistVersionedPackage) Scheme() string {
+	return "scip-php"
+}
+
+func (p *PackagistVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *PackagistVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *PackagistVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *PackagistVersionedPackage) Description() string { return "" }
+
+func (p *PackagistVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(packagistPackagesPrefix + p.Name)
+}
+
+// GitTagFromVersion returns the tag for the version. Composer package
+// versions are usually derived from VCS tags, so they may already carry a
+// leading "v".
+func (p *PackagistVersionedPackage) GitTagFromVersion() string {
+	return "v" + strings.TrimPrefix(p.Version, "v")
+}
+
+func (p *PackagistVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*PackagistVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
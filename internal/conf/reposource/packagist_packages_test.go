@@ -0,0 +1,49 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParsePackagistVersionedPackage(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		name    PackageName
+		version string
+		wantErr bool
+	}{
+		{in: "monolog/monolog@3.5.0", name: "monolog/monolog", version: "3.5.0"},
+		{in: "symfony/http-foundation@v6.4.0", name: "symfony/http-foundation", version: "v6.4.0"},
+		{in: "Laravel/Framework@10.0.0", name: "laravel/framework", version: "10.0.0"},
+		{in: "guzzlehttp/guzzle", name: "guzzlehttp/guzzle"},
+		{in: "monolog", wantErr: true},
+		{in: "../evil/pkg@1.0.0", wantErr: true},
+		{in: "monolog/monolog@../1.0.0", wantErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			dep, err := ParsePackagistVersionedPackage(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, dep.Name)
+			assert.Equal(t, tc.version, dep.Version)
+		})
+	}
+}
+
+func TestParsePackagistPackageFromRepoName(t *testing.T) {
+	dep, err := ParsePackagistPackageFromRepoName("packagist/symfony/console")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("symfony/console"), dep.Name)
+	assert.Equal(t, api.RepoName("packagist/symfony/console"), dep.RepoName())
+	assert.Equal(t, "v7.0.1", NewPackagistVersionedPackage("symfony/console", "v7.0.1").GitTagFromVersion())
+
+	_, err = ParsePackagistPackageFromRepoName("nuget/xunit")
+	require.Error(t, err)
+}
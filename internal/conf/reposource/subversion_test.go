@@ -0,0 +1,46 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSubversion_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.SubversionConnection
+		urls []urlToRepoName
+	}{{
+		conn: schema.SubversionConnection{
+			Url: "https://svn.apache.org/repos/asf",
+		},
+		urls: []urlToRepoName{
+			{"https://svn.apache.org/repos/asf/commons-lang", "svn.apache.org/repos/asf/commons-lang"},
+			{"https://svn.apache.org/repos/asf/incubator/project/", "svn.apache.org/repos/asf/incubator/project"},
+
+			{"https://svn.apache.org/other/commons-lang", ""},
+		},
+	}, {
+		conn: schema.SubversionConnection{
+			Url:                   "svn://svn.example.org:3690/",
+			RepositoryPathPattern: "svn/{repo}",
+		},
+		urls: []urlToRepoName{
+			{"svn://svn.example.org:3690/legacy/app", "svn/legacy/app"},
+
+			{"svn://svn.corp.org:3690/legacy/app", ""},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Subversion{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
@@ -257,6 +257,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.AzureDevOps != nil {
 			oldAuthProviderSecrets[ap.AzureDevOps.ClientID] = ap.AzureDevOps.ClientSecret
 		}
+		if ap.Ldap != nil {
+			oldAuthProviderSecrets[ap.Ldap.Url+ap.Ldap.BindDN] = ap.Ldap.BindPassword
+		}
 	}
 
 	newAuthProviderCfg, err := ParseConfig(conftypes.RawUnified{
@@ -281,6 +284,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.AzureDevOps != nil && ap.AzureDevOps.ClientSecret == redactedSecret {
 			ap.AzureDevOps.ClientSecret = oldAuthProviderSecrets[ap.AzureDevOps.ClientID]
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword == redactedSecret {
+			ap.Ldap.BindPassword = oldAuthProviderSecrets[ap.Ldap.Url+ap.Ldap.BindDN]
+		}
 	}
 
 	unredactedSite, err := jsonc.Edit(input, newAuthProviderCfg.AuthProviders, "auth.providers")
@@ -412,6 +418,9 @@ func redactConfSecrets(raw conftypes.RawUnified, hashSecrets bool) (empty confty
 		if ap.AzureDevOps != nil {
 			ap.AzureDevOps.ClientSecret = getRedactedSecret(ap.AzureDevOps.ClientSecret, hashSecrets)
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword != "" {
+			ap.Ldap.BindPassword = getRedactedSecret(ap.Ldap.BindPassword, hashSecrets)
+		}
 	}
 
 	for _, oa := range cfg.ObservabilityAlerts {
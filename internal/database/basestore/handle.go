@@ -106,6 +106,11 @@ func (h *dbHandle) Transact(ctx context.Context) (TransactableHandle, error) {
 	return &txHandle{lockingTx: &lockingTx{tx: tx, logger: h.logger}, txOptions: h.txOptions}, nil
 }
 
+func (h *dbHandle) afterCommit(f func()) {
+	// Without a transaction, every statement is committed right away.
+	f()
+}
+
 func (h *dbHandle) Done(err error) error {
 	return errors.Append(err, ErrNotInTransaction)
 }
@@ -113,6 +118,7 @@ func (h *dbHandle) Done(err error) error {
 type txHandle struct {
 	*lockingTx
 	txOptions sql.TxOptions
+	hooks     afterCommitHooks
 }
 
 func (h *txHandle) InTransaction() bool {
@@ -125,12 +131,23 @@ func (h *txHandle) Transact(ctx context.Context) (TransactableHandle, error) {
 		return nil, err
 	}
 
-	return &savepointHandle{lockingTx: h.lockingTx, savepointID: savepointID, ctx: context.WithoutCancel(ctx)}, nil
+	return &savepointHandle{lockingTx: h.lockingTx, savepointID: savepointID, ctx: context.WithoutCancel(ctx), parent: h}, nil
+}
+
+func (h *txHandle) afterCommit(f func()) {
+	h.hooks.add(f)
 }
 
 func (h *txHandle) Done(err error) error {
+	hooks := h.hooks.take()
 	if err == nil {
-		return h.Commit()
+		if err := h.Commit(); err != nil {
+			return err
+		}
+		for _, f := range hooks {
+			f()
+		}
+		return nil
 	}
 	return errors.Append(err, h.Rollback())
 }
@@ -139,6 +156,11 @@ type savepointHandle struct {
 	*lockingTx
 	savepointID string
 	ctx         context.Context
+	// parent is the transaction or savepoint the savepoint was created in.
+	// The after commit hooks of the savepoint are passed on to it when the
+	// savepoint is released.
+	parent afterCommitter
+	hooks  afterCommitHooks
 }
 
 func (h *savepointHandle) InTransaction() bool {
@@ -151,19 +173,55 @@ func (h *savepointHandle) Transact(ctx context.Context) (TransactableHandle, err
 		return nil, err
 	}
 
-	return &savepointHandle{lockingTx: h.lockingTx, savepointID: savepointID, ctx: context.WithoutCancel(ctx)}, nil
+	return &savepointHandle{lockingTx: h.lockingTx, savepointID: savepointID, ctx: context.WithoutCancel(ctx), parent: h}, nil
+}
+
+func (h *savepointHandle) afterCommit(f func()) {
+	h.hooks.add(f)
 }
 
 func (h *savepointHandle) Done(err error) error {
+	hooks := h.hooks.take()
 	if err == nil {
-		_, execErr := h.ExecContext(h.ctx, fmt.Sprintf(commitSavepointQuery, h.savepointID))
-		return execErr
+		if _, execErr := h.ExecContext(h.ctx, fmt.Sprintf(commitSavepointQuery, h.savepointID)); execErr != nil {
+			return execErr
+		}
+		for _, f := range hooks {
+			h.parent.afterCommit(f)
+		}
+		return nil
 	}
 
 	_, execErr := h.ExecContext(h.ctx, fmt.Sprintf(rollbackSavepointQuery, h.savepointID))
 	return errors.Append(err, execErr)
 }
 
+// afterCommitter is implemented by the handles that support AfterCommit.
+type afterCommitter interface {
+	afterCommit(f func())
+}
+
+// afterCommitHooks are the functions registered with AfterCommit on a
+// transaction or savepoint that is not done yet.
+type afterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+func (h *afterCommitHooks) add(f func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, f)
+}
+
+func (h *afterCommitHooks) take() []func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hooks := h.hooks
+	h.hooks = nil
+	return hooks
+}
+
 const (
 	savepointQuery         = "SAVEPOINT %s"
 	commitSavepointQuery   = "RELEASE %s"
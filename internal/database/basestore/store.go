@@ -135,6 +135,19 @@ func (s *Store) Transact(ctx context.Context) (*Store, error) {
 	return &Store{handle: handle}, nil
 }
 
+// AfterCommit calls f once the changes made through s are committed: when the
+// outermost transaction s belongs to is committed, or right away if s is not
+// in a transaction. f is never called if the transaction is rolled back. Use
+// it for side effects outside of the database, such as clearing caches, that
+// must not be observed before the changes are.
+func AfterCommit(s ShareableStore, f func()) {
+	if h, ok := s.Handle().(afterCommitter); ok {
+		h.afterCommit(f)
+		return
+	}
+	f()
+}
+
 var ErrPanicDuringTransaction = errors.New("encountered panic during transaction")
 
 // WithTransact executes the callback using a transaction on the store. If the callback
@@ -148,6 +148,50 @@ func TestSavepoints(t *testing.T) {
 	}
 }
 
+func TestAfterCommit(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := dbtest.NewRawDB(logger, t)
+	setupStoreTest(t, db)
+	store := testStore(t, db)
+	ctx := context.Background()
+
+	var called []string
+	hook := func(name string) func() {
+		return func() { called = append(called, name) }
+	}
+
+	// Outside of a transaction, hooks run right away.
+	AfterCommit(store, hook("no transaction"))
+	require.Equal(t, []string{"no transaction"}, called)
+	called = nil
+
+	tx, err := store.Transact(ctx)
+	require.NoError(t, err)
+	AfterCommit(tx, hook("transaction"))
+
+	released, err := tx.Transact(ctx)
+	require.NoError(t, err)
+	AfterCommit(released, hook("released savepoint"))
+	require.NoError(t, released.Done(nil))
+
+	rolledBack, err := tx.Transact(ctx)
+	require.NoError(t, err)
+	AfterCommit(rolledBack, hook("rolled back savepoint"))
+	rollbackErr := errors.New("rollback")
+	require.ErrorIs(t, rolledBack.Done(rollbackErr), rollbackErr)
+
+	require.Empty(t, called)
+	require.NoError(t, tx.Done(nil))
+	require.Equal(t, []string{"transaction", "released savepoint"}, called)
+	called = nil
+
+	tx, err = store.Transact(ctx)
+	require.NoError(t, err)
+	AfterCommit(tx, hook("rolled back transaction"))
+	require.ErrorIs(t, tx.Done(rollbackErr), rollbackErr)
+	require.Empty(t, called)
+}
+
 func TestSetLocal(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := dbtest.NewRawDB(logger, t)
@@ -3,7 +3,6 @@ package database
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"strconv"
 	"time"
 
@@ -14,11 +13,13 @@ import (
 )
 
 type ActionJob struct {
-	ID           int32
-	Email        *int64
-	Webhook      *int64
-	SlackWebhook *int64
-	TriggerEvent int32
+	ID               int32
+	Email            *int64
+	Webhook          *int64
+	SlackWebhook     *int64
+	TeamsWebhook     *int64
+	TemplatedWebhook *int64
+	TriggerEvent     int32
 
 	// Fields demanded by any dbworker.
 	State          string
@@ -42,8 +43,11 @@ func (a *ActionJob) RecordUID() string {
 type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
-	Results     []*result.CommitMatch
+	Results     result.Matches
+	OwnerID     int32
 	OwnerName   string
+	// EmailID is the email action of the job, if it is the job of one.
+	EmailID *int64
 
 	// The query with after: filter.
 	Query string
@@ -56,6 +60,8 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.templated_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -87,6 +93,16 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TeamsWebhookID, if set, will filter to only actions jobs that are
+	// executing the given Microsoft Teams webhook action. Refers to
+	// cm_teams_webhooks(id)
+	TeamsWebhookID *int
+
+	// TemplatedWebhookID, if set, will filter to only actions jobs that are
+	// executing the given templated webhook action. Refers to
+	// cm_templated_webhooks(id)
+	TemplatedWebhookID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -110,6 +126,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TeamsWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("teams_webhook = %s", *o.TeamsWebhookID))
+	}
+	if o.TemplatedWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("templated_webhook = %s", *o.TemplatedWebhookID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -169,16 +191,44 @@ func (s *codeMonitorStore) CountActionJobs(ctx context.Context, opts ListActionJ
 	return count, err
 }
 
+// CodeMonitorDigest is how often the results of an action are summarised and
+// sent. The zero value sends a notification for every trigger event.
+type CodeMonitorDigest string
+
+const (
+	CodeMonitorDigestHourly CodeMonitorDigest = "hourly"
+	CodeMonitorDigestDaily  CodeMonitorDigest = "daily"
+	CodeMonitorDigestWeekly CodeMonitorDigest = "weekly"
+)
+
+// digestProcessAfterFmtStr is the end of the current period of a digest
+// action, which is when its action jobs are processed.
+const digestProcessAfterFmtStr = `
+date_trunc(
+	CASE digest WHEN 'hourly' THEN 'hour' WHEN 'daily' THEN 'day' ELSE 'week' END,
+	%s::timestamp with time zone
+) + CASE digest WHEN 'hourly' THEN interval '1 hour' WHEN 'daily' THEN interval '1 day' ELSE interval '1 week' END
+`
+
 const enqueueActionEmailFmtStr = `
 WITH due_emails AS (
 	SELECT id
 	FROM cm_emails
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest IS NULL
 	EXCEPT
 	SELECT DISTINCT email as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), digest_emails AS (
+	-- Unlike other actions, a job is enqueued for every trigger event of a
+	-- digest action so the results accumulate until the end of the period.
+	SELECT id, %s AS process_after
+	FROM cm_emails
+	WHERE monitor = %s
+		AND enabled = true
+		AND digest IS NOT NULL
 ), due_webhooks AS (
 	SELECT id
 	FROM cm_webhooks
@@ -193,27 +243,71 @@ WITH due_emails AS (
 	FROM cm_slack_webhooks
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest IS NULL
 	EXCEPT
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), digest_slack_webhooks AS (
+	SELECT id, %s AS process_after
+	FROM cm_slack_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+		AND digest IS NOT NULL
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_templated_webhooks AS (
+	SELECT id
+	FROM cm_templated_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT templated_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, templated_webhook, trigger_event, process_after)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE) from due_emails
+UNION
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, process_after from digest_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE) from due_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE) from due_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, process_after from digest_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE) from due_teams_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE) from due_templated_webhooks
+ORDER BY 1, 2, 3, 4, 5
 RETURNING %s
 `
 
 func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJobID int32) ([]*ActionJob, error) {
+	digestProcessAfter := sqlf.Sprintf(digestProcessAfterFmtStr, s.Now())
 	q := sqlf.Sprintf(
 		enqueueActionEmailFmtStr,
 		monitorID,
+		digestProcessAfter,
+		monitorID,
+		monitorID,
+		monitorID,
+		digestProcessAfter,
 		monitorID,
 		monitorID,
+		monitorID,
+		triggerJobID,
+		triggerJobID,
+		triggerJobID,
+		triggerJobID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
@@ -233,7 +327,10 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
-	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
+	ctj.content_results,
+	users.id,
+	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END,
+	caj.email
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
 INNER JOIN cm_queries cq on cq.id = ctj.query
@@ -245,13 +342,95 @@ WHERE caj.id = %s
 // GetActionJobMetada returns the set of fields needed to execute all action jobs
 func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
-	var resultsJSON []byte
+	return scanActionJobMetadata(row)
+}
+
+const collectDigestActionJobsFmtStr = `
+WITH job AS (
+	SELECT caj.id, caj.email, caj.slack_webhook, caj.process_after, ce.digest AS email_digest, cm.namespace_user_id
+	FROM cm_action_jobs caj
+	INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
+	INNER JOIN cm_queries cq on cq.id = ctj.query
+	INNER JOIN cm_monitors cm on cm.id = cq.monitor
+	LEFT JOIN cm_emails ce on ce.id = caj.email
+	WHERE caj.id = %s
+), digest_jobs AS (
+	SELECT caj.id
+	FROM cm_action_jobs caj, job
+	WHERE caj.id != job.id
+		AND caj.state = 'queued'
+		AND caj.process_after = job.process_after
+		AND (
+			caj.slack_webhook = job.slack_webhook
+			OR caj.email IN (
+				SELECT ce.id
+				FROM cm_emails ce
+				INNER JOIN cm_monitors cm on cm.id = ce.monitor
+				WHERE ce.digest = job.email_digest
+					AND cm.namespace_user_id = job.namespace_user_id
+			)
+		)
+	FOR UPDATE OF caj SKIP LOCKED
+), completed AS (
+	UPDATE cm_action_jobs
+	SET state = 'completed',
+		finished_at = %s
+	WHERE id IN (SELECT id FROM digest_jobs)
+	RETURNING id
+)
+SELECT
+	cm.description,
+	ctj.query_string,
+	cm.id AS monitorID,
+	ctj.search_results,
+	ctj.content_results,
+	users.id,
+	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END,
+	caj.email
+FROM cm_action_jobs caj
+INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
+INNER JOIN cm_queries cq on cq.id = ctj.query
+INNER JOIN cm_monitors cm on cm.id = cq.monitor
+INNER JOIN users on cm.namespace_user_id = users.id
+WHERE caj.id IN (SELECT id FROM job UNION SELECT id FROM completed)
+ORDER BY caj.id
+`
+
+// CollectDigestActionJobs returns the metadata of the given action job of a
+// digest action and of all other queued action jobs that are part of the
+// same digest, which are marked as completed. Slack webhook digests contain
+// the jobs of a single action, whereas email digests contain the jobs of all
+// email actions with the same digest period on monitors of the same user.
+//
+// It must be called in a transaction so the other jobs are only completed
+// if the digest is sent.
+func (s *codeMonitorStore) CollectDigestActionJobs(ctx context.Context, jobID int32) ([]*ActionJobMetadata, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(collectDigestActionJobsFmtStr, jobID, s.Now()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ms []*ActionJobMetadata
+	for rows.Next() {
+		m, err := scanActionJobMetadata(rows)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	return ms, rows.Err()
+}
+
+func scanActionJobMetadata(scanner dbutil.Scanner) (*ActionJobMetadata, error) {
+	var resultsJSON, contentResultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := scanner.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &contentResultsJSON, &m.OwnerID, &m.OwnerName, &m.EmailID)
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
+	m.Results, err = unmarshalTriggerJobResults(resultsJSON, contentResultsJSON)
+	if err != nil {
 		return nil, err
 	}
 	return m, nil
@@ -288,6 +467,8 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.TeamsWebhook,
+		&aj.TemplatedWebhook,
 		&aj.TriggerEvent,
 		&aj.State,
 		&aj.FailureMessage,
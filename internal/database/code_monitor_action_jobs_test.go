@@ -7,7 +7,10 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
@@ -41,7 +44,7 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 
 func TestGetActionJobMetadata(t *testing.T) {
 	ctx, db, s := newTestStore(t)
-	userName, _, userCTX := newTestUser(ctx, t, db)
+	userName, userID, userCTX := newTestUser(ctx, t, db)
 	fixtures := s.insertTestMonitor(userCTX, t)
 
 	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
@@ -50,8 +53,21 @@ func TestGetActionJobMetadata(t *testing.T) {
 	triggerJobID := triggerJobs[0].ID
 
 	var (
-		wantResults = make([]*result.CommitMatch, 42)
-		wantQuery   = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
+		wantResults = result.Matches{
+			&result.CommitMatch{
+				Repo:   types.MinimalRepo{ID: 1, Name: "github.com/test/a"},
+				Commit: gitdomain.Commit{ID: "abc", Parents: []api.CommitID{"def"}},
+			},
+			&result.FileMatch{
+				File: result.File{Repo: types.MinimalRepo{ID: 2, Name: "github.com/test/b"}, CommitID: "abc", Path: "main.go"},
+				ChunkMatches: result.ChunkMatches{{
+					Content:      "token := 1",
+					ContentStart: result.Location{Offset: 10, Line: 1},
+					Ranges:       result.Ranges{{Start: result.Location{Offset: 10, Line: 1}, End: result.Location{Offset: 15, Line: 1, Column: 5}}},
+				}},
+			},
+		}
+		wantQuery = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
 	)
 	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, wantQuery, wantResults)
 	require.NoError(t, err)
@@ -68,11 +84,82 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Query:       wantQuery,
 		Results:     wantResults,
 		MonitorID:   fixtures.monitor.ID,
+		OwnerID:     userID,
 		OwnerName:   userName,
+		EmailID:     actionJobs[0].Email,
 	}
 	require.Equal(t, want, got)
 }
 
+func TestDigestActionJobs(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, userID, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	_, err := s.UpdateEmailAction(userCTX, fixtures.emails[0].ID, &EmailActionArgs{
+		Enabled:  true,
+		Priority: "NORMAL",
+		Digest:   CodeMonitorDigestDaily,
+	})
+	require.NoError(t, err)
+	slackWebhook, err := s.CreateSlackWebhookAction(userCTX, fixtures.monitor.ID, true, false, "https://example.com", CodeMonitorDigestHourly)
+	require.NoError(t, err)
+
+	enqueue := func() []*ActionJob {
+		triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, triggerJobs, 1)
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJobs[0].ID, testQuery, result.Matches{&result.CommitMatch{}})
+		require.NoError(t, err)
+		err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_trigger_jobs SET state = 'completed' WHERE id = %s", triggerJobs[0].ID))
+		require.NoError(t, err)
+
+		actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+		require.NoError(t, err)
+		return actionJobs
+	}
+
+	first := enqueue()
+	require.Len(t, first, 3)
+	require.Equal(t, &fixtures.emails[0].ID, first[0].Email)
+	require.NotNil(t, first[0].ProcessAfter)
+	require.True(t, first[0].ProcessAfter.After(s.Now()))
+	require.Nil(t, first[1].ProcessAfter)
+	require.Equal(t, &slackWebhook.ID, first[2].SlackWebhook)
+	require.NotNil(t, first[2].ProcessAfter)
+	require.False(t, first[2].ProcessAfter.After(s.Now().Add(time.Hour)))
+
+	// The immediate email action still has a queued job, but a job is
+	// enqueued for every trigger event of the digest actions.
+	second := enqueue()
+	require.Len(t, second, 2)
+	require.Equal(t, first[0].ProcessAfter, second[0].ProcessAfter)
+	require.Equal(t, first[2].ProcessAfter, second[1].ProcessAfter)
+
+	err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'processing' WHERE id = %s", first[0].ID))
+	require.NoError(t, err)
+	emailDigest, err := s.CollectDigestActionJobs(ctx, first[0].ID)
+	require.NoError(t, err)
+	require.Len(t, emailDigest, 2)
+	for _, m := range emailDigest {
+		require.Equal(t, fixtures.monitor.ID, m.MonitorID)
+		require.Equal(t, userID, m.OwnerID)
+		require.Equal(t, &fixtures.emails[0].ID, m.EmailID)
+		require.Len(t, m.Results, 1)
+	}
+
+	job, err := s.GetActionJob(ctx, second[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "completed", job.State)
+	job, err = s.GetActionJob(ctx, second[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, "queued", job.State)
+
+	slackDigest, err := s.CollectDigestActionJobs(ctx, first[2].ID)
+	require.NoError(t, err)
+	require.Len(t, slackDigest, 2)
+}
+
 func TestScanActionJob(t *testing.T) {
 	ctx, db, s := newTestStore(t)
 	_, _, userCTX := newTestUser(ctx, t, db)
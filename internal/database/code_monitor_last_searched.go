@@ -28,7 +28,8 @@ func (s *codeMonitorStore) UpsertLastSearched(ctx context.Context, monitorID int
 	INSERT INTO cm_last_searched (monitor_id, repo_id, commit_oids)
 	VALUES (%s, %s, %s)
 	ON CONFLICT (monitor_id, repo_id) DO UPDATE
-	SET commit_oids = %s
+	SET commit_oids = %s,
+		content_match_keys = NULL
 	`
 
 	// Appease non-null constraint on column
@@ -56,3 +57,51 @@ func (s *codeMonitorStore) GetLastSearched(ctx context.Context, monitorID int64,
 	}
 	return commitOIDs, err
 }
+
+func (s *codeMonitorStore) UpsertLastSearchedContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID, commitOIDs, matchKeys []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_searched (monitor_id, repo_id, commit_oids, content_match_keys)
+	VALUES (%s, %s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET commit_oids = EXCLUDED.commit_oids,
+		content_match_keys = EXCLUDED.content_match_keys
+	`
+
+	// Appease non-null constraint on column, and distinguish "no matches" from
+	// repos that were never searched for content.
+	if commitOIDs == nil {
+		commitOIDs = []string{}
+	}
+	if matchKeys == nil {
+		matchKeys = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(commitOIDs), pq.StringArray(matchKeys))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) ListLastSearchedContentMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error) {
+	rawQuery := `
+	SELECT repo_id, COALESCE(content_match_keys, '{}')
+	FROM cm_last_searched
+	WHERE monitor_id = %s
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matchKeys := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID api.RepoID
+			keys   []string
+		)
+		if err := rows.Scan(&repoID, (*pq.StringArray)(&keys)); err != nil {
+			return nil, err
+		}
+		matchKeys[repoID] = keys
+	}
+	return matchKeys, rows.Err()
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 )
 
@@ -84,6 +85,49 @@ func TestCodeMonitorStoreLastSearched(t *testing.T) {
 	})
 }
 
+func TestCodeMonitorStoreLastSearchedContentMatches(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := NewDB(logger, dbtest.NewDB(t))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	matchKeys, err := cm.ListLastSearchedContentMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Empty(t, matchKeys)
+
+	// Repos searched by a diff or commit query are listed without keys.
+	err = cm.UpsertLastSearched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"commit1"})
+	require.NoError(t, err)
+	matchKeys, err = cm.ListLastSearchedContentMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {}}, matchKeys)
+
+	err = cm.UpsertLastSearchedContentMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"commit2"}, []string{"key1", "key2"})
+	require.NoError(t, err)
+	matchKeys, err = cm.ListLastSearchedContentMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {"key1", "key2"}}, matchKeys)
+
+	lastSearched, err := cm.GetLastSearched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"commit2"}, lastSearched)
+
+	// Other monitors are not affected.
+	matchKeys, err = cm.ListLastSearchedContentMatches(ctx, fixtures.Monitor.ID+1)
+	require.NoError(t, err)
+	require.Empty(t, matchKeys)
+
+	// Upserting as a diff or commit monitor clears the keys.
+	err = cm.UpsertLastSearched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"commit3"})
+	require.NoError(t, err)
+	matchKeys, err = cm.ListLastSearchedContentMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {}}, matchKeys)
+}
+
 func TestCodeMonitorHasAnyLastSearched(t *testing.T) {
 	t.Parallel()
 	logger := logtest.Scoped(t)
@@ -17,6 +17,7 @@ type SlackWebhookAction struct {
 	Enabled        bool
 	URL            string
 	IncludeResults bool
+	Digest         CodeMonitorDigest
 
 	CreatedBy int32
 	CreatedAt time.Time
@@ -29,6 +30,7 @@ UPDATE cm_slack_webhooks
 SET enabled = %s,
 	include_results = %s,
 	url = %s,
+	digest = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,7 +43,7 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string, digest CodeMonitorDigest) (*SlackWebhookAction, error) {
 	a := actor.FromContext(ctx)
 
 	user, err := a.User(ctx, s.userStore)
@@ -54,6 +56,7 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 		enabled,
 		includeResults,
 		url,
+		dbutil.NullStringColumn(string(digest)),
 		a.UID,
 		s.Now(),
 		id,
@@ -67,12 +70,12 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 
 const createSlackWebhookActionQuery = `
 INSERT INTO cm_slack_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, digest, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest CodeMonitorDigest) (*SlackWebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -81,6 +84,7 @@ func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitor
 		enabled,
 		includeResults,
 		url,
+		dbutil.NullStringColumn(string(digest)),
 		a.UID,
 		now,
 		a.UID,
@@ -175,6 +179,7 @@ var slackWebhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_slack_webhooks.enabled"),
 	sqlf.Sprintf("cm_slack_webhooks.url"),
 	sqlf.Sprintf("cm_slack_webhooks.include_results"),
+	sqlf.Sprintf("cm_slack_webhooks.digest"),
 	sqlf.Sprintf("cm_slack_webhooks.created_by"),
 	sqlf.Sprintf("cm_slack_webhooks.created_at"),
 	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
@@ -203,6 +208,7 @@ func scanSlackWebhookAction(scanner dbutil.Scanner) (*SlackWebhookAction, error)
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&dbutil.NullString{S: (*string)(&w.Digest)},
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
@@ -27,7 +27,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitorsWith(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		got, err := s.GetSlackWebhookAction(ctx, action.ID)
@@ -44,10 +44,10 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitorsWith(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		updated, err := s.UpdateSlackWebhookAction(ctx, action.ID, false, false, url2)
+		updated, err := s.UpdateSlackWebhookAction(ctx, action.ID, false, false, url2, "")
 		require.NoError(t, err)
 		require.Equal(t, false, updated.Enabled)
 		require.Equal(t, url2, updated.URL)
@@ -64,7 +64,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		_, _, ctx := newTestUser(ctx, t, db)
 		s := CodeMonitorsWith(db)
 
-		_, err := s.UpdateSlackWebhookAction(ctx, 383838, false, false, url2)
+		_, err := s.UpdateSlackWebhookAction(ctx, 383838, false, false, url2, "")
 		require.Error(t, err)
 	})
 
@@ -76,10 +76,10 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		s := CodeMonitorsWith(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action1, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action1, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		action2, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action2, err := s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		err = s.DeleteSlackWebhookActions(ctx, fixtures.monitor.ID, action1.ID)
@@ -104,7 +104,7 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 0, count)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		count, err = s.CountSlackWebhookActions(ctx, fixtures.monitor.ID)
@@ -124,10 +124,10 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, actions, 0)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url2)
+		_, err = s.CreateSlackWebhookAction(ctx, fixtures.monitor.ID, true, false, url2, "")
 		require.NoError(t, err)
 
 		actions2, err := s.ListSlackWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
@@ -151,19 +151,19 @@ func TestCodeMonitorStoreSlackWebhooks(t *testing.T) {
 		fixtures := s.insertTestMonitor(ctx1, t)
 		_ = s.insertTestMonitor(ctx2, t)
 
-		wa, err := s.CreateSlackWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com")
+		wa, err := s.CreateSlackWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com", "")
 		require.NoError(t, err)
 
 		// User1 can update it
-		_, err = s.UpdateSlackWebhookAction(ctx1, wa.ID, true, true, "https://false.com")
+		_, err = s.UpdateSlackWebhookAction(ctx1, wa.ID, true, true, "https://false.com", "")
 		require.NoError(t, err)
 
 		// User2 cannot update it
-		_, err = s.UpdateSlackWebhookAction(ctx2, wa.ID, true, true, "https://truer.com")
+		_, err = s.UpdateSlackWebhookAction(ctx2, wa.ID, true, true, "https://truer.com", "")
 		require.Error(t, err)
 
 		// User3 can update it
-		_, err = s.UpdateSlackWebhookAction(ctx3, wa.ID, true, true, "https://false.com")
+		_, err = s.UpdateSlackWebhookAction(ctx3, wa.ID, true, true, "https://false.com", "")
 		require.NoError(t, err)
 
 		wa, err = s.GetSlackWebhookAction(ctx1, wa.ID)
@@ -11,8 +11,10 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -23,7 +25,9 @@ type TriggerJob struct {
 	// The query we ran including after: filter.
 	QueryString *string
 
-	SearchResults []*result.CommitMatch
+	// SearchResults are the new commit matches found by the job, or the new
+	// file content matches for content monitors.
+	SearchResults result.Matches
 
 	// Fields demanded for any dbworker.
 	State          string
@@ -93,21 +97,41 @@ func (s *codeMonitorStore) EnqueueQueryTriggerJobs(ctx context.Context) ([]*Trig
 const logSearchFmtStr = `
 UPDATE cm_trigger_jobs
 SET query_string = %s,
-    search_results = %s
+    search_results = %s,
+    content_results = %s
 WHERE id = %s
 `
 
-func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error {
-	if results == nil {
-		// appease db non-null constraint
-		results = []*result.CommitMatch{}
+// UpdateTriggerJobWithResults records the query a trigger job ran and its
+// results. Commit matches are stored in search_results, and the file matches
+// of content monitors in content_results.
+func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results result.Matches) error {
+	// appease db non-null constraint
+	commitMatches := []*result.CommitMatch{}
+	var fileMatches []*result.FileMatch
+	for _, r := range results {
+		switch v := r.(type) {
+		case *result.CommitMatch:
+			commitMatches = append(commitMatches, v)
+		case *result.FileMatch:
+			fileMatches = append(fileMatches, v)
+		default:
+			return errors.Errorf("unexpected code monitor result type %T", r)
+		}
 	}
 
-	resultsJSON, err := json.Marshal(results)
+	resultsJSON, err := json.Marshal(commitMatches)
 	if err != nil {
 		return err
 	}
-	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
+	var contentResultsJSON []byte
+	if len(fileMatches) > 0 {
+		contentResultsJSON, err = marshalContentResults(fileMatches)
+		if err != nil {
+			return err
+		}
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, contentResultsJSON, triggerJobID))
 }
 
 const updateTriggerJobLogsFmtStr = `
@@ -208,7 +232,7 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, contentResultsJSON []byte
 	var logs []TriggerJobLogs
 	m := &TriggerJob{}
 	err := scanner.Scan(
@@ -216,6 +240,7 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&contentResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -231,13 +256,118 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 
 	m.Logs = append(m.Logs, logs...)
 
+	m.SearchResults, err = unmarshalTriggerJobResults(resultsJSON, contentResultsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// unmarshalTriggerJobResults decodes the search_results and content_results
+// columns of a trigger job.
+func unmarshalTriggerJobResults(resultsJSON, contentResultsJSON []byte) (result.Matches, error) {
+	var (
+		commitMatches []*result.CommitMatch
+		fileMatches   []*result.FileMatch
+	)
 	if len(resultsJSON) > 0 {
-		if err := json.Unmarshal(resultsJSON, &m.SearchResults); err != nil {
+		if err := json.Unmarshal(resultsJSON, &commitMatches); err != nil {
+			return nil, err
+		}
+	}
+	if len(contentResultsJSON) > 0 {
+		var err error
+		if fileMatches, err = unmarshalContentResults(contentResultsJSON); err != nil {
 			return nil, err
 		}
 	}
 
-	return m, nil
+	var matches result.Matches
+	for _, cm := range commitMatches {
+		matches = append(matches, cm)
+	}
+	for _, fm := range fileMatches {
+		matches = append(matches, fm)
+	}
+	return matches, nil
+}
+
+// stableContentResultJSON is the stored representation of the file content
+// matches of content monitors. Like the stored form of commit matches, it is
+// kept stable so that changes to result.FileMatch don't break stored results,
+// and unlike result.FileMatch it includes the repo and commit of the file.
+type stableContentResultJSON struct {
+	RepoID       int32                  `json:"repoID"`
+	RepoName     string                 `json:"repoName"`
+	RepoStars    int                    `json:"repoStars"`
+	CommitID     string                 `json:"commitID"`
+	InputRev     *string                `json:"inputRev,omitempty"`
+	Path         string                 `json:"path"`
+	ChunkMatches []stableChunkMatchJSON `json:"chunkMatches"`
+}
+
+type stableChunkMatchJSON struct {
+	Content      string          `json:"content"`
+	ContentStart result.Location `json:"contentStart"`
+	Ranges       result.Ranges   `json:"ranges"`
+}
+
+func marshalContentResults(fms []*result.FileMatch) ([]byte, error) {
+	stable := make([]stableContentResultJSON, 0, len(fms))
+	for _, fm := range fms {
+		chunks := make([]stableChunkMatchJSON, 0, len(fm.ChunkMatches))
+		for _, cm := range fm.ChunkMatches {
+			chunks = append(chunks, stableChunkMatchJSON{
+				Content:      cm.Content,
+				ContentStart: cm.ContentStart,
+				Ranges:       cm.Ranges,
+			})
+		}
+		stable = append(stable, stableContentResultJSON{
+			RepoID:       int32(fm.Repo.ID),
+			RepoName:     string(fm.Repo.Name),
+			RepoStars:    fm.Repo.Stars,
+			CommitID:     string(fm.CommitID),
+			InputRev:     fm.InputRev,
+			Path:         fm.Path,
+			ChunkMatches: chunks,
+		})
+	}
+	return json.Marshal(stable)
+}
+
+func unmarshalContentResults(data []byte) ([]*result.FileMatch, error) {
+	var stable []stableContentResultJSON
+	if err := json.Unmarshal(data, &stable); err != nil {
+		return nil, err
+	}
+
+	fms := make([]*result.FileMatch, 0, len(stable))
+	for _, s := range stable {
+		chunks := make(result.ChunkMatches, 0, len(s.ChunkMatches))
+		for _, cm := range s.ChunkMatches {
+			chunks = append(chunks, result.ChunkMatch{
+				Content:      cm.Content,
+				ContentStart: cm.ContentStart,
+				Ranges:       cm.Ranges,
+			})
+		}
+		fms = append(fms, &result.FileMatch{
+			File: result.File{
+				InputRev: s.InputRev,
+				Repo: types.MinimalRepo{
+					ID:    api.RepoID(s.RepoID),
+					Name:  api.RepoName(s.RepoName),
+					Stars: s.RepoStars,
+				},
+				CommitID: api.CommitID(s.CommitID),
+				Path:     s.Path,
+			},
+			ChunkMatches: chunks,
+		})
+	}
+	return fms, nil
 }
 
 var TriggerJobsColumns = []*sqlf.Query{
@@ -245,6 +375,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.content_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
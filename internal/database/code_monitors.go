@@ -50,7 +50,7 @@ type CodeMonitorStore interface {
 	ListQueryTriggerJobs(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
-	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results result.Matches) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 	UpdateTriggerJobWithLogs(ctx context.Context, triggerJobID int32, entry TriggerJobLogs) error
 
@@ -67,13 +67,27 @@ type CodeMonitorStore interface {
 	GetWebhookAction(ctx context.Context, id int64) (*WebhookAction, error)
 	ListWebhookActions(context.Context, ListActionsOpts) ([]*WebhookAction, error)
 
-	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
-	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
+	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string, digest CodeMonitorDigest) (*SlackWebhookAction, error)
+	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest CodeMonitorDigest) (*SlackWebhookAction, error)
 	DeleteSlackWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountSlackWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTeamsWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error)
+	ListTeamsWebhookActions(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+
+	UpdateTemplatedWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url, body string) (*TemplatedWebhookAction, error)
+	CreateTemplatedWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url, body string) (*TemplatedWebhookAction, error)
+	DeleteTemplatedWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTemplatedWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTemplatedWebhookAction(ctx context.Context, id int64) (*TemplatedWebhookAction, error)
+	ListTemplatedWebhookActions(context.Context, ListActionsOpts) ([]*TemplatedWebhookAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -82,6 +96,7 @@ type CodeMonitorStore interface {
 	ListActionJobs(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
 	CountActionJobs(context.Context, ListActionJobsOpts) (int, error)
 	GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error)
+	CollectDigestActionJobs(ctx context.Context, jobID int32) ([]*ActionJobMetadata, error)
 	GetActionJob(ctx context.Context, jobID int32) (*ActionJob, error)
 	EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJob int32) ([]*ActionJob, error)
 
@@ -92,6 +107,13 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+	// UpsertLastSearchedContentMatches records the commits searched by a content
+	// monitor in a repo along with the keys of the matches found at them.
+	UpsertLastSearchedContentMatches(ctx context.Context, monitorID int64, repoID api.RepoID, commitOIDs, matchKeys []string) error
+	// ListLastSearchedContentMatches returns the match keys recorded for each repo
+	// previously searched by the monitor. Repos searched by a diff or commit query
+	// are included with no keys.
+	ListLastSearchedContentMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error)
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -54,6 +54,7 @@ type DB interface {
 	RedisKeyValue() RedisKeyValueStore
 	Repos() RepoStore
 	RepoCommitsChangelists() RepoCommitsChangelistsStore
+	RepoCommitsSubversionRevisions() RepoCommitsSubversionRevisionsStore
 	RepoKVPs() RepoKVPStore
 	RepoPaths() RepoPathStore
 	RolePermissions() RolePermissionStore
@@ -68,6 +69,7 @@ type DB interface {
 	UserEmails() UserEmailsStore
 	UserExternalAccounts() UserExternalAccountsStore
 	UserRoles() UserRoleStore
+	UserSecondFactors(encryption.Key) UserSecondFactorStore
 	Users() UserStore
 	WebhookLogs(encryption.Key) WebhookLogStore
 	Webhooks(encryption.Key) WebhookStore
@@ -262,6 +264,10 @@ func (d *db) RepoCommitsChangelists() RepoCommitsChangelistsStore {
 	return RepoCommitsChangelistsWith(d.logger, d.Store)
 }
 
+func (d *db) RepoCommitsSubversionRevisions() RepoCommitsSubversionRevisionsStore {
+	return RepoCommitsSubversionRevisionsWith(d.logger, d.Store)
+}
+
 func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
@@ -321,6 +327,10 @@ func (d *db) UserRoles() UserRoleStore {
 	return UserRolesWith(d.Store)
 }
 
+func (d *db) UserSecondFactors(key encryption.Key) UserSecondFactorStore {
+	return UserSecondFactorsWith(d.logger, d.Store, key)
+}
+
 func (d *db) Users() UserStore {
 	return UsersWith(d.logger, d.Store)
 }
@@ -6346,6 +6346,9 @@ type MockCodeMonitorStore struct {
 	// ClockFunc is an instance of a mock function object controlling the
 	// behavior of the method Clock.
 	ClockFunc *CodeMonitorStoreClockFunc
+	// CollectDigestActionJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method CollectDigestActionJobs.
+	CollectDigestActionJobsFunc *CodeMonitorStoreCollectDigestActionJobsFunc
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
@@ -6361,6 +6364,13 @@ type MockCodeMonitorStore struct {
 	// CountSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSlackWebhookActions.
 	CountSlackWebhookActionsFunc *CodeMonitorStoreCountSlackWebhookActionsFunc
+	// CountTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountTeamsWebhookActions.
+	CountTeamsWebhookActionsFunc *CodeMonitorStoreCountTeamsWebhookActionsFunc
+	// CountTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CountTemplatedWebhookActions.
+	CountTemplatedWebhookActionsFunc *CodeMonitorStoreCountTemplatedWebhookActionsFunc
 	// CountWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountWebhookActions.
 	CountWebhookActionsFunc *CodeMonitorStoreCountWebhookActionsFunc
@@ -6379,6 +6389,13 @@ type MockCodeMonitorStore struct {
 	// CreateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateSlackWebhookAction.
 	CreateSlackWebhookActionFunc *CodeMonitorStoreCreateSlackWebhookActionFunc
+	// CreateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateTeamsWebhookAction.
+	CreateTeamsWebhookActionFunc *CodeMonitorStoreCreateTeamsWebhookActionFunc
+	// CreateTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CreateTemplatedWebhookAction.
+	CreateTemplatedWebhookActionFunc *CodeMonitorStoreCreateTemplatedWebhookActionFunc
 	// CreateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateWebhookAction.
 	CreateWebhookActionFunc *CodeMonitorStoreCreateWebhookActionFunc
@@ -6398,6 +6415,14 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteTeamsWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTeamsWebhookActions.
+	DeleteTeamsWebhookActionsFunc *CodeMonitorStoreDeleteTeamsWebhookActionsFunc
+	// DeleteTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTemplatedWebhookActions.
+	DeleteTemplatedWebhookActionsFunc *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -6439,6 +6464,13 @@ type MockCodeMonitorStore struct {
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
+	// GetTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTeamsWebhookAction.
+	GetTeamsWebhookActionFunc *CodeMonitorStoreGetTeamsWebhookActionFunc
+	// GetTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetTemplatedWebhookAction.
+	GetTemplatedWebhookActionFunc *CodeMonitorStoreGetTemplatedWebhookActionFunc
 	// GetWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetWebhookAction.
 	GetWebhookActionFunc *CodeMonitorStoreGetWebhookActionFunc
@@ -6454,6 +6486,10 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListLastSearchedContentMatchesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListLastSearchedContentMatches.
+	ListLastSearchedContentMatchesFunc *CodeMonitorStoreListLastSearchedContentMatchesFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -6466,6 +6502,13 @@ type MockCodeMonitorStore struct {
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
+	// ListTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListTeamsWebhookActions.
+	ListTeamsWebhookActionsFunc *CodeMonitorStoreListTeamsWebhookActionsFunc
+	// ListTemplatedWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListTemplatedWebhookActions.
+	ListTemplatedWebhookActionsFunc *CodeMonitorStoreListTemplatedWebhookActionsFunc
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
@@ -6497,6 +6540,13 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateTeamsWebhookAction.
+	UpdateTeamsWebhookActionFunc *CodeMonitorStoreUpdateTeamsWebhookActionFunc
+	// UpdateTemplatedWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateTemplatedWebhookAction.
+	UpdateTemplatedWebhookActionFunc *CodeMonitorStoreUpdateTemplatedWebhookActionFunc
 	// UpdateTriggerJobWithLogsFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateTriggerJobWithLogs.
 	UpdateTriggerJobWithLogsFunc *CodeMonitorStoreUpdateTriggerJobWithLogsFunc
@@ -6510,6 +6560,10 @@ type MockCodeMonitorStore struct {
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
+	// UpsertLastSearchedContentMatchesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpsertLastSearchedContentMatches.
+	UpsertLastSearchedContentMatchesFunc *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc
 }
 
 // NewMockCodeMonitorStore creates a new mock of the CodeMonitorStore
@@ -6522,6 +6576,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CollectDigestActionJobsFunc: &CodeMonitorStoreCollectDigestActionJobsFunc{
+			defaultHook: func(context.Context, int32) (r0 []*database.ActionJobMetadata, r1 error) {
+				return
+			},
+		},
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: func(context.Context, database.ListActionJobsOpts) (r0 int, r1 error) {
 				return
@@ -6547,6 +6606,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
 				return
@@ -6573,7 +6642,17 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (r0 *database.SlackWebhookAction, r1 error) {
+				return
+			},
+		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *database.TemplatedWebhookAction, r1 error) {
 				return
 			},
 		},
@@ -6607,6 +6686,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -6672,6 +6761,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *database.TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *database.WebhookAction, r1 error) {
 				return
@@ -6697,6 +6796,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListLastSearchedContentMatchesFunc: &CodeMonitorStoreListLastSearchedContentMatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) (r0 []*database.Monitor, r1 error) {
 				return
@@ -6717,6 +6821,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.TemplatedWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.WebhookAction, r1 error) {
 				return
@@ -6763,7 +6877,17 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (r0 *database.SlackWebhookAction, r1 error) {
+				return
+			},
+		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *database.TemplatedWebhookAction, r1 error) {
 				return
 			},
 		},
@@ -6773,7 +6897,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
+			defaultHook: func(context.Context, int32, string, result.Matches) (r0 error) {
 				return
 			},
 		},
@@ -6787,6 +6911,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertLastSearchedContentMatchesFunc: &CodeMonitorStoreUpsertLastSearchedContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string, []string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -6799,6 +6928,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.Clock")
 			},
 		},
+		CollectDigestActionJobsFunc: &CodeMonitorStoreCollectDigestActionJobsFunc{
+			defaultHook: func(context.Context, int32) ([]*database.ActionJobMetadata, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CollectDigestActionJobs")
+			},
+		},
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: func(context.Context, database.ListActionJobsOpts) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
@@ -6824,6 +6958,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountSlackWebhookActions")
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTeamsWebhookActions")
+			},
+		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTemplatedWebhookActions")
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountWebhookActions")
@@ -6850,10 +6994,20 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTeamsWebhookAction")
+			},
+		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTemplatedWebhookAction")
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
@@ -6884,6 +7038,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTeamsWebhookActions")
+			},
+		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTemplatedWebhookActions")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -6949,6 +7113,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTeamsWebhookAction")
+			},
+		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*database.TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTemplatedWebhookAction")
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetWebhookAction")
@@ -6974,6 +7148,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListLastSearchedContentMatchesFunc: &CodeMonitorStoreListLastSearchedContentMatchesFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListLastSearchedContentMatches")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) ([]*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -6994,6 +7173,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTeamsWebhookActions")
+			},
+		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTemplatedWebhookActions")
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
@@ -7040,17 +7229,27 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTeamsWebhookAction")
+			},
+		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTemplatedWebhookAction")
+			},
+		},
 		UpdateTriggerJobWithLogsFunc: &CodeMonitorStoreUpdateTriggerJobWithLogsFunc{
 			defaultHook: func(context.Context, int32, database.TriggerJobLogs) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithLogs")
 			},
 		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
+			defaultHook: func(context.Context, int32, string, result.Matches) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
 			},
 		},
@@ -7064,6 +7263,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
 			},
 		},
+		UpsertLastSearchedContentMatchesFunc: &CodeMonitorStoreUpsertLastSearchedContentMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearchedContentMatches")
+			},
+		},
 	}
 }
 
@@ -7075,6 +7279,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ClockFunc: &CodeMonitorStoreClockFunc{
 			defaultHook: i.Clock,
 		},
+		CollectDigestActionJobsFunc: &CodeMonitorStoreCollectDigestActionJobsFunc{
+			defaultHook: i.CollectDigestActionJobs,
+		},
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
@@ -7090,6 +7297,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CountSlackWebhookActionsFunc: &CodeMonitorStoreCountSlackWebhookActionsFunc{
 			defaultHook: i.CountSlackWebhookActions,
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: i.CountTeamsWebhookActions,
+		},
+		CountTemplatedWebhookActionsFunc: &CodeMonitorStoreCountTemplatedWebhookActionsFunc{
+			defaultHook: i.CountTemplatedWebhookActions,
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: i.CountWebhookActions,
 		},
@@ -7108,6 +7321,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
 			defaultHook: i.CreateSlackWebhookAction,
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: i.CreateTeamsWebhookAction,
+		},
+		CreateTemplatedWebhookActionFunc: &CodeMonitorStoreCreateTemplatedWebhookActionFunc{
+			defaultHook: i.CreateTemplatedWebhookAction,
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: i.CreateWebhookAction,
 		},
@@ -7126,6 +7345,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: i.DeleteTeamsWebhookActions,
+		},
+		DeleteTemplatedWebhookActionsFunc: &CodeMonitorStoreDeleteTemplatedWebhookActionsFunc{
+			defaultHook: i.DeleteTemplatedWebhookActions,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -7165,6 +7390,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: i.GetTeamsWebhookAction,
+		},
+		GetTemplatedWebhookActionFunc: &CodeMonitorStoreGetTemplatedWebhookActionFunc{
+			defaultHook: i.GetTemplatedWebhookAction,
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: i.GetWebhookAction,
 		},
@@ -7180,6 +7411,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListLastSearchedContentMatchesFunc: &CodeMonitorStoreListLastSearchedContentMatchesFunc{
+			defaultHook: i.ListLastSearchedContentMatches,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -7192,6 +7426,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: i.ListTeamsWebhookActions,
+		},
+		ListTemplatedWebhookActionsFunc: &CodeMonitorStoreListTemplatedWebhookActionsFunc{
+			defaultHook: i.ListTemplatedWebhookActions,
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
@@ -7222,6 +7462,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: i.UpdateTeamsWebhookAction,
+		},
+		UpdateTemplatedWebhookActionFunc: &CodeMonitorStoreUpdateTemplatedWebhookActionFunc{
+			defaultHook: i.UpdateTemplatedWebhookAction,
+		},
 		UpdateTriggerJobWithLogsFunc: &CodeMonitorStoreUpdateTriggerJobWithLogsFunc{
 			defaultHook: i.UpdateTriggerJobWithLogs,
 		},
@@ -7234,6 +7480,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
+		UpsertLastSearchedContentMatchesFunc: &CodeMonitorStoreUpsertLastSearchedContentMatchesFunc{
+			defaultHook: i.UpsertLastSearchedContentMatches,
+		},
 	}
 }
 
@@ -7336,6 +7585,118 @@ func (c CodeMonitorStoreClockFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreCollectDigestActionJobsFunc describes the behavior when
+// the CollectDigestActionJobs method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCollectDigestActionJobsFunc struct {
+	defaultHook func(context.Context, int32) ([]*database.ActionJobMetadata, error)
+	hooks       []func(context.Context, int32) ([]*database.ActionJobMetadata, error)
+	history     []CodeMonitorStoreCollectDigestActionJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// CollectDigestActionJobs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CollectDigestActionJobs(v0 context.Context, v1 int32) ([]*database.ActionJobMetadata, error) {
+	r0, r1 := m.CollectDigestActionJobsFunc.nextHook()(v0, v1)
+	m.CollectDigestActionJobsFunc.appendCall(CodeMonitorStoreCollectDigestActionJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CollectDigestActionJobs method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) SetDefaultHook(hook func(context.Context, int32) ([]*database.ActionJobMetadata, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CollectDigestActionJobs method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) PushHook(hook func(context.Context, int32) ([]*database.ActionJobMetadata, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) SetDefaultReturn(r0 []*database.ActionJobMetadata, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*database.ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) PushReturn(r0 []*database.ActionJobMetadata, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*database.ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) nextHook() func(context.Context, int32) ([]*database.ActionJobMetadata, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) appendCall(r0 CodeMonitorStoreCollectDigestActionJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCollectDigestActionJobsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCollectDigestActionJobsFunc) History() []CodeMonitorStoreCollectDigestActionJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCollectDigestActionJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCollectDigestActionJobsFuncCall is an object that
+// describes an invocation of method CollectDigestActionJobs on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCollectDigestActionJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.ActionJobMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCollectDigestActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCollectDigestActionJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountActionJobsFunc describes the behavior when the
 // CountActionJobs method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -7892,6 +8253,230 @@ func (c CodeMonitorStoreCountSlackWebhookActionsFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountTeamsWebhookActionsFunc describes the behavior when
+// the CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCountTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTeamsWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreCountTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) History() []CodeMonitorStoreCountTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCountTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCountTemplatedWebhookActionsFunc describes the behavior
+// when the CountTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCountTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountTemplatedWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTemplatedWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountTemplatedWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreCountTemplatedWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCountTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCountTemplatedWebhookActionsFunc) History() []CodeMonitorStoreCountTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCountTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
 // CountWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -8462,24 +9047,24 @@ func (c CodeMonitorStoreCreateRecipientFuncCall) Results() []interface{} {
 // the CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreCreateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)
 	history     []CodeMonitorStoreCreateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.SlackWebhookAction, error) {
-	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
+	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -8488,7 +9073,7 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -8497,19 +9082,19 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -8559,6 +9144,9 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 database.CodeMonitorDigest
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.SlackWebhookAction
@@ -8570,7 +9158,7 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -8579,6 +9167,251 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateTeamsWebhookActionFunc describes the behavior when
+// the CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCreateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreCreateTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.CreateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreCreateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) History() []CodeMonitorStoreCreateTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCreateTemplatedWebhookActionFunc describes the behavior
+// when the CreateTemplatedWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCreateTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreCreateTemplatedWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTemplatedWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*database.TemplatedWebhookAction, error) {
+	r0, r1 := m.CreateTemplatedWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreCreateTemplatedWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) SetDefaultReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) PushReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCreateTemplatedWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCreateTemplatedWebhookActionFunc) History() []CodeMonitorStoreCreateTemplatedWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateTemplatedWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTemplatedWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateTemplatedWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
 // CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -9253,6 +10086,244 @@ func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteTeamsWebhookActionsFunc describes the behavior when
+// the DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteTeamsWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteTeamsWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteTeamsWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) History() []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTeamsWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFunc describes the behavior
+// when the DeleteTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreDeleteTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteTemplatedWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteTemplatedWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreDeleteTemplatedWebhookActionsFunc) History() []CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteWebhookActionsFunc describes the behavior when the
 // DeleteWebhookActions method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -10692,6 +11763,230 @@ func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetTeamsWebhookActionFunc describes the behavior when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreGetTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetTeamsWebhookAction(v0 context.Context, v1 int64) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.GetTeamsWebhookActionFunc.nextHook()(v0, v1)
+	m.GetTeamsWebhookActionFunc.appendCall(CodeMonitorStoreGetTeamsWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) nextHook() func(context.Context, int64) (*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) History() []CodeMonitorStoreGetTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall is an object that describes
+// an invocation of method GetTeamsWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetTemplatedWebhookActionFunc describes the behavior when
+// the GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreGetTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.TemplatedWebhookAction, error)
+	hooks       []func(context.Context, int64) (*database.TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreGetTemplatedWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetTemplatedWebhookAction(v0 context.Context, v1 int64) (*database.TemplatedWebhookAction, error) {
+	r0, r1 := m.GetTemplatedWebhookActionFunc.nextHook()(v0, v1)
+	m.GetTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreGetTemplatedWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) SetDefaultReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) PushReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) nextHook() func(context.Context, int64) (*database.TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTemplatedWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetTemplatedWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetTemplatedWebhookActionFunc) History() []CodeMonitorStoreGetTemplatedWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetTemplatedWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method GetTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetTemplatedWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetTemplatedWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetWebhookActionFunc describes the behavior when the
 // GetWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -11234,6 +12529,118 @@ func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListLastSearchedContentMatchesFunc describes the behavior
+// when the ListLastSearchedContentMatches method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreListLastSearchedContentMatchesFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID][]string, error)
+	history     []CodeMonitorStoreListLastSearchedContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListLastSearchedContentMatches delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListLastSearchedContentMatches(v0 context.Context, v1 int64) (map[api.RepoID][]string, error) {
+	r0, r1 := m.ListLastSearchedContentMatchesFunc.nextHook()(v0, v1)
+	m.ListLastSearchedContentMatchesFunc.appendCall(CodeMonitorStoreListLastSearchedContentMatchesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListLastSearchedContentMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListLastSearchedContentMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) nextHook() func(context.Context, int64) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) appendCall(r0 CodeMonitorStoreListLastSearchedContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListLastSearchedContentMatchesFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreListLastSearchedContentMatchesFunc) History() []CodeMonitorStoreListLastSearchedContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListLastSearchedContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListLastSearchedContentMatchesFuncCall is an object that
+// describes an invocation of method ListLastSearchedContentMatches on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreListLastSearchedContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListMonitorsFunc describes the behavior when the
 // ListMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -11677,6 +13084,230 @@ func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListTeamsWebhookActionsFunc describes the behavior when
+// the ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreListTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreListTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListTeamsWebhookActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+	r0, r1 := m.ListTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreListTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultReturn(r0 []*database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushReturn(r0 []*database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) History() []CodeMonitorStoreListTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreListTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListTemplatedWebhookActionsFunc describes the behavior
+// when the ListTemplatedWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreListTemplatedWebhookActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreListTemplatedWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTemplatedWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListTemplatedWebhookActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error) {
+	r0, r1 := m.ListTemplatedWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListTemplatedWebhookActionsFunc.appendCall(CodeMonitorStoreListTemplatedWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTemplatedWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) SetDefaultReturn(r0 []*database.TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) PushReturn(r0 []*database.TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTemplatedWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListTemplatedWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreListTemplatedWebhookActionsFunc) History() []CodeMonitorStoreListTemplatedWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListTemplatedWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListTemplatedWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTemplatedWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreListTemplatedWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListTemplatedWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListTemplatedWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListWebhookActionsFunc describes the behavior when the
 // ListWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -12673,24 +14304,24 @@ func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
 // the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)
 	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.SlackWebhookAction, error) {
-	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
+	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -12699,7 +14330,7 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12708,19 +14339,19 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, database.CodeMonitorDigest) (*database.SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12770,6 +14401,9 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 database.CodeMonitorDigest
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.SlackWebhookAction
@@ -12781,7 +14415,7 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -12790,6 +14424,251 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTeamsWebhookActionFunc describes the behavior when
+// the UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpdateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.UpdateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) History() []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreUpdateTemplatedWebhookActionFunc describes the behavior
+// when the UpdateTemplatedWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTemplatedWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTemplatedWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTemplatedWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*database.TemplatedWebhookAction, error) {
+	r0, r1 := m.UpdateTemplatedWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateTemplatedWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTemplatedWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) SetDefaultReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) PushReturn(r0 *database.TemplatedWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*database.TemplatedWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateTemplatedWebhookActionFunc) History() []CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTemplatedWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TemplatedWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTemplatedWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithLogsFunc describes the behavior when
 // the UpdateTriggerJobWithLogs method of the parent MockCodeMonitorStore
 // instance is invoked.
@@ -12906,15 +14785,15 @@ func (c CodeMonitorStoreUpdateTriggerJobWithLogsFuncCall) Results() []interface{
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
 type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
-	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
-	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
+	defaultHook func(context.Context, int32, string, result.Matches) error
+	hooks       []func(context.Context, int32, string, result.Matches) error
 	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateTriggerJobWithResults delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 result.Matches) error {
 	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
 	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
 	return r0
@@ -12923,7 +14802,7 @@ func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v
 // SetDefaultHook sets function that is called when the
 // UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, result.Matches) error) {
 	f.defaultHook = hook
 }
 
@@ -12932,7 +14811,7 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook fu
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, result.Matches) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12941,19 +14820,19 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.SetDefaultHook(func(context.Context, int32, string, result.Matches) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.PushHook(func(context.Context, int32, string, result.Matches) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, result.Matches) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12999,7 +14878,7 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 []*result.CommitMatch
+	Arg3 result.Matches
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -13251,6 +15130,124 @@ func (c CodeMonitorStoreUpsertLastSearchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreUpsertLastSearchedContentMatchesFunc describes the
+// behavior when the UpsertLastSearchedContentMatches method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpsertLastSearchedContentMatchesFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string, []string) error
+	history     []CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastSearchedContentMatches delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastSearchedContentMatches(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string, v4 []string) error {
+	r0 := m.UpsertLastSearchedContentMatchesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpsertLastSearchedContentMatchesFunc.appendCall(CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertLastSearchedContentMatches method of the parent
+// MockCodeMonitorStore instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastSearchedContentMatches method of the parent
+// MockCodeMonitorStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) nextHook() func(context.Context, int64, api.RepoID, []string, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) appendCall(r0 CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpsertLastSearchedContentMatchesFunc) History() []CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall is an object
+// that describes an invocation of method UpsertLastSearchedContentMatches
+// on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedContentMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockCodeownersStore is a mock implementation of the CodeownersStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
@@ -15289,6 +17286,10 @@ type MockDB struct {
 	// RepoCommitsChangelistsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoCommitsChangelists.
 	RepoCommitsChangelistsFunc *DBRepoCommitsChangelistsFunc
+	// RepoCommitsSubversionRevisionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// RepoCommitsSubversionRevisions.
+	RepoCommitsSubversionRevisionsFunc *DBRepoCommitsSubversionRevisionsFunc
 	// RepoKVPsFunc is an instance of a mock function object controlling the
 	// behavior of the method RepoKVPs.
 	RepoKVPsFunc *DBRepoKVPsFunc
@@ -15344,6 +17345,9 @@ type MockDB struct {
 	// UserRolesFunc is an instance of a mock function object controlling
 	// the behavior of the method UserRoles.
 	UserRolesFunc *DBUserRolesFunc
+	// UserSecondFactorsFunc is an instance of a mock function object
+	// controlling the behavior of the method UserSecondFactors.
+	UserSecondFactorsFunc *DBUserSecondFactorsFunc
 	// UsersFunc is an instance of a mock function object controlling the
 	// behavior of the method Users.
 	UsersFunc *DBUsersFunc
@@ -15580,6 +17584,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		RepoCommitsSubversionRevisionsFunc: &DBRepoCommitsSubversionRevisionsFunc{
+			defaultHook: func() (r0 database.RepoCommitsSubversionRevisionsStore) {
+				return
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() (r0 database.RepoKVPStore) {
 				return
@@ -15670,6 +17679,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		UserSecondFactorsFunc: &DBUserSecondFactorsFunc{
+			defaultHook: func(encryption.Key) (r0 database.UserSecondFactorStore) {
+				return
+			},
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: func() (r0 database.UserStore) {
 				return
@@ -15917,6 +17931,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.RepoCommitsChangelists")
 			},
 		},
+		RepoCommitsSubversionRevisionsFunc: &DBRepoCommitsSubversionRevisionsFunc{
+			defaultHook: func() database.RepoCommitsSubversionRevisionsStore {
+				panic("unexpected invocation of MockDB.RepoCommitsSubversionRevisions")
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() database.RepoKVPStore {
 				panic("unexpected invocation of MockDB.RepoKVPs")
@@ -16007,6 +18026,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.UserRoles")
 			},
 		},
+		UserSecondFactorsFunc: &DBUserSecondFactorsFunc{
+			defaultHook: func(encryption.Key) database.UserSecondFactorStore {
+				panic("unexpected invocation of MockDB.UserSecondFactors")
+			},
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: func() database.UserStore {
 				panic("unexpected invocation of MockDB.Users")
@@ -16168,6 +18192,9 @@ func NewMockDBFrom(i database.DB) *MockDB {
 		RepoCommitsChangelistsFunc: &DBRepoCommitsChangelistsFunc{
 			defaultHook: i.RepoCommitsChangelists,
 		},
+		RepoCommitsSubversionRevisionsFunc: &DBRepoCommitsSubversionRevisionsFunc{
+			defaultHook: i.RepoCommitsSubversionRevisions,
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: i.RepoKVPs,
 		},
@@ -16222,6 +18249,9 @@ func NewMockDBFrom(i database.DB) *MockDB {
 		UserRolesFunc: &DBUserRolesFunc{
 			defaultHook: i.UserRoles,
 		},
+		UserSecondFactorsFunc: &DBUserSecondFactorsFunc{
+			defaultHook: i.UserSecondFactors,
+		},
 		UsersFunc: &DBUsersFunc{
 			defaultHook: i.Users,
 		},
@@ -20543,6 +22573,108 @@ func (c DBRepoCommitsChangelistsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRepoCommitsSubversionRevisionsFunc describes the behavior when the
+// RepoCommitsSubversionRevisions method of the parent MockDB instance is
+// invoked.
+type DBRepoCommitsSubversionRevisionsFunc struct {
+	defaultHook func() database.RepoCommitsSubversionRevisionsStore
+	hooks       []func() database.RepoCommitsSubversionRevisionsStore
+	history     []DBRepoCommitsSubversionRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoCommitsSubversionRevisions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDB) RepoCommitsSubversionRevisions() database.RepoCommitsSubversionRevisionsStore {
+	r0 := m.RepoCommitsSubversionRevisionsFunc.nextHook()()
+	m.RepoCommitsSubversionRevisionsFunc.appendCall(DBRepoCommitsSubversionRevisionsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RepoCommitsSubversionRevisions method of the parent MockDB instance is
+// invoked and the hook queue is empty.
+func (f *DBRepoCommitsSubversionRevisionsFunc) SetDefaultHook(hook func() database.RepoCommitsSubversionRevisionsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoCommitsSubversionRevisions method of the parent MockDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBRepoCommitsSubversionRevisionsFunc) PushHook(hook func() database.RepoCommitsSubversionRevisionsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRepoCommitsSubversionRevisionsFunc) SetDefaultReturn(r0 database.RepoCommitsSubversionRevisionsStore) {
+	f.SetDefaultHook(func() database.RepoCommitsSubversionRevisionsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRepoCommitsSubversionRevisionsFunc) PushReturn(r0 database.RepoCommitsSubversionRevisionsStore) {
+	f.PushHook(func() database.RepoCommitsSubversionRevisionsStore {
+		return r0
+	})
+}
+
+func (f *DBRepoCommitsSubversionRevisionsFunc) nextHook() func() database.RepoCommitsSubversionRevisionsStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRepoCommitsSubversionRevisionsFunc) appendCall(r0 DBRepoCommitsSubversionRevisionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRepoCommitsSubversionRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *DBRepoCommitsSubversionRevisionsFunc) History() []DBRepoCommitsSubversionRevisionsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRepoCommitsSubversionRevisionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRepoCommitsSubversionRevisionsFuncCall is an object that describes an
+// invocation of method RepoCommitsSubversionRevisions on an instance of
+// MockDB.
+type DBRepoCommitsSubversionRevisionsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoCommitsSubversionRevisionsStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRepoCommitsSubversionRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRepoCommitsSubversionRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBRepoKVPsFunc describes the behavior when the RepoKVPs method of the
 // parent MockDB instance is invoked.
 type DBRepoKVPsFunc struct {
@@ -22319,6 +24451,108 @@ func (c DBUserRolesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBUserSecondFactorsFunc describes the behavior when the UserSecondFactors
+// method of the parent MockDB instance is invoked.
+type DBUserSecondFactorsFunc struct {
+	defaultHook func(encryption.Key) database.UserSecondFactorStore
+	hooks       []func(encryption.Key) database.UserSecondFactorStore
+	history     []DBUserSecondFactorsFuncCall
+	mutex       sync.Mutex
+}
+
+// UserSecondFactors delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDB) UserSecondFactors(v0 encryption.Key) database.UserSecondFactorStore {
+	r0 := m.UserSecondFactorsFunc.nextHook()(v0)
+	m.UserSecondFactorsFunc.appendCall(DBUserSecondFactorsFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UserSecondFactors
+// method of the parent MockDB instance is invoked and the hook queue is
+// empty.
+func (f *DBUserSecondFactorsFunc) SetDefaultHook(hook func(encryption.Key) database.UserSecondFactorStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UserSecondFactors method of the parent MockDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBUserSecondFactorsFunc) PushHook(hook func(encryption.Key) database.UserSecondFactorStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBUserSecondFactorsFunc) SetDefaultReturn(r0 database.UserSecondFactorStore) {
+	f.SetDefaultHook(func(encryption.Key) database.UserSecondFactorStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBUserSecondFactorsFunc) PushReturn(r0 database.UserSecondFactorStore) {
+	f.PushHook(func(encryption.Key) database.UserSecondFactorStore {
+		return r0
+	})
+}
+
+func (f *DBUserSecondFactorsFunc) nextHook() func(encryption.Key) database.UserSecondFactorStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBUserSecondFactorsFunc) appendCall(r0 DBUserSecondFactorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBUserSecondFactorsFuncCall objects
+// describing the invocations of this function.
+func (f *DBUserSecondFactorsFunc) History() []DBUserSecondFactorsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBUserSecondFactorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBUserSecondFactorsFuncCall is an object that describes an invocation of
+// method UserSecondFactors on an instance of MockDB.
+type DBUserSecondFactorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 encryption.Key
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.UserSecondFactorStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBUserSecondFactorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBUserSecondFactorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBUsersFunc describes the behavior when the Users method of the parent
 // MockDB instance is invoked.
 type DBUsersFunc struct {
@@ -60094,97 +62328,138 @@ func (c RepoCommitsChangelistsStoreGetRepoCommitChangelistFuncCall) Results() []
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockRepoPathStore is a mock implementation of the RepoPathStore interface
-// (from the package github.com/sourcegraph/sourcegraph/internal/database)
-// used for unit testing.
-type MockRepoPathStore struct {
-	// AggregateFileCountFunc is an instance of a mock function object
-	// controlling the behavior of the method AggregateFileCount.
-	AggregateFileCountFunc *RepoPathStoreAggregateFileCountFunc
-	// UpdateFileCountsFunc is an instance of a mock function object
-	// controlling the behavior of the method UpdateFileCounts.
-	UpdateFileCountsFunc *RepoPathStoreUpdateFileCountsFunc
+// MockRepoCommitsSubversionRevisionsStore is a mock implementation of the
+// RepoCommitsSubversionRevisionsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRepoCommitsSubversionRevisionsStore struct {
+	// BatchGetRepoCommitSubversionRevisionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// BatchGetRepoCommitSubversionRevision.
+	BatchGetRepoCommitSubversionRevisionFunc *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc
+	// BatchInsertCommitSHAsWithSubversionRevisionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// BatchInsertCommitSHAsWithSubversionRevision.
+	BatchInsertCommitSHAsWithSubversionRevisionFunc *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc
+	// GetLatestForRepoFunc is an instance of a mock function object controlling
+	// the behavior of the method GetLatestForRepo.
+	GetLatestForRepoFunc *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc
+	// GetRepoCommitSubversionRevisionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetRepoCommitSubversionRevision.
+	GetRepoCommitSubversionRevisionFunc *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc
 }
 
-// NewMockRepoPathStore creates a new mock of the RepoPathStore interface.
-// All methods return zero values for all results, unless overwritten.
-func NewMockRepoPathStore() *MockRepoPathStore {
-	return &MockRepoPathStore{
-		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
-			defaultHook: func(context.Context, database.TreeLocationOpts) (r0 int32, r1 error) {
+// NewMockRepoCommitsSubversionRevisionsStore creates a new mock of the
+// RepoCommitsSubversionRevisionsStore interface. All methods return zero
+// values for all results, unless overwritten.
+func NewMockRepoCommitsSubversionRevisionsStore() *MockRepoCommitsSubversionRevisionsStore {
+	return &MockRepoCommitsSubversionRevisionsStore{
+		BatchGetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc{
+			defaultHook: func(context.Context, ...database.RepoSubversionRevisions) (r0 map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, r1 error) {
 				return
 			},
 		},
-		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
-			defaultHook: func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (r0 int, r1 error) {
+		BatchInsertCommitSHAsWithSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc{
+			defaultHook: func(context.Context, api.RepoID, []types.SubversionRevision) (r0 error) {
+				return
+			},
+		},
+		GetLatestForRepoFunc: &RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *types.RepoCommitSubversionRevision, r1 error) {
+				return
+			},
+		},
+		GetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc{
+			defaultHook: func(context.Context, api.RepoID, int64) (r0 *types.RepoCommitSubversionRevision, r1 error) {
 				return
 			},
 		},
 	}
 }
 
-// NewStrictMockRepoPathStore creates a new mock of the RepoPathStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockRepoPathStore() *MockRepoPathStore {
-	return &MockRepoPathStore{
-		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
-			defaultHook: func(context.Context, database.TreeLocationOpts) (int32, error) {
-				panic("unexpected invocation of MockRepoPathStore.AggregateFileCount")
+// NewStrictMockRepoCommitsSubversionRevisionsStore creates a new mock of
+// the RepoCommitsSubversionRevisionsStore interface. All methods panic on
+// invocation, unless overwritten.
+func NewStrictMockRepoCommitsSubversionRevisionsStore() *MockRepoCommitsSubversionRevisionsStore {
+	return &MockRepoCommitsSubversionRevisionsStore{
+		BatchGetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc{
+			defaultHook: func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
+				panic("unexpected invocation of MockRepoCommitsSubversionRevisionsStore.BatchGetRepoCommitSubversionRevision")
 			},
 		},
-		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
-			defaultHook: func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
-				panic("unexpected invocation of MockRepoPathStore.UpdateFileCounts")
+		BatchInsertCommitSHAsWithSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc{
+			defaultHook: func(context.Context, api.RepoID, []types.SubversionRevision) error {
+				panic("unexpected invocation of MockRepoCommitsSubversionRevisionsStore.BatchInsertCommitSHAsWithSubversionRevision")
+			},
+		},
+		GetLatestForRepoFunc: &RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+				panic("unexpected invocation of MockRepoCommitsSubversionRevisionsStore.GetLatestForRepo")
+			},
+		},
+		GetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc{
+			defaultHook: func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error) {
+				panic("unexpected invocation of MockRepoCommitsSubversionRevisionsStore.GetRepoCommitSubversionRevision")
 			},
 		},
 	}
 }
 
-// NewMockRepoPathStoreFrom creates a new mock of the MockRepoPathStore
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockRepoPathStoreFrom(i database.RepoPathStore) *MockRepoPathStore {
-	return &MockRepoPathStore{
-		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
-			defaultHook: i.AggregateFileCount,
+// NewMockRepoCommitsSubversionRevisionsStoreFrom creates a new mock of the
+// MockRepoCommitsSubversionRevisionsStore interface. All methods delegate
+// to the given implementation, unless overwritten.
+func NewMockRepoCommitsSubversionRevisionsStoreFrom(i database.RepoCommitsSubversionRevisionsStore) *MockRepoCommitsSubversionRevisionsStore {
+	return &MockRepoCommitsSubversionRevisionsStore{
+		BatchGetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc{
+			defaultHook: i.BatchGetRepoCommitSubversionRevision,
 		},
-		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
-			defaultHook: i.UpdateFileCounts,
+		BatchInsertCommitSHAsWithSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc{
+			defaultHook: i.BatchInsertCommitSHAsWithSubversionRevision,
+		},
+		GetLatestForRepoFunc: &RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc{
+			defaultHook: i.GetLatestForRepo,
+		},
+		GetRepoCommitSubversionRevisionFunc: &RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc{
+			defaultHook: i.GetRepoCommitSubversionRevision,
 		},
 	}
 }
 
-// RepoPathStoreAggregateFileCountFunc describes the behavior when the
-// AggregateFileCount method of the parent MockRepoPathStore instance is
+// RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc
+// describes the behavior when the BatchGetRepoCommitSubversionRevision
+// method of the parent MockRepoCommitsSubversionRevisionsStore instance is
 // invoked.
-type RepoPathStoreAggregateFileCountFunc struct {
-	defaultHook func(context.Context, database.TreeLocationOpts) (int32, error)
-	hooks       []func(context.Context, database.TreeLocationOpts) (int32, error)
-	history     []RepoPathStoreAggregateFileCountFuncCall
+type RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc struct {
+	defaultHook func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error)
+	hooks       []func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error)
+	history     []RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall
 	mutex       sync.Mutex
 }
 
-// AggregateFileCount delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoPathStore) AggregateFileCount(v0 context.Context, v1 database.TreeLocationOpts) (int32, error) {
-	r0, r1 := m.AggregateFileCountFunc.nextHook()(v0, v1)
-	m.AggregateFileCountFunc.appendCall(RepoPathStoreAggregateFileCountFuncCall{v0, v1, r0, r1})
+// BatchGetRepoCommitSubversionRevision delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockRepoCommitsSubversionRevisionsStore) BatchGetRepoCommitSubversionRevision(v0 context.Context, v1 ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
+	r0, r1 := m.BatchGetRepoCommitSubversionRevisionFunc.nextHook()(v0, v1...)
+	m.BatchGetRepoCommitSubversionRevisionFunc.appendCall(RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the AggregateFileCount
-// method of the parent MockRepoPathStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the
+// BatchGetRepoCommitSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance is invoked and the hook
 // queue is empty.
-func (f *RepoPathStoreAggregateFileCountFunc) SetDefaultHook(hook func(context.Context, database.TreeLocationOpts) (int32, error)) {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) SetDefaultHook(hook func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// AggregateFileCount method of the parent MockRepoPathStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *RepoPathStoreAggregateFileCountFunc) PushHook(hook func(context.Context, database.TreeLocationOpts) (int32, error)) {
+// BatchGetRepoCommitSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) PushHook(hook func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -60192,20 +62467,20 @@ func (f *RepoPathStoreAggregateFileCountFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoPathStoreAggregateFileCountFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, database.TreeLocationOpts) (int32, error) {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) SetDefaultReturn(r0 map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoPathStoreAggregateFileCountFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, database.TreeLocationOpts) (int32, error) {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) PushReturn(r0 map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, r1 error) {
+	f.PushHook(func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoPathStoreAggregateFileCountFunc) nextHook() func(context.Context, database.TreeLocationOpts) (int32, error) {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) nextHook() func(context.Context, ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -60218,36 +62493,267 @@ func (f *RepoPathStoreAggregateFileCountFunc) nextHook() func(context.Context, d
 	return hook
 }
 
-func (f *RepoPathStoreAggregateFileCountFunc) appendCall(r0 RepoPathStoreAggregateFileCountFuncCall) {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) appendCall(r0 RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoPathStoreAggregateFileCountFuncCall
+// History returns a sequence of
+// RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall
 // objects describing the invocations of this function.
-func (f *RepoPathStoreAggregateFileCountFunc) History() []RepoPathStoreAggregateFileCountFuncCall {
+func (f *RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFunc) History() []RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoPathStoreAggregateFileCountFuncCall, len(f.history))
+	history := make([]RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoPathStoreAggregateFileCountFuncCall is an object that describes an
-// invocation of method AggregateFileCount on an instance of
-// MockRepoPathStore.
-type RepoPathStoreAggregateFileCountFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
+// RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall
+// is an object that describes an invocation of method
+// BatchGetRepoCommitSubversionRevision on an instance of
+// MockRepoCommitsSubversionRevisionsStore.
+type RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments passed to
+	// this method invocation.
+	Arg1 []database.RepoSubversionRevisions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision
+	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoCommitsSubversionRevisionsStoreBatchGetRepoCommitSubversionRevisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc
+// describes the behavior when the
+// BatchInsertCommitSHAsWithSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance is invoked.
+type RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc struct {
+	defaultHook func(context.Context, api.RepoID, []types.SubversionRevision) error
+	hooks       []func(context.Context, api.RepoID, []types.SubversionRevision) error
+	history     []RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall
+	mutex       sync.Mutex
+}
+
+// BatchInsertCommitSHAsWithSubversionRevision delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockRepoCommitsSubversionRevisionsStore) BatchInsertCommitSHAsWithSubversionRevision(v0 context.Context, v1 api.RepoID, v2 []types.SubversionRevision) error {
+	r0 := m.BatchInsertCommitSHAsWithSubversionRevisionFunc.nextHook()(v0, v1, v2)
+	m.BatchInsertCommitSHAsWithSubversionRevisionFunc.appendCall(RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// BatchInsertCommitSHAsWithSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []types.SubversionRevision) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BatchInsertCommitSHAsWithSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) PushHook(hook func(context.Context, api.RepoID, []types.SubversionRevision) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []types.SubversionRevision) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []types.SubversionRevision) error {
+		return r0
+	})
+}
+
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) nextHook() func(context.Context, api.RepoID, []types.SubversionRevision) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) appendCall(r0 RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall
+// objects describing the invocations of this function.
+func (f *RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFunc) History() []RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall
+// is an object that describes an invocation of method
+// BatchInsertCommitSHAsWithSubversionRevision on an instance of
+// MockRepoCommitsSubversionRevisionsStore.
+type RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 []types.SubversionRevision
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Arg1 database.TreeLocationOpts
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoCommitsSubversionRevisionsStoreBatchInsertCommitSHAsWithSubversionRevisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc describes the
+// behavior when the GetLatestForRepo method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance is invoked.
+type RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error)
+	hooks       []func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error)
+	history     []RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// GetLatestForRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoCommitsSubversionRevisionsStore) GetLatestForRepo(v0 context.Context, v1 api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+	r0, r1 := m.GetLatestForRepoFunc.nextHook()(v0, v1)
+	m.GetLatestForRepoFunc.appendCall(RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetLatestForRepo
+// method of the parent MockRepoCommitsSubversionRevisionsStore instance is
+// invoked and the hook queue is empty.
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLatestForRepo method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) PushHook(hook func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) SetDefaultReturn(r0 *types.RepoCommitSubversionRevision, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) PushReturn(r0 *types.RepoCommitSubversionRevision, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) nextHook() func(context.Context, api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) appendCall(r0 RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall objects
+// describing the invocations of this function.
+func (f *RepoCommitsSubversionRevisionsStoreGetLatestForRepoFunc) History() []RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall is an object
+// that describes an invocation of method GetLatestForRepo on an instance of
+// MockRepoCommitsSubversionRevisionsStore.
+type RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 *types.RepoCommitSubversionRevision
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -60255,46 +62761,48 @@ type RepoPathStoreAggregateFileCountFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoPathStoreAggregateFileCountFuncCall) Args() []interface{} {
+func (c RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoPathStoreAggregateFileCountFuncCall) Results() []interface{} {
+func (c RepoCommitsSubversionRevisionsStoreGetLatestForRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// RepoPathStoreUpdateFileCountsFunc describes the behavior when the
-// UpdateFileCounts method of the parent MockRepoPathStore instance is
-// invoked.
-type RepoPathStoreUpdateFileCountsFunc struct {
-	defaultHook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)
-	hooks       []func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)
-	history     []RepoPathStoreUpdateFileCountsFuncCall
+// RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc
+// describes the behavior when the GetRepoCommitSubversionRevision method of
+// the parent MockRepoCommitsSubversionRevisionsStore instance is invoked.
+type RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc struct {
+	defaultHook func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error)
+	hooks       []func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error)
+	history     []RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateFileCounts delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockRepoPathStore) UpdateFileCounts(v0 context.Context, v1 api.RepoID, v2 database.RepoTreeCounts, v3 time.Time) (int, error) {
-	r0, r1 := m.UpdateFileCountsFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateFileCountsFunc.appendCall(RepoPathStoreUpdateFileCountsFuncCall{v0, v1, v2, v3, r0, r1})
+// GetRepoCommitSubversionRevision delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockRepoCommitsSubversionRevisionsStore) GetRepoCommitSubversionRevision(v0 context.Context, v1 api.RepoID, v2 int64) (*types.RepoCommitSubversionRevision, error) {
+	r0, r1 := m.GetRepoCommitSubversionRevisionFunc.nextHook()(v0, v1, v2)
+	m.GetRepoCommitSubversionRevisionFunc.appendCall(RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the UpdateFileCounts
-// method of the parent MockRepoPathStore instance is invoked and the hook
+// SetDefaultHook sets function that is called when the
+// GetRepoCommitSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance is invoked and the hook
 // queue is empty.
-func (f *RepoPathStoreUpdateFileCountsFunc) SetDefaultHook(hook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)) {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) SetDefaultHook(hook func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateFileCounts method of the parent MockRepoPathStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *RepoPathStoreUpdateFileCountsFunc) PushHook(hook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)) {
+// GetRepoCommitSubversionRevision method of the parent
+// MockRepoCommitsSubversionRevisionsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) PushHook(hook func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -60302,20 +62810,20 @@ func (f *RepoPathStoreUpdateFileCountsFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoPathStoreUpdateFileCountsFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) SetDefaultReturn(r0 *types.RepoCommitSubversionRevision, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoPathStoreUpdateFileCountsFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) PushReturn(r0 *types.RepoCommitSubversionRevision, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoPathStoreUpdateFileCountsFunc) nextHook() func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) nextHook() func(context.Context, api.RepoID, int64) (*types.RepoCommitSubversionRevision, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -60328,42 +62836,38 @@ func (f *RepoPathStoreUpdateFileCountsFunc) nextHook() func(context.Context, api
 	return hook
 }
 
-func (f *RepoPathStoreUpdateFileCountsFunc) appendCall(r0 RepoPathStoreUpdateFileCountsFuncCall) {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) appendCall(r0 RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of RepoPathStoreUpdateFileCountsFuncCall
+// History returns a sequence of
+// RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall
 // objects describing the invocations of this function.
-func (f *RepoPathStoreUpdateFileCountsFunc) History() []RepoPathStoreUpdateFileCountsFuncCall {
+func (f *RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFunc) History() []RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall {
 	f.mutex.Lock()
-	history := make([]RepoPathStoreUpdateFileCountsFuncCall, len(f.history))
+	history := make([]RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// RepoPathStoreUpdateFileCountsFuncCall is an object that describes an
-// invocation of method UpdateFileCounts on an instance of
-// MockRepoPathStore.
-type RepoPathStoreUpdateFileCountsFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
+// RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall
+// is an object that describes an invocation of method
+// GetRepoCommitSubversionRevision on an instance of
+// MockRepoCommitsSubversionRevisionsStore.
+type RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
 	Arg1 api.RepoID
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 database.RepoTreeCounts
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 time.Time
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 *types.RepoCommitSubversionRevision
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -60371,52 +62875,339 @@ type RepoPathStoreUpdateFileCountsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c RepoPathStoreUpdateFileCountsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c RepoPathStoreUpdateFileCountsFuncCall) Results() []interface{} {
+func (c RepoCommitsSubversionRevisionsStoreGetRepoCommitSubversionRevisionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockRepoStatisticsStore is a mock implementation of the
-// RepoStatisticsStore interface (from the package
-// github.com/sourcegraph/sourcegraph/internal/database) used for unit
-// testing.
-type MockRepoStatisticsStore struct {
-	// CompactGitserverReposStatisticsFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// CompactGitserverReposStatistics.
-	CompactGitserverReposStatisticsFunc *RepoStatisticsStoreCompactGitserverReposStatisticsFunc
-	// CompactRepoStatisticsFunc is an instance of a mock function object
-	// controlling the behavior of the method CompactRepoStatistics.
-	CompactRepoStatisticsFunc *RepoStatisticsStoreCompactRepoStatisticsFunc
-	// DeleteAndRecreateStatisticsFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// DeleteAndRecreateStatistics.
-	DeleteAndRecreateStatisticsFunc *RepoStatisticsStoreDeleteAndRecreateStatisticsFunc
-	// GetGitserverReposStatisticsFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// GetGitserverReposStatistics.
-	GetGitserverReposStatisticsFunc *RepoStatisticsStoreGetGitserverReposStatisticsFunc
-	// GetRepoStatisticsFunc is an instance of a mock function object
-	// controlling the behavior of the method GetRepoStatistics.
-	GetRepoStatisticsFunc *RepoStatisticsStoreGetRepoStatisticsFunc
-	// HandleFunc is an instance of a mock function object controlling the
-	// behavior of the method Handle.
-	HandleFunc *RepoStatisticsStoreHandleFunc
-	// WithFunc is an instance of a mock function object controlling the
-	// behavior of the method With.
-	WithFunc *RepoStatisticsStoreWithFunc
-	// WithTransactFunc is an instance of a mock function object controlling
-	// the behavior of the method WithTransact.
-	WithTransactFunc *RepoStatisticsStoreWithTransactFunc
-}
-
-// NewMockRepoStatisticsStore creates a new mock of the RepoStatisticsStore
-// interface. All methods return zero values for all results, unless
+// MockRepoPathStore is a mock implementation of the RepoPathStore interface
+// (from the package github.com/sourcegraph/sourcegraph/internal/database)
+// used for unit testing.
+type MockRepoPathStore struct {
+	// AggregateFileCountFunc is an instance of a mock function object
+	// controlling the behavior of the method AggregateFileCount.
+	AggregateFileCountFunc *RepoPathStoreAggregateFileCountFunc
+	// UpdateFileCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateFileCounts.
+	UpdateFileCountsFunc *RepoPathStoreUpdateFileCountsFunc
+}
+
+// NewMockRepoPathStore creates a new mock of the RepoPathStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockRepoPathStore() *MockRepoPathStore {
+	return &MockRepoPathStore{
+		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
+			defaultHook: func(context.Context, database.TreeLocationOpts) (r0 int32, r1 error) {
+				return
+			},
+		},
+		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
+			defaultHook: func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoPathStore creates a new mock of the RepoPathStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockRepoPathStore() *MockRepoPathStore {
+	return &MockRepoPathStore{
+		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
+			defaultHook: func(context.Context, database.TreeLocationOpts) (int32, error) {
+				panic("unexpected invocation of MockRepoPathStore.AggregateFileCount")
+			},
+		},
+		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
+			defaultHook: func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+				panic("unexpected invocation of MockRepoPathStore.UpdateFileCounts")
+			},
+		},
+	}
+}
+
+// NewMockRepoPathStoreFrom creates a new mock of the MockRepoPathStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockRepoPathStoreFrom(i database.RepoPathStore) *MockRepoPathStore {
+	return &MockRepoPathStore{
+		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
+			defaultHook: i.AggregateFileCount,
+		},
+		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
+			defaultHook: i.UpdateFileCounts,
+		},
+	}
+}
+
+// RepoPathStoreAggregateFileCountFunc describes the behavior when the
+// AggregateFileCount method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStoreAggregateFileCountFunc struct {
+	defaultHook func(context.Context, database.TreeLocationOpts) (int32, error)
+	hooks       []func(context.Context, database.TreeLocationOpts) (int32, error)
+	history     []RepoPathStoreAggregateFileCountFuncCall
+	mutex       sync.Mutex
+}
+
+// AggregateFileCount delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) AggregateFileCount(v0 context.Context, v1 database.TreeLocationOpts) (int32, error) {
+	r0, r1 := m.AggregateFileCountFunc.nextHook()(v0, v1)
+	m.AggregateFileCountFunc.appendCall(RepoPathStoreAggregateFileCountFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AggregateFileCount
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStoreAggregateFileCountFunc) SetDefaultHook(hook func(context.Context, database.TreeLocationOpts) (int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AggregateFileCount method of the parent MockRepoPathStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoPathStoreAggregateFileCountFunc) PushHook(hook func(context.Context, database.TreeLocationOpts) (int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStoreAggregateFileCountFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.TreeLocationOpts) (int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStoreAggregateFileCountFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, database.TreeLocationOpts) (int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoPathStoreAggregateFileCountFunc) nextHook() func(context.Context, database.TreeLocationOpts) (int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStoreAggregateFileCountFunc) appendCall(r0 RepoPathStoreAggregateFileCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStoreAggregateFileCountFuncCall
+// objects describing the invocations of this function.
+func (f *RepoPathStoreAggregateFileCountFunc) History() []RepoPathStoreAggregateFileCountFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStoreAggregateFileCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStoreAggregateFileCountFuncCall is an object that describes an
+// invocation of method AggregateFileCount on an instance of
+// MockRepoPathStore.
+type RepoPathStoreAggregateFileCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.TreeLocationOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStoreAggregateFileCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStoreAggregateFileCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoPathStoreUpdateFileCountsFunc describes the behavior when the
+// UpdateFileCounts method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStoreUpdateFileCountsFunc struct {
+	defaultHook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)
+	hooks       []func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)
+	history     []RepoPathStoreUpdateFileCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateFileCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) UpdateFileCounts(v0 context.Context, v1 api.RepoID, v2 database.RepoTreeCounts, v3 time.Time) (int, error) {
+	r0, r1 := m.UpdateFileCountsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateFileCountsFunc.appendCall(RepoPathStoreUpdateFileCountsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateFileCounts
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStoreUpdateFileCountsFunc) SetDefaultHook(hook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateFileCounts method of the parent MockRepoPathStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoPathStoreUpdateFileCountsFunc) PushHook(hook func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStoreUpdateFileCountsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStoreUpdateFileCountsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoPathStoreUpdateFileCountsFunc) nextHook() func(context.Context, api.RepoID, database.RepoTreeCounts, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStoreUpdateFileCountsFunc) appendCall(r0 RepoPathStoreUpdateFileCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStoreUpdateFileCountsFuncCall
+// objects describing the invocations of this function.
+func (f *RepoPathStoreUpdateFileCountsFunc) History() []RepoPathStoreUpdateFileCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStoreUpdateFileCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStoreUpdateFileCountsFuncCall is an object that describes an
+// invocation of method UpdateFileCounts on an instance of
+// MockRepoPathStore.
+type RepoPathStoreUpdateFileCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 database.RepoTreeCounts
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStoreUpdateFileCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStoreUpdateFileCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockRepoStatisticsStore is a mock implementation of the
+// RepoStatisticsStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRepoStatisticsStore struct {
+	// CompactGitserverReposStatisticsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CompactGitserverReposStatistics.
+	CompactGitserverReposStatisticsFunc *RepoStatisticsStoreCompactGitserverReposStatisticsFunc
+	// CompactRepoStatisticsFunc is an instance of a mock function object
+	// controlling the behavior of the method CompactRepoStatistics.
+	CompactRepoStatisticsFunc *RepoStatisticsStoreCompactRepoStatisticsFunc
+	// DeleteAndRecreateStatisticsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteAndRecreateStatistics.
+	DeleteAndRecreateStatisticsFunc *RepoStatisticsStoreDeleteAndRecreateStatisticsFunc
+	// GetGitserverReposStatisticsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetGitserverReposStatistics.
+	GetGitserverReposStatisticsFunc *RepoStatisticsStoreGetGitserverReposStatisticsFunc
+	// GetRepoStatisticsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetRepoStatistics.
+	GetRepoStatisticsFunc *RepoStatisticsStoreGetRepoStatisticsFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *RepoStatisticsStoreHandleFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *RepoStatisticsStoreWithFunc
+	// WithTransactFunc is an instance of a mock function object controlling
+	// the behavior of the method WithTransact.
+	WithTransactFunc *RepoStatisticsStoreWithTransactFunc
+}
+
+// NewMockRepoStatisticsStore creates a new mock of the RepoStatisticsStore
+// interface. All methods return zero values for all results, unless
 // overwritten.
 func NewMockRepoStatisticsStore() *MockRepoStatisticsStore {
 	return &MockRepoStatisticsStore{
@@ -85775,6 +88566,2186 @@ func (c UserRoleStoreWithTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockUserSecondFactorStore is a mock implementation of the
+// UserSecondFactorStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockUserSecondFactorStore struct {
+	// ConfirmTOTPFunc is an instance of a mock function object controlling
+	// the behavior of the method ConfirmTOTP.
+	ConfirmTOTPFunc *UserSecondFactorStoreConfirmTOTPFunc
+	// CountUnusedRecoveryCodesFunc is an instance of a mock function object
+	// controlling the behavior of the method CountUnusedRecoveryCodes.
+	CountUnusedRecoveryCodesFunc *UserSecondFactorStoreCountUnusedRecoveryCodesFunc
+	// CreateTOTPFunc is an instance of a mock function object controlling
+	// the behavior of the method CreateTOTP.
+	CreateTOTPFunc *UserSecondFactorStoreCreateTOTPFunc
+	// CreateWebAuthnCredentialFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateWebAuthnCredential.
+	CreateWebAuthnCredentialFunc *UserSecondFactorStoreCreateWebAuthnCredentialFunc
+	// DeleteAllFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteAll.
+	DeleteAllFunc *UserSecondFactorStoreDeleteAllFunc
+	// DeleteTOTPFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteTOTP.
+	DeleteTOTPFunc *UserSecondFactorStoreDeleteTOTPFunc
+	// DeleteWebAuthnCredentialFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteWebAuthnCredential.
+	DeleteWebAuthnCredentialFunc *UserSecondFactorStoreDeleteWebAuthnCredentialFunc
+	// GetTOTPFunc is an instance of a mock function object controlling the
+	// behavior of the method GetTOTP.
+	GetTOTPFunc *UserSecondFactorStoreGetTOTPFunc
+	// GetWebAuthnCredentialFunc is an instance of a mock function object
+	// controlling the behavior of the method GetWebAuthnCredential.
+	GetWebAuthnCredentialFunc *UserSecondFactorStoreGetWebAuthnCredentialFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *UserSecondFactorStoreHandleFunc
+	// HasSecondFactorFunc is an instance of a mock function object
+	// controlling the behavior of the method HasSecondFactor.
+	HasSecondFactorFunc *UserSecondFactorStoreHasSecondFactorFunc
+	// ListWebAuthnCredentialsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListWebAuthnCredentials.
+	ListWebAuthnCredentialsFunc *UserSecondFactorStoreListWebAuthnCredentialsFunc
+	// ReplaceRecoveryCodesFunc is an instance of a mock function object
+	// controlling the behavior of the method ReplaceRecoveryCodes.
+	ReplaceRecoveryCodesFunc *UserSecondFactorStoreReplaceRecoveryCodesFunc
+	// UpdateWebAuthnSignCountFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateWebAuthnSignCount.
+	UpdateWebAuthnSignCountFunc *UserSecondFactorStoreUpdateWebAuthnSignCountFunc
+	// UseRecoveryCodeFunc is an instance of a mock function object
+	// controlling the behavior of the method UseRecoveryCode.
+	UseRecoveryCodeFunc *UserSecondFactorStoreUseRecoveryCodeFunc
+	// UseTOTPCounterFunc is an instance of a mock function object
+	// controlling the behavior of the method UseTOTPCounter.
+	UseTOTPCounterFunc *UserSecondFactorStoreUseTOTPCounterFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *UserSecondFactorStoreWithFunc
+}
+
+// NewMockUserSecondFactorStore creates a new mock of the
+// UserSecondFactorStore interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockUserSecondFactorStore() *MockUserSecondFactorStore {
+	return &MockUserSecondFactorStore{
+		ConfirmTOTPFunc: &UserSecondFactorStoreConfirmTOTPFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 error) {
+				return
+			},
+		},
+		CountUnusedRecoveryCodesFunc: &UserSecondFactorStoreCountUnusedRecoveryCodesFunc{
+			defaultHook: func(context.Context, int32) (r0 int, r1 error) {
+				return
+			},
+		},
+		CreateTOTPFunc: &UserSecondFactorStoreCreateTOTPFunc{
+			defaultHook: func(context.Context, int32, string) (r0 error) {
+				return
+			},
+		},
+		CreateWebAuthnCredentialFunc: &UserSecondFactorStoreCreateWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, *database.UserWebAuthnCredential) (r0 *database.UserWebAuthnCredential, r1 error) {
+				return
+			},
+		},
+		DeleteAllFunc: &UserSecondFactorStoreDeleteAllFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		DeleteTOTPFunc: &UserSecondFactorStoreDeleteTOTPFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
+		DeleteWebAuthnCredentialFunc: &UserSecondFactorStoreDeleteWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, int32, int32) (r0 error) {
+				return
+			},
+		},
+		GetTOTPFunc: &UserSecondFactorStoreGetTOTPFunc{
+			defaultHook: func(context.Context, int32) (r0 *database.UserTOTPCredential, r1 error) {
+				return
+			},
+		},
+		GetWebAuthnCredentialFunc: &UserSecondFactorStoreGetWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, int32, []byte) (r0 *database.UserWebAuthnCredential, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &UserSecondFactorStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		HasSecondFactorFunc: &UserSecondFactorStoreHasSecondFactorFunc{
+			defaultHook: func(context.Context, int32) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListWebAuthnCredentialsFunc: &UserSecondFactorStoreListWebAuthnCredentialsFunc{
+			defaultHook: func(context.Context, int32) (r0 []*database.UserWebAuthnCredential, r1 error) {
+				return
+			},
+		},
+		ReplaceRecoveryCodesFunc: &UserSecondFactorStoreReplaceRecoveryCodesFunc{
+			defaultHook: func(context.Context, int32, [][]byte) (r0 error) {
+				return
+			},
+		},
+		UpdateWebAuthnSignCountFunc: &UserSecondFactorStoreUpdateWebAuthnSignCountFunc{
+			defaultHook: func(context.Context, int32, uint32) (r0 bool, r1 error) {
+				return
+			},
+		},
+		UseRecoveryCodeFunc: &UserSecondFactorStoreUseRecoveryCodeFunc{
+			defaultHook: func(context.Context, int32, []byte) (r0 bool, r1 error) {
+				return
+			},
+		},
+		UseTOTPCounterFunc: &UserSecondFactorStoreUseTOTPCounterFunc{
+			defaultHook: func(context.Context, int32, int64) (r0 bool, r1 error) {
+				return
+			},
+		},
+		WithFunc: &UserSecondFactorStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 database.UserSecondFactorStore) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockUserSecondFactorStore creates a new mock of the
+// UserSecondFactorStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockUserSecondFactorStore() *MockUserSecondFactorStore {
+	return &MockUserSecondFactorStore{
+		ConfirmTOTPFunc: &UserSecondFactorStoreConfirmTOTPFunc{
+			defaultHook: func(context.Context, int32, int64) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.ConfirmTOTP")
+			},
+		},
+		CountUnusedRecoveryCodesFunc: &UserSecondFactorStoreCountUnusedRecoveryCodesFunc{
+			defaultHook: func(context.Context, int32) (int, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.CountUnusedRecoveryCodes")
+			},
+		},
+		CreateTOTPFunc: &UserSecondFactorStoreCreateTOTPFunc{
+			defaultHook: func(context.Context, int32, string) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.CreateTOTP")
+			},
+		},
+		CreateWebAuthnCredentialFunc: &UserSecondFactorStoreCreateWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.CreateWebAuthnCredential")
+			},
+		},
+		DeleteAllFunc: &UserSecondFactorStoreDeleteAllFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.DeleteAll")
+			},
+		},
+		DeleteTOTPFunc: &UserSecondFactorStoreDeleteTOTPFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.DeleteTOTP")
+			},
+		},
+		DeleteWebAuthnCredentialFunc: &UserSecondFactorStoreDeleteWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, int32, int32) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.DeleteWebAuthnCredential")
+			},
+		},
+		GetTOTPFunc: &UserSecondFactorStoreGetTOTPFunc{
+			defaultHook: func(context.Context, int32) (*database.UserTOTPCredential, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.GetTOTP")
+			},
+		},
+		GetWebAuthnCredentialFunc: &UserSecondFactorStoreGetWebAuthnCredentialFunc{
+			defaultHook: func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.GetWebAuthnCredential")
+			},
+		},
+		HandleFunc: &UserSecondFactorStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockUserSecondFactorStore.Handle")
+			},
+		},
+		HasSecondFactorFunc: &UserSecondFactorStoreHasSecondFactorFunc{
+			defaultHook: func(context.Context, int32) (bool, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.HasSecondFactor")
+			},
+		},
+		ListWebAuthnCredentialsFunc: &UserSecondFactorStoreListWebAuthnCredentialsFunc{
+			defaultHook: func(context.Context, int32) ([]*database.UserWebAuthnCredential, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.ListWebAuthnCredentials")
+			},
+		},
+		ReplaceRecoveryCodesFunc: &UserSecondFactorStoreReplaceRecoveryCodesFunc{
+			defaultHook: func(context.Context, int32, [][]byte) error {
+				panic("unexpected invocation of MockUserSecondFactorStore.ReplaceRecoveryCodes")
+			},
+		},
+		UpdateWebAuthnSignCountFunc: &UserSecondFactorStoreUpdateWebAuthnSignCountFunc{
+			defaultHook: func(context.Context, int32, uint32) (bool, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.UpdateWebAuthnSignCount")
+			},
+		},
+		UseRecoveryCodeFunc: &UserSecondFactorStoreUseRecoveryCodeFunc{
+			defaultHook: func(context.Context, int32, []byte) (bool, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.UseRecoveryCode")
+			},
+		},
+		UseTOTPCounterFunc: &UserSecondFactorStoreUseTOTPCounterFunc{
+			defaultHook: func(context.Context, int32, int64) (bool, error) {
+				panic("unexpected invocation of MockUserSecondFactorStore.UseTOTPCounter")
+			},
+		},
+		WithFunc: &UserSecondFactorStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) database.UserSecondFactorStore {
+				panic("unexpected invocation of MockUserSecondFactorStore.With")
+			},
+		},
+	}
+}
+
+// NewMockUserSecondFactorStoreFrom creates a new mock of the
+// MockUserSecondFactorStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockUserSecondFactorStoreFrom(i database.UserSecondFactorStore) *MockUserSecondFactorStore {
+	return &MockUserSecondFactorStore{
+		ConfirmTOTPFunc: &UserSecondFactorStoreConfirmTOTPFunc{
+			defaultHook: i.ConfirmTOTP,
+		},
+		CountUnusedRecoveryCodesFunc: &UserSecondFactorStoreCountUnusedRecoveryCodesFunc{
+			defaultHook: i.CountUnusedRecoveryCodes,
+		},
+		CreateTOTPFunc: &UserSecondFactorStoreCreateTOTPFunc{
+			defaultHook: i.CreateTOTP,
+		},
+		CreateWebAuthnCredentialFunc: &UserSecondFactorStoreCreateWebAuthnCredentialFunc{
+			defaultHook: i.CreateWebAuthnCredential,
+		},
+		DeleteAllFunc: &UserSecondFactorStoreDeleteAllFunc{
+			defaultHook: i.DeleteAll,
+		},
+		DeleteTOTPFunc: &UserSecondFactorStoreDeleteTOTPFunc{
+			defaultHook: i.DeleteTOTP,
+		},
+		DeleteWebAuthnCredentialFunc: &UserSecondFactorStoreDeleteWebAuthnCredentialFunc{
+			defaultHook: i.DeleteWebAuthnCredential,
+		},
+		GetTOTPFunc: &UserSecondFactorStoreGetTOTPFunc{
+			defaultHook: i.GetTOTP,
+		},
+		GetWebAuthnCredentialFunc: &UserSecondFactorStoreGetWebAuthnCredentialFunc{
+			defaultHook: i.GetWebAuthnCredential,
+		},
+		HandleFunc: &UserSecondFactorStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		HasSecondFactorFunc: &UserSecondFactorStoreHasSecondFactorFunc{
+			defaultHook: i.HasSecondFactor,
+		},
+		ListWebAuthnCredentialsFunc: &UserSecondFactorStoreListWebAuthnCredentialsFunc{
+			defaultHook: i.ListWebAuthnCredentials,
+		},
+		ReplaceRecoveryCodesFunc: &UserSecondFactorStoreReplaceRecoveryCodesFunc{
+			defaultHook: i.ReplaceRecoveryCodes,
+		},
+		UpdateWebAuthnSignCountFunc: &UserSecondFactorStoreUpdateWebAuthnSignCountFunc{
+			defaultHook: i.UpdateWebAuthnSignCount,
+		},
+		UseRecoveryCodeFunc: &UserSecondFactorStoreUseRecoveryCodeFunc{
+			defaultHook: i.UseRecoveryCode,
+		},
+		UseTOTPCounterFunc: &UserSecondFactorStoreUseTOTPCounterFunc{
+			defaultHook: i.UseTOTPCounter,
+		},
+		WithFunc: &UserSecondFactorStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// UserSecondFactorStoreConfirmTOTPFunc describes the behavior when the
+// ConfirmTOTP method of the parent MockUserSecondFactorStore instance is
+// invoked.
+type UserSecondFactorStoreConfirmTOTPFunc struct {
+	defaultHook func(context.Context, int32, int64) error
+	hooks       []func(context.Context, int32, int64) error
+	history     []UserSecondFactorStoreConfirmTOTPFuncCall
+	mutex       sync.Mutex
+}
+
+// ConfirmTOTP delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) ConfirmTOTP(v0 context.Context, v1 int32, v2 int64) error {
+	r0 := m.ConfirmTOTPFunc.nextHook()(v0, v1, v2)
+	m.ConfirmTOTPFunc.appendCall(UserSecondFactorStoreConfirmTOTPFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ConfirmTOTP method
+// of the parent MockUserSecondFactorStore instance is invoked and the hook
+// queue is empty.
+func (f *UserSecondFactorStoreConfirmTOTPFunc) SetDefaultHook(hook func(context.Context, int32, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ConfirmTOTP method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreConfirmTOTPFunc) PushHook(hook func(context.Context, int32, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreConfirmTOTPFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreConfirmTOTPFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, int64) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreConfirmTOTPFunc) nextHook() func(context.Context, int32, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreConfirmTOTPFunc) appendCall(r0 UserSecondFactorStoreConfirmTOTPFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreConfirmTOTPFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreConfirmTOTPFunc) History() []UserSecondFactorStoreConfirmTOTPFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreConfirmTOTPFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreConfirmTOTPFuncCall is an object that describes an
+// invocation of method ConfirmTOTP on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreConfirmTOTPFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreConfirmTOTPFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreConfirmTOTPFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreCountUnusedRecoveryCodesFunc describes the behavior
+// when the CountUnusedRecoveryCodes method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreCountUnusedRecoveryCodesFunc struct {
+	defaultHook func(context.Context, int32) (int, error)
+	hooks       []func(context.Context, int32) (int, error)
+	history     []UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall
+	mutex       sync.Mutex
+}
+
+// CountUnusedRecoveryCodes delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) CountUnusedRecoveryCodes(v0 context.Context, v1 int32) (int, error) {
+	r0, r1 := m.CountUnusedRecoveryCodesFunc.nextHook()(v0, v1)
+	m.CountUnusedRecoveryCodesFunc.appendCall(UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountUnusedRecoveryCodes method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) SetDefaultHook(hook func(context.Context, int32) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountUnusedRecoveryCodes method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) PushHook(hook func(context.Context, int32) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int32) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) nextHook() func(context.Context, int32) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) appendCall(r0 UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall objects describing
+// the invocations of this function.
+func (f *UserSecondFactorStoreCountUnusedRecoveryCodesFunc) History() []UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall is an object that
+// describes an invocation of method CountUnusedRecoveryCodes on an instance
+// of MockUserSecondFactorStore.
+type UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreCountUnusedRecoveryCodesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreCreateTOTPFunc describes the behavior when the
+// CreateTOTP method of the parent MockUserSecondFactorStore instance is
+// invoked.
+type UserSecondFactorStoreCreateTOTPFunc struct {
+	defaultHook func(context.Context, int32, string) error
+	hooks       []func(context.Context, int32, string) error
+	history     []UserSecondFactorStoreCreateTOTPFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateTOTP delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) CreateTOTP(v0 context.Context, v1 int32, v2 string) error {
+	r0 := m.CreateTOTPFunc.nextHook()(v0, v1, v2)
+	m.CreateTOTPFunc.appendCall(UserSecondFactorStoreCreateTOTPFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CreateTOTP method of
+// the parent MockUserSecondFactorStore instance is invoked and the hook
+// queue is empty.
+func (f *UserSecondFactorStoreCreateTOTPFunc) SetDefaultHook(hook func(context.Context, int32, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateTOTP method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreCreateTOTPFunc) PushHook(hook func(context.Context, int32, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreCreateTOTPFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreCreateTOTPFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreCreateTOTPFunc) nextHook() func(context.Context, int32, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreCreateTOTPFunc) appendCall(r0 UserSecondFactorStoreCreateTOTPFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreCreateTOTPFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreCreateTOTPFunc) History() []UserSecondFactorStoreCreateTOTPFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreCreateTOTPFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreCreateTOTPFuncCall is an object that describes an
+// invocation of method CreateTOTP on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreCreateTOTPFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreCreateTOTPFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreCreateTOTPFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreCreateWebAuthnCredentialFunc describes the behavior
+// when the CreateWebAuthnCredential method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreCreateWebAuthnCredentialFunc struct {
+	defaultHook func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error)
+	hooks       []func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error)
+	history     []UserSecondFactorStoreCreateWebAuthnCredentialFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateWebAuthnCredential delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) CreateWebAuthnCredential(v0 context.Context, v1 *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error) {
+	r0, r1 := m.CreateWebAuthnCredentialFunc.nextHook()(v0, v1)
+	m.CreateWebAuthnCredentialFunc.appendCall(UserSecondFactorStoreCreateWebAuthnCredentialFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) SetDefaultHook(hook func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) PushHook(hook func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) SetDefaultReturn(r0 *database.UserWebAuthnCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) PushReturn(r0 *database.UserWebAuthnCredential, r1 error) {
+	f.PushHook(func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) nextHook() func(context.Context, *database.UserWebAuthnCredential) (*database.UserWebAuthnCredential, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) appendCall(r0 UserSecondFactorStoreCreateWebAuthnCredentialFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreCreateWebAuthnCredentialFuncCall objects describing
+// the invocations of this function.
+func (f *UserSecondFactorStoreCreateWebAuthnCredentialFunc) History() []UserSecondFactorStoreCreateWebAuthnCredentialFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreCreateWebAuthnCredentialFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreCreateWebAuthnCredentialFuncCall is an object that
+// describes an invocation of method CreateWebAuthnCredential on an instance
+// of MockUserSecondFactorStore.
+type UserSecondFactorStoreCreateWebAuthnCredentialFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *database.UserWebAuthnCredential
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.UserWebAuthnCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreCreateWebAuthnCredentialFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreCreateWebAuthnCredentialFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreDeleteAllFunc describes the behavior when the
+// DeleteAll method of the parent MockUserSecondFactorStore instance is
+// invoked.
+type UserSecondFactorStoreDeleteAllFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserSecondFactorStoreDeleteAllFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteAll delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) DeleteAll(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteAllFunc.nextHook()(v0, v1)
+	m.DeleteAllFunc.appendCall(UserSecondFactorStoreDeleteAllFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteAll method of
+// the parent MockUserSecondFactorStore instance is invoked and the hook
+// queue is empty.
+func (f *UserSecondFactorStoreDeleteAllFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteAll method of the parent MockUserSecondFactorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserSecondFactorStoreDeleteAllFunc) PushHook(hook func(context.Context, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreDeleteAllFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreDeleteAllFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreDeleteAllFunc) nextHook() func(context.Context, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreDeleteAllFunc) appendCall(r0 UserSecondFactorStoreDeleteAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreDeleteAllFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreDeleteAllFunc) History() []UserSecondFactorStoreDeleteAllFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreDeleteAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreDeleteAllFuncCall is an object that describes an
+// invocation of method DeleteAll on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreDeleteAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreDeleteAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreDeleteAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreDeleteTOTPFunc describes the behavior when the
+// DeleteTOTP method of the parent MockUserSecondFactorStore instance is
+// invoked.
+type UserSecondFactorStoreDeleteTOTPFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []UserSecondFactorStoreDeleteTOTPFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteTOTP delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) DeleteTOTP(v0 context.Context, v1 int32) error {
+	r0 := m.DeleteTOTPFunc.nextHook()(v0, v1)
+	m.DeleteTOTPFunc.appendCall(UserSecondFactorStoreDeleteTOTPFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteTOTP method of
+// the parent MockUserSecondFactorStore instance is invoked and the hook
+// queue is empty.
+func (f *UserSecondFactorStoreDeleteTOTPFunc) SetDefaultHook(hook func(context.Context, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteTOTP method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreDeleteTOTPFunc) PushHook(hook func(context.Context, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreDeleteTOTPFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreDeleteTOTPFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreDeleteTOTPFunc) nextHook() func(context.Context, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreDeleteTOTPFunc) appendCall(r0 UserSecondFactorStoreDeleteTOTPFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreDeleteTOTPFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreDeleteTOTPFunc) History() []UserSecondFactorStoreDeleteTOTPFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreDeleteTOTPFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreDeleteTOTPFuncCall is an object that describes an
+// invocation of method DeleteTOTP on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreDeleteTOTPFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreDeleteTOTPFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreDeleteTOTPFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreDeleteWebAuthnCredentialFunc describes the behavior
+// when the DeleteWebAuthnCredential method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreDeleteWebAuthnCredentialFunc struct {
+	defaultHook func(context.Context, int32, int32) error
+	hooks       []func(context.Context, int32, int32) error
+	history     []UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteWebAuthnCredential delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) DeleteWebAuthnCredential(v0 context.Context, v1 int32, v2 int32) error {
+	r0 := m.DeleteWebAuthnCredentialFunc.nextHook()(v0, v1, v2)
+	m.DeleteWebAuthnCredentialFunc.appendCall(UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) SetDefaultHook(hook func(context.Context, int32, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) PushHook(hook func(context.Context, int32, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, int32) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) nextHook() func(context.Context, int32, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) appendCall(r0 UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall objects describing
+// the invocations of this function.
+func (f *UserSecondFactorStoreDeleteWebAuthnCredentialFunc) History() []UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall is an object that
+// describes an invocation of method DeleteWebAuthnCredential on an instance
+// of MockUserSecondFactorStore.
+type UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreDeleteWebAuthnCredentialFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreGetTOTPFunc describes the behavior when the GetTOTP
+// method of the parent MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreGetTOTPFunc struct {
+	defaultHook func(context.Context, int32) (*database.UserTOTPCredential, error)
+	hooks       []func(context.Context, int32) (*database.UserTOTPCredential, error)
+	history     []UserSecondFactorStoreGetTOTPFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTOTP delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) GetTOTP(v0 context.Context, v1 int32) (*database.UserTOTPCredential, error) {
+	r0, r1 := m.GetTOTPFunc.nextHook()(v0, v1)
+	m.GetTOTPFunc.appendCall(UserSecondFactorStoreGetTOTPFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetTOTP method of
+// the parent MockUserSecondFactorStore instance is invoked and the hook
+// queue is empty.
+func (f *UserSecondFactorStoreGetTOTPFunc) SetDefaultHook(hook func(context.Context, int32) (*database.UserTOTPCredential, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTOTP method of the parent MockUserSecondFactorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserSecondFactorStoreGetTOTPFunc) PushHook(hook func(context.Context, int32) (*database.UserTOTPCredential, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreGetTOTPFunc) SetDefaultReturn(r0 *database.UserTOTPCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*database.UserTOTPCredential, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreGetTOTPFunc) PushReturn(r0 *database.UserTOTPCredential, r1 error) {
+	f.PushHook(func(context.Context, int32) (*database.UserTOTPCredential, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreGetTOTPFunc) nextHook() func(context.Context, int32) (*database.UserTOTPCredential, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreGetTOTPFunc) appendCall(r0 UserSecondFactorStoreGetTOTPFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreGetTOTPFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreGetTOTPFunc) History() []UserSecondFactorStoreGetTOTPFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreGetTOTPFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreGetTOTPFuncCall is an object that describes an
+// invocation of method GetTOTP on an instance of MockUserSecondFactorStore.
+type UserSecondFactorStoreGetTOTPFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.UserTOTPCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreGetTOTPFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreGetTOTPFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreGetWebAuthnCredentialFunc describes the behavior
+// when the GetWebAuthnCredential method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreGetWebAuthnCredentialFunc struct {
+	defaultHook func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error)
+	hooks       []func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error)
+	history     []UserSecondFactorStoreGetWebAuthnCredentialFuncCall
+	mutex       sync.Mutex
+}
+
+// GetWebAuthnCredential delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) GetWebAuthnCredential(v0 context.Context, v1 int32, v2 []byte) (*database.UserWebAuthnCredential, error) {
+	r0, r1 := m.GetWebAuthnCredentialFunc.nextHook()(v0, v1, v2)
+	m.GetWebAuthnCredentialFunc.appendCall(UserSecondFactorStoreGetWebAuthnCredentialFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) SetDefaultHook(hook func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetWebAuthnCredential method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) PushHook(hook func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) SetDefaultReturn(r0 *database.UserWebAuthnCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) PushReturn(r0 *database.UserWebAuthnCredential, r1 error) {
+	f.PushHook(func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) nextHook() func(context.Context, int32, []byte) (*database.UserWebAuthnCredential, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) appendCall(r0 UserSecondFactorStoreGetWebAuthnCredentialFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreGetWebAuthnCredentialFuncCall objects describing the
+// invocations of this function.
+func (f *UserSecondFactorStoreGetWebAuthnCredentialFunc) History() []UserSecondFactorStoreGetWebAuthnCredentialFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreGetWebAuthnCredentialFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreGetWebAuthnCredentialFuncCall is an object that
+// describes an invocation of method GetWebAuthnCredential on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreGetWebAuthnCredentialFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.UserWebAuthnCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreGetWebAuthnCredentialFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreGetWebAuthnCredentialFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []UserSecondFactorStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(UserSecondFactorStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockUserSecondFactorStore instance is invoked and the hook queue
+// is empty.
+func (f *UserSecondFactorStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockUserSecondFactorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UserSecondFactorStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreHandleFunc) appendCall(r0 UserSecondFactorStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *UserSecondFactorStoreHandleFunc) History() []UserSecondFactorStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockUserSecondFactorStore.
+type UserSecondFactorStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreHasSecondFactorFunc describes the behavior when the
+// HasSecondFactor method of the parent MockUserSecondFactorStore instance
+// is invoked.
+type UserSecondFactorStoreHasSecondFactorFunc struct {
+	defaultHook func(context.Context, int32) (bool, error)
+	hooks       []func(context.Context, int32) (bool, error)
+	history     []UserSecondFactorStoreHasSecondFactorFuncCall
+	mutex       sync.Mutex
+}
+
+// HasSecondFactor delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) HasSecondFactor(v0 context.Context, v1 int32) (bool, error) {
+	r0, r1 := m.HasSecondFactorFunc.nextHook()(v0, v1)
+	m.HasSecondFactorFunc.appendCall(UserSecondFactorStoreHasSecondFactorFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the HasSecondFactor
+// method of the parent MockUserSecondFactorStore instance is invoked and
+// the hook queue is empty.
+func (f *UserSecondFactorStoreHasSecondFactorFunc) SetDefaultHook(hook func(context.Context, int32) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasSecondFactor method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreHasSecondFactorFunc) PushHook(hook func(context.Context, int32) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreHasSecondFactorFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreHasSecondFactorFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreHasSecondFactorFunc) nextHook() func(context.Context, int32) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreHasSecondFactorFunc) appendCall(r0 UserSecondFactorStoreHasSecondFactorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreHasSecondFactorFuncCall objects describing the
+// invocations of this function.
+func (f *UserSecondFactorStoreHasSecondFactorFunc) History() []UserSecondFactorStoreHasSecondFactorFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreHasSecondFactorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreHasSecondFactorFuncCall is an object that describes
+// an invocation of method HasSecondFactor on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreHasSecondFactorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreHasSecondFactorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreHasSecondFactorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreListWebAuthnCredentialsFunc describes the behavior
+// when the ListWebAuthnCredentials method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreListWebAuthnCredentialsFunc struct {
+	defaultHook func(context.Context, int32) ([]*database.UserWebAuthnCredential, error)
+	hooks       []func(context.Context, int32) ([]*database.UserWebAuthnCredential, error)
+	history     []UserSecondFactorStoreListWebAuthnCredentialsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWebAuthnCredentials delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) ListWebAuthnCredentials(v0 context.Context, v1 int32) ([]*database.UserWebAuthnCredential, error) {
+	r0, r1 := m.ListWebAuthnCredentialsFunc.nextHook()(v0, v1)
+	m.ListWebAuthnCredentialsFunc.appendCall(UserSecondFactorStoreListWebAuthnCredentialsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListWebAuthnCredentials method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) SetDefaultHook(hook func(context.Context, int32) ([]*database.UserWebAuthnCredential, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWebAuthnCredentials method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) PushHook(hook func(context.Context, int32) ([]*database.UserWebAuthnCredential, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) SetDefaultReturn(r0 []*database.UserWebAuthnCredential, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) PushReturn(r0 []*database.UserWebAuthnCredential, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*database.UserWebAuthnCredential, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) nextHook() func(context.Context, int32) ([]*database.UserWebAuthnCredential, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) appendCall(r0 UserSecondFactorStoreListWebAuthnCredentialsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreListWebAuthnCredentialsFuncCall objects describing
+// the invocations of this function.
+func (f *UserSecondFactorStoreListWebAuthnCredentialsFunc) History() []UserSecondFactorStoreListWebAuthnCredentialsFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreListWebAuthnCredentialsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreListWebAuthnCredentialsFuncCall is an object that
+// describes an invocation of method ListWebAuthnCredentials on an instance
+// of MockUserSecondFactorStore.
+type UserSecondFactorStoreListWebAuthnCredentialsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.UserWebAuthnCredential
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreListWebAuthnCredentialsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreListWebAuthnCredentialsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreReplaceRecoveryCodesFunc describes the behavior when
+// the ReplaceRecoveryCodes method of the parent MockUserSecondFactorStore
+// instance is invoked.
+type UserSecondFactorStoreReplaceRecoveryCodesFunc struct {
+	defaultHook func(context.Context, int32, [][]byte) error
+	hooks       []func(context.Context, int32, [][]byte) error
+	history     []UserSecondFactorStoreReplaceRecoveryCodesFuncCall
+	mutex       sync.Mutex
+}
+
+// ReplaceRecoveryCodes delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) ReplaceRecoveryCodes(v0 context.Context, v1 int32, v2 [][]byte) error {
+	r0 := m.ReplaceRecoveryCodesFunc.nextHook()(v0, v1, v2)
+	m.ReplaceRecoveryCodesFunc.appendCall(UserSecondFactorStoreReplaceRecoveryCodesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReplaceRecoveryCodes
+// method of the parent MockUserSecondFactorStore instance is invoked and
+// the hook queue is empty.
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) SetDefaultHook(hook func(context.Context, int32, [][]byte) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReplaceRecoveryCodes method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) PushHook(hook func(context.Context, int32, [][]byte) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, [][]byte) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, [][]byte) error {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) nextHook() func(context.Context, int32, [][]byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) appendCall(r0 UserSecondFactorStoreReplaceRecoveryCodesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreReplaceRecoveryCodesFuncCall objects describing the
+// invocations of this function.
+func (f *UserSecondFactorStoreReplaceRecoveryCodesFunc) History() []UserSecondFactorStoreReplaceRecoveryCodesFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreReplaceRecoveryCodesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreReplaceRecoveryCodesFuncCall is an object that
+// describes an invocation of method ReplaceRecoveryCodes on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreReplaceRecoveryCodesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 [][]byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreReplaceRecoveryCodesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreReplaceRecoveryCodesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UserSecondFactorStoreUpdateWebAuthnSignCountFunc describes the behavior
+// when the UpdateWebAuthnSignCount method of the parent
+// MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreUpdateWebAuthnSignCountFunc struct {
+	defaultHook func(context.Context, int32, uint32) (bool, error)
+	hooks       []func(context.Context, int32, uint32) (bool, error)
+	history     []UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateWebAuthnSignCount delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) UpdateWebAuthnSignCount(v0 context.Context, v1 int32, v2 uint32) (bool, error) {
+	r0, r1 := m.UpdateWebAuthnSignCountFunc.nextHook()(v0, v1, v2)
+	m.UpdateWebAuthnSignCountFunc.appendCall(UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateWebAuthnSignCount method of the parent MockUserSecondFactorStore
+// instance is invoked and the hook queue is empty.
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) SetDefaultHook(hook func(context.Context, int32, uint32) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateWebAuthnSignCount method of the parent MockUserSecondFactorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) PushHook(hook func(context.Context, int32, uint32) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, uint32) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, uint32) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) nextHook() func(context.Context, int32, uint32) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) appendCall(r0 UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall objects describing
+// the invocations of this function.
+func (f *UserSecondFactorStoreUpdateWebAuthnSignCountFunc) History() []UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall is an object that
+// describes an invocation of method UpdateWebAuthnSignCount on an instance
+// of MockUserSecondFactorStore.
+type UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 uint32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreUpdateWebAuthnSignCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreUseRecoveryCodeFunc describes the behavior when the
+// UseRecoveryCode method of the parent MockUserSecondFactorStore instance
+// is invoked.
+type UserSecondFactorStoreUseRecoveryCodeFunc struct {
+	defaultHook func(context.Context, int32, []byte) (bool, error)
+	hooks       []func(context.Context, int32, []byte) (bool, error)
+	history     []UserSecondFactorStoreUseRecoveryCodeFuncCall
+	mutex       sync.Mutex
+}
+
+// UseRecoveryCode delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) UseRecoveryCode(v0 context.Context, v1 int32, v2 []byte) (bool, error) {
+	r0, r1 := m.UseRecoveryCodeFunc.nextHook()(v0, v1, v2)
+	m.UseRecoveryCodeFunc.appendCall(UserSecondFactorStoreUseRecoveryCodeFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UseRecoveryCode
+// method of the parent MockUserSecondFactorStore instance is invoked and
+// the hook queue is empty.
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) SetDefaultHook(hook func(context.Context, int32, []byte) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UseRecoveryCode method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) PushHook(hook func(context.Context, int32, []byte) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, []byte) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, []byte) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) nextHook() func(context.Context, int32, []byte) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) appendCall(r0 UserSecondFactorStoreUseRecoveryCodeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UserSecondFactorStoreUseRecoveryCodeFuncCall objects describing the
+// invocations of this function.
+func (f *UserSecondFactorStoreUseRecoveryCodeFunc) History() []UserSecondFactorStoreUseRecoveryCodeFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreUseRecoveryCodeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreUseRecoveryCodeFuncCall is an object that describes
+// an invocation of method UseRecoveryCode on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreUseRecoveryCodeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreUseRecoveryCodeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreUseRecoveryCodeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreUseTOTPCounterFunc describes the behavior when the
+// UseTOTPCounter method of the parent MockUserSecondFactorStore instance is
+// invoked.
+type UserSecondFactorStoreUseTOTPCounterFunc struct {
+	defaultHook func(context.Context, int32, int64) (bool, error)
+	hooks       []func(context.Context, int32, int64) (bool, error)
+	history     []UserSecondFactorStoreUseTOTPCounterFuncCall
+	mutex       sync.Mutex
+}
+
+// UseTOTPCounter delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) UseTOTPCounter(v0 context.Context, v1 int32, v2 int64) (bool, error) {
+	r0, r1 := m.UseTOTPCounterFunc.nextHook()(v0, v1, v2)
+	m.UseTOTPCounterFunc.appendCall(UserSecondFactorStoreUseTOTPCounterFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UseTOTPCounter
+// method of the parent MockUserSecondFactorStore instance is invoked and
+// the hook queue is empty.
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) SetDefaultHook(hook func(context.Context, int32, int64) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UseTOTPCounter method of the parent MockUserSecondFactorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) PushHook(hook func(context.Context, int32, int64) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int32, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) nextHook() func(context.Context, int32, int64) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) appendCall(r0 UserSecondFactorStoreUseTOTPCounterFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreUseTOTPCounterFuncCall
+// objects describing the invocations of this function.
+func (f *UserSecondFactorStoreUseTOTPCounterFunc) History() []UserSecondFactorStoreUseTOTPCounterFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreUseTOTPCounterFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreUseTOTPCounterFuncCall is an object that describes
+// an invocation of method UseTOTPCounter on an instance of
+// MockUserSecondFactorStore.
+type UserSecondFactorStoreUseTOTPCounterFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreUseTOTPCounterFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreUseTOTPCounterFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UserSecondFactorStoreWithFunc describes the behavior when the With method
+// of the parent MockUserSecondFactorStore instance is invoked.
+type UserSecondFactorStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) database.UserSecondFactorStore
+	hooks       []func(basestore.ShareableStore) database.UserSecondFactorStore
+	history     []UserSecondFactorStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUserSecondFactorStore) With(v0 basestore.ShareableStore) database.UserSecondFactorStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(UserSecondFactorStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockUserSecondFactorStore instance is invoked and the hook queue
+// is empty.
+func (f *UserSecondFactorStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) database.UserSecondFactorStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockUserSecondFactorStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserSecondFactorStoreWithFunc) PushHook(hook func(basestore.ShareableStore) database.UserSecondFactorStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserSecondFactorStoreWithFunc) SetDefaultReturn(r0 database.UserSecondFactorStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) database.UserSecondFactorStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserSecondFactorStoreWithFunc) PushReturn(r0 database.UserSecondFactorStore) {
+	f.PushHook(func(basestore.ShareableStore) database.UserSecondFactorStore {
+		return r0
+	})
+}
+
+func (f *UserSecondFactorStoreWithFunc) nextHook() func(basestore.ShareableStore) database.UserSecondFactorStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserSecondFactorStoreWithFunc) appendCall(r0 UserSecondFactorStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserSecondFactorStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *UserSecondFactorStoreWithFunc) History() []UserSecondFactorStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]UserSecondFactorStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserSecondFactorStoreWithFuncCall is an object that describes an
+// invocation of method With on an instance of MockUserSecondFactorStore.
+type UserSecondFactorStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.UserSecondFactorStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserSecondFactorStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserSecondFactorStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockUserStore is a mock implementation of the UserStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -203,24 +203,30 @@ func (e *externalServiceStore) Done(err error) error {
 // ExternalServiceKinds contains a map of all supported kinds of
 // external services.
 var ExternalServiceKinds = map[string]ExternalServiceKind{
-	extsvc.KindAWSCodeCommit:   {CodeHost: true, JSONSchema: schema.AWSCodeCommitSchemaJSON},
-	extsvc.KindAzureDevOps:     {CodeHost: true, JSONSchema: schema.AzureDevOpsSchemaJSON},
-	extsvc.KindBitbucketCloud:  {CodeHost: true, JSONSchema: schema.BitbucketCloudSchemaJSON},
-	extsvc.KindBitbucketServer: {CodeHost: true, JSONSchema: schema.BitbucketServerSchemaJSON},
-	extsvc.KindGerrit:          {CodeHost: true, JSONSchema: schema.GerritSchemaJSON},
-	extsvc.KindGitHub:          {CodeHost: true, JSONSchema: schema.GitHubSchemaJSON},
-	extsvc.KindGitLab:          {CodeHost: true, JSONSchema: schema.GitLabSchemaJSON},
-	extsvc.KindGitolite:        {CodeHost: true, JSONSchema: schema.GitoliteSchemaJSON},
-	extsvc.KindGoPackages:      {CodeHost: true, JSONSchema: schema.GoModulesSchemaJSON},
-	extsvc.KindJVMPackages:     {CodeHost: true, JSONSchema: schema.JVMPackagesSchemaJSON},
-	extsvc.KindNpmPackages:     {CodeHost: true, JSONSchema: schema.NpmPackagesSchemaJSON},
-	extsvc.KindOther:           {CodeHost: true, JSONSchema: schema.OtherExternalServiceSchemaJSON},
-	extsvc.KindPagure:          {CodeHost: true, JSONSchema: schema.PagureSchemaJSON},
-	extsvc.KindPerforce:        {CodeHost: true, JSONSchema: schema.PerforceSchemaJSON},
-	extsvc.KindPhabricator:     {CodeHost: true, JSONSchema: schema.PhabricatorSchemaJSON},
-	extsvc.KindPythonPackages:  {CodeHost: true, JSONSchema: schema.PythonPackagesSchemaJSON},
-	extsvc.KindRustPackages:    {CodeHost: true, JSONSchema: schema.RustPackagesSchemaJSON},
-	extsvc.KindRubyPackages:    {CodeHost: true, JSONSchema: schema.RubyPackagesSchemaJSON},
+	extsvc.KindAWSCodeCommit:     {CodeHost: true, JSONSchema: schema.AWSCodeCommitSchemaJSON},
+	extsvc.KindAzureDevOps:       {CodeHost: true, JSONSchema: schema.AzureDevOpsSchemaJSON},
+	extsvc.KindBitbucketCloud:    {CodeHost: true, JSONSchema: schema.BitbucketCloudSchemaJSON},
+	extsvc.KindBitbucketServer:   {CodeHost: true, JSONSchema: schema.BitbucketServerSchemaJSON},
+	extsvc.KindGerrit:            {CodeHost: true, JSONSchema: schema.GerritSchemaJSON},
+	extsvc.KindGitea:             {CodeHost: true, JSONSchema: schema.GiteaSchemaJSON},
+	extsvc.KindGitHub:            {CodeHost: true, JSONSchema: schema.GitHubSchemaJSON},
+	extsvc.KindGitLab:            {CodeHost: true, JSONSchema: schema.GitLabSchemaJSON},
+	extsvc.KindGitolite:          {CodeHost: true, JSONSchema: schema.GitoliteSchemaJSON},
+	extsvc.KindGoPackages:        {CodeHost: true, JSONSchema: schema.GoModulesSchemaJSON},
+	extsvc.KindHexPackages:       {CodeHost: true, JSONSchema: schema.HexPackagesSchemaJSON},
+	extsvc.KindJVMPackages:       {CodeHost: true, JSONSchema: schema.JVMPackagesSchemaJSON},
+	extsvc.KindMercurial:         {CodeHost: true, JSONSchema: schema.MercurialSchemaJSON},
+	extsvc.KindNpmPackages:       {CodeHost: true, JSONSchema: schema.NpmPackagesSchemaJSON},
+	extsvc.KindNuGetPackages:     {CodeHost: true, JSONSchema: schema.NuGetPackagesSchemaJSON},
+	extsvc.KindOther:             {CodeHost: true, JSONSchema: schema.OtherExternalServiceSchemaJSON},
+	extsvc.KindPackagistPackages: {CodeHost: true, JSONSchema: schema.PackagistPackagesSchemaJSON},
+	extsvc.KindPagure:            {CodeHost: true, JSONSchema: schema.PagureSchemaJSON},
+	extsvc.KindPerforce:          {CodeHost: true, JSONSchema: schema.PerforceSchemaJSON},
+	extsvc.KindPhabricator:       {CodeHost: true, JSONSchema: schema.PhabricatorSchemaJSON},
+	extsvc.KindPythonPackages:    {CodeHost: true, JSONSchema: schema.PythonPackagesSchemaJSON},
+	extsvc.KindRustPackages:      {CodeHost: true, JSONSchema: schema.RustPackagesSchemaJSON},
+	extsvc.KindRubyPackages:      {CodeHost: true, JSONSchema: schema.RubyPackagesSchemaJSON},
+	extsvc.KindSubversion:        {CodeHost: true, JSONSchema: schema.SubversionSchemaJSON},
 }
 
 // ExternalServiceKind describes a kind of external service.
@@ -421,6 +427,18 @@ func MakeValidateExternalServiceConfigFunc(
 				return nil, err
 			}
 			err = validateOtherExternalServiceConnection(&c)
+		case extsvc.KindMercurial:
+			var c schema.MercurialConnection
+			if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+				return nil, err
+			}
+			err = validateMercurialConnection(&c)
+		case extsvc.KindSubversion:
+			var c schema.SubversionConnection
+			if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+				return nil, err
+			}
+			err = validateSubversionConnection(&c)
 		}
 
 		return normalized, errors.Append(errs, err)
@@ -460,6 +478,52 @@ func validateOtherExternalServiceConnection(c *schema.OtherExternalServiceConnec
 	return nil
 }
 
+// validateMercurialConnection checks that every entry in repos resolves to a
+// URL that Mercurial can clone from when combined with the base URL.
+func validateMercurialConnection(c *schema.MercurialConnection) error {
+	// We ignore the error because this already validated by JSON Schema.
+	baseURL, _ := url.Parse(c.Url)
+
+	for i, repo := range c.Repos {
+		cloneURL, err := baseURL.Parse(repo)
+		if err != nil {
+			return errors.Errorf(`repos.%d: %s`, i, err)
+		}
+
+		switch cloneURL.Scheme {
+		case "http", "https", "ssh":
+			continue
+		default:
+			return errors.Errorf("repos.%d: scheme %q not one of http, https or ssh", i, cloneURL.Scheme)
+		}
+	}
+
+	return nil
+}
+
+// validateSubversionConnection checks that every entry in repos resolves to a
+// URL on a protocol that git-svn can talk to when combined with the base URL.
+func validateSubversionConnection(c *schema.SubversionConnection) error {
+	// We ignore the error because this already validated by JSON Schema.
+	baseURL, _ := url.Parse(c.Url)
+
+	for i, repo := range c.Repos {
+		repoURL, err := baseURL.Parse(repo)
+		if err != nil {
+			return errors.Errorf(`repos.%d: %s`, i, err)
+		}
+
+		switch repoURL.Scheme {
+		case "http", "https", "svn", "svn+ssh":
+			continue
+		default:
+			return errors.Errorf("repos.%d: scheme %q not one of http, https, svn or svn+ssh", i, repoURL.Scheme)
+		}
+	}
+
+	return nil
+}
+
 func validateGitHubConnection(db DB, githubValidators []GitHubValidatorFunc, id int64, c *schema.GitHubConnection) error {
 	var err error
 	for _, validate := range githubValidators {
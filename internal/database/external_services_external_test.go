@@ -46,6 +46,30 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			config:  `{"url": "https://bitbucket.org", "username": "ceo", "appPassword": "abc", "exclude": [{"uuid":"{fceb73c7+cef6-4abe-956d-e471281126bd}"}]}`,
 			wantErr: `exclude.0.uuid: Does not match pattern '^\{[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\}$'`,
 		},
+		{
+			name:    "0 errors - Mercurial",
+			kind:    extsvc.KindMercurial,
+			config:  `{"url": "https://hg.mozilla.org", "repos": ["mozilla-central"]}`,
+			wantErr: "<nil>",
+		},
+		{
+			name:    "1 error - Mercurial repo scheme",
+			kind:    extsvc.KindMercurial,
+			config:  `{"url": "https://hg.mozilla.org", "repos": ["git://hg.mozilla.org/mozilla-central"]}`,
+			wantErr: `repos.0: scheme "git" not one of http, https or ssh`,
+		},
+		{
+			name:    "0 errors - Subversion",
+			kind:    extsvc.KindSubversion,
+			config:  `{"url": "https://svn.apache.org/repos/asf", "repos": ["commons-lang"], "username": "admin", "password": "secret"}`,
+			wantErr: "<nil>",
+		},
+		{
+			name:    "1 error - Subversion repo scheme",
+			kind:    extsvc.KindSubversion,
+			config:  `{"url": "https://svn.apache.org/repos/asf", "repos": ["file:///var/svn/commons-lang"]}`,
+			wantErr: `repos.0: scheme "file" not one of http, https, svn or svn+ssh`,
+		},
 		{
 			name:    "1 error",
 			kind:    extsvc.KindGitHub,
@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -13,7 +15,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-var clearRedisCache = ff.ClearEvaluatedFlagFromCache
+var (
+	clearRedisCache        = ff.ClearEvaluatedFlagFromCache
+	clearRedisCacheForUser = ff.ClearEvaluatedFlagsForUser
+)
 
 type FeatureFlagStore interface {
 	basestore.ShareableStore
@@ -63,26 +68,30 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_name,
 			flag_type,
 			bool_value,
-			rollout
+			rollout,
+			targeting
 		) VALUES (
 			%s,
 			%s,
 			%s,
+			%s,
 			%s
 		) RETURNING
 			flag_name,
 			flag_type,
 			bool_value,
 			rollout,
+			targeting,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
 	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
+		flagType  string
+		boolVal   *bool
+		rollout   *int32
+		targeting []byte
 	)
 	switch {
 	case flag.Bool != nil:
@@ -91,6 +100,15 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 	case flag.Rollout != nil:
 		flagType = "rollout"
 		rollout = &flag.Rollout.Rollout
+	case flag.Targeting != nil:
+		if err := flag.Targeting.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid targeting")
+		}
+		flagType = "targeting"
+		var err error
+		if targeting, err = json.Marshal(flag.Targeting); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("feature flag must have exactly one type")
 	}
@@ -100,7 +118,8 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 		flag.Name,
 		flagType,
 		boolVal,
-		rollout))
+		rollout,
+		targeting))
 	return scanFeatureFlag(row)
 }
 
@@ -111,6 +130,7 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type = %s,
 			bool_value = %s,
 			rollout = %s,
+			targeting = %s,
 			updated_at = NOW()
 		WHERE flag_name = %s
 		RETURNING
@@ -118,15 +138,17 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_type,
 			bool_value,
 			rollout,
+			targeting,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
 	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
+		flagType  string
+		boolVal   *bool
+		rollout   *int32
+		targeting []byte
 	)
 	switch {
 	case flag.Bool != nil:
@@ -135,6 +157,15 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 	case flag.Rollout != nil:
 		flagType = "rollout"
 		rollout = &flag.Rollout.Rollout
+	case flag.Targeting != nil:
+		if err := flag.Targeting.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid targeting")
+		}
+		flagType = "targeting"
+		var err error
+		if targeting, err = json.Marshal(flag.Targeting); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("feature flag must have exactly one type")
 	}
@@ -144,6 +175,7 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 		flagType,
 		boolVal,
 		rollout,
+		targeting,
 		flag.Name,
 	))
 	clearRedisCache(flag.Name)
@@ -185,16 +217,18 @@ var ErrInvalidColumnState = errors.New("encountered column that is unexpectedly
 
 func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
 	var (
-		res      ff.FeatureFlag
-		flagType string
-		boolVal  *bool
-		rollout  *int32
+		res       ff.FeatureFlag
+		flagType  string
+		boolVal   *bool
+		rollout   *int32
+		targeting dbutil.NullJSONRawMessage
 	)
 	err := scanner.Scan(
 		&res.Name,
 		&flagType,
 		&boolVal,
 		&rollout,
+		&targeting,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 		&res.DeletedAt,
@@ -218,6 +252,14 @@ func scanFeatureFlag(scanner dbutil.Scanner) (*ff.FeatureFlag, error) {
 		res.Rollout = &ff.FeatureFlagRollout{
 			Rollout: *rollout,
 		}
+	case "targeting":
+		if targeting.Raw == nil {
+			return nil, ErrInvalidColumnState
+		}
+		res.Targeting = &ff.FeatureFlagTargeting{}
+		if err := json.Unmarshal(targeting.Raw, res.Targeting); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, ErrInvalidColumnState
 	}
@@ -232,6 +274,7 @@ func (f *featureFlagStore) GetFeatureFlag(ctx context.Context, flagName string)
 			flag_type,
 			bool_value,
 			rollout,
+			targeting,
 			created_at,
 			updated_at,
 			deleted_at
@@ -251,6 +294,7 @@ func (f *featureFlagStore) GetFeatureFlags(ctx context.Context) ([]*ff.FeatureFl
 			flag_type,
 			bool_value,
 			rollout,
+			targeting,
 			created_at,
 			updated_at,
 			deleted_at
@@ -515,6 +559,7 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 			ff.flag_type,
 			ff.bool_value,
 			ff.rollout,
+			ff.targeting,
 			-- We prioritize user overrides over org overrides.
 			-- If neither exist override will be NULL.
 			COALESCE(uo.flag_value, oo.flag_value) AS override
@@ -529,15 +574,20 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 	}
 	defer rows.Close()
 
+	// Targeting flags are evaluated once all rows are read, so the user's
+	// attributes are only loaded if there are any.
+	targetingFlags := make(map[string]*ff.FeatureFlagTargeting)
+
 	scanRow := func(rows *sql.Rows) (string, bool, error) {
 		var (
-			flagName string
-			flagType *string
-			boolVal  *bool
-			rollout  *int32
-			override *bool
+			flagName  string
+			flagType  *string
+			boolVal   *bool
+			rollout   *int32
+			targeting dbutil.NullJSONRawMessage
+			override  *bool
 		)
-		err := rows.Scan(&flagName, &flagType, &boolVal, &rollout, &override)
+		err := rows.Scan(&flagName, &flagType, &boolVal, &rollout, &targeting, &override)
 		if err != nil {
 			return "", false, err
 		}
@@ -559,6 +609,16 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 			}
 			ffr := ff.FeatureFlagRollout{Rollout: *rollout}
 			return flagName, ffr.Evaluate(flagName, userID), nil
+		case "targeting":
+			if targeting.Raw == nil {
+				return "", false, ErrInvalidColumnState
+			}
+			var fft ff.FeatureFlagTargeting
+			if err := json.Unmarshal(targeting.Raw, &fft); err != nil {
+				return "", false, err
+			}
+			targetingFlags[flagName] = &fft
+			return flagName, false, nil
 		default:
 			return "", false, ErrInvalidColumnState
 		}
@@ -572,7 +632,86 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 		}
 		res[flag] = value
 	}
-	return res, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(targetingFlags) == 0 {
+		return res, nil
+	}
+	attrs, err := f.getTargetingAttributes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for flagName, fft := range targetingFlags {
+		res[flagName] = fft.Evaluate(flagName, attrs)
+	}
+	return res, nil
+}
+
+// getTargetingAttributes returns the attributes of the given user that
+// targeting rules are evaluated against. Only verified email addresses count
+// towards the user's email domains.
+func (f *featureFlagStore) getTargetingAttributes(ctx context.Context, userID int32) (ff.TargetingAttributes, error) {
+	const getTargetingAttributesFmtStr = `
+		SELECT
+			u.site_admin,
+			ARRAY(
+				SELECT org_id
+				FROM org_members
+				WHERE user_id = u.id
+			),
+			ARRAY(
+				SELECT r.name
+				FROM user_roles ur
+				JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id
+			),
+			ARRAY(
+				SELECT DISTINCT lower(split_part(email, '@', 2))
+				FROM user_emails
+				WHERE user_id = u.id
+					AND verified_at IS NOT NULL
+			)
+		FROM users u
+		WHERE u.id = %s
+			AND u.deleted_at IS NULL
+	`
+
+	attrs := ff.TargetingAttributes{UserID: userID}
+	err := f.QueryRow(ctx, sqlf.Sprintf(getTargetingAttributesFmtStr, userID)).Scan(
+		&attrs.SiteAdmin,
+		pq.Array(&attrs.OrgIDs),
+		pq.Array(&attrs.Roles),
+		pq.Array(&attrs.EmailDomains),
+	)
+	if err == sql.ErrNoRows {
+		// A deleted user matches no rules, only the fallback rollout applies.
+		return attrs, nil
+	}
+	return attrs, err
+}
+
+// clearTargetingFlagsCacheForUser clears the cached evaluations of all targeting
+// flags for the given user. It must be called with the store that changed an
+// attribute that targeting rules match on. If the store is in a transaction,
+// the cache is only cleared once it is committed, as evaluations in between
+// would still see and cache the old attributes. Errors are ignored, as the
+// cache is refreshed the next time the flags are evaluated for the user.
+func clearTargetingFlagsCacheForUser(ctx context.Context, s basestore.ShareableStore, userID int32) {
+	const listTargetingFlagsQuery = `
+		SELECT flag_name
+		FROM feature_flags
+		WHERE flag_type = 'targeting'
+			AND deleted_at IS NULL
+	`
+
+	names, err := basestore.ScanStrings(basestore.NewWithHandle(s.Handle()).Query(ctx, sqlf.Sprintf(listTargetingFlagsQuery)))
+	if err != nil {
+		return
+	}
+	basestore.AfterCommit(s, func() { clearRedisCacheForUser(userID, names...) })
 }
 
 // GetAnonymousUserFlags returns the calculated values for feature flags for the given anonymousUID
@@ -633,7 +772,11 @@ func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, f
 	if override != nil {
 		return override.Value, nil
 	} else if globalFlag != nil {
-		return globalFlag.Bool.Value, nil
+		// Rollout and targeting flags are evaluated per user, so they can't
+		// be enabled for a whole organization without an override.
+		if val, ok := globalFlag.EvaluateGlobal(); ok {
+			return val, nil
+		}
 	}
 
 	return false, nil
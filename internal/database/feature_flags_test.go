@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	ff "github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestFeatureFlagStore(t *testing.T) {
@@ -133,6 +134,19 @@ func testNewFeatureFlagRoundtrip(t *testing.T, db DB) {
 			flag:      &ff.FeatureFlag{Name: "err_too_low_rollout", Rollout: &ff.FeatureFlagRollout{Rollout: -1}},
 			assertErr: errorContains(`violates check constraint "feature_flags_rollout_check"`),
 		},
+		{
+			flag: &ff.FeatureFlag{Name: "targeting", Targeting: &ff.FeatureFlagTargeting{
+				Rules: []ff.TargetingRule{
+					{Type: ff.TargetingRuleOrg, OrgIDs: []int32{1, 2}, Value: true},
+					{Type: ff.TargetingRuleSiteAdmin, Value: false},
+				},
+				Rollout: 2500,
+			}},
+		},
+		{
+			flag:      &ff.FeatureFlag{Name: "err_invalid_targeting", Targeting: &ff.FeatureFlagTargeting{Rules: []ff.TargetingRule{{Type: ff.TargetingRuleRole}}}},
+			assertErr: errorContains(`invalid targeting`),
+		},
 		{
 			flag:      &ff.FeatureFlag{Name: "err_no_types"},
 			assertErr: errorContains(`feature flag must have exactly one type`),
@@ -153,6 +167,7 @@ func testNewFeatureFlagRoundtrip(t *testing.T, db DB) {
 			require.Equal(t, tc.flag.Name, res.Name)
 			require.Equal(t, tc.flag.Bool, res.Bool)
 			require.Equal(t, tc.flag.Rollout, res.Rollout)
+			require.Equal(t, tc.flag.Targeting, res.Targeting)
 		})
 	}
 }
@@ -573,6 +588,102 @@ func testUserFlags(t *testing.T, db DB) {
 		require.NoError(t, err)
 		require.Len(t, flags, 0)
 	})
+
+	t.Run("targeting rules", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		o1 := mkOrg("o1")
+		o2 := mkOrg("o2")
+		u1 := mkUser("u1", o1.ID)
+		u2 := mkUser("u2", o2.ID)
+		u3 := mkUser("u3")
+		require.NoError(t, db.UserEmails().Add(ctx, u3.ID, "u3@example.com", nil))
+		require.NoError(t, db.UserEmails().SetVerified(ctx, u3.ID, "u3@example.com", true))
+		u4 := mkUser("u4")
+		require.NoError(t, db.UserEmails().Add(ctx, u4.ID, "u4@example.com", nil))
+		u5 := mkUser("u5")
+		require.NoError(t, users.SetIsSiteAdmin(ctx, u5.ID, true))
+
+		_, err := flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{Name: "f1", Targeting: &ff.FeatureFlagTargeting{
+			Rules: []ff.TargetingRule{
+				{Type: ff.TargetingRuleOrg, OrgIDs: []int32{o1.ID}, Value: true},
+				{Type: ff.TargetingRuleEmailDomain, EmailDomains: []string{"example.com"}, Value: true},
+				{Type: ff.TargetingRuleRole, Roles: []string{string(types.SiteAdministratorSystemRole)}, Value: true},
+			},
+			Rollout: 0,
+		}})
+		require.NoError(t, err)
+		mkUserOverride(u1.ID, "f1", false)
+
+		for _, tc := range []struct {
+			user *types.User
+			want bool
+		}{
+			// The user override beats the matching org rule.
+			{user: u1, want: false},
+			{user: u2, want: false},
+			{user: u3, want: true},
+			// Unverified email addresses don't count.
+			{user: u4, want: false},
+			{user: u5, want: true},
+		} {
+			got, err := flagStore.GetUserFlags(ctx, tc.user.ID)
+			require.NoError(t, err)
+			require.Equal(t, map[string]bool{"f1": tc.want}, got, tc.user.Username)
+		}
+	})
+
+	t.Run("targeting cache cleared when attributes change", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		o1 := mkOrg("o1")
+		u1 := mkUser("u1")
+		_, err := flagStore.CreateFeatureFlag(ctx, &ff.FeatureFlag{Name: "f1", Targeting: &ff.FeatureFlagTargeting{
+			Rules: []ff.TargetingRule{{Type: ff.TargetingRuleOrg, OrgIDs: []int32{o1.ID}, Value: true}},
+		}})
+		require.NoError(t, err)
+		mkFFBool("f2", true)
+
+		var cleared []string
+		oldClearRedisCacheForUser := clearRedisCacheForUser
+		clearRedisCacheForUser = func(userID int32, flagNames ...string) {
+			require.Equal(t, u1.ID, userID)
+			cleared = append(cleared, flagNames...)
+		}
+		t.Cleanup(func() { clearRedisCacheForUser = oldClearRedisCacheForUser })
+
+		_, err = orgMembers.Create(ctx, o1.ID, u1.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"f1"}, cleared)
+
+		require.NoError(t, orgMembers.Remove(ctx, o1.ID, u1.ID))
+		require.Equal(t, []string{"f1", "f1"}, cleared)
+
+		// In a transaction, the cache is only cleared after the commit, and
+		// not at all on rollback.
+		cleared = nil
+		require.NoError(t, orgMembers.WithTransact(ctx, func(tx OrgMemberStore) error {
+			_, err := tx.Create(ctx, o1.ID, u1.ID)
+			require.NoError(t, err)
+			require.Empty(t, cleared)
+			return nil
+		}))
+		require.Equal(t, []string{"f1"}, cleared)
+
+		rollbackErr := errors.New("rollback")
+		err = orgMembers.WithTransact(ctx, func(tx OrgMemberStore) error {
+			require.NoError(t, tx.Remove(ctx, o1.ID, u1.ID))
+			return rollbackErr
+		})
+		require.ErrorIs(t, err, rollbackErr)
+		require.Equal(t, []string{"f1"}, cleared)
+
+		// Promoting a user clears the cache, even if they already have the
+		// site admin role.
+		for range 2 {
+			cleared = nil
+			require.NoError(t, db.Users().SetIsSiteAdmin(ctx, u1.ID, true))
+			require.Contains(t, cleared, "f1")
+		}
+	})
 }
 
 func testAnonymousUserFlags(t *testing.T, db DB) {
@@ -784,6 +895,11 @@ func testUpdateFeatureFlag(t *testing.T, db DB) {
 		assert.False(t, updatedFlag.Bool.Value)
 		assert.Greater(t, updatedFlag.UpdatedAt, boolFlag.UpdatedAt)
 	})
+	t.Run("invalid targeting", func(t *testing.T) {
+		updatedFf, err := flagStore.UpdateFeatureFlag(ctx, &ff.FeatureFlag{Name: "invalid", Targeting: &ff.FeatureFlagTargeting{Rollout: -1}})
+		require.EqualError(t, err, "invalid targeting: rollout must be between 0 and 10000, got -1")
+		require.Nil(t, updatedFf)
+	})
 	t.Run("rollout flag successful update", func(t *testing.T) {
 		rolloutFlag, err := flagStore.CreateRollout(ctx, "update-test-rollout-flag", 42)
 		require.NoError(t, err)
@@ -60,6 +60,7 @@ func (m *orgMemberStore) Create(ctx context.Context, orgID, userID int32) (*type
 		}
 		return nil, err
 	}
+	clearTargetingFlagsCacheForUser(ctx, m, userID)
 	return &om, nil
 }
 
@@ -85,7 +86,11 @@ func (m *orgMemberStore) MemberCount(ctx context.Context, orgID int32) (int, err
 
 func (m *orgMemberStore) Remove(ctx context.Context, orgID, userID int32) error {
 	_, err := m.Handle().ExecContext(ctx, "DELETE FROM org_members WHERE (org_id=$1 AND user_id=$2)", orgID, userID)
-	return err
+	if err != nil {
+		return err
+	}
+	clearTargetingFlagsCacheForUser(ctx, m, userID)
+	return nil
 }
 
 // GetByOrgID returns a list of all members of a given organization.
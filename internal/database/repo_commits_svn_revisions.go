@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type RepoCommitsSubversionRevisionsStore interface {
+	// BatchInsertCommitSHAsWithSubversionRevision will insert rows into the
+	// repo_commits_svn_revisions table in batches. Revisions that are already
+	// mapped are left untouched.
+	BatchInsertCommitSHAsWithSubversionRevision(context.Context, api.RepoID, []types.SubversionRevision) error
+	// GetLatestForRepo will return the commit of the highest revision that has
+	// been mapped in the database.
+	GetLatestForRepo(ctx context.Context, repoID api.RepoID) (*types.RepoCommitSubversionRevision, error)
+
+	// GetRepoCommitSubversionRevision will return the matching row from the
+	// table for the given repo ID and the given Subversion revision.
+	GetRepoCommitSubversionRevision(ctx context.Context, repoID api.RepoID, revision int64) (*types.RepoCommitSubversionRevision, error)
+
+	// BatchGetRepoCommitSubversionRevision bulk loads repo commits for given
+	// repo ids and Subversion revisions.
+	BatchGetRepoCommitSubversionRevision(ctx context.Context, rrs ...RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error)
+}
+
+type repoCommitsSubversionRevisionsStore struct {
+	*basestore.Store
+	logger log.Logger
+}
+
+var _ RepoCommitsSubversionRevisionsStore = (*repoCommitsSubversionRevisionsStore)(nil)
+
+func RepoCommitsSubversionRevisionsWith(logger log.Logger, other basestore.ShareableStore) RepoCommitsSubversionRevisionsStore {
+	return &repoCommitsSubversionRevisionsStore{
+		logger: logger,
+		Store:  basestore.NewWithHandle(other.Handle()),
+	}
+}
+
+// SubversionRevisionNotFoundError is returned when no commit has been mapped
+// to a Subversion revision of a repository.
+type SubversionRevisionNotFoundError struct {
+	RepoID   api.RepoID
+	Revision int64
+}
+
+func (e *SubversionRevisionNotFoundError) NotFound() bool { return true }
+
+func (e *SubversionRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("subversion revision not found. repo=%d, revision=%d", e.RepoID, e.Revision)
+}
+
+func (s *repoCommitsSubversionRevisionsStore) BatchInsertCommitSHAsWithSubversionRevision(ctx context.Context, repoID api.RepoID, revisions []types.SubversionRevision) error {
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		// A single Subversion revision that touches several branches is
+		// converted into one commit per branch. We keep the first commit we
+		// see for a revision.
+		inserter := batch.NewInserterWithConflict(ctx, tx.Handle(), "repo_commits_svn_revisions", batch.MaxNumPostgresParameters, "ON CONFLICT DO NOTHING", "repo_id", "commit_sha", "svn_revision")
+		for _, item := range revisions {
+			if err := inserter.Insert(
+				ctx,
+				int32(repoID),
+				dbutil.CommitBytea(item.CommitSHA),
+				item.Revision,
+			); err != nil {
+				return err
+			}
+		}
+		return inserter.Flush(ctx)
+	})
+}
+
+const repoCommitSubversionRevisionColumns = `
+	id,
+	repo_id,
+	commit_sha,
+	svn_revision,
+	created_at
+`
+
+var getLatestSubversionRevisionForRepoFmtStr = `
+SELECT` + repoCommitSubversionRevisionColumns + `FROM
+	repo_commits_svn_revisions
+WHERE
+	repo_id = %s
+ORDER BY
+	svn_revision DESC
+LIMIT 1`
+
+func (s *repoCommitsSubversionRevisionsStore) GetLatestForRepo(ctx context.Context, repoID api.RepoID) (*types.RepoCommitSubversionRevision, error) {
+	q := sqlf.Sprintf(getLatestSubversionRevisionForRepoFmtStr, repoID)
+	return scanRepoCommitSubversionRevisionRow(s.QueryRow(ctx, q))
+}
+
+func scanRepoCommitSubversionRevisionRow(scanner dbutil.Scanner) (*types.RepoCommitSubversionRevision, error) {
+	var r types.RepoCommitSubversionRevision
+	if err := scanner.Scan(
+		&r.ID,
+		&r.RepoID,
+		&r.CommitSHA,
+		&r.SubversionRevision,
+		&r.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+var getRepoCommitSubversionRevisionFmtStr = `
+SELECT` + repoCommitSubversionRevisionColumns + `FROM
+	repo_commits_svn_revisions
+WHERE
+	repo_id = %s
+	AND svn_revision = %s;
+`
+
+func (s *repoCommitsSubversionRevisionsStore) GetRepoCommitSubversionRevision(ctx context.Context, repoID api.RepoID, revision int64) (*types.RepoCommitSubversionRevision, error) {
+	q := sqlf.Sprintf(getRepoCommitSubversionRevisionFmtStr, repoID, revision)
+
+	repoCommit, err := scanRepoCommitSubversionRevisionRow(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, &SubversionRevisionNotFoundError{RepoID: repoID, Revision: revision}
+	} else if err != nil {
+		return nil, err
+	}
+	return repoCommit, nil
+}
+
+type RepoSubversionRevisions struct {
+	RepoID    api.RepoID
+	Revisions []int64
+}
+
+var batchGetRepoCommitSubversionRevisionFmtStr = `
+SELECT` + repoCommitSubversionRevisionColumns + `FROM
+	repo_commits_svn_revisions
+WHERE
+	%s;
+`
+
+func (s *repoCommitsSubversionRevisionsStore) BatchGetRepoCommitSubversionRevision(ctx context.Context, rrs ...RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
+	res := make(map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, len(rrs))
+	for _, rr := range rrs {
+		res[rr.RepoID] = make(map[int64]*types.RepoCommitSubversionRevision, len(rr.Revisions))
+	}
+
+	var where []*sqlf.Query
+	for _, rr := range rrs {
+		if len(rr.Revisions) == 0 {
+			continue
+		}
+		items := make([]*sqlf.Query, len(rr.Revisions))
+		for i, revision := range rr.Revisions {
+			items[i] = sqlf.Sprintf("%d", revision)
+		}
+		where = append(where, sqlf.Sprintf("(repo_id=%d AND svn_revision IN (%s))", rr.RepoID, sqlf.Join(items, ",")))
+	}
+
+	// If input has no revisions just return an empty result.
+	if len(where) == 0 {
+		return res, nil
+	}
+
+	q := sqlf.Sprintf(batchGetRepoCommitSubversionRevisionFmtStr, sqlf.Join(where, "\n OR "))
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		repoCommit, err := scanRepoCommitSubversionRevisionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		res[repoCommit.RepoID][repoCommit.SubversionRevision] = repoCommit
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRepoCommitsSubversionRevisions(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+
+	repos := db.Repos()
+	require.NoError(t, repos.Create(ctx, &types.Repo{ID: 1, Name: "foo"}))
+	require.NoError(t, repos.Create(ctx, &types.Repo{ID: 2, Name: "bar"}))
+
+	commitSHA1 := "98d3ec26623660f17f6c298943f55aa339aa894a"
+	commitSHA2 := "4b6152a804c4c177f5fe0dfd61e71cacb64d64dd"
+	commitSHA3 := "e9c83398bbd4c4e9481fd20f100a7e49d68d7510"
+	commitSHA4 := "aac83398bbd4c4e9481fd20f100a7e49d68d7510"
+
+	s := RepoCommitsSubversionRevisionsWith(logger, db)
+
+	require.NoError(t, s.BatchInsertCommitSHAsWithSubversionRevision(ctx, 1, []types.SubversionRevision{
+		{CommitSHA: api.CommitID(commitSHA1), Revision: 10},
+		{CommitSHA: api.CommitID(commitSHA2), Revision: 11},
+		// The same revision converted to a commit on another branch is ignored.
+		{CommitSHA: api.CommitID(commitSHA3), Revision: 11},
+	}))
+	require.NoError(t, s.BatchInsertCommitSHAsWithSubversionRevision(ctx, 2, []types.SubversionRevision{
+		{CommitSHA: api.CommitID(commitSHA4), Revision: 3},
+	}))
+	// Inserting already mapped revisions again is a no-op.
+	require.NoError(t, s.BatchInsertCommitSHAsWithSubversionRevision(ctx, 2, []types.SubversionRevision{
+		{CommitSHA: api.CommitID(commitSHA4), Revision: 3},
+	}))
+
+	t.Run("GetLatestForRepo", func(t *testing.T) {
+		got, err := s.GetLatestForRepo(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, api.RepoID(1), got.RepoID)
+		require.Equal(t, dbutil.CommitBytea(commitSHA2), got.CommitSHA)
+		require.Equal(t, int64(11), got.SubversionRevision)
+
+		_, err = s.GetLatestForRepo(ctx, 3)
+		require.True(t, errors.Is(err, sql.ErrNoRows))
+	})
+
+	t.Run("GetRepoCommitSubversionRevision", func(t *testing.T) {
+		got, err := s.GetRepoCommitSubversionRevision(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, dbutil.CommitBytea(commitSHA1), got.CommitSHA)
+
+		_, err = s.GetRepoCommitSubversionRevision(ctx, 2, 999)
+		var notFoundErr *SubversionRevisionNotFoundError
+		require.True(t, errors.As(err, &notFoundErr))
+		require.Equal(t, api.RepoID(2), notFoundErr.RepoID)
+		require.Equal(t, int64(999), notFoundErr.Revision)
+	})
+
+	t.Run("BatchGetRepoCommitSubversionRevision", func(t *testing.T) {
+		got, err := s.BatchGetRepoCommitSubversionRevision(ctx,
+			RepoSubversionRevisions{RepoID: 1, Revisions: []int64{10, 11, 12}},
+			RepoSubversionRevisions{RepoID: 2, Revisions: []int64{3}},
+		)
+		require.NoError(t, err)
+		require.Len(t, got[1], 2)
+		require.Equal(t, dbutil.CommitBytea(commitSHA2), got[1][11].CommitSHA)
+		require.Equal(t, dbutil.CommitBytea(commitSHA4), got[2][3].CommitSHA)
+
+		got, err = s.BatchGetRepoCommitSubversionRevision(ctx, RepoSubversionRevisions{RepoID: 1})
+		require.NoError(t, err)
+		require.Empty(t, got[1])
+	})
+}
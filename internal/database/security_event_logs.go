@@ -30,6 +30,11 @@ const (
 	SecurityEventNameSignInFailed    SecurityEventName = "SignInFailed"
 	SecurityEventNameSignInSucceeded SecurityEventName = "SignInSucceeded"
 
+	SecurityEventNameSecondFactorEnrolled  SecurityEventName = "SecondFactorEnrolled"
+	SecurityEventNameSecondFactorRemoved   SecurityEventName = "SecondFactorRemoved"
+	SecurityEventNameSecondFactorSucceeded SecurityEventName = "SecondFactorSucceeded"
+	SecurityEventNameSecondFactorFailed    SecurityEventName = "SecondFactorFailed"
+
 	SecurityEventNameAccountCreated  SecurityEventName = "AccountCreated"
 	SecurityEventNameAccountDeleted  SecurityEventName = "AccountDeleted"
 	SecurityEventNameAccountModified SecurityEventName = "AccountModified"
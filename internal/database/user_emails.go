@@ -206,6 +206,7 @@ func (s *userEmailsStore) Remove(ctx context.Context, userID int32, email string
 	if err != nil {
 		return err
 	}
+	clearTargetingFlagsCacheForUser(ctx, tx, userID)
 	return nil
 }
 
@@ -229,6 +230,7 @@ func (s *userEmailsStore) Verify(ctx context.Context, userID int32, email, code
 	if _, err := s.Handle().ExecContext(ctx, "UPDATE user_emails SET verification_code=null, verified_at=now() WHERE user_id=$1 AND email=$2", userID, email); err != nil {
 		return false, err
 	}
+	clearTargetingFlagsCacheForUser(ctx, s, userID)
 
 	return true, nil
 }
@@ -280,6 +282,7 @@ func (s *userEmailsStore) SetVerified(ctx context.Context, userID int32, email s
 		// At this point the email is already verified and the operation successful, so if deletion returns any errors we ignore it.
 		_, _ = s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM user_emails WHERE verified_at IS NULL AND email=%s", email))
 	}
+	clearTargetingFlagsCacheForUser(ctx, s, userID)
 	return nil
 }
 
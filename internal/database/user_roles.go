@@ -148,6 +148,7 @@ func (r *userRoleStore) Assign(ctx context.Context, opts AssignUserRoleOpts) err
 		}
 		return errors.Wrap(err, "scanning user role")
 	}
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -178,6 +179,7 @@ func (r *userRoleStore) AssignSystemRole(ctx context.Context, opts AssignSystemR
 		}
 		return errors.Wrap(err, "scanning user role")
 	}
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -213,6 +215,7 @@ func (r *userRoleStore) BulkAssignRolesToUser(ctx context.Context, opts BulkAssi
 		}
 		return err
 	}
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -248,6 +251,7 @@ func (r *userRoleStore) BulkAssignSystemRolesToUser(ctx context.Context, opts Bu
 		}
 		return err
 	}
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -301,6 +305,7 @@ func (r *userRoleStore) Revoke(ctx context.Context, opts RevokeUserRoleOpts) err
 		}, "failed to revoke user role")
 	}
 
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -325,6 +330,7 @@ func (r *userRoleStore) RevokeSystemRole(ctx context.Context, opts RevokeSystemR
 		return errors.Wrap(err, "running delete query")
 	}
 
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
 	return nil
 }
 
@@ -352,7 +358,11 @@ func (r *userRoleStore) BulkRevokeRolesForUser(ctx context.Context, opts BulkRev
 		sqlf.Join(preds, " AND "),
 	)
 
-	return r.Exec(ctx, q)
+	if err := r.Exec(ctx, q); err != nil {
+		return err
+	}
+	clearTargetingFlagsCacheForUser(ctx, r, opts.UserID)
+	return nil
 }
 
 func (r *userRoleStore) GetByUserID(ctx context.Context, opts GetUserRoleOpts) ([]*types.UserRole, error) {
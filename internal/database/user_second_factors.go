@@ -0,0 +1,437 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UserTOTPCredential is an authenticator app a user enrolled as a second
+// factor.
+type UserTOTPCredential struct {
+	UserID int32
+	// LastUsedCounter is the TOTP counter of the last code that was used.
+	LastUsedCounter int64
+	// ConfirmedAt is nil until the user entered a valid code to finish
+	// enrollment.
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+
+	// unexported so that it is only decrypted when needed. Use Secret to
+	// access it.
+	encryptedSecret *encryption.Encryptable
+}
+
+// Secret decrypts and returns the base32-encoded TOTP secret.
+func (c *UserTOTPCredential) Secret(ctx context.Context) (string, error) {
+	return c.encryptedSecret.Decrypt(ctx)
+}
+
+// UserWebAuthnCredential is a security key a user registered as a second
+// factor.
+type UserWebAuthnCredential struct {
+	ID     int32
+	UserID int32
+	// Name is the name the user gave the security key.
+	Name         string
+	CredentialID []byte
+	// PublicKey is the COSE-encoded public key of the credential.
+	PublicKey  []byte
+	SignCount  uint32
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// UserSecondFactorNotFoundErr is returned when a user has no second factor of
+// the requested kind.
+type UserSecondFactorNotFoundErr struct {
+	userID int32
+	kind   string
+}
+
+func (err UserSecondFactorNotFoundErr) Error() string {
+	return fmt.Sprintf("%s not found: user=%d", err.kind, err.userID)
+}
+
+func (UserSecondFactorNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ErrTOTPAlreadyEnrolled is returned by CreateTOTP if the user already has a
+// confirmed TOTP secret.
+var ErrTOTPAlreadyEnrolled = errors.New("an authenticator app is already enrolled")
+
+// UserSecondFactorStore provides access to the second factors of builtin
+// username/password accounts, stored in the `user_totp_credentials`,
+// `user_webauthn_credentials` and `user_recovery_codes` tables.
+type UserSecondFactorStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) UserSecondFactorStore
+
+	// HasSecondFactor reports whether the user has a confirmed TOTP secret
+	// or a WebAuthn credential, which they must use to sign in.
+	HasSecondFactor(ctx context.Context, userID int32) (bool, error)
+
+	// CreateTOTP stores a new unconfirmed TOTP secret for the user, replacing
+	// an unconfirmed one from an earlier enrollment attempt. It returns
+	// ErrTOTPAlreadyEnrolled if the user has a confirmed secret.
+	CreateTOTP(ctx context.Context, userID int32, secret string) error
+	// GetTOTP returns the TOTP secret of the user, confirmed or not. It
+	// returns UserSecondFactorNotFoundErr if there is none.
+	GetTOTP(ctx context.Context, userID int32) (*UserTOTPCredential, error)
+	// ConfirmTOTP marks the TOTP secret of the user as confirmed, after the
+	// code for counter was entered.
+	ConfirmTOTP(ctx context.Context, userID int32, counter int64) error
+	// UseTOTPCounter records that the code for counter was used to sign in.
+	// It returns false if the code for the same or a later counter was
+	// used already.
+	UseTOTPCounter(ctx context.Context, userID int32, counter int64) (bool, error)
+	// DeleteTOTP deletes the TOTP secret of the user. It returns
+	// UserSecondFactorNotFoundErr if there is none.
+	DeleteTOTP(ctx context.Context, userID int32) error
+
+	// CreateWebAuthnCredential stores a new WebAuthn credential.
+	CreateWebAuthnCredential(ctx context.Context, credential *UserWebAuthnCredential) (*UserWebAuthnCredential, error)
+	// ListWebAuthnCredentials returns the WebAuthn credentials of the user,
+	// oldest first.
+	ListWebAuthnCredentials(ctx context.Context, userID int32) ([]*UserWebAuthnCredential, error)
+	// GetWebAuthnCredential returns the WebAuthn credential of the user with
+	// the given credential ID. It returns UserSecondFactorNotFoundErr if
+	// there is none.
+	GetWebAuthnCredential(ctx context.Context, userID int32, credentialID []byte) (*UserWebAuthnCredential, error)
+	// UpdateWebAuthnSignCount stores the signature counter of a WebAuthn
+	// credential after it was used to sign in. It returns false if the stored
+	// counter is not lower, which means a concurrent sign in used the same or
+	// a later signature. Authenticators without a counter always report 0.
+	UpdateWebAuthnSignCount(ctx context.Context, id int32, signCount uint32) (bool, error)
+	// DeleteWebAuthnCredential deletes a WebAuthn credential of the user. It
+	// returns UserSecondFactorNotFoundErr if there is none with the ID.
+	DeleteWebAuthnCredential(ctx context.Context, userID, id int32) error
+
+	// ReplaceRecoveryCodes replaces all recovery codes of the user with the
+	// codes with the given hashes.
+	ReplaceRecoveryCodes(ctx context.Context, userID int32, codeHashes [][]byte) error
+	// UseRecoveryCode marks the unused recovery code of the user with the
+	// given hash as used. It returns false if there is no such code.
+	UseRecoveryCode(ctx context.Context, userID int32, codeHash []byte) (bool, error)
+	// CountUnusedRecoveryCodes returns the number of recovery codes the user
+	// has left.
+	CountUnusedRecoveryCodes(ctx context.Context, userID int32) (int, error)
+
+	// DeleteAll deletes all second factors and recovery codes of the user.
+	DeleteAll(ctx context.Context, userID int32) error
+}
+
+type userSecondFactorStore struct {
+	logger log.Logger
+	*basestore.Store
+	key encryption.Key
+}
+
+var _ UserSecondFactorStore = (*userSecondFactorStore)(nil)
+
+// UserSecondFactorsWith instantiates and returns a new UserSecondFactorStore
+// using the other store handle. TOTP secrets are encrypted with key.
+func UserSecondFactorsWith(logger log.Logger, other basestore.ShareableStore, key encryption.Key) UserSecondFactorStore {
+	return &userSecondFactorStore{
+		logger: logger,
+		Store:  basestore.NewWithHandle(other.Handle()),
+		key:    key,
+	}
+}
+
+func (s *userSecondFactorStore) With(other basestore.ShareableStore) UserSecondFactorStore {
+	return &userSecondFactorStore{
+		logger: s.logger,
+		Store:  s.Store.With(other),
+		key:    s.key,
+	}
+}
+
+const hasSecondFactorQuery = `
+SELECT
+	EXISTS (SELECT 1 FROM user_totp_credentials WHERE user_id = %s AND confirmed_at IS NOT NULL)
+	OR EXISTS (SELECT 1 FROM user_webauthn_credentials WHERE user_id = %s)
+`
+
+func (s *userSecondFactorStore) HasSecondFactor(ctx context.Context, userID int32) (bool, error) {
+	has, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(hasSecondFactorQuery, userID, userID)))
+	return has, err
+}
+
+const createTOTPQuery = `
+INSERT INTO user_totp_credentials (user_id, secret, encryption_key_id)
+VALUES (%s, %s, %s)
+ON CONFLICT (user_id) DO UPDATE
+SET
+	secret = EXCLUDED.secret,
+	encryption_key_id = EXCLUDED.encryption_key_id,
+	last_used_counter = 0,
+	created_at = now()
+WHERE user_totp_credentials.confirmed_at IS NULL
+`
+
+func (s *userSecondFactorStore) CreateTOTP(ctx context.Context, userID int32, secret string) error {
+	if secret == "" {
+		return errors.New("got empty secret")
+	}
+	data, keyID, err := encryption.MaybeEncrypt(ctx, s.key, secret)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(createTOTPQuery, userID, []byte(data), keyID))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		// The conflicting row is confirmed.
+		return ErrTOTPAlreadyEnrolled
+	}
+	return nil
+}
+
+const getTOTPQuery = `
+SELECT user_id, secret, encryption_key_id, last_used_counter, confirmed_at, created_at
+FROM user_totp_credentials
+WHERE user_id = %s
+`
+
+func (s *userSecondFactorStore) GetTOTP(ctx context.Context, userID int32) (*UserTOTPCredential, error) {
+	var (
+		c      UserTOTPCredential
+		secret []byte
+		keyID  string
+	)
+	err := s.QueryRow(ctx, sqlf.Sprintf(getTOTPQuery, userID)).Scan(
+		&c.UserID,
+		&secret,
+		&keyID,
+		&c.LastUsedCounter,
+		&c.ConfirmedAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, UserSecondFactorNotFoundErr{userID: userID, kind: "TOTP secret"}
+		}
+		return nil, err
+	}
+	c.encryptedSecret = encryption.NewEncrypted(string(secret), keyID, s.key)
+	return &c, nil
+}
+
+func (s *userSecondFactorStore) ConfirmTOTP(ctx context.Context, userID int32, counter int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(
+		"UPDATE user_totp_credentials SET confirmed_at = now(), last_used_counter = %s WHERE user_id = %s AND confirmed_at IS NULL",
+		counter,
+		userID,
+	))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return UserSecondFactorNotFoundErr{userID: userID, kind: "unconfirmed TOTP secret"}
+	}
+	return nil
+}
+
+func (s *userSecondFactorStore) UseTOTPCounter(ctx context.Context, userID int32, counter int64) (bool, error) {
+	// The condition on last_used_counter makes concurrent sign ins with the
+	// same code fail.
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(
+		"UPDATE user_totp_credentials SET last_used_counter = %s WHERE user_id = %s AND confirmed_at IS NOT NULL AND last_used_counter < %s",
+		counter,
+		userID,
+		counter,
+	))
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	return rows > 0, err
+}
+
+func (s *userSecondFactorStore) DeleteTOTP(ctx context.Context, userID int32) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM user_totp_credentials WHERE user_id = %s", userID))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return UserSecondFactorNotFoundErr{userID: userID, kind: "TOTP secret"}
+	}
+	return nil
+}
+
+var webAuthnCredentialColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("credential_id"),
+	sqlf.Sprintf("public_key"),
+	sqlf.Sprintf("sign_count"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("last_used_at"),
+}
+
+func scanWebAuthnCredential(sc dbutil.Scanner) (*UserWebAuthnCredential, error) {
+	var c UserWebAuthnCredential
+	if err := sc.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Name,
+		&c.CredentialID,
+		&c.PublicKey,
+		&c.SignCount,
+		&c.CreatedAt,
+		&c.LastUsedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+var scanWebAuthnCredentials = basestore.NewSliceScanner(scanWebAuthnCredential)
+
+func (s *userSecondFactorStore) CreateWebAuthnCredential(ctx context.Context, c *UserWebAuthnCredential) (*UserWebAuthnCredential, error) {
+	q := sqlf.Sprintf(
+		"INSERT INTO user_webauthn_credentials (user_id, name, credential_id, public_key, sign_count) VALUES (%s, %s, %s, %s, %s) RETURNING %s",
+		c.UserID,
+		c.Name,
+		c.CredentialID,
+		c.PublicKey,
+		c.SignCount,
+		sqlf.Join(webAuthnCredentialColumns, ", "),
+	)
+	return scanWebAuthnCredential(s.QueryRow(ctx, q))
+}
+
+func (s *userSecondFactorStore) ListWebAuthnCredentials(ctx context.Context, userID int32) ([]*UserWebAuthnCredential, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM user_webauthn_credentials WHERE user_id = %s ORDER BY id",
+		sqlf.Join(webAuthnCredentialColumns, ", "),
+		userID,
+	)
+	return scanWebAuthnCredentials(s.Query(ctx, q))
+}
+
+func (s *userSecondFactorStore) GetWebAuthnCredential(ctx context.Context, userID int32, credentialID []byte) (*UserWebAuthnCredential, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM user_webauthn_credentials WHERE user_id = %s AND credential_id = %s",
+		sqlf.Join(webAuthnCredentialColumns, ", "),
+		userID,
+		credentialID,
+	)
+	c, err := scanWebAuthnCredential(s.QueryRow(ctx, q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, UserSecondFactorNotFoundErr{userID: userID, kind: "WebAuthn credential"}
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *userSecondFactorStore) UpdateWebAuthnSignCount(ctx context.Context, id int32, signCount uint32) (bool, error) {
+	// The condition on sign_count makes concurrent sign ins with the same
+	// signature fail, like last_used_counter does for TOTP codes.
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(
+		"UPDATE user_webauthn_credentials SET sign_count = %s, last_used_at = now() WHERE id = %s AND (sign_count < %s OR (sign_count = 0 AND %s = 0))",
+		signCount,
+		id,
+		signCount,
+		signCount,
+	))
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	return rows > 0, err
+}
+
+func (s *userSecondFactorStore) DeleteWebAuthnCredential(ctx context.Context, userID, id int32) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM user_webauthn_credentials WHERE id = %s AND user_id = %s", id, userID))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return UserSecondFactorNotFoundErr{userID: userID, kind: "WebAuthn credential"}
+	}
+	return nil
+}
+
+func (s *userSecondFactorStore) ReplaceRecoveryCodes(ctx context.Context, userID int32, codeHashes [][]byte) error {
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM user_recovery_codes WHERE user_id = %s", userID)); err != nil {
+			return err
+		}
+		if len(codeHashes) == 0 {
+			return nil
+		}
+
+		values := make([]*sqlf.Query, 0, len(codeHashes))
+		for _, h := range codeHashes {
+			values = append(values, sqlf.Sprintf("(%s, %s)", userID, h))
+		}
+		return tx.Exec(ctx, sqlf.Sprintf("INSERT INTO user_recovery_codes (user_id, code_hash) VALUES %s", sqlf.Join(values, ", ")))
+	})
+}
+
+func (s *userSecondFactorStore) UseRecoveryCode(ctx context.Context, userID int32, codeHash []byte) (bool, error) {
+	// Only one row is updated even if the same code was generated twice, so
+	// that each code can be used once.
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE user_recovery_codes SET used_at = now()
+WHERE id = (
+	SELECT id FROM user_recovery_codes
+	WHERE user_id = %s AND code_hash = %s AND used_at IS NULL
+	LIMIT 1
+	FOR UPDATE
+)
+AND used_at IS NULL
+`,
+		userID,
+		codeHash,
+	))
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	return rows > 0, err
+}
+
+func (s *userSecondFactorStore) CountUnusedRecoveryCodes(ctx context.Context, userID int32) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		"SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = %s AND used_at IS NULL",
+		userID,
+	)))
+	return count, err
+}
+
+func (s *userSecondFactorStore) DeleteAll(ctx context.Context, userID int32) error {
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		for _, table := range []string{"user_totp_credentials", "user_webauthn_credentials", "user_recovery_codes"} {
+			if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM "+table+" WHERE user_id = %s", userID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func setUpUserSecondFactorTest(t *testing.T) (context.Context, UserSecondFactorStore, int32) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Helper()
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u", Password: "p"})
+	require.NoError(t, err)
+
+	return ctx, db.UserSecondFactors(et.TestKey{}), user.ID
+}
+
+func TestUserSecondFactors_TOTP(t *testing.T) {
+	ctx, store, userID := setUpUserSecondFactorTest(t)
+
+	_, err := store.GetTOTP(ctx, userID)
+	assert.True(t, errcode.IsNotFound(err))
+
+	require.NoError(t, store.CreateTOTP(ctx, userID, "FIRST"))
+	// Unconfirmed secrets don't count and can be replaced.
+	has, err := store.HasSecondFactor(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, has)
+	require.NoError(t, store.CreateTOTP(ctx, userID, "SECOND"))
+
+	c, err := store.GetTOTP(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, c.ConfirmedAt)
+	secret, err := c.Secret(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "SECOND", secret)
+
+	// Codes can't be used before the secret is confirmed.
+	ok, err := store.UseTOTPCounter(ctx, userID, 5)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.ConfirmTOTP(ctx, userID, 10))
+	assert.True(t, errcode.IsNotFound(store.ConfirmTOTP(ctx, userID, 11)))
+	assert.ErrorIs(t, store.CreateTOTP(ctx, userID, "THIRD"), ErrTOTPAlreadyEnrolled)
+
+	has, err = store.HasSecondFactor(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	for _, tc := range []struct {
+		counter int64
+		want    bool
+	}{
+		{10, false},
+		{11, true},
+		{11, false},
+		{9, false},
+		{12, true},
+	} {
+		ok, err := store.UseTOTPCounter(ctx, userID, tc.counter)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, ok, "counter %d", tc.counter)
+	}
+
+	require.NoError(t, store.DeleteTOTP(ctx, userID))
+	assert.True(t, errcode.IsNotFound(store.DeleteTOTP(ctx, userID)))
+	has, err = store.HasSecondFactor(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestUserSecondFactors_WebAuthn(t *testing.T) {
+	ctx, store, userID := setUpUserSecondFactorTest(t)
+
+	created, err := store.CreateWebAuthnCredential(ctx, &UserWebAuthnCredential{
+		UserID:       userID,
+		Name:         "my key",
+		CredentialID: []byte("credential"),
+		PublicKey:    []byte("public key"),
+		SignCount:    3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "my key", created.Name)
+	assert.Nil(t, created.LastUsedAt)
+
+	has, err := store.HasSecondFactor(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = store.GetWebAuthnCredential(ctx, userID, []byte("other"))
+	assert.True(t, errcode.IsNotFound(err))
+
+	ok, err := store.UpdateWebAuthnSignCount(ctx, created.ID, 4)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	// A concurrent sign in with the same or an older signature must fail.
+	for _, signCount := range []uint32{4, 3} {
+		ok, err = store.UpdateWebAuthnSignCount(ctx, created.ID, signCount)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	got, err := store.GetWebAuthnCredential(ctx, userID, []byte("credential"))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), got.SignCount)
+	assert.Equal(t, []byte("public key"), got.PublicKey)
+	assert.NotNil(t, got.LastUsedAt)
+
+	list, err := store.ListWebAuthnCredentials(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, created.ID, list[0].ID)
+
+	assert.True(t, errcode.IsNotFound(store.DeleteWebAuthnCredential(ctx, userID+1, created.ID)))
+	require.NoError(t, store.DeleteWebAuthnCredential(ctx, userID, created.ID))
+	list, err = store.ListWebAuthnCredentials(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// Authenticators without a counter always report 0.
+	counterless, err := store.CreateWebAuthnCredential(ctx, &UserWebAuthnCredential{
+		UserID:       userID,
+		Name:         "counterless key",
+		CredentialID: []byte("counterless"),
+		PublicKey:    []byte("public key"),
+	})
+	require.NoError(t, err)
+	for range 2 {
+		ok, err = store.UpdateWebAuthnSignCount(ctx, counterless.ID, 0)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestUserSecondFactors_RecoveryCodes(t *testing.T) {
+	ctx, store, userID := setUpUserSecondFactorTest(t)
+
+	require.NoError(t, store.ReplaceRecoveryCodes(ctx, userID, [][]byte{[]byte("a"), []byte("b")}))
+	count, err := store.CountUnusedRecoveryCodes(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	ok, err := store.UseRecoveryCode(ctx, userID, []byte("a"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = store.UseRecoveryCode(ctx, userID, []byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = store.UseRecoveryCode(ctx, userID, []byte("c"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	count, err = store.CountUnusedRecoveryCodes(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, store.ReplaceRecoveryCodes(ctx, userID, [][]byte{[]byte("a")}))
+	ok, err = store.UseRecoveryCode(ctx, userID, []byte("b"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = store.UseRecoveryCode(ctx, userID, []byte("a"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestUserSecondFactors_DeleteAll(t *testing.T) {
+	ctx, store, userID := setUpUserSecondFactorTest(t)
+
+	require.NoError(t, store.CreateTOTP(ctx, userID, "SECRET"))
+	require.NoError(t, store.ConfirmTOTP(ctx, userID, 1))
+	_, err := store.CreateWebAuthnCredential(ctx, &UserWebAuthnCredential{
+		UserID:       userID,
+		CredentialID: []byte("credential"),
+		PublicKey:    []byte("public key"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.ReplaceRecoveryCodes(ctx, userID, [][]byte{[]byte("a")}))
+
+	require.NoError(t, store.DeleteAll(ctx, userID))
+
+	has, err := store.HasSecondFactor(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, has)
+	count, err := store.CountUnusedRecoveryCodes(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
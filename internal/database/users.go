@@ -923,6 +923,9 @@ func (u *userStore) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bo
 		if err != nil {
 			return err
 		}
+		// Targeting rules can match on site admins. The role changes below
+		// clear the cache too, but not if the user already had the role.
+		clearTargetingFlagsCacheForUser(ctx, tx, id)
 
 		userRoleStore := tx.UserRoles()
 		if isSiteAdmin {
@@ -104,6 +104,13 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if keyConfig.UserSecondFactorKey != nil {
+		r.UserSecondFactorKey, err = NewKey(ctx, keyConfig.UserSecondFactorKey, keyConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if keyConfig.WebhookKey != nil {
 		r.WebhookKey, err = NewKey(ctx, keyConfig.WebhookKey, keyConfig)
 		if err != nil {
@@ -134,6 +141,7 @@ type Ring struct {
 	GitHubAppKey              encryption.Key
 	OutboundWebhookKey        encryption.Key
 	UserExternalAccountKey    encryption.Key
+	UserSecondFactorKey       encryption.Key
 	WebhookKey                encryption.Key
 	WebhookLogKey             encryption.Key
 	ExecutorSecretKey         encryption.Key
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// CacheEntryStore is the store for interacting with the executor_cache_entries table.
+// The archives of the entries are stored in object storage.
+type CacheEntryStore interface {
+	// Exists checks if the CacheEntry exists.
+	Exists(ctx context.Context, queue string, key string) (bool, error)
+	// MarkAccessed updates the last access time of the matching CacheEntry. If no entry
+	// matches, a false-valued flag is returned.
+	MarkAccessed(ctx context.Context, queue string, key string) (bool, error)
+	// Upsert creates the matching CacheEntry, or replaces it if it exists.
+	Upsert(ctx context.Context, queue string, key string, sizeBytes int64) error
+	// ListEvictable returns the CacheEntries that were created longer than maxAge ago, or
+	// that don't fit into maxTotalSizeBytes when keeping the most recently accessed
+	// entries. Entries are ordered from least to most recently accessed.
+	ListEvictable(ctx context.Context, maxAge time.Duration, maxTotalSizeBytes int64) ([]CacheEntry, error)
+	// Delete deletes the CacheEntry, unless it was replaced since it was read. If nothing
+	// was deleted, a false-valued flag is returned.
+	Delete(ctx context.Context, entry CacheEntry) (bool, error)
+}
+
+// CacheEntry is a cache that executor jobs keep between runs.
+type CacheEntry struct {
+	ID             int
+	Queue          string
+	Key            string
+	SizeBytes      int64
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+}
+
+type cacheEntryStore struct {
+	*basestore.Store
+}
+
+// NewCacheEntryStore creates a new CacheEntryStore.
+func NewCacheEntryStore(db database.DB) CacheEntryStore {
+	return &cacheEntryStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+func (s *cacheEntryStore) Exists(ctx context.Context, queue string, key string) (bool, error) {
+	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(existsCacheEntryFmtstr, queue, key)))
+	return exists, err
+}
+
+const existsCacheEntryFmtstr = `
+SELECT EXISTS(SELECT 1 FROM executor_cache_entries WHERE queue = %s AND key = %s)
+`
+
+func (s *cacheEntryStore) MarkAccessed(ctx context.Context, queue string, key string) (bool, error) {
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(markAccessedCacheEntryFmtstr, queue, key)))
+	return ok, err
+}
+
+const markAccessedCacheEntryFmtstr = `
+UPDATE executor_cache_entries SET last_accessed_at = NOW()
+WHERE queue = %s AND key = %s
+RETURNING id
+`
+
+func (s *cacheEntryStore) Upsert(ctx context.Context, queue string, key string, sizeBytes int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(upsertCacheEntryFmtstr, queue, key, sizeBytes))
+}
+
+const upsertCacheEntryFmtstr = `
+INSERT INTO executor_cache_entries (queue, key, size_bytes)
+VALUES (%s, %s, %s)
+ON CONFLICT (queue, key) DO UPDATE SET
+	size_bytes = EXCLUDED.size_bytes,
+	created_at = NOW(),
+	last_accessed_at = NOW()
+`
+
+func (s *cacheEntryStore) ListEvictable(ctx context.Context, maxAge time.Duration, maxTotalSizeBytes int64) ([]CacheEntry, error) {
+	return scanCacheEntries(s.Query(ctx, sqlf.Sprintf(listEvictableCacheEntriesFmtstr, maxAge/time.Second, maxTotalSizeBytes)))
+}
+
+const listEvictableCacheEntriesFmtstr = `
+SELECT id, queue, key, size_bytes, created_at, last_accessed_at
+FROM (
+	SELECT
+		e.*,
+		SUM(e.size_bytes) OVER (ORDER BY e.last_accessed_at DESC, e.id DESC) AS cumulative_size_bytes
+	FROM executor_cache_entries e
+) e
+WHERE
+	e.created_at < NOW() - (%s * interval '1 second') OR
+	e.cumulative_size_bytes > %s
+ORDER BY e.last_accessed_at, e.id
+`
+
+func (s *cacheEntryStore) Delete(ctx context.Context, entry CacheEntry) (bool, error) {
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(deleteCacheEntryFmtstr, entry.ID, entry.CreatedAt)))
+	return ok, err
+}
+
+const deleteCacheEntryFmtstr = `
+DELETE FROM executor_cache_entries WHERE id = %s AND created_at = %s
+RETURNING id
+`
+
+var scanCacheEntries = basestore.NewSliceScanner(scanCacheEntry)
+
+func scanCacheEntry(s dbutil.Scanner) (entry CacheEntry, err error) {
+	err = s.Scan(
+		&entry.ID,
+		&entry.Queue,
+		&entry.Key,
+		&entry.SizeBytes,
+		&entry.CreatedAt,
+		&entry.LastAccessedAt,
+	)
+	return entry, err
+}
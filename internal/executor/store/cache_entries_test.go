@@ -0,0 +1,99 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+)
+
+func TestCacheEntryStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	cacheStore := store.NewCacheEntryStore(db)
+
+	ctx := context.Background()
+
+	exists, err := cacheStore.Exists(ctx, "batches", "go-mod")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	ok, err := cacheStore.MarkAccessed(ctx, "batches", "go-mod")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, cacheStore.Upsert(ctx, "batches", "go-mod", 10))
+	require.NoError(t, cacheStore.Upsert(ctx, "batches", "node-modules", 20))
+	require.NoError(t, cacheStore.Upsert(ctx, "codeintel", "go-mod", 30))
+
+	exists, err = cacheStore.Exists(ctx, "batches", "go-mod")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = cacheStore.Exists(ctx, "codeintel", "node-modules")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Accessing the first entry makes node-modules the least recently used one.
+	ok, err = cacheStore.MarkAccessed(ctx, "batches", "go-mod")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = db.ExecContext(ctx, `UPDATE executor_cache_entries SET last_accessed_at = NOW() + interval '1 minute' WHERE queue = 'batches' AND key = 'go-mod'`)
+	require.NoError(t, err)
+
+	t.Run("nothing to evict", func(t *testing.T) {
+		entries, err := cacheStore.ListEvictable(ctx, time.Hour, 100)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		entries, err := cacheStore.ListEvictable(ctx, time.Hour, 25)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "node-modules", entries[0].Key)
+		assert.Equal(t, int64(20), entries[0].SizeBytes)
+		assert.Equal(t, "codeintel", entries[1].Queue)
+	})
+
+	t.Run("age limit", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `UPDATE executor_cache_entries SET created_at = NOW() - interval '2 hours' WHERE queue = 'codeintel'`)
+		require.NoError(t, err)
+
+		entries, err := cacheStore.ListEvictable(ctx, time.Hour, 100)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "codeintel", entries[0].Queue)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		entries, err := cacheStore.ListEvictable(ctx, time.Hour, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		// Replacing an entry after it was listed keeps it from being deleted.
+		require.NoError(t, cacheStore.Upsert(ctx, "batches", "go-mod", 15))
+
+		var deleted int
+		for _, entry := range entries {
+			ok, err := cacheStore.Delete(ctx, entry)
+			require.NoError(t, err)
+			if ok {
+				deleted++
+			}
+		}
+		assert.Equal(t, 2, deleted)
+
+		remaining, err := cacheStore.ListEvictable(ctx, time.Hour, 0)
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, "go-mod", remaining[0].Key)
+		assert.Equal(t, int64(15), remaining[0].SizeBytes)
+	})
+}
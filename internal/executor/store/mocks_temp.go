@@ -9,8 +9,673 @@ package store
 import (
 	"context"
 	"sync"
+	"time"
 )
 
+// MockCacheEntryStore is a mock implementation of the CacheEntryStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/executor/store) used for unit
+// testing.
+type MockCacheEntryStore struct {
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *CacheEntryStoreDeleteFunc
+	// ExistsFunc is an instance of a mock function object controlling the
+	// behavior of the method Exists.
+	ExistsFunc *CacheEntryStoreExistsFunc
+	// ListEvictableFunc is an instance of a mock function object
+	// controlling the behavior of the method ListEvictable.
+	ListEvictableFunc *CacheEntryStoreListEvictableFunc
+	// MarkAccessedFunc is an instance of a mock function object controlling
+	// the behavior of the method MarkAccessed.
+	MarkAccessedFunc *CacheEntryStoreMarkAccessedFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *CacheEntryStoreUpsertFunc
+}
+
+// NewMockCacheEntryStore creates a new mock of the CacheEntryStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockCacheEntryStore() *MockCacheEntryStore {
+	return &MockCacheEntryStore{
+		DeleteFunc: &CacheEntryStoreDeleteFunc{
+			defaultHook: func(context.Context, CacheEntry) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ExistsFunc: &CacheEntryStoreExistsFunc{
+			defaultHook: func(context.Context, string, string) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListEvictableFunc: &CacheEntryStoreListEvictableFunc{
+			defaultHook: func(context.Context, time.Duration, int64) (r0 []CacheEntry, r1 error) {
+				return
+			},
+		},
+		MarkAccessedFunc: &CacheEntryStoreMarkAccessedFunc{
+			defaultHook: func(context.Context, string, string) (r0 bool, r1 error) {
+				return
+			},
+		},
+		UpsertFunc: &CacheEntryStoreUpsertFunc{
+			defaultHook: func(context.Context, string, string, int64) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockCacheEntryStore creates a new mock of the CacheEntryStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockCacheEntryStore() *MockCacheEntryStore {
+	return &MockCacheEntryStore{
+		DeleteFunc: &CacheEntryStoreDeleteFunc{
+			defaultHook: func(context.Context, CacheEntry) (bool, error) {
+				panic("unexpected invocation of MockCacheEntryStore.Delete")
+			},
+		},
+		ExistsFunc: &CacheEntryStoreExistsFunc{
+			defaultHook: func(context.Context, string, string) (bool, error) {
+				panic("unexpected invocation of MockCacheEntryStore.Exists")
+			},
+		},
+		ListEvictableFunc: &CacheEntryStoreListEvictableFunc{
+			defaultHook: func(context.Context, time.Duration, int64) ([]CacheEntry, error) {
+				panic("unexpected invocation of MockCacheEntryStore.ListEvictable")
+			},
+		},
+		MarkAccessedFunc: &CacheEntryStoreMarkAccessedFunc{
+			defaultHook: func(context.Context, string, string) (bool, error) {
+				panic("unexpected invocation of MockCacheEntryStore.MarkAccessed")
+			},
+		},
+		UpsertFunc: &CacheEntryStoreUpsertFunc{
+			defaultHook: func(context.Context, string, string, int64) error {
+				panic("unexpected invocation of MockCacheEntryStore.Upsert")
+			},
+		},
+	}
+}
+
+// NewMockCacheEntryStoreFrom creates a new mock of the MockCacheEntryStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockCacheEntryStoreFrom(i CacheEntryStore) *MockCacheEntryStore {
+	return &MockCacheEntryStore{
+		DeleteFunc: &CacheEntryStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		ExistsFunc: &CacheEntryStoreExistsFunc{
+			defaultHook: i.Exists,
+		},
+		ListEvictableFunc: &CacheEntryStoreListEvictableFunc{
+			defaultHook: i.ListEvictable,
+		},
+		MarkAccessedFunc: &CacheEntryStoreMarkAccessedFunc{
+			defaultHook: i.MarkAccessed,
+		},
+		UpsertFunc: &CacheEntryStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+	}
+}
+
+// CacheEntryStoreDeleteFunc describes the behavior when the Delete method
+// of the parent MockCacheEntryStore instance is invoked.
+type CacheEntryStoreDeleteFunc struct {
+	defaultHook func(context.Context, CacheEntry) (bool, error)
+	hooks       []func(context.Context, CacheEntry) (bool, error)
+	history     []CacheEntryStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCacheEntryStore) Delete(v0 context.Context, v1 CacheEntry) (bool, error) {
+	r0, r1 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(CacheEntryStoreDeleteFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockCacheEntryStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheEntryStoreDeleteFunc) SetDefaultHook(hook func(context.Context, CacheEntry) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockCacheEntryStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheEntryStoreDeleteFunc) PushHook(hook func(context.Context, CacheEntry) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheEntryStoreDeleteFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, CacheEntry) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheEntryStoreDeleteFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, CacheEntry) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CacheEntryStoreDeleteFunc) nextHook() func(context.Context, CacheEntry) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheEntryStoreDeleteFunc) appendCall(r0 CacheEntryStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheEntryStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *CacheEntryStoreDeleteFunc) History() []CacheEntryStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheEntryStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheEntryStoreDeleteFuncCall is an object that describes an invocation
+// of method Delete on an instance of MockCacheEntryStore.
+type CacheEntryStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 CacheEntry
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheEntryStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheEntryStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CacheEntryStoreExistsFunc describes the behavior when the Exists method
+// of the parent MockCacheEntryStore instance is invoked.
+type CacheEntryStoreExistsFunc struct {
+	defaultHook func(context.Context, string, string) (bool, error)
+	hooks       []func(context.Context, string, string) (bool, error)
+	history     []CacheEntryStoreExistsFuncCall
+	mutex       sync.Mutex
+}
+
+// Exists delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCacheEntryStore) Exists(v0 context.Context, v1 string, v2 string) (bool, error) {
+	r0, r1 := m.ExistsFunc.nextHook()(v0, v1, v2)
+	m.ExistsFunc.appendCall(CacheEntryStoreExistsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Exists method of the
+// parent MockCacheEntryStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheEntryStoreExistsFunc) SetDefaultHook(hook func(context.Context, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Exists method of the parent MockCacheEntryStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheEntryStoreExistsFunc) PushHook(hook func(context.Context, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheEntryStoreExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheEntryStoreExistsFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CacheEntryStoreExistsFunc) nextHook() func(context.Context, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheEntryStoreExistsFunc) appendCall(r0 CacheEntryStoreExistsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheEntryStoreExistsFuncCall objects
+// describing the invocations of this function.
+func (f *CacheEntryStoreExistsFunc) History() []CacheEntryStoreExistsFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheEntryStoreExistsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheEntryStoreExistsFuncCall is an object that describes an invocation
+// of method Exists on an instance of MockCacheEntryStore.
+type CacheEntryStoreExistsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheEntryStoreExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheEntryStoreExistsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CacheEntryStoreListEvictableFunc describes the behavior when the
+// ListEvictable method of the parent MockCacheEntryStore instance is
+// invoked.
+type CacheEntryStoreListEvictableFunc struct {
+	defaultHook func(context.Context, time.Duration, int64) ([]CacheEntry, error)
+	hooks       []func(context.Context, time.Duration, int64) ([]CacheEntry, error)
+	history     []CacheEntryStoreListEvictableFuncCall
+	mutex       sync.Mutex
+}
+
+// ListEvictable delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCacheEntryStore) ListEvictable(v0 context.Context, v1 time.Duration, v2 int64) ([]CacheEntry, error) {
+	r0, r1 := m.ListEvictableFunc.nextHook()(v0, v1, v2)
+	m.ListEvictableFunc.appendCall(CacheEntryStoreListEvictableFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListEvictable method
+// of the parent MockCacheEntryStore instance is invoked and the hook queue
+// is empty.
+func (f *CacheEntryStoreListEvictableFunc) SetDefaultHook(hook func(context.Context, time.Duration, int64) ([]CacheEntry, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListEvictable method of the parent MockCacheEntryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CacheEntryStoreListEvictableFunc) PushHook(hook func(context.Context, time.Duration, int64) ([]CacheEntry, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheEntryStoreListEvictableFunc) SetDefaultReturn(r0 []CacheEntry, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int64) ([]CacheEntry, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheEntryStoreListEvictableFunc) PushReturn(r0 []CacheEntry, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, int64) ([]CacheEntry, error) {
+		return r0, r1
+	})
+}
+
+func (f *CacheEntryStoreListEvictableFunc) nextHook() func(context.Context, time.Duration, int64) ([]CacheEntry, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheEntryStoreListEvictableFunc) appendCall(r0 CacheEntryStoreListEvictableFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheEntryStoreListEvictableFuncCall
+// objects describing the invocations of this function.
+func (f *CacheEntryStoreListEvictableFunc) History() []CacheEntryStoreListEvictableFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheEntryStoreListEvictableFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheEntryStoreListEvictableFuncCall is an object that describes an
+// invocation of method ListEvictable on an instance of MockCacheEntryStore.
+type CacheEntryStoreListEvictableFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []CacheEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheEntryStoreListEvictableFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheEntryStoreListEvictableFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CacheEntryStoreMarkAccessedFunc describes the behavior when the
+// MarkAccessed method of the parent MockCacheEntryStore instance is
+// invoked.
+type CacheEntryStoreMarkAccessedFunc struct {
+	defaultHook func(context.Context, string, string) (bool, error)
+	hooks       []func(context.Context, string, string) (bool, error)
+	history     []CacheEntryStoreMarkAccessedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkAccessed delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCacheEntryStore) MarkAccessed(v0 context.Context, v1 string, v2 string) (bool, error) {
+	r0, r1 := m.MarkAccessedFunc.nextHook()(v0, v1, v2)
+	m.MarkAccessedFunc.appendCall(CacheEntryStoreMarkAccessedFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the MarkAccessed method
+// of the parent MockCacheEntryStore instance is invoked and the hook queue
+// is empty.
+func (f *CacheEntryStoreMarkAccessedFunc) SetDefaultHook(hook func(context.Context, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkAccessed method of the parent MockCacheEntryStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CacheEntryStoreMarkAccessedFunc) PushHook(hook func(context.Context, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheEntryStoreMarkAccessedFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheEntryStoreMarkAccessedFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CacheEntryStoreMarkAccessedFunc) nextHook() func(context.Context, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheEntryStoreMarkAccessedFunc) appendCall(r0 CacheEntryStoreMarkAccessedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheEntryStoreMarkAccessedFuncCall objects
+// describing the invocations of this function.
+func (f *CacheEntryStoreMarkAccessedFunc) History() []CacheEntryStoreMarkAccessedFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheEntryStoreMarkAccessedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheEntryStoreMarkAccessedFuncCall is an object that describes an
+// invocation of method MarkAccessed on an instance of MockCacheEntryStore.
+type CacheEntryStoreMarkAccessedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheEntryStoreMarkAccessedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheEntryStoreMarkAccessedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CacheEntryStoreUpsertFunc describes the behavior when the Upsert method
+// of the parent MockCacheEntryStore instance is invoked.
+type CacheEntryStoreUpsertFunc struct {
+	defaultHook func(context.Context, string, string, int64) error
+	hooks       []func(context.Context, string, string, int64) error
+	history     []CacheEntryStoreUpsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCacheEntryStore) Upsert(v0 context.Context, v1 string, v2 string, v3 int64) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertFunc.appendCall(CacheEntryStoreUpsertFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockCacheEntryStore instance is invoked and the hook queue is
+// empty.
+func (f *CacheEntryStoreUpsertFunc) SetDefaultHook(hook func(context.Context, string, string, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upsert method of the parent MockCacheEntryStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CacheEntryStoreUpsertFunc) PushHook(hook func(context.Context, string, string, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CacheEntryStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CacheEntryStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, int64) error {
+		return r0
+	})
+}
+
+func (f *CacheEntryStoreUpsertFunc) nextHook() func(context.Context, string, string, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CacheEntryStoreUpsertFunc) appendCall(r0 CacheEntryStoreUpsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CacheEntryStoreUpsertFuncCall objects
+// describing the invocations of this function.
+func (f *CacheEntryStoreUpsertFunc) History() []CacheEntryStoreUpsertFuncCall {
+	f.mutex.Lock()
+	history := make([]CacheEntryStoreUpsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CacheEntryStoreUpsertFuncCall is an object that describes an invocation
+// of method Upsert on an instance of MockCacheEntryStore.
+type CacheEntryStoreUpsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CacheEntryStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CacheEntryStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockJobTokenStore is a mock implementation of the JobTokenStore interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/internal/executor/store) used for unit
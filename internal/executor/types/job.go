@@ -280,6 +280,10 @@ type DockerStep struct {
 
 	// Env specifies a set of NAME=value pairs to supply to the docker command.
 	Env []string `json:"env"`
+
+	// Caches are restored into the workspace before the job runs, and saved after
+	// all steps completed successfully.
+	Caches []StepCache `json:"caches,omitempty"`
 }
 
 // CliStep is a step that runs a src-cli command.
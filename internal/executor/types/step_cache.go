@@ -0,0 +1,41 @@
+package types
+
+import (
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StepCache describes a set of workspace paths that are kept between runs of a job,
+// such as dependency caches.
+type StepCache struct {
+	// Key identifies the cache entry within the queue of the job.
+	Key string `json:"key"`
+
+	// KeyFiles are paths relative to the workspace root whose content is hashed into
+	// the key of the cache entry. This is used to start with a fresh entry once a
+	// lockfile changes.
+	KeyFiles []string `json:"keyFiles,omitempty"`
+
+	// Paths are the files and directories relative to the workspace root that make up
+	// the cache entry.
+	Paths []string `json:"paths"`
+}
+
+var cacheKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// MaxCacheKeyLength is the maximum length of a cache key, including the hash of
+// the key files.
+const MaxCacheKeyLength = 255
+
+// ValidateCacheKey returns an error if the given key cannot be used as the key of a
+// cache entry. Keys are used in URLs and object storage keys unescaped.
+func ValidateCacheKey(key string) error {
+	if len(key) > MaxCacheKeyLength {
+		return errors.Newf("cache key must not be longer than %d characters", MaxCacheKeyLength)
+	}
+	if !cacheKeyPattern.MatchString(key) {
+		return errors.Newf("invalid cache key %q: must start with a letter or digit and only contain letters, digits, '.', '_' and '-'", key)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestValidateCacheKey(t *testing.T) {
+	tests := []struct {
+		key         string
+		expectValid bool
+	}{
+		{key: "go-mod", expectValid: true},
+		{key: "node_modules.v2-3f2a", expectValid: true},
+		{key: "", expectValid: false},
+		{key: "..", expectValid: false},
+		{key: ".hidden", expectValid: false},
+		{key: "foo/bar", expectValid: false},
+		{key: "foo bar", expectValid: false},
+		{key: strings.Repeat("a", types.MaxCacheKeyLength), expectValid: true},
+		{key: strings.Repeat("a", types.MaxCacheKeyLength+1), expectValid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			err := types.ValidateCacheKey(test.key)
+			if test.expectValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
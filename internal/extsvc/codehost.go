@@ -15,7 +15,7 @@ type CodeHost struct {
 
 func (c *CodeHost) IsPackageHost() bool {
 	switch c.ServiceType {
-	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages:
+	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages, TypeNuGetPackages, TypeHexPackages, TypePackagistPackages:
 		return true
 	}
 	return false
@@ -49,6 +49,15 @@ var (
 	RubyURL      = &url.URL{Host: "rubygems"}
 	RubyPackages = NewCodeHost(RubyURL, TypeRubyPackages)
 
+	NuGetURL      = &url.URL{Host: "nuget"}
+	NuGetPackages = NewCodeHost(NuGetURL, TypeNuGetPackages)
+
+	HexURL      = &url.URL{Host: "hex"}
+	HexPackages = NewCodeHost(HexURL, TypeHexPackages)
+
+	PackagistURL      = &url.URL{Host: "packagist"}
+	PackagistPackages = NewCodeHost(PackagistURL, TypePackagistPackages)
+
 	PublicCodeHosts = []*CodeHost{
 		GitHubDotCom,
 		GitLabDotCom,
@@ -58,6 +67,9 @@ var (
 		PythonPackages,
 		RustPackages,
 		RubyPackages,
+		NuGetPackages,
+		HexPackages,
+		PackagistPackages,
 	}
 )
 
@@ -0,0 +1,333 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+// defaultPageSize is the number of items requested per page. Gitea caps the
+// page size at the instance's MAX_RESPONSE_ITEMS setting, which defaults to 50
+// but may be lower, so pages can come back shorter than this.
+const defaultPageSize = 50
+
+// Client access a Gitea (or Forgejo) instance via the REST API.
+type Client struct {
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// token is the access token used to authenticate requests.
+	token string
+
+	// sudo is the name of the user to impersonate. Only site admin tokens are
+	// allowed to impersonate other users.
+	sudo string
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a concept
+	// of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, baseURL *url.URL, token string, httpClient httpcli.Doer) (*Client, error) {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	// API paths are resolved relative to the base URL, so it needs to end in a
+	// slash for instances that are served from a sub-path.
+	u := *baseURL
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return &Client{
+		URL:        &u,
+		token:      token,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("GiteaClient"), urn)),
+	}, nil
+}
+
+// WithSudo returns a copy of the client that performs all requests on behalf
+// of the given user. This requires the client's token to belong to a site
+// admin.
+func (c *Client) WithSudo(username string) *Client {
+	cc := *c
+	cc.sudo = username
+	return &cc
+}
+
+// WithToken returns a copy of the client that authenticates with the given
+// token instead of the one it was configured with.
+func (c *Client) WithToken(token string) *Client {
+	cc := *c
+	cc.token = token
+	return &cc
+}
+
+// GetAuthenticatedUser returns the user the client's token belongs to.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "api/v1/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUser returns the user with the given username.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	u := url.URL{Path: "api/v1/users/" + username}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetRepo returns the repository identified by owner and name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	u := url.URL{Path: repoPath(owner, name)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// DeleteBranch deletes the given branch of the repository identified by owner
+// and name.
+func (c *Client) DeleteBranch(ctx context.Context, owner, name, branch string) error {
+	u := url.URL{Path: repoPath(owner, name) + "/branches/" + branch}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// ListOrgRepos lists all repositories of the given organization that are
+// visible to the client.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) *iterator.Iterator[*Repository] {
+	return listPages[*Repository](ctx, c, "api/v1/orgs/"+org+"/repos", nil)
+}
+
+// ListUserRepos lists all repositories owned by the given user that are
+// visible to the client.
+func (c *Client) ListUserRepos(ctx context.Context, username string) *iterator.Iterator[*Repository] {
+	return listPages[*Repository](ctx, c, "api/v1/users/"+username+"/repos", nil)
+}
+
+// ListAccessibleRepos lists all repositories the authenticated user (or the
+// impersonated user, see WithSudo) has at least read access to. This includes
+// repositories the user owns, collaborates on, or can access through
+// organization team membership.
+func (c *Client) ListAccessibleRepos(ctx context.Context) *iterator.Iterator[*Repository] {
+	return listPages[*Repository](ctx, c, "api/v1/user/repos", nil)
+}
+
+// searchReposResponse defines the response envelope returned by the repository
+// search endpoint.
+type searchReposResponse struct {
+	OK   bool          `json:"ok"`
+	Data []*Repository `json:"data"`
+}
+
+// SearchRepos lists all repositories visible to the client that match the
+// given keyword. An empty query matches all repositories.
+func (c *Client) SearchRepos(ctx context.Context, query string) *iterator.Iterator[*Repository] {
+	qs := make(url.Values)
+	if query != "" {
+		qs.Set("q", query)
+	}
+
+	page := 1
+	fetched := 0
+	done := false
+
+	return iterator.New(func() ([]*Repository, error) {
+		if done {
+			return nil, nil
+		}
+
+		q := paginate(qs, page)
+		u := url.URL{Path: "api/v1/repos/search", RawQuery: q.Encode()}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp searchReposResponse
+		res, err := c.do(ctx, req, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.OK {
+			return nil, errors.Newf("Gitea repository search for %q failed", query)
+		}
+
+		page++
+		fetched += len(resp.Data)
+		done = isLastPage(res, len(resp.Data), fetched)
+
+		return resp.Data, nil
+	})
+}
+
+// listPages returns an iterator over a paginated list endpoint. Gitea does not
+// return a cursor, so we keep requesting pages until isLastPage.
+func listPages[T any](ctx context.Context, c *Client, path string, qs url.Values) *iterator.Iterator[T] {
+	page := 1
+	fetched := 0
+	done := false
+
+	return iterator.New(func() ([]T, error) {
+		if done {
+			return nil, nil
+		}
+
+		u := url.URL{Path: path, RawQuery: paginate(qs, page).Encode()}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []T
+		res, err := c.do(ctx, req, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		page++
+		fetched += len(items)
+		done = isLastPage(res, len(items), fetched)
+
+		return items, nil
+	})
+}
+
+// isLastPage reports whether the response with n items was the last page of a
+// list, after fetched items in total. A short page doesn't tell, because the
+// instance may return fewer items than we asked for. We use the total reported
+// in X-Total-Count if there is one, and otherwise stop at the first empty page.
+func isLastPage(resp *http.Response, n, fetched int) bool {
+	if n == 0 {
+		return true
+	}
+	if total, err := strconv.Atoi(resp.Header.Get("X-Total-Count")); err == nil {
+		return fetched >= total
+	}
+	return false
+}
+
+func paginate(qs url.Values, page int) url.Values {
+	q := make(url.Values, len(qs)+2)
+	for k, v := range qs {
+		q[k] = v
+	}
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(defaultPageSize))
+	return q
+}
+
+func repoPath(owner, name string) string {
+	return "api/v1/repos/" + owner + "/" + name
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	if req.Header.Get("Content-Type") == "" && req.Method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	if c.sudo != "" {
+		req.Header.Set("Sudo", c.sudo)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,125 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/inconshreveable/log15" //nolint:logging // TODO move all logging to sourcegraph/log
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOrgRepos", *update)
+	defer save()
+
+	it := cli.ListOrgRepos(context.Background(), "sourcegraph")
+
+	var repos []*Repository
+	for it.Next() {
+		repos = append(repos, it.Current())
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/ListOrgRepos.json", *update, repos)
+}
+
+func TestClient_ListPages(t *testing.T) {
+	// The server caps the page size at 2, like an instance with
+	// MAX_RESPONSE_ITEMS = 2 would, even though we ask for more.
+	const maxResponseItems = 2
+	all := make([]*Repository, 5)
+	for i := range all {
+		all[i] = &Repository{ID: int64(i + 1), Name: "repo-" + strconv.Itoa(i+1)}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		totalCount bool
+		wantPages  int
+	}{
+		{name: "with X-Total-Count", totalCount: true, wantPages: 3},
+		{name: "without X-Total-Count", totalCount: false, wantPages: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var pages atomic.Int32
+			cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				pages.Add(1)
+				assert.Equal(t, strconv.Itoa(defaultPageSize), r.URL.Query().Get("limit"))
+				page, err := strconv.Atoi(r.URL.Query().Get("page"))
+				if !assert.NoError(t, err) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				start := min((page-1)*maxResponseItems, len(all))
+				end := min(start+maxResponseItems, len(all))
+				if tc.totalCount {
+					w.Header().Set("X-Total-Count", strconv.Itoa(len(all)))
+				}
+				if r.URL.Path == "/api/v1/repos/search" {
+					_ = json.NewEncoder(w).Encode(searchReposResponse{OK: true, Data: all[start:end]})
+				} else {
+					_ = json.NewEncoder(w).Encode(all[start:end])
+				}
+			})
+
+			t.Run("ListOrgRepos", func(t *testing.T) {
+				pages.Store(0)
+				repos, err := iterator.Collect(cli.ListOrgRepos(context.Background(), "sourcegraph"))
+				require.NoError(t, err)
+				assert.Equal(t, all, repos)
+				assert.Equal(t, tc.wantPages, int(pages.Load()))
+			})
+
+			t.Run("SearchRepos", func(t *testing.T) {
+				pages.Store(0)
+				repos, err := iterator.Collect(cli.SearchRepos(context.Background(), ""))
+				require.NoError(t, err)
+				assert.Equal(t, all, repos)
+				assert.Equal(t, tc.wantPages, int(pages.Load()))
+			})
+		})
+	}
+}
+
+func TestClient_GetRepo(t *testing.T) {
+	cli, save := NewTestClient(t, "GetRepo", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	repo, err := cli.GetRepo(ctx, "sourcegraph", "src-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertGolden(t, "testdata/golden/GetRepo.json", *update, repo)
+
+	_, err = cli.GetRepo(ctx, "sourcegraph", "does-not-exist")
+	if !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
+	}
+	os.Exit(m.Run())
+}
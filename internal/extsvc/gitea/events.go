@@ -0,0 +1,92 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// EventTypeHeader is the header Gitea uses to announce the webhook event
+	// type. Forgejo sends it as well for compatibility.
+	EventTypeHeader = "X-Gitea-Event"
+	// SignatureHeader is the header carrying the hex encoded HMAC-SHA256
+	// signature of the payload.
+	SignatureHeader = "X-Gitea-Signature"
+
+	forgejoEventTypeHeader = "X-Forgejo-Event"
+	forgejoSignatureHeader = "X-Forgejo-Signature"
+)
+
+// PushEventType is the event type of PushEvent.
+const PushEventType = "push"
+
+// PushEvent is sent when commits are pushed to a repository.
+type PushEvent struct {
+	Ref        string      `json:"ref"`
+	Before     string      `json:"before"`
+	After      string      `json:"after"`
+	CompareURL string      `json:"compare_url"`
+	Repository *Repository `json:"repository"`
+	Pusher     *User       `json:"pusher"`
+	Sender     *User       `json:"sender"`
+}
+
+var ErrEventTypeUnknown = errors.New("unknown event type")
+
+// WebhookEventType returns the event type of the webhook request.
+func WebhookEventType(r *http.Request) string {
+	if t := r.Header.Get(EventTypeHeader); t != "" {
+		return t
+	}
+	return r.Header.Get(forgejoEventTypeHeader)
+}
+
+// WebhookSignature returns the payload signature of the webhook request, if
+// any.
+func WebhookSignature(r *http.Request) string {
+	if sig := r.Header.Get(SignatureHeader); sig != "" {
+		return sig
+	}
+	return r.Header.Get(forgejoSignatureHeader)
+}
+
+// ParseWebhookEvent unmarshals the payload into the type matching eventType.
+//
+// Errors caused by a valid payload being of an unknown type may be
+// distinguished from other errors by checking for ErrEventTypeUnknown in the
+// error chain.
+func ParseWebhookEvent(eventType string, payload []byte) (any, error) {
+	var target any
+	switch eventType {
+	case PushEventType:
+		target = &PushEvent{}
+	default:
+		return nil, errors.Wrapf(ErrEventTypeUnknown, "event type: %s", eventType)
+	}
+
+	if err := json.Unmarshal(payload, target); err != nil {
+		return nil, errors.Wrapf(err, "unmarshalling %s event", eventType)
+	}
+	return target, nil
+}
+
+// ValidateSignature checks that signature is the HMAC-SHA256 of payload keyed
+// with secret.
+func ValidateSignature(signature string, payload, secret []byte) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "decoding signature")
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("payload signature does not match")
+	}
+	return nil
+}
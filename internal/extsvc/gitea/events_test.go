@@ -0,0 +1,67 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("push", func(t *testing.T) {
+		payload := []byte(`{
+			"ref": "refs/heads/main",
+			"before": "0000000000000000000000000000000000000000",
+			"after": "c6b3f7b8f2a1e9d4a5c3b2e1f0a9b8c7d6e5f4a3",
+			"repository": {"id": 3, "full_name": "sourcegraph/src-cli"},
+			"pusher": {"id": 1, "login": "alice"}
+		}`)
+
+		event, err := ParseWebhookEvent(PushEventType, payload)
+		require.NoError(t, err)
+
+		push, ok := event.(*PushEvent)
+		require.True(t, ok, "unexpected event type %T", event)
+		assert.Equal(t, "refs/heads/main", push.Ref)
+		assert.Equal(t, int64(3), push.Repository.ID)
+		assert.Equal(t, "alice", push.Pusher.Login)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := ParseWebhookEvent("issue_comment", []byte(`{}`))
+		assert.True(t, errors.Is(err, ErrEventTypeUnknown))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseWebhookEvent(PushEventType, []byte(`{`))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrEventTypeUnknown))
+	})
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	secret := []byte("secret")
+	// echo -n '{"ref":"refs/heads/main"}' | openssl dgst -sha256 -hmac secret
+	const signature = "d8f89f0618acd61fe621aa4e64078c0e2bca15d0b578b7f3eb734f55883c5320"
+
+	assert.NoError(t, ValidateSignature(signature, payload, secret))
+	assert.Error(t, ValidateSignature(signature, payload, []byte("other")))
+	assert.Error(t, ValidateSignature("not-hex", payload, secret))
+}
+
+func TestWebhookHeaders(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(forgejoEventTypeHeader, "push")
+	r.Header.Set(forgejoSignatureHeader, "abc")
+	assert.Equal(t, "push", WebhookEventType(r))
+	assert.Equal(t, "abc", WebhookSignature(r))
+
+	r.Header.Set(EventTypeHeader, "create")
+	r.Header.Set(SignatureHeader, "def")
+	assert.Equal(t, "create", WebhookEventType(r))
+	assert.Equal(t, "def", WebhookSignature(r))
+}
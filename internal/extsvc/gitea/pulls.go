@@ -0,0 +1,353 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+var (
+	// ErrPullRequestAlreadyExists is returned when creating a pull request
+	// for a head and base branch combination that already has an open pull
+	// request.
+	ErrPullRequestAlreadyExists = errors.New("pull request already exists")
+	// ErrPullRequestNotMergeable is returned when merging a pull request that
+	// is in a state that cannot be merged, for example because of conflicts
+	// or missing approvals required by branch protection.
+	ErrPullRequestNotMergeable = errors.New("pull request is not mergeable")
+)
+
+// PullRequestState is the state of a pull request. Merged pull requests are
+// reported as closed, with HasMerged set.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "open"
+	PullRequestStateClosed PullRequestState = "closed"
+)
+
+// PullRequest is a Gitea pull request.
+type PullRequest struct {
+	ID        int64            `json:"id"`
+	Number    int64            `json:"number"`
+	HTMLURL   string           `json:"html_url"`
+	User      *User            `json:"user"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Labels    []*Label         `json:"labels"`
+	State     PullRequestState `json:"state"`
+	IsLocked  bool             `json:"is_locked"`
+	Mergeable bool             `json:"mergeable"`
+	HasMerged bool             `json:"merged"`
+	MergedAt  *time.Time       `json:"merged_at"`
+	MergedBy  *User            `json:"merged_by"`
+	Base      *PRBranchInfo    `json:"base"`
+	Head      *PRBranchInfo    `json:"head"`
+	MergeBase string           `json:"merge_base"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	ClosedAt  *time.Time       `json:"closed_at"`
+}
+
+// PRBranchInfo describes one side of a pull request.
+type PRBranchInfo struct {
+	Label      string      `json:"label"`
+	Ref        string      `json:"ref"`
+	Sha        string      `json:"sha"`
+	RepoID     int64       `json:"repo_id"`
+	Repository *Repository `json:"repo"`
+}
+
+// Label is a label attached to an issue or pull request.
+type Label struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// wipPrefixes are the title prefixes Gitea treats as marking a pull request
+// as work in progress in its default configuration.
+var wipPrefixes = []string{"WIP:", "[WIP]"}
+
+// IsWorkInProgress returns true if the title of the pull request marks it as
+// a draft.
+func (pr *PullRequest) IsWorkInProgress() bool {
+	return IsWIP(pr.Title)
+}
+
+// IsWIP returns true if Gitea renders a pull request with the given title as
+// work in progress.
+func IsWIP(title string) bool {
+	upper := strings.ToUpper(title)
+	for _, prefix := range wipPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetWIP ensures a "WIP: " prefix on the given title.
+func SetWIP(title string) string {
+	return "WIP: " + UnsetWIP(title)
+}
+
+// UnsetWIP removes any work in progress prefix from the given title.
+func UnsetWIP(title string) string {
+	upper := strings.ToUpper(title)
+	for _, prefix := range wipPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return strings.TrimLeft(title[len(prefix):], " ")
+		}
+	}
+	return title
+}
+
+// ReviewState is the state of a pull request review.
+type ReviewState string
+
+const (
+	ReviewStateApproved       ReviewState = "APPROVED"
+	ReviewStatePending        ReviewState = "PENDING"
+	ReviewStateComment        ReviewState = "COMMENT"
+	ReviewStateRequestChanges ReviewState = "REQUEST_CHANGES"
+	ReviewStateRequestReview  ReviewState = "REQUEST_REVIEW"
+)
+
+// PullReview is a review submitted on a pull request.
+type PullReview struct {
+	ID          int64       `json:"id"`
+	User        *User       `json:"user"`
+	State       ReviewState `json:"state"`
+	Body        string      `json:"body"`
+	CommitID    string      `json:"commit_id"`
+	Stale       bool        `json:"stale"`
+	Official    bool        `json:"official"`
+	Dismissed   bool        `json:"dismissed"`
+	HTMLURL     string      `json:"html_url"`
+	SubmittedAt time.Time   `json:"submitted_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Key is a unique key identifying this review in the context of its pull
+// request.
+func (r *PullReview) Key() string {
+	return strconv.FormatInt(r.ID, 10)
+}
+
+// CommitStatusState is the state of a commit status.
+type CommitStatusState string
+
+const (
+	CommitStatusPending CommitStatusState = "pending"
+	CommitStatusSuccess CommitStatusState = "success"
+	CommitStatusError   CommitStatusState = "error"
+	CommitStatusFailure CommitStatusState = "failure"
+	CommitStatusWarning CommitStatusState = "warning"
+)
+
+// CommitStatus is a status reported on a commit, usually by a CI system.
+type CommitStatus struct {
+	ID          int64             `json:"id"`
+	State       CommitStatusState `json:"status"`
+	TargetURL   string            `json:"target_url"`
+	Description string            `json:"description"`
+	Context     string            `json:"context"`
+	Creator     *User             `json:"creator"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// Key is a unique key identifying this status in the context of its commit.
+// Gitea only keeps the latest status per context, so the context is used.
+func (s *CommitStatus) Key() string {
+	return s.Context
+}
+
+// CombinedStatus is the latest status of each context on a commit.
+type CombinedStatus struct {
+	State      CommitStatusState `json:"state"`
+	SHA        string            `json:"sha"`
+	TotalCount int               `json:"total_count"`
+	Statuses   []*CommitStatus   `json:"statuses"`
+}
+
+// CreatePullRequestOptions are the options for CreatePullRequest.
+type CreatePullRequestOptions struct {
+	// Head is the branch containing the changes. For pull requests from
+	// forks, it has the form "owner:branch".
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// EditPullRequestOptions are the options for EditPullRequest. Nil fields are
+// left unchanged.
+type EditPullRequestOptions struct {
+	Title *string           `json:"title,omitempty"`
+	Body  *string           `json:"body,omitempty"`
+	Base  *string           `json:"base,omitempty"`
+	State *PullRequestState `json:"state,omitempty"`
+}
+
+// MergeStyle is the strategy used to merge a pull request.
+type MergeStyle string
+
+const (
+	MergeStyleMerge  MergeStyle = "merge"
+	MergeStyleRebase MergeStyle = "rebase"
+	MergeStyleSquash MergeStyle = "squash"
+)
+
+// MergePullRequestOptions are the options for MergePullRequest.
+type MergePullRequestOptions struct {
+	Do                     MergeStyle `json:"Do"`
+	DeleteBranchAfterMerge bool       `json:"delete_branch_after_merge,omitempty"`
+}
+
+// CreateForkOptions are the options for CreateFork.
+type CreateForkOptions struct {
+	// Organization to fork into. If nil, the fork is created in the namespace
+	// of the authenticated user.
+	Organization *string `json:"organization,omitempty"`
+	// Name of the fork. If nil, the name of the original repository is used.
+	Name *string `json:"name,omitempty"`
+}
+
+// GetPullRequest returns the pull request with the given number.
+func (c *Client) GetPullRequest(ctx context.Context, owner, name string, number int64) (*PullRequest, error) {
+	u := url.URL{Path: repoPath(owner, name) + "/pulls/" + strconv.FormatInt(number, 10)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequestByBaseHead returns the open pull request merging head into
+// base.
+func (c *Client) GetPullRequestByBaseHead(ctx context.Context, owner, name, base, head string) (*PullRequest, error) {
+	u := url.URL{Path: repoPath(owner, name) + "/pulls/" + base + "/" + head}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequest opens a new pull request. If one already exists for the
+// same head and base, ErrPullRequestAlreadyExists is returned.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, name string, opts CreatePullRequestOptions) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.sendJSON(ctx, http.MethodPost, repoPath(owner, name)+"/pulls", opts, &pr); err != nil {
+		if statusCode(err) == http.StatusConflict {
+			return nil, ErrPullRequestAlreadyExists
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// EditPullRequest updates the given pull request. This is also used to close
+// and reopen pull requests.
+func (c *Client) EditPullRequest(ctx context.Context, owner, name string, number int64, opts EditPullRequestOptions) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.sendJSON(ctx, http.MethodPatch, repoPath(owner, name)+"/pulls/"+strconv.FormatInt(number, 10), opts, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the given pull request. If the pull request cannot
+// be merged, ErrPullRequestNotMergeable is returned.
+func (c *Client) MergePullRequest(ctx context.Context, owner, name string, number int64, opts MergePullRequestOptions) error {
+	err := c.sendJSON(ctx, http.MethodPost, repoPath(owner, name)+"/pulls/"+strconv.FormatInt(number, 10)+"/merge", opts, nil)
+	switch statusCode(err) {
+	case http.StatusMethodNotAllowed, http.StatusConflict:
+		return errors.Wrap(ErrPullRequestNotMergeable, err.Error())
+	}
+	return err
+}
+
+// CreateIssueComment posts a comment on the issue or pull request with the
+// given number.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, name string, number int64, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+	return c.sendJSON(ctx, http.MethodPost, repoPath(owner, name)+"/issues/"+strconv.FormatInt(number, 10)+"/comments", payload, nil)
+}
+
+// ListPullReviews lists all reviews submitted on the given pull request.
+func (c *Client) ListPullReviews(ctx context.Context, owner, name string, number int64) *iterator.Iterator[*PullReview] {
+	return listPages[*PullReview](ctx, c, repoPath(owner, name)+"/pulls/"+strconv.FormatInt(number, 10)+"/reviews", nil)
+}
+
+// GetCombinedStatus returns the latest status of each context on the given
+// ref.
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, name, ref string) (*CombinedStatus, error) {
+	u := url.URL{Path: repoPath(owner, name) + "/commits/" + ref + "/status"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status CombinedStatus
+	if _, err := c.do(ctx, req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// CreateFork forks the given repository.
+func (c *Client) CreateFork(ctx context.Context, owner, name string, opts CreateForkOptions) (*Repository, error) {
+	var repo Repository
+	if err := c.sendJSON(ctx, http.MethodPost, repoPath(owner, name)+"/forks", opts, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func (c *Client) sendJSON(ctx context.Context, method, path string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	u := url.URL{Path: path}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, result)
+	return err
+}
+
+func statusCode(err error) int {
+	var e *httpError
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
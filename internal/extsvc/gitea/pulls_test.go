@@ -0,0 +1,126 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestWIP(t *testing.T) {
+	for _, tc := range []struct {
+		title   string
+		wip     bool
+		unset   string
+		withWIP string
+	}{
+		{title: "Fix the thing", wip: false, unset: "Fix the thing", withWIP: "WIP: Fix the thing"},
+		{title: "WIP: Fix the thing", wip: true, unset: "Fix the thing", withWIP: "WIP: Fix the thing"},
+		{title: "wip: Fix the thing", wip: true, unset: "Fix the thing", withWIP: "WIP: Fix the thing"},
+		{title: "[WIP] Fix the thing", wip: true, unset: "Fix the thing", withWIP: "WIP: Fix the thing"},
+		{title: "WIPE the disk", wip: false, unset: "WIPE the disk", withWIP: "WIP: WIPE the disk"},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			assert.Equal(t, tc.wip, IsWIP(tc.title))
+			assert.Equal(t, tc.unset, UnsetWIP(tc.title))
+			assert.Equal(t, tc.withWIP, SetWIP(tc.title))
+		})
+	}
+}
+
+func newPullsTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	ratelimit.SetupForTest(t)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	cli, err := NewClient("extsvc:gitea:1", u, "token", http.DefaultClient)
+	require.NoError(t, err)
+	return cli
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/repos/sourcegraph/src-cli/pulls", r.URL.Path)
+
+			var opts CreatePullRequestOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			assert.Equal(t, CreatePullRequestOptions{Head: "batch", Base: "main", Title: "title", Body: "body"}, opts)
+
+			json.NewEncoder(w).Encode(PullRequest{Number: 42, Title: opts.Title, State: PullRequestStateOpen})
+		})
+
+		pr, err := cli.CreatePullRequest(ctx, "sourcegraph", "src-cli", CreatePullRequestOptions{
+			Head:  "batch",
+			Base:  "main",
+			Title: "title",
+			Body:  "body",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), pr.Number)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"pull request already exists for these targets"}`, http.StatusConflict)
+		})
+
+		_, err := cli.CreatePullRequest(ctx, "sourcegraph", "src-cli", CreatePullRequestOptions{})
+		assert.True(t, errors.Is(err, ErrPullRequestAlreadyExists))
+	})
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/repos/sourcegraph/src-cli/pulls/42/merge", r.URL.Path)
+
+			var opts MergePullRequestOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+			assert.Equal(t, MergeStyleSquash, opts.Do)
+		})
+
+		err := cli.MergePullRequest(ctx, "sourcegraph", "src-cli", 42, MergePullRequestOptions{Do: MergeStyleSquash})
+		assert.NoError(t, err)
+	})
+
+	for _, code := range []int{http.StatusMethodNotAllowed, http.StatusConflict} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+			})
+
+			err := cli.MergePullRequest(ctx, "sourcegraph", "src-cli", 42, MergePullRequestOptions{Do: MergeStyleMerge})
+			assert.True(t, errors.Is(err, ErrPullRequestNotMergeable))
+		})
+	}
+
+	t.Run("other errors", func(t *testing.T) {
+		cli := newPullsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		err := cli.MergePullRequest(ctx, "sourcegraph", "src-cli", 42, MergePullRequestOptions{Do: MergeStyleMerge})
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrPullRequestNotMergeable))
+	})
+}
@@ -0,0 +1,51 @@
+package gitea
+
+import "time"
+
+// Repository is a Gitea repository, as returned by the REST API.
+//
+// This is also stored as the Metadata of repositories synced from Gitea, so
+// fields should only be removed with care.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Internal      bool        `json:"internal"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	Size          int         `json:"size"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	StarsCount    int         `json:"stars_count"`
+	ForksCount    int         `json:"forks_count"`
+	Permissions   *Permission `json:"permissions,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// Permission describes the access the requesting user has to a repository.
+type Permission struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// User is a Gitea user or organization account. Gitea returns organizations
+// in the same shape as users when they own a repository.
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	IsAdmin   bool   `json:"is_admin"`
+}
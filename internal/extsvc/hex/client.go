@@ -0,0 +1,106 @@
+package hex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+// DefaultRepository is the public Hex.pm repository.
+const DefaultRepository = "https://repo.hex.pm"
+
+type Client struct {
+	repositoryURL string
+	apiKey        string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns a client for the Hex repository at repositoryURL. If
+// apiKey is set, it is sent in the Authorization header of every request, which
+// is how Hex authenticates access to private organizations and repositories.
+func NewClient(urn, repositoryURL, apiKey string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	if repositoryURL == "" {
+		repositoryURL = DefaultRepository
+	}
+	return &Client{
+		repositoryURL:  strings.TrimSuffix(repositoryURL, "/"),
+		apiKey:         apiKey,
+		uncachedClient: uncached,
+		limiter:        ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("HexClient"), urn)),
+	}, nil
+}
+
+// GetPackageContents downloads the tarball of the given package version. The
+// tarball is an uncompressed tar archive with the package metadata and its
+// files in a nested contents.tar.gz, see
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md.
+// The caller must close the returned reader.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s/tarballs/%s-%s.tar", c.repositoryURL, dep.PackageSyntax(), dep.PackageVersion())
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-hex-syncer (sourcegraph.com)")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", c.apiKey)
+	}
+
+	return c.do(c.uncachedClient, req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	// Hex responds with 403 instead of 404 for tarballs that don't exist,
+	// because they are served from a bucket that doesn't allow listing.
+	return e.code == http.StatusNotFound || e.code == http.StatusForbidden
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: fmt.Sprintf("failed to read non-200 body: %v", err)}
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return resp.Body, nil
+}
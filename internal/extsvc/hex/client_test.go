@@ -0,0 +1,49 @@
+package hex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/acme/tarballs/phoenix-1.7.10.tar":
+			io.WriteString(w, "tarball contents")
+		default:
+			// Like repo.hex.pm, which serves tarballs from a bucket.
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("hex_urn", srv.URL+"/repos/acme/", "secret-key", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("hex", rate.NewLimiter(100, 10))
+
+	ctx := context.Background()
+	body, err := client.GetPackageContents(ctx, reposource.NewHexVersionedPackage("phoenix", "1.7.10"))
+	require.NoError(t, err)
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "tarball contents", string(contents))
+
+	_, err = client.GetPackageContents(ctx, reposource.NewHexVersionedPackage("phoenix", "0.0.1"))
+	require.True(t, errcode.IsNotFound(err))
+}
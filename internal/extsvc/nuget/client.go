@@ -0,0 +1,192 @@
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultRegistry is the service index of the public NuGet gallery.
+const DefaultRegistry = "https://api.nuget.org/v3/index.json"
+
+// packageBaseAddressType is the service index resource type of the package
+// content API, which serves .nupkg archives of every version of a package.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	registryURL string
+	username    string
+	password    string
+	// registryOrigin is the origin of registryURL. Credentials are only sent
+	// to it, since the service index may point at other hosts.
+	registryOrigin string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu          sync.Mutex
+	baseAddress string
+}
+
+// NewClient returns a client for the NuGet V3 feed with the service index at
+// registryURL. If password is set, requests to the host of registryURL are
+// authenticated with basic auth.
+func NewClient(urn, registryURL, username, password string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	if registryURL == "" {
+		registryURL = DefaultRegistry
+	}
+	u, err := url.Parse(registryURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid NuGet registry URL")
+	}
+	return &Client{
+		registryURL:    registryURL,
+		username:       username,
+		password:       password,
+		registryOrigin: origin(u),
+		uncachedClient: uncached,
+		limiter:        ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("NuGetClient"), urn)),
+	}, nil
+}
+
+// GetPackageContents downloads the .nupkg archive of the given package version.
+// The caller must close the returned reader.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	base, err := c.packageBaseAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The package content API only accepts lower case ids and normalized
+	// versions.
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := reposource.NormalizeNuGetVersion(dep.PackageVersion())
+	u := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", base, id, version, id, version)
+
+	return c.get(ctx, u)
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// packageBaseAddress looks up the URL of the package content API in the
+// service index of the feed. The result is kept for the lifetime of the client.
+func (c *Client) packageBaseAddress(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.baseAddress != "" {
+		return c.baseAddress, nil
+	}
+
+	body, err := c.get(ctx, c.registryURL)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching NuGet service index")
+	}
+	defer body.Close()
+
+	var index serviceIndex
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "decoding NuGet service index")
+	}
+
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			c.baseAddress = strings.TrimSuffix(r.ID, "/")
+			return c.baseAddress, nil
+		}
+	}
+
+	return "", errors.Newf("NuGet service index %s has no %s resource", c.registryURL, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+	// 🚨 SECURITY: The package base address comes from the service index, and
+	// may be on another host, such as a CDN. Only the registry gets the
+	// credentials.
+	if c.password != "" && origin(req.URL) == c.registryOrigin {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	return c.do(c.uncachedClient, req)
+}
+
+// origin returns the lower case scheme, host and port of u, with the default
+// port of its scheme if it has none.
+func origin(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	port := u.Port()
+	if port == "" {
+		switch scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		}
+	}
+	return scheme + "://" + strings.ToLower(u.Hostname()) + ":" + port
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: fmt.Sprintf("failed to read non-200 body: %v", err)}
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,103 @@
+package nuget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	var indexRequests int
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "sourcegraph" || pass != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/index.json":
+			indexRequests++
+			fmt.Fprintf(w, `{"version":"3.0.0","resources":[{"@id":"%[1]s/query","@type":"SearchQueryService"},{"@id":"%[1]s/flat/","@type":"PackageBaseAddress/3.0.0"}]}`, srv.URL)
+		case "/flat/newtonsoft.json/13.0.3/newtonsoft.json.13.0.3.nupkg":
+			fmt.Fprint(w, "nupkg contents")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("nuget_urn", srv.URL+"/v3/index.json", "sourcegraph", "hunter2", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("nuget", rate.NewLimiter(100, 10))
+
+	ctx := context.Background()
+	dep, err := reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@13.0.3")
+	require.NoError(t, err)
+
+	body, err := client.GetPackageContents(ctx, dep)
+	require.NoError(t, err)
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "nupkg contents", string(contents))
+
+	dep, err = reposource.ParseNuGetVersionedPackage("Newtonsoft.Json@0.0.1")
+	require.NoError(t, err)
+	_, err = client.GetPackageContents(ctx, dep)
+	require.True(t, errcode.IsNotFound(err))
+
+	// The service index is only requested once.
+	require.Equal(t, 1, indexRequests)
+}
+
+func TestGetPackageContents_OtherHost(t *testing.T) {
+	// Packages are served from another host, like a CDN, which must not get
+	// the credentials of the registry.
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/flat/newtonsoft.json/13.0.0/newtonsoft.json.13.0.0.nupkg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "nupkg contents")
+	}))
+	t.Cleanup(cdn.Close)
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "sourcegraph" || pass != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"version":"3.0.0","resources":[{"@id":"%s/flat/","@type":"PackageBaseAddress/3.0.0"}]}`, cdn.URL)
+	}))
+	t.Cleanup(registry.Close)
+
+	client, err := NewClient("nuget_urn", registry.URL+"/v3/index.json", "sourcegraph", "hunter2", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("nuget", rate.NewLimiter(100, 10))
+
+	// The version is normalized to 13.0.0 in the package URL.
+	dep := &reposource.NuGetVersionedPackage{Name: "Newtonsoft.Json", Version: "13.0"}
+	body, err := client.GetPackageContents(context.Background(), dep)
+	require.NoError(t, err)
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "nupkg contents", string(contents))
+}
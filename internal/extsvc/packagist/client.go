@@ -0,0 +1,172 @@
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultRepository is the public Packagist repository.
+const DefaultRepository = "https://repo.packagist.org"
+
+type Client struct {
+	repositoryURL string
+	username      string
+	password      string
+
+	uncachedClient httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns a client for the Composer repository at repositoryURL. If
+// password is set, requests to the repository are authenticated with basic
+// auth.
+func NewClient(urn, repositoryURL, username, password string, httpfactory *httpcli.Factory) (*Client, error) {
+	uncached, err := httpfactory.Doer(httpcli.NewCachedTransportOpt(httpcli.NoopCache{}, false))
+	if err != nil {
+		return nil, err
+	}
+	if repositoryURL == "" {
+		repositoryURL = DefaultRepository
+	}
+	return &Client{
+		repositoryURL:  strings.TrimSuffix(repositoryURL, "/"),
+		username:       username,
+		password:       password,
+		uncachedClient: uncached,
+		limiter:        ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("PackagistClient"), urn)),
+	}, nil
+}
+
+// Version is a single version of a package in the Composer v2 metadata format,
+// see https://packagist.org/apidoc#get-package-metadata-v2.
+type Version struct {
+	Version string `json:"version"`
+	Dist    *Dist  `json:"dist"`
+}
+
+// Dist describes the distribution archive of a package version.
+type Dist struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type packageMetadata struct {
+	Packages map[string][]Version `json:"packages"`
+}
+
+// GetPackageContents downloads the zip distribution archive of the given
+// package version. The caller must close the returned reader.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (io.ReadCloser, error) {
+	version, err := c.getVersion(ctx, dep)
+	if err != nil {
+		return nil, err
+	}
+	if version.Dist == nil || version.Dist.URL == "" {
+		return nil, errors.Newf("package %q has no distribution archive", dep.VersionedPackageSyntax())
+	}
+	if version.Dist.Type != "zip" {
+		return nil, errors.Newf("unsupported distribution archive type %q of package %q", version.Dist.Type, dep.VersionedPackageSyntax())
+	}
+
+	return c.get(ctx, version.Dist.URL)
+}
+
+// getVersion looks up the given version in the metadata of the package.
+// Versions derived from VCS tags may or may not carry a leading "v", so both
+// spellings are accepted.
+func (c *Client) getVersion(ctx context.Context, dep reposource.VersionedPackage) (*Version, error) {
+	name := string(dep.PackageSyntax())
+	body, err := c.get(ctx, fmt.Sprintf("%s/p2/%s.json", c.repositoryURL, name))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var metadata packageMetadata
+	if err := json.NewDecoder(body).Decode(&metadata); err != nil {
+		return nil, errors.Wrapf(err, "decoding metadata of package %q", name)
+	}
+
+	want := strings.TrimPrefix(dep.PackageVersion(), "v")
+	for _, v := range metadata.Packages[name] {
+		if strings.TrimPrefix(v.Version, "v") == want {
+			return &v, nil
+		}
+	}
+
+	return nil, &Error{path: name, code: http.StatusNotFound, message: fmt.Sprintf("version %q not found", dep.PackageVersion())}
+}
+
+func (c *Client) get(ctx context.Context, rawURL string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-packagist-syncer (sourcegraph.com)")
+	// Distribution archives are often hosted elsewhere, for example on
+	// GitHub, and must never see the credentials of the repository.
+	if c.password != "" && c.isRepositoryHost(req.URL) {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	return c.do(c.uncachedClient, req)
+}
+
+func (c *Client) isRepositoryHost(u *url.URL) bool {
+	repo, err := url.Parse(c.repositoryURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(repo.Host, u.Host)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(doer httpcli.Doer, req *http.Request) (io.ReadCloser, error) {
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: fmt.Sprintf("failed to read non-200 body: %v", err)}
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,71 @@
+package packagist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	// The distribution archives are served from another host, which must not
+	// receive the credentials of the repository.
+	dist := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/zipball/c915e26" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, "zip contents")
+	}))
+	t.Cleanup(dist.Close)
+
+	repo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "token" || pass != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/acme/p2/monolog/monolog.json":
+			fmt.Fprintf(w, `{"packages":{"monolog/monolog":[
+				{"name":"monolog/monolog","version":"3.5.0","dist":{"type":"zip","url":"%[1]s/zipball/c915e26"}},
+				{"version":"3.4.0","dist":{"type":"zip","url":"%[1]s/zipball/e2392369"}}
+			]},"minified":"composer/2.0"}`, dist.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(repo.Close)
+
+	client, err := NewClient("packagist_urn", repo.URL+"/acme", "token", "hunter2", httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	client.limiter = ratelimit.NewInstrumentedLimiter("packagist", rate.NewLimiter(100, 10))
+
+	ctx := context.Background()
+	body, err := client.GetPackageContents(ctx, reposource.NewPackagistVersionedPackage("monolog/monolog", "v3.5.0"))
+	require.NoError(t, err)
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "zip contents", string(contents))
+
+	_, err = client.GetPackageContents(ctx, reposource.NewPackagistVersionedPackage("monolog/monolog", "0.0.1"))
+	require.True(t, errcode.IsNotFound(err))
+
+	_, err = client.GetPackageContents(ctx, reposource.NewPackagistVersionedPackage("acme/missing", "1.0.0"))
+	require.True(t, errcode.IsNotFound(err))
+}
@@ -204,6 +204,27 @@ const (
 	// VariantRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	VariantRubyPackages
 
+	// VariantNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	VariantNuGetPackages
+
+	// VariantHexPackages is the (api.ExternalRepoSpec).ServiceType value for Hex packages (Elixir and Erlang libraries).
+	VariantHexPackages
+
+	// VariantPackagistPackages is the (api.ExternalRepoSpec).ServiceType value for Packagist packages (PHP Composer libraries).
+	VariantPackagistPackages
+
+	// VariantGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	VariantGitea
+
+	// VariantMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the clone base URL of the connection.
+	VariantMercurial
+
+	// VariantSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories. The
+	// ServiceID value is the base URL of the connection.
+	VariantSubversion
+
 	// VariantOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	VariantOther
 )
@@ -217,24 +238,30 @@ type variantValues struct {
 }
 
 var variantValuesMap = map[Variant]variantValues{
-	VariantAWSCodeCommit:   {AsKind: "AWSCODECOMMIT", AsType: "awscodecommit", ConfigPrototype: func() any { return &schema.AWSCodeCommitConnection{} }, SupportsRepoExclusion: true},
-	VariantAzureDevOps:     {AsKind: "AZUREDEVOPS", AsType: "azuredevops", ConfigPrototype: func() any { return &schema.AzureDevOpsConnection{} }, SupportsRepoExclusion: true},
-	VariantBitbucketCloud:  {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
-	VariantBitbucketServer: {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
-	VariantGerrit:          {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }, SupportsRepoExclusion: true},
-	VariantGitHub:          {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
-	VariantGitLab:          {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
-	VariantGitolite:        {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
-	VariantGoPackages:      {AsKind: "GOMODULES", AsType: "goModules", ConfigPrototype: func() any { return &schema.GoModulesConnection{} }},
-	VariantJVMPackages:     {AsKind: "JVMPACKAGES", AsType: "jvmPackages", ConfigPrototype: func() any { return &schema.JVMPackagesConnection{} }},
-	VariantNpmPackages:     {AsKind: "NPMPACKAGES", AsType: "npmPackages", ConfigPrototype: func() any { return &schema.NpmPackagesConnection{} }},
-	VariantOther:           {AsKind: "OTHER", AsType: "other", ConfigPrototype: func() any { return &schema.OtherExternalServiceConnection{} }},
-	VariantPagure:          {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
-	VariantPerforce:        {AsKind: "PERFORCE", AsType: "perforce", ConfigPrototype: func() any { return &schema.PerforceConnection{} }},
-	VariantPhabricator:     {AsKind: "PHABRICATOR", AsType: "phabricator", ConfigPrototype: func() any { return &schema.PhabricatorConnection{} }},
-	VariantPythonPackages:  {AsKind: "PYTHONPACKAGES", AsType: "pythonPackages", ConfigPrototype: func() any { return &schema.PythonPackagesConnection{} }},
-	VariantRubyPackages:    {AsKind: "RUBYPACKAGES", AsType: "rubyPackages", ConfigPrototype: func() any { return &schema.RubyPackagesConnection{} }},
-	VariantRustPackages:    {AsKind: "RUSTPACKAGES", AsType: "rustPackages", ConfigPrototype: func() any { return &schema.RustPackagesConnection{} }},
+	VariantAWSCodeCommit:     {AsKind: "AWSCODECOMMIT", AsType: "awscodecommit", ConfigPrototype: func() any { return &schema.AWSCodeCommitConnection{} }, SupportsRepoExclusion: true},
+	VariantAzureDevOps:       {AsKind: "AZUREDEVOPS", AsType: "azuredevops", ConfigPrototype: func() any { return &schema.AzureDevOpsConnection{} }, SupportsRepoExclusion: true},
+	VariantBitbucketCloud:    {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
+	VariantBitbucketServer:   {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
+	VariantGerrit:            {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }, SupportsRepoExclusion: true},
+	VariantGitea:             {AsKind: "GITEA", AsType: "gitea", ConfigPrototype: func() any { return &schema.GiteaConnection{} }, WebhookURLPath: "gitea-webhooks", SupportsRepoExclusion: true},
+	VariantGitHub:            {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
+	VariantGitLab:            {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
+	VariantGitolite:          {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
+	VariantGoPackages:        {AsKind: "GOMODULES", AsType: "goModules", ConfigPrototype: func() any { return &schema.GoModulesConnection{} }},
+	VariantHexPackages:       {AsKind: "HEXPACKAGES", AsType: "hexPackages", ConfigPrototype: func() any { return &schema.HexPackagesConnection{} }},
+	VariantJVMPackages:       {AsKind: "JVMPACKAGES", AsType: "jvmPackages", ConfigPrototype: func() any { return &schema.JVMPackagesConnection{} }},
+	VariantMercurial:         {AsKind: "MERCURIAL", AsType: "mercurial", ConfigPrototype: func() any { return &schema.MercurialConnection{} }, SupportsRepoExclusion: true},
+	VariantNpmPackages:       {AsKind: "NPMPACKAGES", AsType: "npmPackages", ConfigPrototype: func() any { return &schema.NpmPackagesConnection{} }},
+	VariantNuGetPackages:     {AsKind: "NUGETPACKAGES", AsType: "nugetPackages", ConfigPrototype: func() any { return &schema.NuGetPackagesConnection{} }},
+	VariantOther:             {AsKind: "OTHER", AsType: "other", ConfigPrototype: func() any { return &schema.OtherExternalServiceConnection{} }},
+	VariantPackagistPackages: {AsKind: "PACKAGISTPACKAGES", AsType: "packagistPackages", ConfigPrototype: func() any { return &schema.PackagistPackagesConnection{} }},
+	VariantPagure:            {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
+	VariantPerforce:          {AsKind: "PERFORCE", AsType: "perforce", ConfigPrototype: func() any { return &schema.PerforceConnection{} }},
+	VariantPhabricator:       {AsKind: "PHABRICATOR", AsType: "phabricator", ConfigPrototype: func() any { return &schema.PhabricatorConnection{} }},
+	VariantPythonPackages:    {AsKind: "PYTHONPACKAGES", AsType: "pythonPackages", ConfigPrototype: func() any { return &schema.PythonPackagesConnection{} }},
+	VariantRubyPackages:      {AsKind: "RUBYPACKAGES", AsType: "rubyPackages", ConfigPrototype: func() any { return &schema.RubyPackagesConnection{} }},
+	VariantRustPackages:      {AsKind: "RUSTPACKAGES", AsType: "rustPackages", ConfigPrototype: func() any { return &schema.RustPackagesConnection{} }},
+	VariantSubversion:        {AsKind: "SUBVERSION", AsType: "subversion", ConfigPrototype: func() any { return &schema.SubversionConnection{} }, SupportsRepoExclusion: true},
 }
 
 func (v Variant) AsKind() string {
@@ -283,24 +310,30 @@ var (
 	// The constants below represent the different kinds of external service we support and should be used
 	// in preference to the Type values below.
 
-	KindAWSCodeCommit   = VariantAWSCodeCommit.AsKind()
-	KindBitbucketServer = VariantBitbucketServer.AsKind()
-	KindBitbucketCloud  = VariantBitbucketCloud.AsKind()
-	KindGerrit          = VariantGerrit.AsKind()
-	KindGitHub          = VariantGitHub.AsKind()
-	KindGitLab          = VariantGitLab.AsKind()
-	KindGitolite        = VariantGitolite.AsKind()
-	KindPerforce        = VariantPerforce.AsKind()
-	KindPhabricator     = VariantPhabricator.AsKind()
-	KindGoPackages      = VariantGoPackages.AsKind()
-	KindJVMPackages     = VariantJVMPackages.AsKind()
-	KindPythonPackages  = VariantPythonPackages.AsKind()
-	KindRustPackages    = VariantRustPackages.AsKind()
-	KindRubyPackages    = VariantRubyPackages.AsKind()
-	KindNpmPackages     = VariantNpmPackages.AsKind()
-	KindPagure          = VariantPagure.AsKind()
-	KindAzureDevOps     = VariantAzureDevOps.AsKind()
-	KindOther           = VariantOther.AsKind()
+	KindAWSCodeCommit     = VariantAWSCodeCommit.AsKind()
+	KindBitbucketServer   = VariantBitbucketServer.AsKind()
+	KindBitbucketCloud    = VariantBitbucketCloud.AsKind()
+	KindGerrit            = VariantGerrit.AsKind()
+	KindGitea             = VariantGitea.AsKind()
+	KindGitHub            = VariantGitHub.AsKind()
+	KindGitLab            = VariantGitLab.AsKind()
+	KindGitolite          = VariantGitolite.AsKind()
+	KindPerforce          = VariantPerforce.AsKind()
+	KindPhabricator       = VariantPhabricator.AsKind()
+	KindGoPackages        = VariantGoPackages.AsKind()
+	KindJVMPackages       = VariantJVMPackages.AsKind()
+	KindPythonPackages    = VariantPythonPackages.AsKind()
+	KindRustPackages      = VariantRustPackages.AsKind()
+	KindRubyPackages      = VariantRubyPackages.AsKind()
+	KindNpmPackages       = VariantNpmPackages.AsKind()
+	KindNuGetPackages     = VariantNuGetPackages.AsKind()
+	KindHexPackages       = VariantHexPackages.AsKind()
+	KindPackagistPackages = VariantPackagistPackages.AsKind()
+	KindPagure            = VariantPagure.AsKind()
+	KindAzureDevOps       = VariantAzureDevOps.AsKind()
+	KindMercurial         = VariantMercurial.AsKind()
+	KindSubversion        = VariantSubversion.AsKind()
+	KindOther             = VariantOther.AsKind()
 )
 
 // TODO: Deprecated: use Variant with its `AsType()` function instead
@@ -323,6 +356,10 @@ var (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = VariantGerrit.AsType()
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = VariantGitea.AsType()
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = VariantGitHub.AsType()
@@ -364,6 +401,23 @@ var (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = VariantRubyPackages.AsType()
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	TypeNuGetPackages = VariantNuGetPackages.AsType()
+
+	// TypeHexPackages is the (api.ExternalRepoSpec).ServiceType value for Hex packages (Elixir and Erlang libraries).
+	TypeHexPackages = VariantHexPackages.AsType()
+
+	// TypePackagistPackages is the (api.ExternalRepoSpec).ServiceType value for Packagist packages (PHP Composer libraries).
+	TypePackagistPackages = VariantPackagistPackages.AsType()
+
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the clone base URL of the connection.
+	TypeMercurial = VariantMercurial.AsType()
+
+	// TypeSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories. The
+	// ServiceID value is the base URL of the connection.
+	TypeSubversion = VariantSubversion.AsType()
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = VariantOther.AsType()
 )
@@ -553,6 +607,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -633,6 +689,12 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		limit = GetDefaultRateLimit(KindGitea)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = GetDefaultRateLimit(KindNpmPackages)
 		if c != nil && c.RateLimit != nil {
@@ -663,6 +725,24 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		limit = GetDefaultRateLimit(KindNuGetPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.HexPackagesConnection:
+		limit = GetDefaultRateLimit(KindHexPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.PackagistPackagesConnection:
+		limit = GetDefaultRateLimit(KindPackagistPackages)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.AzureDevOpsConnection:
 		limit = GetDefaultRateLimit(KindAzureDevOps)
 		if c != nil && c.RateLimit != nil {
@@ -696,6 +776,10 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 	case KindPagure:
 		// 8/s is the default limit we enforce
 		return rate.Limit(8)
+	case KindGitea:
+		// Gitea does not rate limit API requests by default, so we enforce
+		// the same limit as for Pagure.
+		return rate.Limit(8)
 	case KindNpmPackages:
 		return rate.Limit(6000 / 3600.0)
 	case KindGoPackages:
@@ -713,6 +797,18 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 	case KindRubyPackages:
 		// The rubygems.org API allows 10 rps https://guides.rubygems.org/rubygems-org-rate-limits/
 		return rate.Limit(10)
+	case KindNuGetPackages:
+		// nuget.org serves package content from a CDN and doesn't document
+		// a rate limit for it, private feeds usually sit behind their own limits.
+		return rate.Limit(1)
+	case KindHexPackages:
+		// The Hex.pm API allows 100 unauthenticated requests per minute per IP
+		// https://hexpm.docs.apiary.io/#introduction/rate-limiting
+		return rate.Limit(100.0 / 60.0)
+	case KindPackagistPackages:
+		// repo.packagist.org is served from a CDN, Packagist asks API clients
+		// to keep their request rate reasonable https://packagist.org/apidoc
+		return rate.Limit(10)
 	case KindAzureDevOps:
 		return rate.Inf
 	default:
@@ -817,10 +913,16 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
+	case *schema.SubversionConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
@@ -842,6 +944,12 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return VariantRustPackages.AsKind(), nil
 	case *schema.RubyPackagesConnection:
 		return VariantRubyPackages.AsKind(), nil
+	case *schema.NuGetPackagesConnection:
+		return VariantNuGetPackages.AsKind(), nil
+	case *schema.HexPackagesConnection:
+		return VariantHexPackages.AsKind(), nil
+	case *schema.PackagistPackagesConnection:
+		return VariantPackagistPackages.AsKind(), nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
@@ -37,6 +37,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantGerrit.ConfigPrototype().(*schema.GerritConnection); !ok {
 		t.Errorf("wrong type for Gerrit configuration prototype: %T", y)
 	}
+	if y, ok := VariantGitea.ConfigPrototype().(*schema.GiteaConnection); !ok {
+		t.Errorf("wrong type for Gitea configuration prototype: %T", y)
+	}
 	if y, ok := VariantGitHub.ConfigPrototype().(*schema.GitHubConnection); !ok {
 		t.Errorf("wrong type for GitHub configuration prototype: %T", y)
 	}
@@ -49,12 +52,24 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantGoPackages.ConfigPrototype().(*schema.GoModulesConnection); !ok {
 		t.Errorf("wrong type for Go Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantHexPackages.ConfigPrototype().(*schema.HexPackagesConnection); !ok {
+		t.Errorf("wrong type for Hex Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantJVMPackages.ConfigPrototype().(*schema.JVMPackagesConnection); !ok {
 		t.Errorf("wrong type for JVM Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantMercurial.ConfigPrototype().(*schema.MercurialConnection); !ok {
+		t.Errorf("wrong type for Mercurial configuration prototype: %T", y)
+	}
 	if y, ok := VariantNpmPackages.ConfigPrototype().(*schema.NpmPackagesConnection); !ok {
 		t.Errorf("wrong type for NPM Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantNuGetPackages.ConfigPrototype().(*schema.NuGetPackagesConnection); !ok {
+		t.Errorf("wrong type for NuGet Packages configuration prototype: %T", y)
+	}
+	if y, ok := VariantPackagistPackages.ConfigPrototype().(*schema.PackagistPackagesConnection); !ok {
+		t.Errorf("wrong type for Packagist Packages configuration prototype: %T", y)
+	}
 	if y, ok := VariantOther.ConfigPrototype().(*schema.OtherExternalServiceConnection); !ok {
 		t.Errorf("wrong type for Other configuration prototype: %T", y)
 	}
@@ -76,6 +91,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantRustPackages.ConfigPrototype().(*schema.RustPackagesConnection); !ok {
 		t.Errorf("wrong type for Rust Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantSubversion.ConfigPrototype().(*schema.SubversionConnection); !ok {
+		t.Errorf("wrong type for Subversion configuration prototype: %T", y)
+	}
 }
 
 func TestExtractToken(t *testing.T) {
@@ -109,6 +127,11 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindPhabricator,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindGitea,
+			want:   "deadbeef",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := ExtractToken(tc.config, tc.kind)
@@ -192,6 +215,20 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			kind:   KindBitbucketCloud,
 			want:   1.0,
 		},
+		{
+			name:          "Gitea default",
+			config:        `{"url": "https://example.com/"}`,
+			kind:          KindGitea,
+			want:          8.0,
+			expectDefault: true,
+		},
+		{
+			name:          "Gitea non-default",
+			config:        `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:          KindGitea,
+			want:          1.0,
+			expectDefault: false,
+		},
 		{
 			name:          "NPM default",
 			config:        `{"registry": "https://registry.npmjs.org"}`,
@@ -45,7 +45,7 @@ func setEvaluatedFlagToCache(a *actor.Actor, flagName string, value bool) {
 
 func getVisitorIDForActor(a *actor.Actor) (string, error) {
 	if a.IsAuthenticated() {
-		return fmt.Sprintf("uid_%d", a.UID), nil
+		return getUserVisitorID(a.UID), nil
 	} else if a.AnonymousUID != "" {
 		return "auid_" + a.AnonymousUID, nil
 	} else {
@@ -53,6 +53,10 @@ func getVisitorIDForActor(a *actor.Actor) (string, error) {
 	}
 }
 
+func getUserVisitorID(userID int32) string {
+	return fmt.Sprintf("uid_%d", userID)
+}
+
 func getFlagCacheKey(name string) string {
 	return "ff_" + name
 }
@@ -61,3 +65,12 @@ func getFlagCacheKey(name string) string {
 func ClearEvaluatedFlagFromCache(flagName string) {
 	_ = evalStore.Del(getFlagCacheKey(flagName))
 }
+
+// Clears the stored evaluations of the given flags for a single user. This is
+// used for targeting flags, whose value for a user changes with the user's
+// attributes rather than with the flag.
+func ClearEvaluatedFlagsForUser(userID int32, flagNames ...string) {
+	for _, name := range flagNames {
+		_ = evalStore.HDel(getFlagCacheKey(name), getUserVisitorID(userID))
+	}
+}
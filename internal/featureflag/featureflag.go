@@ -11,23 +11,34 @@ type FeatureFlag struct {
 
 	// A feature flag is one of the following types.
 	// Exactly one of the following will be set.
-	Bool    *FeatureFlagBool
-	Rollout *FeatureFlagRollout
+	Bool      *FeatureFlagBool
+	Rollout   *FeatureFlagRollout
+	Targeting *FeatureFlagTargeting
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
-// EvaluateForUser evaluates the feature flag for a userID.
+// EvaluateForUser evaluates the feature flag for a userID. Targeting flags are
+// evaluated as if the user matched none of the rules, use
+// EvaluateForUserWithAttributes to take the rules into account.
 func (f *FeatureFlag) EvaluateForUser(userID int32) bool {
+	return f.EvaluateForUserWithAttributes(TargetingAttributes{UserID: userID})
+}
+
+// EvaluateForUserWithAttributes evaluates the feature flag for a user with the
+// given attributes.
+func (f *FeatureFlag) EvaluateForUserWithAttributes(attrs TargetingAttributes) bool {
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
 	case f.Rollout != nil:
-		return f.Rollout.Evaluate(f.Name, userID)
+		return f.Rollout.Evaluate(f.Name, attrs.UserID)
+	case f.Targeting != nil:
+		return f.Targeting.Evaluate(f.Name, attrs)
 	}
-	panic("one of Bool or Rollout must be set")
+	panic("one of Bool, Rollout or Targeting must be set")
 }
 
 func hashUserAndFlag(userID int32, flagName string) uint32 {
@@ -44,8 +55,12 @@ func (f *FeatureFlag) EvaluateForAnonymousUser(anonymousUID string) bool {
 		return f.Bool.Value
 	case f.Rollout != nil:
 		return hashAnonymousUserAndFlag(anonymousUID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+	case f.Targeting != nil:
+		// Anonymous users have no attributes to match, so only the fallback
+		// rollout applies.
+		return hashAnonymousUserAndFlag(anonymousUID, f.Name)%10000 < uint32(f.Targeting.Rollout)
 	}
-	panic("one of Bool or Rollout must be set")
+	panic("one of Bool, Rollout or Targeting must be set")
 }
 
 func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
@@ -57,7 +72,7 @@ func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
 
 // EvaluateGlobal returns the evaluated feature flag for a global context (no user
 // is associated with the request). If the flag is not evaluatable in the global context
-// (i.e. the flag type is a rollout), then the second parameter will return false.
+// (i.e. the flag type is a rollout or targeting), then the second parameter will return false.
 func (f *FeatureFlag) EvaluateGlobal() (res bool, ok bool) {
 	switch {
 	case f.Bool != nil:
@@ -64,11 +64,14 @@ func (f *flagSetFetcher) fetch(ctx context.Context) *FlagSet {
 	return f.fetchForActor(ctx, currentActor)
 }
 
+// fetchForActor fetches the flags for a. The returned FlagSet records its
+// evaluations for a, which matters for flags that evaluate differently per
+// user, such as rollout and targeting flags.
 func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *FlagSet {
 	if a.IsAuthenticated() {
 		flags, err := f.ffs.GetUserFlags(ctx, a.UID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			return &FlagSet{flags: flags, actor: a}
 		}
 		// Continue if err != nil
 	}
@@ -76,17 +79,17 @@ func (f *flagSetFetcher) fetchForActor(ctx context.Context, a *actor.Actor) *Fla
 	if a.AnonymousUID != "" {
 		flags, err := f.ffs.GetAnonymousUserFlags(ctx, a.AnonymousUID)
 		if err == nil {
-			return &FlagSet{flags: flags, actor: f.actor}
+			return &FlagSet{flags: flags, actor: a}
 		}
 		// Continue if err != nil
 	}
 
 	flags, err := f.ffs.GetGlobalFeatureFlags(ctx)
 	if err == nil {
-		return &FlagSet{flags: flags, actor: f.actor}
+		return &FlagSet{flags: flags, actor: a}
 	}
 
-	return &FlagSet{actor: f.actor}
+	return &FlagSet{actor: a}
 }
 
 // FromContext retrieves the current set of flags from the current
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	mockrequire "github.com/derision-test/go-mockgen/v2/testutil/require"
@@ -102,6 +103,23 @@ func TestContextFlags_GetBool(t *testing.T) {
 	})
 }
 
+func TestClearEvaluatedFlagsForUser(t *testing.T) {
+	setupRedisTest(t)
+	mockStore := NewMockStore()
+	mockStore.GetUserFlagsFunc.SetDefaultReturn(map[string]bool{"targeted": true}, nil)
+
+	ctx := WithFlags(context.Background(), mockStore)
+	ctx1 := actor.WithActor(ctx, actor.FromUser(1))
+	ctx2 := actor.WithActor(ctx, actor.FromUser(2))
+
+	require.True(t, FromContext(ctx1).GetBoolOr("targeted", false))
+	require.True(t, FromContext(ctx2).GetBoolOr("targeted", false))
+
+	ClearEvaluatedFlagsForUser(1, "targeted")
+	require.Equal(t, EvaluatedFlagSet{}, GetEvaluatedFlagSet(ctx1))
+	require.Equal(t, EvaluatedFlagSet{"targeted": true}, GetEvaluatedFlagSet(ctx2))
+}
+
 func TestContextFlags_GetBoolOr(t *testing.T) {
 	setupRedisTest(t)
 	mockStore := NewMockStore()
@@ -174,14 +192,22 @@ func setupRedisTest(t *testing.T) {
 
 	mockStore := redispool.NewMockKeyValue()
 	mockStore.HSetFunc.SetDefaultHook(func(key string, field string, value any) error {
-		cache[key] = []byte(value.(string))
+		cache[key+"/"+field] = []byte(value.(string))
 		return nil
 	})
 	mockStore.HGetFunc.SetDefaultHook(func(key string, field string) redispool.Value {
-		return redispool.NewValue(cache[key], nil)
+		return redispool.NewValue(cache[key+"/"+field], nil)
+	})
+	mockStore.HDelFunc.SetDefaultHook(func(key string, field string) redispool.Value {
+		delete(cache, key+"/"+field)
+		return redispool.NewValue(int64(1), nil)
 	})
 	mockStore.DelFunc.SetDefaultHook(func(key string) error {
-		delete(cache, key)
+		for k := range cache {
+			if strings.HasPrefix(k, key+"/") {
+				delete(cache, k)
+			}
+		}
 		return nil
 	})
 	evalStore = mockStore
@@ -0,0 +1,122 @@
+package featureflag
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// TargetingRuleType is the user attribute a TargetingRule matches on.
+type TargetingRuleType string
+
+const (
+	// TargetingRuleOrg matches members of any of the rule's OrgIDs.
+	TargetingRuleOrg TargetingRuleType = "org"
+	// TargetingRuleRole matches users that have any of the rule's Roles.
+	TargetingRuleRole TargetingRuleType = "role"
+	// TargetingRuleEmailDomain matches users with a verified email address in
+	// any of the rule's EmailDomains.
+	TargetingRuleEmailDomain TargetingRuleType = "emailDomain"
+	// TargetingRuleSiteAdmin matches site admins.
+	TargetingRuleSiteAdmin TargetingRuleType = "siteAdmin"
+)
+
+// FeatureFlagTargeting is a feature flag that is evaluated against the
+// attributes of a user. Rules are evaluated in order and the first matching
+// rule decides the value. Users not matched by any rule fall back to a
+// percentage rollout.
+type FeatureFlagTargeting struct {
+	Rules []TargetingRule `json:"rules"`
+
+	// Rollout is the fallback for users that match no rule. Like
+	// FeatureFlagRollout.Rollout, it's an integer between 0 and 10000 in
+	// increments of 0.01%.
+	Rollout int32 `json:"rollout"`
+}
+
+type TargetingRule struct {
+	Type TargetingRuleType `json:"type"`
+
+	OrgIDs       []int32  `json:"orgIDs,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	EmailDomains []string `json:"emailDomains,omitempty"`
+
+	// Value is what the flag evaluates to for users matched by this rule.
+	Value bool `json:"value"`
+}
+
+// TargetingAttributes are the attributes of a user that targeting rules are
+// evaluated against.
+type TargetingAttributes struct {
+	UserID    int32
+	SiteAdmin bool
+	OrgIDs    []int32
+	Roles     []string
+	// EmailDomains are the domains of the user's verified email addresses.
+	EmailDomains []string
+}
+
+// Validate returns an error if the targeting rules can never match or the
+// fallback rollout is out of range.
+func (f *FeatureFlagTargeting) Validate() error {
+	if f.Rollout < 0 || f.Rollout > 10000 {
+		return errors.Newf("rollout must be between 0 and 10000, got %d", f.Rollout)
+	}
+	for i, r := range f.Rules {
+		var empty bool
+		switch r.Type {
+		case TargetingRuleOrg:
+			empty = len(r.OrgIDs) == 0
+		case TargetingRuleRole:
+			empty = len(r.Roles) == 0
+		case TargetingRuleEmailDomain:
+			empty = len(r.EmailDomains) == 0
+		case TargetingRuleSiteAdmin:
+		default:
+			return errors.Newf("rule %d: unknown type %q", i, r.Type)
+		}
+		if empty {
+			return errors.Newf("rule %d: %s rule must list at least one value", i, r.Type)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the value of the first rule matching attrs, or the result
+// of the fallback rollout for the user.
+func (f *FeatureFlagTargeting) Evaluate(flagName string, attrs TargetingAttributes) bool {
+	for _, r := range f.Rules {
+		if r.Matches(attrs) {
+			return r.Value
+		}
+	}
+	return hashUserAndFlag(attrs.UserID, flagName)%10000 < uint32(f.Rollout)
+}
+
+// Matches reports whether the rule applies to a user with the given attributes.
+func (r TargetingRule) Matches(attrs TargetingAttributes) bool {
+	switch r.Type {
+	case TargetingRuleOrg:
+		for _, id := range attrs.OrgIDs {
+			if slices.Contains(r.OrgIDs, id) {
+				return true
+			}
+		}
+	case TargetingRuleRole:
+		for _, role := range attrs.Roles {
+			if slices.ContainsFunc(r.Roles, func(s string) bool { return strings.EqualFold(s, role) }) {
+				return true
+			}
+		}
+	case TargetingRuleEmailDomain:
+		for _, domain := range attrs.EmailDomains {
+			if slices.ContainsFunc(r.EmailDomains, func(s string) bool { return strings.EqualFold(s, domain) }) {
+				return true
+			}
+		}
+	case TargetingRuleSiteAdmin:
+		return attrs.SiteAdmin
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagTargeting_Evaluate(t *testing.T) {
+	targeting := &FeatureFlagTargeting{
+		Rules: []TargetingRule{
+			{Type: TargetingRuleEmailDomain, EmailDomains: []string{"contractor.example.com"}, Value: false},
+			{Type: TargetingRuleOrg, OrgIDs: []int32{10, 11}, Value: true},
+			{Type: TargetingRuleRole, Roles: []string{"BETA_TESTER"}, Value: true},
+			{Type: TargetingRuleEmailDomain, EmailDomains: []string{"example.com"}, Value: true},
+			{Type: TargetingRuleSiteAdmin, Value: true},
+		},
+		Rollout: 0,
+	}
+
+	cases := []struct {
+		name  string
+		attrs TargetingAttributes
+		want  bool
+	}{
+		{name: "no match uses fallback", attrs: TargetingAttributes{UserID: 1}, want: false},
+		{name: "org member", attrs: TargetingAttributes{UserID: 1, OrgIDs: []int32{3, 11}}, want: true},
+		{name: "role is case-insensitive", attrs: TargetingAttributes{UserID: 1, Roles: []string{"beta_tester"}}, want: true},
+		{name: "email domain", attrs: TargetingAttributes{UserID: 1, EmailDomains: []string{"EXAMPLE.com"}}, want: true},
+		{name: "site admin", attrs: TargetingAttributes{UserID: 1, SiteAdmin: true}, want: true},
+		{
+			name:  "first matching rule wins",
+			attrs: TargetingAttributes{UserID: 1, OrgIDs: []int32{10}, EmailDomains: []string{"contractor.example.com"}},
+			want:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, targeting.Evaluate("flag", tc.attrs))
+		})
+	}
+
+	t.Run("fallback rollout", func(t *testing.T) {
+		all := &FeatureFlagTargeting{Rollout: 10000}
+		require.True(t, all.Evaluate("flag", TargetingAttributes{UserID: 1}))
+
+		// The fallback must agree with a rollout flag of the same percentage.
+		half := &FeatureFlagTargeting{Rollout: 5000}
+		rollout := &FeatureFlagRollout{Rollout: 5000}
+		for userID := int32(1); userID <= 100; userID++ {
+			require.Equal(t, rollout.Evaluate("flag", userID), half.Evaluate("flag", TargetingAttributes{UserID: userID}))
+		}
+	})
+}
+
+func TestFeatureFlagTargeting_Validate(t *testing.T) {
+	require.NoError(t, (&FeatureFlagTargeting{
+		Rules:   []TargetingRule{{Type: TargetingRuleSiteAdmin, Value: true}},
+		Rollout: 100,
+	}).Validate())
+
+	for _, invalid := range []*FeatureFlagTargeting{
+		{Rollout: 10001},
+		{Rules: []TargetingRule{{Type: "team"}}},
+		{Rules: []TargetingRule{{Type: TargetingRuleOrg}}},
+		{Rules: []TargetingRule{{Type: TargetingRuleEmailDomain, Roles: []string{"USER"}}}},
+	} {
+		require.Error(t, invalid.Validate())
+	}
+}
+
+func TestFeatureFlag_EvaluateTargeting(t *testing.T) {
+	flag := &FeatureFlag{
+		Name: "flag",
+		Targeting: &FeatureFlagTargeting{
+			Rules: []TargetingRule{{Type: TargetingRuleSiteAdmin, Value: true}},
+		},
+	}
+
+	require.True(t, flag.EvaluateForUserWithAttributes(TargetingAttributes{UserID: 1, SiteAdmin: true}))
+	// Without attributes, no rule can match.
+	require.False(t, flag.EvaluateForUser(1))
+	require.False(t, flag.EvaluateForAnonymousUser("anon"))
+	_, ok := flag.EvaluateGlobal()
+	require.False(t, ok)
+}
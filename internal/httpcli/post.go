@@ -0,0 +1,62 @@
+package httpcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StatusCodeError is returned by Post and PostJSON when the server responds
+// with a non-2xx status.
+type StatusCodeError struct {
+	Code   int
+	Status string
+	Body   string
+}
+
+func (s StatusCodeError) Error() string {
+	return fmt.Sprintf("non-2xx response %d %s with body %q", s.Code, s.Status, s.Body)
+}
+
+// PostJSON posts the JSON encoding of payload to url, for example to send a
+// webhook notification. Any 2xx response is a success, because some receivers
+// respond with 202 Accepted or 204 No Content rather than 200 OK.
+func PostJSON(ctx context.Context, doer Doer, url string, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	return Post(ctx, doer, url, "application/json", raw)
+}
+
+// Post posts body with the given content type to url. Like PostJSON, it
+// returns a StatusCodeError for non-2xx responses.
+func Post(ctx context.Context, doer Doer, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed new request")
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to post")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return StatusCodeError{
+			Code:   resp.StatusCode,
+			Status: resp.Status,
+			Body:   string(respBody),
+		}
+	}
+
+	return nil
+}
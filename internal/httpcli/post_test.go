@@ -0,0 +1,47 @@
+package httpcli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestPostJSON(t *testing.T) {
+	for _, code := range []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["text"] != "hello" || r.Header.Get("Content-Type") != "application/json" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(code)
+			}))
+			defer s.Close()
+
+			require.NoError(t, PostJSON(context.Background(), s.Client(), s.URL, map[string]string{"text": "hello"}))
+		})
+	}
+
+	for _, code := range []int{http.StatusMultipleChoices, http.StatusBadRequest, http.StatusInternalServerError} {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte("nope"))
+			}))
+			defer s.Close()
+
+			err := PostJSON(context.Background(), s.Client(), s.URL, map[string]string{"text": "hello"})
+			var statusErr StatusCodeError
+			require.True(t, errors.As(err, &statusErr))
+			require.Equal(t, code, statusErr.Code)
+			require.Equal(t, "nope", statusErr.Body)
+		})
+	}
+}
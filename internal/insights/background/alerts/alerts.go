@@ -0,0 +1,142 @@
+// Package alerts evaluates the alert rules defined on insight series after each
+// recording, and notifies their owners when they trigger.
+package alerts
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MaxIntervals bounds the number of recordings a percent-change alert may look back.
+const MaxIntervals = 52
+
+// Validate returns an error if the alert is not well-formed.
+func Validate(alert types.InsightSeriesAlert) error {
+	switch alert.Kind {
+	case types.AlertAbsoluteThreshold, types.AlertAnyIncrease:
+	case types.AlertPercentChange:
+		if alert.Threshold == 0 {
+			return errors.New("percent change alerts require a non-zero threshold")
+		}
+		if alert.Intervals < 1 || alert.Intervals > MaxIntervals {
+			return errors.Newf("percent change alerts must compare between 1 and %d intervals", MaxIntervals)
+		}
+	default:
+		return errors.Newf("unknown alert kind %q", alert.Kind)
+	}
+	if math.IsNaN(alert.Threshold) || math.IsInf(alert.Threshold, 0) {
+		return errors.New("alert threshold must be a finite number")
+	}
+
+	if len(alert.Actions) == 0 {
+		return errors.New("alerts require at least one action")
+	}
+	for _, action := range alert.Actions {
+		switch action.Type {
+		case types.AlertActionEmail:
+			if action.URL != "" {
+				return errors.New("email actions notify the owner of the alert and cannot have a URL")
+			}
+		case types.AlertActionSlack, types.AlertActionWebhook:
+			u, err := url.Parse(action.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Newf("invalid %s action URL %q", action.Type, action.URL)
+			}
+		default:
+			return errors.Newf("unknown alert action type %q", action.Type)
+		}
+	}
+	return nil
+}
+
+// window returns the number of recordings, including the latest one, that are
+// needed to evaluate the alert.
+func window(alert types.InsightSeriesAlert) int {
+	if alert.Kind == types.AlertPercentChange {
+		return alert.Intervals + 1
+	}
+	return 2
+}
+
+// triggered returns true if the alert triggers for the given values, ordered
+// from oldest to latest recording. values has at most window(alert) elements.
+func triggered(alert types.InsightSeriesAlert, values []float64) bool {
+	if len(values) == 0 {
+		return false
+	}
+	latest := values[len(values)-1]
+
+	switch alert.Kind {
+	case types.AlertAbsoluteThreshold:
+		if latest <= alert.Threshold {
+			return false
+		}
+		// Only notify when the threshold is crossed, not on every recording
+		// that stays above it.
+		return len(values) < 2 || values[len(values)-2] <= alert.Threshold
+
+	case types.AlertPercentChange:
+		if len(values) < window(alert) {
+			// There is not enough history to compare against yet.
+			return false
+		}
+		change := percentChange(values[0], latest)
+		if alert.Threshold > 0 {
+			return change >= alert.Threshold
+		}
+		return change <= alert.Threshold
+
+	case types.AlertAnyIncrease:
+		return len(values) >= 2 && latest > values[len(values)-2]
+	}
+	return false
+}
+
+// percentChange returns the change from before to after in percent. Any
+// increase from zero is an infinite change.
+func percentChange(before, after float64) float64 {
+	if before == 0 {
+		switch {
+		case after > 0:
+			return math.Inf(1)
+		case after < 0:
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	return (after - before) / math.Abs(before) * 100
+}
+
+// describeCondition returns a human-readable description of the condition
+// under which the alert triggers, such as "rose above 100".
+func describeCondition(alert types.InsightSeriesAlert) string {
+	switch alert.Kind {
+	case types.AlertAbsoluteThreshold:
+		return "rose above " + formatValue(alert.Threshold)
+	case types.AlertPercentChange:
+		direction := "increased"
+		if alert.Threshold < 0 {
+			direction = "decreased"
+		}
+		return fmt.Sprintf("%s by at least %s%% over %d %s", direction, formatValue(math.Abs(alert.Threshold)), alert.Intervals, pluralize("recording", alert.Intervals))
+	case types.AlertAnyIncrease:
+		return "increased"
+	}
+	return string(alert.Kind)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func pluralize(word string, count int) string {
+	if count == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,123 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestTriggered(t *testing.T) {
+	absolute := types.InsightSeriesAlert{Kind: types.AlertAbsoluteThreshold, Threshold: 10}
+	percentUp := types.InsightSeriesAlert{Kind: types.AlertPercentChange, Threshold: 50, Intervals: 2}
+	percentDown := types.InsightSeriesAlert{Kind: types.AlertPercentChange, Threshold: -50, Intervals: 1}
+	increase := types.InsightSeriesAlert{Kind: types.AlertAnyIncrease}
+
+	testCases := []struct {
+		name   string
+		alert  types.InsightSeriesAlert
+		values []float64
+		want   bool
+	}{
+		{name: "no values", alert: absolute, values: nil, want: false},
+		{name: "absolute first recording above", alert: absolute, values: []float64{11}, want: true},
+		{name: "absolute crosses threshold", alert: absolute, values: []float64{10, 11}, want: true},
+		{name: "absolute stays above threshold", alert: absolute, values: []float64{11, 12}, want: false},
+		{name: "absolute at threshold", alert: absolute, values: []float64{5, 10}, want: false},
+		{name: "absolute falls below threshold", alert: absolute, values: []float64{11, 9}, want: false},
+
+		{name: "percent not enough history", alert: percentUp, values: []float64{10, 20}, want: false},
+		{name: "percent increase", alert: percentUp, values: []float64{10, 12, 15}, want: true},
+		{name: "percent increase compares with oldest", alert: percentUp, values: []float64{10, 100, 14}, want: false},
+		{name: "percent increase from zero", alert: percentUp, values: []float64{0, 0, 1}, want: true},
+		{name: "percent unchanged zero", alert: percentUp, values: []float64{0, 0, 0}, want: false},
+		{name: "percent decrease", alert: percentDown, values: []float64{10, 5}, want: true},
+		{name: "percent small decrease", alert: percentDown, values: []float64{10, 6}, want: false},
+		{name: "percent decrease on increase", alert: percentDown, values: []float64{10, 20}, want: false},
+
+		{name: "any increase", alert: increase, values: []float64{1, 2}, want: true},
+		{name: "any increase unchanged", alert: increase, values: []float64{2, 2}, want: false},
+		{name: "any increase decrease", alert: increase, values: []float64{2, 1}, want: false},
+		{name: "any increase first recording", alert: increase, values: []float64{2}, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, triggered(tc.alert, tc.values))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	email := []types.InsightSeriesAlertAction{{Type: types.AlertActionEmail}}
+
+	testCases := []struct {
+		name    string
+		alert   types.InsightSeriesAlert
+		wantErr bool
+	}{
+		{
+			name:  "absolute threshold",
+			alert: types.InsightSeriesAlert{Kind: types.AlertAbsoluteThreshold, Threshold: 100, Intervals: 1, Actions: email},
+		},
+		{
+			name: "percent change with all actions",
+			alert: types.InsightSeriesAlert{Kind: types.AlertPercentChange, Threshold: -20, Intervals: 4, Actions: []types.InsightSeriesAlertAction{
+				{Type: types.AlertActionEmail},
+				{Type: types.AlertActionSlack, URL: "https://hooks.slack.com/services/abc"},
+				{Type: types.AlertActionWebhook, URL: "http://example.com/hook"},
+			}},
+		},
+		{
+			name:    "unknown kind",
+			alert:   types.InsightSeriesAlert{Kind: "sometimes", Intervals: 1, Actions: email},
+			wantErr: true,
+		},
+		{
+			name:    "percent change without threshold",
+			alert:   types.InsightSeriesAlert{Kind: types.AlertPercentChange, Intervals: 1, Actions: email},
+			wantErr: true,
+		},
+		{
+			name:    "percent change without intervals",
+			alert:   types.InsightSeriesAlert{Kind: types.AlertPercentChange, Threshold: 10, Actions: email},
+			wantErr: true,
+		},
+		{
+			name:    "no actions",
+			alert:   types.InsightSeriesAlert{Kind: types.AlertAnyIncrease, Intervals: 1},
+			wantErr: true,
+		},
+		{
+			name: "email with URL",
+			alert: types.InsightSeriesAlert{Kind: types.AlertAnyIncrease, Intervals: 1, Actions: []types.InsightSeriesAlertAction{
+				{Type: types.AlertActionEmail, URL: "https://example.com"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "webhook without URL",
+			alert: types.InsightSeriesAlert{Kind: types.AlertAnyIncrease, Intervals: 1, Actions: []types.InsightSeriesAlertAction{
+				{Type: types.AlertActionWebhook},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "slack with non-http URL",
+			alert: types.InsightSeriesAlert{Kind: types.AlertAnyIncrease, Intervals: 1, Actions: []types.InsightSeriesAlertAction{
+				{Type: types.AlertActionSlack, URL: "file:///etc/passwd"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.alert)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package alerts
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type alertStore interface {
+	GetAlerts(ctx context.Context, args store.AlertQueryArgs) ([]types.InsightSeriesAlert, error)
+	ClaimRecording(ctx context.Context, id int, recordingTime time.Time) (bool, error)
+}
+
+type seriesStore interface {
+	LastRecordingTimes(ctx context.Context, seriesId int, to time.Time, n int) ([]time.Time, error)
+	SeriesPoints(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error)
+}
+
+type viewStore interface {
+	GetAll(ctx context.Context, args store.InsightQueryArgs) ([]types.InsightViewSeries, error)
+}
+
+type permissionStore interface {
+	GetUserPermissions(ctx context.Context) (userIDs []int, orgIDs []int, err error)
+}
+
+// Evaluator evaluates the alerts defined on a series after it is recorded.
+type Evaluator struct {
+	logger      log.Logger
+	alerts      alertStore
+	series      seriesStore
+	views       viewStore
+	permStore   permissionStore
+	users       database.UserStore
+	notifier    notifier
+	externalURL func() *url.URL
+}
+
+// NewEvaluator returns an Evaluator that reads alerts and series data from
+// insightsDB, and users from db.
+func NewEvaluator(logger log.Logger, db database.DB, insightsDB database.InsightsDB) *Evaluator {
+	permStore := store.NewInsightPermissionStore(db)
+	return &Evaluator{
+		logger:      logger,
+		alerts:      store.NewAlertStore(insightsDB),
+		series:      store.New(insightsDB, permStore),
+		views:       store.NewInsightStore(insightsDB),
+		permStore:   permStore,
+		users:       db.Users(),
+		notifier:    &actionNotifier{db: db, doer: httpcli.ExternalDoer},
+		externalURL: conf.ExternalURLParsed,
+	}
+}
+
+// EvaluateSeries evaluates all alerts defined on the series against the
+// recording at recordingTime, and notifies the owners of those that trigger.
+// Each alert is evaluated at most once per recording.
+func (e *Evaluator) EvaluateSeries(ctx context.Context, series *types.InsightSeries, recordingTime time.Time) error {
+	alerts, err := e.alerts.GetAlerts(ctx, store.AlertQueryArgs{InsightSeriesID: series.ID})
+	if err != nil {
+		return errors.Wrap(err, "GetAlerts")
+	}
+
+	var errs error
+	for _, alert := range alerts {
+		if err := e.evaluate(ctx, series, alert, recordingTime); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert %d", alert.ID))
+		}
+	}
+	return errs
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, series *types.InsightSeries, alert types.InsightSeriesAlert, recordingTime time.Time) error {
+	// Claiming the recording first means a failed notification is not retried,
+	// but a retried recording job never notifies twice.
+	claimed, err := e.alerts.ClaimRecording(ctx, alert.ID, recordingTime)
+	if err != nil || !claimed {
+		return err
+	}
+
+	// 🚨 SECURITY: the series data is read as the owner of the alert, so that
+	// notifications only contain values from repositories they can see, and
+	// only while they can still view an insight with this series.
+	userCtx := actor.WithActor(ctx, actor.FromUser(alert.UserID))
+	if _, err := e.users.GetByID(userCtx, alert.UserID); err != nil {
+		if errcode.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	userIDs, orgIDs, err := e.permStore.GetUserPermissions(userCtx)
+	if err != nil {
+		return err
+	}
+	views, err := e.views.GetAll(userCtx, store.InsightQueryArgs{
+		SeriesID: series.SeriesID,
+		UserIDs:  userIDs,
+		OrgIDs:   orgIDs,
+		Limit:    1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		e.logger.Debug("skipping alert on series the owner cannot view", log.Int("alertID", alert.ID), log.Int32("userID", alert.UserID))
+		return nil
+	}
+	view := views[0]
+
+	times, err := e.series.LastRecordingTimes(userCtx, series.ID, recordingTime, window(alert))
+	if err != nil {
+		return errors.Wrap(err, "LastRecordingTimes")
+	}
+	if len(times) == 0 {
+		return nil
+	}
+	points, err := e.series.SeriesPoints(userCtx, store.SeriesPointsOpts{
+		SeriesID: &series.SeriesID,
+		From:     &times[0],
+		To:       &times[len(times)-1],
+	})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	values := triggeredValues(alert, times, points, !series.GeneratedFromCaptureGroups)
+	if len(values) == 0 {
+		return nil
+	}
+
+	label := view.Label
+	if label == "" {
+		label = view.Query
+	}
+	return e.notifier.notify(ctx, notification{
+		Alert:         alert,
+		InsightTitle:  view.Title,
+		InsightURL:    insightURL(e.externalURL(), view.UniqueID),
+		SeriesLabel:   label,
+		Query:         series.Query,
+		RecordingTime: times[len(times)-1],
+		Values:        values,
+	})
+}
+
+// triggeredValues evaluates the alert on the values of each capture of the
+// series at the given recording times, and returns those that trigger it. A
+// capture with no point at a recording time had a value of zero. If
+// includeEmpty is true, the series without a capture is evaluated even if it
+// has no points.
+func triggeredValues(alert types.InsightSeriesAlert, times []time.Time, points []store.SeriesPoint, includeEmpty bool) []triggeredValue {
+	// Points are aggregated to the second, so recording times are matched with
+	// second precision.
+	index := make(map[int64]int, len(times))
+	for i, t := range times {
+		index[t.Unix()] = i
+	}
+
+	valuesByCapture := make(map[string][]float64)
+	if includeEmpty {
+		valuesByCapture[""] = make([]float64, len(times))
+	}
+	for _, point := range points {
+		i, ok := index[point.Time.Unix()]
+		if !ok {
+			continue
+		}
+		capture := ""
+		if point.Capture != nil {
+			capture = *point.Capture
+		}
+		values, ok := valuesByCapture[capture]
+		if !ok {
+			values = make([]float64, len(times))
+			valuesByCapture[capture] = values
+		}
+		values[i] += point.Value
+	}
+
+	captures := make([]string, 0, len(valuesByCapture))
+	for capture := range valuesByCapture {
+		captures = append(captures, capture)
+	}
+	sort.Strings(captures)
+
+	var results []triggeredValue
+	for _, capture := range captures {
+		values := valuesByCapture[capture]
+		if !triggered(alert, values) {
+			continue
+		}
+		result := triggeredValue{Capture: capture, Value: values[len(values)-1]}
+		if len(values) > 1 {
+			previous := values[len(values)-2]
+			if alert.Kind == types.AlertPercentChange {
+				previous = values[0]
+			}
+			result.PreviousValue = &previous
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,190 @@
+package alerts
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeAlertStore struct {
+	alerts  []types.InsightSeriesAlert
+	claimed map[int]time.Time
+}
+
+func (s *fakeAlertStore) GetAlerts(_ context.Context, _ store.AlertQueryArgs) ([]types.InsightSeriesAlert, error) {
+	return s.alerts, nil
+}
+
+func (s *fakeAlertStore) ClaimRecording(_ context.Context, id int, recordingTime time.Time) (bool, error) {
+	if last, ok := s.claimed[id]; ok && !last.Before(recordingTime) {
+		return false, nil
+	}
+	s.claimed[id] = recordingTime
+	return true, nil
+}
+
+type fakeSeriesStore struct {
+	times  []time.Time
+	points []store.SeriesPoint
+}
+
+func (s *fakeSeriesStore) LastRecordingTimes(_ context.Context, _ int, to time.Time, n int) ([]time.Time, error) {
+	var times []time.Time
+	for _, t := range s.times {
+		if !t.After(to) {
+			times = append(times, t)
+		}
+	}
+	if len(times) > n {
+		times = times[len(times)-n:]
+	}
+	return times, nil
+}
+
+func (s *fakeSeriesStore) SeriesPoints(_ context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+	var points []store.SeriesPoint
+	for _, p := range s.points {
+		if !p.Time.Before(*opts.From) && !p.Time.After(*opts.To) {
+			points = append(points, p)
+		}
+	}
+	return points, nil
+}
+
+type fakeViewStore struct {
+	views []types.InsightViewSeries
+}
+
+func (s *fakeViewStore) GetAll(_ context.Context, _ store.InsightQueryArgs) ([]types.InsightViewSeries, error) {
+	return s.views, nil
+}
+
+type fakePermissionStore struct{}
+
+func (fakePermissionStore) GetUserPermissions(_ context.Context) ([]int, []int, error) {
+	return []int{42}, nil, nil
+}
+
+type fakeNotifier struct {
+	notifications []notification
+}
+
+func (n *fakeNotifier) notify(_ context.Context, notification notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func TestEvaluateSeries(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	t3 := t2.Add(24 * time.Hour)
+	capture := func(s string) *string { return &s }
+
+	series := &types.InsightSeries{ID: 1, SeriesID: "series", Query: "deprecatedAPI(", GeneratedFromCaptureGroups: true}
+	seriesStore := &fakeSeriesStore{
+		times: []time.Time{t1, t2, t3},
+		points: []store.SeriesPoint{
+			{SeriesID: "series", Time: t1, Value: 5, Capture: capture("a")},
+			{SeriesID: "series", Time: t2, Value: 5, Capture: capture("a")},
+			{SeriesID: "series", Time: t3, Value: 7, Capture: capture("a")},
+			{SeriesID: "series", Time: t2, Value: 3, Capture: capture("b")},
+			{SeriesID: "series", Time: t3, Value: 3, Capture: capture("b")},
+			{SeriesID: "series", Time: t3, Value: 1, Capture: capture("c")},
+		},
+	}
+	alert := types.InsightSeriesAlert{
+		ID:              1,
+		InsightSeriesID: 1,
+		SeriesID:        "series",
+		UserID:          42,
+		Kind:            types.AlertAnyIncrease,
+		Intervals:       1,
+		Actions:         []types.InsightSeriesAlertAction{{ID: 1, Type: types.AlertActionEmail}},
+	}
+
+	newEvaluator := func(views []types.InsightViewSeries) (*Evaluator, *fakeNotifier) {
+		users := dbmocks.NewMockUserStore()
+		users.GetByIDFunc.SetDefaultReturn(&internaltypes.User{ID: 42}, nil)
+		notifier := &fakeNotifier{}
+		return &Evaluator{
+			logger:      logtest.Scoped(t),
+			alerts:      &fakeAlertStore{alerts: []types.InsightSeriesAlert{alert}, claimed: map[int]time.Time{}},
+			series:      seriesStore,
+			views:       &fakeViewStore{views: views},
+			permStore:   fakePermissionStore{},
+			users:       users,
+			notifier:    notifier,
+			externalURL: func() *url.URL { return &url.URL{Scheme: "https", Host: "sourcegraph.test"} },
+		}, notifier
+	}
+
+	t.Run("notifies triggered captures", func(t *testing.T) {
+		evaluator, notifier := newEvaluator([]types.InsightViewSeries{{UniqueID: "view", Title: "Deprecated APIs", Label: "usages"}})
+
+		require.NoError(t, evaluator.EvaluateSeries(context.Background(), series, t3))
+		require.Len(t, notifier.notifications, 1)
+
+		n := notifier.notifications[0]
+		require.Equal(t, "Deprecated APIs", n.InsightTitle)
+		require.Equal(t, "usages", n.SeriesLabel)
+		require.Equal(t, "https://sourcegraph.test/insights/insight/aW5zaWdodF92aWV3OiJ2aWV3Ig==", n.InsightURL)
+		require.Equal(t, t3, n.RecordingTime)
+
+		five, zero := 5.0, 0.0
+		want := []triggeredValue{
+			{Capture: "a", Value: 7, PreviousValue: &five},
+			{Capture: "c", Value: 1, PreviousValue: &zero},
+		}
+		if diff := cmp.Diff(want, n.Values); diff != "" {
+			t.Errorf("unexpected values (-want +got):\n%s", diff)
+		}
+
+		// A retried recording does not notify again.
+		require.NoError(t, evaluator.EvaluateSeries(context.Background(), series, t3))
+		require.Len(t, notifier.notifications, 1)
+	})
+
+	t.Run("evaluates earlier recordings", func(t *testing.T) {
+		evaluator, notifier := newEvaluator([]types.InsightViewSeries{{UniqueID: "view", Title: "Deprecated APIs"}})
+
+		require.NoError(t, evaluator.EvaluateSeries(context.Background(), series, t2))
+		require.Len(t, notifier.notifications, 1)
+		require.Equal(t, []triggeredValue{{Capture: "b", Value: 3, PreviousValue: new(float64)}}, notifier.notifications[0].Values)
+	})
+
+	t.Run("skips series the owner cannot view", func(t *testing.T) {
+		evaluator, notifier := newEvaluator(nil)
+
+		require.NoError(t, evaluator.EvaluateSeries(context.Background(), series, t3))
+		require.Empty(t, notifier.notifications)
+	})
+}
+
+func TestTriggeredValuesWithoutCaptures(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	alert := types.InsightSeriesAlert{Kind: types.AlertAbsoluteThreshold, Threshold: -1}
+
+	// A series without points had a value of zero, which is above the threshold.
+	values := triggeredValues(alert, []time.Time{t1}, nil, true)
+	require.Equal(t, []triggeredValue{{Value: 0}}, values)
+
+	// Points are matched to recording times with second precision.
+	alert.Threshold = 5
+	values = triggeredValues(alert, []time.Time{t1, t2.Add(300 * time.Millisecond)}, []store.SeriesPoint{
+		{Time: t1, Value: 1},
+		{Time: t2, Value: 10},
+	}, true)
+	one := 1.0
+	require.Equal(t, []triggeredValue{{Value: 10, PreviousValue: &one}}, values)
+}
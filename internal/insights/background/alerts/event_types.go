@@ -0,0 +1,12 @@
+package alerts
+
+import "github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+
+const SeriesAlertTriggered = "insight_series:alert"
+
+func init() {
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         SeriesAlertTriggered,
+		Description: "sent when an alert on a code insights series triggers",
+	})
+}
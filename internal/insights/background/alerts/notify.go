@@ -0,0 +1,250 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// notification describes a triggered alert.
+type notification struct {
+	Alert         types.InsightSeriesAlert
+	InsightTitle  string
+	InsightURL    string
+	SeriesLabel   string
+	Query         string
+	RecordingTime time.Time
+	Values        []triggeredValue
+}
+
+// triggeredValue is the value of a series, or of one of its captured values,
+// that triggered an alert.
+type triggeredValue struct {
+	// Capture is empty unless the series is a capture group series.
+	Capture string
+	Value   float64
+	// PreviousValue is the value the alert compared against: the previous
+	// recording, or the recording Intervals ago for percent change alerts.
+	PreviousValue *float64
+}
+
+type notifier interface {
+	notify(ctx context.Context, n notification) error
+}
+
+type actionNotifier struct {
+	db   database.DB
+	doer httpcli.Doer
+}
+
+// notify runs all actions of the alert, and enqueues an outbound webhook
+// event. All actions are attempted even if some fail.
+func (a *actionNotifier) notify(ctx context.Context, n notification) error {
+	var errs error
+	for _, action := range n.Alert.Actions {
+		var err error
+		switch action.Type {
+		case types.AlertActionEmail:
+			err = sendEmail(ctx, a.db, n.Alert.UserID, n)
+		case types.AlertActionSlack:
+			err = httpcli.PostJSON(ctx, a.doer, action.URL, slackPayload(n))
+		case types.AlertActionWebhook:
+			err = httpcli.PostJSON(ctx, a.doer, action.URL, generateWebhookPayload(n))
+		default:
+			err = errors.Newf("unknown alert action type %q", action.Type)
+		}
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "%s action %d", action.Type, action.ID))
+		}
+	}
+
+	payload, err := json.Marshal(generateWebhookPayload(n))
+	if err != nil {
+		return errors.Append(errs, errors.Wrap(err, "marshalling outbound webhook payload"))
+	}
+	svc := outbound.NewOutboundWebhookService(a.db, keyring.Default().OutboundWebhookKey)
+	if err := svc.Enqueue(ctx, SeriesAlertTriggered, nil, payload); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "enqueuing outbound webhook"))
+	}
+	return errs
+}
+
+// insightURL returns the URL of the insight view with the given unique ID.
+func insightURL(externalURL *url.URL, uniqueID string) string {
+	u := externalURL.JoinPath("insights", "insight", string(relay.MarshalID("insight_view", uniqueID)))
+	return u.String()
+}
+
+var emailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight {{.InsightTitle}}: {{.SeriesLabel}} {{.Condition}}`,
+	Text: `
+The series {{.SeriesLabel}} of the code insight {{.InsightTitle}} {{.Condition}}.
+{{range .Values}}
+  {{if .Capture}}{{.Capture}}: {{end}}{{.Value}}{{if .PreviousValue}} (was {{.PreviousValue}}){{end}}
+{{- end}}
+
+View the insight: {{.InsightURL}}
+`,
+	HTML: `
+<p>The series <strong>{{.SeriesLabel}}</strong> of the code insight <a href="{{.InsightURL}}">{{.InsightTitle}}</a> {{.Condition}}.</p>
+<ul>
+{{range .Values}}
+  <li>{{if .Capture}}<code>{{.Capture}}</code>: {{end}}{{.Value}}{{if .PreviousValue}} (was {{.PreviousValue}}){{end}}</li>
+{{end}}
+</ul>
+`,
+})
+
+type emailTemplateData struct {
+	InsightTitle string
+	InsightURL   string
+	SeriesLabel  string
+	Condition    string
+	Values       []emailValue
+}
+
+type emailValue struct {
+	Capture       string
+	Value         string
+	PreviousValue string
+}
+
+func sendEmail(ctx context.Context, db database.DB, userID int32, n notification) error {
+	email, verified, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+		}
+		return errors.Errorf("get primary email for userID=%d: %w", userID, err)
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
+	}
+
+	data := emailTemplateData{
+		InsightTitle: n.InsightTitle,
+		InsightURL:   n.InsightURL,
+		SeriesLabel:  n.SeriesLabel,
+		Condition:    describeCondition(n.Alert),
+	}
+	for _, v := range n.Values {
+		ev := emailValue{Capture: v.Capture, Value: formatValue(v.Value)}
+		if v.PreviousValue != nil {
+			ev.PreviousValue = formatValue(*v.PreviousValue)
+		}
+		data.Values = append(data.Values, ev)
+	}
+
+	if err := txemail.Send(ctx, "code-insights-alert", txtypes.Message{
+		To:       []string{email},
+		Template: emailTemplates,
+		Data:     data,
+	}); err != nil {
+		return errors.Errorf("send mail to email=%q userID=%d: %w", email, userID, err)
+	}
+	return nil
+}
+
+// maxSlackValues bounds the number of values listed in a Slack message, so
+// that capture group series with many values stay readable.
+const maxSlackValues = 10
+
+func slackPayload(n notification) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"The series *%s* of the Sourcegraph code insight <%s|%s> %s.",
+			n.SeriesLabel,
+			n.InsightURL,
+			n.InsightTitle,
+			describeCondition(n.Alert),
+		)),
+	}
+
+	var lines []string
+	for i, v := range n.Values {
+		if i == maxSlackValues {
+			lines = append(lines, fmt.Sprintf("...and %d more", len(n.Values)-maxSlackValues))
+			break
+		}
+		line := "• "
+		if v.Capture != "" {
+			line += fmt.Sprintf("`%s`: ", v.Capture)
+		}
+		line += formatValue(v.Value)
+		if v.PreviousValue != nil {
+			line += fmt.Sprintf(" (was %s)", formatValue(*v.PreviousValue))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > 0 {
+		blocks = append(blocks, newMarkdownSection(strings.Join(lines, "\n")))
+	}
+
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+type webhookPayload struct {
+	InsightTitle  string              `json:"insightTitle"`
+	InsightURL    string              `json:"insightURL"`
+	SeriesID      string              `json:"seriesID"`
+	SeriesLabel   string              `json:"seriesLabel"`
+	Query         string              `json:"query"`
+	Alert         webhookAlert        `json:"alert"`
+	RecordingTime time.Time           `json:"recordingTime"`
+	Values        []webhookAlertValue `json:"values"`
+}
+
+type webhookAlert struct {
+	Kind      types.AlertKind `json:"kind"`
+	Threshold float64         `json:"threshold"`
+	Intervals int             `json:"intervals,omitempty"`
+}
+
+type webhookAlertValue struct {
+	Capture       string   `json:"capture,omitempty"`
+	Value         float64  `json:"value"`
+	PreviousValue *float64 `json:"previousValue,omitempty"`
+}
+
+func generateWebhookPayload(n notification) webhookPayload {
+	p := webhookPayload{
+		InsightTitle: n.InsightTitle,
+		InsightURL:   n.InsightURL,
+		SeriesID:     n.Alert.SeriesID,
+		SeriesLabel:  n.SeriesLabel,
+		Query:        n.Query,
+		Alert: webhookAlert{
+			Kind:      n.Alert.Kind,
+			Threshold: n.Alert.Threshold,
+		},
+		RecordingTime: n.RecordingTime.UTC(),
+		Values:        make([]webhookAlertValue, 0, len(n.Values)),
+	}
+	if n.Alert.Kind == types.AlertPercentChange {
+		p.Alert.Intervals = n.Alert.Intervals
+	}
+	for _, v := range n.Values {
+		p.Values = append(p.Values, webhookAlertValue(v))
+	}
+	return p
+}
@@ -0,0 +1,96 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestWebhookPayload(t *testing.T) {
+	previous := 5.0
+	n := notification{
+		Alert: types.InsightSeriesAlert{
+			SeriesID:  "series",
+			Kind:      types.AlertPercentChange,
+			Threshold: 20,
+			Intervals: 3,
+		},
+		InsightTitle:  "Deprecated APIs",
+		InsightURL:    "https://sourcegraph.test/insights/insight/abc",
+		SeriesLabel:   "usages",
+		Query:         "deprecatedAPI(",
+		RecordingTime: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Values:        []triggeredValue{{Value: 7, PreviousValue: &previous}},
+	}
+
+	t.Run("payload", func(t *testing.T) {
+		raw, err := json.Marshal(generateWebhookPayload(n))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"insightTitle": "Deprecated APIs",
+			"insightURL": "https://sourcegraph.test/insights/insight/abc",
+			"seriesID": "series",
+			"seriesLabel": "usages",
+			"query": "deprecatedAPI(",
+			"alert": {"kind": "percent-change", "threshold": 20, "intervals": 3},
+			"recordingTime": "2024-01-02T00:00:00Z",
+			"values": [{"value": 7, "previousValue": 5}]
+		}`, string(raw))
+	})
+
+	t.Run("posted", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var payload webhookPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			require.Equal(t, "series", payload.SeriesID)
+			// Any 2xx response is a success.
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer s.Close()
+
+		require.NoError(t, httpcli.PostJSON(context.Background(), s.Client(), s.URL, generateWebhookPayload(n)))
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer s.Close()
+
+		err := httpcli.PostJSON(context.Background(), s.Client(), s.URL, slackPayload(n))
+		require.Error(t, err)
+		var statusErr httpcli.StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, http.StatusInternalServerError, statusErr.Code)
+	})
+}
+
+func TestSlackPayload(t *testing.T) {
+	values := make([]triggeredValue, 0, maxSlackValues+2)
+	for i := range maxSlackValues + 2 {
+		values = append(values, triggeredValue{Capture: string(rune('a' + i)), Value: float64(i)})
+	}
+	msg := slackPayload(notification{
+		Alert:        types.InsightSeriesAlert{Kind: types.AlertAbsoluteThreshold, Threshold: 100},
+		InsightTitle: "Deprecated APIs",
+		InsightURL:   "https://sourcegraph.test/insights/insight/abc",
+		SeriesLabel:  "usages",
+		Values:       values,
+	})
+
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "The series *usages* of the Sourcegraph code insight \\u003chttps://sourcegraph.test/insights/insight/abc|Deprecated APIs\\u003e rose above 100.")
+	require.Contains(t, string(raw), "...and 2 more")
+}
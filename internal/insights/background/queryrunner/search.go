@@ -11,10 +11,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	internalGitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/internal/insights/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -56,10 +61,39 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	repoSearchStream := func(ctx context.Context, query string) (*streaming.RepoResult, error) {
+		tr, ctx := trace.New(ctx, "CodeInsightsSearch.repoSearchStream")
+		defer tr.End()
+
+		decoder, repoResult := streaming.RepoDecoder()
+		err := streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		tr.AddEvent("repo search results", attribute.Int("repo_count", len(repoResult.Repos)))
+		return repoResult, nil
+	}
+
+	commitClient := gitserver.NewGitCommitClient(internalGitserver.NewClient("insights.languagestats"))
+	languageStats := func(ctx context.Context, repoName api.RepoName, at time.Time) ([]inventory.Lang, error) {
+		commits, err := commitClient.RecentCommits(ctx, repoName, at, "")
+		if err != nil {
+			if errors.HasType[*gitdomain.RevisionNotFoundError](err) || gitdomain.IsRepoNotExist(err) {
+				return nil, nil // the repository is empty or not cloned yet
+			}
+			return nil, errors.Wrap(err, "RecentCommits")
+		}
+		if len(commits) == 0 {
+			return nil, nil
+		}
+		return commitClient.LanguageStats(ctx, repoName, commits[0].ID)
+	}
+
 	return map[types.GenerationMethod]InsightsHandler{
-		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
-		types.SearchCompute:  makeComputeHandler(computeSearchStream),
-		types.Search:         makeSearchHandler(searchStream),
+		types.MappingCompute:          makeMappingComputeHandler(computeTextExtraSearch),
+		types.SearchCompute:           makeComputeHandler(computeSearchStream),
+		types.Search:                  makeSearchHandler(searchStream),
+		types.HistoricalLanguageStats: makeLanguageStatsHandler(repoSearchStream, languageStats),
 	}
 
 }
@@ -89,6 +123,11 @@ func toRecording(record *SearchJob, value float64, recordTime time.Time, repoNam
 
 type streamComputeProvider func(context.Context, string) (*streaming.ComputeTabulationResult, error)
 type streamSearchProvider func(context.Context, string) (*streaming.TabulationResult, error)
+type streamRepoProvider func(context.Context, string) (*streaming.RepoResult, error)
+
+// languageStatsProvider returns the languages used in a repository as of the
+// given time, or nothing if the repository had no commits yet.
+type languageStatsProvider func(ctx context.Context, repoName api.RepoName, at time.Time) ([]inventory.Lang, error)
 
 func generateComputeRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamComputeProvider, logger log.Logger) (_ []store.RecordSeriesPointArgs, err error) {
 	streamResults, err := provider(ctx, job.SearchQuery)
@@ -172,6 +211,69 @@ func generateSearchRecordingsStream(ctx context.Context, job *SearchJob, recordT
 	return recordings, nil
 }
 
+// generateLanguageStatsRecordings records the lines of code per language in
+// each repository matched by the job query, as of recordTime.
+func generateLanguageStatsRecordings(ctx context.Context, job *SearchJob, recordTime time.Time, repoProvider streamRepoProvider, statsProvider languageStatsProvider, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	repoQuery, err := querybuilder.SelectRepoQuery(querybuilder.BasicQuery(job.SearchQuery))
+	if err != nil {
+		return nil, errors.Wrap(err, "SelectRepoQuery")
+	}
+	repoResult, err := repoProvider(ctx, repoQuery.String())
+	if err != nil {
+		return nil, err
+	}
+
+	rr := *repoResult
+	if len(rr.SkippedReasons) > 0 {
+		logger.Error("repo search encountered skipped events", log.String("seriesID", job.SeriesID), log.String("reasons", fmt.Sprintf("%v", rr.SkippedReasons)), log.String("query", job.SearchQuery))
+	}
+	if len(rr.Errors) > 0 {
+		return nil, classifiedError(rr.Errors, types.HistoricalLanguageStats)
+	}
+	if rr.DidTimeout {
+		return nil, SearchTimeoutError
+	}
+	if len(rr.Alerts) > 0 {
+		return nil, errors.Errorf("streaming search: alerts: %v", rr.Alerts)
+	}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+
+	seen := make(map[api.RepoID]struct{}, len(rr.Repos))
+	for _, repo := range rr.Repos {
+		if _, ok := seen[repo.ID]; ok {
+			continue
+		}
+		seen[repo.ID] = struct{}{}
+
+		// Language statistics cover every file in the repository, so repositories with
+		// sub-repo permissions are excluded entirely.
+		subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, repo.ID)
+		if subRepoErr != nil {
+			logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", string(repo.Name)), log.Error(subRepoErr))
+			continue
+		}
+		if subRepoEnabled {
+			continue
+		}
+
+		langs, err := statsProvider(ctx, repo.Name, recordTime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "language statistics for %s", repo.Name)
+		}
+		for _, lang := range langs {
+			if lang.Name == "" || lang.TotalLines == 0 {
+				continue
+			}
+			capture := lang.Name
+			recordings = append(recordings, toRecording(job, float64(lang.TotalLines), recordTime, string(repo.Name), repo.ID, &capture)...)
+		}
+	}
+
+	return recordings, nil
+}
+
 func makeSearchHandler(provider streamSearchProvider) InsightsHandler {
 	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
 		recordings, err := generateSearchRecordingsStream(ctx, job, recordTime, provider, log.Scoped("SearchRecordingsGenerator"))
@@ -205,6 +307,16 @@ func makeMappingComputeHandler(provider streamComputeProvider) InsightsHandler {
 	}
 }
 
+func makeLanguageStatsHandler(repoProvider streamRepoProvider, statsProvider languageStatsProvider) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := generateLanguageStatsRecordings(ctx, job, recordTime, repoProvider, statsProvider, log.Scoped("LanguageStatsRecordingsGenerator"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "languageStatsHandler")
+		}
+		return recordings, nil
+	}
+}
+
 func (r *workHandler) persistRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordings []store.RecordSeriesPointArgs, recordTime time.Time) (err error) {
 	tx, err := r.insightsStore.Transact(ctx)
 	if err != nil {
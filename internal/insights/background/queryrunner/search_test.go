@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	dbtypes "github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -684,6 +685,101 @@ func TestGenerateSearchRecordingsStream(t *testing.T) {
 	})
 }
 
+func TestGenerateLanguageStatsRecordings(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dependent := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	repoProvider := func(repos ...dbtypes.MinimalRepo) streamRepoProvider {
+		return func(_ context.Context, query string) (*streaming.RepoResult, error) {
+			if !strings.Contains(query, "select:repo") {
+				return nil, errors.Newf("expected a repo query, got %q", query)
+			}
+			return &streaming.RepoResult{Repos: repos}, nil
+		}
+	}
+
+	t.Run("records lines per language", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:        "testseries1",
+			SearchQuery:     "repo:sourcegraph",
+			RecordTime:      &date,
+			PersistMode:     "record",
+			DependentFrames: []time.Time{dependent},
+		}
+
+		repos := repoProvider(
+			dbtypes.MinimalRepo{ID: 11, Name: "github.com/sourcegraph/sourcegraph"},
+			dbtypes.MinimalRepo{ID: 11, Name: "github.com/sourcegraph/sourcegraph"},
+			dbtypes.MinimalRepo{ID: 12, Name: "github.com/sourcegraph/empty"},
+		)
+		stats := func(_ context.Context, repoName api.RepoName, at time.Time) ([]inventory.Lang, error) {
+			if !at.Equal(date) {
+				return nil, errors.Newf("unexpected time %s", at)
+			}
+			if repoName != "github.com/sourcegraph/sourcegraph" {
+				return nil, nil
+			}
+			return []inventory.Lang{
+				{Name: "Go", TotalLines: 120},
+				{Name: "TypeScript", TotalLines: 80},
+				{Name: "", TotalLines: 3},
+				{Name: "Markdown", TotalLines: 0},
+			}, nil
+		}
+
+		recordings, err := generateLanguageStatsRecordings(context.Background(), &job, date, repos, stats, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC Go 120.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC TypeScript 80.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC Go 120.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC TypeScript 80.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("language statistics error", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:    "testseries1",
+			SearchQuery: "repo:sourcegraph",
+			RecordTime:  &date,
+			PersistMode: "record",
+		}
+
+		repos := repoProvider(dbtypes.MinimalRepo{ID: 11, Name: "github.com/sourcegraph/sourcegraph"})
+		stats := func(context.Context, api.RepoName, time.Time) ([]inventory.Lang, error) {
+			return nil, errors.New("archive failed")
+		}
+
+		if _, err := generateLanguageStatsRecordings(context.Background(), &job, date, repos, stats, logtest.Scoped(t)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("repo search errors are classified", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:    "testseries1",
+			SearchQuery: "repo:sourcegraph",
+			RecordTime:  &date,
+			PersistMode: "record",
+		}
+
+		repos := func(context.Context, string) (*streaming.RepoResult, error) {
+			return &streaming.RepoResult{StreamDecoderEvents: streaming.StreamDecoderEvents{Errors: []string{"invalid query"}}}, nil
+		}
+		stats := func(context.Context, api.RepoName, time.Time) ([]inventory.Lang, error) {
+			t.Fatal("language statistics should not be computed")
+			return nil, nil
+		}
+
+		_, err := generateLanguageStatsRecordings(context.Background(), &job, date, repos, stats, logtest.Scoped(t))
+		if !errors.HasType[TerminalStreamingError](err) {
+			t.Fatalf("expected terminal error, got %v", err)
+		}
+	})
+}
+
 func TestFilterRecordsingsByRepo(t *testing.T) {
 	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
 	repo1 := &dbtypes.Repo{ID: 1, Name: "repo1"}
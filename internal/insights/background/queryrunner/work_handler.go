@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/alerts"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
@@ -30,6 +31,7 @@ type workHandler struct {
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
 	limiter         *ratelimit.InstrumentedLimiter
+	alertEvaluator  *alerts.Evaluator
 	logger          log.Logger
 
 	mu          sync.RWMutex
@@ -142,7 +144,18 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	if r.alertEvaluator != nil && job.PersistMode == string(store.RecordMode) {
+		// Alerts are evaluated on a best effort basis, failing to notify must not fail the
+		// recording and cause it to be retried.
+		if err := r.alertEvaluator.EvaluateSeries(ctx, series, recordTime); err != nil {
+			r.logger.Error("evaluating insight series alerts", log.String("seriesId", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
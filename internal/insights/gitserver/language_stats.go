@@ -0,0 +1,50 @@
+package gitserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+// languageStatsCache holds the inventories computed for historical language
+// statistics. A commit's inventory never changes, so entries only expire to
+// bound the size of the cache.
+var languageStatsCache = rcache.NewWithTTL(redispool.Cache, "insights:language-stats", 7*24*60*60)
+
+// LanguageStats returns the languages used in the repository at the given
+// commit, with the number of lines written in each.
+func (g *GitCommitClient) LanguageStats(ctx context.Context, repoName api.RepoName, commitID api.CommitID) ([]inventory.Lang, error) {
+	invCtx := inventory.Context{
+		Repo:            repoName,
+		CommitID:        commitID,
+		GitServerClient: g.gitserverClient,
+		// Line counts are only computed when file contents are read.
+		ShouldSkipEnhancedLanguageDetection: false,
+		CacheGet: func(_ context.Context, key string) (inventory.Inventory, bool) {
+			b, ok := languageStatsCache.Get(key)
+			if !ok {
+				return inventory.Inventory{}, false
+			}
+			var inv inventory.Inventory
+			if err := json.Unmarshal(b, &inv); err != nil {
+				return inventory.Inventory{}, false
+			}
+			return inv, true
+		},
+		CacheSet: func(_ context.Context, key string, inv inventory.Inventory) {
+			if b, err := json.Marshal(&inv); err == nil {
+				languageStatsCache.Set(key, b)
+			}
+		},
+	}
+
+	inv, err := invCtx.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inv.Languages, nil
+}
@@ -224,7 +224,9 @@ func makeHistoricalSearchJobFunc(logger log.Logger, commitClient GitCommitClient
 		if err != nil {
 			return err, nil, nil
 		}
-		if containsRepo {
+		// Language statistics use the query to select repositories, so a repo
+		// filter is expected there.
+		if containsRepo && bctx.series.GenerationMethod != types.HistoricalLanguageStats {
 			// This maintains existing behavior that searches with a repo filter are ignored
 			return nil, nil, nil
 		}
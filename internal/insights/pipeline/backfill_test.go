@@ -190,6 +190,21 @@ func TestMakeSearchJobs(t *testing.T) {
 		Repo:        &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
 	}
 
+	backfillReqLanguageStatsRepoQuery := &BackfillRequest{
+		Series: &types.InsightSeries{
+			ID:                         1,
+			SeriesID:                   "abc",
+			Query:                      "repo:repoA",
+			CreatedAt:                  createdDate,
+			SampleIntervalUnit:         string(types.Week),
+			SampleIntervalValue:        1,
+			GeneratedFromCaptureGroups: true,
+			GenerationMethod:           types.HistoricalLanguageStats,
+		},
+		SampleTimes: sampleTimes,
+		Repo:        &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
+	}
+
 	basicCommitClient := newFakeCommitClient(&firstCommit, recentCommits)
 	// used to simulate a single call to recent commits failing
 	recentsErrorAfter := func(times int, commits []*gitdomain.Commit) func(ctx context.Context, repoName api.RepoName, target time.Time, revision string) ([]*gitdomain.Commit, error) {
@@ -320,6 +335,24 @@ func TestMakeSearchJobs(t *testing.T) {
 			name:         "Query with repo: in it",
 			commitClient: basicCommitClient, backfillReq: backfillReqRepoQuery, workers: 1, want: autogold.Expect([]string{"error occurred: false"}),
 		},
+		{
+			name:         "Language statistics query with repo: in it",
+			commitClient: basicCommitClient, backfillReq: backfillReqLanguageStatsRepoQuery, workers: 1, want: autogold.Expect([]string{
+				"job recordtime:2022-04-01T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-03-25T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-03-18T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-03-11T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-03-04T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-02-25T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-02-18T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-02-11T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-02-04T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-01-28T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-01-21T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"job recordtime:2022-01-14T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 repo:repoA repo:^testrepo$@1",
+				"error occurred: false",
+			}),
+		},
 	}
 
 	for _, tc := range testCases {
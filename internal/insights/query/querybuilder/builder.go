@@ -599,6 +599,33 @@ func RepositoryScopeQuery(query string) (BasicQuery, error) {
 	return BasicQuery(searchquery.StringHuman(modified.ToQ())), nil
 }
 
+// SelectRepoQuery sets select:repo on a user inputted query, so that it returns the
+// repositories containing a match instead of the matches themselves. It overwrites
+// any existing select: value.
+func SelectRepoQuery(query BasicQuery) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", errors.Wrap(err, "Pipeline")
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		for _, param := range basic.Parameters {
+			if param.Field != searchquery.FieldSelect {
+				modified = append(modified, param)
+			}
+		}
+		modified = append(modified, searchquery.Parameter{
+			Field:      searchquery.FieldSelect,
+			Value:      "repo",
+			Negated:    false,
+			Annotation: searchquery.Annotation{},
+		})
+		return basic.MapParameters(modified)
+	})
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func MakeQueryWithRepoFilters(repositoryCriteria string, query BasicQuery, countAll bool, defaults ...searchquery.Parameter) (BasicQuery, error) {
 	if countAll {
 		query = withCountAll(query)
@@ -686,6 +686,40 @@ func TestRepositoryScopeQuery(t *testing.T) {
 	}
 }
 
+func TestSelectRepoQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  autogold.Value
+	}{
+		{
+			"basic query",
+			"myquery repo:supergreat",
+			autogold.Expect(BasicQuery("repo:supergreat select:repo myquery")),
+		},
+		{
+			"overwrites select: values",
+			"select:file myquery",
+			autogold.Expect(BasicQuery("select:repo myquery")),
+		},
+		{
+			"compound query",
+			"(myquery repo:supergreat) or (big repo:asdf)",
+			autogold.Expect(BasicQuery("(repo:supergreat select:repo myquery OR repo:asdf select:repo big)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SelectRepoQuery(BasicQuery(test.input))
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
 func TestWithCount(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -174,7 +174,9 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 }
 
 func parseQuery(series types.InsightSeries) (query.Plan, error) {
-	if series.GeneratedFromCaptureGroups {
+	// Language statistics series expand like capture group series, but their
+	// query is a plain search query.
+	if series.GeneratedFromCaptureGroups && series.GenerationMethod != types.HistoricalLanguageStats {
 		seriesQuery, err := compute.Parse(series.Query)
 		if err != nil {
 			return nil, errors.Wrap(err, "compute.Parse")
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore stores the alert rules defined on insight series.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore. Shareable store as the underlying basestore.Store.
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+var ErrAlertSeriesNotFound = errors.New("insight series not found")
+
+// CreateAlert creates the alert and its actions on the series with the alert's
+// SeriesID.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (_ types.InsightSeriesAlert, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if alert.CreatedAt.IsZero() {
+		alert.CreatedAt = s.Now()
+	}
+	row := tx.QueryRow(ctx, sqlf.Sprintf(createAlertSql,
+		alert.UserID,
+		alert.Kind,
+		alert.Threshold,
+		alert.Intervals,
+		alert.CreatedAt,
+		alert.SeriesID,
+	))
+	if err := row.Scan(&alert.ID, &alert.InsightSeriesID); err != nil {
+		if err == sql.ErrNoRows {
+			return types.InsightSeriesAlert{}, ErrAlertSeriesNotFound
+		}
+		return types.InsightSeriesAlert{}, errors.Wrap(err, "creating alert")
+	}
+
+	for i, action := range alert.Actions {
+		var url *string
+		if action.URL != "" {
+			url = &action.URL
+		}
+		row := tx.QueryRow(ctx, sqlf.Sprintf(createAlertActionSql, alert.ID, action.Type, url))
+		if err := row.Scan(&alert.Actions[i].ID); err != nil {
+			return types.InsightSeriesAlert{}, errors.Wrap(err, "creating alert action")
+		}
+	}
+	return alert, nil
+}
+
+const createAlertSql = `
+INSERT INTO insight_series_alerts (series_id, user_id, kind, threshold, intervals, created_at)
+SELECT id, %s, %s, %s, %s, %s FROM insight_series WHERE series_id = %s AND deleted_at IS NULL
+RETURNING id, series_id
+`
+
+const createAlertActionSql = `
+INSERT INTO insight_series_alert_actions (alert_id, type, url) VALUES (%s, %s, %s) RETURNING id
+`
+
+type AlertQueryArgs struct {
+	ID              int
+	SeriesID        string
+	InsightSeriesID int
+	UserID          int32
+}
+
+// GetAlerts returns the alerts matching args, with their actions.
+func (s *AlertStore) GetAlerts(ctx context.Context, args AlertQueryArgs) (_ []types.InsightSeriesAlert, err error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("s.deleted_at IS NULL")}
+	if args.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.id = %s", args.ID))
+	}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("s.series_id = %s", args.SeriesID))
+	}
+	if args.InsightSeriesID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.series_id = %s", args.InsightSeriesID))
+	}
+	if args.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.user_id = %s", args.UserID))
+	}
+
+	alerts, err := scanAlerts(s.Query(ctx, sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "AND"))))
+	if err != nil || len(alerts) == 0 {
+		return alerts, err
+	}
+
+	ids := make([]int, 0, len(alerts))
+	byID := make(map[int]*types.InsightSeriesAlert, len(alerts))
+	for i := range alerts {
+		ids = append(ids, alerts[i].ID)
+		byID[alerts[i].ID] = &alerts[i]
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(getAlertActionsSql, pq.Array(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+	for rows.Next() {
+		var alertID int
+		var action types.InsightSeriesAlertAction
+		var url sql.NullString
+		if err := rows.Scan(&action.ID, &alertID, &action.Type, &url); err != nil {
+			return nil, err
+		}
+		action.URL = url.String
+		alert := byID[alertID]
+		alert.Actions = append(alert.Actions, action)
+	}
+	return alerts, err
+}
+
+const getAlertsSql = `
+SELECT a.id, a.series_id, s.series_id, a.user_id, a.kind, a.threshold, a.intervals, a.last_recording_time, a.created_at
+FROM insight_series_alerts a
+JOIN insight_series s ON s.id = a.series_id
+WHERE %s
+ORDER BY a.id
+`
+
+const getAlertActionsSql = `
+SELECT id, alert_id, type, url FROM insight_series_alert_actions WHERE alert_id = ANY(%s) ORDER BY id
+`
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.InsightSeriesAlert, 0)
+	for rows.Next() {
+		var alert types.InsightSeriesAlert
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.InsightSeriesID,
+			&alert.SeriesID,
+			&alert.UserID,
+			&alert.Kind,
+			&alert.Threshold,
+			&alert.Intervals,
+			&alert.LastRecordingTime,
+			&alert.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, alert)
+	}
+	return results, nil
+}
+
+// DeleteAlert deletes the alert with the given ID and its actions.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id))
+}
+
+const deleteAlertSql = `
+DELETE FROM insight_series_alerts WHERE id = %s
+`
+
+// ClaimRecording marks the alert as evaluated for the recording at the given
+// time. It returns false if the alert was already evaluated for this or a later
+// recording, in which case the caller must not notify again.
+func (s *AlertStore) ClaimRecording(ctx context.Context, id int, recordingTime time.Time) (bool, error) {
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(claimRecordingSql, recordingTime, id, recordingTime)))
+	return ok, err
+}
+
+const claimRecordingSql = `
+UPDATE insight_series_alerts SET last_recording_time = %s
+WHERE id = %s AND (last_recording_time IS NULL OR last_recording_time < %s)
+RETURNING id
+`
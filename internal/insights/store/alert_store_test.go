@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestAlertStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	insightStore := NewInsightStore(insightsDB)
+	alertStore := NewAlertStore(insightsDB)
+	alertStore.Now = func() time.Time { return now }
+
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query-1",
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		Enabled:            true,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.Search,
+	})
+	require.NoError(t, err)
+
+	t.Run("unknown series", func(t *testing.T) {
+		_, err := alertStore.CreateAlert(ctx, types.InsightSeriesAlert{
+			SeriesID:  "unknown",
+			UserID:    1,
+			Kind:      types.AlertAnyIncrease,
+			Intervals: 1,
+		})
+		require.ErrorIs(t, err, ErrAlertSeriesNotFound)
+	})
+
+	created, err := alertStore.CreateAlert(ctx, types.InsightSeriesAlert{
+		SeriesID:  "series1",
+		UserID:    1,
+		Kind:      types.AlertPercentChange,
+		Threshold: 25,
+		Intervals: 2,
+		Actions: []types.InsightSeriesAlertAction{
+			{Type: types.AlertActionEmail},
+			{Type: types.AlertActionWebhook, URL: "https://example.com/hook"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, series.ID, created.InsightSeriesID)
+	require.Equal(t, now, created.CreatedAt)
+
+	t.Run("get", func(t *testing.T) {
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{SeriesID: "series1", UserID: 1})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		got[0].CreatedAt = got[0].CreatedAt.UTC()
+		require.Equal(t, created, got[0])
+
+		got, err = alertStore.GetAlerts(ctx, AlertQueryArgs{InsightSeriesID: series.ID, UserID: 2})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("claim recording", func(t *testing.T) {
+		claimed, err := alertStore.ClaimRecording(ctx, created.ID, now)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = alertStore.ClaimRecording(ctx, created.ID, now)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		claimed, err = alertStore.ClaimRecording(ctx, created.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{ID: created.ID})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, now, got[0].LastRecordingTime.UTC())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, alertStore.DeleteAlert(ctx, created.ID))
+
+		got, err := alertStore.GetAlerts(ctx, AlertQueryArgs{ID: created.ID})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+}
@@ -51,6 +51,9 @@ type InsightQueryArgs struct {
 	UserIDs     []int
 	OrgIDs      []int
 	DashboardID int
+	// SeriesID limits the results to insights that include the data series
+	// with the given series ID.
+	SeriesID string
 
 	After    string
 	Limit    int
@@ -119,6 +122,9 @@ func (s *InsightStore) GetAll(ctx context.Context, args InsightQueryArgs) ([]typ
 	if args.DashboardID > 0 {
 		preds = append(preds, sqlf.Sprintf("iv.id in (select insight_view_id from dashboard_insight_view where dashboard_id = %s)", args.DashboardID))
 	}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("i.series_id = %s", args.SeriesID))
+	}
 	if args.After != "" {
 		preds = append(preds, sqlf.Sprintf("iv.unique_id > %s", args.After))
 	}
@@ -820,6 +826,9 @@ type MatchSeriesArgs struct {
 	StepIntervalValue         int
 	GenerateFromCaptureGroups bool
 	GroupBy                   *string
+	// GenerationMethod only matches series with the given generation method
+	// if set.
+	GenerationMethod types.GenerationMethod
 }
 
 func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesArgs) (_ types.InsightSeries, found bool, _ error) {
@@ -827,9 +836,13 @@ func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesA
 	if args.GroupBy != nil {
 		groupByClause = sqlf.Sprintf("group_by = %s", *args.GroupBy)
 	}
+	generationMethodClause := sqlf.Sprintf("TRUE")
+	if args.GenerationMethod != "" {
+		generationMethodClause = sqlf.Sprintf("generation_method = %s", args.GenerationMethod)
+	}
 	where := sqlf.Sprintf(
-		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s",
-		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause,
+		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s AND %s",
+		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause, generationMethodClause,
 	)
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, where)
@@ -848,6 +848,21 @@ func TestGetAll(t *testing.T) {
 			t.Errorf("unexpected insight view series want/got: %s", diff)
 		}
 	})
+	t.Run("filter by series id", func(t *testing.T) {
+		got, err := store.GetAll(ctx, InsightQueryArgs{SeriesID: "series-id-2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uniqueIDs := make(map[string]struct{})
+		for _, s := range got {
+			uniqueIDs[s.UniqueID] = struct{}{}
+		}
+		// View 4 also includes the series, but it isn't visible.
+		want := map[string]struct{}{"b": {}, "d": {}}
+		if diff := cmp.Diff(want, uniqueIDs); diff != "" {
+			t.Errorf("unexpected insight views want/got: %s", diff)
+		}
+	})
 	t.Run("returns expected number of samples", func(t *testing.T) {
 		// Set the series_num_samples value
 		numSamples := int32(50)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/lib/pq"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -512,6 +513,28 @@ func countDataQuery(opts CountDataOpts) *sqlf.Query {
 	)
 }
 
+// SeriesRepoIDs returns the IDs of the repositories with a non-zero value in
+// the most recent recording or snapshot of the series with the given series
+// ID. Repository permissions are not enforced, callers must filter the result.
+func (s *Store) SeriesRepoIDs(ctx context.Context, seriesID string) ([]api.RepoID, error) {
+	return scanRepoIDs(s.Store.Query(ctx, sqlf.Sprintf(seriesRepoIDsFmtstr, seriesID, seriesID)))
+}
+
+var scanRepoIDs = basestore.NewSliceScanner(basestore.ScanAny[api.RepoID])
+
+const seriesRepoIDsFmtstr = `
+WITH points AS (
+	SELECT repo_id, time, value FROM series_points WHERE series_id = %s
+	UNION ALL
+	SELECT repo_id, time, value FROM series_points_snapshots WHERE series_id = %s
+)
+SELECT DISTINCT repo_id FROM points
+WHERE repo_id IS NOT NULL
+	AND value > 0
+	AND time = (SELECT MAX(time) FROM points)
+ORDER BY repo_id
+`
+
 func (s *Store) DeleteSnapshots(ctx context.Context, series *types.InsightSeries) error {
 	if series == nil {
 		return errors.New("invalid input for Delete Snapshots")
@@ -715,6 +738,23 @@ const getOffsetNRecordingTimeSql = `
 select recording_time from insight_series_recording_times where %s order by recording_time desc offset %s limit 1
 `
 
+// LastRecordingTimes returns up to n of the most recent recording times of the series that are not
+// snapshots, and not after the given time. The times are in ascending order.
+func (s *Store) LastRecordingTimes(ctx context.Context, seriesId int, to time.Time, n int) ([]time.Time, error) {
+	times, err := basestore.ScanTimes(s.Query(ctx, sqlf.Sprintf(getLastRecordingTimesSql, seriesId, to.UTC(), n)))
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(times)
+	return times, nil
+}
+
+const getLastRecordingTimesSql = `
+select recording_time from insight_series_recording_times
+where insight_series_id = %s and snapshot is false and recording_time <= %s
+order by recording_time desc limit %s
+`
+
 // RecordSeriesPointsAndRecordingTimes is a wrapper around the RecordSeriesPoints and SetInsightSeriesRecordingTimes
 // functions. It makes the assumption that this is called per-series, so all the points will share the same SeriesID.
 // Use this in favour of RecordSeriesPoints if recording times are known.
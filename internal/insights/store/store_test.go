@@ -220,6 +220,63 @@ func TestCountData(t *testing.T) {
 	autogold.Expect(5).Equal(t, numDataPoints)
 }
 
+func TestSeriesRepoIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	previous := time.Date(2021, time.August, 10, 10, 0, 0, 0, time.UTC)
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+
+	record := func(seriesID string, at time.Time, repoID api.RepoID, value float64, mode PersistMode) RecordSeriesPointArgs {
+		return RecordSeriesPointArgs{
+			SeriesID:    seriesID,
+			Point:       SeriesPoint{Time: at, Value: value},
+			RepoName:    optionalString("repo" + strconv.Itoa(int(repoID))),
+			RepoID:      optionalRepoID(repoID),
+			PersistMode: mode,
+		}
+	}
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		// Only part of an older recording.
+		record("one", previous, 1, 3, RecordMode),
+		record("one", previous, 2, 1, RecordMode),
+		record("one", current, 2, 2, RecordMode),
+		record("one", current, 3, 0, RecordMode),
+		record("one", current, 4, 1, SnapshotMode),
+		record("two", current, 5, 1, RecordMode),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := store.SeriesRepoIDs(ctx, "one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoID{2, 4}, have); diff != "" {
+		t.Errorf("unexpected repo IDs (-want +got):\n%s", diff)
+	}
+
+	have, err = store.SeriesRepoIDs(ctx, "unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 0 {
+		t.Errorf("unexpected repo IDs for unknown series: %v", have)
+	}
+}
+
 func TestRecordSeriesPoints(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
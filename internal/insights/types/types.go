@@ -131,6 +131,9 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	// HistoricalLanguageStats records the lines of code per language in the
+	// repositories matching the series query, with the language as capture.
+	HistoricalLanguageStats GenerationMethod = "historical-language-stats"
 )
 
 type Dashboard struct {
@@ -231,3 +234,48 @@ const (
 const (
 	NO_REPO_METADATA_TEXT = "No metadata"
 )
+
+// AlertKind is the condition under which an alert on an insight series triggers.
+type AlertKind string
+
+const (
+	// AlertAbsoluteThreshold triggers when the latest value crosses above the threshold.
+	AlertAbsoluteThreshold AlertKind = "absolute-threshold"
+	// AlertPercentChange triggers when the latest value changed by at least the threshold
+	// percent compared to the value a number of recordings earlier. Negative thresholds
+	// trigger on decreases.
+	AlertPercentChange AlertKind = "percent-change"
+	// AlertAnyIncrease triggers when the latest value is greater than the previous one.
+	AlertAnyIncrease AlertKind = "any-increase"
+)
+
+// AlertActionType is the kind of notification sent when an alert triggers.
+type AlertActionType string
+
+const (
+	AlertActionEmail   AlertActionType = "email"
+	AlertActionSlack   AlertActionType = "slack"
+	AlertActionWebhook AlertActionType = "webhook"
+)
+
+// InsightSeriesAlert is an alert rule on an insight series, evaluated after each
+// recording of the series.
+type InsightSeriesAlert struct {
+	ID                int
+	InsightSeriesID   int
+	SeriesID          string
+	UserID            int32
+	Kind              AlertKind
+	Threshold         float64
+	Intervals         int
+	LastRecordingTime *time.Time
+	CreatedAt         time.Time
+	Actions           []InsightSeriesAlertAction
+}
+
+type InsightSeriesAlertAction struct {
+	ID   int
+	Type AlertActionType
+	// URL is empty for email actions, which notify the owner of the alert.
+	URL string
+}
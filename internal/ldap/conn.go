@@ -0,0 +1,261 @@
+// Package ldap authenticates users against an LDAP directory and looks up
+// their groups. The protocol is implemented by github.com/go-ldap/ldap, this
+// package only adds the context deadlines and the result handling we need.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultTimeout is used for requests whose context has no deadline.
+const defaultTimeout = 30 * time.Second
+
+// Scope is the scope of a search.
+type Scope int
+
+const (
+	ScopeBaseObject   Scope = ldap.ScopeBaseObject
+	ScopeSingleLevel  Scope = ldap.ScopeSingleLevel
+	ScopeWholeSubtree Scope = ldap.ScopeWholeSubtree
+)
+
+// IsInvalidCredentials reports whether err is caused by a failed bind.
+func IsInvalidCredentials(err error) bool {
+	return hasResultCode(err, ldap.LDAPResultInvalidCredentials)
+}
+
+// IsNoSuchObject reports whether err is caused by searching a base DN that
+// does not exist.
+func IsNoSuchObject(err error) bool {
+	return hasResultCode(err, ldap.LDAPResultNoSuchObject)
+}
+
+// hasResultCode is like ldap.IsErrorWithCode, but also finds wrapped errors.
+func hasResultCode(err error, code uint16) bool {
+	var e *ldap.Error
+	return errors.As(err, &e) && e.ResultCode == code
+}
+
+// Conn is a connection to an LDAP server. Requests are sent one at a time, a
+// Conn is safe for concurrent use.
+type Conn struct {
+	mu   sync.Mutex
+	conn *ldap.Conn
+	// serverName is the host name of the LDAP URL, which the certificate of
+	// the server is verified against after StartTLS.
+	serverName string
+}
+
+// Dial connects to the LDAP server at rawURL, which must have the ldap or
+// ldaps scheme. The default ports are 389 and 636. tlsConfig is used for
+// ldaps URLs and may be nil.
+func Dial(ctx context.Context, rawURL string, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing LDAP URL")
+	}
+
+	host := u.Host
+	var d net.Dialer
+	var conn net.Conn
+	switch u.Scheme {
+	case "ldap":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "389")
+		}
+		conn, err = d.DialContext(ctx, "tcp", host)
+	case "ldaps":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+		tlsDialer := tls.Dialer{NetDialer: &d, Config: withServerName(tlsConfig, u.Hostname())}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", host)
+	default:
+		return nil, errors.Newf("unsupported LDAP URL scheme %q, expected ldap or ldaps", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := ldap.NewConn(conn, u.Scheme == "ldaps")
+	c.Start()
+	return &Conn{conn: c, serverName: u.Hostname()}, nil
+}
+
+func withServerName(cfg *tls.Config, serverName string) *tls.Config {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	} else {
+		cfg = cfg.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = serverName
+	}
+	return cfg
+}
+
+// setTimeout applies the deadline of ctx to the next request. It must be
+// called with c.mu held.
+func (c *Conn) setTimeout(ctx context.Context) {
+	timeout := defaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	c.conn.SetTimeout(timeout)
+}
+
+// StartTLS upgrades the connection to TLS with the StartTLS extended
+// operation. It must be called before any bind. The certificate of the server
+// is verified against the host name of the URL passed to Dial, unless
+// tlsConfig sets a ServerName.
+func (c *Conn) StartTLS(ctx context.Context, tlsConfig *tls.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setTimeout(ctx)
+	if err := c.conn.StartTLS(withServerName(tlsConfig, c.serverName)); err != nil {
+		return errors.Wrap(err, "StartTLS")
+	}
+	return nil
+}
+
+// Bind authenticates the connection as dn with a simple bind.
+//
+// 🚨 SECURITY: An empty password is an "unauthenticated bind" which servers
+// accept for any dn, so we refuse it here instead of relying on callers.
+func (c *Conn) Bind(ctx context.Context, dn, password string) error {
+	if password == "" {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("empty password"))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setTimeout(ctx)
+	if err := c.conn.Bind(dn, password); err != nil {
+		return errors.Wrap(err, "bind")
+	}
+	return nil
+}
+
+// SearchRequest is the subset of the search parameters of RFC 4511 section
+// 4.5.1 we support.
+type SearchRequest struct {
+	BaseDN string
+	Scope  Scope
+	Filter string
+	// Attributes to return. Empty returns all user attributes.
+	Attributes []string
+	// SizeLimit is the maximum number of entries to return, 0 means no limit.
+	SizeLimit int
+}
+
+// Search performs a search and returns the matching entries. Referrals are
+// ignored.
+func (c *Conn) Search(ctx context.Context, req SearchRequest) ([]*Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setTimeout(ctx)
+	res, err := c.conn.Search(ldap.NewSearchRequest(
+		req.BaseDN,
+		int(req.Scope),
+		ldap.NeverDerefAliases,
+		req.SizeLimit,
+		0, // no time limit
+		false,
+		normalizeFilter(req.Filter),
+		req.Attributes,
+		nil,
+	))
+	if err != nil {
+		// Return what we got when we hit our own size limit.
+		if !hasResultCode(err, ldap.LDAPResultSizeLimitExceeded) || req.SizeLimit == 0 || res == nil {
+			return nil, errors.Wrap(err, "search")
+		}
+	}
+
+	entries := make([]*Entry, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		entry := &Entry{DN: e.DN}
+		for _, a := range e.Attributes {
+			entry.Attributes = append(entry.Attributes, &Attribute{Name: a.Name, Values: a.Values})
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Close sends an unbind request and closes the connection.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn.SetTimeout(time.Second)
+	return c.conn.Unbind()
+}
+
+// normalizeFilter adds the outer parentheses of a filter, which are optional
+// in practice but required by RFC 4515.
+func normalizeFilter(filter string) string {
+	filter = strings.TrimSpace(filter)
+	if filter != "" && filter[0] != '(' {
+		filter = "(" + filter + ")"
+	}
+	return filter
+}
+
+// ValidateFilter returns an error if filter is not a valid search filter.
+func ValidateFilter(filter string) error {
+	if _, err := ldap.CompileFilter(normalizeFilter(filter)); err != nil {
+		return errors.Wrapf(err, "invalid filter %q", filter)
+	}
+	return nil
+}
+
+// EscapeFilter escapes s for use as an assertion value in a filter string, as
+// described in RFC 4515 section 3.
+func EscapeFilter(s string) string {
+	return ldap.EscapeFilter(s)
+}
+
+// Entry is an entry returned by a search.
+type Entry struct {
+	DN         string
+	Attributes []*Attribute
+}
+
+// Attribute is an attribute of an Entry.
+type Attribute struct {
+	Name   string
+	Values []string
+}
+
+// Values returns the values of the attribute name, which is
+// case-insensitive.
+func (e *Entry) Values(name string) []string {
+	for _, a := range e.Attributes {
+		if strings.EqualFold(a.Name, name) {
+			return a.Values
+		}
+	}
+	return nil
+}
+
+// Value returns the first value of the attribute name, or "" if it has none.
+func (e *Entry) Value(name string) string {
+	if values := e.Values(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,317 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ServiceType is the service type of the external accounts of users who
+// signed in with an LDAP auth provider.
+const ServiceType = "ldap"
+
+// Defaults for the optional fields of schema.LDAPAuthProvider, matching the
+// defaults documented in the site configuration schema.
+const (
+	DefaultUserFilter           = "(uid={username})"
+	DefaultUsernameAttribute    = "uid"
+	DefaultEmailAttribute       = "mail"
+	DefaultDisplayNameAttribute = "cn"
+	DefaultGroupFilter          = "(|(member={dn})(uniqueMember={dn})(memberUid={username}))"
+	DefaultGroupNameAttribute   = "cn"
+)
+
+var (
+	// ErrInvalidCredentials is returned by Authenticate if the user does not
+	// exist or the password is wrong. We don't distinguish the two cases to
+	// not reveal which usernames exist.
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrUserNotFound is returned by LookupUser if no entry matches the
+	// username.
+	ErrUserNotFound = errors.New("user not found in LDAP directory")
+)
+
+// User is a user entry of the directory.
+type User struct {
+	DN          string
+	Username    string
+	Email       string
+	DisplayName string
+	Groups      []Group
+}
+
+// Group is a group a User is a member of.
+type Group struct {
+	DN   string
+	Name string
+}
+
+// Matches reports whether nameOrDN is the name or the DN of g. Both are
+// compared case-insensitively, like LDAP does.
+func (g Group) Matches(nameOrDN string) bool {
+	return strings.EqualFold(g.Name, nameOrDN) || (g.DN != "" && strings.EqualFold(normalizeDN(g.DN), normalizeDN(nameOrDN)))
+}
+
+// InAnyGroup reports whether u is a member of any of groups, which are names
+// or DNs.
+func (u *User) InAnyGroup(groups []string) bool {
+	for _, g := range u.Groups {
+		for _, want := range groups {
+			if g.Matches(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeDN removes insignificant whitespace around the RDNs of dn.
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Directory looks up and authenticates users in the LDAP directory described
+// by an LDAP auth provider config.
+type Directory struct {
+	c         schema.LDAPAuthProvider
+	tlsConfig *tls.Config
+}
+
+// NewDirectory returns a Directory for c, with the defaults applied to the
+// optional fields. It returns an error if c is invalid.
+func NewDirectory(c *schema.LDAPAuthProvider) (*Directory, error) {
+	d := &Directory{c: WithDefaults(c)}
+
+	if !strings.HasPrefix(d.c.Url, "ldap://") && !strings.HasPrefix(d.c.Url, "ldaps://") {
+		return nil, errors.Newf("invalid LDAP URL %q, expected an ldap:// or ldaps:// URL", d.c.Url)
+	}
+	if d.c.StartTLS && strings.HasPrefix(d.c.Url, "ldaps://") {
+		return nil, errors.New("startTLS can't be used with an ldaps:// URL")
+	}
+	if d.c.UserBaseDN == "" {
+		return nil, errors.New("userBaseDN must be set")
+	}
+	if err := ValidateFilter(expand(d.c.UserFilter, map[string]string{"username": "x"})); err != nil {
+		return nil, errors.Wrap(err, "userFilter")
+	}
+	if err := ValidateFilter(expand(d.c.GroupFilter, map[string]string{"username": "x", "dn": "x"})); err != nil {
+		return nil, errors.Wrap(err, "groupFilter")
+	}
+
+	if d.c.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(d.c.Certificate)) {
+			return nil, errors.New("certificate is not a valid PEM encoded certificate")
+		}
+		d.tlsConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return d, nil
+}
+
+// WithDefaults returns a copy of c with the defaults applied to unset
+// optional fields.
+func WithDefaults(c *schema.LDAPAuthProvider) schema.LDAPAuthProvider {
+	withDefault := func(s *string, def string) {
+		if *s == "" {
+			*s = def
+		}
+	}
+
+	cc := *c
+	withDefault(&cc.UserFilter, DefaultUserFilter)
+	withDefault(&cc.UsernameAttribute, DefaultUsernameAttribute)
+	withDefault(&cc.EmailAttribute, DefaultEmailAttribute)
+	withDefault(&cc.DisplayNameAttribute, DefaultDisplayNameAttribute)
+	withDefault(&cc.GroupFilter, DefaultGroupFilter)
+	withDefault(&cc.GroupNameAttribute, DefaultGroupNameAttribute)
+	return cc
+}
+
+// Authenticate verifies password for username and returns the user. It
+// returns ErrInvalidCredentials if the username does not exist or the password
+// is wrong.
+func (d *Directory) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	// 🚨 SECURITY: an empty password would be an unauthenticated bind, which
+	// succeeds for any DN.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := d.findUser(ctx, conn, username)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: verify the password by binding as the user. We use a
+	// separate connection so that the group lookup below still runs as the
+	// service account.
+	userConn, err := d.dialTLS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer userConn.Close()
+	if err := userConn.Bind(ctx, entry.DN, password); err != nil {
+		if IsInvalidCredentials(err) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	return d.toUser(ctx, conn, entry)
+}
+
+// LookupUser returns the user with username without verifying any
+// credentials. It is used to sync the groups of users in the background.
+func (d *Directory) LookupUser(ctx context.Context, username string) (*User, error) {
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := d.findUser(ctx, conn, username)
+	if err != nil {
+		return nil, err
+	}
+	return d.toUser(ctx, conn, entry)
+}
+
+// dialTLS dials the server and upgrades the connection with StartTLS if
+// configured.
+func (d *Directory) dialTLS(ctx context.Context) (*Conn, error) {
+	conn, err := Dial(ctx, d.c.Url, d.tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	if d.c.StartTLS {
+		if err := conn.StartTLS(ctx, d.tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// connect returns a connection bound as the service account, or an
+// anonymous connection if no service account is configured.
+func (d *Directory) connect(ctx context.Context) (*Conn, error) {
+	conn, err := d.dialTLS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if d.c.BindDN != "" {
+		if err := conn.Bind(ctx, d.c.BindDN, d.c.BindPassword); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "binding as the service account")
+		}
+	}
+	return conn, nil
+}
+
+func (d *Directory) findUser(ctx context.Context, conn *Conn, username string) (*Entry, error) {
+	attributes := []string{d.c.UsernameAttribute, d.c.EmailAttribute, d.c.DisplayNameAttribute}
+	if d.c.GroupBaseDN == "" {
+		attributes = append(attributes, "memberOf")
+	}
+
+	entries, err := conn.Search(ctx, SearchRequest{
+		BaseDN:     d.c.UserBaseDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     expand(d.c.UserFilter, map[string]string{"username": EscapeFilter(username)}),
+		Attributes: attributes,
+		// We only need to know whether there is more than one.
+		SizeLimit: 2,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for user")
+	}
+
+	switch len(entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return entries[0], nil
+	default:
+		// 🚨 SECURITY: we don't know which entry the user means, so we
+		// must not pick one.
+		return nil, errors.Newf("userFilter matches more than one entry for username %q", username)
+	}
+}
+
+func (d *Directory) toUser(ctx context.Context, conn *Conn, entry *Entry) (*User, error) {
+	u := &User{
+		DN:          entry.DN,
+		Username:    entry.Value(d.c.UsernameAttribute),
+		Email:       entry.Value(d.c.EmailAttribute),
+		DisplayName: entry.Value(d.c.DisplayNameAttribute),
+	}
+	if u.Username == "" {
+		return nil, errors.Newf("entry %q has no %s attribute", entry.DN, d.c.UsernameAttribute)
+	}
+
+	if d.c.GroupBaseDN == "" {
+		for _, dn := range entry.Values("memberOf") {
+			u.Groups = append(u.Groups, Group{DN: dn, Name: firstRDNValue(dn)})
+		}
+		return u, nil
+	}
+
+	groups, err := conn.Search(ctx, SearchRequest{
+		BaseDN: d.c.GroupBaseDN,
+		Scope:  ScopeWholeSubtree,
+		Filter: expand(d.c.GroupFilter, map[string]string{
+			"dn":       EscapeFilter(entry.DN),
+			"username": EscapeFilter(u.Username),
+		}),
+		Attributes: []string{d.c.GroupNameAttribute},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for groups")
+	}
+	for _, g := range groups {
+		name := g.Value(d.c.GroupNameAttribute)
+		if name == "" {
+			name = firstRDNValue(g.DN)
+		}
+		u.Groups = append(u.Groups, Group{DN: g.DN, Name: name})
+	}
+	return u, nil
+}
+
+// expand replaces the {name} placeholders in s with the values of vars.
+func expand(s string, vars map[string]string) string {
+	for k, v := range vars {
+		s = strings.ReplaceAll(s, "{"+k+"}", v)
+	}
+	return s
+}
+
+// firstRDNValue returns the value of the first RDN of dn, for example "eng"
+// for "cn=eng,ou=groups,dc=example,dc=com".
+func firstRDNValue(dn string) string {
+	rdn, _, _ := strings.Cut(dn, ",")
+	_, value, ok := strings.Cut(rdn, "=")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,191 @@
+package ldap_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/ldap/ldaptest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestServer(t *testing.T) *ldaptest.Server {
+	s := ldaptest.New(t)
+	s.AddEntry("dc=example,dc=com", "", map[string][]string{"objectClass": {"domain"}})
+	s.AddEntry("cn=admin,dc=example,dc=com", "adminpw", map[string][]string{"cn": {"admin"}})
+	s.AddEntry("ou=people,dc=example,dc=com", "", map[string][]string{"ou": {"people"}})
+	s.AddEntry("ou=groups,dc=example,dc=com", "", map[string][]string{"ou": {"groups"}})
+	s.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alicepw", map[string][]string{
+		"uid":      {"alice"},
+		"mail":     {"alice@example.com"},
+		"cn":       {"Alice Smith"},
+		"memberOf": {"cn=eng,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+	})
+	s.AddEntry("uid=bob,ou=people,dc=example,dc=com", "bobpw", map[string][]string{
+		"uid": {"bob"},
+		"cn":  {"Bob"},
+	})
+	s.AddEntry("cn=eng,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"cn":     {"eng"},
+		"member": {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+	})
+	s.AddEntry("cn=ops,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"cn":        {"ops"},
+		"memberUid": {"bob"},
+	})
+	return s
+}
+
+func TestDirectory_Authenticate(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	d, err := ldap.NewDirectory(&schema.LDAPAuthProvider{
+		Url:          s.URL,
+		BindDN:       "cn=admin,dc=example,dc=com",
+		BindPassword: "adminpw",
+		UserBaseDN:   "ou=people,dc=example,dc=com",
+	})
+	require.NoError(t, err)
+
+	t.Run("success with memberOf groups", func(t *testing.T) {
+		u, err := d.Authenticate(ctx, "alice", "alicepw")
+		require.NoError(t, err)
+		require.Equal(t, &ldap.User{
+			DN:          "uid=alice,ou=people,dc=example,dc=com",
+			Username:    "alice",
+			Email:       "alice@example.com",
+			DisplayName: "Alice Smith",
+			Groups: []ldap.Group{
+				{DN: "cn=eng,ou=groups,dc=example,dc=com", Name: "eng"},
+				{DN: "cn=admins,ou=groups,dc=example,dc=com", Name: "admins"},
+			},
+		}, u)
+
+		require.True(t, u.InAnyGroup([]string{"ENG"}))
+		require.True(t, u.InAnyGroup([]string{"cn=admins, ou=groups, dc=example, dc=com"}))
+		require.False(t, u.InAnyGroup([]string{"ops"}))
+	})
+
+	for name, tc := range map[string]struct{ username, password string }{
+		"wrong password":   {"alice", "bobpw"},
+		"empty password":   {"alice", ""},
+		"unknown user":     {"carol", "alicepw"},
+		"filter injection": {"*", "alicepw"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := d.Authenticate(ctx, tc.username, tc.password)
+			require.ErrorIs(t, err, ldap.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestDirectory_LookupUser_GroupSearch(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	// Without a service account, searches are anonymous.
+	d, err := ldap.NewDirectory(&schema.LDAPAuthProvider{
+		Url:         s.URL,
+		UserBaseDN:  "ou=people,dc=example,dc=com",
+		GroupBaseDN: "ou=groups,dc=example,dc=com",
+	})
+	require.NoError(t, err)
+
+	u, err := d.LookupUser(ctx, "bob")
+	require.NoError(t, err)
+	require.Equal(t, []ldap.Group{
+		{DN: "cn=eng,ou=groups,dc=example,dc=com", Name: "eng"},
+		{DN: "cn=ops,ou=groups,dc=example,dc=com", Name: "ops"},
+	}, u.Groups)
+
+	_, err = d.LookupUser(ctx, "carol")
+	require.ErrorIs(t, err, ldap.ErrUserNotFound)
+}
+
+func TestDirectory_AmbiguousUser(t *testing.T) {
+	s := newTestServer(t)
+
+	d, err := ldap.NewDirectory(&schema.LDAPAuthProvider{
+		Url:        s.URL,
+		UserBaseDN: "ou=people,dc=example,dc=com",
+		UserFilter: "(|(uid={username})(objectClass=*))",
+	})
+	require.NoError(t, err)
+
+	_, err = d.Authenticate(context.Background(), "alice", "alicepw")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ldap.ErrInvalidCredentials)
+}
+
+func TestDirectory_StartTLS(t *testing.T) {
+	s := newTestServer(t)
+
+	// The certificate is only valid for localhost, so the handshake fails
+	// unless it is verified against the host name of the URL rather than the
+	// address we connected to.
+	certPEM, cert := newLocalhostCertificate(t)
+	s.EnableStartTLS(&tls.Config{Certificates: []tls.Certificate{cert}})
+
+	d, err := ldap.NewDirectory(&schema.LDAPAuthProvider{
+		Url:         strings.Replace(s.URL, "127.0.0.1", "localhost", 1),
+		StartTLS:    true,
+		Certificate: certPEM,
+		UserBaseDN:  "ou=people,dc=example,dc=com",
+	})
+	require.NoError(t, err)
+
+	u, err := d.Authenticate(context.Background(), "alice", "alicepw")
+	require.NoError(t, err)
+	require.Equal(t, "alice", u.Username)
+}
+
+// newLocalhostCertificate returns a self-signed certificate for the DNS name
+// localhost, both PEM encoded and ready to be served.
+func newLocalhostCertificate(t *testing.T) (string, tls.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return certPEM, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestNewDirectory_Invalid(t *testing.T) {
+	for name, c := range map[string]schema.LDAPAuthProvider{
+		"bad scheme":       {Url: "http://ldap.example.com", UserBaseDN: "dc=example,dc=com"},
+		"startTLS + ldaps": {Url: "ldaps://ldap.example.com", StartTLS: true, UserBaseDN: "dc=example,dc=com"},
+		"no userBaseDN":    {Url: "ldap://ldap.example.com"},
+		"bad userFilter":   {Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com", UserFilter: "(uid={username}"},
+		"bad certificate":  {Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com", Certificate: "not a cert"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ldap.NewDirectory(&c)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package groupsync syncs the LDAP group memberships of users to organization
+// memberships and roles, as configured by the groupMappings of an LDAP auth
+// provider.
+package groupsync
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Sync adds the user to the organizations and roles mapped to their groups,
+// and removes them from the mapped organizations and roles of groups they are
+// not a member of. Organizations and roles that don't appear in mappings are
+// left untouched, and so are system roles and organizations or roles that
+// don't exist.
+func Sync(ctx context.Context, logger log.Logger, db database.DB, mappings []*schema.LDAPGroupMapping, userID int32, groups []ldap.Group) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	// For every org and role named in mappings, whether the user should be
+	// a member of it.
+	wantOrgs := map[string]bool{}
+	wantRoles := map[string]bool{}
+	for _, m := range mappings {
+		inGroup := false
+		for _, g := range groups {
+			if g.Matches(m.Group) {
+				inGroup = true
+				break
+			}
+		}
+		for _, org := range m.Orgs {
+			wantOrgs[org] = wantOrgs[org] || inGroup
+		}
+		for _, role := range m.Roles {
+			wantRoles[role] = wantRoles[role] || inGroup
+		}
+	}
+
+	logger = logger.With(log.Int32("userID", userID))
+	var errs error
+	for name, want := range wantOrgs {
+		if err := syncOrg(ctx, logger, db, userID, name, want); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing membership of org %q", name))
+		}
+	}
+	if err := syncRoles(ctx, logger, db, userID, wantRoles); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return errs
+}
+
+func syncOrg(ctx context.Context, logger log.Logger, db database.DB, userID int32, name string, want bool) error {
+	org, err := db.Orgs().GetByName(ctx, name)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			logger.Warn("organization in LDAP group mapping does not exist", log.String("org", name))
+			return nil
+		}
+		return err
+	}
+
+	_, err = db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return err
+	}
+	isMember := err == nil
+
+	switch {
+	case want && !isMember:
+		_, err = db.OrgMembers().Create(ctx, org.ID, userID)
+		return err
+	case !want && isMember:
+		return db.OrgMembers().Remove(ctx, org.ID, userID)
+	}
+	return nil
+}
+
+func syncRoles(ctx context.Context, logger log.Logger, db database.DB, userID int32, wantRoles map[string]bool) error {
+	if len(wantRoles) == 0 {
+		return nil
+	}
+
+	userRoles, err := db.UserRoles().GetByUserID(ctx, database.GetUserRoleOpts{UserID: userID})
+	if err != nil {
+		return errors.Wrap(err, "getting roles of user")
+	}
+	hasRole := make(map[int32]bool, len(userRoles))
+	for _, ur := range userRoles {
+		hasRole[ur.RoleID] = true
+	}
+
+	var errs error
+	for name, want := range wantRoles {
+		role, err := db.Roles().Get(ctx, database.GetRoleOpts{Name: name})
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				logger.Warn("role in LDAP group mapping does not exist", log.String("role", name))
+				continue
+			}
+			errs = errors.Append(errs, errors.Wrapf(err, "getting role %q", name))
+			continue
+		}
+		// 🚨 SECURITY: system roles such as the site administrator role must
+		// only be granted explicitly, never through a directory group.
+		if role.System {
+			logger.Warn("system role in LDAP group mapping is ignored", log.String("role", name))
+			continue
+		}
+
+		switch {
+		case want && !hasRole[role.ID]:
+			err = db.UserRoles().Assign(ctx, database.AssignUserRoleOpts{UserID: userID, RoleID: role.ID})
+		case !want && hasRole[role.ID]:
+			err = db.UserRoles().Revoke(ctx, database.RevokeUserRoleOpts{UserID: userID, RoleID: role.ID})
+		}
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "syncing role %q", name))
+		}
+	}
+	return errs
+}
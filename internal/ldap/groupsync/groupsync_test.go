@@ -0,0 +1,90 @@
+package groupsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSync(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "alice"})
+	require.NoError(t, err)
+
+	_, err = db.Orgs().Create(ctx, "eng", nil)
+	require.NoError(t, err)
+	_, err = db.Orgs().Create(ctx, "ops", nil)
+	require.NoError(t, err)
+	unmanaged, err := db.Orgs().Create(ctx, "unmanaged", nil)
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, unmanaged.ID, user.ID)
+	require.NoError(t, err)
+
+	reviewer, err := db.Roles().Create(ctx, "reviewer", false)
+	require.NoError(t, err)
+
+	mappings := []*schema.LDAPGroupMapping{
+		{Group: "eng", Orgs: []string{"eng", "missing-org"}, Roles: []string{"reviewer", "missing-role"}},
+		{Group: "cn=ops,ou=groups,dc=example,dc=com", Orgs: []string{"ops"}},
+		{Group: "admins", Roles: []string{string(types.SiteAdministratorSystemRole)}},
+	}
+
+	orgNames := func() []string {
+		t.Helper()
+		orgs, err := db.Orgs().GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		var names []string
+		for _, o := range orgs {
+			names = append(names, o.Name)
+		}
+		return names
+	}
+	roleIDs := func() []int32 {
+		t.Helper()
+		userRoles, err := db.UserRoles().GetByUserID(ctx, database.GetUserRoleOpts{UserID: user.ID})
+		require.NoError(t, err)
+		var ids []int32
+		for _, ur := range userRoles {
+			ids = append(ids, ur.RoleID)
+		}
+		return ids
+	}
+	userRoleIDs := roleIDs()
+
+	err = Sync(ctx, logger, db, mappings, user.ID, []ldap.Group{
+		{DN: "cn=eng,ou=groups,dc=example,dc=com", Name: "eng"},
+		{DN: "cn=ops,ou=groups,dc=example,dc=com", Name: "ops"},
+		{DN: "cn=admins,ou=groups,dc=example,dc=com", Name: "admins"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"eng", "ops", "unmanaged"}, orgNames())
+	require.ElementsMatch(t, append(userRoleIDs, reviewer.ID), roleIDs())
+
+	// Syncing again is a no-op.
+	err = Sync(ctx, logger, db, mappings, user.ID, []ldap.Group{
+		{DN: "cn=eng,ou=groups,dc=example,dc=com", Name: "eng"},
+		{DN: "cn=ops,ou=groups,dc=example,dc=com", Name: "ops"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"eng", "ops", "unmanaged"}, orgNames())
+
+	// Leaving the eng group removes the user from the eng org and the
+	// reviewer role, but not from orgs that aren't mapped.
+	err = Sync(ctx, logger, db, mappings, user.ID, []ldap.Group{
+		{DN: "cn=ops,ou=groups,dc=example,dc=com", Name: "ops"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"ops", "unmanaged"}, orgNames())
+	require.ElementsMatch(t, userRoleIDs, roleIDs())
+}
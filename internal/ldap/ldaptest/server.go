@@ -0,0 +1,409 @@
+// Package ldaptest provides an in-process LDAP server for tests.
+package ldaptest
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/internal/ldap"
+)
+
+// startTLSOID is the name of the StartTLS extended operation.
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// Server is a minimal in-memory LDAP server. It supports simple binds,
+// searches, unbinds and optionally StartTLS, which is what package ldap needs.
+// Searches are allowed on anonymous connections.
+type Server struct {
+	// URL is the ldap:// URL of the server.
+	URL string
+
+	ln net.Listener
+	wg sync.WaitGroup
+
+	mu        sync.Mutex
+	conns     map[net.Conn]struct{}
+	entries   []*ldap.Entry
+	passwords map[string]string
+	tlsConfig *tls.Config
+}
+
+// New starts a Server on a random local port. It is stopped when the test
+// ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		URL:       "ldap://" + ln.Addr().String(),
+		ln:        ln,
+		conns:     map[net.Conn]struct{}{},
+		passwords: map[string]string{},
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// EnableStartTLS makes the server accept StartTLS requests, after which the
+// connection is served with cfg.
+func (s *Server) EnableStartTLS(cfg *tls.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tlsConfig = cfg
+}
+
+// AddEntry adds an entry with the given attributes. If password is not empty,
+// clients can bind as dn with it.
+func (s *Server) AddEntry(dn, password string, attributes map[string][]string) {
+	e := &ldap.Entry{DN: dn}
+	for name, values := range attributes {
+		e.Attributes = append(e.Attributes, &ldap.Attribute{Name: name, Values: values})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	if password != "" {
+		s.passwords[strings.ToLower(dn)] = password
+	}
+}
+
+// Close stops the server and closes all connections.
+func (s *Server) Close() {
+	_ = s.ln.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	var conns sync.WaitGroup
+	defer conns.Wait()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+			_ = conn.Close()
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	write := func(id int64, op *ber.Packet) bool {
+		msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+		msg.AppendChild(op)
+		_, err := conn.Write(msg.Bytes())
+		return err == nil
+	}
+
+	for {
+		msg, err := ber.ReadPacket(r)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, ok := msg.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		op := msg.Children[1]
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			if !write(id, s.bind(op)) {
+				return
+			}
+
+		case goldap.ApplicationSearchRequest:
+			entries, done := s.search(op)
+			for _, e := range entries {
+				if !write(id, encodeEntry(e)) {
+					return
+				}
+			}
+			if !write(id, done) {
+				return
+			}
+
+		case goldap.ApplicationUnbindRequest:
+			return
+
+		case goldap.ApplicationExtendedRequest:
+			s.mu.Lock()
+			tlsConfig := s.tlsConfig
+			s.mu.Unlock()
+			if tlsConfig == nil || len(op.Children) == 0 || stringValue(op.Children[0]) != startTLSOID {
+				if !write(id, result(goldap.ApplicationExtendedResponse, goldap.LDAPResultProtocolError, "extended operation is not supported")) {
+					return
+				}
+				continue
+			}
+			if !write(id, result(goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess, "")) {
+				return
+			}
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			r = bufio.NewReader(tlsConn)
+
+		default:
+			return
+		}
+	}
+}
+
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 || op.Children[2].Tag != 0 {
+		return result(goldap.ApplicationBindResponse, goldap.LDAPResultProtocolError, "only simple binds are supported")
+	}
+	dn, password := stringValue(op.Children[1]), stringValue(op.Children[2])
+	if password == "" {
+		return result(goldap.ApplicationBindResponse, goldap.LDAPResultUnwillingToPerform, "unauthenticated binds are not allowed")
+	}
+
+	s.mu.Lock()
+	want, ok := s.passwords[strings.ToLower(dn)]
+	s.mu.Unlock()
+	if !ok || want != password {
+		return result(goldap.ApplicationBindResponse, goldap.LDAPResultInvalidCredentials, "invalid credentials")
+	}
+	return result(goldap.ApplicationBindResponse, goldap.LDAPResultSuccess, "")
+}
+
+// search returns the entries matching the search request op and the
+// SearchResultDone to send after them.
+func (s *Server) search(op *ber.Packet) ([]*ldap.Entry, *ber.Packet) {
+	if len(op.Children) != 8 {
+		return nil, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultProtocolError, "malformed search request")
+	}
+	baseDN := strings.ToLower(stringValue(op.Children[0]))
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	var attributes []string
+	for _, a := range op.Children[7].Children {
+		attributes = append(attributes, stringValue(a))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	baseExists := false
+	var matches []*ldap.Entry
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.DN)
+		if dn == baseDN {
+			baseExists = true
+		}
+		if !inScope(dn, baseDN, ldap.Scope(scope)) || !matchFilter(e, filter) {
+			continue
+		}
+		if sizeLimit > 0 && len(matches) == int(sizeLimit) {
+			return matches, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSizeLimitExceeded, "")
+		}
+		matches = append(matches, project(e, attributes))
+	}
+	if !baseExists && len(matches) == 0 {
+		return nil, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultNoSuchObject, "no such object")
+	}
+	return matches, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess, "")
+}
+
+func inScope(dn, baseDN string, scope ldap.Scope) bool {
+	switch scope {
+	case ldap.ScopeBaseObject:
+		return dn == baseDN
+	case ldap.ScopeSingleLevel:
+		_, parent, _ := strings.Cut(dn, ",")
+		return parent == baseDN
+	default:
+		return dn == baseDN || strings.HasSuffix(dn, ","+baseDN)
+	}
+}
+
+// project returns a copy of e with only the given attributes, or all of them
+// if attributes is empty.
+func project(e *ldap.Entry, attributes []string) *ldap.Entry {
+	if len(attributes) == 0 {
+		return e
+	}
+	projected := &ldap.Entry{DN: e.DN}
+	for _, a := range e.Attributes {
+		for _, want := range attributes {
+			if strings.EqualFold(a.Name, want) {
+				projected.Attributes = append(projected.Attributes, a)
+				break
+			}
+		}
+	}
+	return projected
+}
+
+// matchFilter evaluates the filter f, as encoded in a search request, against
+// e. Values are compared case-insensitively, and extensible matches never
+// match.
+func matchFilter(e *ldap.Entry, f *ber.Packet) bool {
+	switch f.Tag {
+	case goldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matchFilter(e, c) {
+				return false
+			}
+		}
+		return true
+
+	case goldap.FilterOr:
+		for _, c := range f.Children {
+			if matchFilter(e, c) {
+				return true
+			}
+		}
+		return false
+
+	case goldap.FilterNot:
+		return len(f.Children) == 1 && !matchFilter(e, f.Children[0])
+
+	case goldap.FilterPresent:
+		attribute := stringValue(f)
+		if strings.EqualFold(attribute, "objectClass") {
+			return true
+		}
+		return len(e.Values(attribute)) > 0
+
+	case goldap.FilterExtensibleMatch:
+		return false
+	}
+
+	if len(f.Children) != 2 {
+		return false
+	}
+	attribute := stringValue(f.Children[0])
+	for _, v := range e.Values(attribute) {
+		v := strings.ToLower(v)
+		switch f.Tag {
+		case goldap.FilterEqualityMatch, goldap.FilterApproxMatch:
+			if v == strings.ToLower(stringValue(f.Children[1])) {
+				return true
+			}
+		case goldap.FilterGreaterOrEqual:
+			if v >= strings.ToLower(stringValue(f.Children[1])) {
+				return true
+			}
+		case goldap.FilterLessOrEqual:
+			if v <= strings.ToLower(stringValue(f.Children[1])) {
+				return true
+			}
+		case goldap.FilterSubstrings:
+			if matchSubstrings(v, f.Children[1].Children) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchSubstrings(v string, substrings []*ber.Packet) bool {
+	for _, s := range substrings {
+		sub := strings.ToLower(stringValue(s))
+		switch s.Tag {
+		case goldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, sub) {
+				return false
+			}
+			v = v[len(sub):]
+		case goldap.FilterSubstringsAny:
+			i := strings.Index(v, sub)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(sub):]
+		case goldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, sub) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stringValue returns the content of a primitive packet as a string. Only the
+// universal types are decoded by ber.ReadPacket.
+func stringValue(p *ber.Packet) string {
+	if s, ok := p.Value.(string); ok {
+		return s
+	}
+	return p.Data.String()
+}
+
+func encodeEntry(e *ldap.Entry) *ber.Packet {
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for _, a := range e.Attributes {
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range a.Values {
+			values.AppendChild(octetString(v))
+		}
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attribute.AppendChild(octetString(a.Name))
+		attribute.AppendChild(values)
+		attributes.AppendChild(attribute)
+	}
+
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "")
+	p.AppendChild(octetString(e.DN))
+	p.AppendChild(attributes)
+	return p
+}
+
+func result(tag ber.Tag, code int64, message string) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	p.AppendChild(octetString(""))
+	p.AppendChild(octetString(message))
+	return p
+}
+
+func octetString(s string) *ber.Packet {
+	return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, s, "")
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"strings"
+	"time"
 )
 
 // StorageConfig captures all parameters required for instantiating an object.Storage.
@@ -12,6 +13,11 @@ type StorageConfig struct {
 	Bucket       string
 	S3           S3Config
 	GCS          GCSConfig
+	Filesystem   FilesystemConfig
+
+	// TTL is the maximum age of objects in the bucket. If the bucket is managed, Init
+	// configures a lifecycle rule on the bucket which expires older objects.
+	TTL time.Duration
 }
 
 func normalizeConfig(t StorageConfig) StorageConfig {
@@ -30,3 +36,26 @@ func normalizeConfig(t StorageConfig) StorageConfig {
 	}
 	return o
 }
+
+// daysOf returns the number of days of the given TTL, rounded up, as bucket lifecycle
+// rules only support expiration in whole days.
+func daysOf(ttl time.Duration) int {
+	return int((ttl + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
+// HasLifecycleExpiry returns true if stores created from the given configuration
+// expire objects older than the TTL server-side, via the lifecycle configuration
+// of the bucket. These stores do not need a periodic ExpireObjects call.
+func HasLifecycleExpiry(config StorageConfig) bool {
+	config = normalizeConfig(config)
+	if !config.ManageBucket || config.TTL <= 0 {
+		return false
+	}
+
+	switch config.Backend {
+	case "s3", "blobstore", "gcs":
+		return true
+	default:
+		return false
+	}
+}
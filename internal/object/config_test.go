@@ -3,6 +3,7 @@ package object
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -73,3 +74,24 @@ func TestS3ClientConfig(t *testing.T) {
 		t.Errorf("unexpected endpoint option")
 	}
 }
+
+func TestHasLifecycleExpiry(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config StorageConfig
+		want   bool
+	}{
+		{name: "managed s3", config: StorageConfig{Backend: "S3", ManageBucket: true, TTL: time.Hour}, want: true},
+		{name: "managed gcs", config: StorageConfig{Backend: "gcs", ManageBucket: true, TTL: time.Hour}, want: true},
+		{name: "blobstore", config: StorageConfig{Backend: "blobstore", TTL: time.Hour}, want: true},
+		{name: "unmanaged s3", config: StorageConfig{Backend: "s3", TTL: time.Hour}, want: false},
+		{name: "no ttl", config: StorageConfig{Backend: "s3", ManageBucket: true}, want: false},
+		{name: "filesystem", config: StorageConfig{Backend: "filesystem", ManageBucket: true, TTL: time.Hour}, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := HasLifecycleExpiry(tc.config); have != tc.want {
+				t.Errorf("unexpected result. want=%v have=%v", tc.want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package object
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	sglog "github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+type filesystemStore struct {
+	bucket       string
+	manageBucket bool
+	config       FilesystemConfig
+	operations   *Operations
+}
+
+var _ Storage = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Dir is the directory which contains a subdirectory per bucket.
+	Dir string
+}
+
+// newFilesystemFromConfig creates a new store backed by a local directory.
+func newFilesystemFromConfig(_ context.Context, config StorageConfig, operations *Operations) (Storage, error) {
+	if config.Filesystem.Dir == "" {
+		return nil, errors.New("no directory configured for filesystem storage")
+	}
+
+	return newFilesystemStore(config.Filesystem, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newFilesystemStore(config FilesystemConfig, bucket string, manageBucket bool, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		bucket:       bucket,
+		manageBucket: manageBucket,
+		config:       config,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		if _, err := os.Stat(filepath.Join(s.config.Dir, s.bucket)); err != nil {
+			return errors.Wrap(err, "failed to get bucket attributes")
+		}
+	}
+
+	for _, dir := range []string{s.objectsDir(), s.tmpDir()} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to create bucket")
+		}
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) List(ctx context.Context, prefix string) (_ *iterator.Iterator[string], err error) {
+	_, _, endObservation := s.operations.List.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	keys, err := s.listKeys(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	next := func() ([]string, error) {
+		n := min(len(keys), maxKeys)
+		page := keys[:n]
+		keys = keys[n:]
+		return page, nil
+	}
+
+	return iterator.New[string](next), nil
+}
+
+// Get returns the object's open file. Callers that need to read a range of the object may
+// type assert it to an io.ReadSeeker or io.ReaderAt.
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	_, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	f, err := os.Open(s.objectPath(key))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	_, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.write(key, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	_, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("destination", destination),
+		attribute.StringSlice("sources", sources),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sources); err != nil {
+				s.operations.Compose.Logger.Error("Failed to delete source objects", sglog.Error(err))
+			}
+		}
+	}()
+
+	n, err := s.write(destination, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, source := range sources {
+			n, err := copyFile(w, s.objectPath(source))
+			total += n
+			if err != nil {
+				return total, errors.Wrapf(err, "source %q", source)
+			}
+		}
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	_, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// Like S3, deleting an object that doesn't exist is not an error.
+	if err := os.Remove(s.objectPath(key)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete object")
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	_, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+		attribute.Stringer("maxAge", maxAge),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	keys, err := s.listKeys(prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		info, err := os.Stat(s.objectPath(key))
+		if err != nil {
+			// Deleted concurrently
+			continue
+		}
+
+		if time.Since(info.ModTime()) >= maxAge {
+			if err := os.Remove(s.objectPath(key)); err != nil && !os.IsNotExist(err) {
+				s.operations.ExpireObjects.Logger.Error("Failed to delete expired object",
+					sglog.Error(err),
+					sglog.String("bucket", s.bucket),
+					sglog.String("object", key))
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// listKeys returns the sorted keys of all objects with the given prefix.
+func (s *filesystemStore) listKeys(prefix string) ([]string, error) {
+	entries, err := os.ReadDir(s.objectsDir())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list objects")
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		key, err := url.PathUnescape(entry.Name())
+		if err != nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	// Entries are sorted by their escaped names, which doesn't preserve key order.
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// write atomically replaces the object at the given key with the content written by
+// writeContent. Readers never observe a partially written object.
+func (s *filesystemStore) write(key string, writeContent func(w io.Writer) (int64, error)) (int64, error) {
+	tmpFile, err := os.CreateTemp(s.tmpDir(), "upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// The temporary file no longer exists after a successful rename.
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+
+	n, err := writeContent(tmpFile)
+	if err != nil {
+		return 0, err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		return 0, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpFile.Name(), s.objectPath(key)); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) deleteSources(sources []string) error {
+	return forEachString(sources, func(index int, source string) error {
+		if err := os.Remove(s.objectPath(source)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
+
+// Objects and in-progress writes are kept in separate directories of the bucket, so that
+// listing never returns temporary files and renames stay on the same filesystem.
+func (s *filesystemStore) objectsDir() string {
+	return filepath.Join(s.config.Dir, s.bucket, "objects")
+}
+
+func (s *filesystemStore) tmpDir() string {
+	return filepath.Join(s.config.Dir, s.bucket, "tmp")
+}
+
+// Keys may contain slashes, so they are escaped to keep a flat directory of objects. This
+// keeps prefix listing a single directory read.
+func (s *filesystemStore) objectPath(key string) string {
+	name := url.PathEscape(key)
+	// PathEscape leaves dots alone, but keys such as ".." must not be interpreted as paths.
+	if strings.HasPrefix(name, ".") {
+		name = "%2E" + name[1:]
+	}
+	return filepath.Join(s.objectsDir(), name)
+}
+
+func copyFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
@@ -0,0 +1,231 @@
+package object
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := testFilesystemClient(dir, false).Init(context.Background()); err == nil {
+		t.Fatalf("expected error initializing unmanaged client without bucket directory")
+	}
+
+	if err := testFilesystemClient(dir, true).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-bucket")); err != nil {
+		t.Fatalf("expected bucket directory to be created: %s", err)
+	}
+
+	if err := testFilesystemClient(dir, false).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing unmanaged client: %s", err)
+	}
+}
+
+func TestFilesystemUploadAndGet(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	for _, key := range []string{"foobar", "foo/bar", "..", "../foobar"} {
+		size, err := client.Upload(context.Background(), key, strings.NewReader("Hello world!"))
+		if err != nil {
+			t.Fatalf("unexpected error uploading %q: %s", key, err)
+		}
+		if size != 12 {
+			t.Errorf("unexpected size. want=%d have=%d", 12, size)
+		}
+
+		if content := readTestObject(t, client, key); content != "Hello world!" {
+			t.Errorf("unexpected content. want=%q have=%q", "Hello world!", content)
+		}
+	}
+
+	// Overwriting replaces the content.
+	if _, err := client.Upload(context.Background(), "foobar", strings.NewReader("Bye!")); err != nil {
+		t.Fatalf("unexpected error uploading: %s", err)
+	}
+	if content := readTestObject(t, client, "foobar"); content != "Bye!" {
+		t.Errorf("unexpected content. want=%q have=%q", "Bye!", content)
+	}
+
+	// No objects escape the bucket, and no temporary files are left behind.
+	entries, err := os.ReadDir(client.tmpDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected temporary files: %v", entries)
+	}
+	if _, err := os.Stat(filepath.Join(client.config.Dir, "foobar")); !os.IsNotExist(err) {
+		t.Errorf("expected object to be stored in the bucket, got %v", err)
+	}
+}
+
+func TestFilesystemGetNotExists(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	if _, err := client.Get(context.Background(), "does-not-exist"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, got %v", err)
+	}
+}
+
+func TestFilesystemList(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	for _, key := range []string{"foobar1", "foobar2", "banana", "foo/bar"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for prefix, expectedKeys := range map[string][]string{
+		"foobar": {"foobar1", "foobar2"},
+		"foo":    {"foo/bar", "foobar1", "foobar2"},
+		"banana": {"banana"},
+		"":       {"banana", "foo/bar", "foobar1", "foobar2"},
+		"cherry": nil,
+	} {
+		iter, err := client.List(context.Background(), prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var keys []string
+		for iter.Next() {
+			keys = append(keys, iter.Current())
+		}
+		if err := iter.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(expectedKeys, keys); diff != "" {
+			t.Errorf("unexpected keys for prefix %q (-want +got):\n%s", prefix, diff)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	for key, content := range map[string]string{"foobar1": "Hello 1! ", "foobar2": "Hello 2! ", "foobar3": "Hello 3!"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "foobar-result", "foobar1", "foobar2", "foobar3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 26 {
+		t.Errorf("unexpected size. want=%d have=%d", 26, size)
+	}
+	if content := readTestObject(t, client, "foobar-result"); content != "Hello 1! Hello 2! Hello 3!" {
+		t.Errorf("unexpected content. want=%q have=%q", "Hello 1! Hello 2! Hello 3!", content)
+	}
+
+	for _, key := range []string{"foobar1", "foobar2", "foobar3"} {
+		if _, err := os.Stat(client.objectPath(key)); !os.IsNotExist(err) {
+			t.Errorf("expected source %q to be deleted", key)
+		}
+	}
+
+	// A missing source fails the compose without writing the destination.
+	if _, err := client.Compose(context.Background(), "missing-result", "foobar1"); err == nil {
+		t.Fatalf("expected error composing missing source")
+	}
+	if _, err := os.Stat(client.objectPath("missing-result")); !os.IsNotExist(err) {
+		t.Errorf("expected destination to not exist")
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	if _, err := client.Upload(context.Background(), "foobar", strings.NewReader("Hello world!")); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Delete(context.Background(), "foobar"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "foobar"); err == nil {
+		t.Fatalf("expected object to be deleted")
+	}
+
+	// Like S3, deleting a missing object succeeds.
+	if err := client.Delete(context.Background(), "foobar"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	client := initTestFilesystemClient(t)
+
+	for _, key := range []string{"foobar1", "foobar2", "foobar3", "other"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for key, age := range map[string]time.Duration{"foobar1": time.Hour, "foobar2": 20 * time.Minute, "other": time.Hour} {
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(client.objectPath(key), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := client.ExpireObjects(context.Background(), "foobar", 10*time.Minute); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	iter, err := client.List(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for iter.Next() {
+		keys = append(keys, iter.Current())
+	}
+	if diff := cmp.Diff([]string{"foobar3", "other"}, keys); diff != "" {
+		t.Errorf("unexpected keys after expiry (-want +got):\n%s", diff)
+	}
+}
+
+func testFilesystemClient(dir string, manageBucket bool) *filesystemStore {
+	return newFilesystemStore(FilesystemConfig{Dir: dir}, "test-bucket", manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore"))
+}
+
+func initTestFilesystemClient(t *testing.T) *filesystemStore {
+	client := testFilesystemClient(t.TempDir(), true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	return client
+}
+
+func readTestObject(t *testing.T, client *filesystemStore, key string) string {
+	t.Helper()
+
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting %q: %s", key, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading %q: %s", key, err)
+	}
+	return string(content)
+}
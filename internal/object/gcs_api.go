@@ -17,6 +17,7 @@ type gcsBucketHandle interface {
 	Create(ctx context.Context, projectID string, attrs *storage.BucketAttrs) error
 	Object(name string) gcsObjectHandle
 	Objects(ctx context.Context, q *storage.Query) gcsObjectIterator
+	Update(ctx context.Context, attrs storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)
 }
 
 type gcsObjectHandle interface {
@@ -71,6 +72,10 @@ func (s *bucketHandleShim) Objects(ctx context.Context, q *storage.Query) gcsObj
 	return &objectIteratorShim{handle: s.handle.Objects(ctx, q)}
 }
 
+func (s *bucketHandleShim) Update(ctx context.Context, attrs storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+	return s.handle.Update(ctx, attrs)
+}
+
 func (s *objectHandleShim) Delete(ctx context.Context) error {
 	return s.handle.Delete(ctx)
 }
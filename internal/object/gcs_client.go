@@ -18,6 +18,7 @@ import (
 
 type gcsStore struct {
 	bucket       string
+	ttl          time.Duration
 	manageBucket bool
 	config       GCSConfig
 	client       gcsAPI
@@ -39,12 +40,13 @@ func newGCSFromConfig(ctx context.Context, config StorageConfig, operations *Ope
 		return nil, err
 	}
 
-	return newGCSWithClient(&gcsAPIShim{client}, config.Bucket, config.ManageBucket, config.GCS, operations), nil
+	return newGCSWithClient(&gcsAPIShim{client}, config.Bucket, config.TTL, config.ManageBucket, config.GCS, operations), nil
 }
 
-func newGCSWithClient(client gcsAPI, bucket string, manageBucket bool, config GCSConfig, operations *Operations) *gcsStore {
+func newGCSWithClient(client gcsAPI, bucket string, ttl time.Duration, manageBucket bool, config GCSConfig, operations *Operations) *gcsStore {
 	return &gcsStore{
 		bucket:       bucket,
+		ttl:          ttl,
 		config:       config,
 		manageBucket: manageBucket,
 		client:       client,
@@ -71,6 +73,10 @@ func (s *gcsStore) Init(ctx context.Context) error {
 		return errors.Wrap(err, "failed to get bucket attributes")
 	}
 
+	if err := s.update(ctx, bucket); err != nil {
+		return errors.Wrap(err, "failed to update bucket attributes")
+	}
+
 	return nil
 }
 
@@ -223,7 +229,35 @@ func (s *gcsStore) ExpireObjects(ctx context.Context, prefix string, maxAge time
 }
 
 func (s *gcsStore) create(ctx context.Context, bucket gcsBucketHandle) error {
-	return bucket.Create(ctx, s.config.ProjectID, nil)
+	var attrs *storage.BucketAttrs
+	if s.ttl > 0 {
+		attrs = &storage.BucketAttrs{Lifecycle: s.lifecycle()}
+	}
+
+	return bucket.Create(ctx, s.config.ProjectID, attrs)
+}
+
+// update configures the lifecycle of an existing bucket, so that objects are expired
+// by the object storage itself instead of by periodic ExpireObjects calls.
+func (s *gcsStore) update(ctx context.Context, bucket gcsBucketHandle) error {
+	if s.ttl <= 0 {
+		return nil
+	}
+
+	lifecycle := s.lifecycle()
+	_, err := bucket.Update(ctx, storage.BucketAttrsToUpdate{Lifecycle: &lifecycle})
+	return err
+}
+
+func (s *gcsStore) lifecycle() storage.Lifecycle {
+	return storage.Lifecycle{
+		Rules: []storage.LifecycleRule{
+			{
+				Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+				Condition: storage.LifecycleCondition{AgeInDays: int64(daysOf(s.ttl))},
+			},
+		},
+	}
 }
 
 func (s *gcsStore) deleteSources(ctx context.Context, bucket gcsBucketHandle, sources []string) error {
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
@@ -35,6 +36,8 @@ func TestGCSInit(t *testing.T) {
 		t.Fatalf("unexpected number of Create calls. want=%d have=%d", 1, len(calls))
 	} else if value := calls[0].Arg1; value != "pid" {
 		t.Errorf("unexpected projectId argument. want=%s have=%s", "pid", value)
+	} else if diff := cmp.Diff(testGCSLifecycle, calls[0].Arg2.Lifecycle); diff != "" {
+		t.Errorf("unexpected lifecycle (-want +got):\n%s", diff)
 	}
 }
 
@@ -57,6 +60,12 @@ func TestGCSInitBucketExists(t *testing.T) {
 	if calls := bucketHandle.CreateFunc.History(); len(calls) != 0 {
 		t.Fatalf("unexpected number of Create calls. want=%d have=%d", 0, len(calls))
 	}
+
+	if calls := bucketHandle.UpdateFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of Update calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff(&testGCSLifecycle, calls[0].Arg1.Lifecycle); diff != "" {
+		t.Errorf("unexpected lifecycle (-want +got):\n%s", diff)
+	}
 }
 
 func TestGCSUnmanagedInit(t *testing.T) {
@@ -302,12 +311,21 @@ func TestGCSDelete(t *testing.T) {
 	}
 }
 
+var testGCSLifecycle = storage.Lifecycle{
+	Rules: []storage.LifecycleRule{
+		{
+			Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+			Condition: storage.LifecycleCondition{AgeInDays: 5},
+		},
+	},
+}
+
 func testGCSClient(client gcsAPI, manageBucket bool) Storage {
 	return newLazyStore(rawGCSClient(client, manageBucket))
 }
 
 func rawGCSClient(client gcsAPI, manageBucket bool) *gcsStore {
-	return newGCSWithClient(client, "test-bucket", manageBucket, GCSConfig{ProjectID: "pid"}, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	return newGCSWithClient(client, "test-bucket", 5*24*time.Hour, manageBucket, GCSConfig{ProjectID: "pid"}, NewOperations(&observation.TestContext, "test", "brittlestore"))
 }
 
 type nopCloser struct {
@@ -183,6 +183,9 @@ type MockGcsBucketHandle struct {
 	// ObjectsFunc is an instance of a mock function object controlling the
 	// behavior of the method Objects.
 	ObjectsFunc *GcsBucketHandleObjectsFunc
+	// UpdateFunc is an instance of a mock function object controlling the
+	// behavior of the method Update.
+	UpdateFunc *GcsBucketHandleUpdateFunc
 }
 
 // NewMockGcsBucketHandle creates a new mock of the gcsBucketHandle
@@ -210,6 +213,11 @@ func NewMockGcsBucketHandle() *MockGcsBucketHandle {
 				return
 			},
 		},
+		UpdateFunc: &GcsBucketHandleUpdateFunc{
+			defaultHook: func(context.Context, storage.BucketAttrsToUpdate) (r0 *storage.BucketAttrs, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -237,6 +245,11 @@ func NewStrictMockGcsBucketHandle() *MockGcsBucketHandle {
 				panic("unexpected invocation of MockGcsBucketHandle.Objects")
 			},
 		},
+		UpdateFunc: &GcsBucketHandleUpdateFunc{
+			defaultHook: func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+				panic("unexpected invocation of MockGcsBucketHandle.Update")
+			},
+		},
 	}
 }
 
@@ -248,6 +261,7 @@ type surrogateMockGcsBucketHandle interface {
 	Create(context.Context, string, *storage.BucketAttrs) error
 	Object(string) gcsObjectHandle
 	Objects(context.Context, *storage.Query) gcsObjectIterator
+	Update(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)
 }
 
 // NewMockGcsBucketHandleFrom creates a new mock of the MockGcsBucketHandle
@@ -267,6 +281,9 @@ func NewMockGcsBucketHandleFrom(i surrogateMockGcsBucketHandle) *MockGcsBucketHa
 		ObjectsFunc: &GcsBucketHandleObjectsFunc{
 			defaultHook: i.Objects,
 		},
+		UpdateFunc: &GcsBucketHandleUpdateFunc{
+			defaultHook: i.Update,
+		},
 	}
 }
 
@@ -690,6 +707,114 @@ func (c GcsBucketHandleObjectsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GcsBucketHandleUpdateFunc describes the behavior when the Update method of
+// the parent MockGcsBucketHandle instance is invoked.
+type GcsBucketHandleUpdateFunc struct {
+	defaultHook func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)
+	hooks       []func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)
+	history     []GcsBucketHandleUpdateFuncCall
+	mutex       sync.Mutex
+}
+
+// Attrs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGcsBucketHandle) Update(v0 context.Context, v1 storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+	r0, r1 := m.UpdateFunc.nextHook()(v0, v1)
+	m.UpdateFunc.appendCall(GcsBucketHandleUpdateFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Update method of the
+// parent MockGcsBucketHandle instance is invoked and the hook queue is
+// empty.
+func (f *GcsBucketHandleUpdateFunc) SetDefaultHook(hook func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Update method of the parent MockGcsBucketHandle instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GcsBucketHandleUpdateFunc) PushHook(hook func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GcsBucketHandleUpdateFunc) SetDefaultReturn(r0 *storage.BucketAttrs, r1 error) {
+	f.SetDefaultHook(func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GcsBucketHandleUpdateFunc) PushReturn(r0 *storage.BucketAttrs, r1 error) {
+	f.PushHook(func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+		return r0, r1
+	})
+}
+
+func (f *GcsBucketHandleUpdateFunc) nextHook() func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GcsBucketHandleUpdateFunc) appendCall(r0 GcsBucketHandleUpdateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GcsBucketHandleUpdateFuncCall objects
+// describing the invocations of this function.
+func (f *GcsBucketHandleUpdateFunc) History() []GcsBucketHandleUpdateFuncCall {
+	f.mutex.Lock()
+	history := make([]GcsBucketHandleUpdateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GcsBucketHandleUpdateFuncCall is an object that describes an invocation of
+// method Update on an instance of MockGcsBucketHandle.
+type GcsBucketHandleUpdateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 storage.BucketAttrsToUpdate
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *storage.BucketAttrs
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GcsBucketHandleUpdateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GcsBucketHandleUpdateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockGcsComposer is a mock implementation of the gcsComposer interface
 // (from the package github.com/sourcegraph/sourcegraph/internal/object)
 // used for unit testing.
@@ -1408,6 +1533,10 @@ type MockS3API struct {
 	// object controlling the behavior of the method
 	// NewListObjectsV2Paginator.
 	NewListObjectsV2PaginatorFunc *S3APINewListObjectsV2PaginatorFunc
+	// PutBucketLifecycleConfigurationFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// PutBucketLifecycleConfiguration.
+	PutBucketLifecycleConfigurationFunc *S3APIPutBucketLifecycleConfigurationFunc
 	// UploadPartCopyFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadPartCopy.
 	UploadPartCopyFunc *S3APIUploadPartCopyFunc
@@ -1462,6 +1591,11 @@ func NewMockS3API() *MockS3API {
 				return
 			},
 		},
+		PutBucketLifecycleConfigurationFunc: &S3APIPutBucketLifecycleConfigurationFunc{
+			defaultHook: func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (r0 *s3.PutBucketLifecycleConfigurationOutput, r1 error) {
+				return
+			},
+		},
 		UploadPartCopyFunc: &S3APIUploadPartCopyFunc{
 			defaultHook: func(context.Context, *s3.UploadPartCopyInput) (r0 *s3.UploadPartCopyOutput, r1 error) {
 				return
@@ -1519,6 +1653,11 @@ func NewStrictMockS3API() *MockS3API {
 				panic("unexpected invocation of MockS3API.NewListObjectsV2Paginator")
 			},
 		},
+		PutBucketLifecycleConfigurationFunc: &S3APIPutBucketLifecycleConfigurationFunc{
+			defaultHook: func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+				panic("unexpected invocation of MockS3API.PutBucketLifecycleConfiguration")
+			},
+		},
 		UploadPartCopyFunc: &S3APIUploadPartCopyFunc{
 			defaultHook: func(context.Context, *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
 				panic("unexpected invocation of MockS3API.UploadPartCopy")
@@ -1540,6 +1679,7 @@ type surrogateMockS3API interface {
 	GetObject(context.Context, *s3.GetObjectInput) (*s3.GetObjectOutput, error)
 	HeadObject(context.Context, *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
 	NewListObjectsV2Paginator(*s3.ListObjectsV2Input) *s3.ListObjectsV2Paginator
+	PutBucketLifecycleConfiguration(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
 	UploadPartCopy(context.Context, *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error)
 }
 
@@ -1574,6 +1714,9 @@ func NewMockS3APIFrom(i surrogateMockS3API) *MockS3API {
 		NewListObjectsV2PaginatorFunc: &S3APINewListObjectsV2PaginatorFunc{
 			defaultHook: i.NewListObjectsV2Paginator,
 		},
+		PutBucketLifecycleConfigurationFunc: &S3APIPutBucketLifecycleConfigurationFunc{
+			defaultHook: i.PutBucketLifecycleConfiguration,
+		},
 		UploadPartCopyFunc: &S3APIUploadPartCopyFunc{
 			defaultHook: i.UploadPartCopy,
 		},
@@ -2554,6 +2697,114 @@ func (c S3APINewListObjectsV2PaginatorFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// S3APIPutBucketLifecycleConfigurationFunc describes the behavior when the
+// PutBucketLifecycleConfiguration method of the parent MockS3API instance
+// is invoked.
+type S3APIPutBucketLifecycleConfigurationFunc struct {
+	defaultHook func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	hooks       []func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	history     []S3APIPutBucketLifecycleConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// PutBucketLifecycleConfiguration delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockS3API) PutBucketLifecycleConfiguration(v0 context.Context, v1 *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	r0, r1 := m.PutBucketLifecycleConfigurationFunc.nextHook()(v0, v1)
+	m.PutBucketLifecycleConfigurationFunc.appendCall(S3APIPutBucketLifecycleConfigurationFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the PutBucketLifecycleConfiguration method
+// of the parent MockS3API instance is invoked and the hook queue is empty.
+func (f *S3APIPutBucketLifecycleConfigurationFunc) SetDefaultHook(hook func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PutBucketLifecycleConfiguration method of the parent MockS3API instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *S3APIPutBucketLifecycleConfigurationFunc) PushHook(hook func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *S3APIPutBucketLifecycleConfigurationFunc) SetDefaultReturn(r0 *s3.PutBucketLifecycleConfigurationOutput, r1 error) {
+	f.SetDefaultHook(func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *S3APIPutBucketLifecycleConfigurationFunc) PushReturn(r0 *s3.PutBucketLifecycleConfigurationOutput, r1 error) {
+	f.PushHook(func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+		return r0, r1
+	})
+}
+
+func (f *S3APIPutBucketLifecycleConfigurationFunc) nextHook() func(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *S3APIPutBucketLifecycleConfigurationFunc) appendCall(r0 S3APIPutBucketLifecycleConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of S3APIPutBucketLifecycleConfigurationFuncCall objects
+// describing the invocations of this function.
+func (f *S3APIPutBucketLifecycleConfigurationFunc) History() []S3APIPutBucketLifecycleConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]S3APIPutBucketLifecycleConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// S3APIPutBucketLifecycleConfigurationFuncCall is an object that describes an invocation of
+// method PutBucketLifecycleConfiguration on an instance of MockS3API.
+type S3APIPutBucketLifecycleConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *s3.PutBucketLifecycleConfigurationInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *s3.PutBucketLifecycleConfigurationOutput
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c S3APIPutBucketLifecycleConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c S3APIPutBucketLifecycleConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // S3APIUploadPartCopyFunc describes the behavior when the UploadPartCopy
 // method of the parent MockS3API instance is invoked.
 type S3APIUploadPartCopyFunc struct {
@@ -18,6 +18,7 @@ type s3API interface {
 	CreateBucket(ctx context.Context, input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error)
 	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
 	NewListObjectsV2Paginator(input *s3.ListObjectsV2Input) *s3.ListObjectsV2Paginator
+	PutBucketLifecycleConfiguration(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
 }
 
 type s3Uploader interface {
@@ -74,6 +75,10 @@ func (s *s3APIShim) NewListObjectsV2Paginator(input *s3.ListObjectsV2Input) *s3.
 	return s3.NewListObjectsV2Paginator(s.Client, input)
 }
 
+func (s *s3APIShim) PutBucketLifecycleConfiguration(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	return s.Client.PutBucketLifecycleConfiguration(ctx, input)
+}
+
 func (s *s3UploaderShim) Upload(ctx context.Context, input *s3.PutObjectInput) error {
 	_, err := s.Uploader.Upload(ctx, input)
 	return err
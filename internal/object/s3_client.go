@@ -24,6 +24,7 @@ import (
 
 type s3Store struct {
 	bucket       string
+	ttl          time.Duration
 	manageBucket bool
 	client       s3API
 	uploader     s3Uploader
@@ -52,12 +53,13 @@ func newS3FromConfig(ctx context.Context, config StorageConfig, operations *Oper
 	s3Client := s3.NewFromConfig(cfg, s3ClientOptions(config.S3))
 	api := &s3APIShim{s3Client}
 	uploader := &s3UploaderShim{manager.NewUploader(s3Client)}
-	return newS3WithClients(api, uploader, config.Bucket, config.ManageBucket, operations), nil
+	return newS3WithClients(api, uploader, config.Bucket, config.TTL, config.ManageBucket, operations), nil
 }
 
-func newS3WithClients(client s3API, uploader s3Uploader, bucket string, manageBucket bool, operations *Operations) *s3Store {
+func newS3WithClients(client s3API, uploader s3Uploader, bucket string, ttl time.Duration, manageBucket bool, operations *Operations) *s3Store {
 	return &s3Store{
 		bucket:       bucket,
+		ttl:          ttl,
 		manageBucket: manageBucket,
 		client:       client,
 		uploader:     uploader,
@@ -74,6 +76,10 @@ func (s *s3Store) Init(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create bucket")
 	}
 
+	if err := s.update(ctx); err != nil {
+		return errors.Wrap(err, "failed to update bucket attributes")
+	}
+
 	return nil
 }
 
@@ -373,6 +379,34 @@ func (s *s3Store) create(ctx context.Context) error {
 	return err
 }
 
+// update configures the lifecycle of the bucket, so that objects are expired by the
+// object storage itself instead of by periodic ExpireObjects calls.
+func (s *s3Store) update(ctx context.Context) error {
+	if s.ttl <= 0 {
+		return nil
+	}
+
+	_, err := s.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(s.bucket),
+		LifecycleConfiguration: s.lifecycle(),
+	})
+
+	return err
+}
+
+func (s *s3Store) lifecycle() *s3types.BucketLifecycleConfiguration {
+	return &s3types.BucketLifecycleConfiguration{
+		Rules: []s3types.LifecycleRule{
+			{
+				ID:         aws.String("Expiration Rule"),
+				Status:     s3types.ExpirationStatusEnabled,
+				Filter:     &s3types.LifecycleRuleFilterMemberPrefix{Value: ""},
+				Expiration: &s3types.LifecycleExpiration{Days: int32(daysOf(s.ttl))},
+			},
+		},
+	}
+}
+
 func (s *s3Store) deleteSources(ctx context.Context, bucket string, sources []string) error {
 	return forEachString(sources, func(index int, source string) error {
 		if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -31,6 +32,16 @@ func TestS3Init(t *testing.T) {
 	} else if value := *calls[0].Arg1.Bucket; value != "test-bucket" {
 		t.Errorf("unexpected bucket argument. want=%s have=%s", "test-bucket", value)
 	}
+
+	if calls := s3Client.PutBucketLifecycleConfigurationFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of PutBucketLifecycleConfiguration calls. want=%d have=%d", 1, len(calls))
+	} else if value := *calls[0].Arg1.Bucket; value != "test-bucket" {
+		t.Errorf("unexpected bucket argument. want=%s have=%s", "test-bucket", value)
+	} else if rules := calls[0].Arg1.LifecycleConfiguration.Rules; len(rules) != 1 {
+		t.Fatalf("unexpected number of lifecycle rules. want=%d have=%d", 1, len(rules))
+	} else if value := rules[0].Expiration.Days; value != 5 {
+		t.Errorf("unexpected expiration days. want=%d have=%d", 5, value)
+	}
 }
 
 func TestS3InitBucketExists(t *testing.T) {
@@ -53,7 +64,7 @@ func TestS3InitBucketExists(t *testing.T) {
 
 func TestS3UnmanagedInit(t *testing.T) {
 	s3Client := NewMockS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", 0, false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
 	if err := client.Init(context.Background()); err != nil {
 		t.Fatalf("unexpected error initializing client: %s", err)
 	}
@@ -61,6 +72,9 @@ func TestS3UnmanagedInit(t *testing.T) {
 	if calls := s3Client.CreateBucketFunc.History(); len(calls) != 0 {
 		t.Fatalf("unexpected number of CreateBucket calls. want=%d have=%d", 0, len(calls))
 	}
+	if calls := s3Client.PutBucketLifecycleConfigurationFunc.History(); len(calls) != 0 {
+		t.Fatalf("unexpected number of PutBucketLifecycleConfiguration calls. want=%d have=%d", 0, len(calls))
+	}
 }
 
 func TestS3Get(t *testing.T) {
@@ -69,7 +83,7 @@ func TestS3Get(t *testing.T) {
 		Body: io.NopCloser(bytes.NewReader([]byte("TEST PAYLOAD"))),
 	}, nil)
 
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", 0, false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -121,7 +135,7 @@ func TestS3GetTransientErrors(t *testing.T) {
 	}
 
 	s3Client := fullContentsS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", 0, false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -150,7 +164,7 @@ func TestS3GetReadNothingLoop(t *testing.T) {
 	}
 
 	s3Client := fullContentsS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", 0, false, NewOperations(observation.TestContextTB(t), "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -368,5 +382,5 @@ func testS3Client(client s3API, uploader s3Uploader) Storage {
 }
 
 func rawS3Client(client s3API, uploader s3Uploader) *s3Store {
-	return newS3WithClients(client, uploader, "test-bucket", true, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	return newS3WithClients(client, uploader, "test-bucket", 5*24*time.Hour, true, NewOperations(&observation.TestContext, "test", "brittleStore"))
 }
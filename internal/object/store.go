@@ -1,5 +1,5 @@
 // Package object provides an interface to object storage that abstracts over
-// S3, GCS and blobstore.
+// S3, GCS, blobstore and the local filesystem.
 package object
 
 import (
@@ -43,9 +43,10 @@ type Storage interface {
 }
 
 var storeConstructors = map[string]func(ctx context.Context, config StorageConfig, operations *Operations) (Storage, error){
-	"s3":        newS3FromConfig,
-	"blobstore": newS3FromConfig,
-	"gcs":       newGCSFromConfig,
+	"s3":         newS3FromConfig,
+	"blobstore":  newS3FromConfig,
+	"gcs":        newGCSFromConfig,
+	"filesystem": newFilesystemFromConfig,
 }
 
 // CreateLazyStorage initialize a new store from the given configuration that is initialized
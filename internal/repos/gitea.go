@@ -0,0 +1,265 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo)
+// connection configured in Sourcegraph via the external services
+// configuration.
+type GiteaSource struct {
+	svc       *types.ExternalService
+	config    *schema.GiteaConnection
+	cli       *gitea.Client
+	serviceID string
+	excluder  repoExcluder
+	logger    log.Logger
+}
+
+var _ Source = &GiteaSource{}
+var _ UserSource = &GiteaSource{}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, logger log.Logger, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), u, c.Token, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var ex repoExcluder
+	excludeFork := func(repo any) bool {
+		if giteaRepo, ok := repo.(gitea.Repository); ok {
+			return giteaRepo.Fork
+		}
+		return false
+	}
+	excludeArchived := func(repo any) bool {
+		if giteaRepo, ok := repo.(gitea.Repository); ok {
+			return giteaRepo.Archived
+		}
+		return false
+	}
+	excludeMirror := func(repo any) bool {
+		if giteaRepo, ok := repo.(gitea.Repository); ok {
+			return giteaRepo.Mirror
+		}
+		return false
+	}
+
+	for _, r := range c.Exclude {
+		// Either Name OR ID must match, or pattern if set.
+		rule := NewRule().
+			Exact(r.Name).
+			Pattern(r.Pattern)
+
+		if r.Id != 0 {
+			rule.Exact(strconv.Itoa(r.Id))
+		}
+		if r.Forks {
+			rule.Generic(excludeFork)
+		}
+		if r.Archived {
+			rule.Generic(excludeArchived)
+		}
+		if r.Mirrors {
+			rule.Generic(excludeMirror)
+		}
+
+		ex.AddRule(rule)
+	}
+	if err := ex.RuleErrors(); err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:       svc,
+		config:    &c,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		excluder:  ex,
+		logger:    logger,
+	}, nil
+}
+
+// CheckConnection returns an error if the configured token cannot be used to
+// talk to the Gitea API.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	if _, err := s.cli.GetAuthenticatedUser(ctx); err != nil {
+		return errors.Wrap(err, "connection check failed. could not fetch authenticated user")
+	}
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config. Repositories that are matched by more than one of "orgs", "users",
+// "repos" and "repositoryQuery" are only yielded once.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]struct{})
+
+	emit := func(r *gitea.Repository) {
+		if _, ok := seen[r.ID]; ok {
+			return
+		}
+		seen[r.ID] = struct{}{}
+
+		if s.excludes(r) {
+			return
+		}
+		results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+	}
+
+	drain := func(it *iterator.Iterator[*gitea.Repository]) {
+		for it.Next() {
+			emit(it.Current())
+		}
+		if err := it.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		drain(s.cli.ListOrgRepos(ctx, org))
+	}
+
+	for _, user := range s.config.Users {
+		drain(s.cli.ListUserRepos(ctx, user))
+	}
+
+	for _, nameWithOwner := range s.config.Repos {
+		owner, name, ok := strings.Cut(nameWithOwner, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Gitea repository name %q", nameWithOwner)}
+			continue
+		}
+
+		r, err := s.cli.GetRepo(ctx, owner, name)
+		if err != nil {
+			// A configured repository that doesn't exist anymore shouldn't
+			// fail the entire sync.
+			s.logger.Warn("fetching Gitea repository failed", log.String("repo", nameWithOwner), log.Error(err))
+			continue
+		}
+		emit(r)
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		if query == "all" {
+			query = ""
+		}
+		drain(s.cli.SearchRepos(ctx, query))
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s *GiteaSource) WithAuthenticator(a auth.Authenticator) (Source, error) {
+	token, ok := a.(*auth.OAuthBearerToken)
+	if !ok {
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	sc := *s
+	sc.cli = sc.cli.WithToken(token.Token)
+
+	return &sc, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s *GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.cli.GetAuthenticatedUser(ctx)
+	return err
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.excluder.ShouldExclude(r.FullName) ||
+		s.excluder.ShouldExclude(strconv.FormatInt(r.ID, 10)) ||
+		s.excluder.ShouldExclude(*r)
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+
+	cloneURL := r.CloneURL
+	if s.config.GitURLType == "ssh" {
+		cloneURL = r.SSHURL
+	}
+
+	host := s.cli.URL.Hostname()
+
+	return &types.Repo{
+		Name: reposource.GiteaRepoName(
+			s.config.RepositoryPathPattern,
+			host,
+			r.FullName,
+		),
+		URI: string(reposource.GiteaRepoName(
+			"",
+			host,
+			r.FullName,
+		)),
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Stars:       r.StarsCount,
+		Private:     r.Private || r.Internal,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: r,
+	}
+}
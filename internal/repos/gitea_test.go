@@ -0,0 +1,44 @@
+package repos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	conf := &schema.GiteaConnection{
+		Url:   "https://gitea.sgdev.org",
+		Token: "secret",
+		Orgs:  []string{"sourcegraph"},
+		Users: []string{"alice"},
+		// Also matched by the org, should only be yielded once.
+		Repos: []string{"sourcegraph/src-cli"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Archived: true},
+		},
+	}
+	cf, save := NewClientFactory(t, t.Name())
+	defer save(t)
+
+	svc := typestest.MakeExternalService(t, extsvc.VariantGitea, conf)
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, logtest.Scoped(t), svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := ListAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/sources/"+t.Name(), Update(t.Name()), repos)
+}
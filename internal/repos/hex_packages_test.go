@@ -0,0 +1,60 @@
+package repos
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHexPackagesSource_ListRepos(t *testing.T) {
+	ctx := context.Background()
+	depsSvc := testDependenciesService(ctx, t, []dependencies.MinimalPackageRepoRef{
+		{
+			Scheme: dependencies.HexPackagesScheme,
+			Name:   "phoenix",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{
+				{Version: "1.7.10"}, // test deduplication with version from config
+				{Version: "1.7.9"},
+			},
+		},
+		{
+			Scheme:   dependencies.HexPackagesScheme,
+			Name:     "jason",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{{Version: "1.4.1"}},
+		},
+	})
+
+	svc := typestest.MakeExternalService(t,
+		extsvc.VariantHexPackages,
+		&schema.HexPackagesConnection{
+			Repository: "https://repo.hex.pm",
+			Dependencies: []string{
+				"phoenix@1.7.10",
+				"telemetry@1.2.1",
+			},
+		})
+
+	src, err := NewHexPackagesSource(ctx, svc, httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	src.SetDependenciesService(depsSvc)
+
+	repos, err := ListAll(ctx, src)
+	require.NoError(t, err)
+
+	var names []api.RepoName
+	for _, r := range repos {
+		names = append(names, r.Name)
+		require.Equal(t, extsvc.TypeHexPackages, r.ExternalRepo.ServiceType)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	require.Equal(t, []api.RepoName{"hex/jason", "hex/phoenix", "hex/telemetry"}, names)
+}
@@ -0,0 +1,147 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection
+// configured in Sourcegraph via the external services configuration. The
+// repositories are converted to git by gitserver when they are cloned.
+type MercurialSource struct {
+	svc      *types.ExternalService
+	conn     *schema.MercurialConnection
+	excluder repoExcluder
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	var ex repoExcluder
+	for _, r := range c.Exclude {
+		ex.AddRule(NewRule().
+			Exact(r.Name).
+			Pattern(r.Pattern))
+	}
+	if err := ex.RuleErrors(); err != nil {
+		return nil, err
+	}
+
+	return &MercurialSource{
+		svc:      svc,
+		conn:     &c,
+		excluder: ex,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors
+// returned when gitserver clones the repositories.
+func (s MercurialSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Mercurial repositories listed in the connection.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	urls, err := s.cloneURLs()
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+
+	urn := s.svc.URN()
+	for _, u := range urls {
+		r, err := s.makeRepo(urn, u)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		if s.excluder.ShouldExclude(string(r.Name)) {
+			continue
+		}
+
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s MercurialSource) cloneURLs() ([]*url.URL, error) {
+	base, err := url.Parse(s.conn.Url)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve repos relative to the base path, even if the configured URL
+	// lacks a trailing slash.
+	base = extsvc.NormalizeBaseURL(base)
+
+	cloneURLs := make([]*url.URL, 0, len(s.conn.Repos))
+	for _, repo := range s.conn.Repos {
+		cloneURL, err := base.Parse(repo)
+		if err != nil {
+			return nil, err
+		}
+		cloneURLs = append(cloneURLs, cloneURL)
+	}
+
+	return cloneURLs, nil
+}
+
+func (s MercurialSource) makeRepo(urn string, u *url.URL) (*types.Repo, error) {
+	repoSource := reposource.Mercurial{MercurialConnection: s.conn}
+	repoName, err := repoSource.CloneURLToRepoName(u.String())
+	if err != nil {
+		return nil, err
+	}
+	repoURI, err := repoSource.CloneURLToRepoURI(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// The clone URL stored on the repo must not contain credentials, those are
+	// added back from the connection config when gitserver computes the
+	// remote URL.
+	u.User = nil
+	cloneURL := u.String()
+	relativePath := u.EscapedPath()
+	u.Path, u.RawPath, u.RawQuery = "", "", ""
+	serviceID := u.String()
+
+	return &types.Repo{
+		Name: repoName,
+		URI:  repoURI,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{
+			RelativePath: relativePath,
+		},
+		Private: !s.svc.Unrestricted,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package repos
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNuGetPackagesSource_ListRepos(t *testing.T) {
+	ctx := context.Background()
+	depsSvc := testDependenciesService(ctx, t, []dependencies.MinimalPackageRepoRef{
+		{
+			Scheme: dependencies.NuGetPackagesScheme,
+			Name:   "newtonsoft.json",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{
+				{Version: "13.0.3"}, // test deduplication with version from config
+				{Version: "13.0.1"},
+			},
+		},
+		{
+			Scheme:   dependencies.NuGetPackagesScheme,
+			Name:     "serilog",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{{Version: "3.1.1"}},
+		},
+	})
+
+	svc := typestest.MakeExternalService(t,
+		extsvc.VariantNuGetPackages,
+		&schema.NuGetPackagesConnection{
+			Registry: "https://api.nuget.org/v3/index.json",
+			Dependencies: []string{
+				"Newtonsoft.Json@13.0.3",
+				"xunit@2.6.1",
+			},
+		})
+
+	src, err := NewNuGetPackagesSource(ctx, svc, httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	src.SetDependenciesService(depsSvc)
+
+	repos, err := ListAll(ctx, src)
+	require.NoError(t, err)
+
+	var names []api.RepoName
+	for _, r := range repos {
+		names = append(names, r.Name)
+		require.Equal(t, extsvc.TypeNuGetPackages, r.ExternalRepo.ServiceType)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	require.Equal(t, []api.RepoName{"nuget/newtonsoft.json", "nuget/serilog", "nuget/xunit"}, names)
+}
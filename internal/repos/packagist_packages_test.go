@@ -0,0 +1,60 @@
+package repos
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPackagistPackagesSource_ListRepos(t *testing.T) {
+	ctx := context.Background()
+	depsSvc := testDependenciesService(ctx, t, []dependencies.MinimalPackageRepoRef{
+		{
+			Scheme: dependencies.PackagistPackagesScheme,
+			Name:   "monolog/monolog",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{
+				{Version: "3.5.0"}, // test deduplication with version from config
+				{Version: "3.4.0"},
+			},
+		},
+		{
+			Scheme:   dependencies.PackagistPackagesScheme,
+			Name:     "symfony/console",
+			Versions: []dependencies.MinimalPackageRepoRefVersion{{Version: "v7.0.1"}},
+		},
+	})
+
+	svc := typestest.MakeExternalService(t,
+		extsvc.VariantPackagistPackages,
+		&schema.PackagistPackagesConnection{
+			Repository: "https://repo.packagist.org",
+			Dependencies: []string{
+				"Monolog/Monolog@3.5.0",
+				"guzzlehttp/guzzle@7.8.1",
+			},
+		})
+
+	src, err := NewPackagistPackagesSource(ctx, svc, httpcli.NewFactory(nil))
+	require.NoError(t, err)
+	src.SetDependenciesService(depsSvc)
+
+	repos, err := ListAll(ctx, src)
+	require.NoError(t, err)
+
+	var names []api.RepoName
+	for _, r := range repos {
+		names = append(names, r.Name)
+		require.Equal(t, extsvc.TypePackagistPackages, r.ExternalRepo.ServiceType)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	require.Equal(t, []api.RepoName{"packagist/guzzlehttp/guzzle", "packagist/monolog/monolog", "packagist/symfony/console"}, names)
+}
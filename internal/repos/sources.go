@@ -55,6 +55,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewAzureDevOpsSource(ctx, logger.Scoped("AzureDevOpsSource"), svc, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, logger.Scoped("GiteaSource"), svc, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource"), svc, cf)
 	case extsvc.KindBitbucketCloud:
@@ -76,12 +78,22 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewPagureSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
+	case extsvc.KindNuGetPackages:
+		return NewNuGetPackagesSource(ctx, svc, cf)
+	case extsvc.KindHexPackages:
+		return NewHexPackagesSource(ctx, svc, cf)
+	case extsvc.KindPackagistPackages:
+		return NewPackagistPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
 		return NewPythonPackagesSource(ctx, svc, cf)
 	case extsvc.KindRustPackages:
 		return NewRustPackagesSource(ctx, svc, cf)
 	case extsvc.KindRubyPackages:
 		return NewRubyPackagesSource(ctx, svc, cf)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(ctx, svc)
+	case extsvc.KindSubversion:
+		return NewSubversionSource(ctx, svc)
 	case extsvc.KindOther:
 		return NewOtherSource(ctx, svc, cf, logger.Scoped("OtherSource"))
 	default:
@@ -0,0 +1,146 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SubversionSource yields repositories from a single Subversion connection
+// configured in Sourcegraph via the external services configuration. The
+// repositories are converted to git by gitserver using git-svn.
+type SubversionSource struct {
+	svc      *types.ExternalService
+	conn     *schema.SubversionConnection
+	excluder repoExcluder
+}
+
+// NewSubversionSource returns a new SubversionSource from the given external service.
+func NewSubversionSource(ctx context.Context, svc *types.ExternalService) (*SubversionSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.SubversionConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	var ex repoExcluder
+	for _, r := range c.Exclude {
+		ex.AddRule(NewRule().
+			Exact(r.Name).
+			Pattern(r.Pattern))
+	}
+	if err := ex.RuleErrors(); err != nil {
+		return nil, err
+	}
+
+	return &SubversionSource{
+		svc:      svc,
+		conn:     &c,
+		excluder: ex,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors
+// returned when gitserver clones the repositories.
+func (s SubversionSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Subversion repositories listed in the connection.
+func (s SubversionSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	urls, err := s.cloneURLs()
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+
+	urn := s.svc.URN()
+	for _, u := range urls {
+		r, err := s.makeRepo(urn, u)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		if s.excluder.ShouldExclude(string(r.Name)) {
+			continue
+		}
+
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SubversionSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s SubversionSource) cloneURLs() ([]*url.URL, error) {
+	base, err := url.Parse(s.conn.Url)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve repos relative to the base path, even if the configured URL
+	// lacks a trailing slash.
+	base = extsvc.NormalizeBaseURL(base)
+
+	cloneURLs := make([]*url.URL, 0, len(s.conn.Repos))
+	for _, repo := range s.conn.Repos {
+		cloneURL, err := base.Parse(repo)
+		if err != nil {
+			return nil, err
+		}
+		cloneURLs = append(cloneURLs, cloneURL)
+	}
+
+	return cloneURLs, nil
+}
+
+func (s SubversionSource) makeRepo(urn string, u *url.URL) (*types.Repo, error) {
+	repoSource := reposource.Subversion{SubversionConnection: s.conn}
+	repoName, err := repoSource.CloneURLToRepoName(u.String())
+	if err != nil {
+		return nil, err
+	}
+	repoURI, err := repoSource.CloneURLToRepoURI(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Credentials are configured separately and added to the remote URL by
+	// gitserver, never store any that were part of the base URL.
+	u.User = nil
+	cloneURL := u.String()
+	relativePath := u.EscapedPath()
+	u.Path, u.RawPath, u.RawQuery = "", "", ""
+	serviceID := u.String()
+
+	return &types.Repo{
+		Name: repoName,
+		URI:  repoURI,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceType: extsvc.TypeSubversion,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{
+			RelativePath: relativePath,
+		},
+		Private: !s.svc.Unrestricted,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSubversionSource_ListRepos(t *testing.T) {
+	cases := []struct {
+		Name string
+		Conn *schema.SubversionConnection
+		Want []string
+	}{{
+		Name: "simple",
+		Conn: &schema.SubversionConnection{
+			Url:   "https://svn.example.org",
+			Repos: []string{"a", "b/c"},
+		},
+		Want: []string{"svn.example.org/a", "svn.example.org/b/c"},
+	}, {
+		Name: "base path without trailing slash",
+		Conn: &schema.SubversionConnection{
+			Url:   "https://svn.example.org/repos",
+			Repos: []string{"a"},
+		},
+		Want: []string{"svn.example.org/repos/a"},
+	}, {
+		Name: "pattern",
+		Conn: &schema.SubversionConnection{
+			Url:                   "https://svn.example.org",
+			Repos:                 []string{"a", "b/c"},
+			RepositoryPathPattern: "svn/{repo}",
+		},
+		Want: []string{"svn/a", "svn/b/c"},
+	}, {
+		Name: "exclude",
+		Conn: &schema.SubversionConnection{
+			Url:                   "https://svn.example.org",
+			Repos:                 []string{"keep1", "exclude/exact", "exclude-dir/a", "keep2"},
+			Exclude:               []*schema.ExcludedSubversionRepo{{Name: "exclude/exact"}, {Pattern: "exclude-dir"}},
+			RepositoryPathPattern: "{repo}",
+		},
+		Want: []string{"keep1", "keep2"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := listSubversionRepos(t, tc.Conn)
+
+			names := make([]string, 0, len(got))
+			for _, r := range got {
+				names = append(names, string(r.Name))
+			}
+			if d := cmp.Diff(tc.Want, names); d != "" {
+				t.Fatalf("unexpected repos (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestSubversionSource_makeRepo(t *testing.T) {
+	got := listSubversionRepos(t, &schema.SubversionConnection{
+		Url:      "https://svn.example.org/repos/",
+		Repos:    []string{"legacy/app"},
+		Username: "user",
+		Password: "secret",
+	})
+
+	want := []*types.Repo{{
+		Name: "svn.example.org/repos/legacy/app",
+		URI:  "svn.example.org/repos/legacy/app",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "svn.example.org/repos/legacy/app",
+			ServiceType: extsvc.TypeSubversion,
+			ServiceID:   "https://svn.example.org",
+		},
+		Sources: map[string]*types.SourceInfo{
+			"extsvc:subversion:1": {
+				ID:       "extsvc:subversion:1",
+				CloneURL: "https://svn.example.org/repos/legacy/app",
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{RelativePath: "/repos/legacy/app"},
+		Private:  true,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("unexpected repos (-want, +got):\n%s", d)
+	}
+}
+
+func listSubversionRepos(t *testing.T, conn *schema.SubversionConnection) []*types.Repo {
+	t.Helper()
+
+	config, err := json.Marshal(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	source, err := NewSubversionSource(ctx, &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindSubversion,
+		Config: extsvc.NewUnencryptedConfig(string(config)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan SourceResult)
+	go func() {
+		defer close(results)
+		source.ListRepos(ctx, results)
+	}()
+
+	var repos []*types.Repo
+	for r := range results {
+		if r.Err != nil {
+			t.Error(r.Err)
+		} else {
+			repos = append(repos, r.Repo)
+		}
+	}
+	return repos
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/internal/object"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// ResultsWriter writes the matches of a search job to the object store.
+// Flush must be called once all matches are written.
+type ResultsWriter interface {
+	MatchWriter
+	Flush() error
+}
+
+// NewResultsWriter returns a ResultsWriter for the results format and columns
+// of job. Shards are named like for NewJSONWriter.
+func NewResultsWriter(ctx context.Context, store object.Storage, prefix string, job *types.ExhaustiveSearchJob) (ResultsWriter, error) {
+	columns := job.ResultsColumns
+	if len(columns) == 0 {
+		columns = types.DefaultResultsColumns
+	}
+
+	switch job.ResultsFormat {
+	case types.ResultsFormatCSV:
+		return NewCSVWriter(ctx, store, prefix, columns), nil
+	case types.ResultsFormatNDJSON:
+		return NewNDJSONWriter(ctx, store, prefix, columns), nil
+	default:
+		return NewJSONWriter(ctx, store, prefix)
+	}
+}
+
+// NewCSVWriter creates a MatchCSVWriter which writes a CSV record with the
+// given columns per matched line.
+//
+// The shards do not contain a header so that they can be concatenated. The
+// header is written once when the results are downloaded.
+func NewCSVWriter(ctx context.Context, store object.Storage, prefix string, columns []string) *MatchCSVWriter {
+	m := &MatchCSVWriter{
+		w:       newShardWriter(ctx, store, prefix),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+	m.cw = csv.NewWriter(&m.buf)
+	return m
+}
+
+type MatchCSVWriter struct {
+	w       *bufferedWriter
+	columns []string
+
+	// buf holds the record being encoded by cw, so that a record is never
+	// split across shards.
+	buf    bytes.Buffer
+	cw     *csv.Writer
+	record []string
+}
+
+func (m *MatchCSVWriter) Flush() error {
+	return m.w.Flush()
+}
+
+func (m *MatchCSVWriter) Write(match result.Match) error {
+	for _, row := range matchRows(match) {
+		for i, column := range m.columns {
+			m.record[i] = row.stringValue(column)
+		}
+
+		m.buf.Reset()
+		if err := m.cw.Write(m.record); err != nil {
+			return err
+		}
+		m.cw.Flush()
+		if err := m.cw.Error(); err != nil {
+			return err
+		}
+
+		if err := m.w.AppendBytes(m.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewNDJSONWriter creates a MatchNDJSONWriter which writes a JSON object
+// with the given columns as keys per matched line. Columns without a value for
+// a match are null.
+func NewNDJSONWriter(ctx context.Context, store object.Storage, prefix string, columns []string) *MatchNDJSONWriter {
+	return &MatchNDJSONWriter{
+		w:       newShardWriter(ctx, store, prefix),
+		columns: columns,
+	}
+}
+
+type MatchNDJSONWriter struct {
+	w       *bufferedWriter
+	columns []string
+
+	buf bytes.Buffer
+}
+
+func (m *MatchNDJSONWriter) Flush() error {
+	return m.w.Flush()
+}
+
+func (m *MatchNDJSONWriter) Write(match result.Match) error {
+	for _, row := range matchRows(match) {
+		// We encode the object by hand since a map would not preserve the
+		// order of the columns.
+		m.buf.Reset()
+		m.buf.WriteByte('{')
+		for i, column := range m.columns {
+			if i > 0 {
+				m.buf.WriteByte(',')
+			}
+			key, err := json.Marshal(column)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(row.value(column))
+			if err != nil {
+				return err
+			}
+			m.buf.Write(key)
+			m.buf.WriteByte(':')
+			m.buf.Write(value)
+		}
+		m.buf.WriteString("}\n")
+
+		if err := m.w.AppendBytes(m.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/object/mocks"
+	exhaustivetypes "github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMatchCSVWriter(t *testing.T) {
+	mockStore := setupMockStore(t)
+
+	w, err := NewResultsWriter(context.Background(), mockStore, "dummy_prefix", &exhaustivetypes.ExhaustiveSearchJob{
+		ResultsFormat: exhaustivetypes.ResultsFormatCSV,
+	})
+	require.NoError(t, err)
+
+	fm := mkFileMatch(types.MinimalRepo{ID: 1, Name: "repo"}, "internal/search.go", 18, 27)
+	fm.CommitID = "deadbeef"
+	fm.ChunkMatches[0].Content = "func a(b, c string) {"
+	fm.ChunkMatches[0].ContentStart = result.Location{Line: 18}
+	fm.ChunkMatches[0].Ranges[0].Start.Column = 5
+
+	require.NoError(t, w.Write(fm))
+	require.NoError(t, w.Write(&result.RepoMatch{Name: "other", Rev: "main"}))
+	require.NoError(t, w.Flush())
+
+	autogold.Expect(`repo,deadbeef,internal/search.go,19,6,"func a(b, c string) {"
+repo,deadbeef,internal/search.go,28,1,
+other,main,,,,
+`).Equal(t, readBlob(t, mockStore, "dummy_prefix"))
+}
+
+func TestMatchNDJSONWriter(t *testing.T) {
+	mockStore := setupMockStore(t)
+
+	w, err := NewResultsWriter(context.Background(), mockStore, "dummy_prefix", &exhaustivetypes.ExhaustiveSearchJob{
+		ResultsFormat:  exhaustivetypes.ResultsFormatNDJSON,
+		ResultsColumns: []string{"repository", "commit", "author_date", "line", "message"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(&result.CommitMatch{
+		Repo: types.MinimalRepo{ID: 1, Name: "repo"},
+		Commit: gitdomain.Commit{
+			ID:      api.CommitID("deadbeef"),
+			Author:  gitdomain.Signature{Name: "alice", Email: "alice@example.com", Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			Message: "fix bug\n\nlong description",
+		},
+		MessagePreview: &result.MatchedString{Content: "fix bug\n\nlong description"},
+	}))
+	require.NoError(t, w.Flush())
+
+	autogold.Expect(`{"repository":"repo","commit":"deadbeef","author_date":"2024-01-02T03:04:05Z","line":null,"message":"fix bug\n\nlong description"}
+`).Equal(t, readBlob(t, mockStore, "dummy_prefix"))
+}
+
+func TestMatchRows_Diff(t *testing.T) {
+	diff := `a.go a.go
+@@ -10,3 +10,3 @@ func a() {
+ ctx
+-foo
++bar
+`
+	structured, err := result.ParseDiffString(diff)
+	require.NoError(t, err)
+
+	// Highlight "foo" and "bar", which are on lines 3 and 4 of the preview.
+	cm := &result.CommitMatch{
+		Repo:   types.MinimalRepo{Name: "repo"},
+		Commit: gitdomain.Commit{ID: "deadbeef"},
+		DiffPreview: &result.MatchedString{
+			Content: diff,
+			MatchedRanges: result.Ranges{
+				{Start: result.Location{Offset: 45, Line: 3, Column: 1}, End: result.Location{Offset: 48, Line: 3, Column: 4}},
+				{Start: result.Location{Offset: 50, Line: 4, Column: 1}, End: result.Location{Offset: 53, Line: 4, Column: 4}},
+			},
+		},
+		Diff: structured,
+	}
+
+	rows := matchRows(cm)
+	require.Len(t, rows, 2)
+
+	// Removed lines are numbered in the old file, added lines in the new file.
+	require.Equal(t, "a.go", rows[0].path)
+	require.Equal(t, 11, rows[0].line)
+	require.Equal(t, 1, rows[0].column)
+	require.Equal(t, "foo", rows[0].preview)
+
+	require.Equal(t, 11, rows[1].line)
+	require.Equal(t, "bar", rows[1].preview)
+	require.Equal(t, "deadbeef", rows[1].commit)
+}
+
+func readBlob(t *testing.T, store *mocks.MockStorage, key string) string {
+	t.Helper()
+
+	blob, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+
+	b, err := io.ReadAll(blob)
+	require.NoError(t, err)
+
+	return string(b)
+}
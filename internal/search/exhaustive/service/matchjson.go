@@ -17,6 +17,12 @@ import (
 // the shard number, except for the first shard where the shard number is
 // omitted.
 func NewJSONWriter(ctx context.Context, store object.Storage, prefix string) (*MatchJSONWriter, error) {
+	return &MatchJSONWriter{w: newShardWriter(ctx, store, prefix)}, nil
+}
+
+// newShardWriter returns a bufferedWriter which uploads a new shard to store
+// every 100 MiB.
+func newShardWriter(ctx context.Context, store object.Storage, prefix string) *bufferedWriter {
 	blobUploader := &blobUploader{
 		ctx:    ctx,
 		store:  store,
@@ -24,8 +30,7 @@ func NewJSONWriter(ctx context.Context, store object.Storage, prefix string) (*M
 		shard:  1,
 	}
 
-	return &MatchJSONWriter{
-		w: newBufferedWriter(1024*1024*100, blobUploader.write)}, nil
+	return newBufferedWriter(1024*1024*100, blobUploader.write)
 }
 
 type MatchJSONWriter struct {
@@ -107,6 +112,18 @@ func (j *bufferedWriter) Append(v any) error {
 	return nil
 }
 
+// AppendBytes adds p to the buffer. Like Append, p is never split across two
+// writes, so callers should pass complete records.
+func (j *bufferedWriter) AppendBytes(p []byte) error {
+	j.buf.Write(p)
+
+	if j.buf.Len() >= j.flushSize {
+		return j.Flush()
+	}
+
+	return nil
+}
+
 // Flush writes and resets the buffer if there is data to write.
 func (j *bufferedWriter) Flush() error {
 	if j.buf.Len() == 0 {
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// matchRow is a single row of the CSV and NDJSON results formats. A match
+// results in one row per matched line, so analysts can count and filter
+// matches without knowing about our match types.
+type matchRow struct {
+	repository string
+	revision   string
+	path       string
+	// line and column are 1-based. They are zero if the row has no position,
+	// for example for repository or path matches.
+	line    int
+	column  int
+	preview string
+
+	commit      string
+	author      string
+	authorEmail string
+	authorDate  time.Time
+	message     string
+}
+
+// value returns the value of column for the row. It returns nil for a column
+// that does not apply to the row.
+func (r *matchRow) value(column string) any {
+	switch column {
+	case "repository":
+		return r.repository
+	case "revision":
+		return r.revision
+	case "path":
+		return r.path
+	case "line":
+		if r.line == 0 {
+			return nil
+		}
+		return r.line
+	case "column":
+		if r.column == 0 {
+			return nil
+		}
+		return r.column
+	case "preview":
+		return r.preview
+	case "commit":
+		return r.commit
+	case "author":
+		return r.author
+	case "author_email":
+		return r.authorEmail
+	case "author_date":
+		if r.authorDate.IsZero() {
+			return nil
+		}
+		return r.authorDate.Format(time.RFC3339)
+	case "message":
+		return r.message
+	default:
+		return nil
+	}
+}
+
+// stringValue is value formatted for CSV, where missing values are empty.
+func (r *matchRow) stringValue(column string) string {
+	switch v := r.value(column).(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
+
+// matchRows flattens match into rows.
+func matchRows(match result.Match) []matchRow {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		return fileMatchRows(m)
+	case *result.RepoMatch:
+		return []matchRow{{repository: string(m.Name), revision: m.Rev}}
+	case *result.CommitMatch:
+		base := commitRow(m.Repo, m.Commit)
+		if m.DiffPreview == nil {
+			base.preview = m.Commit.Message.Subject()
+			return []matchRow{base}
+		}
+		var rows []matchRow
+		for _, hunk := range m.Hunks() {
+			rows = append(rows, hunkRows(base, hunk)...)
+		}
+		if len(rows) == 0 {
+			return []matchRow{base}
+		}
+		return rows
+	case *result.CommitDiffHunkMatch:
+		return hunkRows(commitRow(m.Repo, m.Commit), m)
+	case *result.OwnerMatch:
+		return []matchRow{{
+			repository: string(m.Repo.Name),
+			commit:     string(m.CommitID),
+			preview:    m.ResolvedOwner.Identifier(),
+		}}
+	default:
+		return nil
+	}
+}
+
+func fileMatchRows(fm *result.FileMatch) []matchRow {
+	base := matchRow{
+		repository: string(fm.Repo.Name),
+		revision:   string(fm.CommitID),
+		path:       fm.Path,
+		commit:     string(fm.CommitID),
+	}
+	if fm.InputRev != nil && *fm.InputRev != "" {
+		base.revision = *fm.InputRev
+	}
+
+	var rows []matchRow
+	for _, sym := range fm.Symbols {
+		row := base
+		row.line = sym.Symbol.Line
+		row.column = sym.Symbol.Character + 1
+		row.preview = sym.Symbol.Name
+		rows = append(rows, row)
+	}
+	for _, cm := range fm.ChunkMatches {
+		lines := strings.Split(cm.Content, "\n")
+		for _, rr := range cm.Ranges {
+			row := base
+			row.line = rr.Start.Line + 1
+			row.column = rr.Start.Column + 1
+			if i := rr.Start.Line - cm.ContentStart.Line; i >= 0 && i < len(lines) {
+				row.preview = lines[i]
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		// A path match.
+		rows = append(rows, base)
+	}
+	return rows
+}
+
+func commitRow(repo types.MinimalRepo, commit gitdomain.Commit) matchRow {
+	return matchRow{
+		repository:  string(repo.Name),
+		revision:    string(commit.ID),
+		commit:      string(commit.ID),
+		author:      commit.Author.Name,
+		authorEmail: commit.Author.Email,
+		authorDate:  commit.Author.Date,
+		message:     string(commit.Message),
+	}
+}
+
+// hunkRows returns a row per highlighted line of hunk, or a single row for the
+// whole hunk if it has no highlights. Lines are numbered in the new version of
+// the file, except for removed lines which only exist in the old version.
+func hunkRows(base matchRow, hunk *result.CommitDiffHunkMatch) []matchRow {
+	base.path = hunk.Path()
+
+	if len(hunk.Preview.MatchedRanges) == 0 {
+		row := base
+		row.line = hunk.Hunk.NewStart
+		if hunk.PathStatus() == result.Deleted {
+			row.line = hunk.Hunk.OldStart
+		}
+		row.preview = hunk.Hunk.Header
+		return []matchRow{row}
+	}
+
+	lineNumbers := make([]int, len(hunk.Hunk.Lines))
+	oldLine, newLine := hunk.Hunk.OldStart, hunk.Hunk.NewStart
+	for i, l := range hunk.Hunk.Lines {
+		if strings.HasPrefix(l, "-") {
+			lineNumbers[i] = oldLine
+			oldLine++
+			continue
+		}
+		lineNumbers[i] = newLine
+		if !strings.HasPrefix(l, "+") {
+			oldLine++
+		}
+		newLine++
+	}
+
+	rows := make([]matchRow, 0, len(hunk.Preview.MatchedRanges))
+	for _, rr := range hunk.Preview.MatchedRanges {
+		i := rr.Start.Line
+		if i < 0 || i >= len(hunk.Hunk.Lines) {
+			continue
+		}
+		row := base
+		row.line = lineNumbers[i]
+		// Drop the leading "+", "-" or " " of the diff line, which shifts the
+		// 0-based column of the highlight to a 1-based column in the file.
+		row.column = max(rr.Start.Column, 1)
+		if l := hunk.Hunk.Lines[i]; len(l) > 0 {
+			row.preview = l[1:]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
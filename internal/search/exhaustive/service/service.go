@@ -6,6 +6,8 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -61,6 +63,7 @@ type operations struct {
 	listSearchJobs           *observation.Operation
 	cancelSearchJob          *observation.Operation
 	getAggregateRepoRevState *observation.Operation
+	listRepoRevisions        *observation.Operation
 
 	getSearchJobResultsWriterTo operationWithWriterTo
 	getSearchJobLogsWriterTo    operationWithWriterTo
@@ -107,6 +110,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			listSearchJobs:           op("ListSearchJobs"),
 			cancelSearchJob:          op("CancelSearchJob"),
 			getAggregateRepoRevState: op("GetAggregateRepoRevState"),
+			listRepoRevisions:        op("ListSearchJobRepoRevisions"),
 
 			getSearchJobResultsWriterTo: operationWithWriterTo{
 				get:      op("GetSearchJobResultsWriterTo"),
@@ -130,9 +134,10 @@ func (s *Service) ValidateSearchJob(ctx context.Context, query string) error {
 	return err
 }
 
-func (s *Service) CreateSearchJob(ctx context.Context, query string) (_ *types.ExhaustiveSearchJob, err error) {
+func (s *Service) CreateSearchJob(ctx context.Context, query string, format types.ResultsFormat, columns []string) (_ *types.ExhaustiveSearchJob, err error) {
 	ctx, _, endObservation := s.operations.createSearchJob.With(ctx, &err, opAttrs(
 		attribute.String("query", query),
+		attribute.String("format", string(format)),
 	))
 	defer endObservation(1, observation.Args{})
 
@@ -147,6 +152,16 @@ func (s *Service) CreateSearchJob(ctx context.Context, query string) (_ *types.E
 		return nil, err
 	}
 
+	if format == "" {
+		format = types.ResultsFormatJSON
+	}
+	if len(columns) > 0 && format == types.ResultsFormatJSON {
+		return nil, errors.New("columns can only be selected for the csv and ndjson results formats")
+	}
+	if err := types.ValidateResultsColumns(columns); err != nil {
+		return nil, err
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -157,8 +172,10 @@ func (s *Service) CreateSearchJob(ctx context.Context, query string) (_ *types.E
 	// ExhaustiveSearchJob type has lots of fields, but reading the store
 	// implementation only two fields are read.
 	jobID, err := tx.CreateExhaustiveSearchJob(ctx, types.ExhaustiveSearchJob{
-		InitiatorID: actor.UID,
-		Query:       query,
+		InitiatorID:    actor.UID,
+		Query:          query,
+		ResultsFormat:  format,
+		ResultsColumns: columns,
 	})
 	if err != nil {
 		return nil, err
@@ -348,7 +365,9 @@ func (s *Service) DeleteSearchJob(ctx context.Context, id int64) (err error) {
 }
 
 // GetSearchJobResultsWriterTo returns a WriterTo which can be called once to
-// write all CSVs associated with a search job to the given writer for job id.
+// write all results associated with a search job to the given writer for job
+// id. The results are written in the format of the job, see
+// types.ExhaustiveSearchJob.ResultsFormat.
 // Note: ctx is used by WriterTo.
 //
 // io.WriterTo is a specialization of an io.Reader. We expect callers of this
@@ -359,11 +378,23 @@ func (s *Service) GetSearchJobResultsWriterTo(parentCtx context.Context, id int6
 		attribute.Int64("id", id)))
 	defer endObservation(1, observation.Args{})
 
-	// 🚨 SECURITY: only someone with access to the job may copy the blobs
-	if err := s.store.UserHasAccess(ctx, id); err != nil {
+	// 🚨 SECURITY: only someone with access to the job may copy the blobs.
+	// GetExhaustiveSearchJob checks access.
+	job, err := s.store.GetExhaustiveSearchJob(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
+	// The shards of CSV results don't have a header, so we write it once
+	// before concatenating them.
+	var header []string
+	if job.ResultsFormat == types.ResultsFormatCSV {
+		header = job.ResultsColumns
+		if len(header) == 0 {
+			header = types.DefaultResultsColumns
+		}
+	}
+
 	iter, err := s.uploadStore.List(ctx, getPrefix(id))
 	if err != nil {
 		return nil, err
@@ -376,7 +407,7 @@ func (s *Service) GetSearchJobResultsWriterTo(parentCtx context.Context, id int6
 			endObservation(1, opAttrs(attribute.Int64("bytesWritten", n)))
 		}()
 
-		return writeSearchJobJSON(ctx, iter, s.uploadStore, w)
+		return writeSearchJobResults(ctx, iter, s.uploadStore, header, w)
 	}), nil
 }
 
@@ -413,7 +444,87 @@ func (s *Service) GetAggregateRepoRevState(ctx context.Context, id int64) (_ *ty
 	return &stats, nil
 }
 
-func writeSearchJobJSON(ctx context.Context, iter *iterator.Iterator[string], uploadStore object.Storage, w io.Writer) (int64, error) {
+// ListSearchJobRepoRevisions returns the repository revisions in which the
+// completed search job with the given ID found results. The results are not
+// read: the repo revision jobs that found results are the ones that uploaded
+// at least one object.
+func (s *Service) ListSearchJobRepoRevisions(ctx context.Context, id int64) (_ []types.RepositoryRevision, err error) {
+	ctx, _, endObservation := s.operations.listRepoRevisions.With(ctx, &err, opAttrs(
+		attribute.Int64("id", id)))
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: only someone with access to the job may list the results.
+	// GetExhaustiveSearchJob checks access.
+	job, err := s.store.GetExhaustiveSearchJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.AggState != types.JobStateCompleted {
+		return nil, errors.Newf("search job %d is %s, only completed search jobs can be used", id, job.AggState)
+	}
+
+	iter, err := s.uploadStore.List(ctx, getPrefix(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var revisionJobIDs []int64
+	seen := make(map[int64]struct{})
+	for iter.Next() {
+		revisionJobID, ok := parseRevisionJobID(id, iter.Current())
+		if !ok {
+			continue
+		}
+		if _, ok := seen[revisionJobID]; ok {
+			continue
+		}
+		seen[revisionJobID] = struct{}{}
+		revisionJobIDs = append(revisionJobIDs, revisionJobID)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if len(revisionJobIDs) == 0 {
+		return nil, nil
+	}
+
+	return s.store.ListSearchJobRepoRevisions(ctx, id, revisionJobIDs)
+}
+
+// parseRevisionJobID returns the ID of the repo revision job that uploaded the
+// results object with the given key. Keys have the form
+// "<search job id>-<revision job id>", followed by "-<shard>" for all but the
+// first shard.
+func parseRevisionJobID(searchJobID int64, key string) (int64, bool) {
+	rest, ok := strings.CutPrefix(key, getPrefix(searchJobID))
+	if !ok {
+		return 0, false
+	}
+	rest, _, _ = strings.Cut(rest, "-")
+	id, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// writeSearchJobResults concatenates the objects listed by iter to w. If
+// header is non-empty it is written as a CSV record first.
+func writeSearchJobResults(ctx context.Context, iter *iterator.Iterator[string], uploadStore object.Storage, header []string, w io.Writer) (int64, error) {
+	var n int64
+	if len(header) > 0 {
+		writeCounter := &writeCounter{w: w}
+		cw := csv.NewWriter(writeCounter)
+		if err := cw.Write(header); err != nil {
+			return writeCounter.n, err
+		}
+		cw.Flush()
+		n = writeCounter.n
+		if err := cw.Error(); err != nil {
+			return n, err
+		}
+	}
+
 	// keep a single bufio.Reader so we can reuse its buffer.
 	var br bufio.Reader
 
@@ -429,13 +540,12 @@ func writeSearchJobJSON(ctx context.Context, iter *iterator.Iterator[string], up
 		return br.WriteTo(w)
 	}
 
-	var n int64
 	for iter.Next() {
 		key := iter.Current()
 		m, err := writeKey(key)
 		n += m
 		if err != nil {
-			return n, errors.Wrapf(err, "writing JSON for key %q", key)
+			return n, errors.Wrapf(err, "writing results for key %q", key)
 		}
 	}
 
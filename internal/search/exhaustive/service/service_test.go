@@ -30,10 +30,47 @@ func Test_copyBlobs(t *testing.T) {
 
 	w := &bytes.Buffer{}
 
-	n, err := writeSearchJobJSON(context.Background(), keysIter, blobstore, w)
+	n, err := writeSearchJobResults(context.Background(), keysIter, blobstore, nil, w)
 	require.NoError(t, err)
 	require.Equal(t, int64(72), n)
 
 	want := "{\"Key\":\"a\"}\n{\"Key\":\"b\"}\n{\"Key\":\"c\"}\n{\"Key\":\"d\"}\n{\"Key\":\"e\"}\n{\"Key\":\"f\"}\n"
 	require.Equal(t, want, w.String())
 }
+
+func Test_copyBlobsWithHeader(t *testing.T) {
+	keysIter := iterator.From([]string{"a", "b"})
+
+	blobs := map[string]io.Reader{
+		"a": bytes.NewReader([]byte("r,a.go\n")),
+		"b": bytes.NewReader([]byte("r,\"b,c.go\"\n")),
+	}
+
+	blobstore := mocks.NewMockStorage()
+	blobstore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(blobs[key]), nil
+	})
+
+	w := &bytes.Buffer{}
+
+	n, err := writeSearchJobResults(context.Background(), keysIter, blobstore, []string{"repository", "path"}, w)
+	require.NoError(t, err)
+
+	want := "repository,path\nr,a.go\nr,\"b,c.go\"\n"
+	require.Equal(t, want, w.String())
+	require.Equal(t, int64(len(want)), n)
+}
+
+func Test_parseRevisionJobID(t *testing.T) {
+	for key, want := range map[string]int64{
+		"12-34":      34,
+		"12-34-2":    34,
+		"12-":        0,
+		"123-4":      0,
+		"log-12.csv": 0,
+	} {
+		id, ok := parseRevisionJobID(12, key)
+		require.Equal(t, want, id, key)
+		require.Equal(t, want != 0, ok, key)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -61,6 +62,8 @@ var exhaustiveSearchJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("is_aggregated"),
+	sqlf.Sprintf("results_format"),
+	sqlf.Sprintf("results_columns"),
 }
 
 func (s *Store) CreateExhaustiveSearchJob(ctx context.Context, job types.ExhaustiveSearchJob) (_ int64, err error) {
@@ -82,9 +85,14 @@ func (s *Store) CreateExhaustiveSearchJob(ctx context.Context, job types.Exhaust
 		return 0, err
 	}
 
+	format := job.ResultsFormat
+	if format == "" {
+		format = types.ResultsFormatJSON
+	}
+
 	return basestore.ScanAny[int64](s.Store.QueryRow(
 		ctx,
-		sqlf.Sprintf(createExhaustiveSearchJobQueryFmtr, job.Query, job.InitiatorID),
+		sqlf.Sprintf(createExhaustiveSearchJobQueryFmtr, job.Query, job.InitiatorID, format, pq.Array(job.ResultsColumns)),
 	))
 }
 
@@ -95,8 +103,8 @@ var MissingQueryErr = errors.New("missing query")
 var MissingInitiatorIDErr = errors.New("missing initiator ID")
 
 const createExhaustiveSearchJobQueryFmtr = `
-INSERT INTO exhaustive_search_jobs (query, initiator_id)
-VALUES (%s, %s)
+INSERT INTO exhaustive_search_jobs (query, initiator_id, results_format, results_columns)
+VALUES (%s, %s, %s, %s)
 RETURNING id
 `
 
@@ -551,6 +559,8 @@ func defaultScanTargets(job *types.ExhaustiveSearchJob) []any {
 		&job.CreatedAt,
 		&job.UpdatedAt,
 		&job.IsAggregated,
+		&job.ResultsFormat,
+		pq.Array(&job.ResultsColumns),
 	}
 }
 
@@ -154,7 +154,7 @@ func TestStore_GetAndListSearchJobs(t *testing.T) {
 	jobs := []types.ExhaustiveSearchJob{
 		{InitiatorID: userID, Query: "repo:job1"},
 		{InitiatorID: userID, Query: "repo:job2"},
-		{InitiatorID: userID, Query: "repo:job3"},
+		{InitiatorID: userID, Query: "repo:job3", ResultsFormat: types.ResultsFormatCSV, ResultsColumns: []string{"path", "commit"}},
 	}
 
 	// Create jobs
@@ -175,6 +175,12 @@ func TestStore_GetAndListSearchJobs(t *testing.T) {
 		assert.Equal(t, haveJob.ID, job.ID)
 		assert.Equal(t, haveJob.Query, job.Query)
 		assert.Equal(t, haveJob.State, types.JobStateQueued)
+		if job.ResultsFormat == "" {
+			assert.Equal(t, types.ResultsFormatJSON, haveJob.ResultsFormat)
+		} else {
+			assert.Equal(t, job.ResultsFormat, haveJob.ResultsFormat)
+		}
+		assert.Equal(t, job.ResultsColumns, haveJob.ResultsColumns)
 		assert.NotZero(t, haveJob.CreatedAt)
 		assert.NotZero(t, haveJob.UpdatedAt)
 	}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -90,26 +92,76 @@ RETURNING id
 `
 
 const getQueryRepoRevFmtStr = `
-SELECT sj.id, sj.initiator_id, sj.query, srj.repo_id, srj.ref_spec
+SELECT sj.id, sj.initiator_id, sj.query, sj.results_format, sj.results_columns, srj.repo_id, srj.ref_spec
 FROM exhaustive_search_repo_jobs srj
 JOIN exhaustive_search_jobs sj ON srj.search_job_id = sj.id
 WHERE srj.id = %s
 `
 
+// GetQueryRepoRev returns the search job and the repository revision to search
+// for job. Only the fields of the search job needed to run the search are set.
 func (s *Store) GetQueryRepoRev(ctx context.Context, job *types.ExhaustiveSearchRepoRevisionJob) (
-	id int64,
-	query string,
+	searchJob *types.ExhaustiveSearchJob,
 	repoRev types.RepositoryRevision,
-	initiatorID int32,
 	err error,
 ) {
+	searchJob = &types.ExhaustiveSearchJob{}
 	row := s.QueryRow(ctx, sqlf.Sprintf(getQueryRepoRevFmtStr, job.SearchRepoJobID))
-	err = row.Scan(&id, &initiatorID, &query, &repoRev.Repository, &repoRev.RevisionSpecifiers)
+	err = row.Scan(
+		&searchJob.ID,
+		&searchJob.InitiatorID,
+		&searchJob.Query,
+		&searchJob.ResultsFormat,
+		pq.Array(&searchJob.ResultsColumns),
+		&repoRev.Repository,
+		&repoRev.RevisionSpecifiers,
+	)
 	if err != nil {
-		return 0, "", types.RepositoryRevision{}, -1, err
+		return nil, types.RepositoryRevision{}, err
 	}
 	repoRev.Revision = job.Revision
-	return id, query, repoRev, initiatorID, nil
+	return searchJob, repoRev, nil
+}
+
+const listSearchJobRepoRevisionsFmtStr = `
+SELECT srj.repo_id, srj.ref_spec, rrj.revision
+FROM exhaustive_search_repo_revision_jobs rrj
+JOIN exhaustive_search_repo_jobs srj ON rrj.search_repo_job_id = srj.id
+WHERE srj.search_job_id = %s AND rrj.id = ANY(%s) AND rrj.state = 'completed'
+ORDER BY rrj.id
+`
+
+// ListSearchJobRepoRevisions returns the repository revisions searched by the
+// completed repo revision jobs with the given IDs that belong to the search job
+// with the given ID.
+func (s *Store) ListSearchJobRepoRevisions(ctx context.Context, id int64, revisionJobIDs []int64) (_ []types.RepositoryRevision, err error) {
+	ctx, _, endObservation := s.operations.listSearchJobRepoRevisions.With(ctx, &err, opAttrs(
+		attribute.Int64("ID", id),
+		attribute.Int("revisionJobs", len(revisionJobIDs)),
+	))
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: only someone with access to the job may list what it searched
+	if err := s.UserHasAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(listSearchJobRepoRevisionsFmtStr, id, pq.Array(revisionJobIDs)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var repoRevs []types.RepositoryRevision
+	for rows.Next() {
+		var repoRev types.RepositoryRevision
+		if err := rows.Scan(&repoRev.Repository, &repoRev.RevisionSpecifiers, &repoRev.Revision); err != nil {
+			return nil, err
+		}
+		repoRevs = append(repoRevs, repoRev)
+	}
+
+	return repoRevs, rows.Err()
 }
 
 func scanRevSearchJob(sc dbutil.Scanner) (*types.ExhaustiveSearchRepoRevisionJob, error) {
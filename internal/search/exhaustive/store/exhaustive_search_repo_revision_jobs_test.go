@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,3 +95,82 @@ func TestStore_CreateExhaustiveSearchRepoRevisionJob(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_ListSearchJobRepoRevisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	bs := basestore.NewWithHandle(db.Handle())
+
+	userID, err := storetest.CreateUser(bs, "alice")
+	require.NoError(t, err)
+	otherUserID, err := storetest.CreateUser(bs, "bob")
+	require.NoError(t, err)
+	repoID, err := storetest.CreateRepo(db, "repo-test")
+	require.NoError(t, err)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{
+		UID: userID,
+	})
+
+	s := store.New(db, observation.TestContextTB(t))
+
+	searchJobID, err := s.CreateExhaustiveSearchJob(
+		ctx,
+		types.ExhaustiveSearchJob{InitiatorID: userID, Query: "repo:^repo-test$ ListSearchJobRepoRevisions"},
+	)
+	require.NoError(t, err)
+
+	repoJobID, err := s.CreateExhaustiveSearchRepoJob(
+		ctx,
+		types.ExhaustiveSearchRepoJob{SearchJobID: searchJobID, RepoID: repoID, RefSpec: "main:dev"},
+	)
+	require.NoError(t, err)
+
+	var revJobIDs []int64
+	for _, rev := range []string{"main", "dev", "failed"} {
+		revJobID, err := s.CreateExhaustiveSearchRepoRevisionJob(
+			ctx,
+			types.ExhaustiveSearchRepoRevisionJob{SearchRepoJobID: repoJobID, Revision: rev},
+		)
+		require.NoError(t, err)
+		state := types.JobStateCompleted
+		if rev == "failed" {
+			state = types.JobStateFailed
+		}
+		err = s.Exec(ctx, sqlf.Sprintf("UPDATE exhaustive_search_repo_revision_jobs SET state = %s WHERE id = %s", state, revJobID))
+		require.NoError(t, err)
+		revJobIDs = append(revJobIDs, revJobID)
+	}
+
+	t.Run("completed jobs", func(t *testing.T) {
+		have, err := s.ListSearchJobRepoRevisions(ctx, searchJobID, revJobIDs)
+		require.NoError(t, err)
+
+		spec := types.RepositoryRevSpecs{Repository: repoID, RevisionSpecifiers: "main:dev"}
+		want := []types.RepositoryRevision{
+			{RepositoryRevSpecs: spec, Revision: "main"},
+			{RepositoryRevSpecs: spec, Revision: "dev"},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("subset of jobs", func(t *testing.T) {
+		have, err := s.ListSearchJobRepoRevisions(ctx, searchJobID, revJobIDs[1:2])
+		require.NoError(t, err)
+		require.Len(t, have, 1)
+		assert.Equal(t, "dev", have[0].Revision)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		otherCtx := actor.WithActor(context.Background(), &actor.Actor{
+			UID: otherUserID,
+		})
+		_, err := s.ListSearchJobRepoRevisions(otherCtx, searchJobID, revJobIDs)
+		require.Error(t, err)
+	})
+}
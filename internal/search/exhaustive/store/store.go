@@ -69,6 +69,7 @@ type operations struct {
 	createExhaustiveSearchRepoJob         *observation.Operation
 	createExhaustiveSearchRepoRevisionJob *observation.Operation
 	getAggregateRepoRevState              *observation.Operation
+	listSearchJobRepoRevisions            *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -102,5 +103,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		createExhaustiveSearchRepoJob:         op("CreateExhaustiveSearchRepoJob"),
 		createExhaustiveSearchRepoRevisionJob: op("CreateExhaustiveSearchRepoRevisionJob"),
 		getAggregateRepoRevState:              op("GetAggregateRepoRevState"),
+		listSearchJobRepoRevisions:            op("ListSearchJobRepoRevisions"),
 	}
 }
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // ExhaustiveSearchJob is a job that runs the exhaustive search.
@@ -18,6 +22,13 @@ type ExhaustiveSearchJob struct {
 
 	Query string
 
+	// ResultsFormat is the format the results of the job are written in.
+	ResultsFormat ResultsFormat
+
+	// ResultsColumns are the columns written for each match by the CSV and
+	// NDJSON formats. Empty means DefaultResultsColumns.
+	ResultsColumns []string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -38,3 +49,80 @@ func (j *ExhaustiveSearchJob) RecordID() int {
 func (j *ExhaustiveSearchJob) RecordUID() string {
 	return strconv.FormatInt(j.ID, 10)
 }
+
+// ResultsFormat is the format the results of a search job are stored and
+// downloaded in.
+type ResultsFormat string
+
+const (
+	// ResultsFormatJSON writes one streaming API match event per line. This is
+	// the default and the only format the search jobs UI can preview.
+	ResultsFormatJSON ResultsFormat = "json"
+	// ResultsFormatNDJSON writes one flat JSON object per matched line, with
+	// a key per selected column.
+	ResultsFormatNDJSON ResultsFormat = "ndjson"
+	// ResultsFormatCSV writes one row per matched line, with a header of the
+	// selected columns.
+	ResultsFormatCSV ResultsFormat = "csv"
+)
+
+// ParseResultsFormat returns the ResultsFormat for s, which is
+// case-insensitive. An empty string is the default format.
+func ParseResultsFormat(s string) (ResultsFormat, error) {
+	switch f := ResultsFormat(strings.ToLower(s)); f {
+	case "":
+		return ResultsFormatJSON, nil
+	case ResultsFormatJSON, ResultsFormatNDJSON, ResultsFormatCSV:
+		return f, nil
+	default:
+		return "", errors.Errorf("unknown results format %q, expected one of json, ndjson or csv", s)
+	}
+}
+
+// FileExtension is the extension of a downloaded results file, without the
+// leading dot.
+func (f ResultsFormat) FileExtension() string {
+	switch f {
+	case ResultsFormatCSV:
+		return "csv"
+	case ResultsFormatNDJSON:
+		return "ndjson"
+	default:
+		return "jsonl"
+	}
+}
+
+// ContentType is the MIME type of a downloaded results file.
+func (f ResultsFormat) ContentType() string {
+	switch f {
+	case ResultsFormatCSV:
+		return "text/csv"
+	case ResultsFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/jsonlines"
+	}
+}
+
+// DefaultResultsColumns are the columns written when a job does not select
+// any.
+var DefaultResultsColumns = []string{"repository", "revision", "path", "line", "column", "preview"}
+
+// CommitResultsColumns are optional columns with commit metadata. Only the
+// commit column is set for file results, the others are only set for commit
+// and diff results.
+var CommitResultsColumns = []string{"commit", "author", "author_email", "author_date", "message"}
+
+// ValidateResultsColumns returns an error if columns contains an unknown or
+// duplicate column.
+func ValidateResultsColumns(columns []string) error {
+	for i, c := range columns {
+		if !slices.Contains(DefaultResultsColumns, c) && !slices.Contains(CommitResultsColumns, c) {
+			return errors.Errorf("unknown results column %q", c)
+		}
+		if slices.Contains(columns[:i], c) {
+			return errors.Errorf("duplicate results column %q", c)
+		}
+	}
+	return nil
+}
@@ -37,6 +37,7 @@ var validSelectors = object{
 		"diff": object{
 			"added":   nil,
 			"removed": nil,
+			"hunk":    nil,
 		},
 	},
 	Content: nil,
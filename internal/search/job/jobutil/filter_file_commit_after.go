@@ -0,0 +1,184 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasCommitAfterJob creates a filter job to post-filter results for the file:has.commit.after() predicate.
+//
+// A file passes if its most recent commit is after every time in include, and not after any time in exclude.
+func NewFileHasCommitAfterJob(child job.Job, include, exclude []time.Time) job.Job {
+	return &fileHasCommitAfterJob{
+		child:   child,
+		include: include,
+		exclude: exclude,
+		cache:   fileLastCommitCache,
+	}
+}
+
+type fileHasCommitAfterJob struct {
+	child job.Job
+
+	include []time.Time
+	exclude []time.Time
+
+	cache *lru.Cache[fileAtCommit, time.Time]
+}
+
+// fileAtCommit identifies a file in a repository at a given commit. The last
+// commit that touched it can never change, so lookups are cached for the
+// lifetime of the process and shared between searches.
+type fileAtCommit struct {
+	repo     api.RepoName
+	commitID api.CommitID
+	path     string
+}
+
+var fileLastCommitCache = func() *lru.Cache[fileAtCommit, time.Time] {
+	cache, _ := lru.New[fileAtCommit, time.Time](100_000)
+	return cache
+}()
+
+func (j *fileHasCommitAfterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer finish(alert, err)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, res := range event.Results {
+			// Filter out any result that is not a file
+			if fm, ok := res.(*result.FileMatch); ok {
+				// We send one commit log request per uncached file path.
+				// We should quit early on context deadline exceeded.
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					mu.Lock()
+					errs = errors.Append(errs, ctx.Err())
+					mu.Unlock()
+					break
+				}
+				lastCommit, err := j.lastCommitTime(ctx, clients.Gitserver, fm)
+				if err != nil {
+					mu.Lock()
+					errs = errors.Append(errs, err)
+					mu.Unlock()
+					continue
+				}
+
+				if !j.Filtered(lastCommit) {
+					continue
+				}
+
+				filtered = append(filtered, fm)
+			}
+		}
+
+		event.Results = filtered
+
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileHasCommitAfterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileHasCommitAfterJob) Name() string {
+	return "FileHasCommitAfterFilterJob"
+}
+
+func (j *fileHasCommitAfterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasCommitAfterJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			attribute.StringSlice("includeAfter", timesToStr(j.include)),
+			attribute.StringSlice("excludeAfter", timesToStr(j.exclude)),
+		)
+	}
+	return res
+}
+
+func timesToStr(times []time.Time) []string {
+	res := make([]string, 0, len(times))
+	for _, t := range times {
+		res = append(res, t.Format(time.RFC3339))
+	}
+	return res
+}
+
+// lastCommitTime returns the commit time of the most recent commit that
+// touched the file at the revision it was found at.
+func (j *fileHasCommitAfterJob) lastCommitTime(ctx context.Context, client gitserver.Client, fm *result.FileMatch) (time.Time, error) {
+	key := fileAtCommit{repo: fm.Repo.Name, commitID: fm.CommitID, path: fm.Path}
+	if t, ok := j.cache.Get(key); ok {
+		return t, nil
+	}
+
+	commits, err := client.Commits(ctx, fm.Repo.Name, gitserver.CommitsOptions{
+		Ranges: []string{string(fm.CommitID)},
+		Path:   fm.Path,
+		N:      1,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// A file without any commits can only come from an incomplete history,
+	// in which case we treat it as never having been modified.
+	var t time.Time
+	if len(commits) > 0 {
+		t = commits[0].Author.Date
+		if commits[0].Committer != nil {
+			t = commits[0].Committer.Date
+		}
+	}
+
+	j.cache.Add(key, t)
+	return t, nil
+}
+
+// Filtered returns true if a file last modified at lastCommit passes all filters.
+func (j *fileHasCommitAfterJob) Filtered(lastCommit time.Time) bool {
+	for _, after := range j.include {
+		if !lastCommit.After(after) {
+			return false
+		}
+	}
+	for _, after := range j.exclude {
+		if lastCommit.After(after) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileHasCommitAfterJob(t *testing.T) {
+	r := func(ms ...result.Match) (res result.Matches) {
+		for _, m := range ms {
+			res = append(res, m)
+		}
+		return res
+	}
+
+	fm := func() *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: "repo"},
+				Path:     "path",
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	commit := func(authored, committed time.Time) []*gitdomain.Commit {
+		return []*gitdomain.Commit{{
+			Author:    gitdomain.Signature{Date: authored},
+			Committer: &gitdomain.Signature{Date: committed},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		include     []time.Time
+		exclude     []time.Time
+		matches     result.Match
+		commits     []*gitdomain.Commit
+		outputEvent streaming.SearchEvent
+	}{{
+		name:        "include commit after",
+		include:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(11), day(11)),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include commit before",
+		include:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(9), day(9)),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "include uses committer date",
+		include:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(1), day(11)),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "exclude commit after",
+		exclude:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(11), day(11)),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude commit before",
+		exclude:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(9), day(9)),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include and exclude bound a window",
+		include:     []time.Time{day(5)},
+		exclude:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     commit(day(7), day(7)),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "every include must match",
+		include:     []time.Time{day(5), day(10)},
+		matches:     fm(),
+		commits:     commit(day(7), day(7)),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "file without commits is stale",
+		exclude:     []time.Time{day(10)},
+		matches:     fm(),
+		commits:     nil,
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "not all matches are files",
+		include:     []time.Time{day(10)},
+		matches:     &result.CommitMatch{},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fileLastCommitCache.Purge()
+
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: r(tc.matches)})
+				return nil, nil
+			})
+
+			gitServerClient := gitserver.NewMockClient()
+			gitServerClient.CommitsFunc.PushReturn(tc.commits, nil)
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			j := NewFileHasCommitAfterJob(childJob, tc.include, tc.exclude)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitServerClient}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+		})
+	}
+
+	t.Run("commit log is cached per file and commit", func(t *testing.T) {
+		fileLastCommitCache.Purge()
+
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: r(fm())})
+			return nil, nil
+		})
+
+		gitServerClient := gitserver.NewMockClient()
+		gitServerClient.CommitsFunc.SetDefaultReturn(commit(day(11), day(11)), nil)
+
+		for range 3 {
+			j := NewFileHasCommitAfterJob(childJob, []time.Time{day(10)}, nil)
+			_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitServerClient}, streaming.NewNullStream())
+			require.NoError(t, err)
+		}
+		require.Len(t, gitServerClient.CommitsFunc.History(), 1)
+	})
+}
@@ -0,0 +1,128 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileSizeFilterJob creates a filter job to post-filter the results of
+// indexed search for the size: filter. Zoekt does not store the size of the
+// files it indexes, so each matched file is looked up in gitserver instead.
+// Searcher evaluates size: itself, so its jobs do not need this filter.
+func NewFileSizeFilterJob(child job.Job, size query.IntRange) job.Job {
+	return &fileSizeFilterJob{
+		child: child,
+		size:  size,
+		cache: fileSizeCache,
+	}
+}
+
+type fileSizeFilterJob struct {
+	child job.Job
+	size  query.IntRange
+
+	cache *lru.Cache[fileAtCommit, int64]
+}
+
+var fileSizeCache = func() *lru.Cache[fileAtCommit, int64] {
+	cache, _ := lru.New[fileAtCommit, int64](100_000)
+	return cache
+}()
+
+func (j *fileSizeFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer finish(alert, err)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, res := range event.Results {
+			fm, ok := res.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				mu.Lock()
+				errs = errors.Append(errs, ctx.Err())
+				mu.Unlock()
+				break
+			}
+			size, err := j.fileSize(ctx, clients.Gitserver, fm)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			if j.size.Contains(size) {
+				filtered = append(filtered, fm)
+			}
+		}
+
+		event.Results = filtered
+
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileSizeFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileSizeFilterJob) Name() string {
+	return "FileSizeFilterJob"
+}
+
+func (j *fileSizeFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileSizeFilterJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res, attribute.Stringer("size", j.size))
+	}
+	return res
+}
+
+// fileSize returns the size in bytes of the file at the revision it was
+// found at.
+func (j *fileSizeFilterJob) fileSize(ctx context.Context, client gitserver.Client, fm *result.FileMatch) (int64, error) {
+	key := fileAtCommit{repo: fm.Repo.Name, commitID: fm.CommitID, path: fm.Path}
+	if size, ok := j.cache.Get(key); ok {
+		return size, nil
+	}
+
+	fi, err := client.Stat(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+	if err != nil {
+		return 0, err
+	}
+
+	j.cache.Add(key, fi.Size())
+	return fi.Size(), nil
+}
@@ -0,0 +1,105 @@
+package jobutil
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileSizeFilterJob(t *testing.T) {
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: "repo"},
+				Path:     path,
+				CommitID: "commitID",
+			},
+		}
+	}
+	sizes := map[string]int64{"small": 10, "medium": 2048, "large": 1 << 20}
+
+	fileSizeCache.Purge()
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{fm("small"), fm("medium"), fm("large"), &result.CommitMatch{}}})
+		return nil, nil
+	})
+
+	gitServerClient := gitserver.NewMockClient()
+	gitServerClient.StatFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (fs.FileInfo, error) {
+		return &fileutil.FileInfo{Name_: path, Size_: sizes[path]}, nil
+	})
+
+	size, err := query.ParseSizeRange(">1kb")
+	require.NoError(t, err)
+
+	for range 2 {
+		var resultEvent streaming.SearchEvent
+		j := NewFileSizeFilterJob(childJob, size)
+		alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitServerClient}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			resultEvent = ev
+		}))
+		require.Nil(t, alert)
+		require.NoError(t, err)
+		require.Equal(t, result.Matches{fm("medium"), fm("large")}, resultEvent.Results)
+	}
+
+	// Sizes are cached per file and commit.
+	require.Len(t, gitServerClient.StatFunc.History(), 3)
+}
+
+func TestFileSizeFilterJob_Limit(t *testing.T) {
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: "repo"},
+				Path:     path,
+				CommitID: "commitID",
+			},
+		}
+	}
+	sizes := map[string]int64{"small1": 10, "small2": 10, "large1": 1 << 20, "large2": 1 << 20, "large3": 1 << 20}
+
+	fileSizeCache.Purge()
+
+	// The files that are filtered out come first, as they would if Zoekt
+	// ranked them higher.
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		for _, path := range []string{"small1", "small2", "large1", "large2", "large3"} {
+			s.Send(streaming.SearchEvent{Results: result.Matches{fm(path)}})
+		}
+		return nil, nil
+	})
+
+	gitServerClient := gitserver.NewMockClient()
+	gitServerClient.StatFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (fs.FileInfo, error) {
+		return &fileutil.FileInfo{Name_: path, Size_: sizes[path]}, nil
+	})
+
+	size, err := query.ParseSizeRange(">1kb")
+	require.NoError(t, err)
+
+	// The limit counts only the files that pass the filter.
+	var results result.Matches
+	j := NewLimitJob(2, NewFileSizeFilterJob(childJob, size))
+	_, err = j.Run(context.Background(), job.RuntimeClients{Gitserver: gitServerClient}, streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		results = append(results, ev.Results...)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, result.Matches{fm("large1"), fm("large2")}, results)
+}
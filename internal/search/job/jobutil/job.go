@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	ownsearch "github.com/sourcegraph/sourcegraph/internal/own/search"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/codycontext"
@@ -225,6 +226,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.commit.after() post-search filter
+		if includeAfter, excludeAfter, ok := isCommitAfterSearch(b); ok {
+			basicJob = NewFileHasCommitAfterJob(basicJob, parseTimeRefs(includeAfter), parseTimeRefs(excludeAfter))
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
@@ -566,6 +573,16 @@ func computeFileMatchLimit(b query.Basic, defaultLimit int) int {
 		// This is the int equivalent of count:all.
 		return query.CountAllLimit
 	}
+	if _, _, ok := isCommitAfterSearch(b); ok {
+		// This is the int equivalent of count:all.
+		return query.CountAllLimit
+	}
+	if !b.FileSize().IsZero() {
+		// Zoekt can't evaluate size:, so it is applied to the results of
+		// indexed search by FileSizeFilterJob. Zoekt would otherwise stop at
+		// the limit before any of the files it found are filtered out.
+		return query.CountAllLimit
+	}
 	if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 		sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 		if isSelectOwnersSearch(sp) {
@@ -596,6 +613,22 @@ func isContributorSearch(b query.Basic) (include, exclude []string, ok bool) {
 	return nil, nil, false
 }
 
+func isCommitAfterSearch(b query.Basic) (include, exclude []string, ok bool) {
+	if includeAfter, excludeAfter := b.FileHasCommitAfter(); len(includeAfter) > 0 || len(excludeAfter) > 0 {
+		return includeAfter, excludeAfter, true
+	}
+	return nil, nil, false
+}
+
+func parseTimeRefs(refs []string) (res []time.Time) {
+	now := time.Now()
+	for _, ref := range refs {
+		t, _ := gitdomain.ParseGitDate(ref, func() time.Time { return now }) // Invariant: ref is validated
+		res = append(res, t)
+	}
+	return res
+}
+
 func contributorsAsRegexp(contributors []string, isCaseSensitive bool) (res []*regexp.Regexp) {
 	for _, pattern := range contributors {
 		if isCaseSensitive {
@@ -703,7 +736,8 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Fea
 	q := protocol.FromJobNode(b.Pattern)
 	if p, ok := q.(*protocol.PatternNode); ok {
 		if p.Value == "" && len(filesExclude) == 0 && len(filesInclude) == 0 &&
-			len(langExclude) == 0 && len(langExclude) == 0 {
+			len(langExclude) == 0 && len(langExclude) == 0 &&
+			b.FileSize().IsZero() && b.FileLines().IsZero() {
 			return nil, errors.New("At least one of pattern and include/exclude patterns must be non-empty")
 		}
 	}
@@ -724,6 +758,8 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Fea
 		CombyRule:                    b.FindValue(query.FieldCombyRule),
 		Index:                        b.Index(),
 		Select:                       selector,
+		Size:                         b.FileSize(),
+		Lines:                        b.FileLines(),
 	}, nil
 }
 
@@ -892,12 +928,12 @@ func (b *jobBuilder) newZoektGlobalSearch(typ search.IndexedRequestType) (job.Jo
 			RepoOpts:         b.repoOptions,
 		}, nil
 	case search.TextRequest:
-		return &zoekt.GlobalTextSearchJob{
+		return b.withFileSizeFilter(&zoekt.GlobalTextSearchJob{
 			GlobalZoektQuery:        globalZoektQuery,
 			ZoektParams:             zoektParams,
 			RepoOpts:                b.repoOptions,
 			GlobalZoektQueryRegexps: zoektQueryPatternsAsRegexps(globalZoektQuery.Query),
-		}, nil
+		}), nil
 	}
 	return nil, errors.Errorf("attempt to create unrecognized zoekt global search with value %v", typ)
 }
@@ -924,16 +960,25 @@ func (b *jobBuilder) newZoektSearch(typ search.IndexedRequestType) (job.Job, err
 			ZoektParams: zoektParams,
 		}, nil
 	case search.TextRequest:
-		return &zoekt.RepoSubsetTextSearchJob{
+		return b.withFileSizeFilter(&zoekt.RepoSubsetTextSearchJob{
 			Query:             zoektQuery,
 			ZoektQueryRegexps: zoektQueryPatternsAsRegexps(zoektQuery),
 			Typ:               typ,
 			ZoektParams:       zoektParams,
-		}, nil
+		}), nil
 	}
 	return nil, errors.Errorf("attempt to create unrecognized zoekt search with value %v", typ)
 }
 
+// withFileSizeFilter applies the size: filter to the results of a Zoekt text
+// search, which cannot express it in its own query language.
+func (b *jobBuilder) withFileSizeFilter(j job.Job) job.Job {
+	if size := b.query.FileSize(); !size.IsZero() {
+		return NewFileSizeFilterJob(j, size)
+	}
+	return j
+}
+
 func zoektQueryPatternsAsRegexps(q zoektquery.Q) (res []*regexp.Regexp) {
 	zoektquery.VisitAtoms(q, func(zoektQ zoektquery.Q) {
 		switch typedQ := zoektQ.(type) {
@@ -975,7 +1020,8 @@ func jobMode(b query.Basic, repoOptions search.RepoOptions, resultTypes result.T
 	noPattern := b.IsEmptyPattern()
 	noFile := !b.Exists(query.FieldFile)
 	noLang := !b.Exists(query.FieldLang)
-	isEmpty := noPattern && noFile && noLang
+	noFileStat := !b.Exists(query.FieldSize) && !b.Exists(query.FieldLines)
+	isEmpty := noPattern && noFile && noLang && noFileStat
 
 	repoUniverseSearch = isGlobalSearch && isIndexedSearch && hasGlobalSearchResultType && !isEmpty
 	// skipRepoSubsetSearch is a value that controls whether to
@@ -1581,6 +1581,31 @@ func Test_computeResultTypes(t *testing.T) {
 	})
 }
 
+func Test_computeFileMatchLimit(t *testing.T) {
+	test := func(input string) int {
+		plan, err := query.Pipeline(query.Init(input, query.SearchTypeStandard))
+		require.NoError(t, err)
+		return computeFileMatchLimit(plan[0], 30)
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		require.Equal(t, 30, test("foo"))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		require.Equal(t, 10, test("foo count:10"))
+	})
+
+	t.Run("size is post-filtered, so zoekt must not stop at the limit", func(t *testing.T) {
+		require.Equal(t, query.CountAllLimit, test("foo size:>1mb"))
+		require.Equal(t, query.CountAllLimit, test("foo size:>1mb count:10"))
+	})
+
+	t.Run("lines is searched by searcher, which applies the limit itself", func(t *testing.T) {
+		require.Equal(t, 10, test("foo lines:>5000 count:10"))
+	})
+}
+
 func TestRepoSubsetTextSearch(t *testing.T) {
 	searcher.MockSearchFilesInRepo = func(ctx context.Context, repo types.MinimalRepo, gitserverRepo api.RepoName, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration, stream streaming.Sender) (limitHit bool, err error) {
 		repoName := repo.Name
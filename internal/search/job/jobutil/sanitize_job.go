@@ -67,6 +67,10 @@ func (j *sanitizeJob) sanitizeEvent(event streaming.SearchEvent) streaming.Searc
 			if sanitizedCommitMatch := j.sanitizeCommitMatch(v); sanitizedCommitMatch != nil {
 				sanitized = append(sanitized, sanitizedCommitMatch)
 			}
+		case *result.CommitDiffHunkMatch:
+			if !j.matchesAnySanitizePattern(v.Preview.Content) {
+				sanitized = append(sanitized, v)
+			}
 		case *result.RepoMatch:
 			sanitized = append(sanitized, v)
 		default:
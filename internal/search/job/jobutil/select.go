@@ -67,12 +67,7 @@ func newSelectingStream(parent streaming.Sender, s filter.SelectPath) streaming.
 		mux.Lock()
 
 		selected := e.Results[:0]
-		for _, match := range e.Results {
-			current := match.Select(s)
-			if current == nil {
-				continue
-			}
-
+		for _, current := range selectMatches(e.Results, s) {
 			// If the selected file is a file match send it unconditionally
 			// to ensure we get all line matches for a file. One exception:
 			// if we are only interested in the path (via `select:file`),
@@ -95,3 +90,24 @@ func newSelectingStream(parent streaming.Sender, s filter.SelectPath) streaming.
 		parent.Send(e)
 	})
 }
+
+// selectMatches runs the select operation on each match. Selecting diff hunks
+// splits every diff match into one match per hunk.
+func selectMatches(matches result.Matches, s filter.SelectPath) result.Matches {
+	selectHunks := s.String() == "commit.diff.hunk"
+	res := make(result.Matches, 0, len(matches))
+	for _, match := range matches {
+		current := match.Select(s)
+		if current == nil {
+			continue
+		}
+		if cm, ok := current.(*result.CommitMatch); ok && selectHunks {
+			for _, hunk := range cm.Hunks() {
+				res = append(res, hunk)
+			}
+			continue
+		}
+		res = append(res, current)
+	}
+	return res
+}
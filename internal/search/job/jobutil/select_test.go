@@ -2,10 +2,13 @@ package jobutil
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -208,3 +211,38 @@ func TestWithSelect(t *testing.T) {
   }
 ]`).Equal(t, test("content"))
 }
+
+func TestWithSelectHunks(t *testing.T) {
+	diff := "a.go a.go\n@@ -1,1 +1,1 @@\n-foo\n+bar\n@@ -9,1 +9,1 @@\n-baz\n+qux\nb.go b.go\n@@ -1,1 +1,1 @@\n-foo\n+bar\n"
+	structured, err := result.ParseDiffString(diff)
+	require.NoError(t, err)
+
+	commit := func() *result.CommitMatch {
+		return &result.CommitMatch{
+			Commit:      gitdomain.Commit{ID: "abc"},
+			DiffPreview: &result.MatchedString{Content: diff},
+			Diff:        structured,
+		}
+	}
+
+	selectPath, err := filter.SelectPathFromString("commit.diff.hunk")
+	require.NoError(t, err)
+
+	agg := streaming.NewAggregatingStream()
+	selectAgg := newSelectingStream(agg, selectPath)
+	// The same commit found twice should only produce its hunks once.
+	selectAgg.Send(streaming.SearchEvent{Results: result.Matches{commit()}})
+	selectAgg.Send(streaming.SearchEvent{Results: result.Matches{commit(), &result.CommitMatch{MessagePreview: &result.MatchedString{}}}})
+
+	var got []string
+	for _, m := range agg.Results {
+		hm, ok := m.(*result.CommitDiffHunkMatch)
+		require.True(t, ok, "unexpected match type %T", m)
+		got = append(got, fmt.Sprintf("%s#%d %q", hm.Path(), hm.Index, hm.Preview.Content))
+	}
+	require.Equal(t, []string{
+		`a.go#0 "-foo\n+bar\n"`,
+		`a.go#1 "-baz\n+qux\n"`,
+		`b.go#0 "-foo\n+bar\n"`,
+	}, got)
+}
@@ -19,6 +19,8 @@ const (
 	FieldVisibility         = "visibility"
 	FieldRev                = "rev"
 	FieldContext            = "context"
+	FieldSize               = "size"
+	FieldLines              = "lines"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -71,6 +73,8 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldSize:               empty,
+	FieldLines:              empty,
 }
 
 var aliases = map[string]string{
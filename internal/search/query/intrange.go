@@ -0,0 +1,136 @@
+package query
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// IntRange is the half-open interval [Min, Max) of integers accepted by the
+// size: and lines: filters. A Max of zero means the interval has no upper
+// bound, so the zero value matches every file.
+type IntRange struct {
+	Min int64
+	Max int64
+}
+
+// IsZero returns whether r accepts every non-negative number.
+func (r IntRange) IsZero() bool {
+	return r.Min == 0 && r.Max == 0
+}
+
+// Contains returns whether n lies within r.
+func (r IntRange) Contains(n int64) bool {
+	return n >= r.Min && (r.Max == 0 || n < r.Max)
+}
+
+// Intersect returns the range of numbers contained by both r and other.
+func (r IntRange) Intersect(other IntRange) IntRange {
+	res := IntRange{Min: max(r.Min, other.Min), Max: r.Max}
+	if res.Max == 0 || (other.Max != 0 && other.Max < res.Max) {
+		res.Max = other.Max
+	}
+	return res
+}
+
+func (r IntRange) String() string {
+	switch {
+	case r.Max == 0:
+		return ">=" + strconv.FormatInt(r.Min, 10)
+	case r.Min <= 0:
+		return "<" + strconv.FormatInt(r.Max, 10)
+	default:
+		return strconv.FormatInt(r.Min, 10) + ".." + strconv.FormatInt(r.Max, 10)
+	}
+}
+
+// parseIntRange parses a comparison like ">100", "<=5" or "42" into the range
+// of numbers it accepts. The number itself is parsed by parseNumber.
+func parseIntRange(value string, parseNumber func(string) (int64, error)) (IntRange, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, prefix) {
+			op = prefix
+			break
+		}
+	}
+
+	n, err := parseNumber(strings.TrimPrefix(value, op))
+	if err != nil {
+		return IntRange{}, err
+	}
+	if n == math.MaxInt64 {
+		return IntRange{}, errors.Errorf("%q is too large", value)
+	}
+
+	switch op {
+	case ">=":
+		return IntRange{Min: n}, nil
+	case ">":
+		return IntRange{Min: n + 1}, nil
+	case "<=":
+		return IntRange{Max: n + 1}, nil
+	case "<":
+		if n == 0 {
+			return IntRange{}, errors.Errorf("%q never matches, the smallest possible value is 0", value)
+		}
+		return IntRange{Max: n}, nil
+	default:
+		return IntRange{Min: n, Max: n + 1}, nil
+	}
+}
+
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	// Longer suffixes come first so that "kb" is not read as "k" + "b".
+	{"kb", 1 << 10},
+	{"mb", 1 << 20},
+	{"gb", 1 << 30},
+	{"k", 1 << 10},
+	{"m", 1 << 20},
+	{"g", 1 << 30},
+	{"b", 1},
+}
+
+// parseByteSize parses a size like "512", "100kb" or "2MB" into bytes. Units
+// are powers of 1024 and case-insensitive; a bare number is in bytes.
+func parseByteSize(s string) (int64, error) {
+	number, multiplier := strings.ToLower(s), int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(number, unit.suffix) {
+			number, multiplier = strings.TrimSuffix(number, unit.suffix), unit.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf(`invalid size %q (examples: "size:>100kb", "size:<=2mb", "size:0")`, s)
+	}
+	if n > math.MaxInt64/multiplier {
+		return 0, errors.Errorf("size %q is too large", s)
+	}
+	return n * multiplier, nil
+}
+
+func parseLineCount(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf(`invalid line count %q (examples: "lines:>5000", "lines:<100")`, s)
+	}
+	return n, nil
+}
+
+// ParseSizeRange parses the value of a size: filter.
+func ParseSizeRange(value string) (IntRange, error) {
+	return parseIntRange(value, parseByteSize)
+}
+
+// ParseLinesRange parses the value of a lines: filter.
+func ParseLinesRange(value string) (IntRange, error) {
+	return parseIntRange(value, parseLineCount)
+}
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSizeRange(t *testing.T) {
+	cases := []struct {
+		value string
+		want  IntRange
+	}{
+		{value: ">100kb", want: IntRange{Min: 100<<10 + 1}},
+		{value: ">=100KB", want: IntRange{Min: 100 << 10}},
+		{value: "<2mb", want: IntRange{Max: 2 << 20}},
+		{value: "<=1g", want: IntRange{Max: 1<<30 + 1}},
+		{value: "512b", want: IntRange{Min: 512, Max: 513}},
+		{value: "=0", want: IntRange{Min: 0, Max: 1}},
+		{value: "42", want: IntRange{Min: 42, Max: 43}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := ParseSizeRange(tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, value := range []string{"", ">", "big", "10tb", ">-1", "<0", "1.5mb", ">9223372036854775807"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseSizeRange(value)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseLinesRange(t *testing.T) {
+	got, err := ParseLinesRange(">5000")
+	require.NoError(t, err)
+	require.Equal(t, IntRange{Min: 5001}, got)
+
+	_, err = ParseLinesRange(">5kb")
+	require.Error(t, err)
+}
+
+func TestIntRange(t *testing.T) {
+	r := IntRange{Min: 10}.Intersect(IntRange{Max: 20}).Intersect(IntRange{Max: 30})
+	require.Equal(t, IntRange{Min: 10, Max: 20}, r)
+	require.False(t, r.Contains(9))
+	require.True(t, r.Contains(10))
+	require.True(t, r.Contains(19))
+	require.False(t, r.Contains(20))
+
+	require.True(t, IntRange{}.IsZero())
+	require.True(t, IntRange{}.Contains(1<<40))
+	require.False(t, IntRange{Min: 1}.IsZero())
+
+	require.Equal(t, ">=10", IntRange{Min: 10}.String())
+	require.Equal(t, "<20", IntRange{Max: 20}.String())
+	require.Equal(t, "10..20", r.String())
+}
+
+func TestParametersFileSize(t *testing.T) {
+	q, err := ParseLiteral("size:>1kb size:<=2kb lines:<100 foo")
+	require.NoError(t, err)
+	b, err := ToBasicQuery(q)
+	require.NoError(t, err)
+
+	require.Equal(t, IntRange{Min: 1<<10 + 1, Max: 2<<10 + 1}, b.FileSize())
+	require.Equal(t, IntRange{Max: 100}, b.FileLines())
+	require.Equal(t, No, b.Index())
+
+	// size: is evaluated on the results of indexed search too.
+	plan, err := Pipeline(Init("size:>1mb index:only foo", SearchTypeLiteral))
+	require.NoError(t, err)
+	require.Equal(t, Only, plan[0].Index())
+}
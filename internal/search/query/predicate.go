@@ -53,6 +53,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
 	},
 	FieldRev: {
 		"at.time": func() Predicate { return &RevAtTimePredicate{} },
@@ -640,6 +641,26 @@ func (f *FileHasContributorPredicate) Unmarshal(params string, negated bool) err
 func (f FileHasContributorPredicate) Field() string { return FieldFile }
 func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
 
+/* file:has.commit.after(...) */
+
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+	Negated bool
+}
+
+func (f *FileHasCommitAfterPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := gitdomain.ParseGitDate(params, time.Now); err != nil {
+		return errors.Errorf("the file:has.commit.after() predicate has invalid argument: %w", err)
+	}
+
+	f.TimeRef = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+
 type RevAtTimePredicate struct {
 	RevAtTime
 }
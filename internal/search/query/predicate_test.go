@@ -415,3 +415,40 @@ func TestFileHasContributorPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasCommitAfterPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasCommitAfterPredicate
+			error    string
+		}
+
+		valid := []test{
+			{`relative`, `90 days ago`, false, &FileHasCommitAfterPredicate{TimeRef: "90 days ago"}, ""},
+			{`date`, `2024-01-02`, false, &FileHasCommitAfterPredicate{TimeRef: "2024-01-02"}, ""},
+			{`negated`, `1 year ago`, true, &FileHasCommitAfterPredicate{TimeRef: "1 year ago", Negated: true}, ""},
+			{`invalid`, `whenever`, false, &FileHasCommitAfterPredicate{}, "the file:has.commit.after() predicate has invalid argument: invalid date format"},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasCommitAfterPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					if tc.error == "" {
+						t.Fatalf("unexpected error: %s", err)
+					} else if tc.error != err.Error() {
+						t.Fatalf("expected error %s, got %s", tc.error, err.Error())
+					}
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+	})
+}
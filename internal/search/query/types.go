@@ -500,6 +500,19 @@ func (p Parameters) FileHasOwner() (include, exclude []string) {
 	return include, exclude
 }
 
+// FileHasCommitAfter returns the time references of the
+// file:has.commit.after() predicates, split by whether they are negated.
+func (p Parameters) FileHasCommitAfter() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCommitAfterPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.TimeRef)
+		} else {
+			include = append(include, pred.TimeRef)
+		}
+	})
+	return include, exclude
+}
+
 func (p Parameters) FileHasContributor() (include []string, exclude []string) {
 	VisitTypedPredicate(toNodes(p), func(pred *FileHasContributorPredicate) {
 		if pred.Negated {
@@ -565,6 +578,30 @@ func (p Parameters) GetTimeout() *time.Duration {
 	return timeout
 }
 
+// FileSize returns the range of file sizes in bytes accepted by the size:
+// fields. Multiple size: fields are ANDed together.
+func (p Parameters) FileSize() IntRange {
+	return p.intRangeValue(FieldSize, ParseSizeRange)
+}
+
+// FileLines returns the range of line counts accepted by the lines: fields.
+// Multiple lines: fields are ANDed together.
+func (p Parameters) FileLines() IntRange {
+	return p.intRangeValue(FieldLines, ParseLinesRange)
+}
+
+func (p Parameters) intRangeValue(field string, parse func(string) (IntRange, error)) IntRange {
+	var res IntRange
+	VisitField(toNodes(p), field, func(value string, _ bool, _ Annotation) {
+		r, err := parse(value)
+		if err != nil {
+			panic(fmt.Sprintf("Value %q for %s cannot be parsed: %s", value, field, err))
+		}
+		res = res.Intersect(r)
+	})
+	return res
+}
+
 func (p Parameters) VisitParameter(field string, f func(value string, negated bool, annotation Annotation)) {
 	for _, parameter := range p {
 		if parameter.Field == field {
@@ -597,7 +634,14 @@ func (p Parameters) yesNoOnlyValue(field string) *YesNoOnly {
 	return res
 }
 
+// Index returns the value of the index: field. Zoekt does not know the line
+// count of files, so queries containing lines: are never searched with it.
+// Validation rejects lines: together with an explicit index:yes or index:only,
+// and the filter description tells users that lines: searches are unindexed.
 func (p Parameters) Index() YesNoOnly {
+	if p.Exists(FieldLines) {
+		return No
+	}
 	v := p.yesNoOnlyValue(FieldIndex)
 	if v == nil {
 		return Yes
@@ -214,6 +214,16 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isValidSize := func() error {
+		_, err := ParseSizeRange(value)
+		return err
+	}
+
+	isValidLines := func() error {
+		_, err := ParseLinesRange(value)
+		return err
+	}
+
 	isUnrecognizedField := func() error {
 		return errors.Errorf("unrecognized field %q", field)
 	}
@@ -299,6 +309,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldSize:
+		return satisfies(isNotNegated, isValidSize)
+	case
+		FieldLines:
+		return satisfies(isNotNegated, isValidLines)
 	default:
 		return isUnrecognizedField()
 	}
@@ -383,6 +399,46 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+// validateFileStatFilters checks that size: and lines: only appear in queries
+// that search file contents or paths, as they have no meaning for repo,
+// symbol, commit or diff results. lines: is only evaluated by searcher, so it
+// cannot be combined with index:only.
+func validateFileStatFilters(nodes []Node) error {
+	var seenField, seenType, indexValue string
+	seenStructural, seenLines := false, false
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		switch field {
+		case FieldSize:
+			seenField = field
+		case FieldLines:
+			seenField = field
+			seenLines = true
+		case FieldType:
+			if value != "file" && value != "path" {
+				seenType = value
+			}
+		case FieldIndex:
+			indexValue = value
+		}
+	})
+	if seenField == "" {
+		return nil
+	}
+	VisitPattern(nodes, func(_ string, _ bool, annotation Annotation) {
+		seenStructural = seenStructural || annotation.Labels.IsSet(Structural)
+	})
+
+	switch {
+	case seenType != "":
+		return errors.Errorf("the %s: filter only applies to file and path searches, but the query contains type:%s", seenField, seenType)
+	case seenStructural:
+		return errors.Errorf("the %s: filter is not supported for structural search", seenField)
+	case seenLines && indexValue != "" && parseYesNoOnly(indexValue) != No:
+		return errors.Errorf("invalid index:%s (the lines: filter is only evaluated by unindexed search, use index:no)", indexValue)
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -501,6 +557,7 @@ func validate(nodes []Node) error {
 		validateCommitParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateFileStatFilters,
 	)
 }
 
@@ -121,6 +121,35 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input: "size:big",
+			want:  `invalid size "big" (examples: "size:>100kb", "size:<=2mb", "size:0")`,
+		},
+		{
+			input: "-size:>1kb",
+			want:  `field "size" does not support negation`,
+		},
+		{
+			input: "lines:>=1.5",
+			want:  `invalid line count "1.5" (examples: "lines:>5000", "lines:<100")`,
+		},
+		{
+			input: "lines:>5000 type:symbol foo",
+			want:  "the lines: filter only applies to file and path searches, but the query contains type:symbol",
+		},
+		{
+			input: "lines:>5000 size:>1mb index:only",
+			want:  "invalid index:only (the lines: filter is only evaluated by unindexed search, use index:no)",
+		},
+		{
+			input: "lines:>5000 index:yes foo",
+			want:  "invalid index:yes (the lines: filter is only evaluated by unindexed search, use index:no)",
+		},
+		{
+			input:      "size:>1mb foo",
+			want:       "the size: filter is not supported for structural search",
+			searchType: SearchTypeStructural,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -352,6 +352,10 @@ func (r *Resolver) doQueryDB(ctx context.Context, tr trace.Trace, op search.Repo
 	associatedRepoRevs = r.resolvePerforceChangeListIdsToCommitSHAs(ctx, associatedRepoRevs)
 	tr.AddEvent("completed remapping for perforce")
 
+	tr.AddEvent("starting remapping for subversion")
+	associatedRepoRevs = r.resolveSubversionRevisionsToCommitSHAs(ctx, associatedRepoRevs)
+	tr.AddEvent("completed remapping for subversion")
+
 	return dbResolved{
 		Associated: associatedRepoRevs,
 		Missing:    missingRepoRevs,
@@ -550,6 +554,79 @@ func (r *Resolver) resolvePerforceChangeListIdsToCommitSHAs(
 	return repoRevs
 }
 
+var subversionRevisionRegex = regexp.MustCompile(`^r(\d+)$`)
+
+// extractSubversionRevision returns the revision number of a revspec in the
+// "r1234" notation Subversion uses for revisions.
+func extractSubversionRevision(revSpec string) (int64, bool) {
+	matches := subversionRevisionRegex.FindStringSubmatch(revSpec)
+	if matches == nil {
+		return 0, false
+	}
+
+	number, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// resolveSubversionRevisionsToCommitSHAs re-writes resolved refs for
+// subversion repos to use the sha of the commit a revision was converted to
+// instead of the revision number. Revspecs without a mapping are left as is,
+// so a ref that happens to look like a revision still resolves.
+func (r *Resolver) resolveSubversionRevisionsToCommitSHAs(
+	ctx context.Context,
+	repoRevs []RepoRevSpecs,
+) []RepoRevSpecs {
+	reposToMap := []database.RepoSubversionRevisions{}
+	for _, repoRev := range repoRevs {
+		if repoRev.Repo.ExternalRepo.ServiceType != extsvc.TypeSubversion {
+			continue
+		}
+		repoToMap := database.RepoSubversionRevisions{
+			RepoID: repoRev.Repo.ID,
+		}
+		for _, rev := range repoRev.Revs {
+			if revision, ok := extractSubversionRevision(rev.RevSpec); ok {
+				repoToMap.Revisions = append(repoToMap.Revisions, revision)
+			}
+		}
+		if len(repoToMap.Revisions) > 0 {
+			reposToMap = append(reposToMap, repoToMap)
+		}
+	}
+
+	if len(reposToMap) == 0 {
+		return repoRevs
+	}
+
+	revisionsToCommits, err := r.db.RepoCommitsSubversionRevisions().BatchGetRepoCommitSubversionRevision(ctx, reposToMap...)
+	if err != nil {
+		r.logger.Warn("failed to get repo commit subversion revisions", log.Error(err))
+		return repoRevs
+	}
+
+	for i := range repoRevs {
+		repoRev := &repoRevs[i]
+		subMap, ok := revisionsToCommits[repoRev.Repo.ID]
+		if !ok || repoRev.Repo.ExternalRepo.ServiceType != extsvc.TypeSubversion {
+			continue
+		}
+		for j := range repoRev.Revs {
+			rev := &repoRev.Revs[j]
+			if revision, ok := extractSubversionRevision(rev.RevSpec); ok {
+				if commit, ok := subMap[revision]; ok {
+					rev.RevSpec = string(commit.CommitSHA)
+				}
+			}
+		}
+	}
+
+	return repoRevs
+}
+
 // normalizeRefs handles three jobs:
 // 1) expanding each ref glob into a set of refs
 // 2) checking that every revision (except HEAD) exists
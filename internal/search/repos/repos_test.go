@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -1131,6 +1132,33 @@ func TestRepoHasCommitAfter(t *testing.T) {
 	}
 }
 
+func TestResolveSubversionRevisionsToCommitSHAs(t *testing.T) {
+	svnRepo := types.MinimalRepo{ID: 1, Name: "svn.example.org/repos/app", ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeSubversion}}
+	gitRepo := types.MinimalRepo{ID: 2, Name: "github.com/foo/bar", ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub}}
+
+	store := dbmocks.NewMockRepoCommitsSubversionRevisionsStore()
+	store.BatchGetRepoCommitSubversionRevisionFunc.SetDefaultHook(func(_ context.Context, rrs ...database.RepoSubversionRevisions) (map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision, error) {
+		require.Equal(t, []database.RepoSubversionRevisions{{RepoID: 1, Revisions: []int64{42, 43}}}, rrs)
+		return map[api.RepoID]map[int64]*types.RepoCommitSubversionRevision{
+			1: {42: {RepoID: 1, SubversionRevision: 42, CommitSHA: dbutil.CommitBytea(gitSha("42"))}},
+		}, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.RepoCommitsSubversionRevisionsFunc.SetDefaultReturn(store)
+
+	resolver := NewResolver(logtest.Scoped(t), db, nil, nil, defaults.NewConnectionCache(logtest.Scoped(t)), nil)
+	got := resolver.resolveSubversionRevisionsToCommitSHAs(context.Background(), []RepoRevSpecs{
+		{Repo: svnRepo, Revs: []query.RevisionSpecifier{{RevSpec: "r42"}, {RevSpec: "r43"}, {RevSpec: "trunk"}}},
+		{Repo: gitRepo, Revs: []query.RevisionSpecifier{{RevSpec: "r42"}}},
+	})
+
+	require.Equal(t, []RepoRevSpecs{
+		// r43 has not been mapped yet and is passed through unchanged.
+		{Repo: svnRepo, Revs: []query.RevisionSpecifier{{RevSpec: gitSha("42")}, {RevSpec: "r43"}, {RevSpec: "trunk"}}},
+		{Repo: gitRepo, Revs: []query.RevisionSpecifier{{RevSpec: "r42"}}},
+	}, got)
+}
+
 func gitSha(val string) string {
 	writer := sha1.New()
 	writer.Write([]byte(val))
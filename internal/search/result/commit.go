@@ -122,6 +122,12 @@ func (cm *CommitMatch) Select(path filter.SelectPath) Match {
 			if len(fields) == 1 {
 				return cm
 			}
+			if len(fields) == 2 && fields[1] == "hunk" {
+				// Selecting hunks can produce several results per commit,
+				// which Select cannot express. Callers split the match with
+				// Hunks.
+				return cm
+			}
 			if len(fields) == 2 {
 				filteredMatch := selectCommitDiffKind(cm.DiffPreview, fields[1])
 				if filteredMatch == nil {
@@ -0,0 +1,200 @@
+package result
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// CommitDiffHunkMatch is a single hunk of a commit diff. It is produced by
+// `select:commit.diff.hunk`, which splits a diff match into one result per
+// hunk.
+type CommitDiffHunkMatch struct {
+	Commit gitdomain.Commit
+	Repo   types.MinimalRepo
+
+	// OrigName and NewName are the paths of the file before and after the
+	// commit. One of them is "/dev/null" if the file was added or deleted.
+	OrigName, NewName string
+
+	// Index is the position of the hunk within the diff of its file.
+	Index int
+	Hunk  Hunk
+
+	// Preview is the content of the hunk's lines (without the "@@" header)
+	// along with the matched ranges. Line numbers and offsets of the ranges
+	// are relative to the first line of the hunk.
+	Preview MatchedString
+}
+
+func (hm *CommitDiffHunkMatch) RepoName() types.MinimalRepo {
+	return hm.Repo
+}
+
+// Path returns a nonempty path associated with the hunk, preferring the path
+// after the commit.
+func (hm *CommitDiffHunkMatch) Path() string {
+	if hm.NewName == "/dev/null" {
+		return hm.OrigName
+	}
+	return hm.NewName
+}
+
+func (hm *CommitDiffHunkMatch) PathStatus() PathStatus {
+	if hm.OrigName == "/dev/null" {
+		return Added
+	}
+	if hm.NewName == "/dev/null" {
+		return Deleted
+	}
+	return Modified
+}
+
+// Key implements Match interface's Key() method
+func (hm *CommitDiffHunkMatch) Key() Key {
+	return Key{
+		TypeRank:   rankDiffMatch,
+		Repo:       hm.Repo.Name,
+		AuthorDate: hm.Commit.Author.Date,
+		Commit:     hm.Commit.ID,
+		Path:       hm.Path(),
+		Hunk:       hm.Index,
+	}
+}
+
+func (hm *CommitDiffHunkMatch) ResultCount() int {
+	if matchCount := len(hm.Preview.MatchedRanges); matchCount > 0 {
+		return matchCount
+	}
+	// Queries without a pattern have no highlights. We count those hunks as 1.
+	return 1
+}
+
+func (hm *CommitDiffHunkMatch) Limit(limit int) int {
+	if len(hm.Preview.MatchedRanges) == 0 {
+		return limit - 1
+	} else if len(hm.Preview.MatchedRanges) > limit {
+		hm.Preview.MatchedRanges = hm.Preview.MatchedRanges[:limit]
+		return 0
+	}
+	return limit - len(hm.Preview.MatchedRanges)
+}
+
+func (hm *CommitDiffHunkMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: hm.Repo.Name,
+			ID:   hm.Repo.ID,
+		}
+	case filter.Commit:
+		fields := path[1:]
+		if len(fields) == 2 && fields[0] == "diff" && fields[1] != "hunk" {
+			filteredMatch := selectCommitDiffKind(&hm.Preview, fields[1])
+			if filteredMatch == nil {
+				return nil
+			}
+			hm.Preview = *filteredMatch
+		}
+		return hm
+	}
+	return nil
+}
+
+// URL returns the URL of the commit the hunk belongs to.
+func (hm *CommitDiffHunkMatch) URL() *url.URL {
+	u := (&RepoMatch{Name: hm.Repo.Name, ID: hm.Repo.ID}).URL()
+	u.Path = u.Path + "/-/commit/" + string(hm.Commit.ID)
+	return u
+}
+
+func (hm *CommitDiffHunkMatch) searchResultMarker() {}
+
+// CommitMatch returns a diff match of the commit whose diff only contains the
+// hunk. It is used by APIs that have no hunk results, like GraphQL.
+func (hm *CommitDiffHunkMatch) CommitMatch() *CommitMatch {
+	diff := []DiffFile{{
+		OrigName: hm.OrigName,
+		NewName:  hm.NewName,
+		Hunks:    []Hunk{hm.Hunk},
+	}}
+	content := FormatDiffFiles(diff)
+	// The lines of the hunk follow a file and a hunk header line.
+	header := Location{Offset: len(content) - len(hm.Preview.Content), Line: 2}
+	return &CommitMatch{
+		Commit: hm.Commit,
+		Repo:   hm.Repo,
+		DiffPreview: &MatchedString{
+			Content:       content,
+			MatchedRanges: hm.Preview.MatchedRanges.Add(header),
+		},
+		Diff: diff,
+	}
+}
+
+// Hunks splits a diff match into one match per hunk. If the diff has
+// highlights, only the hunks that contain at least one highlight are returned,
+// and their highlights are rebased onto the hunk's lines. Returns nil if cm is
+// not a diff match.
+func (cm *CommitMatch) Hunks() []*CommitDiffHunkMatch {
+	if cm.DiffPreview == nil {
+		return nil
+	}
+
+	// lineOffsets[i] is the byte offset of line i in the formatted diff.
+	lines := strings.SplitAfter(cm.DiffPreview.Content, "\n")
+	lineOffsets := make([]int, len(lines)+1)
+	for i, l := range lines {
+		lineOffsets[i+1] = lineOffsets[i] + len(l)
+	}
+
+	var res []*CommitDiffHunkMatch
+	// The formatted diff has a header line for every file and every hunk,
+	// see FormatDiffFiles.
+	line := 0
+	for _, diffFile := range cm.Diff {
+		line++
+		for i, hunk := range diffFile.Hunks {
+			line++
+			start, end := line, line+len(hunk.Lines)
+			line = end
+			if start >= len(lineOffsets) {
+				// The structured diff does not agree with the preview.
+				return res
+			}
+
+			var ranges Ranges
+			for _, r := range cm.DiffPreview.MatchedRanges {
+				if r.Start.Line >= start && r.Start.Line < end {
+					ranges = append(ranges, r.Sub(Location{Offset: lineOffsets[start], Line: start}))
+				}
+			}
+			if len(cm.DiffPreview.MatchedRanges) > 0 && len(ranges) == 0 {
+				continue
+			}
+
+			var content strings.Builder
+			for _, l := range hunk.Lines {
+				content.WriteString(l)
+				content.WriteByte('\n')
+			}
+
+			res = append(res, &CommitDiffHunkMatch{
+				Commit:   cm.Commit,
+				Repo:     cm.Repo,
+				OrigName: diffFile.OrigName,
+				NewName:  diffFile.NewName,
+				Index:    i,
+				Hunk:     hunk,
+				Preview: MatchedString{
+					Content:       content.String(),
+					MatchedRanges: ranges,
+				},
+			})
+		}
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+package result
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+)
+
+func TestCommitMatch_Hunks(t *testing.T) {
+	diff := `a.go a.go
+@@ -1,2 +1,2 @@ func a() {
+-foo
++bar
+@@ -10,1 +10,2 @@
+ ctx
++baz
+/dev/null b.go
+@@ -0,0 +1,1 @@
++bar
+`
+	structured, err := ParseDiffString(diff)
+	require.NoError(t, err)
+
+	// highlight returns a range covering the first occurrence of s at or
+	// after byte offset from.
+	highlight := func(s string, from int) Range {
+		offset := from + strings.Index(diff[from:], s)
+		line := strings.Count(diff[:offset], "\n")
+		column := offset - (strings.LastIndex(diff[:offset], "\n") + 1)
+		return Range{
+			Start: Location{Offset: offset, Line: line, Column: column},
+			End:   Location{Offset: offset + len(s), Line: line, Column: column + len(s)},
+		}
+	}
+
+	commitMatch := func(ranges Ranges) *CommitMatch {
+		return &CommitMatch{
+			DiffPreview: &MatchedString{Content: diff, MatchedRanges: ranges},
+			Diff:        structured,
+		}
+	}
+
+	t.Run("no highlights returns every hunk", func(t *testing.T) {
+		hunks := commitMatch(nil).Hunks()
+		require.Len(t, hunks, 3)
+
+		require.Equal(t, "a.go", hunks[0].Path())
+		require.Equal(t, 0, hunks[0].Index)
+		require.Equal(t, "func a() {", hunks[0].Hunk.Header)
+		require.Equal(t, "-foo\n+bar\n", hunks[0].Preview.Content)
+
+		require.Equal(t, "a.go", hunks[1].Path())
+		require.Equal(t, 1, hunks[1].Index)
+		require.Equal(t, 10, hunks[1].Hunk.NewStart)
+		require.Equal(t, 2, hunks[1].Hunk.NewCount)
+
+		require.Equal(t, "b.go", hunks[2].Path())
+		require.Equal(t, Added, hunks[2].PathStatus())
+		require.Equal(t, 0, hunks[2].Index)
+	})
+
+	t.Run("highlights select hunks and are rebased", func(t *testing.T) {
+		first := highlight("bar", 0)
+		second := highlight("bar", first.End.Offset)
+		hunks := commitMatch(Ranges{first, second}).Hunks()
+		require.Len(t, hunks, 2)
+
+		require.Equal(t, "a.go", hunks[0].Path())
+		require.Equal(t, Ranges{{
+			Start: Location{Offset: 6, Line: 1, Column: 1},
+			End:   Location{Offset: 9, Line: 1, Column: 4},
+		}}, hunks[0].Preview.MatchedRanges)
+
+		require.Equal(t, "b.go", hunks[1].Path())
+		require.Equal(t, Ranges{{
+			Start: Location{Offset: 1, Line: 0, Column: 1},
+			End:   Location{Offset: 4, Line: 0, Column: 4},
+		}}, hunks[1].Preview.MatchedRanges)
+	})
+
+	t.Run("commit message match has no hunks", func(t *testing.T) {
+		cm := &CommitMatch{MessagePreview: &MatchedString{Content: "message"}}
+		require.Nil(t, cm.Hunks())
+	})
+
+	t.Run("hunks have distinct keys", func(t *testing.T) {
+		dedup := NewDeduper()
+		for _, hunk := range commitMatch(nil).Hunks() {
+			require.False(t, dedup.Seen(hunk))
+			dedup.Add(hunk)
+		}
+	})
+}
+
+func TestCommitDiffHunkMatch_CommitMatch(t *testing.T) {
+	diff := `a.go a.go
+@@ -10,1 +10,2 @@ func a() {
+ ctx
++baz
+`
+	structured, err := ParseDiffString(diff)
+	require.NoError(t, err)
+
+	offset := strings.Index(diff, "baz")
+	hunks := (&CommitMatch{
+		DiffPreview: &MatchedString{Content: diff, MatchedRanges: Ranges{{
+			Start: Location{Offset: offset, Line: 3, Column: 1},
+			End:   Location{Offset: offset + 3, Line: 3, Column: 4},
+		}}},
+		Diff: structured,
+	}).Hunks()
+	require.Len(t, hunks, 1)
+
+	// The highlights of the hunk map back onto the diff of the commit match.
+	cm := hunks[0].CommitMatch()
+	require.Equal(t, diff, cm.DiffPreview.Content)
+	require.Equal(t, structured, cm.Diff)
+	require.Equal(t, Ranges{{
+		Start: Location{Offset: offset, Line: 3, Column: 1},
+		End:   Location{Offset: offset + 3, Line: 3, Column: 4},
+	}}, cm.DiffPreview.MatchedRanges)
+}
+
+func TestCommitDiffHunkMatch_Select(t *testing.T) {
+	hunk := func() *CommitDiffHunkMatch {
+		return &CommitDiffHunkMatch{
+			NewName: "a.go",
+			Preview: MatchedString{Content: " ctx\n+baz\n"},
+		}
+	}
+
+	require.NotNil(t, hunk().Select(filter.SelectPath{"commit", "diff", "hunk"}))
+	require.NotNil(t, hunk().Select(filter.SelectPath{"commit", "diff", "added"}))
+	require.Nil(t, hunk().Select(filter.SelectPath{"commit", "diff", "removed"}))
+	require.IsType(t, &RepoMatch{}, hunk().Select(filter.SelectPath{"repo"}))
+	require.Nil(t, hunk().Select(filter.SelectPath{"file"}))
+}
@@ -35,6 +35,7 @@ var (
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
+	_ Match = (*CommitDiffHunkMatch)(nil)
 	_ Match = (*OwnerMatch)(nil)
 )
 
@@ -77,6 +78,10 @@ type Key struct {
 	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch)
 	Path string
 
+	// Hunk is the index of the diff hunk within Path the match belongs to.
+	// Zero if the match is not a CommitDiffHunkMatch.
+	Hunk int
+
 	// OwnerMetadata gives uniquely identifying information about an owner.
 	// Empty if this is not a Key for an OwnerMatch.
 	OwnerMetadata string
@@ -112,6 +117,10 @@ func (k Key) Compare(other Key) int {
 		return v
 	}
 
+	if v := cmp.Compare(k.Hunk, other.Hunk); v != 0 {
+		return v
+	}
+
 	if v := cmp.Compare(k.OwnerMetadata, other.OwnerMetadata); v != 0 {
 		return v
 	}
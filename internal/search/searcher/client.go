@@ -65,6 +65,8 @@ func Search(
 			PatternMatchesContent:        p.PatternMatchesContent,
 			PatternMatchesPath:           p.PatternMatchesPath,
 			Languages:                    p.Languages,
+			Size:                         p.Size,
+			Lines:                        p.Lines,
 		},
 		Indexed:         indexed,
 		FetchTimeout:    fetchTimeout,
@@ -149,6 +149,8 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case HunkMatchType:
+		r.EventMatch = &EventHunkMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
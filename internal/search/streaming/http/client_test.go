@@ -52,6 +52,11 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventHunkMatch{
+				Type:    HunkMatchType,
+				Path:    "test",
+				Content: "+test\n",
+			},
 		},
 	}, {
 		Name: "filters",
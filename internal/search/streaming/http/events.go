@@ -172,6 +172,44 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventHunkMatch is a single hunk of a commit diff, produced by
+// select:commit.diff.hunk.
+type EventHunkMatch struct {
+	// Type is always HunkMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	URL             string     `json:"url"`
+	RepositoryID    int32      `json:"repositoryID"`
+	Repository      string     `json:"repository"`
+	RepoStars       int        `json:"repoStars,omitempty"`
+	RepoLastFetched *time.Time `json:"repoLastFetched,omitempty"`
+	OID             string     `json:"oid"`
+	AuthorName      string     `json:"authorName"`
+	AuthorDate      time.Time  `json:"authorDate"`
+	CommitterName   string     `json:"committerName"`
+	CommitterDate   time.Time  `json:"committerDate"`
+
+	// Path is the path of the file after the commit, or before it if the
+	// file was deleted. OldPath is only set if it differs from Path.
+	Path    string `json:"path"`
+	OldPath string `json:"oldPath,omitempty"`
+
+	OldStart int `json:"oldStart"`
+	OldCount int `json:"oldCount"`
+	NewStart int `json:"newStart"`
+	NewCount int `json:"newCount"`
+	// SectionHeader is the text following the "@@" range information, which
+	// git usually fills with the enclosing function signature.
+	SectionHeader string `json:"sectionHeader,omitempty"`
+
+	// Content holds the lines of the hunk, each prefixed with "+", "-" or " ".
+	Content string `json:"content"`
+	// [line, character, length], relative to Content
+	Ranges [][3]int32 `json:"ranges"`
+}
+
+func (e *EventHunkMatch) eventMatch() {}
+
 type EventPersonMatch struct {
 	// Type is always PersonMatchType. Included here for marshalling.
 	Type MatchType `json:"type"`
@@ -252,6 +290,7 @@ const (
 	PathMatchType
 	PersonMatchType
 	TeamMatchType
+	HunkMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -270,6 +309,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"person"`), nil
 	case TeamMatchType:
 		return []byte(`"team"`), nil
+	case HunkMatchType:
+		return []byte(`"hunk"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -290,6 +331,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = PersonMatchType
 	} else if bytes.Equal(b, []byte(`"team"`)) {
 		*t = TeamMatchType
+	} else if bytes.Equal(b, []byte(`"hunk"`)) {
+		*t = HunkMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
@@ -243,6 +243,14 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		case *result.RepoMatch:
 			addTypeFilter("type:repo", "Repositories", 1)
 			s.Dirty = true
+		case *result.CommitDiffHunkMatch:
+			count := int32(v.ResultCount())
+			addRepoFilter(v.Repo.Name, "", count)
+			addFileFilter(v.Path(), count)
+			addCommitAuthorFilter(v.Commit)
+			addCommitDateFilter(v.Commit)
+			addTypeFilter("type:diff", "Diffs", count)
+			s.Dirty = true
 		case *result.CommitMatch:
 			// We leave "rev" empty, instead of using "CommitMatch.Commit.ID". This way we
 			// get 1 filter per repo instead of 1 filter per sha in the side-bar.
@@ -32,6 +32,8 @@ func FromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.CommitDiffHunkMatch:
+		return fromCommitDiffHunk(v, repoCache)
 	case *result.OwnerMatch:
 		return fromOwner(v)
 	default:
@@ -262,6 +264,48 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+func fromCommitDiffHunk(hunk *result.CommitDiffHunkMatch, repoCache map[api.RepoID]*types.SearchedRepo) *http.EventHunkMatch {
+	hls := hunk.Preview.ToHighlightedString()
+	ranges := make([][3]int32, len(hls.Highlights))
+	for i, h := range hls.Highlights {
+		ranges[i] = [3]int32{h.Line, h.Character, h.Length}
+	}
+
+	hunkEvent := &http.EventHunkMatch{
+		Type:          http.HunkMatchType,
+		URL:           hunk.URL().String(),
+		Repository:    string(hunk.Repo.Name),
+		RepositoryID:  int32(hunk.Repo.ID),
+		OID:           string(hunk.Commit.ID),
+		AuthorName:    hunk.Commit.Author.Name,
+		AuthorDate:    hunk.Commit.Author.Date,
+		Path:          hunk.Path(),
+		OldStart:      hunk.Hunk.OldStart,
+		OldCount:      hunk.Hunk.OldCount,
+		NewStart:      hunk.Hunk.NewStart,
+		NewCount:      hunk.Hunk.NewCount,
+		SectionHeader: hunk.Hunk.Header,
+		Content:       hls.Value,
+		Ranges:        ranges,
+	}
+
+	if hunk.Commit.Committer != nil {
+		hunkEvent.CommitterName = hunk.Commit.Committer.Name
+		hunkEvent.CommitterDate = hunk.Commit.Committer.Date
+	}
+
+	if hunk.PathStatus() == result.Modified && hunk.OrigName != hunk.NewName {
+		hunkEvent.OldPath = hunk.OrigName
+	}
+
+	if r, ok := repoCache[hunk.Repo.ID]; ok {
+		hunkEvent.RepoStars = r.Stars
+		hunkEvent.RepoLastFetched = r.LastFetched
+	}
+
+	return hunkEvent
+}
+
 func fromOwner(owner *result.OwnerMatch) http.EventMatch {
 	switch v := owner.ResolvedOwner.(type) {
 	case *result.OwnerPerson:
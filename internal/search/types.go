@@ -285,6 +285,10 @@ type TextPatternInfo struct {
 	PatternMatchesContent bool
 	PatternMatchesPath    bool
 
+	// Size and Lines hold the ranges accepted by the size: and lines: filters.
+	Size  query.IntRange
+	Lines query.IntRange
+
 	// Languages is only used for structural search, and is separate from IncludeLangs above
 	// TODO: remove this once the 'search-content-based-lang-detection' feature is enabled by default
 	Languages []string
@@ -330,6 +334,12 @@ func (p *TextPatternInfo) Fields() []attribute.KeyValue {
 	if len(p.Languages) > 0 {
 		add(attribute.StringSlice("languages", p.Languages))
 	}
+	if !p.Size.IsZero() {
+		add(attribute.Stringer("size", p.Size))
+	}
+	if !p.Lines.IsZero() {
+		add(attribute.Stringer("lines", p.Lines))
+	}
 	return res
 }
 
@@ -358,6 +368,12 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	if !p.Size.IsZero() {
+		args = append(args, fmt.Sprintf("size:%s", p.Size))
+	}
+	if !p.Lines.IsZero() {
+		args = append(args, fmt.Sprintf("lines:%s", p.Lines))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
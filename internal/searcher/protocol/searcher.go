@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	proto "github.com/sourcegraph/sourcegraph/internal/searcher/v1"
 )
 
@@ -83,6 +84,11 @@ type PatternInfo struct {
 	IncludeLangs []string
 	ExcludeLangs []string
 
+	// Size and Lines restrict matches to files whose size in bytes and number
+	// of lines lie in the given ranges (e.g., "size:>100kb lines:<5000").
+	Size  query.IntRange
+	Lines query.IntRange
+
 	// IncludeExcludePatternAreCaseSensitive indicates that ExcludePaths, IncludePattern,
 	// and IncludePaths are case sensitive.
 	PathPatternsAreCaseSensitive bool
@@ -147,6 +153,12 @@ func (p *PatternInfo) String() string {
 	if p.Select != "" {
 		args = append(args, fmt.Sprintf("select:%s", p.Select))
 	}
+	if !p.Size.IsZero() {
+		args = append(args, fmt.Sprintf("size:%s", p.Size))
+	}
+	if !p.Lines.IsZero() {
+		args = append(args, fmt.Sprintf("lines:%s", p.Lines))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
@@ -184,6 +196,10 @@ func (r *Request) ToProto() *proto.SearchRequest {
 			ExcludeLangs:                 r.PatternInfo.ExcludeLangs,
 			Select:                       r.PatternInfo.Select,
 			Languages:                    r.PatternInfo.Languages,
+			MinSize:                      r.PatternInfo.Size.Min,
+			MaxSize:                      r.PatternInfo.Size.Max,
+			MinLines:                     r.PatternInfo.Lines.Min,
+			MaxLines:                     r.PatternInfo.Lines.Max,
 		},
 		FetchTimeout:    durationpb.New(r.FetchTimeout),
 		NumContextLines: r.NumContextLines,
@@ -210,6 +226,8 @@ func (r *Request) FromProto(req *proto.SearchRequest) {
 			ExcludeLangs:                 req.PatternInfo.ExcludeLangs,
 			CombyRule:                    req.PatternInfo.CombyRule,
 			Select:                       req.PatternInfo.Select,
+			Size:                         query.IntRange{Min: req.PatternInfo.MinSize, Max: req.PatternInfo.MaxSize},
+			Lines:                        query.IntRange{Min: req.PatternInfo.MinLines, Max: req.PatternInfo.MaxLines},
 		},
 		FetchTimeout:    req.FetchTimeout.AsDuration(),
 		Indexed:         req.Indexed,
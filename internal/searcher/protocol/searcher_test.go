@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/searcher/protocol"
 )
 
@@ -35,6 +36,8 @@ func TestRequestProtoRoundtrip(t *testing.T) {
 			IncludeLangs:                 []string{},
 			CombyRule:                    "",
 			Select:                       "",
+			Size:                         query.IntRange{Min: 100 << 10},
+			Lines:                        query.IntRange{Max: 5000},
 		},
 		FetchTimeout:    1000,
 		Indexed:         false,
@@ -61,6 +61,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -74,6 +76,12 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.PerforceConnection:
 		es.redactString(c.P4Passwd, "p4.passwd")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		if c.Token == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "token"}
+		}
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
@@ -100,11 +108,24 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
+	case *schema.NuGetPackagesConnection:
+		es.redactString(c.Password, "password")
+	case *schema.HexPackagesConnection:
+		es.redactString(c.ApiKey, "apiKey")
+	case *schema.PackagistPackagesConnection:
+		es.redactString(c.Password, "password")
 	case *schema.OtherExternalServiceConnection:
 		err = es.redactURL(c.Url, "url")
 		if err != nil {
 			return "", err
 		}
+	case *schema.MercurialConnection:
+		err = es.redactURL(c.Url, "url")
+		if err != nil {
+			return "", err
+		}
+	case *schema.SubversionConnection:
+		es.redactString(c.Password, "password")
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
 		return "", errors.Errorf("Unrecognized ExternalServiceConfig for redaction: kind %+v not implemented", reflect.TypeOf(cfg))
@@ -214,6 +235,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"p4.port", "p4.passwd"}
 		}
 		es.unredactString(c.P4Passwd, o.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		o := oldCfg.(*schema.SubversionConnection)
+		if c.Password == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
 	case *schema.GerritConnection:
 		o := oldCfg.(*schema.GerritConnection)
 		es.unredactString(c.Password, o.Password, "password")
@@ -278,6 +305,24 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"registry", "credentials"}
 		}
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
+	case *schema.NuGetPackagesConnection:
+		o := oldCfg.(*schema.NuGetPackagesConnection)
+		if c.Password == RedactedSecret && c.Registry != o.Registry {
+			return errCodeHostIdentityChanged{"registry", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
+	case *schema.HexPackagesConnection:
+		o := oldCfg.(*schema.HexPackagesConnection)
+		if c.ApiKey == RedactedSecret && c.Repository != o.Repository {
+			return errCodeHostIdentityChanged{"repository", "apiKey"}
+		}
+		es.unredactString(c.ApiKey, o.ApiKey, "apiKey")
+	case *schema.PackagistPackagesConnection:
+		o := oldCfg.(*schema.PackagistPackagesConnection)
+		if c.Password == RedactedSecret && c.Repository != o.Repository {
+			return errCodeHostIdentityChanged{"repository", "password"}
+		}
+		es.unredactString(c.Password, o.Password, "password")
 	case *schema.OtherExternalServiceConnection:
 		o := oldCfg.(*schema.OtherExternalServiceConnection)
 		err := es.unredactURL(c.Url, o.Url, "url")
@@ -299,6 +344,30 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		oldParsed.User = nil
 		newParsed.User = nil
 
+		if newParsed.String() != oldParsed.String() {
+			if ok && pwd == RedactedSecret {
+				return errCodeHostIdentityChanged{"url", "password"}
+			}
+		}
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		err := es.unredactURL(c.Url, o.Url, "url")
+		if err != nil {
+			return err
+		}
+		oldParsed, err := url.Parse(o.Url)
+		if err != nil {
+			return err
+		}
+		newParsed, err := url.Parse(c.Url)
+		if err != nil {
+			return err
+		}
+		pwd, ok := newParsed.User.Password()
+
+		oldParsed.User = nil
+		newParsed.User = nil
+
 		if newParsed.String() != oldParsed.String() {
 			if ok && pwd == RedactedSecret {
 				return errCodeHostIdentityChanged{"url", "password"}
@@ -115,6 +115,16 @@ type RepoCommit struct {
 	CreatedAt            time.Time
 }
 
+// RepoCommitSubversionRevision is a row of the repo_commits_svn_revisions
+// table, mapping a Subversion revision to the commit it was converted to.
+type RepoCommitSubversionRevision struct {
+	ID                 int64
+	RepoID             api.RepoID
+	CommitSHA          dbutil.CommitBytea
+	SubversionRevision int64
+	CreatedAt          time.Time
+}
+
 // SearchedRepo is a collection of metadata about repos that is used to decorate search results
 type SearchedRepo struct {
 	// ID is the unique numeric ID for this repository.
@@ -2096,6 +2106,11 @@ type PerforceChangelist struct {
 	ChangelistID int64
 }
 
+type SubversionRevision struct {
+	CommitSHA api.CommitID
+	Revision  int64
+}
+
 // CodeHost represents a signle code source, usually defined by url e.g. github.com, gitlab.com, bitbucket.sgdev.org.
 type CodeHost struct {
 	ID                          int32
@@ -0,0 +1,96 @@
+package batches
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AutoMerge describes when the changesets of a batch change are merged
+// automatically.
+type AutoMerge struct {
+	RequiredApprovals    int          `json:"requiredApprovals,omitempty" yaml:"requiredApprovals"`
+	RequirePassingChecks bool         `json:"requirePassingChecks" yaml:"requirePassingChecks"`
+	MergeMethod          string       `json:"mergeMethod,omitempty" yaml:"mergeMethod"`
+	Window               *MergeWindow `json:"window,omitempty" yaml:"window"`
+}
+
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+)
+
+// Squash returns true if changesets are squash merged.
+func (a *AutoMerge) Squash() bool {
+	return a.MergeMethod == MergeMethodSquash
+}
+
+// MergeWindow restricts automatic merges to a time of day and, optionally, to
+// certain days of the week.
+type MergeWindow struct {
+	Days     []string `json:"days,omitempty" yaml:"days"`
+	Start    string   `json:"start,omitempty" yaml:"start"`
+	End      string   `json:"end,omitempty" yaml:"end"`
+	Timezone string   `json:"timezone,omitempty" yaml:"timezone"`
+}
+
+const mergeWindowTimeLayout = "15:04"
+
+// Contains returns true if the given time falls into the merge window. Windows
+// whose end is before their start span midnight, in which case the day of the
+// window is the day it starts on.
+func (w *MergeWindow) Contains(t time.Time) (bool, error) {
+	loc := time.UTC
+	if w.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return false, errors.Wrapf(err, "invalid merge window timezone %q", w.Timezone)
+		}
+	}
+	start, err := time.Parse(mergeWindowTimeLayout, w.Start)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid merge window start %q", w.Start)
+	}
+	end, err := time.Parse(mergeWindowTimeLayout, w.End)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid merge window end %q", w.End)
+	}
+
+	t = t.In(loc)
+	minuteOfDay := t.Hour()*60 + t.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	day := t.Weekday()
+	switch {
+	case startMinute <= endMinute:
+		if minuteOfDay < startMinute || minuteOfDay >= endMinute {
+			return false, nil
+		}
+	case minuteOfDay >= startMinute:
+		// In the part of the window before midnight.
+	case minuteOfDay < endMinute:
+		// In the part of the window after midnight, which belongs to the window
+		// that started the day before.
+		day = (day + 6) % 7
+	default:
+		return false, nil
+	}
+
+	if len(w.Days) == 0 {
+		return true, nil
+	}
+	for _, d := range w.Days {
+		if strings.EqualFold(d, day.String()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validate returns an error if the window can't be evaluated.
+func (w *MergeWindow) validate() error {
+	_, err := w.Contains(time.Time{})
+	return err
+}
@@ -0,0 +1,81 @@
+package batches
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeWindow_Contains(t *testing.T) {
+	// 2024-01-01 was a Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		window MergeWindow
+		t      time.Time
+		want   bool
+	}{
+		{
+			name:   "inside",
+			window: MergeWindow{Start: "09:00", End: "17:00"},
+			t:      monday(12, 0),
+			want:   true,
+		},
+		{
+			name:   "at end",
+			window: MergeWindow{Start: "09:00", End: "17:00"},
+			t:      monday(17, 0),
+			want:   false,
+		},
+		{
+			name:   "before start",
+			window: MergeWindow{Start: "09:00", End: "17:00"},
+			t:      monday(8, 59),
+			want:   false,
+		},
+		{
+			name:   "other day",
+			window: MergeWindow{Days: []string{"tuesday"}, Start: "09:00", End: "17:00"},
+			t:      monday(12, 0),
+			want:   false,
+		},
+		{
+			name:   "timezone",
+			window: MergeWindow{Start: "09:00", End: "17:00", Timezone: "America/New_York"},
+			t:      monday(12, 0),
+			want:   false,
+		},
+		{
+			name:   "spanning midnight before midnight",
+			window: MergeWindow{Days: []string{"Monday"}, Start: "22:00", End: "02:00"},
+			t:      monday(23, 0),
+			want:   true,
+		},
+		{
+			name:   "spanning midnight after midnight",
+			window: MergeWindow{Days: []string{"Sunday"}, Start: "22:00", End: "02:00"},
+			t:      monday(1, 0),
+			want:   true,
+		},
+		{
+			name:   "spanning midnight after midnight on the wrong day",
+			window: MergeWindow{Days: []string{"Monday"}, Start: "22:00", End: "02:00"},
+			t:      monday(1, 0),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, err := tt.window.Contains(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tt.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tt.want, have)
+			}
+		})
+	}
+}
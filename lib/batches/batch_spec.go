@@ -29,15 +29,17 @@ import (
 //    pointers, which is ugly and inefficient.
 
 type BatchSpec struct {
-	Version           int                      `json:"version,omitempty" yaml:"version"`
-	Name              string                   `json:"name,omitempty" yaml:"name"`
-	Description       string                   `json:"description,omitempty" yaml:"description"`
-	On                []OnQueryOrRepository    `json:"on,omitempty" yaml:"on"`
-	Workspaces        []WorkspaceConfiguration `json:"workspaces,omitempty"  yaml:"workspaces"`
-	Steps             []Step                   `json:"steps,omitempty" yaml:"steps"`
-	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
-	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
-	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Version               int                      `json:"version,omitempty" yaml:"version"`
+	Name                  string                   `json:"name,omitempty" yaml:"name"`
+	Description           string                   `json:"description,omitempty" yaml:"description"`
+	On                    []OnQueryOrRepository    `json:"on,omitempty" yaml:"on"`
+	Workspaces            []WorkspaceConfiguration `json:"workspaces,omitempty"  yaml:"workspaces"`
+	Steps                 []Step                   `json:"steps,omitempty" yaml:"steps"`
+	TransformChanges      *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
+	ImportChangesets      []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
+	ChangesetTemplate     *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	AutoMerge             *AutoMerge               `json:"autoMerge,omitempty" yaml:"autoMerge"`
+	ChangesetDependencies []ChangesetDependency    `json:"changesetDependencies,omitempty" yaml:"changesetDependencies"`
 }
 
 type ChangesetTemplate struct {
@@ -75,6 +77,10 @@ type OnQueryOrRepository struct {
 	Repository                string   `json:"repository,omitempty" yaml:"repository"`
 	Branch                    string   `json:"branch,omitempty" yaml:"branch"`
 	Branches                  []string `json:"branches,omitempty" yaml:"branches"`
+	// ExhaustiveSearchJob and InsightSeries target the stored results of a
+	// search job or Code Insights series, instead of running a search.
+	ExhaustiveSearchJob int64  `json:"exhaustiveSearchJob,omitempty" yaml:"exhaustiveSearchJob"`
+	InsightSeries       string `json:"insightSeries,omitempty" yaml:"insightSeries"`
 }
 
 var ErrConflictingBranches = NewValidationError(errors.New("both branch and branches specified"))
@@ -97,6 +103,7 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+	Caches    []StepCache       `json:"caches,omitempty" yaml:"caches,omitempty"`
 }
 
 func (s *Step) IfCondition() string {
@@ -113,6 +120,14 @@ func (s *Step) IfCondition() string {
 	}
 }
 
+// StepCache is a set of paths that executors keep between runs of a step, such as
+// dependency caches. Paths are relative to the directory the step runs in.
+type StepCache struct {
+	Key      string   `json:"key" yaml:"key"`
+	KeyFiles []string `json:"keyFiles,omitempty" yaml:"keyFiles,omitempty"`
+	Paths    []string `json:"paths" yaml:"paths"`
+}
+
 type Outputs map[string]Output
 
 type Output struct {
@@ -178,6 +193,16 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	if spec.AutoMerge != nil && spec.AutoMerge.Window != nil {
+		if err := spec.AutoMerge.Window.validate(); err != nil {
+			errs = errors.Append(errs, NewValidationError(err))
+		}
+	}
+
+	if err := validateChangesetDependencies(spec.ChangesetDependencies); err != nil {
+		errs = errors.Append(errs, NewValidationError(err))
+	}
+
 	return &spec, errs
 }
 
@@ -188,6 +213,10 @@ func (on *OnQueryOrRepository) String() string {
 		return on.RepositoriesMatchingQuery
 	} else if on.Repository != "" {
 		return "repository:" + on.Repository
+	} else if on.ExhaustiveSearchJob != 0 {
+		return fmt.Sprintf("exhaustiveSearchJob:%d", on.ExhaustiveSearchJob)
+	} else if on.InsightSeries != "" {
+		return "insightSeries:" + on.InsightSeries
 	}
 
 	return fmt.Sprintf("%v", *on)
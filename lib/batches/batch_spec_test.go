@@ -3,6 +3,7 @@ package batches
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -286,6 +287,189 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("step caches", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: go mod download
+    container: golang:1.22
+    caches:
+      - key: go-modules
+        keyFiles: [go.sum]
+        paths: [.cache/go-mod]
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []StepCache{{Key: "go-modules", KeyFiles: []string{"go.sum"}, Paths: []string{".cache/go-mod"}}}, batchSpec.Steps[0].Caches)
+
+		_, err = ParseBatchSpec([]byte(strings.Replace(spec, "key: go-modules", "key: ../go-modules", 1)))
+		assert.ErrorContains(t, err, "steps.0.caches.0.key")
+	})
+
+	t.Run("auto merge", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+autoMerge:
+  requiredApprovals: 2
+  requirePassingChecks: true
+  mergeMethod: squash
+  window:
+    days: [Monday, Tuesday]
+    start: "09:00"
+    end: "17:00"
+    timezone: Europe/Berlin
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, batchSpec.AutoMerge.RequiredApprovals)
+		assert.True(t, batchSpec.AutoMerge.RequirePassingChecks)
+		assert.True(t, batchSpec.AutoMerge.Squash())
+		assert.Equal(t, "Europe/Berlin", batchSpec.AutoMerge.Window.Timezone)
+	})
+
+	t.Run("auto merge window with unknown timezone", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+autoMerge:
+  requirePassingChecks: false
+  window:
+    start: "09:00"
+    end: "17:00"
+    timezone: Mars/Olympus_Mons
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `invalid merge window timezone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`, err.Error())
+	})
+
+	t.Run("auto merge without checks policy", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+autoMerge:
+  mergeMethod: squash
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, "requirePassingChecks is required")
+	})
+
+	t.Run("changeset dependency with invalid pattern", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesMatchingQuery: file:go.mod
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+changesetDependencies:
+  - repository: github.com/sourcegraph/*
+    dependsOn:
+      - github.com/sourcegraph/[lib-core
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, `invalid changeset dependency pattern "github.com/sourcegraph/[lib-core"`)
+	})
+
+	t.Run("changeset dependency cycle", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesMatchingQuery: file:go.mod
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+changesetDependencies:
+  - repository: github.com/sourcegraph/app
+    dependsOn:
+      - github.com/sourcegraph/lib-core
+  - repository: github.com/sourcegraph/lib-core
+    dependsOn:
+      - github.com/sourcegraph/app
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, "changeset dependencies form a cycle: github.com/sourcegraph/app -> github.com/sourcegraph/lib-core -> github.com/sourcegraph/app")
+	})
+
+	t.Run("on stored results", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - exhaustiveSearchJob: 42
+  - insightSeries: 2Wy4Nxq6lVYRJpIbnz9uDr2QTqj
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, int64(42), batchSpec.On[0].ExhaustiveSearchJob)
+		assert.Equal(t, "exhaustiveSearchJob:42", batchSpec.On[0].String())
+		assert.Equal(t, "2Wy4Nxq6lVYRJpIbnz9uDr2QTqj", batchSpec.On[1].InsightSeries)
+		assert.Equal(t, "insightSeries:2Wy4Nxq6lVYRJpIbnz9uDr2QTqj", batchSpec.On[1].String())
+	})
+
+	t.Run("on search job with stray branch", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - exhaustiveSearchJob: 42
+    branch: main
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, "Additional property branch is not allowed")
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -0,0 +1,269 @@
+package batches
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangesetDependency declares that the changesets in the repositories matching
+// Repository are held back until the changesets in the repositories matching
+// DependsOn have been merged. Both use the glob syntax of the published field.
+type ChangesetDependency struct {
+	Repository string   `json:"repository" yaml:"repository"`
+	DependsOn  []string `json:"dependsOn" yaml:"dependsOn"`
+}
+
+// ChangesetDependsOn returns true if the changeset in repo has to wait for the
+// changeset in prerequisite to be merged.
+func (s *BatchSpec) ChangesetDependsOn(repo, prerequisite string) (bool, error) {
+	if repo == prerequisite {
+		return false, nil
+	}
+
+	for _, dep := range s.ChangesetDependencies {
+		ok, err := matchGlob(dep.Repository, repo)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+		for _, pattern := range dep.DependsOn {
+			ok, err := matchGlob(pattern, prerequisite)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// ChangesetPrerequisitePatterns returns the patterns matching the repositories
+// whose changesets the changeset in repo depends on.
+func (s *BatchSpec) ChangesetPrerequisitePatterns(repo string) ([]string, error) {
+	var patterns []string
+	for _, dep := range s.ChangesetDependencies {
+		ok, err := matchGlob(dep.Repository, repo)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			patterns = append(patterns, dep.DependsOn...)
+		}
+	}
+	return patterns, nil
+}
+
+func (d ChangesetDependency) validate() error {
+	if _, err := glob.Compile(d.Repository); err != nil {
+		return errors.Wrapf(err, "invalid changeset dependency repository pattern %q", d.Repository)
+	}
+	for _, pattern := range d.DependsOn {
+		if _, err := glob.Compile(pattern); err != nil {
+			return errors.Wrapf(err, "invalid changeset dependency pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// validateChangesetDependencies validates the given dependencies and rejects
+// dependencies that hold changesets back until each other is merged, which
+// would hold them forever.
+//
+// Which repositories match the patterns is only known once the batch spec is
+// applied, so a dependency is assumed to lead to another one if their patterns
+// match at least one common repository name. A single dependency is a cycle if
+// both of its patterns can match more than one repository.
+func validateChangesetDependencies(deps []ChangesetDependency) error {
+	var errs error
+	for _, dep := range deps {
+		if err := dep.validate(); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make([]int, len(deps))
+	var path []int
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		marks[i] = visiting
+		path = append(path, i)
+		for j, dep := range deps {
+			if !deps[i].leadsTo(dep, i == j) {
+				continue
+			}
+			switch marks[j] {
+			case visiting:
+				var cycle []string
+				for _, k := range path[indexOf(path, j):] {
+					cycle = append(cycle, deps[k].Repository)
+				}
+				cycle = append(cycle, dep.Repository)
+				return errors.Errorf("changeset dependencies form a cycle: %s", strings.Join(cycle, " -> "))
+			case unvisited:
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		marks[i] = visited
+		return nil
+	}
+
+	for i := range deps {
+		if marks[i] == unvisited {
+			if err := visit(i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// leadsTo returns true if some changeset held back by d may be a prerequisite
+// of a changeset held back by other.
+func (d ChangesetDependency) leadsTo(other ChangesetDependency, self bool) bool {
+	for _, pattern := range d.DependsOn {
+		// A repository that depends on itself doesn't depend on anything.
+		if pattern == d.Repository && isLiteralGlob(pattern) {
+			continue
+		}
+		if !globsOverlap(pattern, other.Repository) {
+			continue
+		}
+		// A changeset never depends on itself, so a dependency only leads to
+		// itself if more than one repository matches both of its patterns.
+		if !self || (!isLiteralGlob(pattern) && !isLiteralGlob(other.Repository)) {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOf(is []int, i int) int {
+	for k := range is {
+		if is[k] == i {
+			return k
+		}
+	}
+	return -1
+}
+
+// globsOverlap returns true if the patterns a and b may match a common name.
+// It is exact if either of them is a literal name.
+func globsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ok, err := matchGlob(a, b)
+	if err == nil && ok {
+		return true
+	}
+	ok, err = matchGlob(b, a)
+	return err == nil && ok
+}
+
+func isLiteralGlob(pattern string) bool {
+	return glob.QuoteMeta(pattern) == pattern
+}
+
+// GlobRegexp returns a regular expression that matches the same names as the
+// given pattern in the glob syntax of the published field. It only uses syntax
+// that is shared by Go and PostgreSQL regular expressions, so that the names can
+// be matched in the database.
+func GlobRegexp(pattern string) (string, error) {
+	if _, err := glob.Compile(pattern); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	depth := 0
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '{':
+			depth++
+			b.WriteString("(")
+		case '}':
+			depth--
+			b.WriteString(")")
+		case ',':
+			if depth > 0 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
+		case '\\':
+			i++
+			if i < len(runes) {
+				b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return "", errors.Errorf("unterminated character class in %q", pattern)
+			}
+			b.WriteString(globCharClassRegexp(runes[i+1 : end]))
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String(), nil
+}
+
+// globCharClassRegexp converts the content of a glob character class, such as
+// "!a-z", to a regular expression.
+func globCharClassRegexp(class []rune) string {
+	var b strings.Builder
+	b.WriteString("[")
+	if len(class) > 0 && class[0] == '!' {
+		b.WriteString("^")
+		class = class[1:]
+	}
+	for _, r := range class {
+		if r == '-' {
+			b.WriteRune(r)
+			continue
+		}
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r > 127 {
+			b.WriteRune(r)
+		} else {
+			b.WriteString("\\")
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func matchGlob(pattern, name string) (bool, error) {
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return g.Match(name), nil
+}
@@ -0,0 +1,168 @@
+package batches
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatchSpec_ChangesetDependsOn(t *testing.T) {
+	spec := &BatchSpec{
+		ChangesetDependencies: []ChangesetDependency{
+			{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+			{Repository: "github.com/sourcegraph/app", DependsOn: []string{"github.com/sourcegraph/lib-*"}},
+		},
+	}
+
+	tests := []struct {
+		repo, prerequisite string
+		want               bool
+	}{
+		{repo: "github.com/sourcegraph/app", prerequisite: "github.com/sourcegraph/lib-core", want: true},
+		{repo: "github.com/sourcegraph/app", prerequisite: "github.com/sourcegraph/lib-util", want: true},
+		{repo: "github.com/sourcegraph/server", prerequisite: "github.com/sourcegraph/lib-util", want: false},
+		{repo: "github.com/sourcegraph/lib-core", prerequisite: "github.com/sourcegraph/lib-core", want: false},
+		{repo: "github.com/other/app", prerequisite: "github.com/sourcegraph/lib-core", want: false},
+	}
+
+	for _, tt := range tests {
+		have, err := spec.ChangesetDependsOn(tt.repo, tt.prerequisite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != tt.want {
+			t.Errorf("%s depends on %s: want=%t, have=%t", tt.repo, tt.prerequisite, tt.want, have)
+		}
+	}
+}
+
+func TestBatchSpec_ChangesetPrerequisitePatterns(t *testing.T) {
+	spec := &BatchSpec{
+		ChangesetDependencies: []ChangesetDependency{
+			{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+			{Repository: "github.com/sourcegraph/app", DependsOn: []string{"github.com/sourcegraph/lib-*"}},
+		},
+	}
+
+	have, err := spec.ChangesetPrerequisitePatterns("github.com/sourcegraph/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"github.com/sourcegraph/lib-core", "github.com/sourcegraph/lib-*"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("wrong patterns (-want +have):\n%s", diff)
+	}
+
+	have, err = spec.ChangesetPrerequisitePatterns("github.com/other/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 0 {
+		t.Errorf("unexpected patterns %v", have)
+	}
+}
+
+func TestValidateChangesetDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		deps    []ChangesetDependency
+		wantErr string
+	}{
+		{
+			name: "no cycle",
+			deps: []ChangesetDependency{
+				{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+				{Repository: "github.com/sourcegraph/app", DependsOn: []string{"github.com/sourcegraph/lib-*"}},
+				{Repository: "github.com/sourcegraph/lib-core", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+			},
+		},
+		{
+			name: "repositories depending on each other",
+			deps: []ChangesetDependency{
+				{Repository: "github.com/sourcegraph/app", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+				{Repository: "github.com/sourcegraph/lib-core", DependsOn: []string{"github.com/sourcegraph/app"}},
+			},
+			wantErr: "changeset dependencies form a cycle: github.com/sourcegraph/app -> github.com/sourcegraph/lib-core -> github.com/sourcegraph/app",
+		},
+		{
+			name: "cycle through a pattern",
+			deps: []ChangesetDependency{
+				{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/lib-core"}},
+				{Repository: "github.com/sourcegraph/lib-core", DependsOn: []string{"github.com/sourcegraph/lib-base"}},
+			},
+			wantErr: "changeset dependencies form a cycle: github.com/sourcegraph/* -> github.com/sourcegraph/lib-core -> github.com/sourcegraph/*",
+		},
+		{
+			name: "repositories matching both patterns",
+			deps: []ChangesetDependency{
+				{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/lib-*"}},
+			},
+			wantErr: "changeset dependencies form a cycle: github.com/sourcegraph/* -> github.com/sourcegraph/*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChangesetDependencies(tt.deps)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("wrong error. want=%q, have=%v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGlobRegexp(t *testing.T) {
+	names := []string{
+		"github.com/sourcegraph/lib-core",
+		"github.com/sourcegraph/lib-util",
+		"github.com/sourcegraph/app",
+		"github.com/sourcegraph/app2",
+		"github.com/other/lib-core",
+		"github.com/sourcegraph.lib-core",
+		"gitlab.com/sourcegraph/app",
+	}
+
+	for _, pattern := range []string{
+		"github.com/sourcegraph/lib-core",
+		"github.com/sourcegraph/*",
+		"github.com/sourcegraph/lib-*",
+		"github.com/*/lib-core",
+		"github.com/sourcegraph/app?",
+		"github.com/sourcegraph/[a-l]*",
+		"github.com/sourcegraph/[!a]*",
+		"{github,gitlab}.com/sourcegraph/app",
+		"github.com/sourcegraph/{app,lib-*}",
+		`github.com/sourcegraph\.lib-core`,
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			expr, err := GlobRegexp(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				t.Fatalf("invalid regexp %q: %s", expr, err)
+			}
+			for _, name := range names {
+				want, err := matchGlob(pattern, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have := re.MatchString(name); have != want {
+					t.Errorf("%q matching %q: want=%t, have=%t", expr, name, want, have)
+				}
+			}
+		})
+	}
+
+	if _, err := GlobRegexp("github.com/sourcegraph/[lib-core"); err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+}
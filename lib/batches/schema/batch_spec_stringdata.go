@@ -88,6 +88,35 @@ const BatchSpecJSON = `{
                 }
               }
             ]
+          },
+          {
+            "title": "OnSearchJob",
+            "type": "object",
+            "description": "A completed search job whose stored results select the repositories (and revisions) that the batch change will be run on. The search is not run again.",
+            "additionalProperties": false,
+            "required": ["exhaustiveSearchJob"],
+            "properties": {
+              "exhaustiveSearchJob": {
+                "type": "integer",
+                "description": "The ID of the search job. Every repository revision in which the search job found results is added to the list of repositories that the batch change will be run on.",
+                "minimum": 1,
+                "examples": [42]
+              }
+            }
+          },
+          {
+            "title": "OnInsightSeries",
+            "type": "object",
+            "description": "A Code Insights data series whose most recent recording selects the repositories that the batch change will be run on. The series query is not run again.",
+            "additionalProperties": false,
+            "required": ["insightSeries"],
+            "properties": {
+              "insightSeries": {
+                "type": "string",
+                "description": "The ID of the data series. Every repository with a non-zero value in the latest recording of the series is added to the list of repositories that the batch change will be run on, on its default branch.",
+                "examples": ["2Wy4Nxq6lVYRJpIbnz9uDr2QTqj"]
+              }
+            }
           }
         ]
       }
@@ -242,6 +271,36 @@ const BatchSpecJSON = `{
                 }
               }
             }
+          },
+          "caches": {
+            "description": "Files and directories that are kept between runs of the step when it is executed server-side, such as dependency caches. Caches are shared by the batch changes of the same namespace in the same repository.",
+            "type": ["array", "null"],
+            "items": {
+              "title": "StepCache",
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["key", "paths"],
+              "properties": {
+                "key": {
+                  "type": "string",
+                  "description": "The name of the cache.",
+                  "pattern": "^[A-Za-z0-9][A-Za-z0-9._-]*$",
+                  "examples": ["go-modules", "node-modules"]
+                },
+                "keyFiles": {
+                  "type": "array",
+                  "description": "Files relative to the directory the step runs in whose content is part of the cache key, so that the cache starts over once they change.",
+                  "items": { "type": "string" },
+                  "examples": [["go.sum"], ["package-lock.json"]]
+                },
+                "paths": {
+                  "type": "array",
+                  "description": "The files and directories relative to the directory the step runs in that are cached.",
+                  "items": { "type": "string" },
+                  "examples": [[".cache/go-mod"], ["node_modules"]]
+                }
+              }
+            }
           }
         }
       }
@@ -406,6 +465,83 @@ const BatchSpecJSON = `{
           ]
         }
       }
+    },
+    "autoMerge": {
+      "type": "object",
+      "description": "A policy for automatically merging the changesets of this batch change once they are ready.",
+      "additionalProperties": false,
+      "required": ["requirePassingChecks"],
+      "properties": {
+        "requiredApprovals": {
+          "type": "integer",
+          "description": "The number of approving reviews a changeset needs before it is merged.",
+          "minimum": 0
+        },
+        "requirePassingChecks": {
+          "type": "boolean",
+          "description": "Whether all checks of a changeset must pass before it is merged. Changesets without any checks are never merged if this is true."
+        },
+        "mergeMethod": {
+          "type": "string",
+          "description": "How changesets are merged.",
+          "enum": ["merge", "squash"],
+          "default": "merge"
+        },
+        "window": {
+          "type": "object",
+          "description": "Restricts merges to a time of day. If the end is before the start, the window spans midnight.",
+          "additionalProperties": false,
+          "required": ["start", "end"],
+          "properties": {
+            "days": {
+              "type": "array",
+              "description": "The days of the week on which changesets are merged. All days if empty.",
+              "items": {
+                "type": "string",
+                "enum": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"]
+              }
+            },
+            "start": {
+              "type": "string",
+              "description": "The start of the window, in 24-hour HH:MM format.",
+              "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+            },
+            "end": {
+              "type": "string",
+              "description": "The end of the window, in 24-hour HH:MM format.",
+              "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+            },
+            "timezone": {
+              "type": "string",
+              "description": "The IANA timezone of the window, such as America/New_York. Defaults to UTC."
+            }
+          }
+        }
+      }
+    },
+    "changesetDependencies": {
+      "type": "array",
+      "description": "Declares changesets that are only published once the changesets they depend on have been merged. Until then, they are published as drafts if the code host supports them, and otherwise stay unpublished. Dependencies must not form a cycle. Changesets whose prerequisites were closed or are not going to be published fail instead of being held.",
+      "items": {
+        "type": "object",
+        "title": "ChangesetDependency",
+        "additionalProperties": false,
+        "required": ["repository", "dependsOn"],
+        "properties": {
+          "repository": {
+            "type": "string",
+            "description": "A glob pattern to match the names of the repositories whose changesets are held back."
+          },
+          "dependsOn": {
+            "type": "array",
+            "description": "Glob patterns to match the names of the repositories whose changesets have to be merged first.",
+            "items": {
+              "type": "string"
+            },
+            "minItems": 1
+          }
+        }
+      }
     }
   }
 }
@@ -29,9 +29,18 @@ func (j AutoIndexJobSpec) GetIndexerName() string {
 }
 
 type DockerStep struct {
-	Root     string   `json:"root" yaml:"root"`
-	Image    string   `json:"image" yaml:"image"`
-	Commands []string `json:"commands" yaml:"commands"`
+	Root     string      `json:"root" yaml:"root"`
+	Image    string      `json:"image" yaml:"image"`
+	Commands []string    `json:"commands" yaml:"commands"`
+	Caches   []StepCache `json:"caches,omitempty" yaml:"caches,omitempty"`
+}
+
+// StepCache is a set of paths that executors keep between runs of an index job, such
+// as dependency caches. Paths are relative to the root of the step.
+type StepCache struct {
+	Key      string   `json:"key" yaml:"key"`
+	KeyFiles []string `json:"keyFiles,omitempty" yaml:"keyFiles,omitempty"`
+	Paths    []string `json:"paths" yaml:"paths"`
 }
 
 // extractIndexerName Name removes the prefix "sourcegraph/"" and the suffix "@sha256:..."
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS insight_series_alert_actions;
+DROP TABLE IF EXISTS insight_series_alerts;
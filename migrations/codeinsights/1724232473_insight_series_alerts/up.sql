@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id SERIAL PRIMARY KEY,
+    series_id integer NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    user_id integer NOT NULL,
+    kind text NOT NULL CONSTRAINT insight_series_alerts_kind_valid CHECK (kind IN ('absolute-threshold', 'percent-change', 'any-increase')),
+    threshold double precision NOT NULL DEFAULT 0,
+    intervals integer NOT NULL DEFAULT 1 CONSTRAINT insight_series_alerts_intervals_positive CHECK (intervals > 0),
+    last_recording_time timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_series_id_idx ON insight_series_alerts USING btree (series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules evaluated after each recording of an insight series';
+COMMENT ON COLUMN insight_series_alerts.user_id IS 'The user that owns the alert. Alerts are evaluated with the repository permissions of this user, and email actions notify this user';
+COMMENT ON COLUMN insight_series_alerts.threshold IS 'The value the latest point must exceed for absolute-threshold alerts, or the change in percent for percent-change alerts';
+COMMENT ON COLUMN insight_series_alerts.intervals IS 'The number of recordings a percent-change alert compares the latest point against';
+COMMENT ON COLUMN insight_series_alerts.last_recording_time IS 'The latest recording time the alert was evaluated for, so that retried recordings do not notify twice';
+
+CREATE TABLE IF NOT EXISTS insight_series_alert_actions (
+    id SERIAL PRIMARY KEY,
+    alert_id integer NOT NULL REFERENCES insight_series_alerts(id) ON DELETE CASCADE,
+    type text NOT NULL CONSTRAINT insight_series_alert_actions_type_valid CHECK (type IN ('email', 'slack', 'webhook')),
+    url text,
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT insight_series_alert_actions_url_required CHECK ((type = 'email') = (url IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_actions_alert_id_idx ON insight_series_alert_actions USING btree (alert_id);
+
+COMMENT ON TABLE insight_series_alert_actions IS 'Notifications sent when an insight series alert triggers';
+COMMENT ON COLUMN insight_series_alert_actions.url IS 'The Slack or webhook URL to post to. Email actions notify the owner of the alert and have no URL';
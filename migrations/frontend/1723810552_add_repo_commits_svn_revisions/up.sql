@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS repo_commits_svn_revisions (
+    id SERIAL PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    commit_sha bytea NOT NULL,
+    svn_revision integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS repo_id_svn_revision_unique ON repo_commits_svn_revisions USING btree (repo_id, svn_revision);
+
+COMMENT ON TABLE repo_commits_svn_revisions IS 'Maps Subversion revisions to the commits they were converted to by git-svn.';
@@ -0,0 +1,2 @@
+ALTER TABLE exhaustive_search_jobs DROP COLUMN IF EXISTS results_columns;
+ALTER TABLE exhaustive_search_jobs DROP COLUMN IF EXISTS results_format;
@@ -0,0 +1,5 @@
+ALTER TABLE exhaustive_search_jobs ADD COLUMN IF NOT EXISTS results_format text NOT NULL DEFAULT 'json';
+ALTER TABLE exhaustive_search_jobs ADD COLUMN IF NOT EXISTS results_columns text[];
+
+COMMENT ON COLUMN exhaustive_search_jobs.results_format IS 'The format results are written in: json, ndjson or csv.';
+COMMENT ON COLUMN exhaustive_search_jobs.results_columns IS 'The columns written for each match by the ndjson and csv formats. NULL means the default columns.';
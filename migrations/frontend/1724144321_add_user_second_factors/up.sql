@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS user_totp_credentials (
+    id SERIAL PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    secret bytea NOT NULL,
+    encryption_key_id text NOT NULL DEFAULT '',
+    last_used_counter bigint NOT NULL DEFAULT 0,
+    confirmed_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_totp_credentials_user_id ON user_totp_credentials USING btree (user_id);
+
+COMMENT ON TABLE user_totp_credentials IS 'The authenticator app (TOTP) secrets that users enrolled as a second factor.';
+COMMENT ON COLUMN user_totp_credentials.last_used_counter IS 'The TOTP counter of the last code that was used, so that codes can''t be used twice.';
+COMMENT ON COLUMN user_totp_credentials.confirmed_at IS 'NULL until the user entered a valid code to finish enrollment. Unconfirmed secrets are not used to sign in.';
+
+CREATE TABLE IF NOT EXISTS user_webauthn_credentials (
+    id SERIAL PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    name text NOT NULL,
+    credential_id bytea NOT NULL,
+    public_key bytea NOT NULL,
+    sign_count bigint NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone,
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_webauthn_credentials_credential_id ON user_webauthn_credentials USING btree (credential_id);
+CREATE INDEX IF NOT EXISTS user_webauthn_credentials_user_id ON user_webauthn_credentials USING btree (user_id);
+
+COMMENT ON TABLE user_webauthn_credentials IS 'The security keys (WebAuthn credentials) that users registered as a second factor.';
+COMMENT ON COLUMN user_webauthn_credentials.public_key IS 'The COSE-encoded public key of the credential.';
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    code_hash bytea NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_recovery_codes_user_id ON user_recovery_codes USING btree (user_id);
+
+COMMENT ON TABLE user_recovery_codes IS 'Single-use codes to sign in without a second factor device. Only the SHA-256 hashes of the codes are stored.';
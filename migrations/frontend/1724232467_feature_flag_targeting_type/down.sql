@@ -0,0 +1,2 @@
+-- Postgres can't remove values from an enum. The value is unused once the next
+-- migration is reverted, so it is left in place.
@@ -0,0 +1,3 @@
+-- The new value can't be used in the transaction that adds it, so the column
+-- and constraints using it are added by the next migration.
+ALTER TYPE feature_flag_type ADD VALUE IF NOT EXISTS 'targeting';
@@ -0,0 +1,5 @@
+-- Targeting flags can't be represented without the column.
+DELETE FROM feature_flags WHERE flag_type = 'targeting';
+
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_targeting_fields;
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS targeting;
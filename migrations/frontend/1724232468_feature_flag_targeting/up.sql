@@ -0,0 +1,11 @@
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS targeting jsonb;
+
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_targeting_fields;
+ALTER TABLE feature_flags ADD CONSTRAINT required_targeting_fields CHECK ((1 =
+CASE
+    WHEN ((flag_type = 'targeting'::feature_flag_type) AND (targeting IS NULL)) THEN 0
+    WHEN ((flag_type <> 'targeting'::feature_flag_type) AND (targeting IS NOT NULL)) THEN 0
+    ELSE 1
+END));
+
+COMMENT ON COLUMN feature_flags.targeting IS 'Targeting rules and fallback rollout, only defined when flag_type is targeting';
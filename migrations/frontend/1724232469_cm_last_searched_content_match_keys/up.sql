@@ -0,0 +1,3 @@
+ALTER TABLE cm_last_searched ADD COLUMN IF NOT EXISTS content_match_keys text[];
+
+COMMENT ON COLUMN cm_last_searched.content_match_keys IS 'For content monitors, the keys of the file content matches found at commit_oids. Matches whose key is not in this set are new on the next run';
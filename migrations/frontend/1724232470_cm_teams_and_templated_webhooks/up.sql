@@ -0,0 +1,59 @@
+CREATE TABLE IF NOT EXISTS cm_teams_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams incoming webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+
+CREATE TABLE IF NOT EXISTS cm_templated_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    body text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS cm_templated_webhooks_monitor ON cm_templated_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_templated_webhooks IS 'Webhook actions configured on code monitors whose request body is rendered from a template, for chat services like Mattermost';
+COMMENT ON COLUMN cm_templated_webhooks.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_templated_webhooks.url IS 'The URL we send the code monitor event to';
+COMMENT ON COLUMN cm_templated_webhooks.body IS 'A Go text/template that renders the request body from the code monitor event';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS teams_webhook bigint REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS templated_webhook bigint REFERENCES cm_templated_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with the other action columns';
+COMMENT ON COLUMN cm_action_jobs.templated_webhook IS 'The ID of the cm_templated_webhooks action to execute if this is a templated webhook job. Mutually exclusive with the other action columns';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK (
+    (
+        CASE WHEN email IS NULL THEN 0 ELSE 1 END
+        + CASE WHEN webhook IS NULL THEN 0 ELSE 1 END
+        + CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END
+        + CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END
+        + CASE WHEN templated_webhook IS NULL THEN 0 ELSE 1 END
+    ) = 1
+);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
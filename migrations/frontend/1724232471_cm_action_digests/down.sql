@@ -0,0 +1,2 @@
+ALTER TABLE cm_emails DROP COLUMN IF EXISTS digest;
+ALTER TABLE cm_slack_webhooks DROP COLUMN IF EXISTS digest;
@@ -0,0 +1,10 @@
+ALTER TABLE cm_emails ADD COLUMN IF NOT EXISTS digest text;
+ALTER TABLE cm_slack_webhooks ADD COLUMN IF NOT EXISTS digest text;
+
+ALTER TABLE cm_emails DROP CONSTRAINT IF EXISTS cm_emails_digest_valid;
+ALTER TABLE cm_emails ADD CONSTRAINT cm_emails_digest_valid CHECK (digest IN ('hourly', 'daily', 'weekly'));
+ALTER TABLE cm_slack_webhooks DROP CONSTRAINT IF EXISTS cm_slack_webhooks_digest_valid;
+ALTER TABLE cm_slack_webhooks ADD CONSTRAINT cm_slack_webhooks_digest_valid CHECK (digest IN ('hourly', 'daily', 'weekly'));
+
+COMMENT ON COLUMN cm_emails.digest IS 'If set, results are accumulated and sent as one digest email per user at the end of every hourly, daily or weekly period';
+COMMENT ON COLUMN cm_slack_webhooks.digest IS 'If set, results are accumulated and sent as one digest message at the end of every hourly, daily or weekly period';
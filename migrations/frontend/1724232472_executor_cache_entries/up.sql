@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS executor_cache_entries (
+    id SERIAL PRIMARY KEY,
+    queue text NOT NULL,
+    key text NOT NULL,
+    size_bytes bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_accessed_at timestamp with time zone NOT NULL DEFAULT now(),
+    tenant_id integer REFERENCES tenants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS executor_cache_entries_queue_key ON executor_cache_entries USING btree (queue, key);
+CREATE INDEX IF NOT EXISTS executor_cache_entries_last_accessed_at ON executor_cache_entries USING btree (last_accessed_at);
+
+COMMENT ON TABLE executor_cache_entries IS 'The caches that executor jobs keep between runs. The archives are stored in object storage.';
+COMMENT ON COLUMN executor_cache_entries.last_accessed_at IS 'The time the entry was last restored by a job. Entries are evicted in least recently used order once the size limit is exceeded.';
@@ -0,0 +1 @@
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS content_results;
@@ -0,0 +1,3 @@
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS content_results jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.content_results IS 'For content monitors, the new file content matches found by the job. Commit matches are stored in search_results';
@@ -166,6 +166,7 @@ type AuthProviders struct {
 	Github          *GitHubAuthProvider
 	Gitlab          *GitLabAuthProvider
 	HttpHeader      *HTTPHeaderAuthProvider
+	Ldap            *LDAPAuthProvider
 	Openidconnect   *OpenIDConnectAuthProvider
 	Saml            *SAMLAuthProvider
 }
@@ -195,6 +196,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.HttpHeader != nil {
 		return json.Marshal(v.HttpHeader)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	if v.Openidconnect != nil {
 		return json.Marshal(v.Openidconnect)
 	}
@@ -227,12 +231,34 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "bitbucketserver", "builtin", "gerrit", "github", "gitlab", "http-header", "openidconnect", "saml"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "bitbucketserver", "builtin", "gerrit", "github", "gitlab", "http-header", "ldap", "openidconnect", "saml"})
+}
+
+// AuthSecondFactor description: Second factor authentication for builtin username/password accounts. Users can enroll an authenticator app (TOTP) and security keys (WebAuthn) in their account settings.
+type AuthSecondFactor struct {
+	// Issuer description: The issuer shown next to the account name in authenticator apps. Defaults to the hostname of the external URL.
+	Issuer string `json:"issuer,omitempty"`
+	// Required description: Require all builtin username/password accounts to sign in with a second factor. Users who have not enrolled one yet are asked to set up an authenticator app the next time they sign in.
+	Required bool `json:"required,omitempty"`
+}
+
+// AutoMerge description: A policy for automatically merging the changesets of this batch change once they are ready.
+type AutoMerge struct {
+	// MergeMethod description: How changesets are merged.
+	MergeMethod string `json:"mergeMethod,omitempty"`
+	// RequirePassingChecks description: Whether all checks of a changeset must pass before it is merged. Changesets without any checks are never merged if this is true.
+	RequirePassingChecks bool `json:"requirePassingChecks"`
+	// RequiredApprovals description: The number of approving reviews a changeset needs before it is merged.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// Window description: Restricts merges to a time of day. If the end is before the start, the window spans midnight.
+	Window *Window `json:"window,omitempty"`
 }
 
 // AzureDevOpsAuthProvider description: Azure auth provider for dev.azure.com
@@ -307,6 +333,10 @@ type BatchChangeRolloutWindow struct {
 
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
+	// AutoMerge description: A policy for automatically merging the changesets of this batch change once they are ready.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
+	// ChangesetDependencies description: Declares changesets that are only published once the changesets they depend on have been merged. Until then, they are published as drafts if the code host supports them, and otherwise stay unpublished. Dependencies must not form a cycle. Changesets whose prerequisites were closed or are not going to be published fail instead of being held.
+	ChangesetDependencies []*ChangesetDependency `json:"changesetDependencies,omitempty"`
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
@@ -611,6 +641,12 @@ type CapabilitiesResult struct {
 	// At least 1 must be satisfied for the provider to be called. If empty, the provider is never called. If undefined, the provider is called on all files.
 	Selector []*Selector `json:"selector,omitempty"`
 }
+type ChangesetDependency struct {
+	// DependsOn description: Glob patterns to match the names of the repositories whose changesets have to be merged first.
+	DependsOn []string `json:"dependsOn"`
+	// Repository description: A glob pattern to match the names of the repositories whose changesets are held back.
+	Repository string `json:"repository"`
+}
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
@@ -1044,6 +1080,7 @@ type EncryptionKeys struct {
 	GitHubAppKey           *EncryptionKey `json:"gitHubAppKey,omitempty"`
 	OutboundWebhookKey     *EncryptionKey `json:"outboundWebhookKey,omitempty"`
 	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
+	UserSecondFactorKey    *EncryptionKey `json:"userSecondFactorKey,omitempty"`
 	WebhookKey             *EncryptionKey `json:"webhookKey,omitempty"`
 	WebhookLogKey          *EncryptionKey `json:"webhookLogKey,omitempty"`
 }
@@ -1105,18 +1142,44 @@ type ExcludedGitLabProject struct {
 	// Pattern description: Regular expression which matches against the name of a GitLab project ("group/name").
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring.
+	Id int `json:"id,omitempty"`
+	// Mirrors description: If set to true, repositories that are pull mirrors of another code host will be excluded.
+	Mirrors bool `json:"mirrors,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Gitolite repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedMercurialRepo struct {
+	// Name description: The name of a Mercurial repo ("my-repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Mercurial repo to exclude from mirroring.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedOtherRepo struct {
 	// Name description: The name of a Other repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Other repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedSubversionRepo struct {
+	// Name description: The name of a Subversion repo ("my-repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Subversion repo to exclude from mirroring.
+	Pattern string `json:"pattern,omitempty"`
+}
 
 // ExecutorsMultiqueue description: The configuration for multiqueue executors.
 type ExecutorsMultiqueue struct {
@@ -1168,6 +1231,8 @@ type ExperimentalFeatures struct {
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
+	// HexPackages description: Allow adding Hex package host connections
+	HexPackages string `json:"hexPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
 	InsightsAlternateLoadingStrategy bool `json:"insightsAlternateLoadingStrategy,omitempty"`
 	// InsightsBackfillerV2 description: DEPRECATED: Setting any value to this flag has no effect.
@@ -1180,6 +1245,10 @@ type ExperimentalFeatures struct {
 	LanguageDetection *LanguageDetection `json:"languageDetection,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
+	// NugetPackages description: Allow adding NuGet package host connections
+	NugetPackages string `json:"nugetPackages,omitempty"`
+	// PackagistPackages description: Allow adding Packagist package host connections
+	PackagistPackages string `json:"packagistPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
@@ -1665,6 +1734,68 @@ type GitRecorder struct {
 	Size int `json:"size,omitempty"`
 }
 
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. This requires that the configured token belongs to a Gitea site admin.
+type GiteaAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitea identity to use for a given Sourcegraph user.
+	IdentityProvider GiteaIdentityProvider `json:"identityProvider"`
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. This requires that the configured token belongs to a Gitea site admin.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "users", "repos", and "repositoryQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "owner/name"}), by ID ({"id": 42}) or by pattern ({"pattern": "^owner/.*"}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form http(s)://gitea.example.com/myteam/myproject.git (using https: if the Gitea instance uses HTTPS).
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://sourcegraph.com/docs/admin/repo/auth.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// Repos description: An array of repository "owner/name" strings specifying which Gitea repositories to mirror on Sourcegraph.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository. In the pattern, the variable "{host}" is replaced with the Gitea host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" path (such as "myteam/myrepo").
+	//
+	// For example, if your Gitea URL is https://gitea.example.com and your Sourcegraph URL is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/myteam/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myteam/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which repositories to mirror on Sourcegraph. Each string is a keyword passed to the Gitea repository search API. The special string "all" mirrors every repository visible to the token.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: An access token for the Gitea instance. The token needs the `read:repository`, `read:organization` and `read:user` scopes. If `authorization` is set, the token must belong to a site admin.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+	// Users description: An array of user names identifying Gitea users whose repositories should be mirrored on Sourcegraph.
+	Users []string `json:"users,omitempty"`
+	// Webhooks description: An array of configurations defining existing Gitea webhooks that send updates back to Sourcegraph.
+	Webhooks []*GiteaWebhook `json:"webhooks,omitempty"`
+}
+
+// GiteaIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitea identity to use for a given Sourcegraph user.
+type GiteaIdentityProvider struct {
+	// Type description: The identity provider type. With "username", a Sourcegraph user is matched to the Gitea user with the same username.
+	Type string `json:"type"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+type GiteaWebhook struct {
+	// Secret description: The secret used when creating the webhook
+	Secret string `json:"secret"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1727,6 +1858,26 @@ type Header struct {
 	Value     string `json:"value"`
 }
 
+// HexPackagesConnection description: Configuration for a connection to a Hex repository of Elixir and Erlang packages.
+type HexPackagesConnection struct {
+	// ApiKey description: The API key sent in the Authorization header of requests to the repository. Required for private organizations and self-hosted repositories that require authentication.
+	ApiKey string `json:"apiKey,omitempty"`
+	// Dependencies description: An array of strings specifying Hex packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the Hex repository.
+	RateLimit *HexRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Hex repository. Packages of a Hex.pm organization are served from https://repo.hex.pm/repos/<organization>.
+	Repository string `json:"repository,omitempty"`
+}
+
+// HexRateLimit description: Rate limit applied when making background API requests to the Hex repository.
+type HexRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the GitLab identity to use for a given Sourcegraph user.
 type IdentityProvider struct {
 	Oauth    *OAuthIdentity
@@ -1783,6 +1934,60 @@ type JVMPackagesConnection struct {
 	Maven Maven `json:"maven"`
 }
 
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which authenticates users against an LDAP directory such as Active Directory or OpenLDAP, and optionally syncs their group membership into organizations and roles.
+type LDAPAuthProvider struct {
+	// AllowGroups description: Restrict sign in to members of these groups. Groups are matched by name or DN.
+	AllowGroups []string `json:"allowGroups,omitempty"`
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// BindDN description: DN of the service account used to search for users and groups. Searches are anonymous if it is not set.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: Password of the service account set in `bindDN`.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server, or of the CA that issued it, in PEM format. Only needed if the certificate is not trusted by the system.
+	Certificate string `json:"certificate,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: Attribute holding the user's display name.
+	DisplayNameAttribute string  `json:"displayNameAttribute,omitempty"`
+	DisplayPrefix        *string `json:"displayPrefix,omitempty"`
+	// EmailAttribute description: Attribute holding the user's email address.
+	EmailAttribute string `json:"emailAttribute,omitempty"`
+	// GroupBaseDN description: DN of the subtree to search for the groups of a user. If not set, groups are read from the `memberOf` attribute of the user's entry instead.
+	GroupBaseDN string `json:"groupBaseDN,omitempty"`
+	// GroupFilter description: Filter used to find the groups of a user below `groupBaseDN`. `{dn}` is replaced by the escaped DN of the user and `{username}` by the escaped username. For nested Active Directory groups use `(member:1.2.840.113556.1.4.1941:={dn})`.
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// GroupMappings description: Maps LDAP groups to organizations and roles. Membership of the listed organizations and roles is synced on every sign in and periodically in the background: users are added when they are in a mapped group and removed when they no longer are. Organizations and roles that are not listed here are never changed.
+	GroupMappings []*LDAPGroupMapping `json:"groupMappings,omitempty"`
+	// GroupNameAttribute description: Attribute holding the name of a group.
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+	Hidden             bool   `json:"hidden,omitempty"`
+	NoSignIn           bool   `json:"noSignIn,omitempty"`
+	Order              int    `json:"order,omitempty"`
+	// StartTLS description: Upgrade ldap:// connections to TLS with the StartTLS extended operation before binding.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: URL of the LDAP server. Use the ldaps scheme for LDAP over TLS, or the ldap scheme together with `startTLS`.
+	Url string `json:"url"`
+	// UserBaseDN description: DN of the subtree to search for users.
+	UserBaseDN string `json:"userBaseDN"`
+	// UserFilter description: Filter used to find the entry of a user signing in. `{username}` is replaced by the escaped username the user entered. For Active Directory use `(sAMAccountName={username})`.
+	UserFilter string `json:"userFilter,omitempty"`
+	// UsernameAttribute description: Attribute holding the username. Its value identifies the user's external account, so it should never change. For Active Directory use `sAMAccountName`.
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
+// LDAPGroupMapping description: Maps an LDAP group to organizations and roles.
+type LDAPGroupMapping struct {
+	// Group description: Name or DN of the LDAP group.
+	Group string `json:"group"`
+	// Orgs description: Names of the organizations members of the group belong to. The organizations must exist.
+	Orgs []string `json:"orgs,omitempty"`
+	// Roles description: Names of the roles members of the group are assigned. The roles must exist and must not be system roles.
+	Roles []string `json:"roles,omitempty"`
+}
+
 // LanguageDetection description: Setting for customizing language detection behavior
 type LanguageDetection struct {
 	// GraphQL description: What to take into account for computing 'languages' for the GraphQL API. This setting indirectly affects client-side code attempting to determine languages, such as search-based code navigation and the files sidebar.
@@ -1826,6 +2031,21 @@ type MavenRateLimit struct {
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
 
+// MercurialConnection description: Configuration for a connection to Mercurial repositories. Each repository is converted into a Git mirror on gitserver, preserving branches, bookmarks and tags.
+type MercurialConnection struct {
+	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
+	Exclude []*ExcludedMercurialRepo `json:"exclude,omitempty"`
+	Repos   []string                 `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Mercurial clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if your Mercurial clone base URL is https://hg.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://hg.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/repos/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL that the paths in `repos` are resolved against. Credentials may be included in the URL.
+	Url string `json:"url"`
+}
+
 // ModelFilters description: Filters that allow you to constrain which models are available to users.
 type ModelFilters struct {
 	// Allow description: Constrain models to only those in this allow list. Wildcards may be used here, but not regexp.
@@ -2008,6 +2228,28 @@ type NpmRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// NuGetPackagesConnection description: Configuration for a connection to a NuGet package feed.
+type NuGetPackagesConnection struct {
+	// Dependencies description: An array of strings specifying NuGet packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Password description: The password or personal access token used to authenticate against the feed.
+	Password string `json:"password,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+	RateLimit *NuGetRateLimit `json:"rateLimit,omitempty"`
+	// Registry description: The URL of the NuGet V3 service index of the feed.
+	Registry string `json:"registry,omitempty"`
+	// Username description: The username used to authenticate against the feed. Feeds that authenticate with a personal access token usually accept any non-empty username.
+	Username string `json:"username,omitempty"`
+}
+
+// NuGetRateLimit description: Rate limit applied when making background API requests to the NuGet feed.
+type NuGetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }
@@ -2039,6 +2281,12 @@ type ObservabilityTracing struct {
 	UrlTemplate string `json:"urlTemplate,omitempty"`
 }
 
+// OnInsightSeries description: A Code Insights data series whose most recent recording selects the repositories that the batch change will be run on. The series query is not run again.
+type OnInsightSeries struct {
+	// InsightSeries description: The ID of the data series. Every repository with a non-zero value in the latest recording of the series is added to the list of repositories that the batch change will be run on, on its default branch.
+	InsightSeries string `json:"insightSeries"`
+}
+
 // OnQuery description: A Sourcegraph search query that matches a set of repositories (and branches). Each matched repository branch is added to the list of repositories that the batch change will be run on.
 type OnQuery struct {
 	// RepositoriesMatchingQuery description: A Sourcegraph search query that matches a set of repositories (and branches). If the query matches files, symbols, or some other object inside a repository, the object's repository is included.
@@ -2054,6 +2302,12 @@ type OnRepository struct {
 	// Repository description: The name of the repository (as it is known to Sourcegraph).
 	Repository string `json:"repository"`
 }
+
+// OnSearchJob description: A completed search job whose stored results select the repositories (and revisions) that the batch change will be run on. The search is not run again.
+type OnSearchJob struct {
+	// ExhaustiveSearchJob description: The ID of the search job. Every repository revision in which the search job found results is added to the list of repositories that the batch change will be run on.
+	ExhaustiveSearchJob int `json:"exhaustiveSearchJob"`
+}
 type OnboardingStep struct {
 	Action              any      `json:"action"`
 	CompleteAfterEvents []string `json:"completeAfterEvents,omitempty"`
@@ -2199,6 +2453,28 @@ type OutputVariable struct {
 	Value string `json:"value"`
 }
 
+// PackagistPackagesConnection description: Configuration for a connection to a Packagist-compatible repository of PHP Composer packages.
+type PackagistPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Composer packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Password description: The password or token used to authenticate against the repository. Credentials are only sent to the host of the repository, never to the hosts that distribution archives are downloaded from.
+	Password string `json:"password,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the Composer repository.
+	RateLimit *PackagistRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the Composer repository. The repository must serve package metadata in the Composer v2 format at <repository>/p2/<vendor>/<package>.json.
+	Repository string `json:"repository,omitempty"`
+	// Username description: The username used to authenticate against the repository.
+	Username string `json:"username,omitempty"`
+}
+
+// PackagistRateLimit description: Rate limit applied when making background API requests to the Composer repository.
+type PackagistRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // PagureConnection description: Configuration for a connection to Pagure.
 type PagureConnection struct {
 	// Forks description: If true, it includes forks in the returned projects.
@@ -3145,6 +3421,8 @@ type SiteConfiguration struct {
 	AuthProviders []AuthProviders `json:"auth.providers,omitempty"`
 	// AuthPublic description: WARNING: This option has been removed as of 3.8.
 	AuthPublic bool `json:"auth.public,omitempty"`
+	// AuthSecondFactor description: Second factor authentication for builtin username/password accounts. Users can enroll an authenticator app (TOTP) and security keys (WebAuthn) in their account settings.
+	AuthSecondFactor *AuthSecondFactor `json:"auth.secondFactor,omitempty"`
 	// AuthSessionExpiry description: The duration of a user session, after which it expires and the user is required to re-authenticate. The default is 90 days. There is typically no need to set this, but some users may have specific internal security requirements.
 	//
 	// The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). E.g., "720h", "43200m", "2592000s" all indicate a timespan of 30 days.
@@ -3455,6 +3733,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "auth.primaryLoginProvidersCount")
 	delete(m, "auth.providers")
 	delete(m, "auth.public")
+	delete(m, "auth.secondFactor")
 	delete(m, "auth.sessionExpiry")
 	delete(m, "auth.unlockAccountLinkExpiry")
 	delete(m, "auth.unlockAccountLinkSigningKey")
@@ -3663,6 +3942,38 @@ type SubRepoPermissions struct {
 	UserCacheTTLSeconds int `json:"userCacheTTLSeconds,omitempty"`
 }
 
+// SubversionConnection description: Configuration for a connection to Subversion repositories. Each repository is converted into a Git mirror on gitserver. Trunk and branches become Git branches, tags become Git tags.
+type SubversionConnection struct {
+	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
+	Exclude []*ExcludedSubversionRepo `json:"exclude,omitempty"`
+	// Layout description: The directories of the repositories that contain trunk, branches and tags. Trunk is mirrored as the Git branch "trunk", every directory below the branches directory as a Git branch and every directory below the tags directory as a Git tag.
+	Layout *SubversionLayout `json:"layout,omitempty"`
+	// Password description: The password used to authenticate to the Subversion server.
+	Password string `json:"password,omitempty"`
+	// Repos description: Each path must point at the root of a repository, i.e. the directory that contains the trunk, branches and tags directories.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Subversion server base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if your Subversion server base URL is https://svn.example.com/repos and `repos` contains the value "my/project", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://svn.example.com/repos/my/project is available on Sourcegraph at https://sourcegraph.example.com/svn.example.com/repos/my/project.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL that the paths in `repos` are resolved against.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Subversion server.
+	Username string `json:"username,omitempty"`
+}
+
+// SubversionLayout description: The directories of the repositories that contain trunk, branches and tags. Trunk is mirrored as the Git branch "trunk", every directory below the branches directory as a Git branch and every directory below the tags directory as a Git tag.
+type SubversionLayout struct {
+	// Branches description: The path of the directory containing branches relative to the repository root.
+	Branches string `json:"branches,omitempty"`
+	// Tags description: The path of the directory containing tags relative to the repository root.
+	Tags string `json:"tags,omitempty"`
+	// Trunk description: The path of the trunk directory relative to the repository root.
+	Trunk string `json:"trunk,omitempty"`
+}
+
 // SymbolConfiguration description: Configure symbol generation
 type SymbolConfiguration struct {
 	// Engine description: Manually specify overrides for symbol generation engine per language
@@ -3747,6 +4058,18 @@ type Webhooks struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+// Window description: Restricts merges to a time of day. If the end is before the start, the window spans midnight.
+type Window struct {
+	// Days description: The days of the week on which changesets are merged. All days if empty.
+	Days []string `json:"days,omitempty"`
+	// End description: The end of the window, in 24-hour HH:MM format.
+	End string `json:"end"`
+	// Start description: The start of the window, in 24-hour HH:MM format.
+	Start string `json:"start"`
+	// Timezone description: The IANA timezone of the window, such as America/New_York. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
 // WorkspaceConfiguration description: Configuration for how to setup workspaces in repositories
 type WorkspaceConfiguration struct {
 	// In description: The repositories in which to apply the workspace configuration. Supports globbing.
@@ -37,6 +37,11 @@ var ChangesetSpecSchemaJSON string
 //go:embed gerrit.schema.json
 var GerritSchemaJSON string
 
+// GiteaSchemaJSON is the content of the file "gitea.schema.json".
+//
+//go:embed gitea.schema.json
+var GiteaSchemaJSON string
+
 // GitHubSchemaJSON is the content of the file "github.schema.json".
 //
 //go:embed github.schema.json
@@ -55,12 +60,18 @@ var GitoliteSchemaJSON string
 //go:embed go-modules.schema.json
 var GoModulesSchemaJSON string
 
+//go:embed hex-packages.schema.json
+var HexPackagesSchemaJSON string
+
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string
 
+//go:embed nuget-packages.schema.json
+var NuGetPackagesSchemaJSON string
+
 //go:embed python-packages.schema.json
 var PythonPackagesSchemaJSON string
 
@@ -70,6 +81,16 @@ var RustPackagesSchemaJSON string
 //go:embed ruby-packages.schema.json
 var RubyPackagesSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
+// SubversionSchemaJSON is the content of the file "subversion.schema.json".
+//
+//go:embed subversion.schema.json
+var SubversionSchemaJSON string
+
 // OtherExternalServiceSchemaJSON is the content of the file "other_external_service.schema.json".
 //
 //go:embed other_external_service.schema.json
@@ -87,6 +108,9 @@ var PhabricatorSchemaJSON string
 
 // PagureSchemaJSON is the content of the file "pagure.schema.json".
 //
+//go:embed packagist-packages.schema.json
+var PackagistPackagesSchemaJSON string
+
 //go:embed pagure.schema.json
 var PagureSchemaJSON string
 